@@ -2914,3 +2914,299 @@ func (this *ListQueuesResponse) Equal(that interface{}) bool {
 
 	return proto.Equal(this, that1)
 }
+
+// Marshal an object of type DynamicConfigConstraints to the protobuf v3 wire format
+func (val *DynamicConfigConstraints) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type DynamicConfigConstraints from the protobuf v3 wire format
+func (val *DynamicConfigConstraints) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *DynamicConfigConstraints) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two DynamicConfigConstraints values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *DynamicConfigConstraints) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *DynamicConfigConstraints
+	switch t := that.(type) {
+	case *DynamicConfigConstraints:
+		that1 = t
+	case DynamicConfigConstraints:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type DynamicConfigValue to the protobuf v3 wire format
+func (val *DynamicConfigValue) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type DynamicConfigValue from the protobuf v3 wire format
+func (val *DynamicConfigValue) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *DynamicConfigValue) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two DynamicConfigValue values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *DynamicConfigValue) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *DynamicConfigValue
+	switch t := that.(type) {
+	case *DynamicConfigValue:
+		that1 = t
+	case DynamicConfigValue:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type GetDynamicConfigRequest to the protobuf v3 wire format
+func (val *GetDynamicConfigRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type GetDynamicConfigRequest from the protobuf v3 wire format
+func (val *GetDynamicConfigRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *GetDynamicConfigRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two GetDynamicConfigRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *GetDynamicConfigRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *GetDynamicConfigRequest
+	switch t := that.(type) {
+	case *GetDynamicConfigRequest:
+		that1 = t
+	case GetDynamicConfigRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type GetDynamicConfigResponse to the protobuf v3 wire format
+func (val *GetDynamicConfigResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type GetDynamicConfigResponse from the protobuf v3 wire format
+func (val *GetDynamicConfigResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *GetDynamicConfigResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two GetDynamicConfigResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *GetDynamicConfigResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *GetDynamicConfigResponse
+	switch t := that.(type) {
+	case *GetDynamicConfigResponse:
+		that1 = t
+	case GetDynamicConfigResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type WatchWorkflowExecutionsRequest to the protobuf v3 wire format
+func (val *WatchWorkflowExecutionsRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type WatchWorkflowExecutionsRequest from the protobuf v3 wire format
+func (val *WatchWorkflowExecutionsRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *WatchWorkflowExecutionsRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two WatchWorkflowExecutionsRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *WatchWorkflowExecutionsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *WatchWorkflowExecutionsRequest
+	switch t := that.(type) {
+	case *WatchWorkflowExecutionsRequest:
+		that1 = t
+	case WatchWorkflowExecutionsRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type WatchWorkflowExecutionsResponse to the protobuf v3 wire format
+func (val *WatchWorkflowExecutionsResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type WatchWorkflowExecutionsResponse from the protobuf v3 wire format
+func (val *WatchWorkflowExecutionsResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *WatchWorkflowExecutionsResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two WatchWorkflowExecutionsResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *WatchWorkflowExecutionsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *WatchWorkflowExecutionsResponse
+	switch t := that.(type) {
+	case *WatchWorkflowExecutionsResponse:
+		that1 = t
+	case WatchWorkflowExecutionsResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type UpdateNamespaceVisibilityRetentionRequest to the protobuf v3 wire format
+func (val *UpdateNamespaceVisibilityRetentionRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type UpdateNamespaceVisibilityRetentionRequest from the protobuf v3 wire format
+func (val *UpdateNamespaceVisibilityRetentionRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *UpdateNamespaceVisibilityRetentionRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two UpdateNamespaceVisibilityRetentionRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *UpdateNamespaceVisibilityRetentionRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *UpdateNamespaceVisibilityRetentionRequest
+	switch t := that.(type) {
+	case *UpdateNamespaceVisibilityRetentionRequest:
+		that1 = t
+	case UpdateNamespaceVisibilityRetentionRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type UpdateNamespaceVisibilityRetentionResponse to the protobuf v3 wire format
+func (val *UpdateNamespaceVisibilityRetentionResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type UpdateNamespaceVisibilityRetentionResponse from the protobuf v3 wire format
+func (val *UpdateNamespaceVisibilityRetentionResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *UpdateNamespaceVisibilityRetentionResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two UpdateNamespaceVisibilityRetentionResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *UpdateNamespaceVisibilityRetentionResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *UpdateNamespaceVisibilityRetentionResponse
+	switch t := that.(type) {
+	case *UpdateNamespaceVisibilityRetentionResponse:
+		that1 = t
+	case UpdateNamespaceVisibilityRetentionResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
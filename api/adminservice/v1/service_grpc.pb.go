@@ -42,44 +42,47 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	AdminService_RebuildMutableState_FullMethodName               = "/temporal.server.api.adminservice.v1.AdminService/RebuildMutableState"
-	AdminService_ImportWorkflowExecution_FullMethodName           = "/temporal.server.api.adminservice.v1.AdminService/ImportWorkflowExecution"
-	AdminService_DescribeMutableState_FullMethodName              = "/temporal.server.api.adminservice.v1.AdminService/DescribeMutableState"
-	AdminService_DescribeHistoryHost_FullMethodName               = "/temporal.server.api.adminservice.v1.AdminService/DescribeHistoryHost"
-	AdminService_GetShard_FullMethodName                          = "/temporal.server.api.adminservice.v1.AdminService/GetShard"
-	AdminService_CloseShard_FullMethodName                        = "/temporal.server.api.adminservice.v1.AdminService/CloseShard"
-	AdminService_ListHistoryTasks_FullMethodName                  = "/temporal.server.api.adminservice.v1.AdminService/ListHistoryTasks"
-	AdminService_RemoveTask_FullMethodName                        = "/temporal.server.api.adminservice.v1.AdminService/RemoveTask"
-	AdminService_GetWorkflowExecutionRawHistoryV2_FullMethodName  = "/temporal.server.api.adminservice.v1.AdminService/GetWorkflowExecutionRawHistoryV2"
-	AdminService_GetWorkflowExecutionRawHistory_FullMethodName    = "/temporal.server.api.adminservice.v1.AdminService/GetWorkflowExecutionRawHistory"
-	AdminService_GetReplicationMessages_FullMethodName            = "/temporal.server.api.adminservice.v1.AdminService/GetReplicationMessages"
-	AdminService_GetNamespaceReplicationMessages_FullMethodName   = "/temporal.server.api.adminservice.v1.AdminService/GetNamespaceReplicationMessages"
-	AdminService_GetDLQReplicationMessages_FullMethodName         = "/temporal.server.api.adminservice.v1.AdminService/GetDLQReplicationMessages"
-	AdminService_ReapplyEvents_FullMethodName                     = "/temporal.server.api.adminservice.v1.AdminService/ReapplyEvents"
-	AdminService_AddSearchAttributes_FullMethodName               = "/temporal.server.api.adminservice.v1.AdminService/AddSearchAttributes"
-	AdminService_RemoveSearchAttributes_FullMethodName            = "/temporal.server.api.adminservice.v1.AdminService/RemoveSearchAttributes"
-	AdminService_GetSearchAttributes_FullMethodName               = "/temporal.server.api.adminservice.v1.AdminService/GetSearchAttributes"
-	AdminService_DescribeCluster_FullMethodName                   = "/temporal.server.api.adminservice.v1.AdminService/DescribeCluster"
-	AdminService_ListClusters_FullMethodName                      = "/temporal.server.api.adminservice.v1.AdminService/ListClusters"
-	AdminService_ListClusterMembers_FullMethodName                = "/temporal.server.api.adminservice.v1.AdminService/ListClusterMembers"
-	AdminService_AddOrUpdateRemoteCluster_FullMethodName          = "/temporal.server.api.adminservice.v1.AdminService/AddOrUpdateRemoteCluster"
-	AdminService_RemoveRemoteCluster_FullMethodName               = "/temporal.server.api.adminservice.v1.AdminService/RemoveRemoteCluster"
-	AdminService_GetDLQMessages_FullMethodName                    = "/temporal.server.api.adminservice.v1.AdminService/GetDLQMessages"
-	AdminService_PurgeDLQMessages_FullMethodName                  = "/temporal.server.api.adminservice.v1.AdminService/PurgeDLQMessages"
-	AdminService_MergeDLQMessages_FullMethodName                  = "/temporal.server.api.adminservice.v1.AdminService/MergeDLQMessages"
-	AdminService_RefreshWorkflowTasks_FullMethodName              = "/temporal.server.api.adminservice.v1.AdminService/RefreshWorkflowTasks"
-	AdminService_ResendReplicationTasks_FullMethodName            = "/temporal.server.api.adminservice.v1.AdminService/ResendReplicationTasks"
-	AdminService_GetTaskQueueTasks_FullMethodName                 = "/temporal.server.api.adminservice.v1.AdminService/GetTaskQueueTasks"
-	AdminService_DeleteWorkflowExecution_FullMethodName           = "/temporal.server.api.adminservice.v1.AdminService/DeleteWorkflowExecution"
-	AdminService_StreamWorkflowReplicationMessages_FullMethodName = "/temporal.server.api.adminservice.v1.AdminService/StreamWorkflowReplicationMessages"
-	AdminService_GetNamespace_FullMethodName                      = "/temporal.server.api.adminservice.v1.AdminService/GetNamespace"
-	AdminService_GetDLQTasks_FullMethodName                       = "/temporal.server.api.adminservice.v1.AdminService/GetDLQTasks"
-	AdminService_PurgeDLQTasks_FullMethodName                     = "/temporal.server.api.adminservice.v1.AdminService/PurgeDLQTasks"
-	AdminService_MergeDLQTasks_FullMethodName                     = "/temporal.server.api.adminservice.v1.AdminService/MergeDLQTasks"
-	AdminService_DescribeDLQJob_FullMethodName                    = "/temporal.server.api.adminservice.v1.AdminService/DescribeDLQJob"
-	AdminService_CancelDLQJob_FullMethodName                      = "/temporal.server.api.adminservice.v1.AdminService/CancelDLQJob"
-	AdminService_AddTasks_FullMethodName                          = "/temporal.server.api.adminservice.v1.AdminService/AddTasks"
-	AdminService_ListQueues_FullMethodName                        = "/temporal.server.api.adminservice.v1.AdminService/ListQueues"
+	AdminService_RebuildMutableState_FullMethodName                = "/temporal.server.api.adminservice.v1.AdminService/RebuildMutableState"
+	AdminService_ImportWorkflowExecution_FullMethodName            = "/temporal.server.api.adminservice.v1.AdminService/ImportWorkflowExecution"
+	AdminService_DescribeMutableState_FullMethodName               = "/temporal.server.api.adminservice.v1.AdminService/DescribeMutableState"
+	AdminService_DescribeHistoryHost_FullMethodName                = "/temporal.server.api.adminservice.v1.AdminService/DescribeHistoryHost"
+	AdminService_GetShard_FullMethodName                           = "/temporal.server.api.adminservice.v1.AdminService/GetShard"
+	AdminService_CloseShard_FullMethodName                         = "/temporal.server.api.adminservice.v1.AdminService/CloseShard"
+	AdminService_ListHistoryTasks_FullMethodName                   = "/temporal.server.api.adminservice.v1.AdminService/ListHistoryTasks"
+	AdminService_RemoveTask_FullMethodName                         = "/temporal.server.api.adminservice.v1.AdminService/RemoveTask"
+	AdminService_GetWorkflowExecutionRawHistoryV2_FullMethodName   = "/temporal.server.api.adminservice.v1.AdminService/GetWorkflowExecutionRawHistoryV2"
+	AdminService_GetWorkflowExecutionRawHistory_FullMethodName     = "/temporal.server.api.adminservice.v1.AdminService/GetWorkflowExecutionRawHistory"
+	AdminService_GetReplicationMessages_FullMethodName             = "/temporal.server.api.adminservice.v1.AdminService/GetReplicationMessages"
+	AdminService_GetNamespaceReplicationMessages_FullMethodName    = "/temporal.server.api.adminservice.v1.AdminService/GetNamespaceReplicationMessages"
+	AdminService_GetDLQReplicationMessages_FullMethodName          = "/temporal.server.api.adminservice.v1.AdminService/GetDLQReplicationMessages"
+	AdminService_ReapplyEvents_FullMethodName                      = "/temporal.server.api.adminservice.v1.AdminService/ReapplyEvents"
+	AdminService_AddSearchAttributes_FullMethodName                = "/temporal.server.api.adminservice.v1.AdminService/AddSearchAttributes"
+	AdminService_RemoveSearchAttributes_FullMethodName             = "/temporal.server.api.adminservice.v1.AdminService/RemoveSearchAttributes"
+	AdminService_GetSearchAttributes_FullMethodName                = "/temporal.server.api.adminservice.v1.AdminService/GetSearchAttributes"
+	AdminService_DescribeCluster_FullMethodName                    = "/temporal.server.api.adminservice.v1.AdminService/DescribeCluster"
+	AdminService_ListClusters_FullMethodName                       = "/temporal.server.api.adminservice.v1.AdminService/ListClusters"
+	AdminService_ListClusterMembers_FullMethodName                 = "/temporal.server.api.adminservice.v1.AdminService/ListClusterMembers"
+	AdminService_AddOrUpdateRemoteCluster_FullMethodName           = "/temporal.server.api.adminservice.v1.AdminService/AddOrUpdateRemoteCluster"
+	AdminService_RemoveRemoteCluster_FullMethodName                = "/temporal.server.api.adminservice.v1.AdminService/RemoveRemoteCluster"
+	AdminService_GetDLQMessages_FullMethodName                     = "/temporal.server.api.adminservice.v1.AdminService/GetDLQMessages"
+	AdminService_PurgeDLQMessages_FullMethodName                   = "/temporal.server.api.adminservice.v1.AdminService/PurgeDLQMessages"
+	AdminService_MergeDLQMessages_FullMethodName                   = "/temporal.server.api.adminservice.v1.AdminService/MergeDLQMessages"
+	AdminService_RefreshWorkflowTasks_FullMethodName               = "/temporal.server.api.adminservice.v1.AdminService/RefreshWorkflowTasks"
+	AdminService_ResendReplicationTasks_FullMethodName             = "/temporal.server.api.adminservice.v1.AdminService/ResendReplicationTasks"
+	AdminService_GetTaskQueueTasks_FullMethodName                  = "/temporal.server.api.adminservice.v1.AdminService/GetTaskQueueTasks"
+	AdminService_DeleteWorkflowExecution_FullMethodName            = "/temporal.server.api.adminservice.v1.AdminService/DeleteWorkflowExecution"
+	AdminService_StreamWorkflowReplicationMessages_FullMethodName  = "/temporal.server.api.adminservice.v1.AdminService/StreamWorkflowReplicationMessages"
+	AdminService_GetNamespace_FullMethodName                       = "/temporal.server.api.adminservice.v1.AdminService/GetNamespace"
+	AdminService_GetDLQTasks_FullMethodName                        = "/temporal.server.api.adminservice.v1.AdminService/GetDLQTasks"
+	AdminService_PurgeDLQTasks_FullMethodName                      = "/temporal.server.api.adminservice.v1.AdminService/PurgeDLQTasks"
+	AdminService_MergeDLQTasks_FullMethodName                      = "/temporal.server.api.adminservice.v1.AdminService/MergeDLQTasks"
+	AdminService_DescribeDLQJob_FullMethodName                     = "/temporal.server.api.adminservice.v1.AdminService/DescribeDLQJob"
+	AdminService_CancelDLQJob_FullMethodName                       = "/temporal.server.api.adminservice.v1.AdminService/CancelDLQJob"
+	AdminService_AddTasks_FullMethodName                           = "/temporal.server.api.adminservice.v1.AdminService/AddTasks"
+	AdminService_ListQueues_FullMethodName                         = "/temporal.server.api.adminservice.v1.AdminService/ListQueues"
+	AdminService_GetDynamicConfig_FullMethodName                   = "/temporal.server.api.adminservice.v1.AdminService/GetDynamicConfig"
+	AdminService_WatchWorkflowExecutions_FullMethodName            = "/temporal.server.api.adminservice.v1.AdminService/WatchWorkflowExecutions"
+	AdminService_UpdateNamespaceVisibilityRetention_FullMethodName = "/temporal.server.api.adminservice.v1.AdminService/UpdateNamespaceVisibilityRetention"
 )
 
 // AdminServiceClient is the client API for AdminService service.
@@ -165,6 +168,20 @@ type AdminServiceClient interface {
 	CancelDLQJob(ctx context.Context, in *CancelDLQJobRequest, opts ...grpc.CallOption) (*CancelDLQJobResponse, error)
 	AddTasks(ctx context.Context, in *AddTasksRequest, opts ...grpc.CallOption) (*AddTasksResponse, error)
 	ListQueues(ctx context.Context, in *ListQueuesRequest, opts ...grpc.CallOption) (*ListQueuesResponse, error)
+	// GetDynamicConfig returns the dynamic config keys registered on the frontend host serving the
+	// request, the values configured for them, and the values in effect for the given constraints.
+	GetDynamicConfig(ctx context.Context, in *GetDynamicConfigRequest, opts ...grpc.CallOption) (*GetDynamicConfigResponse, error)
+	// WatchWorkflowExecutions streams the changes of the workflow executions of a namespace which match
+	// a visibility query, as soon as they are applied to the visibility store. The stream can be resumed
+	// with the resume token of the last received change, which may stream recent changes again. If the
+	// changes after the token are no longer buffered by the history service, a FailedPrecondition error
+	// is returned and executions need to be listed again.
+	WatchWorkflowExecutions(ctx context.Context, in *WatchWorkflowExecutionsRequest, opts ...grpc.CallOption) (AdminService_WatchWorkflowExecutionsClient, error)
+	// UpdateNamespaceVisibilityRetention sets how long visibility records of closed workflows of a namespace
+	// are kept, independently of the history retention of the namespace. The visibility retention is local
+	// to the cluster: it is not replicated to other clusters, doesn't bump the namespace config version, and
+	// is kept when namespace replication tasks from other clusters are applied.
+	UpdateNamespaceVisibilityRetention(ctx context.Context, in *UpdateNamespaceVisibilityRetentionRequest, opts ...grpc.CallOption) (*UpdateNamespaceVisibilityRetentionResponse, error)
 }
 
 type adminServiceClient struct {
@@ -539,6 +556,56 @@ func (c *adminServiceClient) ListQueues(ctx context.Context, in *ListQueuesReque
 	return out, nil
 }
 
+func (c *adminServiceClient) GetDynamicConfig(ctx context.Context, in *GetDynamicConfigRequest, opts ...grpc.CallOption) (*GetDynamicConfigResponse, error) {
+	out := new(GetDynamicConfigResponse)
+	err := c.cc.Invoke(ctx, AdminService_GetDynamicConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) WatchWorkflowExecutions(ctx context.Context, in *WatchWorkflowExecutionsRequest, opts ...grpc.CallOption) (AdminService_WatchWorkflowExecutionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AdminService_ServiceDesc.Streams[1], AdminService_WatchWorkflowExecutions_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceWatchWorkflowExecutionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_WatchWorkflowExecutionsClient interface {
+	Recv() (*WatchWorkflowExecutionsResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceWatchWorkflowExecutionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceWatchWorkflowExecutionsClient) Recv() (*WatchWorkflowExecutionsResponse, error) {
+	m := new(WatchWorkflowExecutionsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) UpdateNamespaceVisibilityRetention(ctx context.Context, in *UpdateNamespaceVisibilityRetentionRequest, opts ...grpc.CallOption) (*UpdateNamespaceVisibilityRetentionResponse, error) {
+	out := new(UpdateNamespaceVisibilityRetentionResponse)
+	err := c.cc.Invoke(ctx, AdminService_UpdateNamespaceVisibilityRetention_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations must embed UnimplementedAdminServiceServer
 // for forward compatibility
@@ -622,6 +689,20 @@ type AdminServiceServer interface {
 	CancelDLQJob(context.Context, *CancelDLQJobRequest) (*CancelDLQJobResponse, error)
 	AddTasks(context.Context, *AddTasksRequest) (*AddTasksResponse, error)
 	ListQueues(context.Context, *ListQueuesRequest) (*ListQueuesResponse, error)
+	// GetDynamicConfig returns the dynamic config keys registered on the frontend host serving the
+	// request, the values configured for them, and the values in effect for the given constraints.
+	GetDynamicConfig(context.Context, *GetDynamicConfigRequest) (*GetDynamicConfigResponse, error)
+	// WatchWorkflowExecutions streams the changes of the workflow executions of a namespace which match
+	// a visibility query, as soon as they are applied to the visibility store. The stream can be resumed
+	// with the resume token of the last received change, which may stream recent changes again. If the
+	// changes after the token are no longer buffered by the history service, a FailedPrecondition error
+	// is returned and executions need to be listed again.
+	WatchWorkflowExecutions(*WatchWorkflowExecutionsRequest, AdminService_WatchWorkflowExecutionsServer) error
+	// UpdateNamespaceVisibilityRetention sets how long visibility records of closed workflows of a namespace
+	// are kept, independently of the history retention of the namespace. The visibility retention is local
+	// to the cluster: it is not replicated to other clusters, doesn't bump the namespace config version, and
+	// is kept when namespace replication tasks from other clusters are applied.
+	UpdateNamespaceVisibilityRetention(context.Context, *UpdateNamespaceVisibilityRetentionRequest) (*UpdateNamespaceVisibilityRetentionResponse, error)
 	mustEmbedUnimplementedAdminServiceServer()
 }
 
@@ -743,6 +824,15 @@ func (UnimplementedAdminServiceServer) AddTasks(context.Context, *AddTasksReques
 func (UnimplementedAdminServiceServer) ListQueues(context.Context, *ListQueuesRequest) (*ListQueuesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListQueues not implemented")
 }
+func (UnimplementedAdminServiceServer) GetDynamicConfig(context.Context, *GetDynamicConfigRequest) (*GetDynamicConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDynamicConfig not implemented")
+}
+func (UnimplementedAdminServiceServer) WatchWorkflowExecutions(*WatchWorkflowExecutionsRequest, AdminService_WatchWorkflowExecutionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchWorkflowExecutions not implemented")
+}
+func (UnimplementedAdminServiceServer) UpdateNamespaceVisibilityRetention(context.Context, *UpdateNamespaceVisibilityRetentionRequest) (*UpdateNamespaceVisibilityRetentionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNamespaceVisibilityRetention not implemented")
+}
 func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
 
 // UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -1448,6 +1538,63 @@ func _AdminService_ListQueues_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_GetDynamicConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDynamicConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetDynamicConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_GetDynamicConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetDynamicConfig(ctx, req.(*GetDynamicConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_WatchWorkflowExecutions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchWorkflowExecutionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).WatchWorkflowExecutions(m, &adminServiceWatchWorkflowExecutionsServer{stream})
+}
+
+type AdminService_WatchWorkflowExecutionsServer interface {
+	Send(*WatchWorkflowExecutionsResponse) error
+	grpc.ServerStream
+}
+
+type adminServiceWatchWorkflowExecutionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceWatchWorkflowExecutionsServer) Send(m *WatchWorkflowExecutionsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_UpdateNamespaceVisibilityRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNamespaceVisibilityRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UpdateNamespaceVisibilityRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_UpdateNamespaceVisibilityRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UpdateNamespaceVisibilityRetention(ctx, req.(*UpdateNamespaceVisibilityRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1603,6 +1750,14 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListQueues",
 			Handler:    _AdminService_ListQueues_Handler,
 		},
+		{
+			MethodName: "GetDynamicConfig",
+			Handler:    _AdminService_GetDynamicConfig_Handler,
+		},
+		{
+			MethodName: "UpdateNamespaceVisibilityRetention",
+			Handler:    _AdminService_UpdateNamespaceVisibilityRetention_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1611,6 +1766,11 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchWorkflowExecutions",
+			Handler:       _AdminService_WatchWorkflowExecutions_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "temporal/server/api/adminservice/v1/service.proto",
 }
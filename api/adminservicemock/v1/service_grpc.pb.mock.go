@@ -321,6 +321,26 @@ func (mr *MockAdminServiceClientMockRecorder) GetDLQTasks(ctx, in interface{}, o
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDLQTasks", reflect.TypeOf((*MockAdminServiceClient)(nil).GetDLQTasks), varargs...)
 }
 
+// GetDynamicConfig mocks base method.
+func (m *MockAdminServiceClient) GetDynamicConfig(ctx context.Context, in *adminservice.GetDynamicConfigRequest, opts ...grpc.CallOption) (*adminservice.GetDynamicConfigResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDynamicConfig", varargs...)
+	ret0, _ := ret[0].(*adminservice.GetDynamicConfigResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDynamicConfig indicates an expected call of GetDynamicConfig.
+func (mr *MockAdminServiceClientMockRecorder) GetDynamicConfig(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDynamicConfig", reflect.TypeOf((*MockAdminServiceClient)(nil).GetDynamicConfig), varargs...)
+}
+
 // GetNamespace mocks base method.
 func (m *MockAdminServiceClient) GetNamespace(ctx context.Context, in *adminservice.GetNamespaceRequest, opts ...grpc.CallOption) (*adminservice.GetNamespaceResponse, error) {
 	m.ctrl.T.Helper()
@@ -821,6 +841,46 @@ func (mr *MockAdminServiceClientMockRecorder) StreamWorkflowReplicationMessages(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamWorkflowReplicationMessages", reflect.TypeOf((*MockAdminServiceClient)(nil).StreamWorkflowReplicationMessages), varargs...)
 }
 
+// UpdateNamespaceVisibilityRetention mocks base method.
+func (m *MockAdminServiceClient) UpdateNamespaceVisibilityRetention(ctx context.Context, in *adminservice.UpdateNamespaceVisibilityRetentionRequest, opts ...grpc.CallOption) (*adminservice.UpdateNamespaceVisibilityRetentionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateNamespaceVisibilityRetention", varargs...)
+	ret0, _ := ret[0].(*adminservice.UpdateNamespaceVisibilityRetentionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNamespaceVisibilityRetention indicates an expected call of UpdateNamespaceVisibilityRetention.
+func (mr *MockAdminServiceClientMockRecorder) UpdateNamespaceVisibilityRetention(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespaceVisibilityRetention", reflect.TypeOf((*MockAdminServiceClient)(nil).UpdateNamespaceVisibilityRetention), varargs...)
+}
+
+// WatchWorkflowExecutions mocks base method.
+func (m *MockAdminServiceClient) WatchWorkflowExecutions(ctx context.Context, in *adminservice.WatchWorkflowExecutionsRequest, opts ...grpc.CallOption) (adminservice.AdminService_WatchWorkflowExecutionsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchWorkflowExecutions", varargs...)
+	ret0, _ := ret[0].(adminservice.AdminService_WatchWorkflowExecutionsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchWorkflowExecutions indicates an expected call of WatchWorkflowExecutions.
+func (mr *MockAdminServiceClientMockRecorder) WatchWorkflowExecutions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchWorkflowExecutions", reflect.TypeOf((*MockAdminServiceClient)(nil).WatchWorkflowExecutions), varargs...)
+}
+
 // MockAdminService_StreamWorkflowReplicationMessagesClient is a mock of AdminService_StreamWorkflowReplicationMessagesClient interface.
 type MockAdminService_StreamWorkflowReplicationMessagesClient struct {
 	ctrl     *gomock.Controller
@@ -958,6 +1018,129 @@ func (mr *MockAdminService_StreamWorkflowReplicationMessagesClientMockRecorder)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAdminService_StreamWorkflowReplicationMessagesClient)(nil).Trailer))
 }
 
+// MockAdminService_WatchWorkflowExecutionsClient is a mock of AdminService_WatchWorkflowExecutionsClient interface.
+type MockAdminService_WatchWorkflowExecutionsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminService_WatchWorkflowExecutionsClientMockRecorder
+}
+
+// MockAdminService_WatchWorkflowExecutionsClientMockRecorder is the mock recorder for MockAdminService_WatchWorkflowExecutionsClient.
+type MockAdminService_WatchWorkflowExecutionsClientMockRecorder struct {
+	mock *MockAdminService_WatchWorkflowExecutionsClient
+}
+
+// NewMockAdminService_WatchWorkflowExecutionsClient creates a new mock instance.
+func NewMockAdminService_WatchWorkflowExecutionsClient(ctrl *gomock.Controller) *MockAdminService_WatchWorkflowExecutionsClient {
+	mock := &MockAdminService_WatchWorkflowExecutionsClient{ctrl: ctrl}
+	mock.recorder = &MockAdminService_WatchWorkflowExecutionsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminService_WatchWorkflowExecutionsClient) EXPECT() *MockAdminService_WatchWorkflowExecutionsClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAdminService_WatchWorkflowExecutionsClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAdminService_WatchWorkflowExecutionsClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAdminService_WatchWorkflowExecutionsClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsClient) Recv() (*adminservice.WatchWorkflowExecutionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*adminservice.WatchWorkflowExecutionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAdminService_WatchWorkflowExecutionsClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAdminService_WatchWorkflowExecutionsClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAdminService_WatchWorkflowExecutionsClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAdminService_WatchWorkflowExecutionsClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAdminService_WatchWorkflowExecutionsClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAdminService_WatchWorkflowExecutionsClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsClient)(nil).Trailer))
+}
+
 // MockAdminServiceServer is a mock of AdminServiceServer interface.
 type MockAdminServiceServer struct {
 	ctrl     *gomock.Controller
@@ -1176,6 +1359,21 @@ func (mr *MockAdminServiceServerMockRecorder) GetDLQTasks(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDLQTasks", reflect.TypeOf((*MockAdminServiceServer)(nil).GetDLQTasks), arg0, arg1)
 }
 
+// GetDynamicConfig mocks base method.
+func (m *MockAdminServiceServer) GetDynamicConfig(arg0 context.Context, arg1 *adminservice.GetDynamicConfigRequest) (*adminservice.GetDynamicConfigResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDynamicConfig", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.GetDynamicConfigResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDynamicConfig indicates an expected call of GetDynamicConfig.
+func (mr *MockAdminServiceServerMockRecorder) GetDynamicConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDynamicConfig", reflect.TypeOf((*MockAdminServiceServer)(nil).GetDynamicConfig), arg0, arg1)
+}
+
 // GetNamespace mocks base method.
 func (m *MockAdminServiceServer) GetNamespace(arg0 context.Context, arg1 *adminservice.GetNamespaceRequest) (*adminservice.GetNamespaceResponse, error) {
 	m.ctrl.T.Helper()
@@ -1550,6 +1748,35 @@ func (mr *MockAdminServiceServerMockRecorder) StreamWorkflowReplicationMessages(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamWorkflowReplicationMessages", reflect.TypeOf((*MockAdminServiceServer)(nil).StreamWorkflowReplicationMessages), arg0)
 }
 
+// UpdateNamespaceVisibilityRetention mocks base method.
+func (m *MockAdminServiceServer) UpdateNamespaceVisibilityRetention(arg0 context.Context, arg1 *adminservice.UpdateNamespaceVisibilityRetentionRequest) (*adminservice.UpdateNamespaceVisibilityRetentionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNamespaceVisibilityRetention", arg0, arg1)
+	ret0, _ := ret[0].(*adminservice.UpdateNamespaceVisibilityRetentionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNamespaceVisibilityRetention indicates an expected call of UpdateNamespaceVisibilityRetention.
+func (mr *MockAdminServiceServerMockRecorder) UpdateNamespaceVisibilityRetention(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespaceVisibilityRetention", reflect.TypeOf((*MockAdminServiceServer)(nil).UpdateNamespaceVisibilityRetention), arg0, arg1)
+}
+
+// WatchWorkflowExecutions mocks base method.
+func (m *MockAdminServiceServer) WatchWorkflowExecutions(arg0 *adminservice.WatchWorkflowExecutionsRequest, arg1 adminservice.AdminService_WatchWorkflowExecutionsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchWorkflowExecutions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchWorkflowExecutions indicates an expected call of WatchWorkflowExecutions.
+func (mr *MockAdminServiceServerMockRecorder) WatchWorkflowExecutions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchWorkflowExecutions", reflect.TypeOf((*MockAdminServiceServer)(nil).WatchWorkflowExecutions), arg0, arg1)
+}
+
 // mustEmbedUnimplementedAdminServiceServer mocks base method.
 func (m *MockAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {
 	m.ctrl.T.Helper()
@@ -1730,3 +1957,122 @@ func (mr *MockAdminService_StreamWorkflowReplicationMessagesServerMockRecorder)
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAdminService_StreamWorkflowReplicationMessagesServer)(nil).SetTrailer), arg0)
 }
+
+// MockAdminService_WatchWorkflowExecutionsServer is a mock of AdminService_WatchWorkflowExecutionsServer interface.
+type MockAdminService_WatchWorkflowExecutionsServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminService_WatchWorkflowExecutionsServerMockRecorder
+}
+
+// MockAdminService_WatchWorkflowExecutionsServerMockRecorder is the mock recorder for MockAdminService_WatchWorkflowExecutionsServer.
+type MockAdminService_WatchWorkflowExecutionsServerMockRecorder struct {
+	mock *MockAdminService_WatchWorkflowExecutionsServer
+}
+
+// NewMockAdminService_WatchWorkflowExecutionsServer creates a new mock instance.
+func NewMockAdminService_WatchWorkflowExecutionsServer(ctrl *gomock.Controller) *MockAdminService_WatchWorkflowExecutionsServer {
+	mock := &MockAdminService_WatchWorkflowExecutionsServer{ctrl: ctrl}
+	mock.recorder = &MockAdminService_WatchWorkflowExecutionsServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminService_WatchWorkflowExecutionsServer) EXPECT() *MockAdminService_WatchWorkflowExecutionsServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAdminService_WatchWorkflowExecutionsServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAdminService_WatchWorkflowExecutionsServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAdminService_WatchWorkflowExecutionsServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsServer) Send(arg0 *adminservice.WatchWorkflowExecutionsResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAdminService_WatchWorkflowExecutionsServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAdminService_WatchWorkflowExecutionsServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAdminService_WatchWorkflowExecutionsServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAdminService_WatchWorkflowExecutionsServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAdminService_WatchWorkflowExecutionsServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAdminService_WatchWorkflowExecutionsServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAdminService_WatchWorkflowExecutionsServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAdminService_WatchWorkflowExecutionsServer)(nil).SetTrailer), arg0)
+}
@@ -250,3 +250,77 @@ func (this *TaskRange) Equal(that interface{}) bool {
 
 	return proto.Equal(this, that1)
 }
+
+// Marshal an object of type MutableStateFieldDiff to the protobuf v3 wire format
+func (val *MutableStateFieldDiff) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type MutableStateFieldDiff from the protobuf v3 wire format
+func (val *MutableStateFieldDiff) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *MutableStateFieldDiff) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two MutableStateFieldDiff values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *MutableStateFieldDiff) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *MutableStateFieldDiff
+	switch t := that.(type) {
+	case *MutableStateFieldDiff:
+		that1 = t
+	case MutableStateFieldDiff:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type QueueAlert to the protobuf v3 wire format
+func (val *QueueAlert) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type QueueAlert from the protobuf v3 wire format
+func (val *QueueAlert) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *QueueAlert) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two QueueAlert values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *QueueAlert) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *QueueAlert
+	switch t := that.(type) {
+	case *QueueAlert:
+		that1 = t
+	case QueueAlert:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
@@ -4727,3 +4727,77 @@ func (this *ListTasksResponse) Equal(that interface{}) bool {
 
 	return proto.Equal(this, that1)
 }
+
+// Marshal an object of type StreamVisibilityChangesRequest to the protobuf v3 wire format
+func (val *StreamVisibilityChangesRequest) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type StreamVisibilityChangesRequest from the protobuf v3 wire format
+func (val *StreamVisibilityChangesRequest) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *StreamVisibilityChangesRequest) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two StreamVisibilityChangesRequest values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *StreamVisibilityChangesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *StreamVisibilityChangesRequest
+	switch t := that.(type) {
+	case *StreamVisibilityChangesRequest:
+		that1 = t
+	case StreamVisibilityChangesRequest:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
+
+// Marshal an object of type StreamVisibilityChangesResponse to the protobuf v3 wire format
+func (val *StreamVisibilityChangesResponse) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type StreamVisibilityChangesResponse from the protobuf v3 wire format
+func (val *StreamVisibilityChangesResponse) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *StreamVisibilityChangesResponse) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two StreamVisibilityChangesResponse values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *StreamVisibilityChangesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *StreamVisibilityChangesResponse
+	switch t := that.(type) {
+	case *StreamVisibilityChangesResponse:
+		that1 = t
+	case StreamVisibilityChangesResponse:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
@@ -93,6 +93,7 @@ const (
 	HistoryService_UpdateWorkflowExecution_FullMethodName                = "/temporal.server.api.historyservice.v1.HistoryService/UpdateWorkflowExecution"
 	HistoryService_PollWorkflowExecutionUpdate_FullMethodName            = "/temporal.server.api.historyservice.v1.HistoryService/PollWorkflowExecutionUpdate"
 	HistoryService_StreamWorkflowReplicationMessages_FullMethodName      = "/temporal.server.api.historyservice.v1.HistoryService/StreamWorkflowReplicationMessages"
+	HistoryService_StreamVisibilityChanges_FullMethodName                = "/temporal.server.api.historyservice.v1.HistoryService/StreamVisibilityChanges"
 	HistoryService_GetWorkflowExecutionHistory_FullMethodName            = "/temporal.server.api.historyservice.v1.HistoryService/GetWorkflowExecutionHistory"
 	HistoryService_GetWorkflowExecutionHistoryReverse_FullMethodName     = "/temporal.server.api.historyservice.v1.HistoryService/GetWorkflowExecutionHistoryReverse"
 	HistoryService_GetWorkflowExecutionRawHistoryV2_FullMethodName       = "/temporal.server.api.historyservice.v1.HistoryService/GetWorkflowExecutionRawHistoryV2"
@@ -290,6 +291,8 @@ type HistoryServiceClient interface {
 	//	aip.dev/not-precedent: This service does not follow the update method API --)
 	PollWorkflowExecutionUpdate(ctx context.Context, in *PollWorkflowExecutionUpdateRequest, opts ...grpc.CallOption) (*PollWorkflowExecutionUpdateResponse, error)
 	StreamWorkflowReplicationMessages(ctx context.Context, opts ...grpc.CallOption) (HistoryService_StreamWorkflowReplicationMessagesClient, error)
+	// StreamVisibilityChanges streams the visibility records of a namespace written by the visibility queue of a shard.
+	StreamVisibilityChanges(ctx context.Context, in *StreamVisibilityChangesRequest, opts ...grpc.CallOption) (HistoryService_StreamVisibilityChangesClient, error)
 	GetWorkflowExecutionHistory(ctx context.Context, in *GetWorkflowExecutionHistoryRequest, opts ...grpc.CallOption) (*GetWorkflowExecutionHistoryResponse, error)
 	GetWorkflowExecutionHistoryReverse(ctx context.Context, in *GetWorkflowExecutionHistoryReverseRequest, opts ...grpc.CallOption) (*GetWorkflowExecutionHistoryReverseResponse, error)
 	GetWorkflowExecutionRawHistoryV2(ctx context.Context, in *GetWorkflowExecutionRawHistoryV2Request, opts ...grpc.CallOption) (*GetWorkflowExecutionRawHistoryV2Response, error)
@@ -799,6 +802,38 @@ func (x *historyServiceStreamWorkflowReplicationMessagesClient) Recv() (*StreamW
 	return m, nil
 }
 
+func (c *historyServiceClient) StreamVisibilityChanges(ctx context.Context, in *StreamVisibilityChangesRequest, opts ...grpc.CallOption) (HistoryService_StreamVisibilityChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &HistoryService_ServiceDesc.Streams[1], HistoryService_StreamVisibilityChanges_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &historyServiceStreamVisibilityChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type HistoryService_StreamVisibilityChangesClient interface {
+	Recv() (*StreamVisibilityChangesResponse, error)
+	grpc.ClientStream
+}
+
+type historyServiceStreamVisibilityChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *historyServiceStreamVisibilityChangesClient) Recv() (*StreamVisibilityChangesResponse, error) {
+	m := new(StreamVisibilityChangesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *historyServiceClient) GetWorkflowExecutionHistory(ctx context.Context, in *GetWorkflowExecutionHistoryRequest, opts ...grpc.CallOption) (*GetWorkflowExecutionHistoryResponse, error) {
 	out := new(GetWorkflowExecutionHistoryResponse)
 	err := c.cc.Invoke(ctx, HistoryService_GetWorkflowExecutionHistory_FullMethodName, in, out, opts...)
@@ -1074,6 +1109,8 @@ type HistoryServiceServer interface {
 	//	aip.dev/not-precedent: This service does not follow the update method API --)
 	PollWorkflowExecutionUpdate(context.Context, *PollWorkflowExecutionUpdateRequest) (*PollWorkflowExecutionUpdateResponse, error)
 	StreamWorkflowReplicationMessages(HistoryService_StreamWorkflowReplicationMessagesServer) error
+	// StreamVisibilityChanges streams the visibility records of a namespace written by the visibility queue of a shard.
+	StreamVisibilityChanges(*StreamVisibilityChangesRequest, HistoryService_StreamVisibilityChangesServer) error
 	GetWorkflowExecutionHistory(context.Context, *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error)
 	GetWorkflowExecutionHistoryReverse(context.Context, *GetWorkflowExecutionHistoryReverseRequest) (*GetWorkflowExecutionHistoryReverseResponse, error)
 	GetWorkflowExecutionRawHistoryV2(context.Context, *GetWorkflowExecutionRawHistoryV2Request) (*GetWorkflowExecutionRawHistoryV2Response, error)
@@ -1252,6 +1289,9 @@ func (UnimplementedHistoryServiceServer) PollWorkflowExecutionUpdate(context.Con
 func (UnimplementedHistoryServiceServer) StreamWorkflowReplicationMessages(HistoryService_StreamWorkflowReplicationMessagesServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamWorkflowReplicationMessages not implemented")
 }
+func (UnimplementedHistoryServiceServer) StreamVisibilityChanges(*StreamVisibilityChangesRequest, HistoryService_StreamVisibilityChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamVisibilityChanges not implemented")
+}
 func (UnimplementedHistoryServiceServer) GetWorkflowExecutionHistory(context.Context, *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowExecutionHistory not implemented")
 }
@@ -2221,6 +2261,27 @@ func (x *historyServiceStreamWorkflowReplicationMessagesServer) Recv() (*StreamW
 	return m, nil
 }
 
+func _HistoryService_StreamVisibilityChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamVisibilityChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HistoryServiceServer).StreamVisibilityChanges(m, &historyServiceStreamVisibilityChangesServer{stream})
+}
+
+type HistoryService_StreamVisibilityChangesServer interface {
+	Send(*StreamVisibilityChangesResponse) error
+	grpc.ServerStream
+}
+
+type historyServiceStreamVisibilityChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *historyServiceStreamVisibilityChangesServer) Send(m *StreamVisibilityChangesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _HistoryService_GetWorkflowExecutionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetWorkflowExecutionHistoryRequest)
 	if err := dec(in); err != nil {
@@ -2656,6 +2717,11 @@ var HistoryService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamVisibilityChanges",
+			Handler:       _HistoryService_StreamVisibilityChanges_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "temporal/server/api/historyservice/v1/service.proto",
 }
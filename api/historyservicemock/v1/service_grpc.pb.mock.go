@@ -1141,6 +1141,26 @@ func (mr *MockHistoryServiceClientMockRecorder) StartWorkflowExecution(ctx, in i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartWorkflowExecution", reflect.TypeOf((*MockHistoryServiceClient)(nil).StartWorkflowExecution), varargs...)
 }
 
+// StreamVisibilityChanges mocks base method.
+func (m *MockHistoryServiceClient) StreamVisibilityChanges(ctx context.Context, in *historyservice.StreamVisibilityChangesRequest, opts ...grpc.CallOption) (historyservice.HistoryService_StreamVisibilityChangesClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StreamVisibilityChanges", varargs...)
+	ret0, _ := ret[0].(historyservice.HistoryService_StreamVisibilityChangesClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamVisibilityChanges indicates an expected call of StreamVisibilityChanges.
+func (mr *MockHistoryServiceClientMockRecorder) StreamVisibilityChanges(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamVisibilityChanges", reflect.TypeOf((*MockHistoryServiceClient)(nil).StreamVisibilityChanges), varargs...)
+}
+
 // StreamWorkflowReplicationMessages mocks base method.
 func (m *MockHistoryServiceClient) StreamWorkflowReplicationMessages(ctx context.Context, opts ...grpc.CallOption) (historyservice.HistoryService_StreamWorkflowReplicationMessagesClient, error) {
 	m.ctrl.T.Helper()
@@ -1418,6 +1438,129 @@ func (mr *MockHistoryService_StreamWorkflowReplicationMessagesClientMockRecorder
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockHistoryService_StreamWorkflowReplicationMessagesClient)(nil).Trailer))
 }
 
+// MockHistoryService_StreamVisibilityChangesClient is a mock of HistoryService_StreamVisibilityChangesClient interface.
+type MockHistoryService_StreamVisibilityChangesClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryService_StreamVisibilityChangesClientMockRecorder
+}
+
+// MockHistoryService_StreamVisibilityChangesClientMockRecorder is the mock recorder for MockHistoryService_StreamVisibilityChangesClient.
+type MockHistoryService_StreamVisibilityChangesClientMockRecorder struct {
+	mock *MockHistoryService_StreamVisibilityChangesClient
+}
+
+// NewMockHistoryService_StreamVisibilityChangesClient creates a new mock instance.
+func NewMockHistoryService_StreamVisibilityChangesClient(ctrl *gomock.Controller) *MockHistoryService_StreamVisibilityChangesClient {
+	mock := &MockHistoryService_StreamVisibilityChangesClient{ctrl: ctrl}
+	mock.recorder = &MockHistoryService_StreamVisibilityChangesClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryService_StreamVisibilityChangesClient) EXPECT() *MockHistoryService_StreamVisibilityChangesClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockHistoryService_StreamVisibilityChangesClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockHistoryService_StreamVisibilityChangesClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockHistoryService_StreamVisibilityChangesClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesClient) Recv() (*historyservice.StreamVisibilityChangesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*historyservice.StreamVisibilityChangesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockHistoryService_StreamVisibilityChangesClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockHistoryService_StreamVisibilityChangesClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockHistoryService_StreamVisibilityChangesClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockHistoryService_StreamVisibilityChangesClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockHistoryService_StreamVisibilityChangesClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockHistoryService_StreamVisibilityChangesClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesClient)(nil).Trailer))
+}
+
 // MockHistoryServiceServer is a mock of HistoryServiceServer interface.
 type MockHistoryServiceServer struct {
 	ctrl     *gomock.Controller
@@ -2251,6 +2394,20 @@ func (mr *MockHistoryServiceServerMockRecorder) StartWorkflowExecution(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartWorkflowExecution", reflect.TypeOf((*MockHistoryServiceServer)(nil).StartWorkflowExecution), arg0, arg1)
 }
 
+// StreamVisibilityChanges mocks base method.
+func (m *MockHistoryServiceServer) StreamVisibilityChanges(arg0 *historyservice.StreamVisibilityChangesRequest, arg1 historyservice.HistoryService_StreamVisibilityChangesServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamVisibilityChanges", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamVisibilityChanges indicates an expected call of StreamVisibilityChanges.
+func (mr *MockHistoryServiceServerMockRecorder) StreamVisibilityChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamVisibilityChanges", reflect.TypeOf((*MockHistoryServiceServer)(nil).StreamVisibilityChanges), arg0, arg1)
+}
+
 // StreamWorkflowReplicationMessages mocks base method.
 func (m *MockHistoryServiceServer) StreamWorkflowReplicationMessages(arg0 historyservice.HistoryService_StreamWorkflowReplicationMessagesServer) error {
 	m.ctrl.T.Helper()
@@ -2535,3 +2692,122 @@ func (mr *MockHistoryService_StreamWorkflowReplicationMessagesServerMockRecorder
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockHistoryService_StreamWorkflowReplicationMessagesServer)(nil).SetTrailer), arg0)
 }
+
+// MockHistoryService_StreamVisibilityChangesServer is a mock of HistoryService_StreamVisibilityChangesServer interface.
+type MockHistoryService_StreamVisibilityChangesServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryService_StreamVisibilityChangesServerMockRecorder
+}
+
+// MockHistoryService_StreamVisibilityChangesServerMockRecorder is the mock recorder for MockHistoryService_StreamVisibilityChangesServer.
+type MockHistoryService_StreamVisibilityChangesServerMockRecorder struct {
+	mock *MockHistoryService_StreamVisibilityChangesServer
+}
+
+// NewMockHistoryService_StreamVisibilityChangesServer creates a new mock instance.
+func NewMockHistoryService_StreamVisibilityChangesServer(ctrl *gomock.Controller) *MockHistoryService_StreamVisibilityChangesServer {
+	mock := &MockHistoryService_StreamVisibilityChangesServer{ctrl: ctrl}
+	mock.recorder = &MockHistoryService_StreamVisibilityChangesServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryService_StreamVisibilityChangesServer) EXPECT() *MockHistoryService_StreamVisibilityChangesServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockHistoryService_StreamVisibilityChangesServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockHistoryService_StreamVisibilityChangesServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockHistoryService_StreamVisibilityChangesServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesServer) Send(arg0 *historyservice.StreamVisibilityChangesResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockHistoryService_StreamVisibilityChangesServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockHistoryService_StreamVisibilityChangesServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockHistoryService_StreamVisibilityChangesServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockHistoryService_StreamVisibilityChangesServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockHistoryService_StreamVisibilityChangesServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockHistoryService_StreamVisibilityChangesServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockHistoryService_StreamVisibilityChangesServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockHistoryService_StreamVisibilityChangesServer)(nil).SetTrailer), arg0)
+}
@@ -176,3 +176,40 @@ func (this *QueryTask) Equal(that interface{}) bool {
 
 	return proto.Equal(this, that1)
 }
+
+// Marshal an object of type NexusIncomingServicesPageToken to the protobuf v3 wire format
+func (val *NexusIncomingServicesPageToken) Marshal() ([]byte, error) {
+	return proto.Marshal(val)
+}
+
+// Unmarshal an object of type NexusIncomingServicesPageToken from the protobuf v3 wire format
+func (val *NexusIncomingServicesPageToken) Unmarshal(buf []byte) error {
+	return proto.Unmarshal(buf, val)
+}
+
+// Size returns the size of the object, in bytes, once serialized
+func (val *NexusIncomingServicesPageToken) Size() int {
+	return proto.Size(val)
+}
+
+// Equal returns whether two NexusIncomingServicesPageToken values are equivalent by recursively
+// comparing the message's fields.
+// For more information see the documentation for
+// https://pkg.go.dev/google.golang.org/protobuf/proto#Equal
+func (this *NexusIncomingServicesPageToken) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	var that1 *NexusIncomingServicesPageToken
+	switch t := that.(type) {
+	case *NexusIncomingServicesPageToken:
+		that1 = t
+	case NexusIncomingServicesPageToken:
+		that1 = &t
+	default:
+		return false
+	}
+
+	return proto.Equal(this, that1)
+}
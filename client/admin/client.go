@@ -83,3 +83,12 @@ func (c *clientImpl) StreamWorkflowReplicationMessages(
 	// do not use createContext function, let caller manage stream API lifecycle
 	return c.client.StreamWorkflowReplicationMessages(ctx, opts...)
 }
+
+func (c *clientImpl) WatchWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.WatchWorkflowExecutionsRequest,
+	opts ...grpc.CallOption,
+) (adminservice.AdminService_WatchWorkflowExecutionsClient, error) {
+	// do not use createContext function, let caller manage stream API lifecycle
+	return c.client.WatchWorkflowExecutions(ctx, request, opts...)
+}
@@ -163,6 +163,16 @@ func (c *clientImpl) GetDLQTasks(
 	return c.client.GetDLQTasks(ctx, request, opts...)
 }
 
+func (c *clientImpl) GetDynamicConfig(
+	ctx context.Context,
+	request *adminservice.GetDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetDynamicConfigResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.GetDynamicConfig(ctx, request, opts...)
+}
+
 func (c *clientImpl) GetNamespace(
 	ctx context.Context,
 	request *adminservice.GetNamespaceRequest,
@@ -402,3 +412,13 @@ func (c *clientImpl) ResendReplicationTasks(
 	defer cancel()
 	return c.client.ResendReplicationTasks(ctx, request, opts...)
 }
+
+func (c *clientImpl) UpdateNamespaceVisibilityRetention(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceVisibilityRetentionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateNamespaceVisibilityRetentionResponse, error) {
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return c.client.UpdateNamespaceVisibilityRetention(ctx, request, opts...)
+}
@@ -104,3 +104,17 @@ func (c *metricClient) StreamWorkflowReplicationMessages(
 
 	return c.client.StreamWorkflowReplicationMessages(ctx, opts...)
 }
+
+func (c *metricClient) WatchWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.WatchWorkflowExecutionsRequest,
+	opts ...grpc.CallOption,
+) (_ adminservice.AdminService_WatchWorkflowExecutionsClient, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, metrics.AdminClientWatchWorkflowExecutionsScope)
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.WatchWorkflowExecutions(ctx, request, opts...)
+}
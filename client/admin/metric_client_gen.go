@@ -215,6 +215,20 @@ func (c *metricClient) GetDLQTasks(
 	return c.client.GetDLQTasks(ctx, request, opts...)
 }
 
+func (c *metricClient) GetDynamicConfig(
+	ctx context.Context,
+	request *adminservice.GetDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.GetDynamicConfigResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientGetDynamicConfig")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.GetDynamicConfig(ctx, request, opts...)
+}
+
 func (c *metricClient) GetNamespace(
 	ctx context.Context,
 	request *adminservice.GetNamespaceRequest,
@@ -550,3 +564,17 @@ func (c *metricClient) ResendReplicationTasks(
 
 	return c.client.ResendReplicationTasks(ctx, request, opts...)
 }
+
+func (c *metricClient) UpdateNamespaceVisibilityRetention(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceVisibilityRetentionRequest,
+	opts ...grpc.CallOption,
+) (_ *adminservice.UpdateNamespaceVisibilityRetentionResponse, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, "AdminClientUpdateNamespaceVisibilityRetention")
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.UpdateNamespaceVisibilityRetention(ctx, request, opts...)
+}
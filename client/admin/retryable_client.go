@@ -63,3 +63,18 @@ func (c *retryableClient) StreamWorkflowReplicationMessages(
 	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) WatchWorkflowExecutions(
+	ctx context.Context,
+	request *adminservice.WatchWorkflowExecutionsRequest,
+	opts ...grpc.CallOption,
+) (adminservice.AdminService_WatchWorkflowExecutionsClient, error) {
+	var resp adminservice.AdminService_WatchWorkflowExecutionsClient
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.WatchWorkflowExecutions(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -230,6 +230,21 @@ func (c *retryableClient) GetDLQTasks(
 	return resp, err
 }
 
+func (c *retryableClient) GetDynamicConfig(
+	ctx context.Context,
+	request *adminservice.GetDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetDynamicConfigResponse, error) {
+	var resp *adminservice.GetDynamicConfigResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.GetDynamicConfig(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) GetNamespace(
 	ctx context.Context,
 	request *adminservice.GetNamespaceRequest,
@@ -589,3 +604,18 @@ func (c *retryableClient) ResendReplicationTasks(
 	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) UpdateNamespaceVisibilityRetention(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceVisibilityRetentionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateNamespaceVisibilityRetentionResponse, error) {
+	var resp *adminservice.UpdateNamespaceVisibilityRetentionResponse
+	op := func(ctx context.Context) error {
+		var err error
+		resp, err = c.client.UpdateNamespaceVisibilityRetention(ctx, request, opts...)
+		return err
+	}
+	err := backoff.ThrottleRetryContext(ctx, op, c.policy, c.isRetryable)
+	return resp, err
+}
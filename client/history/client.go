@@ -261,6 +261,23 @@ func (c *clientImpl) StreamWorkflowReplicationMessages(
 	)
 }
 
+func (c *clientImpl) StreamVisibilityChanges(
+	ctx context.Context,
+	request *historyservice.StreamVisibilityChangesRequest,
+	opts ...grpc.CallOption,
+) (historyservice.HistoryService_StreamVisibilityChangesClient, error) {
+	if len(request.GetShards()) == 0 {
+		return nil, serviceerror.NewInvalidArgument("Shards are not set on request.")
+	}
+	// All the shards are owned by the same host, so the request is routed by the first one.
+	client, err := c.redirector.clientForShardID(request.GetShards()[0].GetShardId())
+	if err != nil {
+		return nil, err
+	}
+	// do not use createContext function, let caller manage stream API lifecycle
+	return client.StreamVisibilityChanges(ctx, request, opts...)
+}
+
 // GetDLQTasks doesn't need redirects or routing because DLQ tasks are not sharded, so it just picks any available host
 // in the connection pool (or creates one) and forwards the request to it.
 func (c *clientImpl) GetDLQTasks(
@@ -75,6 +75,20 @@ func (c *metricClient) StreamWorkflowReplicationMessages(
 	return c.client.StreamWorkflowReplicationMessages(ctx, opts...)
 }
 
+func (c *metricClient) StreamVisibilityChanges(
+	ctx context.Context,
+	request *historyservice.StreamVisibilityChangesRequest,
+	opts ...grpc.CallOption,
+) (_ historyservice.HistoryService_StreamVisibilityChangesClient, retError error) {
+
+	metricsHandler, startTime := c.startMetricsRecording(ctx, metrics.HistoryClientStreamVisibilityChangesScope)
+	defer func() {
+		c.finishMetricsRecording(metricsHandler, startTime, retError)
+	}()
+
+	return c.client.StreamVisibilityChanges(ctx, request, opts...)
+}
+
 func (c *metricClient) startMetricsRecording(
 	ctx context.Context,
 	operation string,
@@ -30,6 +30,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 	_ "time/tzdata" // embed tzdata as a fallback
 
 	"github.com/urfave/cli/v2"
@@ -158,18 +159,28 @@ func buildCLI() *cli.App {
 				)
 
 				var dynamicConfigClient dynamicconfig.Client
+				if cfg.DynamicConfigClient != nil && cfg.DynamicConfigDirectoryClient != nil {
+					return cli.Exit("Only one of dynamicConfigClient and dynamicConfigDirectoryClient can be configured.", 1)
+				}
 				if cfg.DynamicConfigClient != nil {
 					dynamicConfigClient, err = dynamicconfig.NewFileBasedClient(cfg.DynamicConfigClient, logger, temporal.InterruptCh())
 					if err != nil {
 						return cli.Exit(fmt.Sprintf("Unable to create dynamic config client. Error: %v", err), 1)
 					}
+				} else if cfg.DynamicConfigDirectoryClient != nil {
+					dynamicConfigClient, err = dynamicconfig.NewDirectoryClient(cfg.DynamicConfigDirectoryClient, logger, temporal.InterruptCh())
+					if err != nil {
+						return cli.Exit(fmt.Sprintf("Unable to create dynamic config client. Error: %v", err), 1)
+					}
 				} else {
 					dynamicConfigClient = dynamicconfig.NewNoopClient()
 					logger.Info("Dynamic config client is not configured. Using noop client.")
 				}
 
-				authorizer, err := authorization.GetAuthorizerFromConfig(
+				authorizer, err := authorization.NewAuthorizerFromConfig(
 					&cfg.Global.Authorization,
+					logger,
+					nil,
 				)
 				if err != nil {
 					return cli.Exit(fmt.Sprintf("Unable to instantiate authorizer. Error: %v", err), 1)
@@ -208,6 +219,76 @@ func buildCLI() *cli.App {
 				return cli.Exit("All services are stopped.", 0)
 			},
 		},
+		{
+			Name:      "validate-dynamic-config",
+			Usage:     "Validate dynamic config values against the keys used by the server",
+			ArgsUsage: " ",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "file",
+					Usage: "dynamic config file or directory to validate, defaults to the dynamic config of the server config",
+				},
+			},
+			Action: validateDynamicConfig,
+		},
 	}
 	return app
 }
+
+func validateDynamicConfig(c *cli.Context) error {
+	persistenceConfig := &config.Persistence{NumHistoryShards: 1}
+	var fileConfig *dynamicconfig.FileBasedClientConfig
+	var directoryConfig *dynamicconfig.DirectoryClientConfig
+	if file := c.String("file"); file != "" {
+		info, err := os.Stat(file)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("Unable to read dynamic config: %v.", err), 1)
+		}
+		if info.IsDir() {
+			directoryConfig = &dynamicconfig.DirectoryClientConfig{Directory: file}
+		} else {
+			fileConfig = &dynamicconfig.FileBasedClientConfig{Filepath: file, PollInterval: time.Minute}
+		}
+	} else {
+		configDir := path.Join(c.String("root"), c.String("config"))
+		cfg, err := config.LoadConfig(c.String("env"), configDir, c.String("zone"))
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("Unable to load configuration: %v.", err), 1)
+		}
+		persistenceConfig = &cfg.Persistence
+		fileConfig = cfg.DynamicConfigClient
+		directoryConfig = cfg.DynamicConfigDirectoryClient
+	}
+
+	// The client only needs to load the values once.
+	doneCh := make(chan interface{})
+	close(doneCh)
+	logger := log.NewNoopLogger()
+	var client dynamicconfig.Client
+	switch {
+	case fileConfig != nil:
+		fileClient, err := dynamicconfig.NewFileBasedClient(fileConfig, logger, doneCh)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("Unable to load dynamic config: %v.", err), 1)
+		}
+		client = fileClient
+	case directoryConfig != nil:
+		directoryClient, err := dynamicconfig.NewDirectoryClient(directoryConfig, logger, doneCh)
+		if err != nil {
+			return cli.Exit(fmt.Sprintf("Unable to load dynamic config: %v.", err), 1)
+		}
+		client = directoryClient
+	default:
+		return cli.Exit("Dynamic config client is not configured.", 1)
+	}
+
+	errs := temporal.ValidateDynamicConfig(client, persistenceConfig, logger)
+	for _, err := range errs {
+		fmt.Println(err.Error())
+	}
+	if len(errs) > 0 {
+		return cli.Exit(fmt.Sprintf("Found %d invalid dynamic config values.", len(errs)), 1)
+	}
+	fmt.Println("Dynamic config is valid.")
+	return nil
+}
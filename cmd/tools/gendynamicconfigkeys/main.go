@@ -0,0 +1,306 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// gendynamicconfigkeys generates the list of all dynamic config keys declared in
+// common/dynamicconfig/constants.go, used to validate dynamic config files, and the static
+// registrations of the keys, collected from the Collection getters they are used with.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type (
+	// getter is how a Collection getter registers its key
+	getter struct {
+		valueType  string
+		precedence string
+	}
+
+	// keyUsage is the registration of a key collected from a getter call
+	keyUsage struct {
+		getter
+		// defaultValue is the source of the default value, empty if it can't be evaluated in
+		// the dynamicconfig package
+		defaultValue string
+		usesTime     bool
+	}
+)
+
+// getters maps the Collection getters to the type and precedence they register keys with.
+var getters = map[string]getter{
+	"GetIntProperty":                             {"ValueTypeInt", "PrecedenceGlobal"},
+	"GetIntPropertyFilteredByNamespace":          {"ValueTypeInt", "PrecedenceNamespace"},
+	"GetIntPropertyFilteredByTaskQueueInfo":      {"ValueTypeInt", "PrecedenceTaskQueue"},
+	"GetIntPropertyFilteredByShardID":            {"ValueTypeInt", "PrecedenceShardID"},
+	"GetTaskQueuePartitionsProperty":             {"ValueTypeInt", "PrecedenceTaskQueue"},
+	"GetFloat64Property":                         {"ValueTypeFloat", "PrecedenceGlobal"},
+	"GetFloat64PropertyFilteredByShardID":        {"ValueTypeFloat", "PrecedenceShardID"},
+	"GetFloatPropertyFilteredByNamespace":        {"ValueTypeFloat", "PrecedenceNamespace"},
+	"GetFloatPropertyFilteredByTaskQueueInfo":    {"ValueTypeFloat", "PrecedenceTaskQueue"},
+	"GetDurationProperty":                        {"ValueTypeDuration", "PrecedenceGlobal"},
+	"GetDurationPropertyFilteredByNamespace":     {"ValueTypeDuration", "PrecedenceNamespace"},
+	"GetDurationPropertyFilteredByNamespaceID":   {"ValueTypeDuration", "PrecedenceNamespaceID"},
+	"GetDurationPropertyFilteredByTaskQueueInfo": {"ValueTypeDuration", "PrecedenceTaskQueue"},
+	"GetDurationPropertyFilteredByShardID":       {"ValueTypeDuration", "PrecedenceShardID"},
+	"GetDurationPropertyFilteredByTaskType":      {"ValueTypeDuration", "PrecedenceTaskType"},
+	"GetBoolProperty":                            {"ValueTypeBool", "PrecedenceGlobal"},
+	"GetStringProperty":                          {"ValueTypeString", "PrecedenceGlobal"},
+	"GetMapProperty":                             {"ValueTypeMap", "PrecedenceGlobal"},
+	"GetStringPropertyFnWithNamespaceFilter":     {"ValueTypeString", "PrecedenceNamespace"},
+	"GetStringPropertyFnWithNamespaceIDFilter":   {"ValueTypeString", "PrecedenceNamespaceID"},
+	"GetMapPropertyFnWithNamespaceFilter":        {"ValueTypeMap", "PrecedenceNamespace"},
+	"GetBoolPropertyFnWithNamespaceFilter":       {"ValueTypeBool", "PrecedenceNamespace"},
+	"GetBoolPropertyFnWithNamespaceIDFilter":     {"ValueTypeBool", "PrecedenceNamespaceID"},
+	"GetBoolPropertyFilteredByTaskQueueInfo":     {"ValueTypeBool", "PrecedenceTaskQueue"},
+}
+
+// durationUnits are the time constants a default value may use
+var durationUnits = map[string]bool{
+	"Nanosecond":  true,
+	"Microsecond": true,
+	"Millisecond": true,
+	"Second":      true,
+	"Minute":      true,
+	"Hour":        true,
+}
+
+func readLicenseFile(path string) string {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	var lines []string
+	for _, line := range strings.Split(string(text), "\n") {
+		lines = append(lines, strings.TrimRight("// "+line, " "))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// keyNames returns the names of all string constants declared in file, in declaration order.
+func keyNames(file string) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+	var names []string
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if i >= len(valueSpec.Values) {
+					continue
+				}
+				if lit, ok := valueSpec.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					names = append(names, name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// keyUsages returns the registration of each key passed to a Collection getter in the non-test
+// go files under root. The first usage in file path order is kept for keys used more than once.
+func keyUsages(root string, declared map[string]bool) map[string]keyUsage {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	sort.Strings(files)
+
+	usages := make(map[string]keyUsage)
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			panic(err)
+		}
+		inPackage := f.Name.Name == "dynamicconfig"
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			g, ok := getters[sel.Sel.Name]
+			if !ok {
+				return true
+			}
+			key, ok := keyName(call.Args[0], inPackage)
+			if !ok || !declared[key] {
+				return true
+			}
+			if _, ok := usages[key]; ok {
+				return true
+			}
+			usage := keyUsage{getter: g}
+			if sel.Sel.Name == "GetTaskQueuePartitionsProperty" {
+				usage.defaultValue = "defaultNumTaskQueuePartitions"
+			} else if len(call.Args) > 1 {
+				usage.defaultValue, usage.usesTime = defaultValue(call.Args[1])
+			}
+			usages[key] = usage
+			return true
+		})
+	}
+	return usages
+}
+
+// keyName returns the name of the key constant expr refers to.
+func keyName(expr ast.Expr, inPackage bool) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok && pkg.Name == "dynamicconfig" {
+			return e.Sel.Name, true
+		}
+	case *ast.Ident:
+		if inPackage {
+			return e.Name, true
+		}
+	}
+	return "", false
+}
+
+// defaultValue returns the source of expr if it only uses literals and time units, so it can be
+// evaluated in the dynamicconfig package.
+func defaultValue(expr ast.Expr) (string, bool) {
+	usesTime := false
+	ok := true
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch e := n.(type) {
+		case nil, *ast.BasicLit, *ast.BinaryExpr, *ast.UnaryExpr, *ast.ParenExpr:
+		case *ast.Ident:
+			if e.Name != "true" && e.Name != "false" {
+				ok = false
+			}
+		case *ast.SelectorExpr:
+			pkg, isIdent := e.X.(*ast.Ident)
+			if !isIdent || pkg.Name != "time" || !durationUnits[e.Sel.Name] {
+				ok = false
+			}
+			usesTime = true
+			return false
+		default:
+			ok = false
+		}
+		return ok
+	})
+	if !ok {
+		return "", false
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		panic(err)
+	}
+	return buf.String(), usesTime
+}
+
+func main() {
+	inputFlag := flag.String("input", "constants.go", "file declaring the dynamic config keys")
+	outputFlag := flag.String("output", "keys_gen.go", "file to write")
+	rootFlag := flag.String("root", "../..", "root of the go files using the dynamic config keys")
+	licenseFlag := flag.String("licence_file", "../../LICENSE", "path to license to copy into header")
+	flag.Parse()
+
+	names := keyNames(*inputFlag)
+	declared := make(map[string]bool, len(names))
+	for _, name := range names {
+		declared[name] = true
+	}
+	usages := keyUsages(*rootFlag, declared)
+	usesTime := false
+	for _, usage := range usages {
+		usesTime = usesTime || usage.usesTime
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n// Code generated by cmd/tools/gendynamicconfigkeys. DO NOT EDIT.\n\n", readLicenseFile(*licenseFlag))
+	buf.WriteString("package dynamicconfig\n\n")
+	if usesTime {
+		buf.WriteString("import \"time\"\n\n")
+	}
+	buf.WriteString("// allKeys contains all keys declared in constants.go.\n")
+	buf.WriteString("var allKeys = []Key{\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\t%s,\n", name)
+	}
+	buf.WriteString("}\n\n")
+	buf.WriteString("// staticKeyInfos contains the registrations of the keys used with a Collection getter. Default\n")
+	buf.WriteString("// is nil for defaults that are computed where the key is used.\n")
+	buf.WriteString("var staticKeyInfos = []KeyInfo{\n")
+	for _, name := range names {
+		usage, ok := usages[name]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&buf, "\t{Key: %s, Type: %s, Precedence: %s", name, usage.valueType, usage.precedence)
+		if usage.defaultValue != "" {
+			fmt.Fprintf(&buf, ", Default: %s", usage.defaultValue)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(*outputFlag, src, 0644); err != nil {
+		panic(err)
+	}
+}
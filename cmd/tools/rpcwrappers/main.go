@@ -96,6 +96,12 @@ var (
 		"client.history.StreamWorkflowReplicationMessages":          true,
 		"metricsClient.history.StreamWorkflowReplicationMessages":   true,
 		"retryableClient.history.StreamWorkflowReplicationMessages": true,
+		"client.admin.WatchWorkflowExecutions":                      true,
+		"metricsClient.admin.WatchWorkflowExecutions":               true,
+		"retryableClient.admin.WatchWorkflowExecutions":             true,
+		"client.history.StreamVisibilityChanges":                    true,
+		"metricsClient.history.StreamVisibilityChanges":             true,
+		"retryableClient.history.StreamVisibilityChanges":           true,
 
 		// these are non-standard implementations. do not generate.
 		"client.history.DescribeHistoryHost":    true,
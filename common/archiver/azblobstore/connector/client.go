@@ -0,0 +1,244 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -copyright_file ../../../../LICENSE -package $GOPACKAGE -source $GOFILE -destination client_mock.go
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"go.uber.org/multierr"
+
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/config"
+)
+
+const (
+	delimiter = "/"
+)
+
+var (
+	// ErrContainerNotFound is non retryable error that is thrown when the container doesn't exist
+	ErrContainerNotFound = errors.New("container not found")
+	// ErrBlobNotFound is returned when the requested blob doesn't exist
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrEmptyAccountName is returned when neither a connection string nor an account name is configured
+	ErrEmptyAccountName = errors.New("empty azure storage account name")
+)
+
+type (
+	// Client is a wrapper around the Azure Blob Storage client library.
+	// The container is always taken from the URI hostname, blob names are full paths within the container.
+	Client interface {
+		ContainerExists(ctx context.Context, URI archiver.URI) (bool, error)
+		Exists(ctx context.Context, URI archiver.URI, blobName string) (bool, error)
+		Upload(ctx context.Context, URI archiver.URI, blobName string, data []byte) error
+		Get(ctx context.Context, URI archiver.URI, blobName string) ([]byte, error)
+		// List returns at most maxResults blob names starting with prefix, and a marker to resume
+		// listing from. An empty marker means there are no more results.
+		List(ctx context.Context, URI archiver.URI, prefix string, maxResults int, marker string) ([]string, string, error)
+		// ListPrefixes returns the virtual directories directly under prefix, each ending with "/".
+		ListPrefixes(ctx context.Context, URI archiver.URI, prefix string) ([]string, error)
+	}
+
+	storageWrapper struct {
+		client *azblob.Client
+	}
+)
+
+// NewClient returns a Temporal azblob Client based on the archiver config.
+// Credentials are resolved in order: connection string, shared key, Azure default credential chain.
+// Containers must be created beforehand, this library doesn't create them.
+func NewClient(cfg *config.AzblobArchiver) (Client, error) {
+	if cfg.ConnectionString != "" {
+		client, err := azblob.NewClientFromConnectionString(cfg.ConnectionString, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &storageWrapper{client: client}, nil
+	}
+
+	if cfg.AccountName == "" {
+		return nil, ErrEmptyAccountName
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", cfg.AccountName)
+	}
+
+	if cfg.AccountKey != "" {
+		credential, err := azblob.NewSharedKeyCredential(cfg.AccountName, cfg.AccountKey)
+		if err != nil {
+			return nil, err
+		}
+		client, err := azblob.NewClientWithSharedKeyCredential(endpoint, credential, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &storageWrapper{client: client}, nil
+	}
+
+	credential, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, err
+	}
+	client, err := azblob.NewClient(endpoint, credential, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &storageWrapper{client: client}, nil
+}
+
+// ContainerExists checks if the container named by the URI hostname exists
+func (s *storageWrapper) ContainerExists(ctx context.Context, URI archiver.URI) (bool, error) {
+	_, err := s.client.ServiceClient().NewContainerClient(URI.Hostname()).GetProperties(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Exists checks if a blob exists
+func (s *storageWrapper) Exists(ctx context.Context, URI archiver.URI, blobName string) (bool, error) {
+	_, err := s.client.ServiceClient().NewContainerClient(URI.Hostname()).NewBlobClient(blobName).GetProperties(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return false, nil
+		}
+		if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return false, ErrContainerNotFound
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Upload writes data to a block blob, overwriting any existing blob with the same name
+func (s *storageWrapper) Upload(ctx context.Context, URI archiver.URI, blobName string, data []byte) error {
+	_, err := s.client.UploadBuffer(ctx, URI.Hostname(), blobName, data, nil)
+	if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+		return ErrContainerNotFound
+	}
+	return err
+}
+
+// Get reads the whole content of a blob
+func (s *storageWrapper) Get(ctx context.Context, URI archiver.URI, blobName string) (_ []byte, err error) {
+	resp, err := s.client.DownloadStream(ctx, URI.Hostname(), blobName, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return nil, ErrContainerNotFound
+		}
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, ErrBlobNotFound
+		}
+		return nil, err
+	}
+	defer func() {
+		if ierr := resp.Body.Close(); ierr != nil {
+			err = multierr.Append(err, ierr)
+		}
+	}()
+	return io.ReadAll(resp.Body)
+}
+
+// List returns a single page of blob names starting with prefix
+func (s *storageWrapper) List(ctx context.Context, URI archiver.URI, prefix string, maxResults int, marker string) ([]string, string, error) {
+	options := &azblob.ListBlobsFlatOptions{
+		Prefix: to.Ptr(prefix),
+	}
+	if maxResults > 0 {
+		options.MaxResults = to.Ptr(int32(maxResults))
+	}
+	if marker != "" {
+		options.Marker = to.Ptr(marker)
+	}
+	pager := s.client.NewListBlobsFlatPager(URI.Hostname(), options)
+	if !pager.More() {
+		return nil, "", nil
+	}
+	page, err := pager.NextPage(ctx)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+			return nil, "", ErrContainerNotFound
+		}
+		return nil, "", err
+	}
+
+	names := make([]string, 0, len(page.Segment.BlobItems))
+	for _, item := range page.Segment.BlobItems {
+		names = append(names, *item.Name)
+	}
+	var nextMarker string
+	if page.NextMarker != nil {
+		nextMarker = *page.NextMarker
+	}
+	return names, nextMarker, nil
+}
+
+// ListPrefixes returns all virtual directories directly under prefix
+func (s *storageWrapper) ListPrefixes(ctx context.Context, URI archiver.URI, prefix string) ([]string, error) {
+	pager := s.client.ServiceClient().NewContainerClient(URI.Hostname()).NewListBlobsHierarchyPager(delimiter, &container.ListBlobsHierarchyOptions{
+		Prefix: to.Ptr(prefix),
+	})
+
+	var prefixes []string
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			if bloberror.HasCode(err, bloberror.ContainerNotFound) {
+				return nil, ErrContainerNotFound
+			}
+			return nil, err
+		}
+		for _, blobPrefix := range page.Segment.BlobPrefixes {
+			prefixes = append(prefixes, *blobPrefix.Name)
+		}
+	}
+	return prefixes, nil
+}
+
+// IsRetryableError returns true for throttling and server side errors returned by the storage service
+func IsRetryableError(err error) bool {
+	var responseErr *azcore.ResponseError
+	if !errors.As(err, &responseErr) {
+		return false
+	}
+	return responseErr.StatusCode == http.StatusTooManyRequests ||
+		(responseErr.StatusCode >= http.StatusInternalServerError && responseErr.StatusCode != http.StatusNotImplemented)
+}
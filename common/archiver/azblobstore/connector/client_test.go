@@ -0,0 +1,164 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/config"
+)
+
+const (
+	// emulatorConnectionStringEnv points the emulator tests at a running Azurite (or real storage account), e.g.
+	// DefaultEndpointsProtocol=http;AccountName=devstoreaccount1;AccountKey=...;BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1;
+	emulatorConnectionStringEnv = "AZBLOB_CONNECTION_STRING"
+)
+
+type clientSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	connectionString string
+	containerName    string
+	blobClient       *azblob.Client
+	client           Client
+	URI              archiver.URI
+}
+
+func TestIsRetryableError(t *testing.T) {
+	require.False(t, IsRetryableError(nil))
+	require.False(t, IsRetryableError(errors.New("some random error")))
+	require.False(t, IsRetryableError(ErrContainerNotFound))
+	require.False(t, IsRetryableError(&azcore.ResponseError{StatusCode: http.StatusNotFound}))
+	require.False(t, IsRetryableError(&azcore.ResponseError{StatusCode: http.StatusNotImplemented}))
+	require.True(t, IsRetryableError(&azcore.ResponseError{StatusCode: http.StatusTooManyRequests}))
+	require.True(t, IsRetryableError(&azcore.ResponseError{StatusCode: http.StatusServiceUnavailable}))
+	require.True(t, IsRetryableError(fmt.Errorf("wrapped: %w", &azcore.ResponseError{StatusCode: http.StatusInternalServerError})))
+}
+
+func TestNewClient_EmptyAccountName(t *testing.T) {
+	_, err := NewClient(&config.AzblobArchiver{})
+	require.Equal(t, ErrEmptyAccountName, err)
+}
+
+func TestClientSuite(t *testing.T) {
+	connectionString := os.Getenv(emulatorConnectionStringEnv)
+	if connectionString == "" {
+		t.Skipf("%s is not set, skipping azure blob storage emulator tests", emulatorConnectionStringEnv)
+	}
+	suite.Run(t, &clientSuite{connectionString: connectionString})
+}
+
+func (s *clientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	var err error
+	s.blobClient, err = azblob.NewClientFromConnectionString(s.connectionString, nil)
+	s.NoError(err)
+	s.client, err = NewClient(&config.AzblobArchiver{ConnectionString: s.connectionString})
+	s.NoError(err)
+
+	s.containerName = fmt.Sprintf("temporal-test-%d", time.Now().UnixNano())
+	_, err = s.blobClient.CreateContainer(context.Background(), s.containerName, nil)
+	s.NoError(err)
+	s.URI, err = archiver.NewURI("azblob://" + s.containerName)
+	s.NoError(err)
+}
+
+func (s *clientSuite) TearDownTest() {
+	_, err := s.blobClient.DeleteContainer(context.Background(), s.containerName, nil)
+	s.NoError(err)
+}
+
+func (s *clientSuite) TestContainerExists() {
+	exists, err := s.client.ContainerExists(context.Background(), s.URI)
+	s.NoError(err)
+	s.True(exists)
+
+	URI, err := archiver.NewURI("azblob://" + s.containerName + "-missing")
+	s.NoError(err)
+	exists, err = s.client.ContainerExists(context.Background(), URI)
+	s.NoError(err)
+	s.False(exists)
+}
+
+func (s *clientSuite) TestUploadAndGet() {
+	ctx := context.Background()
+
+	exists, err := s.client.Exists(ctx, s.URI, "a/b/c")
+	s.NoError(err)
+	s.False(exists)
+	_, err = s.client.Get(ctx, s.URI, "a/b/c")
+	s.Equal(ErrBlobNotFound, err)
+
+	s.NoError(s.client.Upload(ctx, s.URI, "a/b/c", []byte("content")))
+	exists, err = s.client.Exists(ctx, s.URI, "a/b/c")
+	s.NoError(err)
+	s.True(exists)
+	data, err := s.client.Get(ctx, s.URI, "a/b/c")
+	s.NoError(err)
+	s.Equal([]byte("content"), data)
+
+	URI, err := archiver.NewURI("azblob://" + s.containerName + "-missing")
+	s.NoError(err)
+	s.Equal(ErrContainerNotFound, s.client.Upload(ctx, URI, "a/b/c", []byte("content")))
+}
+
+func (s *clientSuite) TestList() {
+	ctx := context.Background()
+	for _, name := range []string{"prefix/1/a", "prefix/1/b", "prefix/2/a", "prefix/10/a", "other/a"} {
+		s.NoError(s.client.Upload(ctx, s.URI, name, []byte(name)))
+	}
+
+	var names []string
+	marker := ""
+	for {
+		page, nextMarker, err := s.client.List(ctx, s.URI, "prefix/", 2, marker)
+		s.NoError(err)
+		s.LessOrEqual(len(page), 2)
+		names = append(names, page...)
+		if nextMarker == "" {
+			break
+		}
+		marker = nextMarker
+	}
+	s.Equal([]string{"prefix/1/a", "prefix/1/b", "prefix/10/a", "prefix/2/a"}, names)
+
+	prefixes, err := s.client.ListPrefixes(ctx, s.URI, "prefix/")
+	s.NoError(err)
+	s.ElementsMatch([]string{"prefix/1/", "prefix/2/", "prefix/10/"}, prefixes)
+}
@@ -0,0 +1,422 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Azure Blob Storage History Archiver will archive workflow histories to an azure storage container
+
+package azblobstore
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/azblobstore/connector"
+	"go.temporal.io/server/common/codec"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+)
+
+const (
+	// URIScheme is the scheme for the azure blob storage implementation
+	URIScheme               = "azblob"
+	errEncodeHistory        = "failed to encode history batches"
+	errWriteBlob            = "failed to write history to azure blob storage"
+	defaultBlobstoreTimeout = time.Minute
+	targetHistoryBlobSize   = 2 * 1024 * 1024 // 2MB
+)
+
+var (
+	errNoContainerSpecified = errors.New("no container specified")
+	errContainerNotExists   = errors.New("requested container does not exist")
+)
+
+type (
+	historyArchiver struct {
+		container *archiver.HistoryBootstrapContainer
+		client    connector.Client
+		// only set in test code
+		historyIterator archiver.HistoryIterator
+	}
+
+	getHistoryToken struct {
+		CloseFailoverVersion int64
+		BatchIdx             int
+	}
+
+	uploadProgress struct {
+		BatchIdx      int
+		IteratorState []byte
+		uploadedSize  int64
+		historySize   int64
+	}
+)
+
+// NewHistoryArchiver creates a new archiver.HistoryArchiver based on azure blob storage
+func NewHistoryArchiver(
+	container *archiver.HistoryBootstrapContainer,
+	config *config.AzblobArchiver,
+) (archiver.HistoryArchiver, error) {
+	client, err := connector.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return newHistoryArchiver(container, client, nil), nil
+}
+
+func newHistoryArchiver(
+	container *archiver.HistoryBootstrapContainer,
+	client connector.Client,
+	historyIterator archiver.HistoryIterator,
+) *historyArchiver {
+	return &historyArchiver{
+		container:       container,
+		client:          client,
+		historyIterator: historyIterator,
+	}
+}
+
+func (h *historyArchiver) Archive(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.ArchiveHistoryRequest,
+	opts ...archiver.ArchiveOption,
+) (err error) {
+	handler := h.container.MetricsHandler.WithTags(metrics.OperationTag(metrics.HistoryArchiverScope), metrics.NamespaceTag(request.Namespace))
+	featureCatalog := archiver.GetFeatureCatalog(opts...)
+	startTime := time.Now().UTC()
+	defer func() {
+		handler.Timer(metrics.ServiceLatency.Name()).Record(time.Since(startTime))
+		if err != nil {
+			if common.IsPersistenceTransientError(err) || connector.IsRetryableError(err) {
+				handler.Counter(metrics.HistoryArchiverArchiveTransientErrorCount.Name()).Record(1)
+			} else {
+				handler.Counter(metrics.HistoryArchiverArchiveNonRetryableErrorCount.Name()).Record(1)
+				if featureCatalog.NonRetryableError != nil {
+					err = featureCatalog.NonRetryableError()
+				}
+			}
+		}
+	}()
+
+	logger := archiver.TagLoggerWithArchiveHistoryRequestAndURI(h.container.Logger, request, URI.String())
+
+	if err := SoftValidateURI(URI); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidURI), tag.Error(err))
+		return err
+	}
+
+	if err := archiver.ValidateHistoryArchiveRequest(request); err != nil {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonInvalidArchiveRequest), tag.Error(err))
+		return err
+	}
+
+	var progress uploadProgress
+	historyIterator := h.historyIterator
+	if historyIterator == nil { // will only be set by testing code
+		historyIterator = loadHistoryIterator(ctx, request, h.container.ExecutionManager, featureCatalog, &progress)
+	}
+	for historyIterator.HasNext() {
+		historyBlob, err := historyIterator.Next(ctx)
+		if err != nil {
+			if _, isNotFound := err.(*serviceerror.NotFound); isNotFound {
+				// workflow history no longer exists, may due to duplicated archival signal
+				// this may happen even in the middle of iterating history as two archival signals
+				// can be processed concurrently.
+				logger.Info(archiver.ArchiveSkippedInfoMsg)
+				handler.Counter(metrics.HistoryArchiverDuplicateArchivalsCount.Name()).Record(1)
+				return nil
+			}
+
+			logger := log.With(logger, tag.ArchivalArchiveFailReason(archiver.ErrReasonReadHistory), tag.Error(err))
+			if common.IsPersistenceTransientError(err) {
+				logger.Error(archiver.ArchiveTransientErrorMsg)
+			} else {
+				logger.Error(archiver.ArchiveNonRetryableErrorMsg)
+			}
+			return err
+		}
+
+		if historyMutated(request, historyBlob.Body, historyBlob.Header.IsLast) {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonHistoryMutated))
+			return archiver.ErrHistoryMutated
+		}
+
+		encodedHistoryBlob, err := encode(historyBlob)
+		if err != nil {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errEncodeHistory), tag.Error(err))
+			return err
+		}
+		blobName := constructHistoryBlobName(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID, request.CloseFailoverVersion, progress.BatchIdx)
+
+		exists, err := h.blobExists(ctx, URI, blobName)
+		if err != nil {
+			h.logWriteError(logger, err)
+			return err
+		}
+		blobSize := int64(binary.Size(encodedHistoryBlob))
+		if exists {
+			handler.Counter(metrics.HistoryArchiverBlobExistsCount.Name()).Record(1)
+		} else {
+			if err := h.upload(ctx, URI, blobName, encodedHistoryBlob); err != nil {
+				h.logWriteError(logger, err)
+				return err
+			}
+			progress.uploadedSize += blobSize
+			handler.Histogram(metrics.HistoryArchiverBlobSize.Name(), metrics.HistoryArchiverBlobSize.Unit()).Record(blobSize)
+		}
+
+		progress.historySize += blobSize
+		progress.BatchIdx = progress.BatchIdx + 1
+		saveHistoryIteratorState(ctx, featureCatalog, historyIterator, &progress)
+	}
+
+	handler.Histogram(metrics.HistoryArchiverTotalUploadSize.Name(), metrics.HistoryArchiverTotalUploadSize.Unit()).Record(progress.uploadedSize)
+	handler.Histogram(metrics.HistoryArchiverHistorySize.Name(), metrics.HistoryArchiverHistorySize.Unit()).Record(progress.historySize)
+	handler.Counter(metrics.HistoryArchiverArchiveSuccessCount.Name()).Record(1)
+	return nil
+}
+
+func (h *historyArchiver) logWriteError(logger log.Logger, err error) {
+	if connector.IsRetryableError(err) {
+		logger.Error(archiver.ArchiveTransientErrorMsg, tag.ArchivalArchiveFailReason(errWriteBlob), tag.Error(err))
+	} else {
+		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errWriteBlob), tag.Error(err))
+	}
+}
+
+func (h *historyArchiver) blobExists(ctx context.Context, URI archiver.URI, blobName string) (bool, error) {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+	exists, err := h.client.Exists(ctx, URI, blobName)
+	if err == connector.ErrContainerNotFound {
+		return false, serviceerror.NewInvalidArgument(errContainerNotExists.Error())
+	}
+	return exists, err
+}
+
+func (h *historyArchiver) upload(ctx context.Context, URI archiver.URI, blobName string, data []byte) error {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+	err := h.client.Upload(ctx, URI, blobName, data)
+	if err == connector.ErrContainerNotFound {
+		return serviceerror.NewInvalidArgument(errContainerNotExists.Error())
+	}
+	return err
+}
+
+func loadHistoryIterator(ctx context.Context, request *archiver.ArchiveHistoryRequest, executionManager persistence.ExecutionManager, featureCatalog *archiver.ArchiveFeatureCatalog, progress *uploadProgress) (historyIterator archiver.HistoryIterator) {
+	if featureCatalog.ProgressManager != nil {
+		if featureCatalog.ProgressManager.HasProgress(ctx) {
+			err := featureCatalog.ProgressManager.LoadProgress(ctx, progress)
+			if err == nil {
+				historyIterator, err := archiver.NewHistoryIteratorFromState(request, executionManager, targetHistoryBlobSize, progress.IteratorState)
+				if err == nil {
+					return historyIterator
+				}
+			}
+			progress.IteratorState = nil
+			progress.BatchIdx = 0
+			progress.historySize = 0
+			progress.uploadedSize = 0
+		}
+	}
+	return archiver.NewHistoryIterator(request, executionManager, targetHistoryBlobSize)
+}
+
+func saveHistoryIteratorState(ctx context.Context, featureCatalog *archiver.ArchiveFeatureCatalog, historyIterator archiver.HistoryIterator, progress *uploadProgress) {
+	// Saving history state is a best effort operation. Ignore errors and continue
+	if featureCatalog.ProgressManager != nil {
+		state, err := historyIterator.GetState()
+		if err != nil {
+			return
+		}
+		progress.IteratorState = state
+		err = featureCatalog.ProgressManager.RecordProgress(ctx, progress)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (h *historyArchiver) Get(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.GetHistoryRequest,
+) (*archiver.GetHistoryResponse, error) {
+	if err := SoftValidateURI(URI); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidURI.Error())
+	}
+
+	if err := archiver.ValidateGetRequest(request); err != nil {
+		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidGetHistoryRequest.Error())
+	}
+
+	var err error
+	var token *getHistoryToken
+	if request.NextPageToken != nil {
+		token, err = deserializeGetHistoryToken(request.NextPageToken)
+		if err != nil {
+			return nil, serviceerror.NewInvalidArgument(archiver.ErrNextPageTokenCorrupted.Error())
+		}
+	} else if request.CloseFailoverVersion != nil {
+		token = &getHistoryToken{
+			CloseFailoverVersion: *request.CloseFailoverVersion,
+		}
+	} else {
+		highestVersion, err := h.getHighestVersion(ctx, URI, request)
+		if err != nil {
+			if err == archiver.ErrHistoryNotExist {
+				return nil, serviceerror.NewNotFound(err.Error())
+			}
+			if connector.IsRetryableError(err) {
+				return nil, serviceerror.NewUnavailable(err.Error())
+			}
+			return nil, serviceerror.NewInvalidArgument(err.Error())
+		}
+		token = &getHistoryToken{
+			CloseFailoverVersion: *highestVersion,
+		}
+	}
+	encoder := codec.NewJSONPBEncoder()
+	response := &archiver.GetHistoryResponse{}
+	numOfEvents := 0
+	isTruncated := false
+	for {
+		if numOfEvents >= request.PageSize {
+			isTruncated = true
+			break
+		}
+		blobName := constructHistoryBlobName(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID, token.CloseFailoverVersion, token.BatchIdx)
+
+		encodedRecord, err := h.download(ctx, URI, blobName)
+		if err != nil {
+			return nil, err
+		}
+
+		historyBlob := archiverspb.HistoryBlob{}
+		err = encoder.Decode(encodedRecord, &historyBlob)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+
+		for _, batch := range historyBlob.Body {
+			response.HistoryBatches = append(response.HistoryBatches, batch)
+			numOfEvents += len(batch.Events)
+		}
+
+		if historyBlob.Header.IsLast {
+			break
+		}
+		token.BatchIdx++
+	}
+
+	if isTruncated {
+		nextToken, err := serializeToken(token)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.NextPageToken = nextToken
+	}
+
+	return response, nil
+}
+
+func (h *historyArchiver) download(ctx context.Context, URI archiver.URI, blobName string) ([]byte, error) {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+	data, err := h.client.Get(ctx, URI, blobName)
+	if err != nil {
+		switch {
+		case err == connector.ErrContainerNotFound:
+			return nil, serviceerror.NewInvalidArgument(errContainerNotExists.Error())
+		case err == connector.ErrBlobNotFound:
+			return nil, serviceerror.NewNotFound(archiver.ErrHistoryNotExist.Error())
+		case connector.IsRetryableError(err):
+			return nil, serviceerror.NewUnavailable(err.Error())
+		default:
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+	}
+	return data, nil
+}
+
+func (h *historyArchiver) ValidateURI(URI archiver.URI) error {
+	err := SoftValidateURI(URI)
+	if err != nil {
+		return err
+	}
+	return containerExists(context.TODO(), h.client, URI)
+}
+
+func (h *historyArchiver) getHighestVersion(ctx context.Context, URI archiver.URI, request *archiver.GetHistoryRequest) (*int64, error) {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+	var prefix = constructHistoryBlobPrefix(URI.Path(), request.NamespaceID, request.WorkflowID, request.RunID) + "/"
+	prefixes, err := h.client.ListPrefixes(ctx, URI, prefix)
+	if err != nil {
+		if err == connector.ErrContainerNotFound {
+			return nil, errContainerNotExists
+		}
+		return nil, err
+	}
+	var highestVersion *int64
+
+	for _, p := range prefixes {
+		version, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(p, prefix), "/"), 10, 64)
+		if err != nil {
+			continue
+		}
+		if highestVersion == nil || version > *highestVersion {
+			highestVersion = &version
+		}
+	}
+	if highestVersion == nil {
+		return nil, archiver.ErrHistoryNotExist
+	}
+	return highestVersion, nil
+}
+
+func containerExists(ctx context.Context, client connector.Client, URI archiver.URI) error {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+	exists, err := client.ContainerExists(ctx, URI)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errContainerNotExists
+	}
+	return nil
+}
@@ -0,0 +1,724 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package azblobstore
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/azblobstore/connector"
+	"go.temporal.io/server/common/codec"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+)
+
+const (
+	testNamespaceID          = "test-namespace-id"
+	testNamespace            = "test-namespace"
+	testWorkflowID           = "test-workflow-id"
+	testRunID                = "test-run-id"
+	testNextEventID          = 1800
+	testCloseFailoverVersion = int64(100)
+	testPageSize             = 100
+	testContainerURI         = "azblob://test-container"
+)
+
+var testBranchToken = []byte{1, 2, 3}
+
+type historyArchiverSuite struct {
+	*require.Assertions
+	suite.Suite
+	client             *connector.MockClient
+	container          *archiver.HistoryBootstrapContainer
+	testArchivalURI    archiver.URI
+	historyBatchesV1   []*archiverspb.HistoryBlob
+	historyBatchesV100 []*archiverspb.HistoryBlob
+	controller         *gomock.Controller
+}
+
+func TestHistoryArchiverSuite(t *testing.T) {
+	suite.Run(t, new(historyArchiverSuite))
+}
+
+func (s *historyArchiverSuite) SetupSuite() {
+	var err error
+	s.testArchivalURI, err = archiver.NewURI(testContainerURI)
+	s.Require().NoError(err)
+}
+
+func (s *historyArchiverSuite) TearDownSuite() {
+}
+
+func (s *historyArchiverSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.container = &archiver.HistoryBootstrapContainer{
+		Logger:         log.NewNoopLogger(),
+		MetricsHandler: metrics.NoopMetricsHandler,
+	}
+
+	s.controller = gomock.NewController(s.T())
+	s.client = connector.NewMockClient(s.controller)
+	setupFsEmulation(s.client)
+	s.setupHistoryDirectory()
+}
+
+func setupFsEmulation(client *connector.MockClient) {
+	fs := make(map[string][]byte)
+	fsKey := func(URI archiver.URI, blobName string) string {
+		return URI.Hostname() + "/" + blobName
+	}
+	sortedBlobNames := func(URI archiver.URI, prefix string) []string {
+		var names []string
+		for k := range fs {
+			if strings.HasPrefix(k, fsKey(URI, prefix)) {
+				names = append(names, k[len(URI.Hostname())+1:])
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	client.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI archiver.URI, blobName string, data []byte) error {
+			fs[fsKey(URI, blobName)] = data
+			return nil
+		}).AnyTimes()
+
+	client.EXPECT().Exists(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI archiver.URI, blobName string) (bool, error) {
+			_, ok := fs[fsKey(URI, blobName)]
+			return ok, nil
+		}).AnyTimes()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI archiver.URI, blobName string) ([]byte, error) {
+			data, ok := fs[fsKey(URI, blobName)]
+			if !ok {
+				return nil, connector.ErrBlobNotFound
+			}
+			return data, nil
+		}).AnyTimes()
+
+	client.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI archiver.URI, prefix string, maxResults int, marker string) ([]string, string, error) {
+			names := sortedBlobNames(URI, prefix)
+			start := 0
+			if marker != "" {
+				start, _ = strconv.Atoi(marker)
+			}
+			if start >= len(names) {
+				return nil, "", nil
+			}
+			names = names[start:]
+			if maxResults > 0 && len(names) > maxResults {
+				return names[:maxResults], strconv.Itoa(start + maxResults), nil
+			}
+			return names, "", nil
+		}).AnyTimes()
+
+	client.EXPECT().ListPrefixes(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI archiver.URI, prefix string) ([]string, error) {
+			prefixes := map[string]struct{}{}
+			for _, name := range sortedBlobNames(URI, prefix) {
+				if index := strings.Index(name[len(prefix):], "/"); index != -1 {
+					prefixes[name[:len(prefix)+index+1]] = struct{}{}
+				}
+			}
+			var result []string
+			for p := range prefixes {
+				result = append(result, p)
+			}
+			sort.Strings(result)
+			return result, nil
+		}).AnyTimes()
+}
+
+func (s *historyArchiverSuite) TestValidateURI() {
+	testCases := []struct {
+		URI         string
+		expectedErr error
+	}{
+		{
+			URI:         "wrongscheme:///a/b/c",
+			expectedErr: archiver.ErrURISchemeMismatch,
+		},
+		{
+			URI:         "azblob://",
+			expectedErr: errNoContainerSpecified,
+		},
+		{
+			URI:         "azblob://container/a/b/c",
+			expectedErr: errContainerNotExists,
+		},
+		{
+			URI:         testContainerURI,
+			expectedErr: nil,
+		},
+	}
+
+	s.client.EXPECT().ContainerExists(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, URI archiver.URI) (bool, error) {
+			return URI.Hostname() == s.testArchivalURI.Hostname(), nil
+		}).AnyTimes()
+
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	for _, tc := range testCases {
+		URI, err := archiver.NewURI(tc.URI)
+		s.NoError(err)
+		s.Equal(tc.expectedErr, historyArchiver.ValidateURI(URI))
+	}
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_InvalidURI() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	request := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	URI, err := archiver.NewURI("wrongscheme://")
+	s.NoError(err)
+	err = historyArchiver.Archive(context.Background(), URI, request)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_InvalidRequest() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	request := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           "", // an invalid request
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	err := historyArchiver.Archive(context.Background(), s.testArchivalURI, request)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_ErrorOnReadHistory() {
+	historyIterator := archiver.NewMockHistoryIterator(s.controller)
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(nil, errors.New("some random error")),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	request := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	err := historyArchiver.Archive(context.Background(), s.testArchivalURI, request)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_TimeoutWhenReadingHistory() {
+	historyIterator := archiver.NewMockHistoryIterator(s.controller)
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(nil, serviceerror.NewResourceExhausted(enumspb.RESOURCE_EXHAUSTED_CAUSE_RPS_LIMIT, "")),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	request := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	err := historyArchiver.Archive(getCanceledContext(), s.testArchivalURI, request)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_HistoryMutated() {
+	historyIterator := archiver.NewMockHistoryIterator(s.controller)
+	historyBatches := []*historypb.History{
+		{
+			Events: []*historypb.HistoryEvent{
+				{
+					EventId:   common.FirstEventID + 1,
+					EventTime: timestamppb.New(time.Now().UTC()),
+					Version:   testCloseFailoverVersion + 1,
+				},
+			},
+		},
+	}
+	historyBlob := &archiverspb.HistoryBlob{
+		Header: &archiverspb.HistoryBlobHeader{
+			IsLast: true,
+		},
+		Body: historyBatches,
+	}
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(historyBlob, nil),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	request := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	err := historyArchiver.Archive(context.Background(), s.testArchivalURI, request)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Fail_NonRetryableErrorOption() {
+	historyIterator := archiver.NewMockHistoryIterator(s.controller)
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(nil, errors.New("some random error")),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	request := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	nonRetryableErr := errors.New("some non-retryable error")
+	err := historyArchiver.Archive(context.Background(), s.testArchivalURI, request, archiver.GetNonRetryableErrorOption(nonRetryableErr))
+	s.Equal(nonRetryableErr, err)
+}
+
+func (s *historyArchiverSuite) TestArchive_Skip() {
+	historyIterator := archiver.NewMockHistoryIterator(s.controller)
+	historyBlob := &archiverspb.HistoryBlob{
+		Header: &archiverspb.HistoryBlobHeader{
+			IsLast: false,
+		},
+		Body: []*historypb.History{
+			{
+				Events: []*historypb.HistoryEvent{
+					{
+						EventId:   common.FirstEventID,
+						EventTime: timestamppb.New(time.Now().UTC()),
+						Version:   testCloseFailoverVersion,
+					},
+				},
+			},
+		},
+	}
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(historyBlob, nil),
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(nil, serviceerror.NewNotFound("workflow not found")),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	request := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	URI, err := archiver.NewURI(testContainerURI + "/TestArchive_Skip")
+	s.NoError(err)
+	err = historyArchiver.Archive(context.Background(), URI, request)
+	s.NoError(err)
+
+	expectedBlobName := constructHistoryBlobName(URI.Path(), testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion, 0)
+	s.assertBlobExists(URI, expectedBlobName)
+}
+
+func (s *historyArchiverSuite) TestArchive_Success() {
+	historyIterator := archiver.NewMockHistoryIterator(s.controller)
+	historyBatches := []*historypb.History{
+		{
+			Events: []*historypb.HistoryEvent{
+				{
+					EventId:   common.FirstEventID + 1,
+					EventTime: timestamppb.New(time.Now().UTC()),
+					Version:   testCloseFailoverVersion,
+				},
+				{
+					EventId:   common.FirstEventID + 2,
+					EventTime: timestamppb.New(time.Now().UTC()),
+					Version:   testCloseFailoverVersion,
+				},
+			},
+		},
+		{
+			Events: []*historypb.HistoryEvent{
+				{
+					EventId:   testNextEventID - 1,
+					EventTime: timestamppb.New(time.Now().UTC()),
+					Version:   testCloseFailoverVersion,
+				},
+			},
+		},
+	}
+	historyBlob := &archiverspb.HistoryBlob{
+		Header: &archiverspb.HistoryBlobHeader{
+			IsLast: true,
+		},
+		Body: historyBatches,
+	}
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(historyBlob, nil),
+		historyIterator.EXPECT().HasNext().Return(false),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	request := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	URI, err := archiver.NewURI(testContainerURI + "/TestArchive_Success")
+	s.NoError(err)
+	err = historyArchiver.Archive(context.Background(), URI, request)
+	s.NoError(err)
+
+	expectedBlobName := constructHistoryBlobName(URI.Path(), testNamespaceID, testWorkflowID, testRunID, testCloseFailoverVersion, 0)
+	s.assertBlobExists(URI, expectedBlobName)
+}
+
+func (s *historyArchiverSuite) TestGet_Fail_InvalidURI() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	request := &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    100,
+	}
+	URI, err := archiver.NewURI("wrongscheme://")
+	s.NoError(err)
+	response, err := historyArchiver.Get(context.Background(), URI, request)
+	s.Nil(response)
+	s.Error(err)
+}
+
+func (s *historyArchiverSuite) TestGet_Fail_InvalidRequest() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	request := &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    0, // pageSize should be greater than 0
+	}
+	response, err := historyArchiver.Get(context.Background(), s.testArchivalURI, request)
+	s.Nil(response)
+	s.Error(err)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *historyArchiverSuite) TestGet_Fail_InvalidToken() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	request := &archiver.GetHistoryRequest{
+		NamespaceID:   testNamespaceID,
+		WorkflowID:    testWorkflowID,
+		RunID:         testRunID,
+		PageSize:      testPageSize,
+		NextPageToken: []byte{'r', 'a', 'n', 'd', 'o', 'm'},
+	}
+	URI, err := archiver.NewURI(testContainerURI)
+	s.NoError(err)
+	response, err := historyArchiver.Get(context.Background(), URI, request)
+	s.Nil(response)
+	s.Error(err)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *historyArchiverSuite) TestGet_Fail_BlobNotExist() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	testCloseFailoverVersion := testCloseFailoverVersion
+	request := &archiver.GetHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		PageSize:             testPageSize,
+		CloseFailoverVersion: &testCloseFailoverVersion,
+	}
+	URI, err := archiver.NewURI("azblob://test-container/non-existent")
+	s.NoError(err)
+	response, err := historyArchiver.Get(context.Background(), URI, request)
+	s.Nil(response)
+	s.Error(err)
+	s.IsType(&serviceerror.NotFound{}, err)
+}
+
+func (s *historyArchiverSuite) TestGet_Success_PickHighestVersion() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	request := &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    testPageSize,
+	}
+	URI, err := archiver.NewURI(testContainerURI)
+	s.NoError(err)
+	response, err := historyArchiver.Get(context.Background(), URI, request)
+	s.NoError(err)
+	s.Nil(response.NextPageToken)
+	s.Equal(append(s.historyBatchesV100[0].Body, s.historyBatchesV100[1].Body...), response.HistoryBatches)
+}
+
+func (s *historyArchiverSuite) TestGet_Success_UseProvidedVersion() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	testCloseFailoverVersion := int64(1)
+	request := &archiver.GetHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		PageSize:             testPageSize,
+		CloseFailoverVersion: &testCloseFailoverVersion,
+	}
+	URI, err := archiver.NewURI(testContainerURI)
+	s.NoError(err)
+	response, err := historyArchiver.Get(context.Background(), URI, request)
+	s.NoError(err)
+	s.Nil(response.NextPageToken)
+	s.Equal(s.historyBatchesV1[0].Body, response.HistoryBatches)
+}
+
+func (s *historyArchiverSuite) TestGet_Success_SmallPageSize() {
+	historyArchiver := s.newTestHistoryArchiver(nil)
+	testCloseFailoverVersion := testCloseFailoverVersion
+	request := &archiver.GetHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		PageSize:             1,
+		CloseFailoverVersion: &testCloseFailoverVersion,
+	}
+	var combinedHistory []*historypb.History
+
+	URI, err := archiver.NewURI(testContainerURI)
+	s.NoError(err)
+	response, err := historyArchiver.Get(context.Background(), URI, request)
+	s.NoError(err)
+	s.NotNil(response)
+	s.NotNil(response.NextPageToken)
+	s.NotNil(response.HistoryBatches)
+	s.Len(response.HistoryBatches, 1)
+	combinedHistory = append(combinedHistory, response.HistoryBatches...)
+
+	request.NextPageToken = response.NextPageToken
+	response, err = historyArchiver.Get(context.Background(), URI, request)
+	s.NoError(err)
+	s.NotNil(response)
+	s.Nil(response.NextPageToken)
+	s.NotNil(response.HistoryBatches)
+	s.Len(response.HistoryBatches, 1)
+	combinedHistory = append(combinedHistory, response.HistoryBatches...)
+
+	s.Equal(append(s.historyBatchesV100[0].Body, s.historyBatchesV100[1].Body...), combinedHistory)
+}
+
+func (s *historyArchiverSuite) TestGet_EmptyHistory_ReturnsNotFoundError() {
+	historyIterator := archiver.NewMockHistoryIterator(s.controller)
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	URI, err := archiver.NewURI(testContainerURI + "/TestArchiveAndGet")
+	s.NoError(err)
+	getRequest := &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    testPageSize,
+	}
+	response, err := historyArchiver.Get(context.Background(), URI, getRequest)
+	s.Error(err)
+	s.Nil(response)
+	s.IsType(&serviceerror.NotFound{}, err)
+}
+
+func (s *historyArchiverSuite) TestArchiveAndGet() {
+	historyIterator := archiver.NewMockHistoryIterator(s.controller)
+	gomock.InOrder(
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(s.historyBatchesV100[0], nil),
+		historyIterator.EXPECT().HasNext().Return(true),
+		historyIterator.EXPECT().Next(gomock.Any()).Return(s.historyBatchesV100[1], nil),
+		historyIterator.EXPECT().HasNext().Return(false),
+	)
+
+	historyArchiver := s.newTestHistoryArchiver(historyIterator)
+	archiveRequest := &archiver.ArchiveHistoryRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+	}
+	URI, err := archiver.NewURI(testContainerURI + "/TestArchiveAndGet")
+	s.NoError(err)
+	err = historyArchiver.Archive(context.Background(), URI, archiveRequest)
+	s.NoError(err)
+
+	getRequest := &archiver.GetHistoryRequest{
+		NamespaceID: testNamespaceID,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+		PageSize:    testPageSize,
+	}
+	response, err := historyArchiver.Get(context.Background(), URI, getRequest)
+	s.NoError(err)
+	s.NotNil(response)
+	s.Nil(response.NextPageToken)
+	s.Equal(append(s.historyBatchesV100[0].Body, s.historyBatchesV100[1].Body...), response.HistoryBatches)
+}
+
+func (s *historyArchiverSuite) newTestHistoryArchiver(historyIterator archiver.HistoryIterator) *historyArchiver {
+	return newHistoryArchiver(s.container, s.client, historyIterator)
+}
+
+func (s *historyArchiverSuite) setupHistoryDirectory() {
+	now := time.Date(2020, 8, 22, 1, 2, 3, 4, time.UTC)
+
+	s.historyBatchesV1 = []*archiverspb.HistoryBlob{
+		{
+			Header: &archiverspb.HistoryBlobHeader{
+				IsLast: true,
+			},
+			Body: []*historypb.History{
+				{
+					Events: []*historypb.HistoryEvent{
+						{
+							EventId:   testNextEventID - 1,
+							EventTime: timestamppb.New(now),
+							Version:   1,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	s.historyBatchesV100 = []*archiverspb.HistoryBlob{
+		{
+			Header: &archiverspb.HistoryBlobHeader{
+				IsLast: false,
+			},
+			Body: []*historypb.History{
+				{
+					Events: []*historypb.HistoryEvent{
+						{
+							EventId:   common.FirstEventID + 1,
+							EventTime: timestamppb.New(now),
+							Version:   testCloseFailoverVersion,
+						},
+						{
+							EventId:   common.FirstEventID + 1,
+							EventTime: timestamppb.New(now),
+							Version:   testCloseFailoverVersion,
+						},
+					},
+				},
+			},
+		},
+		{
+			Header: &archiverspb.HistoryBlobHeader{
+				IsLast: true,
+			},
+			Body: []*historypb.History{
+				{
+					Events: []*historypb.HistoryEvent{
+						{
+							EventId:   testNextEventID - 1,
+							EventTime: timestamppb.New(now),
+							Version:   testCloseFailoverVersion,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	s.writeHistoryBatchesForGetTest(s.historyBatchesV1, int64(1))
+	s.writeHistoryBatchesForGetTest(s.historyBatchesV100, testCloseFailoverVersion)
+}
+
+func (s *historyArchiverSuite) writeHistoryBatchesForGetTest(historyBatches []*archiverspb.HistoryBlob, version int64) {
+	for i, batch := range historyBatches {
+		encoder := codec.NewJSONPBEncoder()
+		data, err := encoder.Encode(batch)
+		s.Require().NoError(err)
+		blobName := constructHistoryBlobName("", testNamespaceID, testWorkflowID, testRunID, version, i)
+		err = s.client.Upload(context.Background(), s.testArchivalURI, blobName, data)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *historyArchiverSuite) assertBlobExists(URI archiver.URI, blobName string) {
+	exists, err := s.client.Exists(context.Background(), URI, blobName)
+	s.NoError(err)
+	s.True(exists)
+}
+
+func getCanceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
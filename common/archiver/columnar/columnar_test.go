@@ -0,0 +1,309 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package columnar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/testing/protorequire"
+)
+
+const (
+	testNamespaceID = "test-namespace-id"
+)
+
+type memoryStore struct {
+	sync.Mutex
+	files map[string][]byte
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{files: make(map[string][]byte)}
+}
+
+func (s *memoryStore) List(_ context.Context, dir string) ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+	entries := make(map[string]struct{})
+	for name := range s.files {
+		if !strings.HasPrefix(name, dir+"/") {
+			continue
+		}
+		entry, _, _ := strings.Cut(strings.TrimPrefix(name, dir+"/"), "/")
+		entries[entry] = struct{}{}
+	}
+	var result []string
+	for entry := range entries {
+		result = append(result, entry)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (s *memoryStore) Read(_ context.Context, name string) ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+	data, ok := s.files[name]
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+	return data, nil
+}
+
+func (s *memoryStore) Write(_ context.Context, name string, data []byte) error {
+	s.Lock()
+	defer s.Unlock()
+	s.files[name] = data
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, name string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.files, name)
+	return nil
+}
+
+func (s *memoryStore) fileCount() int {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.files)
+}
+
+func newTestRecord(runID string, closeTime time.Time) *archiverspb.VisibilityRecord {
+	return &archiverspb.VisibilityRecord{
+		NamespaceId:      testNamespaceID,
+		Namespace:        "test-namespace",
+		WorkflowId:       "workflow-" + runID,
+		RunId:            runID,
+		WorkflowTypeName: "test-workflow-type",
+		StartTime:        timestamppb.New(closeTime.Add(-time.Minute)),
+		CloseTime:        timestamppb.New(closeTime),
+		Status:           enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		HistoryLength:    10,
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	full := newTestRecord("run-1", time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC))
+	full.ExecutionTime = full.StartTime
+	full.Memo = &commonpb.Memo{Fields: map[string]*commonpb.Payload{"key": payload.EncodeString("value")}}
+	full.SearchAttributes = map[string]string{"CustomKeywordField": `"keyword"`}
+	full.HistoryArchivalUri = "file:///tmp/history"
+	empty := &archiverspb.VisibilityRecord{
+		NamespaceId: testNamespaceID,
+		RunId:       "run-2",
+	}
+
+	data, err := Encode([]*archiverspb.VisibilityRecord{full, empty})
+	require.NoError(t, err)
+	records, err := Decode(data)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	protorequire.ProtoEqual(t, full, records[0])
+	protorequire.ProtoEqual(t, empty, records[1])
+}
+
+func TestWriter_BatchesConcurrentWrites(t *testing.T) {
+	store := newMemoryStore()
+	writer := NewWriter(&config.ParquetVisibilityArchiver{
+		MaxBatchSize:  100,
+		FlushInterval: 100 * time.Millisecond,
+	}, log.NewNoopLogger())
+	day1 := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		closeTime := day1
+		if i%2 == 0 {
+			closeTime = day2
+		}
+		record := newTestRecord(fmt.Sprintf("run-%d", i), closeTime.Add(time.Duration(i)*time.Second))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, writer.Write(context.Background(), "uri", store, record))
+		}()
+	}
+	wg.Wait()
+
+	// one file per close date partition
+	require.Equal(t, 2, store.fileCount())
+	entries, err := store.List(context.Background(), NamespaceDir(testNamespaceID))
+	require.NoError(t, err)
+	require.Equal(t, []string{"close_date=2024-01-01", "close_date=2024-01-02"}, entries)
+}
+
+func TestWriter_FlushOnMaxBatchSize(t *testing.T) {
+	store := newMemoryStore()
+	writer := NewWriter(&config.ParquetVisibilityArchiver{
+		MaxBatchSize:  1,
+		FlushInterval: time.Hour,
+	}, log.NewNoopLogger())
+	closeTime := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, writer.Write(ctx, "uri", store, newTestRecord("run-1", closeTime)))
+	require.NoError(t, writer.Write(ctx, "uri", store, newTestRecord("run-2", closeTime)))
+	require.Equal(t, 2, store.fileCount())
+}
+
+func TestWriter_ContextCanceled(t *testing.T) {
+	writer := NewWriter(&config.ParquetVisibilityArchiver{
+		FlushInterval: time.Hour,
+	}, log.NewNoopLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := writer.Write(ctx, "uri", newMemoryStore(), newTestRecord("run-1", time.Now().UTC()))
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func writeTestRecords(t *testing.T, store Store, records ...*archiverspb.VisibilityRecord) {
+	writer := NewWriter(&config.ParquetVisibilityArchiver{MaxBatchSize: 1}, log.NewNoopLogger())
+	for _, record := range records {
+		require.NoError(t, writer.Write(context.Background(), "uri", store, record))
+	}
+}
+
+func queryAll(t *testing.T, store Store, request *QueryRequest) []string {
+	var runIDs []string
+	for {
+		response, err := Query(context.Background(), store, request)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(response.Records), request.PageSize)
+		for _, record := range response.Records {
+			runIDs = append(runIDs, record.GetRunId())
+		}
+		if len(response.NextPageToken) == 0 {
+			return runIDs
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
+func TestCompact(t *testing.T) {
+	store := newMemoryStore()
+	closeTime := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	var runIDs []string
+	for i := 0; i < 16; i++ {
+		runID := fmt.Sprintf("run-%02d", i)
+		runIDs = append([]string{runID}, runIDs...)
+		data, err := Encode([]*archiverspb.VisibilityRecord{newTestRecord(runID, closeTime.Add(time.Duration(i)*time.Second))})
+		require.NoError(t, err)
+		require.NoError(t, store.Write(ctx, newFileName(testNamespaceID, CloseDate(closeTime), 0), data))
+		require.NoError(t, Compact(ctx, store, testNamespaceID, CloseDate(closeTime), 4))
+		// every 4 files of a level are merged into a file of the next level
+		n := i + 1
+		require.Equal(t, n%4+n/4%4+n/16, store.fileCount())
+		require.Equal(t, runIDs, queryAll(t, store, &QueryRequest{NamespaceID: testNamespaceID, PageSize: 3}))
+	}
+
+	names, err := store.List(ctx, PartitionDir(testNamespaceID, CloseDate(closeTime)))
+	require.NoError(t, err)
+	require.Len(t, names, 1)
+	require.Equal(t, 2, fileLevel(names[0]))
+}
+
+func TestQuery(t *testing.T) {
+	store := newMemoryStore()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	writeTestRecords(t, store,
+		newTestRecord("a", base),
+		newTestRecord("b", base.Add(time.Hour)),
+		newTestRecord("c", base.Add(24*time.Hour)),
+		newTestRecord("d", base.Add(24*time.Hour)),
+		newTestRecord("e", base.Add(72*time.Hour)),
+		// archived twice
+		newTestRecord("c", base.Add(24*time.Hour)),
+	)
+
+	for _, pageSize := range []int{1, 2, 3, 10} {
+		t.Run(fmt.Sprintf("all/%d", pageSize), func(t *testing.T) {
+			runIDs := queryAll(t, store, &QueryRequest{
+				NamespaceID: testNamespaceID,
+				PageSize:    pageSize,
+			})
+			require.Equal(t, []string{"e", "d", "c", "b", "a"}, runIDs)
+		})
+	}
+
+	t.Run("close time range", func(t *testing.T) {
+		runIDs := queryAll(t, store, &QueryRequest{
+			NamespaceID:       testNamespaceID,
+			PageSize:          2,
+			EarliestCloseTime: base.Add(time.Hour),
+			LatestCloseTime:   base.Add(24 * time.Hour),
+		})
+		require.Equal(t, []string{"d", "c", "b"}, runIDs)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		runIDs := queryAll(t, store, &QueryRequest{
+			NamespaceID: testNamespaceID,
+			PageSize:    1,
+			Filter: func(record *archiverspb.VisibilityRecord) bool {
+				return record.GetRunId() != "d"
+			},
+		})
+		require.Equal(t, []string{"e", "c", "b", "a"}, runIDs)
+	})
+
+	t.Run("unknown namespace", func(t *testing.T) {
+		response, err := Query(context.Background(), store, &QueryRequest{
+			NamespaceID: "unknown",
+			PageSize:    1,
+		})
+		require.NoError(t, err)
+		require.Empty(t, response.Records)
+		require.Empty(t, response.NextPageToken)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := Query(context.Background(), store, &QueryRequest{
+			NamespaceID:   testNamespaceID,
+			PageSize:      1,
+			NextPageToken: []byte("invalid"),
+		})
+		require.Equal(t, ErrInvalidNextPageToken, err)
+	})
+}
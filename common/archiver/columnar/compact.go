@@ -0,0 +1,157 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package columnar
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+)
+
+const (
+	defaultCompactionThreshold = 16
+	// maxReadAttempts is how many times a partition is listed again when one of its files is removed by a
+	// compaction while it is being read.
+	maxReadAttempts = 3
+)
+
+// Compact merges the files of a partition level by level: files written by the Writer are at level 0,
+// and once a level holds threshold files they are replaced by a single file of the next level.
+// The number of files of a partition only grows logarithmically with the number of flushes.
+// The merged file is written before the files it replaces are deleted, so a record is never missing from
+// the partition. Concurrent compactions of a partition may write a record twice, Query removes those duplicates.
+func Compact(ctx context.Context, store Store, namespaceID string, closeDate string, threshold int) error {
+	dir := PartitionDir(namespaceID, closeDate)
+	names, err := store.List(ctx, dir)
+	if err != nil {
+		return err
+	}
+	levels := make(map[int][]string)
+	maxLevel := 0
+	for _, name := range names {
+		if !isDataFile(name) {
+			continue
+		}
+		level := fileLevel(name)
+		levels[level] = append(levels[level], baseName(name))
+		maxLevel = max(maxLevel, level)
+	}
+
+	for level := 0; level <= maxLevel; level++ {
+		files := levels[level]
+		if len(files) < threshold {
+			continue
+		}
+		records, err := readFiles(ctx, store, dir, files)
+		if err != nil {
+			return err
+		}
+		data, err := Encode(records)
+		if err != nil {
+			return err
+		}
+		merged := newFileName(namespaceID, closeDate, level+1)
+		if err := store.Write(ctx, merged, data); err != nil {
+			return err
+		}
+		for _, file := range files {
+			if err := store.Delete(ctx, dir+"/"+file); err != nil {
+				return err
+			}
+		}
+		levels[level+1] = append(levels[level+1], baseName(merged))
+		maxLevel = max(maxLevel, level+1)
+	}
+	return nil
+}
+
+// fileLevel returns the compaction level of a data file from its name.
+func fileLevel(name string) int {
+	name = baseName(name)
+	if !strings.HasPrefix(name, compactedFilePrefix) {
+		return 0
+	}
+	prefix, _, _ := strings.Cut(name, "-")
+	level, err := strconv.Atoi(strings.TrimPrefix(prefix, compactedFilePrefix))
+	if err != nil {
+		return 0
+	}
+	return level
+}
+
+// readPartition reads all records of a partition, records written more than once are only returned once.
+func readPartition(ctx context.Context, store Store, dir string) ([]*archiverspb.VisibilityRecord, error) {
+	for attempt := 1; ; attempt++ {
+		names, err := store.List(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		var files []string
+		for _, name := range names {
+			if isDataFile(name) {
+				files = append(files, baseName(name))
+			}
+		}
+		records, err := readFiles(ctx, store, dir, files)
+		if err == ErrFileNotFound && attempt < maxReadAttempts {
+			// the file was replaced by a compaction since the partition was listed
+			continue
+		}
+		return records, err
+	}
+}
+
+// readFiles reads the records of the given files of a partition, records written more than once are only returned once.
+func readFiles(ctx context.Context, store Store, dir string, files []string) ([]*archiverspb.VisibilityRecord, error) {
+	sort.Strings(files)
+	type recordKey struct {
+		runID     string
+		closeTime int64
+	}
+	seen := make(map[recordKey]struct{})
+	var records []*archiverspb.VisibilityRecord
+	for _, file := range files {
+		data, err := store.Read(ctx, dir+"/"+file)
+		if err != nil {
+			return nil, err
+		}
+		fileRecords, err := Decode(data)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range fileRecords {
+			key := recordKey{runID: record.GetRunId(), closeTime: record.CloseTime.AsTime().UnixNano()}
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
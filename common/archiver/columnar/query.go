@@ -0,0 +1,165 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package columnar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+)
+
+// ErrInvalidNextPageToken is returned when the next page token of a QueryRequest can't be parsed.
+var ErrInvalidNextPageToken = errors.New("invalid next page token")
+
+type (
+	// QueryRequest describes the records to return from a namespace.
+	// Records are returned ordered by close time then run ID, both descending.
+	QueryRequest struct {
+		NamespaceID   string
+		PageSize      int
+		NextPageToken []byte
+		// EarliestCloseTime and LatestCloseTime bound the close time of the returned records (inclusive),
+		// zero values mean unbounded. They are used to skip partitions, so set them whenever possible.
+		EarliestCloseTime time.Time
+		LatestCloseTime   time.Time
+		// Filter is an optional predicate the returned records must match.
+		Filter func(*archiverspb.VisibilityRecord) bool
+	}
+
+	// QueryResponse is the response of Query.
+	QueryResponse struct {
+		Records       []*archiverspb.VisibilityRecord
+		NextPageToken []byte
+	}
+
+	queryToken struct {
+		LastCloseTime time.Time
+		LastRunID     string
+	}
+)
+
+// Query reads the partitions covering the requested close time range, newest first, until a page is filled.
+func Query(ctx context.Context, store Store, request *QueryRequest) (*QueryResponse, error) {
+	var token *queryToken
+	if len(request.NextPageToken) != 0 {
+		token = &queryToken{}
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, ErrInvalidNextPageToken
+		}
+	}
+
+	entries, err := store.List(ctx, NamespaceDir(request.NamespaceID))
+	if err != nil {
+		return nil, err
+	}
+	var closeDates []time.Time
+	for _, entry := range entries {
+		if closeDate, ok := parseCloseDate(entry); ok && request.partitionInRange(closeDate, token) {
+			closeDates = append(closeDates, closeDate)
+		}
+	}
+	sort.Slice(closeDates, func(i, j int) bool {
+		return closeDates[i].After(closeDates[j])
+	})
+
+	response := &QueryResponse{}
+	for i, closeDate := range closeDates {
+		records, err := readPartition(ctx, store, PartitionDir(request.NamespaceID, closeDate.Format(closeDateFormat)))
+		if err != nil {
+			return nil, err
+		}
+		records = request.filter(records, token)
+		remaining := request.PageSize - len(response.Records)
+		if len(records) < remaining {
+			response.Records = append(response.Records, records...)
+			continue
+		}
+
+		response.Records = append(response.Records, records[:remaining]...)
+		if len(records) > remaining || i < len(closeDates)-1 {
+			last := response.Records[len(response.Records)-1]
+			if response.NextPageToken, err = json.Marshal(&queryToken{
+				LastCloseTime: last.CloseTime.AsTime(),
+				LastRunID:     last.GetRunId(),
+			}); err != nil {
+				return nil, err
+			}
+		}
+		break
+	}
+	return response, nil
+}
+
+func (r *QueryRequest) partitionInRange(closeDate time.Time, token *queryToken) bool {
+	// closeDate is the start of the day the partition covers
+	if !r.EarliestCloseTime.IsZero() && !closeDate.After(r.EarliestCloseTime.Add(-24*time.Hour)) {
+		return false
+	}
+	if !r.LatestCloseTime.IsZero() && closeDate.After(r.LatestCloseTime) {
+		return false
+	}
+	if token != nil && closeDate.After(token.LastCloseTime) {
+		return false
+	}
+	return true
+}
+
+// filter removes the records not matching the request, or returned by a previous page, and sorts the remaining ones.
+func (r *QueryRequest) filter(records []*archiverspb.VisibilityRecord, token *queryToken) []*archiverspb.VisibilityRecord {
+	filtered := records[:0]
+	for _, record := range records {
+		closeTime := record.CloseTime.AsTime()
+		if !r.EarliestCloseTime.IsZero() && closeTime.Before(r.EarliestCloseTime) {
+			continue
+		}
+		if !r.LatestCloseTime.IsZero() && closeTime.After(r.LatestCloseTime) {
+			continue
+		}
+		if token != nil && !closedBefore(closeTime, record.GetRunId(), token.LastCloseTime, token.LastRunID) {
+			continue
+		}
+		if r.Filter != nil && !r.Filter(record) {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return closedBefore(filtered[j].CloseTime.AsTime(), filtered[j].GetRunId(), filtered[i].CloseTime.AsTime(), filtered[i].GetRunId())
+	})
+	return filtered
+}
+
+// closedBefore returns true if the first record is returned after the other one:
+// it closed earlier, or closed at the same time and has a smaller run ID.
+func closedBefore(closeTime time.Time, runID string, otherCloseTime time.Time, otherRunID string) bool {
+	if closeTime.Equal(otherCloseTime) {
+		return runID < otherRunID
+	}
+	return closeTime.Before(otherCloseTime)
+}
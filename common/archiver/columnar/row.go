@@ -0,0 +1,186 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package columnar implements a columnar (Parquet) layout for archived visibility records.
+// Records are batched into Parquet files partitioned by namespace and close date:
+//
+//	<root>/namespace_id=<namespace-id>/close_date=<YYYY-MM-DD>/part-<unix-nano>-<uuid>.parquet
+//
+// Files of a partition are merged into level<level>-<unix-nano>-<uuid>.parquet files by compactions.
+// The layout follows the hive partitioning convention, so the files can be read directly
+// by analytics tools, while Query only needs to read the partitions covering the requested close time range.
+package columnar
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress/zstd"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+)
+
+type (
+	// row is the parquet schema of an archived visibility record.
+	// Timestamps are stored as nanoseconds since epoch, unset timestamps are stored as null.
+	// Memo is the serialized commonpb.Memo, empty when unset.
+	row struct {
+		NamespaceID        string            `parquet:"namespace_id,dict"`
+		Namespace          string            `parquet:"namespace,dict"`
+		WorkflowID         string            `parquet:"workflow_id"`
+		RunID              string            `parquet:"run_id"`
+		WorkflowTypeName   string            `parquet:"workflow_type_name,dict"`
+		StartTime          int64             `parquet:"start_time,optional,timestamp(nanosecond)"`
+		ExecutionTime      int64             `parquet:"execution_time,optional,timestamp(nanosecond)"`
+		CloseTime          int64             `parquet:"close_time,optional,timestamp(nanosecond)"`
+		Status             string            `parquet:"status,dict"`
+		HistoryLength      int64             `parquet:"history_length"`
+		Memo               []byte            `parquet:"memo"`
+		SearchAttributes   map[string]string `parquet:"search_attributes"`
+		HistoryArchivalURI *string           `parquet:"history_archival_uri,dict"`
+	}
+)
+
+// Encode writes visibility records to a zstd compressed parquet file.
+func Encode(records []*archiverspb.VisibilityRecord) ([]byte, error) {
+	rows := make([]row, 0, len(records))
+	for _, record := range records {
+		r, err := toRow(record)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r)
+	}
+	var buf bytes.Buffer
+	if err := parquet.Write(&buf, rows, parquet.Compression(&zstd.Codec{})); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode reads all visibility records of a parquet file written by Encode.
+func Decode(data []byte) ([]*archiverspb.VisibilityRecord, error) {
+	rows, err := parquet.Read[row](bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*archiverspb.VisibilityRecord, 0, len(rows))
+	for i := range rows {
+		record, err := fromRow(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func toRow(record *archiverspb.VisibilityRecord) (row, error) {
+	var memo []byte
+	if record.Memo != nil {
+		var err error
+		if memo, err = proto.Marshal(record.Memo); err != nil {
+			return row{}, err
+		}
+	}
+	var historyArchivalURI *string
+	if uri := record.GetHistoryArchivalUri(); uri != "" {
+		historyArchivalURI = &uri
+	}
+	return row{
+		NamespaceID:        record.GetNamespaceId(),
+		Namespace:          record.GetNamespace(),
+		WorkflowID:         record.GetWorkflowId(),
+		RunID:              record.GetRunId(),
+		WorkflowTypeName:   record.GetWorkflowTypeName(),
+		StartTime:          toUnixNano(record.StartTime),
+		ExecutionTime:      toUnixNano(record.ExecutionTime),
+		CloseTime:          toUnixNano(record.CloseTime),
+		Status:             record.GetStatus().String(),
+		HistoryLength:      record.GetHistoryLength(),
+		Memo:               memo,
+		SearchAttributes:   record.GetSearchAttributes(),
+		HistoryArchivalURI: historyArchivalURI,
+	}, nil
+}
+
+func fromRow(r *row) (*archiverspb.VisibilityRecord, error) {
+	var memo *commonpb.Memo
+	if len(r.Memo) > 0 {
+		memo = &commonpb.Memo{}
+		if err := proto.Unmarshal(r.Memo, memo); err != nil {
+			return nil, err
+		}
+	}
+	status, err := enumspb.WorkflowExecutionStatusFromString(r.Status)
+	if err != nil {
+		return nil, err
+	}
+	var searchAttributes map[string]string
+	if len(r.SearchAttributes) > 0 {
+		searchAttributes = r.SearchAttributes
+	}
+	return &archiverspb.VisibilityRecord{
+		NamespaceId:        r.NamespaceID,
+		Namespace:          r.Namespace,
+		WorkflowId:         r.WorkflowID,
+		RunId:              r.RunID,
+		WorkflowTypeName:   r.WorkflowTypeName,
+		StartTime:          fromUnixNano(r.StartTime),
+		ExecutionTime:      fromUnixNano(r.ExecutionTime),
+		CloseTime:          fromUnixNano(r.CloseTime),
+		Status:             status,
+		HistoryLength:      r.HistoryLength,
+		Memo:               memo,
+		SearchAttributes:   searchAttributes,
+		HistoryArchivalUri: fromOptionalString(r.HistoryArchivalURI),
+	}, nil
+}
+
+// toUnixNano returns 0 for an unset timestamp, which the optional column stores as null.
+func toUnixNano(t *timestamppb.Timestamp) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.AsTime().UnixNano()
+}
+
+func fromUnixNano(nanos int64) *timestamppb.Timestamp {
+	if nanos == 0 {
+		return nil
+	}
+	return timestamppb.New(time.Unix(0, nanos).UTC())
+}
+
+func fromOptionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,116 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package columnar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	closeDateFormat = "2006-01-02"
+
+	namespacePartitionKey = "namespace_id="
+	closeDatePartitionKey = "close_date="
+	fileExtension         = ".parquet"
+	// files written by the Writer are named part-<unix-nano>-<uuid>.parquet,
+	// files merged by a compaction are named level<level>-<unix-nano>-<uuid>.parquet
+	writtenFilePrefix   = "part-"
+	compactedFilePrefix = "level"
+)
+
+// ErrFileNotFound is returned by Store.Read when the file doesn't exist.
+var ErrFileNotFound = errors.New("file not found")
+
+type (
+	// Store is the blob storage the parquet files are written to.
+	// Implementations are scoped to the archival URI, file names are relative to it.
+	Store interface {
+		// List returns the names of the files and directories directly under dir, either relative to dir or
+		// as full names. A directory that doesn't exist must be returned as empty.
+		List(ctx context.Context, dir string) ([]string, error)
+		// Read returns ErrFileNotFound when the file doesn't exist, which happens when it is
+		// replaced by a compaction after being listed.
+		Read(ctx context.Context, name string) ([]byte, error)
+		Write(ctx context.Context, name string, data []byte) error
+		// Delete deletes a file, deleting a file that doesn't exist is not an error.
+		Delete(ctx context.Context, name string) error
+	}
+)
+
+// NamespaceDir returns the directory holding all partitions of a namespace.
+func NamespaceDir(namespaceID string) string {
+	return namespacePartitionKey + namespaceID
+}
+
+// PartitionDir returns the directory holding the files of a namespace and close date.
+func PartitionDir(namespaceID string, closeDate string) string {
+	return NamespaceDir(namespaceID) + "/" + closeDatePartitionKey + closeDate
+}
+
+// CloseDate returns the partition a record closed at closeTime belongs to.
+func CloseDate(closeTime time.Time) string {
+	return closeTime.UTC().Format(closeDateFormat)
+}
+
+func newFileName(namespaceID string, closeDate string, level int) string {
+	prefix := writtenFilePrefix
+	if level > 0 {
+		prefix = fmt.Sprintf("%s%d-", compactedFilePrefix, level)
+	}
+	return fmt.Sprintf("%s/%s%d-%s%s", PartitionDir(namespaceID, closeDate), prefix, time.Now().UnixNano(), uuid.NewString(), fileExtension)
+}
+
+// parseCloseDate extracts the close date of a partition directory entry, entries that are not partitions are skipped.
+func parseCloseDate(entry string) (time.Time, bool) {
+	entry = strings.TrimSuffix(entry, "/")
+	if i := strings.LastIndex(entry, "/"); i != -1 {
+		entry = entry[i+1:]
+	}
+	if !strings.HasPrefix(entry, closeDatePartitionKey) {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(closeDateFormat, strings.TrimPrefix(entry, closeDatePartitionKey))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func isDataFile(name string) bool {
+	return strings.HasSuffix(name, fileExtension)
+}
+
+func baseName(name string) string {
+	if i := strings.LastIndex(name, "/"); i != -1 {
+		return name[i+1:]
+	}
+	return name
+}
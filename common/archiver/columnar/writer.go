@@ -0,0 +1,185 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package columnar
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+)
+
+const (
+	defaultMaxBatchSize  = 1000
+	defaultFlushInterval = time.Second
+	flushTimeout         = time.Minute
+)
+
+type (
+	// Writer batches visibility records into parquet files.
+	// Concurrent Write calls for the same store, namespace and close date are grouped into a single file.
+	// Write only returns once the file holding the record is written, so no record is lost on restart,
+	// however a record may be written more than once when Write is retried; Query removes those duplicates.
+	// The partition of a batch is compacted after the batch is written, see Compact.
+	Writer struct {
+		maxBatchSize        int
+		flushInterval       time.Duration
+		compactionThreshold int
+		logger              log.Logger
+
+		sync.Mutex
+		batches    map[batchKey]*batch
+		compacting map[batchKey]struct{}
+	}
+
+	batchKey struct {
+		storeID     string
+		namespaceID string
+		closeDate   string
+	}
+
+	batch struct {
+		key     batchKey
+		store   Store
+		records []*archiverspb.VisibilityRecord
+		timer   *time.Timer
+		done    chan struct{}
+		err     error
+	}
+)
+
+// NewWriter creates a new Writer, zero config values are replaced by defaults.
+func NewWriter(cfg *config.ParquetVisibilityArchiver, logger log.Logger) *Writer {
+	w := &Writer{
+		maxBatchSize:        cfg.MaxBatchSize,
+		flushInterval:       cfg.FlushInterval,
+		compactionThreshold: cfg.CompactionThreshold,
+		logger:              logger,
+		batches:             make(map[batchKey]*batch),
+		compacting:          make(map[batchKey]struct{}),
+	}
+	if w.maxBatchSize <= 0 {
+		w.maxBatchSize = defaultMaxBatchSize
+	}
+	if w.flushInterval <= 0 {
+		w.flushInterval = defaultFlushInterval
+	}
+	if w.compactionThreshold <= 1 {
+		w.compactionThreshold = defaultCompactionThreshold
+	}
+	return w
+}
+
+// Write adds the record to the pending batch of its partition and waits for the batch to be written.
+// storeID identifies the store, usually the archival URI, records of different stores are never batched together.
+func (w *Writer) Write(ctx context.Context, storeID string, store Store, record *archiverspb.VisibilityRecord) error {
+	key := batchKey{
+		storeID:     storeID,
+		namespaceID: record.GetNamespaceId(),
+		closeDate:   CloseDate(record.CloseTime.AsTime()),
+	}
+
+	w.Lock()
+	b, ok := w.batches[key]
+	if !ok {
+		b = &batch{
+			key:   key,
+			store: store,
+			done:  make(chan struct{}),
+		}
+		w.batches[key] = b
+		b.timer = time.AfterFunc(w.flushInterval, func() { w.flush(b) })
+	}
+	b.records = append(b.records, record)
+	full := len(b.records) >= w.maxBatchSize
+	w.Unlock()
+
+	if full {
+		w.flush(b)
+	}
+
+	select {
+	case <-b.done:
+		return b.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Writer) flush(b *batch) {
+	w.Lock()
+	if w.batches[b.key] != b {
+		// already flushed
+		w.Unlock()
+		return
+	}
+	delete(w.batches, b.key)
+	b.timer.Stop()
+	w.Unlock()
+
+	b.err = w.write(b)
+	close(b.done)
+	if b.err == nil {
+		go w.compact(b.key, b.store)
+	}
+}
+
+func (w *Writer) write(b *batch) error {
+	data, err := Encode(b.records)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	return b.store.Write(ctx, newFileName(b.key.namespaceID, b.key.closeDate, 0), data)
+}
+
+// compact compacts the partition of a batch, unless it is already being compacted.
+func (w *Writer) compact(key batchKey, store Store) {
+	w.Lock()
+	if _, ok := w.compacting[key]; ok {
+		w.Unlock()
+		return
+	}
+	w.compacting[key] = struct{}{}
+	w.Unlock()
+	defer func() {
+		w.Lock()
+		delete(w.compacting, key)
+		w.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	if err := Compact(ctx, store, key.namespaceID, key.closeDate, w.compactionThreshold); err != nil {
+		// the partition is compacted again after the next flush
+		w.logger.Warn("Failed to compact archived visibility records",
+			tag.ArchivalRequestNamespaceID(key.namespaceID), tag.Error(err))
+	}
+}
@@ -0,0 +1,81 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"context"
+	"os"
+	"path"
+
+	"go.temporal.io/server/common/archiver/columnar"
+)
+
+type (
+	// parquetStore is the columnar.Store of the directory pointed by an archival URI
+	parquetStore struct {
+		root     string
+		fileMode os.FileMode
+		dirMode  os.FileMode
+	}
+)
+
+var _ columnar.Store = (*parquetStore)(nil)
+
+func (s *parquetStore) List(_ context.Context, dir string) ([]string, error) {
+	dirPath := path.Join(s.root, dir)
+	exists, err := directoryExists(dirPath)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return listFiles(dirPath)
+}
+
+func (s *parquetStore) Read(_ context.Context, name string) ([]byte, error) {
+	data, err := readFile(path.Join(s.root, name))
+	if os.IsNotExist(err) {
+		return nil, columnar.ErrFileNotFound
+	}
+	return data, err
+}
+
+// Write writes the file under a temporary name first, so that queries never read a partially written file.
+func (s *parquetStore) Write(_ context.Context, name string, data []byte) error {
+	filepath := path.Join(s.root, name)
+	if err := mkdirAll(path.Dir(filepath), s.dirMode); err != nil {
+		return err
+	}
+	tmpFilepath := filepath + ".tmp"
+	if err := writeFile(tmpFilepath, data, s.fileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilepath, filepath)
+}
+
+func (s *parquetStore) Delete(_ context.Context, name string) error {
+	if err := os.Remove(path.Join(s.root, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -31,7 +31,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	enumspb "go.temporal.io/api/enums/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/common/util"
 )
 
@@ -113,8 +116,9 @@ func (s *queryParserSuite) TestParseWorkflowID_RunID_WorkflowType() {
 			expectErr: true,
 		},
 		{
-			query:     "WorkflowId = \"random workflowID\" or WorkflowId = \"another workflowID\"",
-			expectErr: true,
+			query:       "WorkflowId = \"random workflowID\" or WorkflowId = \"another workflowID\"",
+			expectErr:   false,
+			parsedQuery: &parsedQuery{},
 		},
 		{
 			query:     "WorkflowId = \"random workflowID\" or runId = \"random runID\"",
@@ -131,7 +135,7 @@ func (s *queryParserSuite) TestParseWorkflowID_RunID_WorkflowType() {
 	}
 
 	for _, tc := range testCases {
-		parsedQuery, err := s.parser.Parse(tc.query)
+		parsedQuery, err := s.parser.Parse(tc.query, searchattribute.TestNameTypeMap)
 		if tc.expectErr {
 			s.Error(err)
 			continue
@@ -213,15 +217,21 @@ func (s *queryParserSuite) TestParseCloseStatus() {
 			expectErr: true,
 		},
 		{
-			query:     "ExecutionStatus = \"Failed\" or ExecutionStatus = \"Failed\"",
-			expectErr: true,
+			query:       "ExecutionStatus = \"Failed\" or ExecutionStatus = \"Failed\"",
+			expectErr:   false,
+			parsedQuery: &parsedQuery{},
 		},
 		{
 			query:     "ExecutionStatus = \"unknown\"",
 			expectErr: true,
 		},
 		{
-			query:     "ExecutionStatus > \"Failed\"",
+			query:       "ExecutionStatus > \"Failed\"",
+			expectErr:   false,
+			parsedQuery: &parsedQuery{},
+		},
+		{
+			query:     "ExecutionStatus = 100",
 			expectErr: true,
 		},
 		{
@@ -238,7 +248,7 @@ func (s *queryParserSuite) TestParseCloseStatus() {
 	}
 
 	for _, tc := range testCases {
-		parsedQuery, err := s.parser.Parse(tc.query)
+		parsedQuery, err := s.parser.Parse(tc.query, searchattribute.TestNameTypeMap)
 		if tc.expectErr {
 			s.Error(err)
 			continue
@@ -304,7 +314,7 @@ func (s *queryParserSuite) TestParseCloseTime() {
 	}
 
 	for i, tc := range testCases {
-		parsedQuery, err := s.parser.Parse(tc.query)
+		parsedQuery, err := s.parser.Parse(tc.query, searchattribute.TestNameTypeMap)
 		if tc.expectErr {
 			s.Error(err)
 			continue
@@ -353,7 +363,7 @@ func (s *queryParserSuite) TestParse() {
 	}
 
 	for i, tc := range testCases {
-		parsedQuery, err := s.parser.Parse(tc.query)
+		parsedQuery, err := s.parser.Parse(tc.query, searchattribute.TestNameTypeMap)
 		if tc.expectErr {
 			s.Error(err)
 			continue
@@ -361,7 +371,42 @@ func (s *queryParserSuite) TestParse() {
 		s.NoError(err, "case %d", i)
 		s.Equal(tc.parsedQuery.emptyResult, parsedQuery.emptyResult, "case %d", i)
 		if !tc.parsedQuery.emptyResult {
+			s.NotNil(parsedQuery.query, "case %d", i)
+			parsedQuery.query = nil
 			s.Equal(tc.parsedQuery, parsedQuery, "case %d", i)
 		}
 	}
 }
+
+func (s *queryParserSuite) TestParse_FullQuery() {
+	record := &archiverspb.VisibilityRecord{
+		WorkflowId:       "random workflowID",
+		RunId:            "random runID",
+		WorkflowTypeName: "random typeName",
+		CloseTime:        timestamppb.New(time.Unix(0, 2000)),
+		Status:           enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+		SearchAttributes: map[string]string{
+			"CustomKeywordField": "keyword value",
+			"CustomIntField":     "10",
+		},
+	}
+	testCases := []struct {
+		query   string
+		matches bool
+	}{
+		{query: "WorkflowId = 'random workflowID' or WorkflowId = 'another workflowID'", matches: true},
+		{query: "WorkflowId = 'random workflowID' and WorkflowId = 'another workflowID'", matches: false},
+		{query: "WorkflowType IN ('random typeName', 'another typeName')", matches: true},
+		{query: "RunId STARTS_WITH 'random'", matches: true},
+		{query: "CloseTime BETWEEN 1000 AND 3000 and ExecutionStatus != 'Completed'", matches: true},
+		{query: "CustomKeywordField = 'keyword value' and CustomIntField > 5", matches: true},
+		{query: "CustomKeywordField = 'keyword value' and CustomIntField > 10", matches: false},
+		{query: "CustomDoubleField IS NULL ORDER BY CloseTime DESC", matches: true},
+	}
+
+	for _, tc := range testCases {
+		parsedQuery, err := s.parser.Parse(tc.query, searchattribute.TestNameTypeMap)
+		s.NoError(err, tc.query)
+		s.Equal(tc.matches, matchQuery(record, parsedQuery), tc.query)
+	}
+}
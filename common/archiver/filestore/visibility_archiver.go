@@ -40,6 +40,8 @@ import (
 
 	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/columnar"
+	"go.temporal.io/server/common/archiver/visibilityquery"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/primitives/timestamp"
@@ -56,6 +58,8 @@ type (
 		fileMode    os.FileMode
 		dirMode     os.FileMode
 		queryParser QueryParser
+		// parquetWriter is only set when the columnar layout is enabled
+		parquetWriter *columnar.Writer
 	}
 
 	queryVisibilityToken struct {
@@ -84,11 +88,16 @@ func NewVisibilityArchiver(
 	if err != nil {
 		return nil, errInvalidDirMode
 	}
+	var parquetWriter *columnar.Writer
+	if config.Parquet != nil {
+		parquetWriter = columnar.NewWriter(config.Parquet, container.Logger)
+	}
 	return &visibilityArchiver{
-		container:   container,
-		fileMode:    os.FileMode(fileMode),
-		dirMode:     os.FileMode(dirMode),
-		queryParser: NewQueryParser(),
+		container:     container,
+		fileMode:      os.FileMode(fileMode),
+		dirMode:       os.FileMode(dirMode),
+		queryParser:   NewQueryParser(),
+		parquetWriter: parquetWriter,
 	}, nil
 }
 
@@ -117,6 +126,14 @@ func (v *visibilityArchiver) Archive(
 		return err
 	}
 
+	if v.parquetWriter != nil {
+		if err := v.parquetWriter.Write(ctx, URI.String(), v.parquetStore(URI), request); err != nil {
+			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errWriteFile), tag.Error(err))
+			return err
+		}
+		return nil
+	}
+
 	dirPath := path.Join(URI.Path(), request.GetNamespaceId())
 	if err = mkdirAll(dirPath, v.dirMode); err != nil {
 		logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(errMakeDirectory), tag.Error(err))
@@ -154,7 +171,7 @@ func (v *visibilityArchiver) Query(
 		return nil, serviceerror.NewInvalidArgument(archiver.ErrInvalidQueryVisibilityRequest.Error())
 	}
 
-	parsedQuery, err := v.queryParser.Parse(request.Query)
+	parsedQuery, err := v.queryParser.Parse(request.Query, saTypeMap)
 	if err != nil {
 		return nil, serviceerror.NewInvalidArgument(err.Error())
 	}
@@ -163,16 +180,36 @@ func (v *visibilityArchiver) Query(
 		return &archiver.QueryVisibilityResponse{}, nil
 	}
 
-	return v.query(
-		ctx,
-		URI,
-		&queryVisibilityRequest{
-			namespaceID:   request.NamespaceID,
-			pageSize:      request.PageSize,
-			nextPageToken: request.NextPageToken,
-			parsedQuery:   parsedQuery,
+	if parsedQuery.query != nil && parsedQuery.query.HasOrderBy() {
+		return v.querySorted(ctx, URI, request, parsedQuery, saTypeMap)
+	}
+
+	queryLegacy := func(pageSize int, nextPageToken []byte) (*archiver.QueryVisibilityResponse, error) {
+		return v.query(
+			ctx,
+			URI,
+			&queryVisibilityRequest{
+				namespaceID:   request.NamespaceID,
+				pageSize:      pageSize,
+				nextPageToken: nextPageToken,
+				parsedQuery:   parsedQuery,
+			},
+			saTypeMap,
+		)
+	}
+	if v.parquetWriter == nil {
+		return queryLegacy(request.PageSize, request.NextPageToken)
+	}
+
+	// Records archived before the columnar layout was enabled are kept in the legacy layout,
+	// they closed before the records of the columnar layout so they are returned last.
+	return archiver.QueryVisibilityLayouts(
+		request.PageSize,
+		request.NextPageToken,
+		func(pageSize int, nextPageToken []byte) (*archiver.QueryVisibilityResponse, error) {
+			return v.queryParquet(ctx, URI, request.NamespaceID, pageSize, nextPageToken, parsedQuery, saTypeMap)
 		},
-		saTypeMap,
+		queryLegacy,
 	)
 }
 
@@ -256,6 +293,154 @@ func (v *visibilityArchiver) query(
 	return response, nil
 }
 
+// querySorted reads all records matching the query to return them in the order of its ORDER BY clause.
+func (v *visibilityArchiver) querySorted(
+	ctx context.Context,
+	URI archiver.URI,
+	request *archiver.QueryVisibilityRequest,
+	parsedQuery *parsedQuery,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	// one record more than the limit is read to detect that the query matches too many records
+	var records []*archiverspb.VisibilityRecord
+	if v.parquetWriter != nil {
+		result, err := columnar.Query(ctx, v.parquetStore(URI), &columnar.QueryRequest{
+			NamespaceID:       request.NamespaceID,
+			PageSize:          visibilityquery.MaxSortedRecords + 1,
+			EarliestCloseTime: parsedQuery.earliestCloseTime,
+			LatestCloseTime:   parsedQuery.latestCloseTime,
+			Filter: func(record *archiverspb.VisibilityRecord) bool {
+				return matchQuery(record, parsedQuery)
+			},
+		})
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		records = result.Records
+	}
+	legacyRecords, err := v.readMatchingRecords(URI, request.NamespaceID, parsedQuery, visibilityquery.MaxSortedRecords+1-len(records))
+	if err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	records = append(records, legacyRecords...)
+
+	page, nextPageToken, err := parsedQuery.query.SortedPage(records, request.PageSize, request.NextPageToken)
+	if err != nil {
+		if err == visibilityquery.ErrInvalidNextPageToken {
+			return nil, serviceerror.NewInvalidArgument(archiver.ErrNextPageTokenCorrupted.Error())
+		}
+		if err == visibilityquery.ErrTooManySortedRecords {
+			return nil, serviceerror.NewInvalidArgument(err.Error())
+		}
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	response := &archiver.QueryVisibilityResponse{
+		NextPageToken: nextPageToken,
+	}
+	for _, record := range page {
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.Executions = append(response.Executions, executionInfo)
+	}
+	return response, nil
+}
+
+// readMatchingRecords reads at most limit records of a namespace in the legacy layout matching the query.
+func (v *visibilityArchiver) readMatchingRecords(
+	URI archiver.URI,
+	namespaceID string,
+	parsedQuery *parsedQuery,
+	limit int,
+) ([]*archiverspb.VisibilityRecord, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	dirPath := path.Join(URI.Path(), namespaceID)
+	exists, err := directoryExists(dirPath)
+	if err != nil || !exists {
+		return nil, err
+	}
+	files, err := listFiles(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	files, err = sortAndFilterFiles(files, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*archiverspb.VisibilityRecord
+	for _, file := range files {
+		encodedRecord, err := readFile(path.Join(dirPath, file))
+		if err != nil {
+			return nil, err
+		}
+		record, err := decodeVisibilityRecord(encodedRecord)
+		if err != nil {
+			return nil, err
+		}
+		if record.CloseTime.AsTime().Before(parsedQuery.earliestCloseTime) {
+			break
+		}
+		if matchQuery(record, parsedQuery) {
+			records = append(records, record)
+			if len(records) == limit {
+				break
+			}
+		}
+	}
+	return records, nil
+}
+
+func (v *visibilityArchiver) queryParquet(
+	ctx context.Context,
+	URI archiver.URI,
+	namespaceID string,
+	pageSize int,
+	nextPageToken []byte,
+	parsedQuery *parsedQuery,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	result, err := columnar.Query(ctx, v.parquetStore(URI), &columnar.QueryRequest{
+		NamespaceID:       namespaceID,
+		PageSize:          pageSize,
+		NextPageToken:     nextPageToken,
+		EarliestCloseTime: parsedQuery.earliestCloseTime,
+		LatestCloseTime:   parsedQuery.latestCloseTime,
+		Filter: func(record *archiverspb.VisibilityRecord) bool {
+			return matchQuery(record, parsedQuery)
+		},
+	})
+	if err != nil {
+		if err == columnar.ErrInvalidNextPageToken {
+			return nil, serviceerror.NewInvalidArgument(archiver.ErrNextPageTokenCorrupted.Error())
+		}
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+
+	response := &archiver.QueryVisibilityResponse{
+		NextPageToken: result.NextPageToken,
+	}
+	for _, record := range result.Records {
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.Executions = append(response.Executions, executionInfo)
+	}
+	return response, nil
+}
+
+func (v *visibilityArchiver) parquetStore(URI archiver.URI) *parquetStore {
+	return &parquetStore{
+		root:     URI.Path(),
+		fileMode: v.fileMode,
+		dirMode:  v.dirMode,
+	}
+}
+
 func (v *visibilityArchiver) ValidateURI(URI archiver.URI) error {
 	if URI.Scheme() != URIScheme {
 		return archiver.ErrURISchemeMismatch
@@ -339,7 +524,7 @@ func matchQuery(record *archiverspb.VisibilityRecord, query *parsedQuery) bool {
 	if query.status != nil && record.Status != *query.status {
 		return false
 	}
-	return true
+	return query.query == nil || query.query.Match(record)
 }
 
 func convertToExecutionInfo(record *archiverspb.VisibilityRecord, saTypeMap searchattribute.NameTypeMap) (*workflowpb.WorkflowExecutionInfo, error) {
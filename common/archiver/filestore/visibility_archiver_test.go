@@ -29,6 +29,7 @@ import (
 	"errors"
 	"os"
 	"path"
+	"sync"
 	"testing"
 	"time"
 
@@ -43,12 +44,14 @@ import (
 
 	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/columnar"
 	"go.temporal.io/server/common/codec"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/common/testing/protorequire"
 	"go.temporal.io/server/common/util"
 	"go.temporal.io/server/tests/testutils"
 )
@@ -356,7 +359,7 @@ func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidRequest() {
 func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidQuery() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
 	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(nil, errors.New("invalid query"))
+	mockParser.EXPECT().Parse(gomock.Any(), gomock.Any()).Return(nil, errors.New("invalid query"))
 	visibilityArchiver.queryParser = mockParser
 	response, err := visibilityArchiver.Query(context.Background(), s.testArchivalURI, &archiver.QueryVisibilityRequest{
 		NamespaceID: "some random namespaceID",
@@ -370,7 +373,7 @@ func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidQuery() {
 func (s *visibilityArchiverSuite) TestQuery_Success_DirectoryNotExist() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
 	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
+	mockParser.EXPECT().Parse(gomock.Any(), gomock.Any()).Return(&parsedQuery{
 		earliestCloseTime: time.Unix(0, 1),
 		latestCloseTime:   time.Unix(0, 101),
 	}, nil)
@@ -390,7 +393,7 @@ func (s *visibilityArchiverSuite) TestQuery_Success_DirectoryNotExist() {
 func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidToken() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
 	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
+	mockParser.EXPECT().Parse(gomock.Any(), gomock.Any()).Return(&parsedQuery{
 		earliestCloseTime: time.Unix(0, 1),
 		latestCloseTime:   time.Unix(0, 101),
 	}, nil)
@@ -409,7 +412,7 @@ func (s *visibilityArchiverSuite) TestQuery_Fail_InvalidToken() {
 func (s *visibilityArchiverSuite) TestQuery_Success_NoNextPageToken() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
 	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
+	mockParser.EXPECT().Parse(gomock.Any(), gomock.Any()).Return(&parsedQuery{
 		earliestCloseTime: time.Unix(0, 1),
 		latestCloseTime:   time.Unix(0, 10001),
 		workflowID:        util.Ptr(testWorkflowID),
@@ -435,7 +438,7 @@ func (s *visibilityArchiverSuite) TestQuery_Success_NoNextPageToken() {
 func (s *visibilityArchiverSuite) TestQuery_Success_SmallPageSize() {
 	visibilityArchiver := s.newTestVisibilityArchiver()
 	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
+	mockParser.EXPECT().Parse(gomock.Any(), gomock.Any()).Return(&parsedQuery{
 		earliestCloseTime: time.Unix(0, 1),
 		latestCloseTime:   time.Unix(0, 10001),
 		status:            toWorkflowExecutionStatusPtr(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED),
@@ -476,7 +479,7 @@ func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
 
 	visibilityArchiver := s.newTestVisibilityArchiver()
 	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
+	mockParser.EXPECT().Parse(gomock.Any(), gomock.Any()).Return(&parsedQuery{
 		earliestCloseTime: time.Unix(0, 10),
 		latestCloseTime:   time.Unix(0, 10001),
 		status:            toWorkflowExecutionStatusPtr(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED),
@@ -511,12 +514,153 @@ func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
 	s.Equal(ei, executions[1])
 }
 
+func (s *visibilityArchiverSuite) TestArchiveAndQuery_FullQuery() {
+	dir := testutils.MkdirTemp(s.T(), "", "TestArchiveAndQuery_FullQuery")
+
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	URI, err := archiver.NewURI("file://" + dir)
+	s.NoError(err)
+	for _, record := range s.visibilityRecords {
+		err := visibilityArchiver.Archive(context.Background(), URI, record)
+		s.NoError(err)
+	}
+
+	testCases := []struct {
+		query           string
+		expectedRecords []*archiverspb.VisibilityRecord
+	}{
+		{
+			query:           "WorkflowId STARTS_WITH 'some' OR (HistoryLength > 400 AND ExecutionStatus != 'Failed')",
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[1], s.visibilityRecords[2]},
+		},
+		{
+			query:           "ExecutionStatus = 'Failed' ORDER BY HistoryLength DESC",
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[3], s.visibilityRecords[1], s.visibilityRecords[0]},
+		},
+		{
+			query:           "CloseTime BETWEEN 10 AND 1000 ORDER BY CloseTime",
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[2], s.visibilityRecords[1]},
+		},
+	}
+	for _, tc := range testCases {
+		request := &archiver.QueryVisibilityRequest{
+			NamespaceID: testNamespaceID,
+			PageSize:    1,
+			Query:       tc.query,
+		}
+		var executions []*workflowpb.WorkflowExecutionInfo
+		for {
+			response, err := visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap)
+			s.NoError(err)
+			executions = append(executions, response.Executions...)
+			if response.NextPageToken == nil {
+				break
+			}
+			request.NextPageToken = response.NextPageToken
+		}
+		s.Len(executions, len(tc.expectedRecords), tc.query)
+		for i, record := range tc.expectedRecords {
+			ei, err := convertToExecutionInfo(record, searchattribute.TestNameTypeMap)
+			s.NoError(err)
+			protorequire.ProtoEqual(s.T(), ei, executions[i])
+		}
+	}
+}
+
+func (s *visibilityArchiverSuite) TestArchiveAndQuery_Parquet() {
+	dir := testutils.MkdirTemp(s.T(), "", "TestArchiveAndQuery_Parquet")
+
+	visibilityArchiver, err := NewVisibilityArchiver(s.container, &config.FilestoreArchiver{
+		FileMode: testFileModeStr,
+		DirMode:  testDirModeStr,
+		Parquet: &config.ParquetVisibilityArchiver{
+			FlushInterval: 10 * time.Millisecond,
+		},
+	})
+	s.NoError(err)
+	URI, err := archiver.NewURI("file://" + dir)
+	s.NoError(err)
+	var wg sync.WaitGroup
+	for _, record := range s.visibilityRecords {
+		record := record
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.NoError(visibilityArchiver.Archive(context.Background(), URI, record))
+		}()
+	}
+	wg.Wait()
+	// all records closed on the same day are batched into a single file
+	files, err := listFiles(path.Join(dir, columnar.PartitionDir(testNamespaceID, "1970-01-01")))
+	s.NoError(err)
+	s.Len(files, 1)
+
+	request := &archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    1,
+		Query:       "CloseTime >= 10 and ExecutionStatus = 'Failed'",
+	}
+	executions := []*workflowpb.WorkflowExecutionInfo{}
+	for len(executions) == 0 || request.NextPageToken != nil {
+		response, err := visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap)
+		s.NoError(err)
+		s.NotNil(response)
+		executions = append(executions, response.Executions...)
+		request.NextPageToken = response.NextPageToken
+	}
+	s.Len(executions, 2)
+	for i, execution := range executions {
+		ei, err := convertToExecutionInfo(s.visibilityRecords[i], searchattribute.TestNameTypeMap)
+		s.NoError(err)
+		protorequire.ProtoEqual(s.T(), ei, execution)
+	}
+}
+
+func (s *visibilityArchiverSuite) TestArchiveAndQuery_ParquetAndLegacyLayouts() {
+	dir := testutils.MkdirTemp(s.T(), "", "TestArchiveAndQuery_ParquetAndLegacyLayouts")
+	URI, err := archiver.NewURI("file://" + dir)
+	s.NoError(err)
+
+	// the second record is archived before the columnar layout is enabled
+	legacyArchiver := s.newTestVisibilityArchiver()
+	s.NoError(legacyArchiver.Archive(context.Background(), URI, s.visibilityRecords[1]))
+	visibilityArchiver, err := NewVisibilityArchiver(s.container, &config.FilestoreArchiver{
+		FileMode: testFileModeStr,
+		DirMode:  testDirModeStr,
+		Parquet: &config.ParquetVisibilityArchiver{
+			FlushInterval: 10 * time.Millisecond,
+		},
+	})
+	s.NoError(err)
+	s.NoError(visibilityArchiver.Archive(context.Background(), URI, s.visibilityRecords[0]))
+
+	request := &archiver.QueryVisibilityRequest{
+		NamespaceID: testNamespaceID,
+		PageSize:    1,
+		Query:       "CloseTime >= 10 and ExecutionStatus = 'Failed'",
+	}
+	executions := []*workflowpb.WorkflowExecutionInfo{}
+	for len(executions) == 0 || request.NextPageToken != nil {
+		response, err := visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap)
+		s.NoError(err)
+		s.NotNil(response)
+		executions = append(executions, response.Executions...)
+		request.NextPageToken = response.NextPageToken
+	}
+	s.Len(executions, 2)
+	for i, execution := range executions {
+		ei, err := convertToExecutionInfo(s.visibilityRecords[i], searchattribute.TestNameTypeMap)
+		s.NoError(err)
+		protorequire.ProtoEqual(s.T(), ei, execution)
+	}
+}
+
 func (s *visibilityArchiverSuite) TestQuery_EmptyQuery_InvalidNamespace() {
 	URI := s.testArchivalURI
 
 	visibilityArchiver := s.newTestVisibilityArchiver()
 	mockParser := NewMockQueryParser(s.controller)
-	mockParser.EXPECT().Parse(gomock.Any()).Return(&parsedQuery{
+	mockParser.EXPECT().Parse(gomock.Any(), gomock.Any()).Return(&parsedQuery{
 		earliestCloseTime: time.Unix(0, 10),
 		latestCloseTime:   time.Unix(0, 10001),
 		status:            toWorkflowExecutionStatusPtr(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED),
@@ -33,6 +33,7 @@ import (
 	"go.temporal.io/server/common/archiver/gcloud"
 
 	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/azblobstore"
 	"go.temporal.io/server/common/archiver/filestore"
 	"go.temporal.io/server/common/archiver/s3store"
 	"go.temporal.io/server/common/config"
@@ -157,6 +158,12 @@ func (p *archiverProvider) GetHistoryArchiver(scheme, serviceName string) (histo
 			return nil, ErrArchiverConfigNotFound
 		}
 		historyArchiver, err = s3store.NewHistoryArchiver(container, p.historyArchiverConfigs.S3store)
+
+	case azblobstore.URIScheme:
+		if p.historyArchiverConfigs.Azblob == nil {
+			return nil, ErrArchiverConfigNotFound
+		}
+		historyArchiver, err = azblobstore.NewHistoryArchiver(container, p.historyArchiverConfigs.Azblob)
 	default:
 		return nil, ErrUnknownScheme
 	}
@@ -207,6 +214,11 @@ func (p *archiverProvider) GetVisibilityArchiver(scheme, serviceName string) (ar
 			return nil, ErrArchiverConfigNotFound
 		}
 		visibilityArchiver, err = gcloud.NewVisibilityArchiver(container, p.visibilityArchiverConfigs.Gstorage)
+	case azblobstore.URIScheme:
+		if p.visibilityArchiverConfigs.Azblob == nil {
+			return nil, ErrArchiverConfigNotFound
+		}
+		visibilityArchiver, err = azblobstore.NewVisibilityArchiver(container, p.visibilityArchiverConfigs.Azblob)
 
 	default:
 		return nil, ErrUnknownScheme
@@ -0,0 +1,155 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package s3store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"go.temporal.io/api/serviceerror"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/columnar"
+)
+
+type (
+	// parquetStore is the columnar.Store of the bucket and path pointed by an archival URI
+	parquetStore struct {
+		s3cli s3iface.S3API
+		URI   archiver.URI
+	}
+)
+
+var _ columnar.Store = (*parquetStore)(nil)
+
+func (s *parquetStore) key(name string) string {
+	return strings.TrimLeft(s.URI.Path()+"/"+name, "/")
+}
+
+func (s *parquetStore) List(ctx context.Context, dir string) ([]string, error) {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+	var names []string
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(s.URI.Hostname()),
+		Prefix:    aws.String(s.key(dir) + "/"),
+		Delimiter: aws.String("/"),
+	}
+	for {
+		page, err := s.s3cli.ListObjectsV2WithContext(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, prefix := range page.CommonPrefixes {
+			names = append(names, aws.StringValue(prefix.Prefix))
+		}
+		for _, object := range page.Contents {
+			names = append(names, aws.StringValue(object.Key))
+		}
+		if !aws.BoolValue(page.IsTruncated) {
+			return names, nil
+		}
+		input.ContinuationToken = page.NextContinuationToken
+	}
+}
+
+func (s *parquetStore) Read(ctx context.Context, name string) ([]byte, error) {
+	data, err := Download(ctx, s.s3cli, s.URI, s.key(name))
+	if _, ok := err.(*serviceerror.NotFound); ok {
+		return nil, columnar.ErrFileNotFound
+	}
+	return data, err
+}
+
+func (s *parquetStore) Write(ctx context.Context, name string, data []byte) error {
+	return Upload(ctx, s.s3cli, s.URI, s.key(name), data)
+}
+
+// Delete relies on S3 not returning an error when deleting a key that doesn't exist.
+func (s *parquetStore) Delete(ctx context.Context, name string) error {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+	_, err := s.s3cli.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.URI.Hostname()),
+		Key:    aws.String(s.key(name)),
+	})
+	return err
+}
+
+// parquetQueryRequest converts a parsed query to a columnar query. The close time range is used to skip partitions,
+// a start time range can only bound the earliest close time since a workflow never closes before it starts.
+func parquetQueryRequest(request *archiver.QueryVisibilityRequest, query *parsedQuery) *columnar.QueryRequest {
+	result := &columnar.QueryRequest{
+		NamespaceID:   request.NamespaceID,
+		PageSize:      request.PageSize,
+		NextPageToken: request.NextPageToken,
+	}
+	var startTimeEarliest, startTimeLatest time.Time
+	if query.closeTime != nil {
+		result.EarliestCloseTime, result.LatestCloseTime = precisionRange(*query.closeTime, *query.searchPrecision)
+	}
+	if query.startTime != nil {
+		startTimeEarliest, startTimeLatest = precisionRange(*query.startTime, *query.searchPrecision)
+		result.EarliestCloseTime = startTimeEarliest
+	}
+	result.Filter = func(record *archiverspb.VisibilityRecord) bool {
+		if query.workflowID != nil && record.GetWorkflowId() != *query.workflowID {
+			return false
+		}
+		if query.workflowTypeName != nil && record.GetWorkflowTypeName() != *query.workflowTypeName {
+			return false
+		}
+		if query.startTime != nil {
+			startTime := record.StartTime.AsTime()
+			if startTime.Before(startTimeEarliest) || startTime.After(startTimeLatest) {
+				return false
+			}
+		}
+		return true
+	}
+	return result
+}
+
+// precisionRange returns the inclusive time range matched by a search on t with the given precision.
+func precisionRange(t time.Time, precision string) (time.Time, time.Time) {
+	var d time.Duration
+	switch precision {
+	case PrecisionDay:
+		d = 24 * time.Hour
+	case PrecisionHour:
+		d = time.Hour
+	case PrecisionMinute:
+		d = time.Minute
+	default:
+		d = time.Second
+	}
+	earliest := t.UTC().Truncate(d)
+	return earliest, earliest.Add(d - time.Nanosecond)
+}
@@ -40,6 +40,8 @@ import (
 
 	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/columnar"
+	"go.temporal.io/server/common/archiver/visibilityquery"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
@@ -51,6 +53,8 @@ type (
 		container   *archiver.VisibilityBootstrapContainer
 		s3cli       s3iface.S3API
 		queryParser QueryParser
+		// parquetWriter is only set when the columnar layout is enabled
+		parquetWriter *columnar.Writer
 	}
 
 	queryVisibilityRequest struct {
@@ -97,10 +101,15 @@ func newVisibilityArchiver(
 	if err != nil {
 		return nil, err
 	}
+	var parquetWriter *columnar.Writer
+	if config.Parquet != nil {
+		parquetWriter = columnar.NewWriter(config.Parquet, container.Logger)
+	}
 	return &visibilityArchiver{
-		container:   container,
-		s3cli:       s3.New(sess),
-		queryParser: NewQueryParser(),
+		container:     container,
+		s3cli:         s3.New(sess),
+		queryParser:   NewQueryParser(),
+		parquetWriter: parquetWriter,
 	}, nil
 }
 
@@ -141,6 +150,15 @@ func (v *visibilityArchiver) Archive(
 		return err
 	}
 
+	if v.parquetWriter != nil {
+		if err := v.parquetWriter.Write(ctx, URI.String(), &parquetStore{s3cli: v.s3cli, URI: URI}, request); err != nil {
+			archiveFailReason = errWriteKey
+			return err
+		}
+		handler.Counter(metrics.VisibilityArchiveSuccessCount.Name()).Record(1)
+		return nil
+	}
+
 	encodedVisibilityRecord, err := Encode(request)
 	if err != nil {
 		archiveFailReason = errEncodeVisibilityRecord
@@ -184,24 +202,65 @@ func (v *visibilityArchiver) Query(
 	}
 
 	if strings.TrimSpace(request.Query) == "" {
-		return v.queryAll(ctx, URI, request, saTypeMap)
+		return v.queryLayouts(ctx, URI, request, &columnar.QueryRequest{
+			NamespaceID: request.NamespaceID,
+		}, saTypeMap, func(pageSize int, nextPageToken []byte) (*archiver.QueryVisibilityResponse, error) {
+			return v.queryAll(ctx, URI, request.NamespaceID, pageSize, nextPageToken, saTypeMap)
+		})
 	}
 
 	parsedQuery, err := v.queryParser.Parse(request.Query)
 	if err != nil {
-		return nil, serviceerror.NewInvalidArgument(err.Error())
+		// Queries which are not in the indexed query syntax documented in README.md are
+		// parsed with the visibility query syntax and evaluated against every record.
+		q, queryErr := visibilityquery.Parse(request.Query, saTypeMap)
+		if queryErr != nil {
+			return nil, serviceerror.NewInvalidArgument(queryErr.Error())
+		}
+		return v.queryFull(ctx, URI, request, q, saTypeMap)
 	}
 
-	return v.query(
-		ctx,
-		URI,
-		&queryVisibilityRequest{
-			namespaceID:   request.NamespaceID,
-			pageSize:      request.PageSize,
-			nextPageToken: request.NextPageToken,
-			parsedQuery:   parsedQuery,
+	return v.queryLayouts(ctx, URI, request, parquetQueryRequest(request, parsedQuery), saTypeMap,
+		func(pageSize int, nextPageToken []byte) (*archiver.QueryVisibilityResponse, error) {
+			return v.query(
+				ctx,
+				URI,
+				&queryVisibilityRequest{
+					namespaceID:   request.NamespaceID,
+					pageSize:      pageSize,
+					nextPageToken: nextPageToken,
+					parsedQuery:   parsedQuery,
+				},
+				saTypeMap,
+			)
+		},
+	)
+}
+
+// queryLayouts returns the page of executions requested from the legacy layout, or from both layouts when
+// the columnar layout is enabled. Records archived before the columnar layout was enabled are kept in the
+// legacy layout, they closed before the records of the columnar layout so they are returned last.
+// The page size and token of parquetRequest are set from the request.
+func (v *visibilityArchiver) queryLayouts(
+	ctx context.Context,
+	uri archiver.URI,
+	request *archiver.QueryVisibilityRequest,
+	parquetRequest *columnar.QueryRequest,
+	saTypeMap searchattribute.NameTypeMap,
+	queryLegacy archiver.VisibilityLayoutQuery,
+) (*archiver.QueryVisibilityResponse, error) {
+	if v.parquetWriter == nil {
+		return queryLegacy(request.PageSize, request.NextPageToken)
+	}
+	return archiver.QueryVisibilityLayouts(
+		request.PageSize,
+		request.NextPageToken,
+		func(pageSize int, nextPageToken []byte) (*archiver.QueryVisibilityResponse, error) {
+			parquetRequest.PageSize = pageSize
+			parquetRequest.NextPageToken = nextPageToken
+			return v.queryParquet(ctx, uri, parquetRequest, saTypeMap)
 		},
-		saTypeMap,
+		queryLegacy,
 	)
 }
 
@@ -209,12 +268,13 @@ func (v *visibilityArchiver) Query(
 func (v *visibilityArchiver) queryAll(
 	ctx context.Context,
 	uri archiver.URI,
-	request *archiver.QueryVisibilityRequest,
+	namespaceID string,
+	pageSize int,
+	nextPageToken []byte,
 	saTypeMap searchattribute.NameTypeMap,
 ) (*archiver.QueryVisibilityResponse, error) {
 	// remaining is the number of workflow executions left to return before we reach pageSize.
-	remaining := request.PageSize
-	nextPageToken := request.NextPageToken
+	remaining := pageSize
 	var executions []*workflowpb.WorkflowExecutionInfo
 	// We need to loop because the number of workflow executions returned by each call to query may be fewer than
 	// pageSize. This is because we may have to skip some workflow executions after querying S3 (client-side filtering)
@@ -222,7 +282,7 @@ func (v *visibilityArchiver) queryAll(
 	// and one for startTimeout), and we only want to return one entry per workflow execution. See
 	// createIndexesToArchive for a list of all indexes.
 	for {
-		searchPrefix := constructVisibilitySearchPrefix(uri.Path(), request.NamespaceID)
+		searchPrefix := constructVisibilitySearchPrefix(uri.Path(), namespaceID)
 		// We suffix searchPrefix with workflowTypeName because the data in S3 is duplicated across combinations of 2
 		// different primary indices (workflowID and workflowTypeName) and 2 different secondary indices (closeTimeout
 		// and startTimeout). We only want to return one entry per workflow execution, but the full path to the S3 key
@@ -239,17 +299,11 @@ func (v *visibilityArchiver) queryAll(
 		// need to make multiple calls to S3 to get the correct number of workflow executions, which will probably make
 		// this API call slower.
 		res, err := v.queryPrefix(ctx, uri, &queryVisibilityRequest{
-			namespaceID:   request.NamespaceID,
+			namespaceID:   namespaceID,
 			pageSize:      remaining,
 			nextPageToken: nextPageToken,
 			parsedQuery:   &parsedQuery{},
-		}, saTypeMap, searchPrefix, func(key string) bool {
-			// We only want to return entries for the closeTimeout secondary index, which will always be of the form:
-			// .../closeTimeout/<closeTimeout>/<runID>, so we split the key on "/" and check that the third-to-last
-			// element is "closeTimeout".
-			elements := strings.Split(key, "/")
-			return len(elements) >= 3 && elements[len(elements)-3] == secondaryIndexKeyCloseTimeout
-		})
+		}, saTypeMap, searchPrefix, isCloseTimeoutKey)
 		if err != nil {
 			return nil, err
 		}
@@ -266,6 +320,215 @@ func (v *visibilityArchiver) queryAll(
 	}, nil
 }
 
+// isCloseTimeoutKey returns true for the keys of the closeTimeout secondary index, which will always be of the form:
+// .../closeTimeout/<closeTimeout>/<runID>, so we split the key on "/" and check that the third-to-last
+// element is "closeTimeout".
+func isCloseTimeoutKey(key string) bool {
+	elements := strings.Split(key, "/")
+	return len(elements) >= 3 && elements[len(elements)-3] == secondaryIndexKeyCloseTimeout
+}
+
+// queryFull returns the workflow executions matching a query in the visibility query syntax.
+// Only WorkflowId and WorkflowType conditions can use the indexes, other conditions are checked
+// after downloading the records.
+func (v *visibilityArchiver) queryFull(
+	ctx context.Context,
+	uri archiver.URI,
+	request *archiver.QueryVisibilityRequest,
+	q *visibilityquery.Query,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	bounds := q.Bounds()
+	if bounds.Empty {
+		return &archiver.QueryVisibilityResponse{}, nil
+	}
+	parquetRequest := &columnar.QueryRequest{
+		NamespaceID:       request.NamespaceID,
+		EarliestCloseTime: bounds.EarliestCloseTime,
+		LatestCloseTime:   bounds.LatestCloseTime,
+		Filter:            q.Match,
+	}
+
+	// Every record is stored once under the closeTimeout index of its workflow ID and of its workflow type.
+	prefix := constructVisibilitySearchPrefix(uri.Path(), request.NamespaceID) + "/" + primaryIndexKeyWorkflowTypeName + "/"
+	keyFilter := isCloseTimeoutKey
+	switch {
+	case bounds.WorkflowID != nil:
+		prefix = constructIndexedVisibilitySearchPrefix(
+			uri.Path(), request.NamespaceID, primaryIndexKeyWorkflowID, *bounds.WorkflowID, secondaryIndexKeyCloseTimeout,
+		) + "/"
+		keyFilter = nil
+	case bounds.WorkflowType != nil:
+		prefix = constructIndexedVisibilitySearchPrefix(
+			uri.Path(), request.NamespaceID, primaryIndexKeyWorkflowTypeName, *bounds.WorkflowType, secondaryIndexKeyCloseTimeout,
+		) + "/"
+		keyFilter = nil
+	}
+
+	if q.HasOrderBy() {
+		// one record more than the limit is read to detect that the query matches too many records
+		var records []*archiverspb.VisibilityRecord
+		if v.parquetWriter != nil {
+			parquetRequest.PageSize = visibilityquery.MaxSortedRecords + 1
+			result, err := columnar.Query(ctx, &parquetStore{s3cli: v.s3cli, URI: uri}, parquetRequest)
+			if err != nil {
+				if isRetryableError(err) {
+					return nil, serviceerror.NewUnavailable(err.Error())
+				}
+				return nil, serviceerror.NewInternal(err.Error())
+			}
+			records = result.Records
+		}
+		err := v.scanRecords(ctx, uri, prefix, "", keyFilter, func(_ string, record *archiverspb.VisibilityRecord) bool {
+			if len(records) > visibilityquery.MaxSortedRecords {
+				return false
+			}
+			if q.Match(record) {
+				records = append(records, record)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return v.sortedPage(records, request, q, saTypeMap)
+	}
+
+	return v.queryLayouts(ctx, uri, request, parquetRequest, saTypeMap,
+		func(pageSize int, nextPageToken []byte) (*archiver.QueryVisibilityResponse, error) {
+			return v.scanPage(ctx, uri, prefix, keyFilter, q, pageSize, nextPageToken, saTypeMap)
+		},
+	)
+}
+
+// scanPage returns a page of the records stored under prefix matching the query, in key order.
+func (v *visibilityArchiver) scanPage(
+	ctx context.Context,
+	uri archiver.URI,
+	prefix string,
+	keyFilter func(key string) bool,
+	q *visibilityquery.Query,
+	pageSize int,
+	nextPageToken []byte,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	var startAfter string
+	if nextPageToken != nil {
+		startAfter = *deserializeQueryVisibilityToken(nextPageToken)
+	}
+	response := &archiver.QueryVisibilityResponse{}
+	var lastKey string
+	var convertErr error
+	err := v.scanRecords(ctx, uri, prefix, startAfter, keyFilter, func(key string, record *archiverspb.VisibilityRecord) bool {
+		if len(response.Executions) == pageSize {
+			// There is at least one more record, so the page needs a token.
+			response.NextPageToken = serializeQueryVisibilityToken(lastKey)
+			return false
+		}
+		if !q.Match(record) {
+			return true
+		}
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			convertErr = err
+			return false
+		}
+		response.Executions = append(response.Executions, executionInfo)
+		lastKey = key
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if convertErr != nil {
+		return nil, serviceerror.NewInternal(convertErr.Error())
+	}
+	return response, nil
+}
+
+// scanRecords downloads the records stored under prefix in key order, starting after the startAfter key
+// if set, and calls fn with each of them until fn returns false. The keyFilter function is an optional
+// filter used to skip keys without downloading them.
+func (v *visibilityArchiver) scanRecords(
+	ctx context.Context,
+	uri archiver.URI,
+	prefix string,
+	startAfter string,
+	keyFilter func(key string) bool,
+	fn func(key string, record *archiverspb.VisibilityRecord) bool,
+) error {
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(uri.Hostname()),
+		Prefix: aws.String(prefix),
+	}
+	if startAfter != "" {
+		input.StartAfter = aws.String(startAfter)
+	}
+	for {
+		results, err := v.s3cli.ListObjectsV2WithContext(ctx, input)
+		if err != nil {
+			if isRetryableError(err) {
+				return serviceerror.NewUnavailable(err.Error())
+			}
+			return serviceerror.NewInvalidArgument(err.Error())
+		}
+		for _, item := range results.Contents {
+			if keyFilter != nil && !keyFilter(*item.Key) {
+				continue
+			}
+			encodedRecord, err := Download(ctx, v.s3cli, uri, *item.Key)
+			if err != nil {
+				return serviceerror.NewUnavailable(err.Error())
+			}
+			record, err := decodeVisibilityRecord(encodedRecord)
+			if err != nil {
+				return serviceerror.NewInternal(err.Error())
+			}
+			if !fn(*item.Key, record) {
+				return nil
+			}
+		}
+		if !aws.BoolValue(results.IsTruncated) {
+			return nil
+		}
+		input.StartAfter = nil
+		input.ContinuationToken = results.NextContinuationToken
+	}
+}
+
+// sortedPage returns the page of records requested by a query with an ORDER BY clause.
+func (v *visibilityArchiver) sortedPage(
+	records []*archiverspb.VisibilityRecord,
+	request *archiver.QueryVisibilityRequest,
+	q *visibilityquery.Query,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	page, nextPageToken, err := q.SortedPage(records, request.PageSize, request.NextPageToken)
+	if err != nil {
+		if err == visibilityquery.ErrInvalidNextPageToken {
+			return nil, serviceerror.NewInvalidArgument(archiver.ErrNextPageTokenCorrupted.Error())
+		}
+		if err == visibilityquery.ErrTooManySortedRecords {
+			return nil, serviceerror.NewInvalidArgument(err.Error())
+		}
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	response := &archiver.QueryVisibilityResponse{
+		NextPageToken: nextPageToken,
+	}
+	for _, record := range page {
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.Executions = append(response.Executions, executionInfo)
+	}
+	return response, nil
+}
+
 func (v *visibilityArchiver) query(
 	ctx context.Context,
 	URI archiver.URI,
@@ -374,6 +637,36 @@ func (v *visibilityArchiver) queryPrefix(
 	return response, nil
 }
 
+func (v *visibilityArchiver) queryParquet(
+	ctx context.Context,
+	URI archiver.URI,
+	request *columnar.QueryRequest,
+	saTypeMap searchattribute.NameTypeMap,
+) (*archiver.QueryVisibilityResponse, error) {
+	result, err := columnar.Query(ctx, &parquetStore{s3cli: v.s3cli, URI: URI}, request)
+	if err != nil {
+		if err == columnar.ErrInvalidNextPageToken {
+			return nil, serviceerror.NewInvalidArgument(archiver.ErrNextPageTokenCorrupted.Error())
+		}
+		if isRetryableError(err) {
+			return nil, serviceerror.NewUnavailable(err.Error())
+		}
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+
+	response := &archiver.QueryVisibilityResponse{
+		NextPageToken: result.NextPageToken,
+	}
+	for _, record := range result.Records {
+		executionInfo, err := convertToExecutionInfo(record, saTypeMap)
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		response.Executions = append(response.Executions, executionInfo)
+	}
+	return response, nil
+}
+
 func (v *visibilityArchiver) ValidateURI(URI archiver.URI) error {
 	err := SoftValidateURI(URI)
 	if err != nil {
@@ -46,13 +46,16 @@ import (
 
 	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/archiver/columnar"
 	"go.temporal.io/server/common/archiver/s3store/mocks"
 	"go.temporal.io/server/common/codec"
+	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/common/testing/protorequire"
 	"go.temporal.io/server/common/util"
 )
 
@@ -659,6 +662,121 @@ func (s *visibilityArchiverSuite) TestArchiveAndQuery() {
 	s.Equal(ei, executions[2])
 }
 
+func (s *visibilityArchiverSuite) TestArchiveAndQuery_FullQuery() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	URI, err := archiver.NewURI(testBucketURI + "/archive-and-query-full-query")
+	s.NoError(err)
+	for _, record := range s.visibilityRecords {
+		err := visibilityArchiver.Archive(context.Background(), URI, record)
+		s.NoError(err)
+	}
+
+	testCases := []struct {
+		query           string
+		expectedRecords []*archiverspb.VisibilityRecord
+	}{
+		{
+			query:           "CloseTime > '1970-01-01T01:00:00Z'",
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[1], s.visibilityRecords[2]},
+		},
+		{
+			query:           fmt.Sprintf("WorkflowId = '%s' AND RunId = '%s1'", testWorkflowID, testRunID),
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[1], s.visibilityRecords[2]},
+		},
+		{
+			query:           fmt.Sprintf("WorkflowType = '%s' AND ExecutionStatus = 'Failed' ORDER BY CloseTime DESC", testWorkflowTypeName),
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[2], s.visibilityRecords[1], s.visibilityRecords[0]},
+		},
+		{
+			query:           "WorkflowType = 'unknown' OR HistoryLength < 100",
+			expectedRecords: nil,
+		},
+	}
+	for _, tc := range testCases {
+		request := &archiver.QueryVisibilityRequest{
+			NamespaceID: testNamespaceID,
+			PageSize:    1,
+			Query:       tc.query,
+		}
+		var executions []*workflowpb.WorkflowExecutionInfo
+		for {
+			response, err := visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap)
+			s.NoError(err, tc.query)
+			executions = append(executions, response.Executions...)
+			if response.NextPageToken == nil {
+				break
+			}
+			request.NextPageToken = response.NextPageToken
+		}
+		s.Len(executions, len(tc.expectedRecords), tc.query)
+		for i, record := range tc.expectedRecords {
+			ei, err := convertToExecutionInfo(record, searchattribute.TestNameTypeMap)
+			s.NoError(err)
+			protorequire.ProtoEqual(s.T(), ei, executions[i])
+		}
+	}
+}
+
+func (s *visibilityArchiverSuite) TestArchiveAndQuery_Parquet() {
+	visibilityArchiver := s.newTestVisibilityArchiver()
+	visibilityArchiver.parquetWriter = columnar.NewWriter(&config.ParquetVisibilityArchiver{MaxBatchSize: 1}, log.NewNoopLogger())
+	URI, err := archiver.NewURI(testBucketURI + "/archive-and-query-parquet")
+	s.NoError(err)
+	for _, record := range s.visibilityRecords {
+		err := visibilityArchiver.Archive(context.Background(), URI, record)
+		s.NoError(err)
+	}
+
+	testCases := []struct {
+		query           string
+		expectedRecords []*archiverspb.VisibilityRecord
+	}{
+		{
+			query:           "",
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[2], s.visibilityRecords[1], s.visibilityRecords[0]},
+		},
+		{
+			query:           fmt.Sprintf("WorkflowId = '%s'", testWorkflowID),
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[2], s.visibilityRecords[1], s.visibilityRecords[0]},
+		},
+		{
+			query:           fmt.Sprintf("WorkflowTypeName = '%s' and CloseTime = '1970-01-01T01:00:00Z' and SearchPrecision = 'Hour'", testWorkflowTypeName),
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[1], s.visibilityRecords[0]},
+		},
+		{
+			query:           fmt.Sprintf("WorkflowId = '%s' and StartTime = '1970-01-01T00:00:00Z' and SearchPrecision = 'Day'", testWorkflowID),
+			expectedRecords: []*archiverspb.VisibilityRecord{s.visibilityRecords[2], s.visibilityRecords[1], s.visibilityRecords[0]},
+		},
+		{
+			query:           "WorkflowId = 'unknown'",
+			expectedRecords: nil,
+		},
+	}
+	for _, tc := range testCases {
+		request := &archiver.QueryVisibilityRequest{
+			NamespaceID: testNamespaceID,
+			PageSize:    1,
+			Query:       tc.query,
+		}
+		var executions []*workflowpb.WorkflowExecutionInfo
+		for {
+			response, err := visibilityArchiver.Query(context.Background(), URI, request, searchattribute.TestNameTypeMap)
+			s.NoError(err)
+			executions = append(executions, response.Executions...)
+			if response.NextPageToken == nil {
+				break
+			}
+			request.NextPageToken = response.NextPageToken
+		}
+		s.Len(executions, len(tc.expectedRecords), tc.query)
+		for i, record := range tc.expectedRecords {
+			ei, err := convertToExecutionInfo(record, searchattribute.TestNameTypeMap)
+			s.NoError(err)
+			protorequire.ProtoEqual(s.T(), ei, executions[i])
+		}
+	}
+}
+
 func (s *visibilityArchiverSuite) setupVisibilityDirectory() {
 	s.visibilityRecords = []*archiverspb.VisibilityRecord{
 		{
@@ -25,8 +25,11 @@
 package archiver
 
 import (
+	"encoding/json"
 	"errors"
 
+	"go.temporal.io/api/serviceerror"
+
 	archiverspb "go.temporal.io/server/api/archiver/v1"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
@@ -144,3 +147,61 @@ func ValidateQueryRequest(request *QueryVisibilityRequest) error {
 	}
 	return nil
 }
+
+type (
+	// VisibilityLayoutQuery returns a page of at most pageSize executions from one storage layout of a
+	// visibility archiver, nextPageToken is nil for the first page.
+	VisibilityLayoutQuery func(pageSize int, nextPageToken []byte) (*QueryVisibilityResponse, error)
+
+	visibilityLayoutToken struct {
+		Layout int
+		Token  []byte
+	}
+)
+
+// QueryVisibilityLayouts pages through several storage layouts of the same archive, one after the other:
+// a page is filled from the next layout once the previous one is exhausted. Archivers use it to keep
+// returning the records written with a previous layout after switching to a new one, so layouts must be
+// given from the newest to the oldest to return records in close time order.
+func QueryVisibilityLayouts(
+	pageSize int,
+	nextPageToken []byte,
+	layouts ...VisibilityLayoutQuery,
+) (*QueryVisibilityResponse, error) {
+	token := &visibilityLayoutToken{}
+	if len(nextPageToken) != 0 {
+		if err := json.Unmarshal(nextPageToken, token); err != nil || token.Layout < 0 || token.Layout >= len(layouts) {
+			return nil, serviceerror.NewInvalidArgument(ErrNextPageTokenCorrupted.Error())
+		}
+	}
+
+	response := &QueryVisibilityResponse{}
+	for layout := token.Layout; layout < len(layouts); layout++ {
+		layoutToken := token.Token
+		if layout != token.Layout {
+			layoutToken = nil
+		}
+		result, err := layouts[layout](pageSize-len(response.Executions), layoutToken)
+		if err != nil {
+			return nil, err
+		}
+		response.Executions = append(response.Executions, result.Executions...)
+
+		next := &visibilityLayoutToken{Layout: layout, Token: result.NextPageToken}
+		if len(result.NextPageToken) == 0 {
+			next = &visibilityLayoutToken{Layout: layout + 1}
+		}
+		if next.Layout == len(layouts) {
+			break
+		}
+		if len(result.NextPageToken) != 0 || len(response.Executions) >= pageSize {
+			encodedToken, err := json.Marshal(next)
+			if err != nil {
+				return nil, serviceerror.NewInternal(err.Error())
+			}
+			response.NextPageToken = encodedToken
+			break
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,83 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package archiver
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+)
+
+// testLayout returns a layout query over count executions, whose token is the index of the next execution.
+func testLayout(name string, count int) VisibilityLayoutQuery {
+	return func(pageSize int, nextPageToken []byte) (*QueryVisibilityResponse, error) {
+		start := 0
+		if nextPageToken != nil {
+			start, _ = strconv.Atoi(string(nextPageToken))
+		}
+		response := &QueryVisibilityResponse{}
+		for i := start; i < count && len(response.Executions) < pageSize; i++ {
+			response.Executions = append(response.Executions, &workflowpb.WorkflowExecutionInfo{
+				Execution: &commonpb.WorkflowExecution{WorkflowId: name + strconv.Itoa(i)},
+			})
+			if i+1 < count && len(response.Executions) == pageSize {
+				response.NextPageToken = []byte(strconv.Itoa(i + 1))
+			}
+		}
+		return response, nil
+	}
+}
+
+func TestQueryVisibilityLayouts(t *testing.T) {
+	for _, pageSize := range []int{1, 2, 3, 10} {
+		var names []string
+		var nextPageToken []byte
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 10)
+			response, err := QueryVisibilityLayouts(pageSize, nextPageToken, testLayout("new", 3), testLayout("empty", 0), testLayout("old", 2))
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(response.Executions), pageSize)
+			for _, execution := range response.Executions {
+				names = append(names, execution.Execution.WorkflowId)
+			}
+			if response.NextPageToken == nil {
+				break
+			}
+			nextPageToken = response.NextPageToken
+		}
+		require.Equal(t, []string{"new0", "new1", "new2", "old0", "old1"}, names, "page size %d", pageSize)
+	}
+}
+
+func TestQueryVisibilityLayouts_InvalidToken(t *testing.T) {
+	for _, token := range []string{"invalid", `{"Layout":2}`, `{"Layout":-1}`} {
+		_, err := QueryVisibilityLayouts(1, []byte(token), testLayout("new", 3), testLayout("old", 2))
+		require.IsType(t, &serviceerror.InvalidArgument{}, err, token)
+	}
+}
@@ -0,0 +1,129 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilityquery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+)
+
+// MaxSortedRecords is the maximum number of records a query with an ORDER BY clause can match,
+// since they are all loaded in memory to be sorted.
+const MaxSortedRecords = 10000
+
+var (
+	// ErrInvalidNextPageToken is returned by SortedPage when the next page token can't be parsed.
+	ErrInvalidNextPageToken = errors.New("invalid next page token")
+	// ErrTooManySortedRecords is returned by SortedPage when more than MaxSortedRecords records match the query.
+	ErrTooManySortedRecords = fmt.Errorf("queries with an ORDER BY clause can match at most %d records, narrow the query down with a CloseTime range", MaxSortedRecords)
+)
+
+type (
+	sortedPageToken struct {
+		Offset int
+	}
+)
+
+// HasOrderBy returns true if the query has an ORDER BY clause. Archived records are not stored in
+// that order, so all records matching the query need to be read and paginated with SortedPage.
+// Callers should stop reading records once more than MaxSortedRecords records match.
+func (q *Query) HasOrderBy() bool {
+	return len(q.orderBy) > 0
+}
+
+// SortedPage sorts the records matching the query by the ORDER BY clause and returns the page of
+// at most pageSize records after the ones returned with nextPageToken. Records with equal sort
+// values keep their relative order, and records without a value are sorted last.
+func (q *Query) SortedPage(
+	records []*archiverspb.VisibilityRecord,
+	pageSize int,
+	nextPageToken []byte,
+) ([]*archiverspb.VisibilityRecord, []byte, error) {
+	if len(records) > MaxSortedRecords {
+		return nil, nil, ErrTooManySortedRecords
+	}
+	token := &sortedPageToken{}
+	if len(nextPageToken) != 0 {
+		if err := json.Unmarshal(nextPageToken, token); err != nil || token.Offset < 0 {
+			return nil, nil, ErrInvalidNextPageToken
+		}
+	}
+
+	sorted := make([]*record, 0, len(records))
+	for _, r := range records {
+		sorted = append(sorted, newRecord(r, q.saTypeMap))
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return q.less(sorted[i], sorted[j])
+	})
+
+	if token.Offset >= len(sorted) {
+		return nil, nil, nil
+	}
+	end := token.Offset + pageSize
+	if end > len(sorted) {
+		end = len(sorted)
+	}
+	page := make([]*archiverspb.VisibilityRecord, 0, end-token.Offset)
+	for _, r := range sorted[token.Offset:end] {
+		page = append(page, r.VisibilityRecord)
+	}
+	if end == len(sorted) {
+		return page, nil, nil
+	}
+	nextPageToken, err := json.Marshal(&sortedPageToken{Offset: end})
+	if err != nil {
+		return nil, nil, err
+	}
+	return page, nextPageToken, nil
+}
+
+func (q *Query) less(a, b *record) bool {
+	for _, orderBy := range q.orderBy {
+		aValues := a.values(orderBy.field)
+		bValues := b.values(orderBy.field)
+		switch {
+		case len(aValues) == 0 && len(bValues) == 0:
+			continue
+		case len(aValues) == 0:
+			return false
+		case len(bValues) == 0:
+			return true
+		}
+		c, ok := compare(aValues[0], bValues[0])
+		if !ok || c == 0 {
+			continue
+		}
+		if orderBy.desc {
+			return c > 0
+		}
+		return c < 0
+	}
+	return false
+}
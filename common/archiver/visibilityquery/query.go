@@ -0,0 +1,581 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package visibilityquery evaluates visibility queries against archived visibility records in memory.
+// Queries use the same syntax as List queries against the live visibility store (AND, OR, IN, BETWEEN,
+// STARTS_WITH, IS NULL, custom search attributes and ORDER BY), so a query keeps working after
+// the workflows it targets are moved to the archive.
+package visibilityquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+var allowedComparisonOperators = map[string]struct{}{
+	sqlparser.EqualStr:         {},
+	sqlparser.NotEqualStr:      {},
+	sqlparser.GreaterThanStr:   {},
+	sqlparser.GreaterEqualStr:  {},
+	sqlparser.LessThanStr:      {},
+	sqlparser.LessEqualStr:     {},
+	sqlparser.InStr:            {},
+	sqlparser.NotInStr:         {},
+	sqlparser.StartsWithStr:    {},
+	sqlparser.NotStartsWithStr: {},
+}
+
+type (
+	// Query is a parsed visibility query.
+	Query struct {
+		saTypeMap searchattribute.NameTypeMap
+		// saMapper is nil when the query doesn't resolve search attribute aliases.
+		saMapper      searchattribute.Mapper
+		namespaceName string
+		// filter is nil when the query has no WHERE clause.
+		filter  predicate
+		orderBy []orderByField
+		bounds  Bounds
+	}
+
+	// Bounds are constraints that every record matching a query satisfies. They are derived
+	// from the conditions joined by AND at the top level of the query, and let archivers skip
+	// records without reading them. Records within the bounds still need to be checked with Match.
+	Bounds struct {
+		// EarliestCloseTime and LatestCloseTime are inclusive, zero values mean unbounded.
+		EarliestCloseTime time.Time
+		LatestCloseTime   time.Time
+		// Nil values mean unbounded.
+		WorkflowID      *string
+		RunID           *string
+		WorkflowType    *string
+		ExecutionStatus *enumspb.WorkflowExecutionStatus
+		// Empty is true when the conditions require different values for the same field,
+		// so no record can match the query.
+		Empty bool
+	}
+
+	field struct {
+		name      string
+		valueType enumspb.IndexedValueType
+	}
+
+	orderByField struct {
+		field
+		desc bool
+	}
+
+	predicate func(r *record) bool
+)
+
+// Parse parses a visibility query. Errors are returned as *query.ConverterError.
+func Parse(queryString string, saTypeMap searchattribute.NameTypeMap) (*Query, error) {
+	return parse(queryString, saTypeMap, nil, "")
+}
+
+// ParseWithMapper parses a visibility query in which custom search attributes are referred to
+// by their aliases in the namespace. Errors are returned as *query.ConverterError.
+func ParseWithMapper(
+	queryString string,
+	saTypeMap searchattribute.NameTypeMap,
+	saMapper searchattribute.Mapper,
+	namespaceName string,
+) (*Query, error) {
+	return parse(queryString, saTypeMap, saMapper, namespaceName)
+}
+
+func parse(
+	queryString string,
+	saTypeMap searchattribute.NameTypeMap,
+	saMapper searchattribute.Mapper,
+	namespaceName string,
+) (*Query, error) {
+	queryString = strings.TrimSpace(queryString)
+	if queryString != "" && !strings.HasPrefix(strings.ToLower(queryString), "order by ") {
+		queryString = "where " + queryString
+	}
+	// sqlparser can't parse just WHERE clause but instead accepts only valid SQL statement.
+	stmt, err := sqlparser.Parse(fmt.Sprintf("select * from table1 %s", queryString))
+	if err != nil {
+		return nil, query.NewConverterError("%s: %v", query.MalformedSqlQueryErrMessage, err)
+	}
+	sel, isSelect := stmt.(*sqlparser.Select)
+	if !isSelect {
+		return nil, query.NewConverterError("%s: statement must be 'select' not %T", query.NotSupportedErrMessage, stmt)
+	}
+	if sel.Limit != nil {
+		return nil, query.NewConverterError("%s: 'limit' clause", query.NotSupportedErrMessage)
+	}
+	if len(sel.GroupBy) > 0 {
+		return nil, query.NewConverterError("%s: 'group by' clause", query.NotSupportedErrMessage)
+	}
+
+	q := &Query{saTypeMap: saTypeMap, saMapper: saMapper, namespaceName: namespaceName}
+	if sel.Where != nil {
+		if q.filter, err = q.convertWhereExpr(sel.Where.Expr); err != nil {
+			return nil, query.NewConverterError("unable to convert filter expression: %v", err)
+		}
+		q.addBounds(sel.Where.Expr)
+	}
+	for _, orderByExpr := range sel.OrderBy {
+		f, err := q.convertColName(orderByExpr.Expr)
+		if err != nil {
+			return nil, query.NewConverterError("unable to convert 'order by' column name: %v", err)
+		}
+		if f.valueType == enumspb.INDEXED_VALUE_TYPE_TEXT {
+			return nil, query.NewConverterError(
+				"unable to sort by field of %s type, use field of type %s",
+				enumspb.INDEXED_VALUE_TYPE_TEXT.String(),
+				enumspb.INDEXED_VALUE_TYPE_KEYWORD.String(),
+			)
+		}
+		q.orderBy = append(q.orderBy, orderByField{field: f, desc: orderByExpr.Direction == sqlparser.DescScr})
+	}
+	return q, nil
+}
+
+// Bounds returns the constraints every record matching the query satisfies.
+func (q *Query) Bounds() Bounds {
+	return q.bounds
+}
+
+// Match returns true if the record matches the query filter.
+func (q *Query) Match(visibilityRecord *archiverspb.VisibilityRecord) bool {
+	if q.filter == nil {
+		return true
+	}
+	return q.filter(newRecord(visibilityRecord, q.saTypeMap))
+}
+
+func (q *Query) convertWhereExpr(expr sqlparser.Expr) (predicate, error) {
+	switch e := expr.(type) {
+	case *sqlparser.AndExpr:
+		left, right, err := q.convertBinaryExpr(e.Left, e.Right)
+		if err != nil {
+			return nil, err
+		}
+		return func(r *record) bool { return left(r) && right(r) }, nil
+	case *sqlparser.OrExpr:
+		left, right, err := q.convertBinaryExpr(e.Left, e.Right)
+		if err != nil {
+			return nil, err
+		}
+		return func(r *record) bool { return left(r) || right(r) }, nil
+	case *sqlparser.ParenExpr:
+		return q.convertWhereExpr(e.Expr)
+	case *sqlparser.ComparisonExpr:
+		return q.convertComparisonExpr(e)
+	case *sqlparser.RangeCond:
+		return q.convertRangeCond(e)
+	case *sqlparser.IsExpr:
+		return q.convertIsExpr(e)
+	case *sqlparser.NotExpr:
+		return nil, query.NewConverterError("%s: 'not' expression", query.NotSupportedErrMessage)
+	case *sqlparser.FuncExpr:
+		return nil, query.NewConverterError("%s: function expression", query.NotSupportedErrMessage)
+	case *sqlparser.ColName:
+		return nil, query.NewConverterError("incomplete expression")
+	default:
+		return nil, query.NewConverterError("%s: expression of type %T", query.NotSupportedErrMessage, expr)
+	}
+}
+
+func (q *Query) convertBinaryExpr(leftExpr, rightExpr sqlparser.Expr) (predicate, predicate, error) {
+	left, err := q.convertWhereExpr(leftExpr)
+	if err != nil {
+		return nil, nil, err
+	}
+	right, err := q.convertWhereExpr(rightExpr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return left, right, nil
+}
+
+func (q *Query) convertComparisonExpr(expr *sqlparser.ComparisonExpr) (predicate, error) {
+	f, err := q.convertColName(expr.Left)
+	if err != nil {
+		return nil, query.NewConverterError("unable to convert left side of %q: %v", sqlparser.String(expr), err)
+	}
+	colValue, err := convertComparisonExprValue(expr.Right)
+	if err != nil {
+		return nil, query.NewConverterError("unable to convert right side of %q: %v", sqlparser.String(expr), err)
+	}
+	colValues, isArray := colValue.([]any)
+	// colValue should be an array only for "in (1,2,3)" queries.
+	if !isArray {
+		colValues = []any{colValue}
+	}
+	if _, ok := allowedComparisonOperators[expr.Operator]; !ok {
+		return nil, query.NewConverterError("operator '%v' not allowed in comparison expression", expr.Operator)
+	}
+	if expr.Operator == sqlparser.StartsWithStr || expr.Operator == sqlparser.NotStartsWithStr {
+		prefix, ok := colValues[0].(string)
+		if !ok {
+			return nil, query.NewConverterError("right-hand side of '%v' must be a string", expr.Operator)
+		}
+		startsWith := func(r *record) bool { return r.any(f, func(v any) bool { return hasPrefix(f, v, prefix) }) }
+		if expr.Operator == sqlparser.NotStartsWithStr {
+			return not(startsWith), nil
+		}
+		return startsWith, nil
+	}
+	values, err := convertValues(f, colValues...)
+	if err != nil {
+		return nil, query.NewConverterError("unable to convert values of comparison expression: %v", err)
+	}
+
+	switch expr.Operator {
+	case sqlparser.GreaterEqualStr:
+		return compareWith(f, values[0], func(c int) bool { return c >= 0 }), nil
+	case sqlparser.LessEqualStr:
+		return compareWith(f, values[0], func(c int) bool { return c <= 0 }), nil
+	case sqlparser.GreaterThanStr:
+		return compareWith(f, values[0], func(c int) bool { return c > 0 }), nil
+	case sqlparser.LessThanStr:
+		return compareWith(f, values[0], func(c int) bool { return c < 0 }), nil
+	case sqlparser.EqualStr:
+		return equalTo(f, values[0]), nil
+	case sqlparser.NotEqualStr:
+		return not(equalTo(f, values[0])), nil
+	case sqlparser.InStr:
+		return in(f, values), nil
+	default: // sqlparser.NotInStr
+		return not(in(f, values)), nil
+	}
+}
+
+func (q *Query) convertRangeCond(expr *sqlparser.RangeCond) (predicate, error) {
+	f, err := q.convertColName(expr.Left)
+	if err != nil {
+		return nil, query.NewConverterError("unable to convert left part of 'between' expression: %v", err)
+	}
+	fromValue, err := query.ParseSqlValue(sqlparser.String(expr.From))
+	if err != nil {
+		return nil, err
+	}
+	toValue, err := query.ParseSqlValue(sqlparser.String(expr.To))
+	if err != nil {
+		return nil, err
+	}
+	values, err := convertValues(f, fromValue, toValue)
+	if err != nil {
+		return nil, query.NewConverterError("unable to convert values of 'between' expression: %v", err)
+	}
+	between := func(r *record) bool {
+		return r.any(f, func(v any) bool {
+			from, ok := compare(v, values[0])
+			if !ok || from < 0 {
+				return false
+			}
+			to, ok := compare(v, values[1])
+			return ok && to <= 0
+		})
+	}
+
+	switch expr.Operator {
+	case sqlparser.BetweenStr:
+		return between, nil
+	case sqlparser.NotBetweenStr:
+		return not(between), nil
+	default:
+		return nil, query.NewConverterError("%s: range condition operator must be 'between' or 'not between'", query.InvalidExpressionErrMessage)
+	}
+}
+
+func (q *Query) convertIsExpr(expr *sqlparser.IsExpr) (predicate, error) {
+	f, err := q.convertColName(expr.Expr)
+	if err != nil {
+		return nil, query.NewConverterError("unable to convert left part of 'is' expression: %v", err)
+	}
+	exists := func(r *record) bool { return len(r.values(f)) > 0 }
+
+	switch expr.Operator {
+	case sqlparser.IsNullStr:
+		return not(exists), nil
+	case sqlparser.IsNotNullStr:
+		return exists, nil
+	default:
+		return nil, query.NewConverterError("%s: 'is' operator can be used with 'null' and 'not null' only", query.InvalidExpressionErrMessage)
+	}
+}
+
+// addBounds narrows the bounds with the conditions of expr which all matching records must satisfy.
+// expr must have been converted successfully before.
+func (q *Query) addBounds(expr sqlparser.Expr) {
+	switch e := expr.(type) {
+	case *sqlparser.AndExpr:
+		q.addBounds(e.Left)
+		q.addBounds(e.Right)
+	case *sqlparser.ParenExpr:
+		q.addBounds(e.Expr)
+	case *sqlparser.ComparisonExpr:
+		f, err := q.convertColName(e.Left)
+		if err != nil {
+			return
+		}
+		value, err := convertComparisonExprValue(e.Right)
+		if err != nil {
+			return
+		}
+		if value, err = convertValue(f, value); err != nil {
+			return
+		}
+		q.bounds.addComparison(f.name, e.Operator, value)
+	case *sqlparser.RangeCond:
+		if e.Operator != sqlparser.BetweenStr {
+			return
+		}
+		f, err := q.convertColName(e.Left)
+		if err != nil || f.name != searchattribute.CloseTime {
+			return
+		}
+		from, err := query.ParseSqlValue(sqlparser.String(e.From))
+		if err != nil {
+			return
+		}
+		to, err := query.ParseSqlValue(sqlparser.String(e.To))
+		if err != nil {
+			return
+		}
+		values, err := convertValues(f, from, to)
+		if err != nil {
+			return
+		}
+		q.bounds.addComparison(f.name, sqlparser.GreaterEqualStr, values[0])
+		q.bounds.addComparison(f.name, sqlparser.LessEqualStr, values[1])
+	}
+}
+
+func (b *Bounds) addComparison(name string, operator string, value any) {
+	if t, isTime := value.(time.Time); isTime && name == searchattribute.CloseTime {
+		switch operator {
+		case sqlparser.EqualStr:
+			b.addComparison(name, sqlparser.GreaterEqualStr, t)
+			b.addComparison(name, sqlparser.LessEqualStr, t)
+		case sqlparser.GreaterThanStr:
+			b.addComparison(name, sqlparser.GreaterEqualStr, t.Add(time.Nanosecond))
+		case sqlparser.GreaterEqualStr:
+			if b.EarliestCloseTime.IsZero() || t.After(b.EarliestCloseTime) {
+				b.EarliestCloseTime = t
+			}
+		case sqlparser.LessThanStr:
+			b.addComparison(name, sqlparser.LessEqualStr, t.Add(-time.Nanosecond))
+		case sqlparser.LessEqualStr:
+			if b.LatestCloseTime.IsZero() || t.Before(b.LatestCloseTime) {
+				b.LatestCloseTime = t
+			}
+		}
+		return
+	}
+
+	s, isString := value.(string)
+	if operator != sqlparser.EqualStr || !isString {
+		return
+	}
+	switch name {
+	case searchattribute.WorkflowID:
+		b.addEqual(&b.WorkflowID, s)
+	case searchattribute.RunID:
+		b.addEqual(&b.RunID, s)
+	case searchattribute.WorkflowType:
+		b.addEqual(&b.WorkflowType, s)
+	case searchattribute.ExecutionStatus:
+		status, ok := parseExecutionStatus(s)
+		if !ok {
+			return
+		}
+		if b.ExecutionStatus == nil {
+			b.ExecutionStatus = &status
+		} else if *b.ExecutionStatus != status {
+			b.Empty = true
+		}
+	}
+}
+
+func (b *Bounds) addEqual(bound **string, value string) {
+	if *bound == nil {
+		*bound = &value
+	} else if **bound != value {
+		b.Empty = true
+	}
+}
+
+func (q *Query) convertColName(expr sqlparser.Expr) (field, error) {
+	colName, isColName := expr.(*sqlparser.ColName)
+	if !isColName {
+		return field{}, query.NewConverterError("%s: must be a column name but was %T", query.InvalidExpressionErrMessage, expr)
+	}
+	name := strings.ReplaceAll(sqlparser.String(colName), "`", "")
+	if q.saMapper != nil && searchattribute.IsMappable(name) {
+		fieldName, err := q.saMapper.GetFieldName(name, q.namespaceName)
+		if err != nil {
+			return field{}, err
+		}
+		name = fieldName
+	}
+	valueType, err := q.saTypeMap.GetType(name)
+	if err != nil {
+		return field{}, query.NewConverterError("invalid search attribute: %s", name)
+	}
+	return field{name: name, valueType: valueType}, nil
+}
+
+// convertComparisonExprValue returns a string, int64, float64, bool or
+// a slice with each value of one of those types.
+func convertComparisonExprValue(expr sqlparser.Expr) (any, error) {
+	switch e := expr.(type) {
+	case *sqlparser.SQLVal:
+		return query.ParseSqlValue(sqlparser.String(e))
+	case sqlparser.BoolVal:
+		return bool(e), nil
+	case sqlparser.ValTuple:
+		// This is "in (1,2,3)" case.
+		var result []any
+		for _, expr := range e {
+			v, err := convertComparisonExprValue(expr)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, v)
+		}
+		return result, nil
+	case *sqlparser.ColName:
+		return nil, query.NewConverterError(
+			"%s: column name on the right side of comparison expression (did you forget to quote %q?)",
+			query.NotSupportedErrMessage,
+			sqlparser.String(expr),
+		)
+	default:
+		return nil, query.NewConverterError("%s: unexpected value type %T", query.InvalidExpressionErrMessage, expr)
+	}
+}
+
+// convertValues converts query values to the type of the values of the field:
+// string, float64, bool or time.Time.
+func convertValues(f field, values ...any) ([]any, error) {
+	result := make([]any, 0, len(values))
+	for _, value := range values {
+		converted, err := convertValue(f, value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+func convertValue(f field, value any) (any, error) {
+	invalidValueErr := query.NewConverterError(
+		"invalid value for search attribute %s of type %s: %#v", f.name, f.valueType.String(), value)
+
+	switch f.name {
+	case searchattribute.ExecutionStatus:
+		switch v := value.(type) {
+		case int64:
+			if _, ok := enumspb.WorkflowExecutionStatus_name[int32(v)]; !ok {
+				return nil, invalidValueErr
+			}
+			return enumspb.WorkflowExecutionStatus(v).String(), nil
+		case string:
+			if status, ok := parseExecutionStatus(v); ok {
+				return status.String(), nil
+			}
+			return nil, invalidValueErr
+		}
+	case searchattribute.ExecutionDuration:
+		if v, ok := value.(string); ok {
+			// To support durations passed as golang durations such as "300ms", "-1.5h" or "2h45m",
+			// and "hh:mm:ss" durations.
+			duration, err := timestamp.ParseDuration(v)
+			if err != nil {
+				if duration, err = timestamp.ParseHHMMSSDuration(v); err != nil {
+					return nil, invalidValueErr
+				}
+			}
+			return float64(duration.Nanoseconds()), nil
+		}
+	}
+
+	switch f.valueType {
+	case enumspb.INDEXED_VALUE_TYPE_INT, enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+		switch v := value.(type) {
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		case string:
+			if number, err := strconv.ParseFloat(v, 64); err == nil {
+				return number, nil
+			}
+		}
+	case enumspb.INDEXED_VALUE_TYPE_BOOL:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		switch v := value.(type) {
+		case int64:
+			return time.Unix(0, v).UTC(), nil
+		case string:
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return t, nil
+			}
+		}
+	default:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case int64, float64, bool:
+			return fmt.Sprint(v), nil
+		}
+	}
+	return nil, invalidValueErr
+}
+
+// parseExecutionStatus parses a workflow execution status ignoring case and underscores,
+// so "Completed", "completed" and "WORKFLOW_EXECUTION_STATUS_COMPLETED" are all accepted.
+func parseExecutionStatus(s string) (enumspb.WorkflowExecutionStatus, bool) {
+	normalize := func(s string) string {
+		s = strings.ToLower(strings.ReplaceAll(s, "_", ""))
+		return strings.TrimPrefix(s, "workflowexecutionstatus")
+	}
+	s = normalize(s)
+	for name, value := range enumspb.WorkflowExecutionStatus_shorthandValue {
+		if normalize(name) == s {
+			return enumspb.WorkflowExecutionStatus(value), true
+		}
+	}
+	return enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED, false
+}
@@ -0,0 +1,279 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilityquery
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+func testRecords() []*archiverspb.VisibilityRecord {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []*archiverspb.VisibilityRecord{
+		{
+			WorkflowId:       "order-1",
+			RunId:            "run-1",
+			WorkflowTypeName: "OrderWorkflow",
+			StartTime:        timestamppb.New(startTime),
+			CloseTime:        timestamppb.New(startTime.Add(time.Minute)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+			HistoryLength:    10,
+			SearchAttributes: map[string]string{
+				"CustomKeywordField": "gold",
+				"CustomIntField":     "5",
+				"CustomTextField":    "Shipped to Berlin",
+				"CustomBoolField":    "true",
+				"KeywordList01":      `["a","b"]`,
+			},
+		},
+		{
+			WorkflowId:       "order-2",
+			RunId:            "run-2",
+			WorkflowTypeName: "OrderWorkflow",
+			StartTime:        timestamppb.New(startTime),
+			CloseTime:        timestamppb.New(startTime.Add(time.Hour)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+			HistoryLength:    20,
+			SearchAttributes: map[string]string{
+				"CustomKeywordField": "silver",
+				"CustomIntField":     "15",
+				"CustomTextField":    "Lost in Paris",
+			},
+		},
+		{
+			WorkflowId:       "refund-1",
+			RunId:            "run-3",
+			WorkflowTypeName: "RefundWorkflow",
+			StartTime:        timestamppb.New(startTime.Add(time.Hour)),
+			CloseTime:        timestamppb.New(startTime.Add(2 * time.Hour)),
+			Status:           enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT,
+			HistoryLength:    30,
+		},
+	}
+}
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "", expected: []string{"run-1", "run-2", "run-3"}},
+		{query: "WorkflowId = 'order-1'", expected: []string{"run-1"}},
+		{query: "WorkflowId != 'order-1'", expected: []string{"run-2", "run-3"}},
+		{query: "WorkflowId = 'order-1' OR WorkflowType = 'RefundWorkflow'", expected: []string{"run-1", "run-3"}},
+		{query: "(WorkflowId = 'order-1' OR WorkflowId = 'order-2') AND ExecutionStatus = 'Failed'", expected: []string{"run-2"}},
+		{query: "WorkflowId IN ('order-2', 'refund-1')", expected: []string{"run-2", "run-3"}},
+		{query: "WorkflowId NOT IN ('order-2', 'refund-1')", expected: []string{"run-1"}},
+		{query: "WorkflowId STARTS_WITH 'order-'", expected: []string{"run-1", "run-2"}},
+		{query: "WorkflowId NOT STARTS_WITH 'order-'", expected: []string{"run-3"}},
+		{query: "ExecutionStatus = 2", expected: []string{"run-1"}},
+		{query: "ExecutionStatus = 'TIMED_OUT'", expected: []string{"run-3"}},
+		{query: "HistoryLength > 10", expected: []string{"run-2", "run-3"}},
+		{query: "HistoryLength BETWEEN 15 AND 25", expected: []string{"run-2"}},
+		{query: "HistoryLength NOT BETWEEN 15 AND 25", expected: []string{"run-1", "run-3"}},
+		{query: "CloseTime >= '2024-01-01T01:00:00Z'", expected: []string{"run-2", "run-3"}},
+		{query: "CloseTime BETWEEN '2024-01-01T00:00:00Z' AND '2024-01-01T00:30:00Z'", expected: []string{"run-1"}},
+		{query: "StartTime < '2024-01-01T00:30:00Z' AND CloseTime > '2024-01-01T00:30:00Z'", expected: []string{"run-2"}},
+		{query: "ExecutionDuration > '30m'", expected: []string{"run-2", "run-3"}},
+		{query: "ExecutionDuration <= '00:01:00'", expected: []string{"run-1"}},
+		{query: "CustomKeywordField = 'gold'", expected: []string{"run-1"}},
+		{query: "CustomKeywordField IS NULL", expected: []string{"run-3"}},
+		{query: "CustomKeywordField IS NOT NULL", expected: []string{"run-1", "run-2"}},
+		{query: "CustomIntField >= 10", expected: []string{"run-2"}},
+		{query: "CustomIntField != 5", expected: []string{"run-2", "run-3"}},
+		{query: "CustomTextField = 'berlin'", expected: []string{"run-1"}},
+		{query: "CustomTextField = 'lost luggage'", expected: []string{"run-2"}},
+		{query: "CustomTextField STARTS_WITH 'Par'", expected: []string{"run-2"}},
+		{query: "CustomBoolField = true", expected: []string{"run-1"}},
+		{query: "KeywordList01 = 'b'", expected: []string{"run-1"}},
+		{query: "KeywordList01 IN ('c', 'a')", expected: []string{"run-1"}},
+		{query: "`WorkflowType` = 'OrderWorkflow' ORDER BY CloseTime", expected: []string{"run-1", "run-2"}},
+	}
+
+	records := testRecords()
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := Parse(tc.query, searchattribute.TestNameTypeMap)
+			require.NoError(t, err)
+			var runIDs []string
+			for _, record := range records {
+				if q.Match(record) {
+					runIDs = append(runIDs, record.GetRunId())
+				}
+			}
+			require.Equal(t, tc.expected, runIDs)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	testCases := []string{
+		"WorkflowId = ",
+		"WorkflowId",
+		"UnknownField = 'a'",
+		"workflowid = 'a'",
+		"WorkflowId = order",
+		"WorkflowId LIKE 'order%'",
+		"NOT WorkflowId = 'a'",
+		"HistoryLength > 'ten'",
+		"CloseTime > '2024-01-01 00:00:00'",
+		"CustomBoolField = 1",
+		"ExecutionStatus = 100",
+		"ExecutionStatus = 'unknown'",
+		"WorkflowId STARTS_WITH 1",
+		"WorkflowId = 'a' LIMIT 10",
+		"GROUP BY ExecutionStatus",
+		"ORDER BY CustomTextField",
+	}
+
+	for _, queryString := range testCases {
+		t.Run(queryString, func(t *testing.T) {
+			_, err := Parse(queryString, searchattribute.TestNameTypeMap)
+			var converterErr *query.ConverterError
+			require.True(t, errors.As(err, &converterErr), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestParseWithMapper(t *testing.T) {
+	saMapper := &searchattribute.TestMapper{}
+	records := testRecords()
+
+	q, err := ParseWithMapper(
+		"AliasForCustomKeywordField = 'gold' AND WorkflowType = 'OrderWorkflow' ORDER BY AliasForCustomIntField",
+		searchattribute.TestNameTypeMap,
+		saMapper,
+		"test-namespace",
+	)
+	require.NoError(t, err)
+	require.True(t, q.Match(records[0]))
+	require.False(t, q.Match(records[1]))
+
+	_, err = ParseWithMapper("CustomKeywordField = 'gold'", searchattribute.TestNameTypeMap, saMapper, "test-namespace")
+	var converterErr *query.ConverterError
+	require.True(t, errors.As(err, &converterErr), "unexpected error: %v", err)
+}
+
+func TestBounds(t *testing.T) {
+	closeTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := enumspb.WORKFLOW_EXECUTION_STATUS_FAILED
+	workflowID := "wid"
+	workflowType := "type"
+	runID := "rid"
+
+	testCases := []struct {
+		query    string
+		expected Bounds
+	}{
+		{query: "", expected: Bounds{}},
+		{query: "ORDER BY StartTime", expected: Bounds{}},
+		{
+			query: "WorkflowId = 'wid' AND (WorkflowType = 'type' AND RunId = 'rid') AND ExecutionStatus = 'failed'",
+			expected: Bounds{
+				WorkflowID:      &workflowID,
+				WorkflowType:    &workflowType,
+				RunID:           &runID,
+				ExecutionStatus: &status,
+			},
+		},
+		{
+			query:    "CloseTime > '2024-01-01T00:00:00Z' AND CloseTime <= '2024-01-02T00:00:00Z' AND CloseTime < '2024-01-03T00:00:00Z'",
+			expected: Bounds{EarliestCloseTime: closeTime.Add(time.Nanosecond), LatestCloseTime: closeTime.Add(24 * time.Hour)},
+		},
+		{
+			query:    "CloseTime BETWEEN '2024-01-01T00:00:00Z' AND '2024-01-02T00:00:00Z'",
+			expected: Bounds{EarliestCloseTime: closeTime, LatestCloseTime: closeTime.Add(24 * time.Hour)},
+		},
+		{
+			query:    "CloseTime = 1704067200000000000",
+			expected: Bounds{EarliestCloseTime: closeTime, LatestCloseTime: closeTime},
+		},
+		{
+			query:    "WorkflowId = 'wid' AND WorkflowId = 'another wid'",
+			expected: Bounds{WorkflowID: &workflowID, Empty: true},
+		},
+		{
+			query:    "ExecutionStatus = 'Failed' AND ExecutionStatus = 'Completed'",
+			expected: Bounds{ExecutionStatus: &status, Empty: true},
+		},
+		{query: "WorkflowId = 'wid' OR CloseTime > '2024-01-01T00:00:00Z'", expected: Bounds{}},
+		{query: "WorkflowId != 'wid' AND CloseTime NOT BETWEEN 1 AND 2", expected: Bounds{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, err := Parse(tc.query, searchattribute.TestNameTypeMap)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, q.Bounds())
+		})
+	}
+}
+
+func TestSortedPage(t *testing.T) {
+	records := testRecords()
+
+	q, err := Parse("ORDER BY CustomIntField DESC, CloseTime", searchattribute.TestNameTypeMap)
+	require.NoError(t, err)
+	require.True(t, q.HasOrderBy())
+
+	var runIDs []string
+	var nextPageToken []byte
+	for {
+		var page []*archiverspb.VisibilityRecord
+		page, nextPageToken, err = q.SortedPage(records, 2, nextPageToken)
+		require.NoError(t, err)
+		for _, record := range page {
+			runIDs = append(runIDs, record.GetRunId())
+		}
+		if nextPageToken == nil {
+			break
+		}
+	}
+	// run-3 has no CustomIntField and is sorted last.
+	require.Equal(t, []string{"run-2", "run-1", "run-3"}, runIDs)
+
+	_, _, err = q.SortedPage(records, 2, []byte("invalid"))
+	require.ErrorIs(t, err, ErrInvalidNextPageToken)
+
+	tooManyRecords := make([]*archiverspb.VisibilityRecord, MaxSortedRecords+1)
+	for i := range tooManyRecords {
+		tooManyRecords[i] = records[0]
+	}
+	_, _, err = q.SortedPage(tooManyRecords, 2, nil)
+	require.ErrorIs(t, err, ErrTooManySortedRecords)
+
+	q, err = Parse("WorkflowType = 'OrderWorkflow'", searchattribute.TestNameTypeMap)
+	require.NoError(t, err)
+	require.False(t, q.HasOrderBy())
+}
@@ -0,0 +1,289 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilityquery
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	enumspb "go.temporal.io/api/enums/v1"
+
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	// record gives access to the values of an archived visibility record by search attribute name.
+	record struct {
+		*archiverspb.VisibilityRecord
+		saTypeMap searchattribute.NameTypeMap
+		// searchAttributes are decoded on first use.
+		searchAttributes map[string][]any
+	}
+)
+
+func newRecord(visibilityRecord *archiverspb.VisibilityRecord, saTypeMap searchattribute.NameTypeMap) *record {
+	return &record{
+		VisibilityRecord: visibilityRecord,
+		saTypeMap:        saTypeMap,
+	}
+}
+
+// values returns the values of a field as strings, float64, bool or time.Time.
+// Fields which are not set, or not stored in archived records, have no values.
+// Keyword lists and search attributes set to a list have one value per element.
+func (r *record) values(f field) []any {
+	switch f.name {
+	case searchattribute.WorkflowID:
+		return []any{r.GetWorkflowId()}
+	case searchattribute.RunID:
+		return []any{r.GetRunId()}
+	case searchattribute.WorkflowType:
+		return []any{r.GetWorkflowTypeName()}
+	case searchattribute.StartTime:
+		return timeValues(r.GetStartTime().AsTime(), r.StartTime != nil)
+	case searchattribute.ExecutionTime:
+		return timeValues(r.GetExecutionTime().AsTime(), r.ExecutionTime != nil)
+	case searchattribute.CloseTime:
+		return timeValues(r.GetCloseTime().AsTime(), r.CloseTime != nil)
+	case searchattribute.ExecutionStatus:
+		return []any{r.GetStatus().String()}
+	case searchattribute.HistoryLength:
+		return []any{float64(r.GetHistoryLength())}
+	case searchattribute.ExecutionDuration:
+		if r.CloseTime == nil {
+			return nil
+		}
+		// ExecutionTime isn't set for workflows without a start delay.
+		executionTime := r.GetExecutionTime().AsTime()
+		if r.ExecutionTime == nil || executionTime.IsZero() {
+			executionTime = r.GetStartTime().AsTime()
+		}
+		return []any{float64(r.GetCloseTime().AsTime().Sub(executionTime).Nanoseconds())}
+	}
+
+	// Other system search attributes aren't archived and are never found in the search attributes map.
+	if r.searchAttributes == nil {
+		r.searchAttributes = r.decodeSearchAttributes()
+	}
+	return r.searchAttributes[f.name]
+}
+
+// any returns true if any value of the field satisfies fn.
+func (r *record) any(f field, fn func(v any) bool) bool {
+	for _, v := range r.values(f) {
+		if fn(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *record) decodeSearchAttributes() map[string][]any {
+	result := make(map[string][]any, len(r.GetSearchAttributes()))
+	for name, valueStr := range r.GetSearchAttributes() {
+		valueType, err := r.saTypeMap.GetType(name)
+		if err != nil {
+			continue
+		}
+		// Values which can't be parsed don't match any condition.
+		searchAttributes, err := searchattribute.Parse(map[string]string{name: valueStr}, &r.saTypeMap)
+		if err != nil {
+			continue
+		}
+		value, err := searchattribute.DecodeValue(searchAttributes.GetIndexedFields()[name], valueType, true)
+		if err != nil {
+			continue
+		}
+		result[name] = normalizeValue(value)
+	}
+	return result
+}
+
+// normalizeValue converts a decoded search attribute value to a slice of strings, float64, bool or time.Time.
+func normalizeValue(value any) []any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case int64:
+		return []any{float64(v)}
+	case []string:
+		return toAnySlice(v)
+	case []int64:
+		result := make([]any, 0, len(v))
+		for _, i := range v {
+			result = append(result, float64(i))
+		}
+		return result
+	case []float64:
+		return toAnySlice(v)
+	case []bool:
+		return toAnySlice(v)
+	case []time.Time:
+		return toAnySlice(v)
+	default:
+		return []any{v}
+	}
+}
+
+func toAnySlice[T any](values []T) []any {
+	result := make([]any, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
+
+func timeValues(t time.Time, isSet bool) []any {
+	if !isSet {
+		return nil
+	}
+	return []any{t}
+}
+
+// compare returns -1, 0 or 1 if a is less than, equal to or greater than b.
+// It returns false if a and b are not comparable.
+func compare(a, b any) (int, bool) {
+	switch a := a.(type) {
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	case float64:
+		if b, ok := b.(float64); ok {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0, true
+			case b:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b), true
+		}
+	}
+	return 0, false
+}
+
+func compareWith(f field, value any, fn func(c int) bool) predicate {
+	return func(r *record) bool {
+		return r.any(f, func(v any) bool {
+			c, ok := compare(v, value)
+			return ok && fn(c)
+		})
+	}
+}
+
+// equalTo matches records with a value of the field equal to value.
+// Like in the live visibility store, Text fields match if they contain any word of value.
+func equalTo(f field, value any) predicate {
+	if f.valueType == enumspb.INDEXED_VALUE_TYPE_TEXT {
+		words := tokenize(value.(string))
+		return func(r *record) bool {
+			return r.any(f, func(v any) bool {
+				for token := range tokenize(v.(string)) {
+					if _, ok := words[token]; ok {
+						return true
+					}
+				}
+				return false
+			})
+		}
+	}
+	return compareWith(f, value, func(c int) bool { return c == 0 })
+}
+
+// in matches records with a value of the field equal to any of values.
+// Text fields match if they contain any of the values as a word.
+func in(f field, values []any) predicate {
+	return func(r *record) bool {
+		return r.any(f, func(v any) bool {
+			if f.valueType == enumspb.INDEXED_VALUE_TYPE_TEXT {
+				words := tokenize(v.(string))
+				for _, value := range values {
+					if _, ok := words[strings.ToLower(value.(string))]; ok {
+						return true
+					}
+				}
+				return false
+			}
+			for _, value := range values {
+				if c, ok := compare(v, value); ok && c == 0 {
+					return true
+				}
+			}
+			return false
+		})
+	}
+}
+
+// hasPrefix returns true if the value starts with prefix. Text fields match if any of their words starts with prefix.
+func hasPrefix(f field, value any, prefix string) bool {
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+	if f.valueType == enumspb.INDEXED_VALUE_TYPE_TEXT {
+		prefix = strings.ToLower(prefix)
+		for word := range tokenize(s) {
+			if strings.HasPrefix(word, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.HasPrefix(s, prefix)
+}
+
+func not(p predicate) predicate {
+	return func(r *record) bool { return !p(r) }
+}
+
+// tokenize splits a text into lowercase words.
+func tokenize(text string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(text), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+	})
+	result := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		result[word] = struct{}{}
+	}
+	return result
+}
@@ -0,0 +1,239 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+)
+
+const (
+	auditSinkFile   = "file"
+	auditSinkSyslog = "syslog"
+
+	auditDecisionAllow = "allow"
+	auditDecisionDeny  = "deny"
+	auditDecisionError = "error"
+
+	auditRedacted = "REDACTED"
+
+	defaultAuditBufferSize = 10000
+)
+
+type (
+	// AuditRecord is a single authorization decision
+	AuditRecord struct {
+		Time         time.Time `json:"time"`
+		Subject      string    `json:"subject,omitempty"`
+		API          string    `json:"api"`
+		Namespace    string    `json:"namespace,omitempty"`
+		WorkflowID   string    `json:"workflowId,omitempty"`
+		RunID        string    `json:"runId,omitempty"`
+		WorkflowType string    `json:"workflowType,omitempty"`
+		TaskQueue    string    `json:"taskQueue,omitempty"`
+		// Decision is "allow", "deny", or "error" if the authorizer failed
+		Decision string `json:"decision"`
+		Reason   string `json:"reason,omitempty"`
+	}
+
+	// AuditSink persists audit records
+	AuditSink interface {
+		Write(record *AuditRecord) error
+		Close() error
+	}
+
+	// AuditLogger records authorization decisions to an AuditSink, applying the sampling
+	// and redaction settings from dynamic config. Records are written to the sink in the
+	// background, so that calls aren't blocked on the sink.
+	AuditLogger struct {
+		sink             AuditSink
+		logger           log.Logger
+		metricsHandler   metrics.Handler
+		samplingRate     dynamicconfig.FloatPropertyFnWithNamespaceFilter
+		redactSubject    dynamicconfig.BoolPropertyFnWithNamespaceFilter
+		redactWorkflowID dynamicconfig.BoolPropertyFnWithNamespaceFilter
+
+		recordC   chan *AuditRecord
+		shutdownC chan struct{}
+		doneC     chan struct{}
+		closeOnce sync.Once
+		closeErr  error
+	}
+
+	hasWorkflowExecution interface {
+		GetWorkflowExecution() *commonpb.WorkflowExecution
+	}
+
+	hasWorkflowID interface {
+		GetWorkflowId() string
+	}
+)
+
+// NewAuditSinkFromConfig creates the audit sink configured in cfg. It returns nil if auditing is disabled.
+func NewAuditSinkFromConfig(cfg *config.AuthorizationAudit) (AuditSink, error) {
+	switch strings.ToLower(cfg.Sink) {
+	case "":
+		return nil, nil
+	case auditSinkFile:
+		return NewFileAuditSink(&cfg.File)
+	case auditSinkSyslog:
+		return NewSyslogAuditSink(&cfg.Syslog)
+	}
+	return nil, fmt.Errorf("unknown audit sink: %s", cfg.Sink)
+}
+
+// NewAuditLogger creates an AuditLogger writing to sink. Up to bufferSize records wait to be
+// written, further records are dropped until the sink catches up.
+func NewAuditLogger(
+	sink AuditSink,
+	bufferSize int,
+	dc *dynamicconfig.Collection,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+) *AuditLogger {
+	if bufferSize <= 0 {
+		bufferSize = defaultAuditBufferSize
+	}
+	a := &AuditLogger{
+		sink:             sink,
+		logger:           logger,
+		metricsHandler:   metricsHandler,
+		samplingRate:     dc.GetFloatPropertyFilteredByNamespace(dynamicconfig.FrontendAuthorizationAuditSamplingRate, 1.0),
+		redactSubject:    dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.FrontendAuthorizationAuditRedactSubject, false),
+		redactWorkflowID: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.FrontendAuthorizationAuditRedactWorkflowID, false),
+		recordC:          make(chan *AuditRecord, bufferSize),
+		shutdownC:        make(chan struct{}),
+		doneC:            make(chan struct{}),
+	}
+	go a.writeLoop()
+	return a
+}
+
+// Record queues the authorization decision for a call to be written to the audit sink. Allowed
+// calls are sampled, while denied calls and authorizer failures are always recorded, unless the
+// buffer is full or the logger is closed. It is a no-op on a nil AuditLogger.
+func (a *AuditLogger) Record(claims *Claims, target *CallTarget, result Result, authErr error) {
+	if a == nil {
+		return
+	}
+
+	record := &AuditRecord{
+		Time:         time.Now().UTC(),
+		API:          target.APIName,
+		Namespace:    target.Namespace,
+		WorkflowType: target.WorkflowType,
+		TaskQueue:    target.TaskQueue,
+		Reason:       result.Reason,
+	}
+	switch {
+	case authErr != nil:
+		record.Decision = auditDecisionError
+		record.Reason = authErr.Error()
+	case result.Decision == DecisionAllow:
+		if rand.Float64() >= a.samplingRate(target.Namespace) {
+			return
+		}
+		record.Decision = auditDecisionAllow
+	default:
+		record.Decision = auditDecisionDeny
+	}
+
+	if claims != nil {
+		record.Subject = claims.Subject
+		if record.Subject != "" && a.redactSubject(target.Namespace) {
+			record.Subject = auditRedacted
+		}
+	}
+	switch r := target.Request.(type) {
+	case hasWorkflowExecution:
+		record.WorkflowID = r.GetWorkflowExecution().GetWorkflowId()
+		record.RunID = r.GetWorkflowExecution().GetRunId()
+	case hasWorkflowID:
+		record.WorkflowID = r.GetWorkflowId()
+	}
+	if record.WorkflowID != "" && a.redactWorkflowID(target.Namespace) {
+		record.WorkflowID = auditRedacted
+	}
+
+	select {
+	case <-a.shutdownC:
+		return
+	default:
+	}
+	select {
+	case a.recordC <- record:
+	default:
+		a.metricsHandler.Counter(metrics.AuthorizationAuditRecordsDropped.Name()).Record(1)
+	}
+}
+
+// Close writes the buffered records and closes the underlying audit sink.
+func (a *AuditLogger) Close() error {
+	if a == nil {
+		return nil
+	}
+	a.closeOnce.Do(func() {
+		close(a.shutdownC)
+		<-a.doneC
+		a.closeErr = a.sink.Close()
+	})
+	return a.closeErr
+}
+
+func (a *AuditLogger) writeLoop() {
+	defer close(a.doneC)
+	for {
+		select {
+		case record := <-a.recordC:
+			a.write(record)
+		case <-a.shutdownC:
+			for {
+				select {
+				case record := <-a.recordC:
+					a.write(record)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (a *AuditLogger) write(record *AuditRecord) {
+	if err := a.sink.Write(record); err != nil {
+		a.logger.Error("Unable to write authorization audit record.", tag.Error(err))
+	}
+}
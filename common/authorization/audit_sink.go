@@ -0,0 +1,202 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/config"
+)
+
+const (
+	defaultAuditFileMaxSizeMB  = 100
+	defaultAuditFileMaxBackups = 5
+
+	auditFileRotateRetryInterval = time.Minute
+)
+
+type (
+	// fileAuditSink writes audit records as JSON lines. When the file would exceed its maximum
+	// size it is renamed to <path>.1, existing backups are shifted by one, and a new file is started.
+	fileAuditSink struct {
+		sync.Mutex
+		path       string
+		maxSize    int64
+		maxBackups int
+		timeSource clock.TimeSource
+		file       *os.File
+		size       int64
+		// nextRotateTime is set after a failed rotation, writes go on to the current file until then.
+		nextRotateTime time.Time
+	}
+
+	// MemoryAuditSink keeps audit records in memory. It is intended for tests.
+	MemoryAuditSink struct {
+		sync.Mutex
+		records []*AuditRecord
+	}
+)
+
+var _ AuditSink = (*fileAuditSink)(nil)
+var _ AuditSink = (*MemoryAuditSink)(nil)
+
+// NewFileAuditSink creates an audit sink writing to the file configured in cfg.
+func NewFileAuditSink(cfg *config.AuditFileSink) (AuditSink, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("audit file path is not set")
+	}
+	s := &fileAuditSink{
+		path:       cfg.Path,
+		maxSize:    int64(cfg.MaxSizeMB) * 1024 * 1024,
+		maxBackups: cfg.MaxBackups,
+		timeSource: clock.NewRealTimeSource(),
+	}
+	if s.maxSize <= 0 {
+		s.maxSize = defaultAuditFileMaxSizeMB * 1024 * 1024
+	}
+	if s.maxBackups <= 0 {
+		s.maxBackups = defaultAuditFileMaxBackups
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileAuditSink) Write(record *AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.file == nil {
+		return errors.New("audit file is closed")
+	}
+	var rotateErr error
+	if s.size > 0 && s.size+int64(len(line)) > s.maxSize && !s.timeSource.Now().Before(s.nextRotateTime) {
+		// the record is written even if rotation fails, rotation is retried after a while
+		if rotateErr = s.rotate(); rotateErr != nil {
+			s.nextRotateTime = s.timeSource.Now().Add(auditFileRotateRetryInterval)
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return err
+	}
+	return rotateErr
+}
+
+func (s *fileAuditSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *fileAuditSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open audit file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("unable to open audit file: %w", err)
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+// rotate moves the audit file to the first backup and starts a new file. The current file is
+// kept open until the new file is opened, so writes go on to the current file when rotation fails.
+// Backups are only shifted up to the first missing one, so that retrying a failed rotation
+// doesn't shift the backups again and drop the oldest ones.
+func (s *fileAuditSink) rotate() error {
+	last := s.maxBackups
+	for i := 1; i < s.maxBackups; i++ {
+		if _, err := os.Lstat(s.backupPath(i)); errors.Is(err, os.ErrNotExist) {
+			last = i
+			break
+		}
+	}
+	for i := last - 1; i > 0; i-- {
+		if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil {
+			return fmt.Errorf("unable to rotate audit file: %w", err)
+		}
+	}
+	if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+		return fmt.Errorf("unable to rotate audit file: %w", err)
+	}
+
+	previous := s.file
+	if err := s.open(); err != nil {
+		return err
+	}
+	return previous.Close()
+}
+
+func (s *fileAuditSink) backupPath(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+// NewMemoryAuditSink creates an empty in-memory audit sink.
+func NewMemoryAuditSink() *MemoryAuditSink {
+	return &MemoryAuditSink{}
+}
+
+func (s *MemoryAuditSink) Write(record *AuditRecord) error {
+	s.Lock()
+	defer s.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *MemoryAuditSink) Close() error {
+	return nil
+}
+
+// Records returns a copy of the records written so far.
+func (s *MemoryAuditSink) Records() []*AuditRecord {
+	s.Lock()
+	defer s.Unlock()
+	return append([]*AuditRecord(nil), s.records...)
+}
@@ -0,0 +1,71 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build !windows && !plan9
+
+package authorization
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+
+	"go.temporal.io/server/common/config"
+)
+
+const defaultAuditSyslogTag = "temporal-audit"
+
+type syslogAuditSink struct {
+	writer *syslog.Writer
+}
+
+var _ AuditSink = (*syslogAuditSink)(nil)
+
+// NewSyslogAuditSink creates an audit sink writing JSON encoded records to syslog.
+func NewSyslogAuditSink(cfg *config.AuditSyslogSink) (AuditSink, error) {
+	syslogTag := cfg.Tag
+	if syslogTag == "" {
+		syslogTag = defaultAuditSyslogTag
+	}
+	writer, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_AUTH, syslogTag)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to syslog: %w", err)
+	}
+	return &syslogAuditSink{writer: writer}, nil
+}
+
+func (s *syslogAuditSink) Write(record *AuditRecord) error {
+	msg, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if record.Decision == auditDecisionAllow {
+		return s.writer.Info(string(msg))
+	}
+	return s.writer.Warning(string(msg))
+}
+
+func (s *syslogAuditSink) Close() error {
+	return s.writer.Close()
+}
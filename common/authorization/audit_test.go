@@ -0,0 +1,234 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/metrics/metricstest"
+)
+
+func TestAuditLogger_SamplingAndRedaction(t *testing.T) {
+	sink := NewMemoryAuditSink()
+	dc := dynamicconfig.NewCollection(dynamicconfig.StaticClient{
+		dynamicconfig.FrontendAuthorizationAuditSamplingRate:     0.0,
+		dynamicconfig.FrontendAuthorizationAuditRedactSubject:    true,
+		dynamicconfig.FrontendAuthorizationAuditRedactWorkflowID: true,
+	}, log.NewNoopLogger())
+	auditLogger := NewAuditLogger(sink, 0, dc, metrics.NoopMetricsHandler, log.NewNoopLogger())
+
+	claims := &Claims{Subject: "alice"}
+	target := &CallTarget{
+		APIName:   "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution",
+		Namespace: testNamespace,
+		Request:   &workflowservice.StartWorkflowExecutionRequest{WorkflowId: "workflow-id"},
+	}
+
+	// allowed calls are sampled out, denied calls are always recorded
+	auditLogger.Record(claims, target, resultAllow, nil)
+	auditLogger.Record(claims, target, resultDeny, nil)
+	require.NoError(t, auditLogger.Close())
+
+	records := sink.Records()
+	require.Len(t, records, 1)
+	require.Equal(t, auditDecisionDeny, records[0].Decision)
+	require.Equal(t, auditRedacted, records[0].Subject)
+	require.Equal(t, auditRedacted, records[0].WorkflowID)
+}
+
+func TestAuditLogger_Nil(t *testing.T) {
+	var auditLogger *AuditLogger
+	auditLogger.Record(nil, &CallTarget{}, resultAllow, nil)
+	require.NoError(t, auditLogger.Close())
+}
+
+func TestFileAuditSink_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileAuditSink(&config.AuditFileSink{Path: path, MaxBackups: 2})
+	require.NoError(t, err)
+	// rotate after every record
+	sink.(*fileAuditSink).maxSize = 1
+
+	auditLogger := NewAuditLogger(sink, 0, dynamicconfig.NewNoopCollection(), metrics.NoopMetricsHandler, log.NewNoopLogger())
+	for _, workflowID := range []string{"wf-1", "wf-2", "wf-3", "wf-4"} {
+		auditLogger.Record(&Claims{Subject: "alice"}, &CallTarget{
+			APIName:   "/temporal.api.workflowservice.v1.WorkflowService/ResetWorkflowExecution",
+			Namespace: testNamespace,
+			Request: &workflowservice.ResetWorkflowExecutionRequest{
+				WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: workflowID},
+			},
+		}, resultAllow, nil)
+	}
+	require.NoError(t, auditLogger.Close())
+
+	for path, workflowID := range map[string]string{
+		path:        "wf-4",
+		path + ".1": "wf-3",
+		path + ".2": "wf-2",
+	} {
+		records := readAuditFile(t, path)
+		require.Len(t, records, 1)
+		require.Equal(t, workflowID, records[0].WorkflowID)
+		require.Equal(t, "alice", records[0].Subject)
+		require.Equal(t, auditDecisionAllow, records[0].Decision)
+	}
+	_, err = os.Stat(path + ".3")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileAuditSink_RotationFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileAuditSink(&config.AuditFileSink{Path: path, MaxBackups: 1})
+	require.NoError(t, err)
+	// rotate after every record
+	sink.(*fileAuditSink).maxSize = 1
+	timeSource := clock.NewEventTimeSource().Update(time.Now())
+	sink.(*fileAuditSink).timeSource = timeSource
+
+	// a non-empty directory in place of the backup file makes rotation fail
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "blocked"), 0700))
+
+	require.NoError(t, sink.Write(&AuditRecord{WorkflowID: "wf-1"}))
+	require.Error(t, sink.Write(&AuditRecord{WorkflowID: "wf-2"}))
+	records := readAuditFile(t, path)
+	require.Len(t, records, 2)
+	require.Equal(t, "wf-2", records[1].WorkflowID)
+
+	// rotation is not retried right away
+	require.NoError(t, os.RemoveAll(path+".1"))
+	require.NoError(t, sink.Write(&AuditRecord{WorkflowID: "wf-3"}))
+	require.Len(t, readAuditFile(t, path), 3)
+
+	// writes rotate again after the retry interval
+	timeSource.Advance(auditFileRotateRetryInterval)
+	require.NoError(t, sink.Write(&AuditRecord{WorkflowID: "wf-4"}))
+	require.NoError(t, sink.Close())
+	require.Len(t, readAuditFile(t, path+".1"), 3)
+	records = readAuditFile(t, path)
+	require.Len(t, records, 1)
+	require.Equal(t, "wf-4", records[0].WorkflowID)
+}
+
+func TestFileAuditSink_RotationShiftsBackupsUpToGap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileAuditSink(&config.AuditFileSink{Path: path, MaxBackups: 3})
+	require.NoError(t, err)
+	sink.(*fileAuditSink).maxSize = 1
+
+	require.NoError(t, os.WriteFile(path+".1", []byte("1\n"), 0600))
+	require.NoError(t, os.WriteFile(path+".3", []byte("3\n"), 0600))
+	require.NoError(t, sink.Write(&AuditRecord{WorkflowID: "wf-1"}))
+	require.NoError(t, sink.Write(&AuditRecord{WorkflowID: "wf-2"}))
+	require.NoError(t, sink.Close())
+
+	for path, content := range map[string]string{
+		path + ".2": "1\n",
+		path + ".3": "3\n",
+	} {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, content, string(data))
+	}
+	require.Equal(t, "wf-1", readAuditFile(t, path+".1")[0].WorkflowID)
+	require.Equal(t, "wf-2", readAuditFile(t, path)[0].WorkflowID)
+}
+
+type blockingAuditSink struct {
+	MemoryAuditSink
+	writingC chan struct{}
+	unblockC chan struct{}
+}
+
+func (s *blockingAuditSink) Write(record *AuditRecord) error {
+	s.writingC <- struct{}{}
+	<-s.unblockC
+	return s.MemoryAuditSink.Write(record)
+}
+
+func TestAuditLogger_DropsRecordsWhenBufferIsFull(t *testing.T) {
+	sink := &blockingAuditSink{writingC: make(chan struct{}, 3), unblockC: make(chan struct{})}
+	metricsHandler := metricstest.NewCaptureHandler()
+	capture := metricsHandler.StartCapture()
+	defer metricsHandler.StopCapture(capture)
+	auditLogger := NewAuditLogger(sink, 1, dynamicconfig.NewNoopCollection(), metricsHandler, log.NewNoopLogger())
+
+	target := &CallTarget{APIName: "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution"}
+	auditLogger.Record(nil, target, resultDeny, nil)
+	// the first record is being written, the second one is buffered and the third one is dropped
+	<-sink.writingC
+	auditLogger.Record(nil, target, resultDeny, nil)
+	auditLogger.Record(nil, target, resultDeny, nil)
+
+	close(sink.unblockC)
+	require.NoError(t, auditLogger.Close())
+	require.Len(t, sink.Records(), 2)
+	require.Len(t, capture.Snapshot()[metrics.AuthorizationAuditRecordsDropped.Name()], 1)
+
+	// records are dropped after the logger is closed
+	auditLogger.Record(nil, target, resultDeny, nil)
+	require.Len(t, sink.Records(), 2)
+}
+
+func TestNewAuditSinkFromConfig(t *testing.T) {
+	sink, err := NewAuditSinkFromConfig(&config.AuthorizationAudit{})
+	require.NoError(t, err)
+	require.Nil(t, sink)
+
+	_, err = NewAuditSinkFromConfig(&config.AuthorizationAudit{Sink: "kafka"})
+	require.Error(t, err)
+
+	_, err = NewAuditSinkFromConfig(&config.AuthorizationAudit{Sink: "file"})
+	require.Error(t, err)
+}
+
+func readAuditFile(t *testing.T, path string) []*AuditRecord {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var records []*AuditRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record AuditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, &record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
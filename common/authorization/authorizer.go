@@ -31,7 +31,11 @@ import (
 	"fmt"
 	"strings"
 
+	commonpb "go.temporal.io/api/common/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+
 	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
 )
 
 const (
@@ -43,13 +47,16 @@ const (
 
 // @@@SNIPSTART temporal-common-authorization-authorizer-calltarget
 // CallTarget is contains information for Authorizer to make a decision.
-// It can be extended to include resources like WorkflowType and TaskQueue
 type CallTarget struct {
 	// APIName must be the full API function name.
 	// Example: "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution".
 	APIName string
 	// If a Namespace is not being targeted this be set to an empty string.
 	Namespace string
+	// WorkflowType is the name of the workflow type targeted by the request, if the request carries one.
+	WorkflowType string
+	// TaskQueue is the name of the task queue targeted by the request, if the request carries one.
+	TaskQueue string
 	// Request contains a deserialized copy of the API request object
 	Request interface{}
 }
@@ -76,17 +83,57 @@ type Authorizer interface {
 
 // @@@SNIPEND
 
-type hasNamespace interface {
-	GetNamespace() string
-}
+type (
+	hasNamespace interface {
+		GetNamespace() string
+	}
+
+	hasWorkflowType interface {
+		GetWorkflowType() *commonpb.WorkflowType
+	}
+
+	hasTaskQueue interface {
+		GetTaskQueue() *taskqueuepb.TaskQueue
+	}
 
+	hasTaskQueueName interface {
+		GetTaskQueue() string
+	}
+
+	// hasTaskToken is implemented by the requests made with the token of a polled task.
+	hasTaskToken interface {
+		GetTaskToken() []byte
+	}
+)
+
+// GetAuthorizerFromConfig creates the authorizer configured in config.
+// Deprecated: use NewAuthorizerFromConfig, which supports authorizers that need a logger
+// or run background routines. Polling for policy changes of the policy authorizer runs until
+// the server it is passed to with temporal.WithAuthorizer stops.
 func GetAuthorizerFromConfig(config *config.Authorization) (Authorizer, error) {
+	return NewAuthorizerFromConfig(config, log.NewNoopLogger(), nil)
+}
+
+// NewAuthorizerFromConfig creates the authorizer configured in config. Background routines
+// of the authorizer, such as polling for policy changes, stop when doneCh is closed or, once
+// the authorizer is passed to temporal.WithAuthorizer, when the server stops.
+func NewAuthorizerFromConfig(
+	config *config.Authorization,
+	logger log.Logger,
+	doneCh <-chan interface{},
+) (Authorizer, error) {
 
 	switch strings.ToLower(config.Authorizer) {
 	case "":
 		return NewNoopAuthorizer(), nil
 	case "default":
 		return NewDefaultAuthorizer(), nil
+	case "policy":
+		authorizer, err := NewPolicyAuthorizer(&config.PolicyAuthorizer, logger, doneCh)
+		if err != nil {
+			return nil, err
+		}
+		return authorizer, nil
 	}
 	return nil, fmt.Errorf("unknown authorizer: %s", config.Authorizer)
 }
@@ -55,6 +55,8 @@ var resultDeny = Result{Decision: DecisionDeny}
 //	Namespace Admin is allowed to access all APIs on their namespaces.
 //	Namespace Writer is allowed to access non admin APIs on their namespaces.
 //	Namespace Reader is allowed to access non admin readonly APIs on their namespaces.
+//	Scoped roles apply the same way as namespace roles, but only to calls targeting a matching
+//	workflow type or task queue.
 func (a *defaultAuthorizer) Authorize(_ context.Context, claims *Claims, target *CallTarget) (Result, error) {
 	// APIs that are essentially read-only health checks with no sensitive information are
 	// always allowed
@@ -74,7 +76,7 @@ func (a *defaultAuthorizer) Authorize(_ context.Context, claims *Claims, target
 	case api.ScopeNamespace:
 		// Note: system-level claims apply across all namespaces.
 		// Note: if claims.Namespace is nil or target.Namespace is not found, the lookup will return zero.
-		hasRole = claims.System | claims.Namespaces[target.Namespace]
+		hasRole = claims.System | claims.Namespaces[target.Namespace] | claims.scopedRole(target)
 	default:
 		return resultDeny, nil
 	}
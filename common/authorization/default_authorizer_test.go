@@ -57,6 +57,16 @@ var (
 			"bar": RoleAdmin,
 		},
 	}
+	claimsPaymentsQueueWriter = Claims{
+		Scopes: map[string][]ScopedRole{
+			testNamespace: {{Kind: ScopeKindTaskQueue, Pattern: "payments-*", Role: RoleWriter}},
+		},
+	}
+	claimsRefundWorkflowWriter = Claims{
+		Scopes: map[string][]ScopedRole{
+			testNamespace: {{Kind: ScopeKindWorkflowType, Pattern: "Refund", Role: RoleWriter}},
+		},
+	}
 	claimsSystemAdmin = Claims{
 		System: RoleAdmin,
 	}
@@ -90,6 +100,18 @@ var (
 		APIName:   "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution",
 		Namespace: testNamespace,
 	}
+	targetStartPaymentsWorkflow = CallTarget{
+		APIName:      "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution",
+		Namespace:    testNamespace,
+		WorkflowType: "Refund",
+		TaskQueue:    "payments-refunds",
+	}
+	targetStartOrdersWorkflow = CallTarget{
+		APIName:      "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution",
+		Namespace:    testNamespace,
+		WorkflowType: "Order",
+		TaskQueue:    "orders",
+	}
 	targetAdminAPI = CallTarget{
 		APIName:   "/temporal.server.api.adminservice.v1.AdminService/AddSearchAttributes",
 		Namespace: testNamespace,
@@ -164,6 +186,13 @@ func (s *defaultAuthorizerSuite) TestAuthorize() {
 		{"NamespaceReaderOnListWorkflow", claimsNamespaceReader, targetGetSystemInfo, DecisionAllow},
 		{"NamespaceReaderOnOperatorNamespaceRead", claimsNamespaceReader, targetOperatorNamespaceRead, DecisionAllow},
 
+		// Scoped roles are only allowed on matching workflow types or task queues
+		{"TaskQueueWriterOnMatchingTaskQueue", claimsPaymentsQueueWriter, targetStartPaymentsWorkflow, DecisionAllow},
+		{"TaskQueueWriterOnOtherTaskQueue", claimsPaymentsQueueWriter, targetStartOrdersWorkflow, DecisionDeny},
+		{"TaskQueueWriterWithoutTaskQueue", claimsPaymentsQueueWriter, targetStartWorkflow, DecisionDeny},
+		{"WorkflowTypeWriterOnMatchingWorkflowType", claimsRefundWorkflowWriter, targetStartPaymentsWorkflow, DecisionAllow},
+		{"WorkflowTypeWriterOnOtherWorkflowType", claimsRefundWorkflowWriter, targetStartOrdersWorkflow, DecisionDeny},
+
 		// healthcheck allowed to everyone
 		{"RoleNoneOnGetSystemInfo", claimsNone, targetGetSystemInfo, DecisionAllow},
 		{"NamespaceReaderOnGetSystemInfo", claimsNamespaceReader, targetGetSystemInfo, DecisionAllow},
@@ -46,6 +46,8 @@ const (
 	permissionWrite             = "write"
 	permissionWorker            = "worker"
 	permissionAdmin             = "admin"
+	permissionScopeWorkflowType = "workflowtype"
+	permissionScopeTaskQueue    = "taskqueue"
 )
 
 // Default claim mapper that gives system level admin permission to everybody
@@ -107,6 +109,19 @@ func (a *defaultJWTClaimMapper) extractPermissions(permissions []interface{}, cl
 			continue
 		}
 		parts := strings.Split(p, ":")
+		if len(parts) == 3 {
+			// <namespace>:<workflowType|taskQueue>=<pattern>:<permission>
+			scope, ok := parseScopedPermission(parts)
+			if !ok {
+				a.logger.Warn(fmt.Sprintf("ignoring scoped permission in unexpected format: %v", permission))
+				continue
+			}
+			if claims.Scopes == nil {
+				claims.Scopes = make(map[string][]ScopedRole)
+			}
+			claims.Scopes[parts[0]] = append(claims.Scopes[parts[0]], scope)
+			continue
+		}
 		if len(parts) != 2 {
 			a.logger.Warn(fmt.Sprintf("ignoring permission in unexpected format: %v", permission))
 			continue
@@ -126,6 +141,23 @@ func (a *defaultJWTClaimMapper) extractPermissions(permissions []interface{}, cl
 	return nil
 }
 
+func parseScopedPermission(parts []string) (ScopedRole, bool) {
+	kind, pattern, ok := strings.Cut(parts[1], "=")
+	if !ok || pattern == "" || parts[0] == permissionScopeSystem {
+		return ScopedRole{}, false
+	}
+	scope := ScopedRole{Pattern: pattern, Role: permissionToRole(parts[2])}
+	switch strings.ToLower(kind) {
+	case permissionScopeWorkflowType:
+		scope.Kind = ScopeKindWorkflowType
+	case permissionScopeTaskQueue:
+		scope.Kind = ScopeKindTaskQueue
+	default:
+		return ScopedRole{}, false
+	}
+	return scope, true
+}
+
 func parseJWT(tokenString string, keyProvider TokenKeyProvider) (jwt.MapClaims, error) {
 	return parseJWTWithAudience(tokenString, keyProvider, "")
 }
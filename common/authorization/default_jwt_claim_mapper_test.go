@@ -210,6 +210,30 @@ func (s *defaultClaimMapperSuite) testTokenWithReaderWriterWorkerPermissions(alg
 	defaultRole := claims.Namespaces[defaultNamespace]
 	s.Equal(RoleReader|RoleWriter|RoleWorker, defaultRole)
 }
+func (s *defaultClaimMapperSuite) TestTokenWithScopedPermissions() {
+	tokenString, err := s.tokenGenerator.generateToken(RSA, testSubject, []string{
+		defaultNamespace + ":taskQueue=payments-*:write",
+		defaultNamespace + ":workflowType=Refund:read",
+		defaultNamespace + ":workerVersion=1:write",
+		defaultNamespace + ":taskQueue=:write",
+	}, errorTestOptionNoError)
+	s.NoError(err)
+	authInfo := &AuthInfo{
+		AddBearer(tokenString),
+		nil,
+		nil,
+		"",
+		"test-audience",
+	}
+	claims, err := s.claimMapper.GetClaims(authInfo)
+	s.NoError(err)
+	s.Empty(claims.Namespaces)
+	s.Equal([]ScopedRole{
+		{Kind: ScopeKindTaskQueue, Pattern: "payments-*", Role: RoleWriter},
+		{Kind: ScopeKindWorkflowType, Pattern: "Refund", Role: RoleReader},
+	}, claims.Scopes[defaultNamespace])
+}
+
 func (s *defaultClaimMapperSuite) TestGetClaimMapperFromConfigNoop() {
 	s.testGetClaimMapperFromConfig("", true, reflect.TypeOf(&noopClaimMapper{}))
 }
@@ -31,11 +31,13 @@ import (
 	"time"
 
 	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 
+	tokenspb "go.temporal.io/server/api/token/v1"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/metrics"
@@ -74,6 +76,40 @@ func (a *interceptor) Interceptor(
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
+	ctx, err := a.authorizeRequest(ctx, req, info)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamInterceptor authorizes streaming calls. Server streams are authorized with their request
+// once it is received, and client and bidirectional streams are authorized without a request
+// when they start.
+func (a *interceptor) StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	unaryInfo := &grpc.UnaryServerInfo{Server: srv, FullMethod: info.FullMethod}
+	if info.IsClientStream {
+		ctx, err := a.authorizeRequest(ss.Context(), nil, unaryInfo)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedServerStream{ServerStream: ss, ctx: ctx, authorized: true})
+	}
+	return handler(srv, &authorizedServerStream{ServerStream: ss, ctx: ss.Context(), interceptor: a, info: unaryInfo})
+}
+
+// authorizeRequest maps the claims of the caller and authorizes the request, returning the
+// context with the mapped claims.
+func (a *interceptor) authorizeRequest(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+) (context.Context, error) {
 
 	var claims *Claims
 
@@ -122,7 +158,7 @@ func (a *interceptor) Interceptor(
 			mappedClaims, err := a.claimMapper.GetClaims(&authInfo)
 			if err != nil {
 				a.logAuthError(err)
-				return nil, errUnauthorized // return a generic error to the caller without disclosing details
+				return ctx, errUnauthorized // return a generic error to the caller without disclosing details
 			}
 			claims = mappedClaims
 			ctx = context.WithValue(ctx, MappedClaims, mappedClaims)
@@ -138,28 +174,70 @@ func (a *interceptor) Interceptor(
 		if ok {
 			namespace = requestWithNamespace.GetNamespace()
 		}
+		workflowType, taskQueue := getWorkflowTypeAndTaskQueue(req)
 
 		handler := a.getMetricsHandler(metrics.AuthorizationScope, namespace)
-		result, err := a.authorize(ctx, claims, &CallTarget{
-			Namespace: namespace,
-			APIName:   info.FullMethod,
-			Request:   req,
-		}, handler)
+		target := &CallTarget{
+			Namespace:    namespace,
+			WorkflowType: workflowType,
+			TaskQueue:    taskQueue,
+			APIName:      info.FullMethod,
+			Request:      req,
+		}
+		result, err := a.authorize(ctx, claims, target, handler)
+		a.auditLogger.Record(claims, target, result, err)
 		if err != nil {
 			handler.Counter(metrics.ServiceErrAuthorizeFailedCounter.Name()).Record(1)
 			a.logAuthError(err)
-			return nil, errUnauthorized // return a generic error to the caller without disclosing details
+			return ctx, errUnauthorized // return a generic error to the caller without disclosing details
 		}
 		if result.Decision != DecisionAllow {
 			handler.Counter(metrics.ServiceErrUnauthorizedCounter.Name()).Record(1)
 			// if a reason is included in the result, include it in the error message
 			if result.Reason != "" {
-				return nil, serviceerror.NewPermissionDenied(RequestUnauthorized, result.Reason)
+				return ctx, serviceerror.NewPermissionDenied(RequestUnauthorized, result.Reason)
 			}
-			return nil, errUnauthorized // return a generic error to the caller without disclosing details
+			return ctx, errUnauthorized // return a generic error to the caller without disclosing details
 		}
 	}
-	return handler(ctx, req)
+	return ctx, nil
+}
+
+// authorizedServerStream authorizes a server stream with the request it receives before
+// anything is sent on it, and carries the mapped claims of the caller in its context.
+type authorizedServerStream struct {
+	grpc.ServerStream
+	ctx         context.Context
+	interceptor *interceptor
+	info        *grpc.UnaryServerInfo
+	authorized  bool
+}
+
+func (s *authorizedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authorized {
+		return nil
+	}
+	ctx, err := s.interceptor.authorizeRequest(s.ctx, m, s.info)
+	if err != nil {
+		return err
+	}
+	s.ctx = ctx
+	s.authorized = true
+	return nil
+}
+
+func (s *authorizedServerStream) SendMsg(m interface{}) error {
+	if !s.authorized {
+		return errUnauthorized
+	}
+	return s.ServerStream.SendMsg(m)
 }
 
 func (a *interceptor) authorize(
@@ -174,10 +252,57 @@ func (a *interceptor) authorize(
 	return a.authorizer.Authorize(ctx, claims, callTarget)
 }
 
+// getWorkflowTypeAndTaskQueue extracts the workflow type and task queue targeted by a request.
+// Requests that only identify a workflow by its execution don't carry either, and neither do
+// requests that target multiple task queues. Calls made with a task token target the workflow
+// type and task queue recorded in the token, which history checks against the persisted workflow.
+// Requests that may act on an existing workflow or schedule other than the one they describe,
+// such as SignalWithStartWorkflowExecution or UpdateSchedule, are reported without either.
+func getWorkflowTypeAndTaskQueue(req interface{}) (workflowType string, taskQueue string) {
+	switch r := req.(type) {
+	case *workflowservice.CreateScheduleRequest:
+		// Schedules start workflows on behalf of the caller, so they target the workflow type
+		// and task queue of their start workflow action.
+		if startWorkflow := r.GetSchedule().GetAction().GetStartWorkflow(); startWorkflow != nil {
+			req = startWorkflow
+		}
+	case *workflowservice.UpdateScheduleRequest,
+		*workflowservice.SignalWithStartWorkflowExecutionRequest:
+		return "", ""
+	case hasTaskToken:
+		token := &tokenspb.Task{}
+		if err := token.Unmarshal(r.GetTaskToken()); err != nil {
+			return "", ""
+		}
+		return token.GetWorkflowType(), token.GetTaskQueue()
+	}
+
+	if r, ok := req.(hasWorkflowType); ok {
+		workflowType = r.GetWorkflowType().GetName()
+	}
+	switch r := req.(type) {
+	case hasTaskQueue:
+		taskQueue = r.GetTaskQueue().GetName()
+	case hasTaskQueueName:
+		taskQueue = r.GetTaskQueue()
+	}
+	return workflowType, taskQueue
+}
+
 func (a *interceptor) logAuthError(err error) {
 	a.logger.Error("Authorization error", tag.Error(err))
 }
 
+// InterceptorOption configures the authorization interceptor.
+type InterceptorOption func(*interceptor)
+
+// WithAuditLogger records the authorization decisions of the interceptor with auditLogger.
+func WithAuditLogger(auditLogger *AuditLogger) InterceptorOption {
+	return func(a *interceptor) {
+		a.auditLogger = auditLogger
+	}
+}
+
 type interceptor struct {
 	authorizer          Authorizer
 	claimMapper         ClaimMapper
@@ -186,6 +311,7 @@ type interceptor struct {
 	audienceGetter      JWTAudienceMapper
 	authHeaderName      string
 	authExtraHeaderName string
+	auditLogger         *AuditLogger
 }
 
 // NewAuthorizationInterceptor creates an authorization interceptor and return a func that points to its Interceptor method
@@ -197,8 +323,54 @@ func NewAuthorizationInterceptor(
 	audienceGetter JWTAudienceMapper,
 	authHeaderName string,
 	authExtraHeaderName string,
+	opts ...InterceptorOption,
 ) grpc.UnaryServerInterceptor {
-	return (&interceptor{
+	return newInterceptor(
+		claimMapper,
+		authorizer,
+		metricsHandler,
+		logger,
+		audienceGetter,
+		authHeaderName,
+		authExtraHeaderName,
+		opts...,
+	).Interceptor
+}
+
+// NewAuthorizationStreamInterceptor creates an authorization interceptor and return a func that points to its StreamInterceptor method
+func NewAuthorizationStreamInterceptor(
+	claimMapper ClaimMapper,
+	authorizer Authorizer,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+	audienceGetter JWTAudienceMapper,
+	authHeaderName string,
+	authExtraHeaderName string,
+	opts ...InterceptorOption,
+) grpc.StreamServerInterceptor {
+	return newInterceptor(
+		claimMapper,
+		authorizer,
+		metricsHandler,
+		logger,
+		audienceGetter,
+		authHeaderName,
+		authExtraHeaderName,
+		opts...,
+	).StreamInterceptor
+}
+
+func newInterceptor(
+	claimMapper ClaimMapper,
+	authorizer Authorizer,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+	audienceGetter JWTAudienceMapper,
+	authHeaderName string,
+	authExtraHeaderName string,
+	opts ...InterceptorOption,
+) *interceptor {
+	a := &interceptor{
 		claimMapper:         claimMapper,
 		authorizer:          authorizer,
 		metricsHandler:      metricsHandler,
@@ -206,7 +378,11 @@ func NewAuthorizationInterceptor(
 		audienceGetter:      audienceGetter,
 		authHeaderName:      util.Coalesce(authHeaderName, defaultAuthHeaderName),
 		authExtraHeaderName: util.Coalesce(authExtraHeaderName, defaultAuthExtraHeaderName),
-	}).Interceptor
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // getMetricsHandler return metrics handler with namespace tag
@@ -31,11 +31,17 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	schedulepb "go.temporal.io/api/schedule/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	"go.temporal.io/api/workflowservicemock/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
+	tokenspb "go.temporal.io/server/api/token/v1"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 )
@@ -66,6 +72,8 @@ type (
 		interceptor         grpc.UnaryServerInterceptor
 		handler             grpc.UnaryHandler
 		mockClaimMapper     *MockClaimMapper
+		auditSink           *MemoryAuditSink
+		auditLogger         *AuditLogger
 	}
 )
 
@@ -84,6 +92,8 @@ func (s *authorizerInterceptorSuite) SetupTest() {
 	s.mockMetricsHandler.EXPECT().WithTags(metrics.OperationTag(metrics.AuthorizationScope)).Return(s.mockMetricsHandler).AnyTimes()
 	s.mockMetricsHandler.EXPECT().Timer(metrics.ServiceAuthorizationLatency.Name()).Return(metrics.NoopTimerMetricFunc).AnyTimes()
 	s.mockClaimMapper = NewMockClaimMapper(s.controller)
+	s.auditSink = NewMemoryAuditSink()
+	s.auditLogger = NewAuditLogger(s.auditSink, 0, dynamicconfig.NewNoopCollection(), metrics.NoopMetricsHandler, log.NewNoopLogger())
 	s.interceptor = NewAuthorizationInterceptor(
 		s.mockClaimMapper,
 		s.mockAuthorizer,
@@ -92,11 +102,13 @@ func (s *authorizerInterceptorSuite) SetupTest() {
 		nil,
 		"",
 		"",
+		WithAuditLogger(s.auditLogger),
 	)
 	s.handler = func(ctx context.Context, req interface{}) (interface{}, error) { return true, nil }
 }
 
 func (s *authorizerInterceptorSuite) TearDownTest() {
+	s.NoError(s.auditLogger.Close())
 	s.controller.Finish()
 }
 
@@ -118,6 +130,111 @@ func (s *authorizerInterceptorSuite) TestIsAuthorizedWithNamespace() {
 	s.NoError(err)
 }
 
+func (s *authorizerInterceptorSuite) TestIsAuthorizedWithWorkflowTypeAndTaskQueue() {
+	request := &workflowservice.StartWorkflowExecutionRequest{
+		Namespace:    testNamespace,
+		WorkflowType: &commonpb.WorkflowType{Name: "Refund"},
+		TaskQueue:    &taskqueuepb.TaskQueue{Name: "payments"},
+	}
+	s.mockAuthorizer.EXPECT().Authorize(ctx, nil, &CallTarget{
+		Namespace:    testNamespace,
+		WorkflowType: "Refund",
+		TaskQueue:    "payments",
+		Request:      request,
+		APIName:      startWorkflowExecutionInfo.FullMethod,
+	}).Return(Result{Decision: DecisionAllow}, nil)
+
+	res, err := s.interceptor(ctx, request, startWorkflowExecutionInfo, s.handler)
+	s.True(res.(bool))
+	s.NoError(err)
+}
+
+func (s *authorizerInterceptorSuite) TestGetWorkflowTypeAndTaskQueue() {
+	taskToken, err := (&tokenspb.Task{
+		NamespaceId:  "test-namespace-id",
+		WorkflowId:   "test-workflow-id",
+		WorkflowType: "Refund",
+		TaskQueue:    "payments",
+	}).Marshal()
+	s.NoError(err)
+
+	testCases := []struct {
+		name         string
+		request      interface{}
+		workflowType string
+		taskQueue    string
+	}{
+		{
+			name:    "no resources",
+			request: describeNamespaceRequest,
+		},
+		{
+			name:      "task queue",
+			request:   &workflowservice.PollActivityTaskQueueRequest{TaskQueue: &taskqueuepb.TaskQueue{Name: "payments"}},
+			taskQueue: "payments",
+		},
+		{
+			name:      "task queue name",
+			request:   &workflowservice.GetWorkerBuildIdCompatibilityRequest{TaskQueue: "payments"},
+			taskQueue: "payments",
+		},
+		{
+			name: "schedule",
+			request: &workflowservice.CreateScheduleRequest{
+				Schedule: &schedulepb.Schedule{
+					Action: &schedulepb.ScheduleAction{
+						Action: &schedulepb.ScheduleAction_StartWorkflow{
+							StartWorkflow: &workflowpb.NewWorkflowExecutionInfo{
+								WorkflowType: &commonpb.WorkflowType{Name: "Refund"},
+								TaskQueue:    &taskqueuepb.TaskQueue{Name: "payments"},
+							},
+						},
+					},
+				},
+			},
+			workflowType: "Refund",
+			taskQueue:    "payments",
+		},
+		{
+			name: "schedule update",
+			request: &workflowservice.UpdateScheduleRequest{
+				Schedule: &schedulepb.Schedule{
+					Action: &schedulepb.ScheduleAction{
+						Action: &schedulepb.ScheduleAction_StartWorkflow{
+							StartWorkflow: &workflowpb.NewWorkflowExecutionInfo{
+								WorkflowType: &commonpb.WorkflowType{Name: "Refund"},
+								TaskQueue:    &taskqueuepb.TaskQueue{Name: "payments"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "signal with start",
+			request: &workflowservice.SignalWithStartWorkflowExecutionRequest{
+				WorkflowType: &commonpb.WorkflowType{Name: "Refund"},
+				TaskQueue:    &taskqueuepb.TaskQueue{Name: "payments"},
+			},
+		},
+		{
+			name:         "task token",
+			request:      &workflowservice.RespondActivityTaskCompletedRequest{TaskToken: taskToken},
+			workflowType: "Refund",
+			taskQueue:    "payments",
+		},
+		{
+			name:    "invalid task token",
+			request: &workflowservice.RespondWorkflowTaskCompletedRequest{TaskToken: []byte("invalid")},
+		},
+	}
+	for _, tc := range testCases {
+		workflowType, taskQueue := getWorkflowTypeAndTaskQueue(tc.request)
+		s.Equal(tc.workflowType, workflowType, tc.name)
+		s.Equal(tc.taskQueue, taskQueue, tc.name)
+	}
+}
+
 func (s *authorizerInterceptorSuite) TestIsUnauthorized() {
 	s.mockAuthorizer.EXPECT().Authorize(ctx, nil, describeNamespaceTarget).
 		Return(Result{Decision: DecisionDeny}, nil)
@@ -128,6 +245,39 @@ func (s *authorizerInterceptorSuite) TestIsUnauthorized() {
 	s.Error(err)
 }
 
+func (s *authorizerInterceptorSuite) TestAuditRecords() {
+	request := &workflowservice.TerminateWorkflowExecutionRequest{
+		Namespace: testNamespace,
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: "workflow-id",
+			RunId:      "run-id",
+		},
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/temporal.api.workflowservice.v1.WorkflowService/TerminateWorkflowExecution"}
+	s.mockAuthorizer.EXPECT().Authorize(ctx, nil, gomock.Any()).
+		Return(Result{Decision: DecisionAllow}, nil)
+	s.mockAuthorizer.EXPECT().Authorize(ctx, nil, gomock.Any()).
+		Return(Result{Decision: DecisionDeny, Reason: "not allowed"}, nil)
+	s.mockMetricsHandler.EXPECT().Counter(metrics.ServiceErrUnauthorizedCounter.Name()).Return(metrics.NoopCounterMetricFunc)
+
+	_, err := s.interceptor(ctx, request, info, s.handler)
+	s.NoError(err)
+	_, err = s.interceptor(ctx, request, info, s.handler)
+	s.Error(err)
+	s.NoError(s.auditLogger.Close())
+
+	records := s.auditSink.Records()
+	s.Len(records, 2)
+	for i, decision := range []string{auditDecisionAllow, auditDecisionDeny} {
+		s.Equal(decision, records[i].Decision)
+		s.Equal(info.FullMethod, records[i].API)
+		s.Equal(testNamespace, records[i].Namespace)
+		s.Equal("workflow-id", records[i].WorkflowID)
+		s.Equal("run-id", records[i].RunID)
+	}
+	s.Equal("not allowed", records[1].Reason)
+}
+
 func (s *authorizerInterceptorSuite) TestAuthorizationFailed() {
 	s.mockAuthorizer.EXPECT().Authorize(ctx, nil, describeNamespaceTarget).
 		Return(Result{Decision: DecisionDeny}, errUnauthorized)
@@ -211,3 +361,73 @@ func (s *authorizerInterceptorSuite) TestAlternateHeaders() {
 		s.NoError(err)
 	}
 }
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []interface{}
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testServerStream) RecvMsg(interface{}) error {
+	return nil
+}
+
+func (s *testServerStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func (s *authorizerInterceptorSuite) TestStreamIsAuthorized() {
+	admin := &Claims{System: RoleAdmin}
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), admin, describeNamespaceTarget).
+		Return(Result{Decision: DecisionAllow}, nil)
+
+	interceptor := NewAuthorizationStreamInterceptor(
+		NewNoopClaimMapper(),
+		s.mockAuthorizer,
+		s.mockMetricsHandler,
+		log.NewNoopLogger(),
+		nil,
+		"",
+		"",
+	)
+	stream := &testServerStream{ctx: ctx}
+	info := &grpc.StreamServerInfo{FullMethod: describeNamespaceInfo.FullMethod, IsServerStream: true}
+	err := interceptor(nil, stream, info, func(srv interface{}, ss grpc.ServerStream) error {
+		if err := ss.RecvMsg(describeNamespaceRequest); err != nil {
+			return err
+		}
+		s.Equal(admin, ss.Context().Value(MappedClaims))
+		return ss.SendMsg(describeNamespaceRequest)
+	})
+	s.NoError(err)
+	s.Len(stream.sent, 1)
+}
+
+func (s *authorizerInterceptorSuite) TestStreamIsUnauthorized() {
+	s.mockAuthorizer.EXPECT().Authorize(ctx, nil, describeNamespaceTarget).
+		Return(Result{Decision: DecisionDeny}, nil)
+	s.mockMetricsHandler.EXPECT().Counter(metrics.ServiceErrUnauthorizedCounter.Name()).Return(metrics.NoopCounterMetricFunc)
+
+	interceptor := NewAuthorizationStreamInterceptor(
+		s.mockClaimMapper,
+		s.mockAuthorizer,
+		s.mockMetricsHandler,
+		log.NewNoopLogger(),
+		nil,
+		"",
+		"",
+	)
+	stream := &testServerStream{ctx: ctx}
+	info := &grpc.StreamServerInfo{FullMethod: describeNamespaceInfo.FullMethod, IsServerStream: true}
+	err := interceptor(nil, stream, info, func(srv interface{}, ss grpc.ServerStream) error {
+		s.ErrorIs(ss.SendMsg(describeNamespaceRequest), errUnauthorized)
+		return ss.RecvMsg(describeNamespaceRequest)
+	})
+	s.Error(err)
+	s.Empty(stream.sent)
+}
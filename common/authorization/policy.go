@@ -0,0 +1,359 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+
+	"go.temporal.io/server/common/api"
+)
+
+const (
+	policyEffectAllow = "allow"
+	policyEffectDeny  = "deny"
+)
+
+var (
+	searchAttributesFieldPath = []protoreflect.Name{"search_attributes", "indexed_fields"}
+)
+
+type (
+	// policyConfig is the YAML representation of an authorization policy file:
+	//
+	//	defaultDecision: deny
+	//	rules:
+	//	  - name: payments-workers
+	//	    effect: allow
+	//	    subjects: ["payments-worker-*"]
+	//	    apis: ["Poll*TaskQueue", "Respond*"]
+	//	    namespaces: ["payments"]
+	//	  - name: no-refunds-from-ci
+	//	    effect: deny
+	//	    subjects: ["ci-*"]
+	//	    workflowTypes: ["Refund*"]
+	//
+	// Every condition of a rule must match for the rule to apply; a condition matches if any
+	// of its patterns does. Omitted conditions match everything. Patterns support the `*` and
+	// `?` wildcards. APIs can be given either as full method names or as short method names.
+	policyConfig struct {
+		// DefaultDecision applies when no rule matches, either "allow" or "deny". Defaults to "deny".
+		DefaultDecision string             `yaml:"defaultDecision"`
+		Rules           []policyRuleConfig `yaml:"rules"`
+	}
+
+	policyRuleConfig struct {
+		Name   string `yaml:"name"`
+		Effect string `yaml:"effect"`
+
+		Subjects   []string `yaml:"subjects"`
+		APIs       []string `yaml:"apis"`
+		Namespaces []string `yaml:"namespaces"`
+
+		// Request conditions. A rule with request conditions never matches requests that
+		// don't carry the corresponding field.
+		WorkflowTypes    []string `yaml:"workflowTypes"`
+		TaskQueues       []string `yaml:"taskQueues"`
+		SearchAttributes []string `yaml:"searchAttributes"`
+		// Fields matches arbitrary request fields, keyed by the dot-separated path of proto
+		// field names, e.g. "workflow_execution.workflow_id".
+		Fields map[string][]string `yaml:"fields"`
+	}
+
+	policy struct {
+		defaultResult Result
+		rules         []*policyRule
+	}
+
+	policyRule struct {
+		name     string
+		decision Decision
+
+		subjects         globs
+		apis             globs
+		namespaces       globs
+		workflowTypes    globs
+		taskQueues       globs
+		searchAttributes globs
+		fields           []policyFieldCondition
+	}
+
+	policyFieldCondition struct {
+		path  []protoreflect.Name
+		globs globs
+	}
+
+	// globs is a set of compiled wildcard patterns. A nil set matches everything.
+	globs []*regexp.Regexp
+)
+
+func parsePolicy(content []byte) (*policy, error) {
+	var cfg policyConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode policy: %w", err)
+	}
+
+	p := &policy{
+		defaultResult: resultDeny,
+		rules:         make([]*policyRule, 0, len(cfg.Rules)),
+	}
+	switch strings.ToLower(cfg.DefaultDecision) {
+	case "", policyEffectDeny:
+	case policyEffectAllow:
+		p.defaultResult = resultAllow
+	default:
+		return nil, fmt.Errorf("invalid default decision %q", cfg.DefaultDecision)
+	}
+
+	for i, ruleCfg := range cfg.Rules {
+		rule, err := compilePolicyRule(ruleCfg)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if rule.name == "" {
+			rule.name = fmt.Sprintf("rules[%d]", i)
+		}
+		p.rules = append(p.rules, rule)
+	}
+	return p, nil
+}
+
+func compilePolicyRule(cfg policyRuleConfig) (*policyRule, error) {
+	rule := &policyRule{name: cfg.Name}
+	switch strings.ToLower(cfg.Effect) {
+	case policyEffectAllow:
+		rule.decision = DecisionAllow
+	case policyEffectDeny:
+		rule.decision = DecisionDeny
+	default:
+		return nil, fmt.Errorf("invalid effect %q, must be %q or %q", cfg.Effect, policyEffectAllow, policyEffectDeny)
+	}
+
+	var err error
+	if rule.subjects, err = compileGlobs(cfg.Subjects); err != nil {
+		return nil, err
+	}
+	if rule.apis, err = compileGlobs(cfg.APIs); err != nil {
+		return nil, err
+	}
+	if rule.namespaces, err = compileGlobs(cfg.Namespaces); err != nil {
+		return nil, err
+	}
+	if rule.workflowTypes, err = compileGlobs(cfg.WorkflowTypes); err != nil {
+		return nil, err
+	}
+	if rule.taskQueues, err = compileGlobs(cfg.TaskQueues); err != nil {
+		return nil, err
+	}
+	if rule.searchAttributes, err = compileGlobs(cfg.SearchAttributes); err != nil {
+		return nil, err
+	}
+	for path, patterns := range cfg.Fields {
+		if path == "" {
+			return nil, errors.New("field path must not be empty")
+		}
+		fieldGlobs, err := compileGlobs(patterns)
+		if err != nil {
+			return nil, err
+		}
+		var fieldPath []protoreflect.Name
+		for _, name := range strings.Split(path, ".") {
+			fieldPath = append(fieldPath, protoreflect.Name(name))
+		}
+		rule.fields = append(rule.fields, policyFieldCondition{path: fieldPath, globs: fieldGlobs})
+	}
+	return rule, nil
+}
+
+func (p *policy) evaluate(claims *Claims, target *CallTarget) Result {
+	result := p.defaultResult
+	allowed := false
+	for _, rule := range p.rules {
+		if !rule.matches(claims, target) {
+			continue
+		}
+		if rule.decision == DecisionDeny {
+			return Result{Decision: DecisionDeny, Reason: fmt.Sprintf("denied by policy rule %q", rule.name)}
+		}
+		allowed = true
+	}
+	if allowed {
+		result = resultAllow
+	}
+	return result
+}
+
+func (r *policyRule) matches(claims *Claims, target *CallTarget) bool {
+	var subject string
+	if claims != nil {
+		subject = claims.Subject
+	}
+	if !r.subjects.matchesAny(subject) {
+		return false
+	}
+	if !r.apis.matchesAny(target.APIName, api.MethodName(target.APIName)) {
+		return false
+	}
+	if !r.namespaces.matchesAny(target.Namespace) {
+		return false
+	}
+
+	if r.workflowTypes != nil && (target.WorkflowType == "" || !r.workflowTypes.matchesAny(target.WorkflowType)) {
+		return false
+	}
+	if r.taskQueues != nil && (target.TaskQueue == "" || !r.taskQueues.matchesAny(target.TaskQueue)) {
+		return false
+	}
+
+	if r.searchAttributes == nil && len(r.fields) == 0 {
+		return true
+	}
+	request, _ := target.Request.(proto.Message)
+	if r.searchAttributes != nil && !r.searchAttributes.matchesAny(requestFieldValues(request, searchAttributesFieldPath)...) {
+		return false
+	}
+	for _, field := range r.fields {
+		if !field.globs.matchesAny(requestFieldValues(request, field.path)...) {
+			return false
+		}
+	}
+	return true
+}
+
+func compileGlobs(patterns []string) (globs, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	result := make(globs, 0, len(patterns))
+	for _, pattern := range patterns {
+		var expr strings.Builder
+		expr.WriteString("^")
+		for _, r := range pattern {
+			switch r {
+			case '*':
+				expr.WriteString(".*")
+			case '?':
+				expr.WriteString(".")
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		expr.WriteString("$")
+		re, err := regexp.Compile(expr.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		result = append(result, re)
+	}
+	return result, nil
+}
+
+// matchesAny returns true if any of the values matches any of the patterns. A nil set of
+// patterns matches everything, including no values at all.
+func (g globs) matchesAny(values ...string) bool {
+	if g == nil {
+		return true
+	}
+	for _, value := range values {
+		for _, re := range g {
+			if re.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requestFieldValues returns the string values found at the given field path of the request.
+// Repeated message fields along the path are traversed element by element, and map fields
+// at the end of the path yield their keys.
+func requestFieldValues(request proto.Message, path []protoreflect.Name) []string {
+	if request == nil {
+		return nil
+	}
+	return messageFieldValues(request.ProtoReflect(), path, nil)
+}
+
+func messageFieldValues(msg protoreflect.Message, path []protoreflect.Name, values []string) []string {
+	if !msg.IsValid() || len(path) == 0 {
+		return values
+	}
+	fd := msg.Descriptor().Fields().ByName(path[0])
+	if fd == nil || !msg.Has(fd) {
+		return values
+	}
+	value := msg.Get(fd)
+	rest := path[1:]
+
+	switch {
+	case fd.IsMap():
+		if len(rest) == 0 {
+			value.Map().Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+				values = append(values, key.String())
+				return true
+			})
+		}
+	case fd.IsList():
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			values = scalarOrMessageValues(fd, list.Get(i), rest, values)
+		}
+	default:
+		values = scalarOrMessageValues(fd, value, rest, values)
+	}
+	return values
+}
+
+func scalarOrMessageValues(
+	fd protoreflect.FieldDescriptor,
+	value protoreflect.Value,
+	rest []protoreflect.Name,
+	values []string,
+) []string {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageFieldValues(value.Message(), rest, values)
+	case protoreflect.StringKind:
+		if len(rest) == 0 {
+			values = append(values, value.String())
+		}
+	case protoreflect.EnumKind:
+		if len(rest) == 0 {
+			if ev := fd.Enum().Values().ByNumber(value.Enum()); ev != nil {
+				values = append(values, string(ev.Name()))
+			}
+		}
+	}
+	return values
+}
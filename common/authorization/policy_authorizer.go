@@ -0,0 +1,169 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+)
+
+const (
+	defaultPolicyPollInterval = 10 * time.Second
+	minPolicyPollInterval     = time.Second
+)
+
+type (
+	// policyAuthorizer makes authorization decisions based on the rules of a policy file.
+	// The file is polled for changes and reloaded when modified. A policy that fails to load
+	// is logged and the previously loaded policy remains in effect.
+	policyAuthorizer struct {
+		policy          atomic.Pointer[policy]
+		config          *config.PolicyAuthorizer
+		logger          log.Logger
+		lastUpdatedTime time.Time
+		doneCh          <-chan interface{}
+		stopCh          chan struct{}
+		stopOnce        sync.Once
+	}
+)
+
+var _ Authorizer = (*policyAuthorizer)(nil)
+
+// NewPolicyAuthorizer creates an authorizer that evaluates the rules of the given policy file.
+// Polling for policy changes stops when doneCh is closed or Stop is called.
+func NewPolicyAuthorizer(
+	cfg *config.PolicyAuthorizer,
+	logger log.Logger,
+	doneCh <-chan interface{},
+) (*policyAuthorizer, error) {
+	a := &policyAuthorizer{
+		config: cfg,
+		logger: logger,
+		doneCh: doneCh,
+		stopCh: make(chan struct{}),
+	}
+	if err := a.init(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Authorize determines if an API call by given claims should be allowed or denied.
+// Rules:
+//
+//	Health check APIs are allowed to everyone.
+//	A call is denied if any deny rule matches it.
+//	Otherwise, a call is allowed if any allow rule matches it.
+//	Otherwise, the default decision of the policy applies.
+func (a *policyAuthorizer) Authorize(_ context.Context, claims *Claims, target *CallTarget) (Result, error) {
+	if IsHealthCheckAPI(target.APIName) {
+		return resultAllow, nil
+	}
+	return a.policy.Load().evaluate(claims, target), nil
+}
+
+// Stop stops polling for policy changes. It is called by the server that the authorizer
+// was passed to with temporal.WithAuthorizer when the server stops.
+func (a *policyAuthorizer) Stop() {
+	a.stopOnce.Do(func() {
+		close(a.stopCh)
+	})
+}
+
+func (a *policyAuthorizer) init() error {
+	if a.config == nil {
+		return errors.New("configuration for policy authorizer is nil")
+	}
+	if a.config.PolicyFile == "" {
+		return errors.New("policy file for policy authorizer is not set")
+	}
+	if a.config.PollInterval == 0 {
+		a.config.PollInterval = defaultPolicyPollInterval
+	}
+	if a.config.PollInterval < minPolicyPollInterval {
+		return fmt.Errorf("policy poll interval should be at least %v", minPolicyPollInterval)
+	}
+
+	if err := a.update(); err != nil {
+		return fmt.Errorf("unable to load authorization policy: %w", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(a.config.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := a.update(); err != nil {
+					a.logger.Error("Unable to update authorization policy.", tag.Error(err))
+				}
+			case <-a.doneCh:
+				return
+			case <-a.stopCh:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (a *policyAuthorizer) update() error {
+	info, err := os.Stat(a.config.PolicyFile)
+	if err != nil {
+		return fmt.Errorf("authorization policy file: %s: %w", a.config.PolicyFile, err)
+	}
+	if !info.ModTime().After(a.lastUpdatedTime) {
+		return nil
+	}
+	// Record the modification time even if the policy turns out to be invalid so that
+	// the same broken file is not parsed and reported on every poll.
+	a.lastUpdatedTime = info.ModTime()
+
+	content, err := os.ReadFile(a.config.PolicyFile)
+	if err != nil {
+		return fmt.Errorf("authorization policy file: %s: %w", a.config.PolicyFile, err)
+	}
+	p, err := parsePolicy(content)
+	if err != nil {
+		return fmt.Errorf("authorization policy file: %s: %w", a.config.PolicyFile, err)
+	}
+
+	a.policy.Store(p)
+	a.logger.Info("Updated authorization policy.",
+		tag.NewStringTag("policy-file", a.config.PolicyFile),
+		tag.NewInt("policy-rules", len(p.rules)),
+	)
+	return nil
+}
@@ -0,0 +1,282 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+)
+
+const testPolicy = `
+defaultDecision: deny
+rules:
+  - name: admins
+    effect: allow
+    subjects: ["admin-*"]
+  - name: payments-workers
+    effect: allow
+    subjects: ["payments-worker"]
+    apis: ["StartWorkflowExecution", "Poll*TaskQueue"]
+    namespaces: ["payments"]
+  - name: no-refunds
+    effect: deny
+    subjects: ["payments-worker"]
+    workflowTypes: ["Refund*"]
+  - name: no-secret-search-attributes
+    effect: deny
+    searchAttributes: ["Secret*"]
+  - name: ci-identity
+    effect: deny
+    fields:
+      identity: ["ci-*"]
+`
+
+type (
+	policyAuthorizerSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		policyFile string
+		doneCh     chan interface{}
+		authorizer *policyAuthorizer
+	}
+)
+
+func TestPolicyAuthorizerSuite(t *testing.T) {
+	s := new(policyAuthorizerSuite)
+	suite.Run(t, s)
+}
+
+func (s *policyAuthorizerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.policyFile = filepath.Join(s.T().TempDir(), "policy.yaml")
+	s.NoError(os.WriteFile(s.policyFile, []byte(testPolicy), 0644))
+	s.doneCh = make(chan interface{})
+
+	var err error
+	s.authorizer, err = NewPolicyAuthorizer(
+		&config.PolicyAuthorizer{PolicyFile: s.policyFile},
+		log.NewNoopLogger(),
+		s.doneCh,
+	)
+	s.NoError(err)
+}
+
+func (s *policyAuthorizerSuite) TearDownTest() {
+	close(s.doneCh)
+}
+
+func (s *policyAuthorizerSuite) TestAuthorize() {
+	startWorkflow := "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution"
+
+	testCases := []struct {
+		name     string
+		claims   *Claims
+		target   *CallTarget
+		decision Decision
+	}{
+		{
+			name:     "health check is always allowed",
+			claims:   nil,
+			target:   &targetGrpcHealthCheck,
+			decision: DecisionAllow,
+		},
+		{
+			name:     "no matching rule falls back to default",
+			claims:   &Claims{Subject: "someone"},
+			target:   &targetStartWorkflow,
+			decision: DecisionDeny,
+		},
+		{
+			name:     "subject pattern",
+			claims:   &Claims{Subject: "admin-alice"},
+			target:   &targetStartWorkflow,
+			decision: DecisionAllow,
+		},
+		{
+			name:   "api and namespace",
+			claims: &Claims{Subject: "payments-worker"},
+			target: &CallTarget{
+				APIName:   "/temporal.api.workflowservice.v1.WorkflowService/PollWorkflowTaskQueue",
+				Namespace: "payments",
+			},
+			decision: DecisionAllow,
+		},
+		{
+			name:   "api not in rule",
+			claims: &Claims{Subject: "payments-worker"},
+			target: &CallTarget{
+				APIName:   "/temporal.api.workflowservice.v1.WorkflowService/TerminateWorkflowExecution",
+				Namespace: "payments",
+			},
+			decision: DecisionDeny,
+		},
+		{
+			name:   "namespace not in rule",
+			claims: &Claims{Subject: "payments-worker"},
+			target: &CallTarget{
+				APIName:   "/temporal.api.workflowservice.v1.WorkflowService/PollWorkflowTaskQueue",
+				Namespace: "orders",
+			},
+			decision: DecisionDeny,
+		},
+		{
+			name:   "allowed workflow type",
+			claims: &Claims{Subject: "payments-worker"},
+			target: &CallTarget{
+				APIName:      startWorkflow,
+				Namespace:    "payments",
+				WorkflowType: "Charge",
+				TaskQueue:    "payments",
+			},
+			decision: DecisionAllow,
+		},
+		{
+			name:   "deny overrides allow",
+			claims: &Claims{Subject: "payments-worker"},
+			target: &CallTarget{
+				APIName:      startWorkflow,
+				Namespace:    "payments",
+				WorkflowType: "RefundOrder",
+				TaskQueue:    "payments",
+			},
+			decision: DecisionDeny,
+		},
+		{
+			name:   "search attribute",
+			claims: &Claims{Subject: "admin-alice"},
+			target: &CallTarget{
+				APIName:   startWorkflow,
+				Namespace: "payments",
+				Request: &workflowservice.StartWorkflowExecutionRequest{
+					SearchAttributes: &commonpb.SearchAttributes{
+						IndexedFields: map[string]*commonpb.Payload{"SecretValue": nil},
+					},
+				},
+			},
+			decision: DecisionDeny,
+		},
+		{
+			name:   "request field",
+			claims: &Claims{Subject: "admin-alice"},
+			target: &CallTarget{
+				APIName:   startWorkflow,
+				Namespace: "payments",
+				Request:   &workflowservice.StartWorkflowExecutionRequest{Identity: "ci-runner"},
+			},
+			decision: DecisionDeny,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			result, err := s.authorizer.Authorize(context.Background(), tc.claims, tc.target)
+			s.NoError(err)
+			s.Equal(tc.decision, result.Decision)
+		})
+	}
+}
+
+func (s *policyAuthorizerSuite) TestDenyReason() {
+	result, err := s.authorizer.Authorize(context.Background(), &Claims{Subject: "payments-worker"}, &CallTarget{
+		APIName:      "/temporal.api.workflowservice.v1.WorkflowService/StartWorkflowExecution",
+		Namespace:    "payments",
+		WorkflowType: "Refund",
+	})
+	s.NoError(err)
+	s.Equal(DecisionDeny, result.Decision)
+	s.Contains(result.Reason, "no-refunds")
+}
+
+func (s *policyAuthorizerSuite) TestReload() {
+	result, err := s.authorizer.Authorize(context.Background(), &Claims{Subject: "someone"}, &targetStartWorkflow)
+	s.NoError(err)
+	s.Equal(DecisionDeny, result.Decision)
+
+	s.writePolicy("defaultDecision: allow\n")
+	s.NoError(s.authorizer.update())
+
+	result, err = s.authorizer.Authorize(context.Background(), &Claims{Subject: "someone"}, &targetStartWorkflow)
+	s.NoError(err)
+	s.Equal(DecisionAllow, result.Decision)
+}
+
+func (s *policyAuthorizerSuite) TestReloadInvalidPolicyKeepsPrevious() {
+	s.writePolicy("rules:\n  - effect: maybe\n")
+	s.Error(s.authorizer.update())
+
+	result, err := s.authorizer.Authorize(context.Background(), &Claims{Subject: "admin-alice"}, &targetStartWorkflow)
+	s.NoError(err)
+	s.Equal(DecisionAllow, result.Decision)
+
+	// the broken file is not reported again until it changes
+	s.NoError(s.authorizer.update())
+}
+
+func (s *policyAuthorizerSuite) TestGetAuthorizerFromConfig() {
+	authorizer, err := GetAuthorizerFromConfig(&config.Authorization{
+		Authorizer:       "policy",
+		PolicyAuthorizer: config.PolicyAuthorizer{PolicyFile: s.policyFile},
+	})
+	s.NoError(err)
+	s.IsType(&policyAuthorizer{}, authorizer)
+
+	// stopping is idempotent, so the server may stop an authorizer that was already stopped
+	authorizer.(*policyAuthorizer).Stop()
+	authorizer.(*policyAuthorizer).Stop()
+}
+
+func (s *policyAuthorizerSuite) TestInvalidPolicy() {
+	testCases := map[string]string{
+		"unknown field":    "rules:\n  - effect: allow\n    subject: [foo]\n",
+		"missing effect":   "rules:\n  - name: foo\n",
+		"invalid default":  "defaultDecision: maybe\n",
+		"empty field path": "rules:\n  - effect: deny\n    fields:\n      \"\": [foo]\n",
+	}
+	for name, content := range testCases {
+		s.Run(name, func() {
+			_, err := parsePolicy([]byte(content))
+			s.Error(err)
+		})
+	}
+}
+
+func (s *policyAuthorizerSuite) writePolicy(content string) {
+	s.NoError(os.WriteFile(s.policyFile, []byte(content), 0644))
+	modTime := time.Now().Add(time.Minute)
+	s.NoError(os.Chtimes(s.policyFile, modTime, modTime))
+}
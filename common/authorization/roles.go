@@ -24,6 +24,10 @@
 
 package authorization
 
+import (
+	"strings"
+)
+
 type Role int16
 
 // @@@SNIPSTART temporal-common-authorization-role-enum
@@ -53,8 +57,56 @@ type Claims struct {
 	System Role
 	// Roles within specific namespaces
 	Namespaces map[string]Role
+	// Roles limited to workflow types or task queues within specific namespaces
+	Scopes map[string][]ScopedRole
 	// Free form bucket for extra data
 	Extensions interface{}
 }
 
 // @@@SNIPEND
+
+// ScopeKind identifies the kind of resource a ScopedRole is limited to
+type ScopeKind int
+
+const (
+	ScopeKindWorkflowType ScopeKind = iota + 1
+	ScopeKindTaskQueue
+)
+
+// ScopedRole grants a role for calls targeting a subset of the workflow types or task queues of a namespace.
+// Pattern matches names exactly, unless it ends with "*", in which case it matches names with the preceding prefix.
+type ScopedRole struct {
+	Kind    ScopeKind
+	Pattern string
+	Role    Role
+}
+
+// Matches checks if the workflow type or task queue of the call target falls under the scope.
+// Targets that don't carry the kind of resource the scope is limited to never match.
+func (r ScopedRole) Matches(target *CallTarget) bool {
+	var name string
+	switch r.Kind {
+	case ScopeKindWorkflowType:
+		name = target.WorkflowType
+	case ScopeKindTaskQueue:
+		name = target.TaskQueue
+	}
+	if name == "" {
+		return false
+	}
+	if prefix, ok := strings.CutSuffix(r.Pattern, "*"); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return name == r.Pattern
+}
+
+// scopedRole returns the union of the roles of all scopes within the target namespace that match the target.
+func (c *Claims) scopedRole(target *CallTarget) Role {
+	var role Role
+	for _, scope := range c.Scopes[target.Namespace] {
+		if scope.Matches(target) {
+			role |= scope.Role
+		}
+	}
+	return role
+}
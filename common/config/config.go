@@ -65,6 +65,9 @@ type (
 		// DynamicConfigClient is the config for setting up the file based dynamic config client
 		// Filepath should be relative to the root directory
 		DynamicConfigClient *dynamicconfig.FileBasedClientConfig `yaml:"dynamicConfigClient"`
+		// DynamicConfigDirectoryClient is the config for setting up a dynamic config client that merges
+		// all files in a directory. It can't be used together with DynamicConfigClient.
+		DynamicConfigDirectoryClient *dynamicconfig.DirectoryClientConfig `yaml:"dynamicConfigDirectoryClient"`
 		// NamespaceDefaults is the default config for every namespace
 		NamespaceDefaults NamespaceDefaults `yaml:"namespaceDefaults"`
 		// ExporterConfig allows the specification of process-wide OTEL exporters
@@ -264,6 +267,8 @@ type (
 		CustomDataStoreConfig *CustomDatastoreConfig `yaml:"customDatastore"`
 		// ElasticSearch contains the config for a ElasticSearch datastore
 		Elasticsearch *client.Config `yaml:"elasticsearch"`
+		// Embedded contains the config for the embedded visibility store. It can only be used as visibility store.
+		Embedded *EmbeddedVisibility `yaml:"embedded"`
 	}
 
 	FaultInjection struct {
@@ -413,6 +418,20 @@ type (
 		TLS *auth.TLS `yaml:"tls"`
 	}
 
+	// EmbeddedVisibility is the configuration of the visibility store which indexes visibility records
+	// in-process, without an external service. All services must run in the same process.
+	EmbeddedVisibility struct {
+		// IndexName is the name custom search attributes are registered under.
+		IndexName string `yaml:"indexName"`
+		// SnapshotPath is the file the index is persisted to. Changes are appended to a write-ahead
+		// log next to it, with the ".wal" suffix, before they are acknowledged. If empty, the index
+		// is kept in memory only.
+		SnapshotPath string `yaml:"snapshotPath"`
+		// SnapshotInterval is how often the index is written to SnapshotPath, which empties the
+		// write-ahead log. Defaults to 10s.
+		SnapshotInterval time.Duration `yaml:"snapshotInterval"`
+	}
+
 	// CustomDatastoreConfig is the configuration for connecting to a custom datastore that is not supported by temporal core
 	CustomDatastoreConfig struct {
 		// Name of the custom datastore
@@ -459,6 +478,7 @@ type (
 		Filestore *FilestoreArchiver `yaml:"filestore"`
 		Gstorage  *GstorageArchiver  `yaml:"gstorage"`
 		S3store   *S3Archiver        `yaml:"s3store"`
+		Azblob    *AzblobArchiver    `yaml:"azblob"`
 	}
 
 	// VisibilityArchival contains the config for visibility archival
@@ -476,12 +496,15 @@ type (
 		Filestore *FilestoreArchiver `yaml:"filestore"`
 		S3store   *S3Archiver        `yaml:"s3store"`
 		Gstorage  *GstorageArchiver  `yaml:"gstorage"`
+		Azblob    *AzblobArchiver    `yaml:"azblob"`
 	}
 
 	// FilestoreArchiver contain the config for filestore archiver
 	FilestoreArchiver struct {
 		FileMode string `yaml:"fileMode"`
 		DirMode  string `yaml:"dirMode"`
+		// Parquet switches the visibility archiver to the columnar layout, only used for visibility archival
+		Parquet *ParquetVisibilityArchiver `yaml:"parquet"`
 	}
 
 	// GstorageArchiver contain the config for google storage archiver
@@ -495,6 +518,34 @@ type (
 		Endpoint         *string `yaml:"endpoint"`
 		S3ForcePathStyle bool    `yaml:"s3ForcePathStyle"`
 		LogLevel         uint    `yaml:"logLevel"`
+		// Parquet switches the visibility archiver to the columnar layout, only used for visibility archival
+		Parquet *ParquetVisibilityArchiver `yaml:"parquet"`
+	}
+
+	// ParquetVisibilityArchiver contains the config for the columnar (Parquet) visibility layout.
+	// Records are batched into Parquet files partitioned by namespace and close date
+	// instead of being written to one file per record.
+	ParquetVisibilityArchiver struct {
+		// MaxBatchSize is the maximum number of records written to a single file, defaults to 1000
+		MaxBatchSize int `yaml:"maxBatchSize"`
+		// FlushInterval is how long records are buffered before being written, defaults to 1s.
+		// Archive calls block until the batch holding their record is written.
+		FlushInterval time.Duration `yaml:"flushInterval"`
+		// CompactionThreshold is the number of files of a partition merged into a single file by a compaction,
+		// defaults to 16. Every flush writes a small file, compactions keep the number of files of a partition
+		// logarithmic in the number of flushes.
+		CompactionThreshold int `yaml:"compactionThreshold"`
+	}
+
+	// AzblobArchiver contains the config for Azure Blob Storage archiver.
+	// Credentials are resolved in order: ConnectionString, AccountName with AccountKey,
+	// and finally the Azure default credential chain (environment, managed identity, CLI).
+	AzblobArchiver struct {
+		// Endpoint is the blob service URL, defaults to https://<accountName>.blob.core.windows.net/
+		Endpoint         string `yaml:"endpoint"`
+		AccountName      string `yaml:"accountName"`
+		AccountKey       string `yaml:"accountKey"`
+		ConnectionString string `yaml:"connectionString"`
 	}
 
 	// PublicClient is the config for internal nodes (history/matching/worker) connecting to
@@ -557,14 +608,59 @@ type (
 		// Signing key provider for validating JWT tokens
 		JWTKeyProvider       JWTKeyProvider `yaml:"jwtKeyProvider"`
 		PermissionsClaimName string         `yaml:"permissionsClaimName"`
-		// Empty string for noopAuthorizer or "default" for defaultAuthorizer
+		// Empty string for noopAuthorizer, "default" for defaultAuthorizer or "policy" for policyAuthorizer
 		Authorizer string `yaml:"authorizer"`
+		// Configuration of the policy authorizer, only used when Authorizer is "policy"
+		PolicyAuthorizer PolicyAuthorizer `yaml:"policyAuthorizer"`
 		// Empty string for noopClaimMapper or "default" for defaultJWTClaimMapper
 		ClaimMapper string `yaml:"claimMapper"`
 		// Name of main auth header to pass to ClaimMapper (as `AuthToken`). Defaults to `authorization`.
 		AuthHeaderName string `yaml:"authHeaderName"`
 		// Name of extra auth header to pass to ClaimMapper (as `ExtraData`). Defaults to `authorization-extras`.
 		AuthExtraHeaderName string `yaml:"authExtraHeaderName"`
+		// Audit configures where authorization decisions are recorded
+		Audit AuthorizationAudit `yaml:"audit"`
+	}
+
+	// AuthorizationAudit contains the config for the authorization decision audit log
+	AuthorizationAudit struct {
+		// Sink is "file" or "syslog". Empty string disables the audit log.
+		Sink string `yaml:"sink"`
+		// File configures the file sink, only used when Sink is "file"
+		File AuditFileSink `yaml:"file"`
+		// Syslog configures the syslog sink, only used when Sink is "syslog"
+		Syslog AuditSyslogSink `yaml:"syslog"`
+		// BufferSize is the number of records waiting to be written to the sink. Records are dropped
+		// while the buffer is full. Defaults to 10000.
+		BufferSize int `yaml:"bufferSize"`
+	}
+
+	// AuditFileSink writes audit records as JSON lines to a file that is rotated by size
+	AuditFileSink struct {
+		// Path of the audit log file
+		Path string `yaml:"path"`
+		// MaxSizeMB is the size at which the file is rotated. Defaults to 100.
+		MaxSizeMB int `yaml:"maxSizeMB"`
+		// MaxBackups is the number of rotated files to keep. Defaults to 5.
+		MaxBackups int `yaml:"maxBackups"`
+	}
+
+	// AuditSyslogSink writes audit records as JSON to syslog
+	AuditSyslogSink struct {
+		// Network and Address of the syslog server, e.g. "udp" and "localhost:514".
+		// Both empty connects to the local syslog server.
+		Network string `yaml:"network"`
+		Address string `yaml:"address"`
+		// Tag of the syslog messages. Defaults to "temporal-audit".
+		Tag string `yaml:"tag"`
+	}
+
+	// PolicyAuthorizer contains the config for the policy based authorizer
+	PolicyAuthorizer struct {
+		// PolicyFile is the path to the YAML file containing the authorization rules
+		PolicyFile string `yaml:"policyFile"`
+		// PollInterval is how often the policy file is checked for changes. Defaults to 10 seconds.
+		PollInterval time.Duration `yaml:"pollInterval"`
 	}
 
 	// @@@SNIPSTART temporal-common-service-config-jwtkeyprovider
@@ -577,13 +673,14 @@ type (
 )
 
 const (
-	ShardStoreName     DataStoreName = "ShardStore"
-	TaskStoreName      DataStoreName = "TaskStore"
-	MetadataStoreName  DataStoreName = "MetadataStore"
-	ExecutionStoreName DataStoreName = "ExecutionStore"
-	QueueName          DataStoreName = "Queue"
-	QueueV2Name        DataStoreName = "QueueV2"
-	ClusterMDStoreName DataStoreName = "ClusterMDStore"
+	ShardStoreName                DataStoreName = "ShardStore"
+	TaskStoreName                 DataStoreName = "TaskStore"
+	MetadataStoreName             DataStoreName = "MetadataStore"
+	ExecutionStoreName            DataStoreName = "ExecutionStore"
+	QueueName                     DataStoreName = "Queue"
+	QueueV2Name                   DataStoreName = "QueueV2"
+	ClusterMDStoreName            DataStoreName = "ClusterMDStore"
+	NexusIncomingServiceStoreName DataStoreName = "NexusIncomingServiceStore"
 )
 
 const (
@@ -80,6 +80,9 @@ func (c *Persistence) Validate() error {
 	if c.VisibilityStore == "" {
 		return errors.New("persistence config: visibilityStore must be specified")
 	}
+	if c.DataStores[c.DefaultStore].Embedded != nil {
+		return errors.New("persistence config: defaultStore cannot be embedded visibility datastore")
+	}
 	if c.DataStores[c.VisibilityStore].Elasticsearch != nil && c.SecondaryVisibilityStore != "" {
 		return errors.New(
 			"persistence config: cannot set secondaryVisibilityStore " +
@@ -134,6 +137,11 @@ func (c *Persistence) IsSQLVisibilityStore() bool {
 		(c.SecondaryVisibilityConfigExist() && c.DataStores[c.SecondaryVisibilityStore].SQL != nil)
 }
 
+func (c *Persistence) IsEmbeddedVisibilityStore() bool {
+	return (c.VisibilityConfigExist() && c.DataStores[c.VisibilityStore].Embedded != nil) ||
+		(c.SecondaryVisibilityConfigExist() && c.DataStores[c.SecondaryVisibilityStore].Embedded != nil)
+}
+
 func (c *Persistence) GetVisibilityStoreConfig() DataStore {
 	return c.DataStores[c.VisibilityStore]
 }
@@ -149,6 +157,8 @@ func (c *Persistence) GetSecondaryVisibilityStoreConfig() DataStore {
 			esConfig.Indices = map[string]string{
 				client.VisibilityAppName: ds.Elasticsearch.GetSecondaryVisibilityIndex(),
 			}
+			// Namespace indices are dedicated to the primary visibility.
+			esConfig.NamespaceIndices = nil
 			ds.Elasticsearch = &esConfig
 			return ds
 		}
@@ -164,6 +174,8 @@ func (ds *DataStore) GetIndexName() string {
 		return ds.Cassandra.Keyspace
 	case ds.Elasticsearch != nil:
 		return ds.Elasticsearch.GetVisibilityIndex()
+	case ds.Embedded != nil:
+		return ds.Embedded.IndexName
 	default:
 		return ""
 	}
@@ -184,10 +196,13 @@ func (ds *DataStore) Validate() error {
 	if ds.Elasticsearch != nil {
 		storeConfigCount++
 	}
+	if ds.Embedded != nil {
+		storeConfigCount++
+	}
 	if storeConfigCount != 1 {
 		return errors.New(
 			"must provide config for one and only one datastore: " +
-				"elasticsearch, cassandra, sql or custom store",
+				"elasticsearch, embedded, cassandra, sql or custom store",
 		)
 	}
 
@@ -204,6 +219,9 @@ func (ds *DataStore) Validate() error {
 			return err
 		}
 	}
+	if ds.Embedded != nil && ds.Embedded.IndexName == "" {
+		return errors.New("embedded config: indexName must be specified")
+	}
 	return nil
 }
 
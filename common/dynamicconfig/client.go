@@ -51,6 +51,19 @@ type (
 		GetValue(key Key) []ConstrainedValue
 	}
 
+	// ValueSourceClient is implemented by Clients that keep track of where each value was
+	// configured, such as the name of the file it was read from.
+	ValueSourceClient interface {
+		GetValueSource(key Key, constraints Constraints) (string, bool)
+	}
+
+	// ListableClient is implemented by Clients that can list all values they have, which
+	// allows them to be validated.
+	ListableClient interface {
+		// GetAllValues returns all values by key. Keys may be returned lower-cased.
+		GetAllValues() map[Key][]ConstrainedValue
+	}
+
 	// Key is a key/property stored in dynamic config. For convenience, it is recommended that
 	// you treat keys as case-insensitive.
 	Key string
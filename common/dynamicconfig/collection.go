@@ -48,6 +48,15 @@ type (
 		errCount int64
 	}
 
+	// ResolvedValue is the value of a key in effect for a set of constraints
+	ResolvedValue struct {
+		Value any
+		// Constraints of the configured or default value that applies
+		Constraints Constraints
+		// FromDefault is true if no configured value applies
+		FromDefault bool
+	}
+
 	// These function types follow a similar pattern:
 	//   {X}PropertyFn - returns a value of type X that is global (no filters)
 	//   {X}PropertyFnWith{Y}Filter - returns a value of type X with the given filters
@@ -116,6 +125,7 @@ func (c *Collection) throttleLog() bool {
 
 // GetIntProperty gets property and asserts that it's an integer
 func (c *Collection) GetIntProperty(key Key, defaultValue any) IntPropertyFn {
+	registerKey(key, defaultValue, ValueTypeInt, PrecedenceGlobal)
 	return func() int {
 		return matchAndConvert(
 			c,
@@ -129,6 +139,7 @@ func (c *Collection) GetIntProperty(key Key, defaultValue any) IntPropertyFn {
 
 // GetIntPropertyFilteredByNamespace gets property with namespace filter and asserts that it's an integer
 func (c *Collection) GetIntPropertyFilteredByNamespace(key Key, defaultValue any) IntPropertyFnWithNamespaceFilter {
+	registerKey(key, defaultValue, ValueTypeInt, PrecedenceNamespace)
 	return func(namespace string) int {
 		return matchAndConvert(
 			c,
@@ -142,6 +153,7 @@ func (c *Collection) GetIntPropertyFilteredByNamespace(key Key, defaultValue any
 
 // GetIntPropertyFilteredByTaskQueueInfo gets property with taskQueueInfo as filters and asserts that it's an integer
 func (c *Collection) GetIntPropertyFilteredByTaskQueueInfo(key Key, defaultValue any) IntPropertyFnWithTaskQueueInfoFilters {
+	registerKey(key, defaultValue, ValueTypeInt, PrecedenceTaskQueue)
 	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) int {
 		return matchAndConvert(
 			c,
@@ -155,6 +167,7 @@ func (c *Collection) GetIntPropertyFilteredByTaskQueueInfo(key Key, defaultValue
 
 // GetIntPropertyFilteredByShardID gets property with shardID as filter and asserts that it's an integer
 func (c *Collection) GetIntPropertyFilteredByShardID(key Key, defaultValue any) IntPropertyFnWithShardIDFilter {
+	registerKey(key, defaultValue, ValueTypeInt, PrecedenceShardID)
 	return func(shardID int32) int {
 		return matchAndConvert(
 			c,
@@ -168,6 +181,7 @@ func (c *Collection) GetIntPropertyFilteredByShardID(key Key, defaultValue any)
 
 // GetFloat64Property gets property and asserts that it's a float64
 func (c *Collection) GetFloat64Property(key Key, defaultValue any) FloatPropertyFn {
+	registerKey(key, defaultValue, ValueTypeFloat, PrecedenceGlobal)
 	return func() float64 {
 		return matchAndConvert(
 			c,
@@ -181,6 +195,7 @@ func (c *Collection) GetFloat64Property(key Key, defaultValue any) FloatProperty
 
 // GetFloat64PropertyFilteredByShardID gets property with shardID filter and asserts that it's a float64
 func (c *Collection) GetFloat64PropertyFilteredByShardID(key Key, defaultValue any) FloatPropertyFnWithShardIDFilter {
+	registerKey(key, defaultValue, ValueTypeFloat, PrecedenceShardID)
 	return func(shardID int32) float64 {
 		return matchAndConvert(
 			c,
@@ -194,6 +209,7 @@ func (c *Collection) GetFloat64PropertyFilteredByShardID(key Key, defaultValue a
 
 // GetFloatPropertyFilteredByNamespace gets property with namespace filter and asserts that it's a float64
 func (c *Collection) GetFloatPropertyFilteredByNamespace(key Key, defaultValue any) FloatPropertyFnWithNamespaceFilter {
+	registerKey(key, defaultValue, ValueTypeFloat, PrecedenceNamespace)
 	return func(namespace string) float64 {
 		return matchAndConvert(
 			c,
@@ -207,6 +223,7 @@ func (c *Collection) GetFloatPropertyFilteredByNamespace(key Key, defaultValue a
 
 // GetFloatPropertyFilteredByTaskQueueInfo gets property with taskQueueInfo as filters and asserts that it's a float64
 func (c *Collection) GetFloatPropertyFilteredByTaskQueueInfo(key Key, defaultValue any) FloatPropertyFnWithTaskQueueInfoFilters {
+	registerKey(key, defaultValue, ValueTypeFloat, PrecedenceTaskQueue)
 	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) float64 {
 		return matchAndConvert(
 			c,
@@ -220,6 +237,7 @@ func (c *Collection) GetFloatPropertyFilteredByTaskQueueInfo(key Key, defaultVal
 
 // GetDurationProperty gets property and asserts that it's a duration
 func (c *Collection) GetDurationProperty(key Key, defaultValue any) DurationPropertyFn {
+	registerKey(key, defaultValue, ValueTypeDuration, PrecedenceGlobal)
 	return func() time.Duration {
 		return matchAndConvert(
 			c,
@@ -233,6 +251,7 @@ func (c *Collection) GetDurationProperty(key Key, defaultValue any) DurationProp
 
 // GetDurationPropertyFilteredByNamespace gets property with namespace filter and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByNamespace(key Key, defaultValue any) DurationPropertyFnWithNamespaceFilter {
+	registerKey(key, defaultValue, ValueTypeDuration, PrecedenceNamespace)
 	return func(namespace string) time.Duration {
 		return matchAndConvert(
 			c,
@@ -246,6 +265,7 @@ func (c *Collection) GetDurationPropertyFilteredByNamespace(key Key, defaultValu
 
 // GetDurationPropertyFilteredByNamespaceID gets property with namespaceID filter and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByNamespaceID(key Key, defaultValue any) DurationPropertyFnWithNamespaceIDFilter {
+	registerKey(key, defaultValue, ValueTypeDuration, PrecedenceNamespaceID)
 	return func(namespaceID string) time.Duration {
 		return matchAndConvert(
 			c,
@@ -259,6 +279,7 @@ func (c *Collection) GetDurationPropertyFilteredByNamespaceID(key Key, defaultVa
 
 // GetDurationPropertyFilteredByTaskQueueInfo gets property with taskQueueInfo as filters and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByTaskQueueInfo(key Key, defaultValue any) DurationPropertyFnWithTaskQueueInfoFilters {
+	registerKey(key, defaultValue, ValueTypeDuration, PrecedenceTaskQueue)
 	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) time.Duration {
 		return matchAndConvert(
 			c,
@@ -272,6 +293,7 @@ func (c *Collection) GetDurationPropertyFilteredByTaskQueueInfo(key Key, default
 
 // GetDurationPropertyFilteredByShardID gets property with shardID id as filter and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByShardID(key Key, defaultValue any) DurationPropertyFnWithShardIDFilter {
+	registerKey(key, defaultValue, ValueTypeDuration, PrecedenceShardID)
 	return func(shardID int32) time.Duration {
 		return matchAndConvert(
 			c,
@@ -285,6 +307,7 @@ func (c *Collection) GetDurationPropertyFilteredByShardID(key Key, defaultValue
 
 // GetDurationPropertyFilteredByTaskType gets property with task type as filters and asserts that it's a duration
 func (c *Collection) GetDurationPropertyFilteredByTaskType(key Key, defaultValue any) DurationPropertyFnWithTaskTypeFilter {
+	registerKey(key, defaultValue, ValueTypeDuration, PrecedenceTaskType)
 	return func(taskType enumsspb.TaskType) time.Duration {
 		return matchAndConvert(
 			c,
@@ -298,6 +321,7 @@ func (c *Collection) GetDurationPropertyFilteredByTaskType(key Key, defaultValue
 
 // GetBoolProperty gets property and asserts that it's a bool
 func (c *Collection) GetBoolProperty(key Key, defaultValue any) BoolPropertyFn {
+	registerKey(key, defaultValue, ValueTypeBool, PrecedenceGlobal)
 	return func() bool {
 		return matchAndConvert(
 			c,
@@ -311,6 +335,7 @@ func (c *Collection) GetBoolProperty(key Key, defaultValue any) BoolPropertyFn {
 
 // GetStringProperty gets property and asserts that it's a string
 func (c *Collection) GetStringProperty(key Key, defaultValue any) StringPropertyFn {
+	registerKey(key, defaultValue, ValueTypeString, PrecedenceGlobal)
 	return func() string {
 		return matchAndConvert(
 			c,
@@ -324,6 +349,7 @@ func (c *Collection) GetStringProperty(key Key, defaultValue any) StringProperty
 
 // GetMapProperty gets property and asserts that it's a map
 func (c *Collection) GetMapProperty(key Key, defaultValue any) MapPropertyFn {
+	registerKey(key, defaultValue, ValueTypeMap, PrecedenceGlobal)
 	return func() map[string]interface{} {
 		return matchAndConvert(
 			c,
@@ -337,6 +363,7 @@ func (c *Collection) GetMapProperty(key Key, defaultValue any) MapPropertyFn {
 
 // GetStringPropertyFnWithNamespaceFilter gets property with namespace filter and asserts that it's a string
 func (c *Collection) GetStringPropertyFnWithNamespaceFilter(key Key, defaultValue any) StringPropertyFnWithNamespaceFilter {
+	registerKey(key, defaultValue, ValueTypeString, PrecedenceNamespace)
 	return func(namespace string) string {
 		return matchAndConvert(
 			c,
@@ -350,6 +377,7 @@ func (c *Collection) GetStringPropertyFnWithNamespaceFilter(key Key, defaultValu
 
 // GetStringPropertyFnWithNamespaceIDFilter gets property with namespace ID filter and asserts that it's a string
 func (c *Collection) GetStringPropertyFnWithNamespaceIDFilter(key Key, defaultValue any) StringPropertyFnWithNamespaceIDFilter {
+	registerKey(key, defaultValue, ValueTypeString, PrecedenceNamespaceID)
 	return func(namespaceID string) string {
 		return matchAndConvert(
 			c,
@@ -363,6 +391,7 @@ func (c *Collection) GetStringPropertyFnWithNamespaceIDFilter(key Key, defaultVa
 
 // GetMapPropertyFnWithNamespaceFilter gets property and asserts that it's a map
 func (c *Collection) GetMapPropertyFnWithNamespaceFilter(key Key, defaultValue any) MapPropertyFnWithNamespaceFilter {
+	registerKey(key, defaultValue, ValueTypeMap, PrecedenceNamespace)
 	return func(namespace string) map[string]interface{} {
 		return matchAndConvert(
 			c,
@@ -376,6 +405,7 @@ func (c *Collection) GetMapPropertyFnWithNamespaceFilter(key Key, defaultValue a
 
 // GetBoolPropertyFnWithNamespaceFilter gets property with namespace filter and asserts that it's a bool
 func (c *Collection) GetBoolPropertyFnWithNamespaceFilter(key Key, defaultValue any) BoolPropertyFnWithNamespaceFilter {
+	registerKey(key, defaultValue, ValueTypeBool, PrecedenceNamespace)
 	return func(namespace string) bool {
 		return matchAndConvert(
 			c,
@@ -389,6 +419,7 @@ func (c *Collection) GetBoolPropertyFnWithNamespaceFilter(key Key, defaultValue
 
 // GetBoolPropertyFnWithNamespaceIDFilter gets property with namespaceID filter and asserts that it's a bool
 func (c *Collection) GetBoolPropertyFnWithNamespaceIDFilter(key Key, defaultValue any) BoolPropertyFnWithNamespaceIDFilter {
+	registerKey(key, defaultValue, ValueTypeBool, PrecedenceNamespaceID)
 	return func(namespaceID string) bool {
 		return matchAndConvert(
 			c,
@@ -402,6 +433,7 @@ func (c *Collection) GetBoolPropertyFnWithNamespaceIDFilter(key Key, defaultValu
 
 // GetBoolPropertyFilteredByTaskQueueInfo gets property with taskQueueInfo as filters and asserts that it's a bool
 func (c *Collection) GetBoolPropertyFilteredByTaskQueueInfo(key Key, defaultValue any) BoolPropertyFnWithTaskQueueInfoFilters {
+	registerKey(key, defaultValue, ValueTypeBool, PrecedenceTaskQueue)
 	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) bool {
 		return matchAndConvert(
 			c,
@@ -423,6 +455,60 @@ func (c *Collection) HasKey(key Key) bool {
 	return len(cvs) > 0
 }
 
+// GetValues returns the values configured for key in the underlying Client.
+func (c *Collection) GetValues(key Key) []ConstrainedValue {
+	return c.client.GetValue(key)
+}
+
+// GetValueSource returns where the value of key for exactly the given constraints was
+// configured, if the underlying Client keeps track of it.
+func (c *Collection) GetValueSource(key Key, constraints Constraints) (string, bool) {
+	if sc, ok := c.client.(ValueSourceClient); ok {
+		return sc.GetValueSource(key, constraints)
+	}
+	return "", false
+}
+
+// Resolve returns the value of a registered key that is in effect for the given constraints,
+// following the same lookup and conversion rules as the property functions. Constraints that
+// the key is not looked up with are ignored. It returns false if the key is not registered, or if
+// no configured value matches and the default value of the key is computed where it is used and
+// no property for the key was created in this process.
+func (c *Collection) Resolve(key Key, constraints Constraints) (ResolvedValue, bool) {
+	info, ok := GetKeyInfo(key)
+	if !ok {
+		return ResolvedValue{}, false
+	}
+
+	cvs := c.client.GetValue(key)
+	defaultCVs, _ := info.Default.([]ConstrainedValue)
+	for _, m := range info.Precedence.constraintsPrecedence(constraints) {
+		for _, cv := range cvs {
+			if m == cv.Constraints {
+				if value, err := info.Type.convert(cv.Value); err == nil {
+					return ResolvedValue{Value: value, Constraints: cv.Constraints}, true
+				}
+				return resolveDefault(info), true
+			}
+		}
+		for _, cv := range defaultCVs {
+			if m == cv.Constraints {
+				value, _ := info.Type.convert(cv.Value)
+				return ResolvedValue{Value: value, Constraints: cv.Constraints, FromDefault: true}, true
+			}
+		}
+	}
+	if info.Default == nil {
+		return ResolvedValue{}, false
+	}
+	return resolveDefault(info), true
+}
+
+func resolveDefault(info KeyInfo) ResolvedValue {
+	value, _ := info.Type.convert(info.Default)
+	return ResolvedValue{Value: value, FromDefault: true}
+}
+
 func findMatch(cvs, defaultCVs []ConstrainedValue, precedence []Constraints) (any, error) {
 	if len(cvs)+len(defaultCVs) == 0 {
 		return nil, errKeyNotPresent
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
 
 	enumsspb "go.temporal.io/server/api/enums/v1"
 	"go.temporal.io/server/common/log"
@@ -54,6 +55,8 @@ const (
 	testGetBoolPropertyFilteredByNamespaceIDKey       = "testGetBoolPropertyFilteredByNamespaceIDKey"
 	testGetBoolPropertyFilteredByTaskQueueInfoKey     = "testGetBoolPropertyFilteredByTaskQueueInfoKey"
 	testGetStringPropertyFilteredByNamespaceIDKey     = "testGetStringPropertyFilteredByNamespaceIDKey"
+	testResolveKey                                    = "testResolveKey"
+	testResolveStructuredDefaultsKey                  = "testResolveStructuredDefaultsKey"
 )
 
 // Note: fileBasedClientSuite also heavily tests Collection, since some tests are easier with data
@@ -393,3 +396,64 @@ func BenchmarkCollection(b *testing.B) {
 		}
 	})
 }
+
+func (s *collectionSuite) TestResolve_StaticRegistration() {
+	// registered from keys_gen.go without creating a property
+	info, ok := GetKeyInfo(ReplicationTaskProcessorErrorRetryMaxInterval)
+	s.True(ok)
+	s.Equal(ValueTypeDuration, info.Type)
+	s.Equal(PrecedenceShardID, info.Precedence)
+	s.Equal(5*time.Second, info.Default)
+
+	s.client[ReplicationTaskProcessorErrorRetryMaxInterval] = []ConstrainedValue{
+		{Constraints: Constraints{ShardID: 3}, Value: "1m"},
+	}
+	resolved, ok := s.cln.Resolve(ReplicationTaskProcessorErrorRetryMaxInterval, Constraints{ShardID: 3})
+	s.True(ok)
+	s.Equal(ResolvedValue{Value: time.Minute, Constraints: Constraints{ShardID: 3}}, resolved)
+	resolved, ok = s.cln.Resolve(ReplicationTaskProcessorErrorRetryMaxInterval, Constraints{ShardID: 4})
+	s.True(ok)
+	s.Equal(ResolvedValue{Value: 5 * time.Second, FromDefault: true}, resolved)
+}
+
+func (s *collectionSuite) TestResolve() {
+	_, ok := s.cln.Resolve(testResolveKey, Constraints{})
+	s.False(ok)
+
+	value := s.cln.GetDurationPropertyFilteredByNamespace(testResolveKey, time.Second)
+	info, ok := GetKeyInfo("TESTRESOLVEKEY")
+	s.True(ok)
+	s.Equal(KeyInfo{Key: testResolveKey, Type: ValueTypeDuration, Precedence: PrecedenceNamespace, Default: time.Second}, info)
+	s.Contains(RegisteredKeys(), info)
+
+	resolved, ok := s.cln.Resolve(testResolveKey, Constraints{Namespace: "ns1"})
+	s.True(ok)
+	s.Equal(ResolvedValue{Value: time.Second, FromDefault: true}, resolved)
+
+	s.client[testResolveKey] = []ConstrainedValue{
+		{Constraints: Constraints{Namespace: "ns1"}, Value: "5s"},
+		{Value: "2s"},
+	}
+	resolved, _ = s.cln.Resolve(testResolveKey, Constraints{Namespace: "ns1", TaskQueueName: "ignored"})
+	s.Equal(ResolvedValue{Value: 5 * time.Second, Constraints: Constraints{Namespace: "ns1"}}, resolved)
+	s.Equal(value("ns1"), resolved.Value)
+	resolved, _ = s.cln.Resolve(testResolveKey, Constraints{Namespace: "ns2"})
+	s.Equal(ResolvedValue{Value: 2 * time.Second}, resolved)
+	s.Equal(value("ns2"), resolved.Value)
+
+	s.client[testResolveKey] = "invalid"
+	resolved, _ = s.cln.Resolve(testResolveKey, Constraints{Namespace: "ns1"})
+	s.Equal(ResolvedValue{Value: time.Second, FromDefault: true}, resolved)
+}
+
+func (s *collectionSuite) TestResolveStructuredDefaults() {
+	value := s.cln.GetIntPropertyFilteredByTaskQueueInfo(testResolveStructuredDefaultsKey, []ConstrainedValue{
+		{Constraints: Constraints{TaskQueueName: "tq"}, Value: 3},
+		{Value: 1},
+	})
+	resolved, _ := s.cln.Resolve(testResolveStructuredDefaultsKey, Constraints{Namespace: "ns", TaskQueueName: "tq"})
+	s.Equal(ResolvedValue{Value: 3, Constraints: Constraints{TaskQueueName: "tq"}, FromDefault: true}, resolved)
+	s.Equal(value("ns", "tq", enumspb.TASK_QUEUE_TYPE_WORKFLOW), resolved.Value)
+	resolved, _ = s.cln.Resolve(testResolveStructuredDefaultsKey, Constraints{Namespace: "ns", TaskQueueName: "other"})
+	s.Equal(ResolvedValue{Value: 1, FromDefault: true}, resolved)
+}
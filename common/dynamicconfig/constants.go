@@ -22,6 +22,8 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
+//go:generate go run ../../cmd/tools/gendynamicconfigkeys
+
 package dynamicconfig
 
 func (k Key) String() string {
@@ -215,6 +217,14 @@ const (
 	RemovableBuildIdDurationSinceDefault = "worker.removableBuildIdDurationSinceDefault"
 	// BuildIdScavengerVisibilityRPS is the rate limit for visibility calls from the build id scavenger
 	BuildIdScavenengerVisibilityRPS = "worker.buildIdScavengerVisibilityRPS"
+	// NexusIncomingServiceNameMaxLength is the maximum length of a Nexus incoming service name.
+	NexusIncomingServiceNameMaxLength = "limit.incomingServiceNameMaxLength"
+	// NexusIncomingServiceMaxSize is the maximum size of a Nexus incoming service in bytes.
+	NexusIncomingServiceMaxSize = "limit.incomingServiceMaxSize"
+	// NexusIncomingServiceListDefaultPageSize is the default page size for listing Nexus incoming services.
+	NexusIncomingServiceListDefaultPageSize = "limit.incomingServiceListDefaultPageSize"
+	// NexusIncomingServiceListMaxPageSize is the maximum page size for listing Nexus incoming services.
+	NexusIncomingServiceListMaxPageSize = "limit.incomingServiceListMaxPageSize"
 
 	// keys for frontend
 
@@ -304,6 +314,8 @@ const (
 	FrontendShutdownDrainDuration = "frontend.shutdownDrainDuration"
 	// FrontendShutdownFailHealthCheckDuration is the duration of shutdown failure detection
 	FrontendShutdownFailHealthCheckDuration = "frontend.shutdownFailHealthCheckDuration"
+	// FrontendMaxConcurrentVisibilityWatches is the max number of concurrent WatchWorkflowExecutions streams per frontend host
+	FrontendMaxConcurrentVisibilityWatches = "frontend.maxConcurrentVisibilityWatches"
 	// FrontendMaxBadBinaries is the max number of bad binaries in namespace config
 	FrontendMaxBadBinaries = "frontend.maxBadBinaries"
 	// SendRawWorkflowHistory is whether to enable raw history retrieving
@@ -364,6 +376,13 @@ const (
 	// that are sent to the history service using the new RPCs. The remaining access history via the existing implementation.
 	// TODO: remove once migration completes.
 	FrontendAdminDeleteAccessHistoryFraction = "frontend.adminDeleteAccessHistoryFraction"
+	// FrontendAuthorizationAuditSamplingRate (0.0~1.0) is the fraction of allowed calls that are recorded in the
+	// authorization audit log. Denied calls are always recorded.
+	FrontendAuthorizationAuditSamplingRate = "frontend.authorizationAuditSamplingRate"
+	// FrontendAuthorizationAuditRedactSubject replaces the subject of the caller in authorization audit records
+	FrontendAuthorizationAuditRedactSubject = "frontend.authorizationAuditRedactSubject"
+	// FrontendAuthorizationAuditRedactWorkflowID replaces the target workflow ID in authorization audit records
+	FrontendAuthorizationAuditRedactWorkflowID = "frontend.authorizationAuditRedactWorkflowID"
 
 	// FrontendEnableUpdateWorkflowExecution enables UpdateWorkflowExecution API in the frontend.
 	// The UpdateWorkflowExecution API has gone through rigorous testing efforts but this config's default is `false` until the
@@ -705,6 +724,10 @@ const (
 	VisibilityProcessorEnableCloseWorkflowCleanup = "history.visibilityProcessorEnableCloseWorkflowCleanup"
 	// VisibilityQueueMaxReaderCount is the max number of readers in one multi-cursor visibility queue
 	VisibilityQueueMaxReaderCount = "history.visibilityQueueMaxReaderCount"
+	// VisibilityWatchEnabled enables streaming the visibility changes written by the visibility queues to watchers
+	VisibilityWatchEnabled = "history.visibilityWatchEnabled"
+	// VisibilityWatchBufferSize is the number of recent visibility changes kept per shard for watchers to resume from
+	VisibilityWatchBufferSize = "history.visibilityWatchBufferSize"
 
 	// ArchivalTaskBatchSize is batch size for archivalQueueProcessor
 	ArchivalTaskBatchSize = "history.archivalTaskBatchSize"
@@ -911,6 +934,39 @@ const (
 	ExecutionScannerWorkerCount = "worker.executionScannerWorkerCount"
 	// ExecutionScannerHistoryEventIdValidator is the flag to enable history event id validator
 	ExecutionScannerHistoryEventIdValidator = "worker.executionEnableHistoryEventIdValidator"
+	// VisibilityScannerPerHostQPS is the maximum rate of calls per host from visibility.Scanner
+	VisibilityScannerPerHostQPS = "worker.visibilityScannerPerHostQPS"
+	// VisibilityScannerPerShardQPS is the maximum rate of calls per shard from visibility.Scanner
+	VisibilityScannerPerShardQPS = "worker.visibilityScannerPerShardQPS"
+	// VisibilityScannerWorkerCount is the visibility scavenger worker count
+	VisibilityScannerWorkerCount = "worker.visibilityScannerWorkerCount"
+	// VisibilityScannerSampleRate is the fraction of executions, in [0, 1], checked by the visibility scavenger.
+	// 1 means a full scan.
+	VisibilityScannerSampleRate = "worker.visibilityScannerSampleRate"
+	// VisibilityScannerMinAge is the minimum time since the last update of an execution before the visibility
+	// scavenger checks it. It gives visibility tasks time to be processed.
+	VisibilityScannerMinAge = "worker.visibilityScannerMinAge"
+	// VisibilityScannerRepairEnabled is the flag to regenerate visibility tasks of mismatched executions
+	VisibilityScannerRepairEnabled = "worker.visibilityScannerRepairEnabled"
+	// VisibilityRetentionScannerRPS is the rate limit for visibility calls from the visibility retention scanner
+	VisibilityRetentionScannerRPS = "worker.visibilityRetentionScannerRPS"
+	// StuckWorkflowScannerPerHostQPS is the maximum rate of calls per host from the stuck workflow scanner
+	StuckWorkflowScannerPerHostQPS = "worker.stuckWorkflowScannerPerHostQPS"
+	// StuckWorkflowScannerPerShardQPS is the maximum rate of calls per shard from the stuck workflow scanner
+	StuckWorkflowScannerPerShardQPS = "worker.stuckWorkflowScannerPerShardQPS"
+	// StuckWorkflowScannerWorkerCount is the stuck workflow scavenger worker count
+	StuckWorkflowScannerWorkerCount = "worker.stuckWorkflowScannerWorkerCount"
+	// StuckWorkflowScannerWorkflowTaskMaxAge is how long a workflow task may stay scheduled without being started
+	// before the stuck workflow scanner reports the workflow
+	StuckWorkflowScannerWorkflowTaskMaxAge = "worker.stuckWorkflowScannerWorkflowTaskMaxAge"
+	// StuckWorkflowScannerActivityTimeoutBuffer is how long an activity may be past one of its timeouts before the
+	// stuck workflow scanner reports the workflow. It gives activity timer tasks time to be processed.
+	StuckWorkflowScannerActivityTimeoutBuffer = "worker.stuckWorkflowScannerActivityTimeoutBuffer"
+	// StuckWorkflowScannerMaxBufferedEvents is the number of buffered events above which the stuck workflow scanner
+	// reports the workflow
+	StuckWorkflowScannerMaxBufferedEvents = "worker.stuckWorkflowScannerMaxBufferedEvents"
+	// StuckWorkflowScannerRemediationEnabled is the flag to refresh the tasks of stuck workflows
+	StuckWorkflowScannerRemediationEnabled = "worker.stuckWorkflowScannerRemediationEnabled"
 	// TaskQueueScannerEnabled indicates if task queue scanner should be started as part of worker.Scanner
 	TaskQueueScannerEnabled = "worker.taskQueueScannerEnabled"
 	// BuildIdScavengerEnabled indicates if the build id scavenger should be started as part of worker.Scanner
@@ -919,6 +975,14 @@ const (
 	HistoryScannerEnabled = "worker.historyScannerEnabled"
 	// ExecutionsScannerEnabled indicates if executions scanner should be started as part of worker.Scanner
 	ExecutionsScannerEnabled = "worker.executionsScannerEnabled"
+	// VisibilityScannerEnabled indicates if visibility scanner should be started as part of worker.Scanner
+	VisibilityScannerEnabled = "worker.visibilityScannerEnabled"
+	// StuckWorkflowScannerEnabled indicates if stuck workflow scanner should be started as part of worker.Scanner
+	StuckWorkflowScannerEnabled = "worker.stuckWorkflowScannerEnabled"
+	// VisibilityRetentionScannerEnabled indicates if the visibility retention scanner, which deletes visibility records
+	// of namespaces with a visibility retention, should be started as part of worker.Scanner. History only keeps
+	// visibility records past the history retention when it is enabled.
+	VisibilityRetentionScannerEnabled = "worker.visibilityRetentionScannerEnabled"
 	// HistoryScannerDataMinAge indicates the history scanner cleanup minimum age.
 	HistoryScannerDataMinAge = "worker.historyScannerDataMinAge"
 	// HistoryScannerVerifyRetention indicates the history scanner verify data retention.
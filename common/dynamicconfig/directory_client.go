@@ -0,0 +1,273 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+)
+
+var (
+	_ Client            = (*directoryClient)(nil)
+	_ ValueSourceClient = (*directoryClient)(nil)
+	_ ListableClient    = (*directoryClient)(nil)
+)
+
+const (
+	directoryFilePattern = "*.yaml"
+	// Editors and config management tools usually produce a burst of events for a single
+	// change, so reloading is delayed until the directory has been quiet for this long.
+	directoryReloadDelay = 500 * time.Millisecond
+)
+
+type (
+	// DirectoryClientConfig is the config for the directory based dynamic config client.
+	// Every *.yaml file in Directory is a fragment in the same format as the file used by the
+	// file based client. Fragments are merged, and the same key with the same constraints
+	// may only be set by a single fragment.
+	DirectoryClientConfig struct {
+		Directory string `yaml:"directory"`
+	}
+
+	directoryClient struct {
+		snapshot atomic.Pointer[directorySnapshot]
+		logger   log.Logger
+		config   *DirectoryClientConfig
+		doneCh   <-chan interface{}
+	}
+
+	directorySnapshot struct {
+		values configValueMap
+		// sources has the name of the file that set each value, in the same order as values
+		sources map[string][]string
+		// files has the values of each file, by file name
+		files map[string]configValueMap
+	}
+)
+
+// NewDirectoryClient creates a client that merges the dynamic config files in a directory and
+// reloads them whenever the directory changes. Watching the directory stops when doneCh is closed.
+func NewDirectoryClient(config *DirectoryClientConfig, logger log.Logger, doneCh <-chan interface{}) (*directoryClient, error) {
+	client := &directoryClient{
+		logger: logger,
+		config: config,
+		doneCh: doneCh,
+	}
+
+	if err := client.init(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (dc *directoryClient) GetValue(key Key) []ConstrainedValue {
+	return dc.snapshot.Load().values[strings.ToLower(key.String())]
+}
+
+func (dc *directoryClient) GetAllValues() map[Key][]ConstrainedValue {
+	values := dc.snapshot.Load().values
+	allValues := make(map[Key][]ConstrainedValue, len(values))
+	for key, cvs := range values {
+		allValues[Key(key)] = cvs
+	}
+	return allValues
+}
+
+// GetValueSource returns the name of the file that sets key for exactly the given constraints.
+func (dc *directoryClient) GetValueSource(key Key, constraints Constraints) (string, bool) {
+	snapshot := dc.snapshot.Load()
+	lowerKey := strings.ToLower(key.String())
+	for i, cv := range snapshot.values[lowerKey] {
+		if cv.Constraints == constraints {
+			return snapshot.sources[lowerKey][i], true
+		}
+	}
+	return "", false
+}
+
+func (dc *directoryClient) init() error {
+	if dc.config == nil {
+		return errors.New("configuration for dynamic config directory client is nil")
+	}
+	info, err := os.Stat(dc.config.Directory)
+	if err != nil {
+		return fmt.Errorf("dynamic config directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("dynamic config directory: %s is not a directory", dc.config.Directory)
+	}
+
+	if err := dc.update(); err != nil {
+		return fmt.Errorf("unable to read dynamic config: %w", err)
+	}
+
+	// The watcher is created after the initial load, so a change that happens in between is
+	// only picked up with the next event. This is the same window a poll interval would leave.
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch dynamic config directory: %w", err)
+	}
+	if err := watcher.Add(dc.config.Directory); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("unable to watch dynamic config directory: %w", err)
+	}
+
+	go dc.watch(watcher)
+
+	return nil
+}
+
+func (dc *directoryClient) watch(watcher *fsnotify.Watcher) {
+	defer func() { _ = watcher.Close() }()
+
+	var reloadCh <-chan time.Time
+	for {
+		select {
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			// restart the delay on every event so that a burst of events triggers a single reload
+			reloadCh = time.After(directoryReloadDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			dc.logger.Error("Error watching dynamic config directory.", tag.Error(err))
+		case <-reloadCh:
+			reloadCh = nil
+			if err := dc.update(); err != nil {
+				dc.logger.Error("Unable to update dynamic config.", tag.Error(err))
+			}
+		case <-dc.doneCh:
+			return
+		}
+	}
+}
+
+func (dc *directoryClient) update() error {
+	paths, err := filepath.Glob(filepath.Join(dc.config.Directory, directoryFilePattern))
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string]configValueMap, len(paths))
+	for _, path := range paths {
+		name := filepath.Base(path)
+		content, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// removed since listing the directory, the removal triggers another update
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("dynamic config file: %s: %w", name, err)
+		}
+		values, err := parseConfigValues(content)
+		if err != nil {
+			return fmt.Errorf("dynamic config file: %s: %w", name, err)
+		}
+		files[name] = values
+	}
+
+	newSnapshot, err := mergeConfigFiles(files)
+	if err != nil {
+		return err
+	}
+
+	oldSnapshot := dc.snapshot.Swap(newSnapshot)
+	var oldFiles map[string]configValueMap
+	if oldSnapshot != nil {
+		oldFiles = oldSnapshot.files
+	}
+	for _, name := range changedFiles(oldFiles, newSnapshot.files) {
+		fileLogger := log.With(dc.logger, tag.NewStringTag("dynamic-config-file", name))
+		logDiff(fileLogger, oldFiles[name], newSnapshot.files[name])
+		fileLogger.Info("Updated dynamic config")
+	}
+
+	return nil
+}
+
+// mergeConfigFiles merges the values of all files, failing if two files set the same key for
+// the same constraints.
+func mergeConfigFiles(files map[string]configValueMap) (*directorySnapshot, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	snapshot := &directorySnapshot{
+		values:  make(configValueMap),
+		sources: make(map[string][]string),
+		files:   files,
+	}
+	for _, name := range names {
+		for key, cvs := range files[name] {
+			for _, cv := range cvs {
+				for i, existing := range snapshot.values[key] {
+					if existing.Constraints == cv.Constraints {
+						return nil, fmt.Errorf(
+							"dynamic config key %s with constraints %+v is set in both %s and %s",
+							key, cv.Constraints, snapshot.sources[key][i], name,
+						)
+					}
+				}
+				snapshot.values[key] = append(snapshot.values[key], cv)
+				snapshot.sources[key] = append(snapshot.sources[key], name)
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+func changedFiles(old map[string]configValueMap, new map[string]configValueMap) []string {
+	var changed []string
+	for name, newValues := range new {
+		if oldValues, ok := old[name]; !ok || !reflect.DeepEqual(oldValues, newValues) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
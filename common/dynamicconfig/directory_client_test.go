@@ -0,0 +1,180 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"go.temporal.io/server/common/log"
+)
+
+type directoryClientSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	dir    string
+	doneCh chan interface{}
+}
+
+func TestDirectoryClientSuite(t *testing.T) {
+	s := new(directoryClientSuite)
+	suite.Run(t, s)
+}
+
+func (s *directoryClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.dir = s.T().TempDir()
+	s.doneCh = make(chan interface{})
+}
+
+func (s *directoryClientSuite) TearDownTest() {
+	close(s.doneCh)
+}
+
+func (s *directoryClientSuite) TestMerge() {
+	s.writeFile("team-a.yaml", `
+testGetBoolPropertyKey:
+  - value: true
+    constraints:
+      namespace: team-a
+`)
+	s.writeFile("team-b.yaml", `
+testGetBoolPropertyKey:
+  - value: false
+    constraints:
+      namespace: team-b
+testGetIntPropertyKey:
+  - value: 10
+`)
+	s.writeFile("ignored.yml", `
+testGetStringPropertyKey:
+  - value: ignored
+`)
+
+	client := s.newClient()
+	s.ElementsMatch([]ConstrainedValue{
+		{Constraints: Constraints{Namespace: "team-a"}, Value: true},
+		{Constraints: Constraints{Namespace: "team-b"}, Value: false},
+	}, client.GetValue(testGetBoolPropertyKey))
+	s.Equal([]ConstrainedValue{{Value: 10}}, client.GetValue(testGetIntPropertyKey))
+	s.Nil(client.GetValue(testGetStringPropertyKey))
+
+	source, ok := client.GetValueSource(testGetBoolPropertyKey, Constraints{Namespace: "team-a"})
+	s.True(ok)
+	s.Equal("team-a.yaml", source)
+	source, ok = client.GetValueSource(testGetIntPropertyKey, Constraints{})
+	s.True(ok)
+	s.Equal("team-b.yaml", source)
+	_, ok = client.GetValueSource(testGetIntPropertyKey, Constraints{Namespace: "team-a"})
+	s.False(ok)
+}
+
+func (s *directoryClientSuite) TestConflict() {
+	s.writeFile("team-a.yaml", `
+testGetIntPropertyKey:
+  - value: 10
+    constraints:
+      namespace: shared
+`)
+	s.writeFile("team-b.yaml", `
+testGetIntPropertyKey:
+  - value: 20
+    constraints:
+      namespace: shared
+`)
+
+	_, err := NewDirectoryClient(&DirectoryClientConfig{Directory: s.dir}, log.NewNoopLogger(), s.doneCh)
+	s.ErrorContains(err, "team-a.yaml")
+	s.ErrorContains(err, "team-b.yaml")
+}
+
+func (s *directoryClientSuite) TestConflictOnUpdateKeepsPreviousValues() {
+	s.writeFile("team-a.yaml", `
+testGetIntPropertyKey:
+  - value: 10
+`)
+	client := s.newClient()
+
+	s.writeFile("team-b.yaml", `
+testGetIntPropertyKey:
+  - value: 20
+`)
+	s.Error(client.update())
+	s.Equal([]ConstrainedValue{{Value: 10}}, client.GetValue(testGetIntPropertyKey))
+}
+
+func (s *directoryClientSuite) TestInvalidFile() {
+	s.writeFile("broken.yaml", "testGetIntPropertyKey: [")
+
+	_, err := NewDirectoryClient(&DirectoryClientConfig{Directory: s.dir}, log.NewNoopLogger(), s.doneCh)
+	s.ErrorContains(err, "broken.yaml")
+}
+
+func (s *directoryClientSuite) TestNotADirectory() {
+	path := filepath.Join(s.dir, "file.yaml")
+	s.writeFile("file.yaml", "")
+
+	_, err := NewDirectoryClient(&DirectoryClientConfig{Directory: path}, log.NewNoopLogger(), s.doneCh)
+	s.Error(err)
+}
+
+func (s *directoryClientSuite) TestReloadOnChange() {
+	s.writeFile("team-a.yaml", `
+testGetIntPropertyKey:
+  - value: 10
+`)
+	client := s.newClient()
+	collection := NewCollection(client, log.NewNoopLogger())
+	s.Equal(10, collection.GetIntProperty(testGetIntPropertyKey, 0)())
+
+	s.writeFile("team-a.yaml", `
+testGetIntPropertyKey:
+  - value: 20
+`)
+	s.Eventually(func() bool {
+		return collection.GetIntProperty(testGetIntPropertyKey, 0)() == 20
+	}, 5*time.Second, 50*time.Millisecond)
+
+	s.NoError(os.Remove(filepath.Join(s.dir, "team-a.yaml")))
+	s.Eventually(func() bool {
+		return collection.GetIntProperty(testGetIntPropertyKey, 0)() == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func (s *directoryClientSuite) newClient() *directoryClient {
+	client, err := NewDirectoryClient(&DirectoryClientConfig{Directory: s.dir}, log.NewNoopLogger(), s.doneCh)
+	s.NoError(err)
+	return client
+}
+
+func (s *directoryClientSuite) writeFile(name string, content string) {
+	s.NoError(os.WriteFile(filepath.Join(s.dir, name), []byte(content), fileMode))
+}
@@ -0,0 +1,812 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Code generated by cmd/tools/gendynamicconfigkeys. DO NOT EDIT.
+
+package dynamicconfig
+
+import "time"
+
+// allKeys contains all keys declared in constants.go.
+var allKeys = []Key{
+	AdminEnableListHistoryTasks,
+	AdminMatchingNamespaceToPartitionDispatchRate,
+	AdminMatchingNamespaceTaskqueueToPartitionDispatchRate,
+	VisibilityPersistenceMaxReadQPS,
+	VisibilityPersistenceMaxWriteQPS,
+	EnableReadFromSecondaryVisibility,
+	SecondaryVisibilityWritingMode,
+	VisibilityDisableOrderByClause,
+	VisibilityEnableManualPagination,
+	VisibilityAllowList,
+	HistoryArchivalState,
+	EnableReadFromHistoryArchival,
+	VisibilityArchivalState,
+	EnableReadFromVisibilityArchival,
+	EnableNamespaceNotActiveAutoForwarding,
+	TransactionSizeLimit,
+	DisallowQuery,
+	EnableAuthorization,
+	EnableCrossNamespaceCommands,
+	ClusterMetadataRefreshInterval,
+	ForceSearchAttributesCacheRefreshOnRead,
+	EnableRingpopTLS,
+	RingpopApproximateMaxPropagationTime,
+	EnableParentClosePolicyWorker,
+	EnableStickyQuery,
+	EnableActivityEagerExecution,
+	EnableEagerWorkflowStart,
+	NamespaceCacheRefreshInterval,
+	PersistenceHealthSignalMetricsEnabled,
+	PersistenceHealthSignalAggregationEnabled,
+	PersistenceHealthSignalWindowSize,
+	PersistenceHealthSignalBufferSize,
+	ShardRPSWarnLimit,
+	ShardPerNsRPSWarnPercent,
+	OperatorRPSRatio,
+	DeadlockDumpGoroutines,
+	DeadlockFailHealthCheck,
+	DeadlockAbortProcess,
+	DeadlockInterval,
+	DeadlockMaxWorkersPerRoot,
+	BlobSizeLimitError,
+	BlobSizeLimitWarn,
+	MemoSizeLimitError,
+	MemoSizeLimitWarn,
+	NumPendingChildExecutionsLimitError,
+	NumPendingActivitiesLimitError,
+	NumPendingSignalsLimitError,
+	NumPendingCancelRequestsLimitError,
+	HistorySizeLimitError,
+	HistorySizeLimitWarn,
+	HistorySizeSuggestContinueAsNew,
+	HistoryCountLimitError,
+	HistoryCountLimitWarn,
+	MutableStateActivityFailureSizeLimitError,
+	MutableStateActivityFailureSizeLimitWarn,
+	MutableStateSizeLimitError,
+	MutableStateSizeLimitWarn,
+	HistoryCountSuggestContinueAsNew,
+	HistoryMaxPageSize,
+	MaxIDLengthLimit,
+	WorkerBuildIdSizeLimit,
+	VersionCompatibleSetLimitPerQueue,
+	VersionBuildIdLimitPerQueue,
+	ReachabilityTaskQueueScanLimit,
+	ReachabilityQueryBuildIdLimit,
+	ReachabilityQuerySetDurationSinceDefault,
+	TaskQueuesPerBuildIdLimit,
+	RemovableBuildIdDurationSinceDefault,
+	BuildIdScavenengerVisibilityRPS,
+	NexusIncomingServiceNameMaxLength,
+	NexusIncomingServiceMaxSize,
+	NexusIncomingServiceListDefaultPageSize,
+	NexusIncomingServiceListMaxPageSize,
+	FrontendPersistenceMaxQPS,
+	FrontendPersistenceGlobalMaxQPS,
+	FrontendPersistenceNamespaceMaxQPS,
+	FrontendPersistenceGlobalNamespaceMaxQPS,
+	FrontendEnablePersistencePriorityRateLimiting,
+	FrontendPersistenceDynamicRateLimitingParams,
+	FrontendVisibilityMaxPageSize,
+	FrontendHistoryMaxPageSize,
+	FrontendRPS,
+	FrontendGlobalRPS,
+	FrontendNamespaceReplicationInducingAPIsRPS,
+	FrontendMaxNamespaceRPSPerInstance,
+	FrontendMaxNamespaceBurstPerInstance,
+	FrontendMaxConcurrentLongRunningRequestsPerInstance,
+	FrontendGlobalMaxConcurrentLongRunningRequests,
+	FrontendMaxNamespaceVisibilityRPSPerInstance,
+	FrontendMaxNamespaceNamespaceReplicationInducingAPIsRPSPerInstance,
+	FrontendMaxNamespaceVisibilityBurstPerInstance,
+	FrontendMaxNamespaceNamespaceReplicationInducingAPIsBurstPerInstance,
+	FrontendGlobalNamespaceRPS,
+	InternalFrontendGlobalNamespaceRPS,
+	FrontendGlobalNamespaceVisibilityRPS,
+	FrontendGlobalNamespaceNamespaceReplicationInducingAPIsRPS,
+	InternalFrontendGlobalNamespaceVisibilityRPS,
+	FrontendThrottledLogRPS,
+	FrontendShutdownDrainDuration,
+	FrontendShutdownFailHealthCheckDuration,
+	FrontendMaxConcurrentVisibilityWatches,
+	FrontendMaxBadBinaries,
+	SendRawWorkflowHistory,
+	SearchAttributesNumberOfKeysLimit,
+	SearchAttributesSizeOfValueLimit,
+	SearchAttributesTotalSizeLimit,
+	VisibilityArchivalQueryMaxPageSize,
+	EnableServerVersionCheck,
+	EnableTokenNamespaceEnforcement,
+	DisableListVisibilityByFilter,
+	KeepAliveMinTime,
+	KeepAlivePermitWithoutStream,
+	KeepAliveMaxConnectionIdle,
+	KeepAliveMaxConnectionAge,
+	KeepAliveMaxConnectionAgeGrace,
+	KeepAliveTime,
+	KeepAliveTimeout,
+	FrontendEnableSchedules,
+	FrontendMaxConcurrentBatchOperationPerNamespace,
+	FrontendMaxExecutionCountBatchOperationPerNamespace,
+	FrontendEnableBatcher,
+	FrontendAccessHistoryFraction,
+	FrontendAdminDeleteAccessHistoryFraction,
+	FrontendAuthorizationAuditSamplingRate,
+	FrontendAuthorizationAuditRedactSubject,
+	FrontendAuthorizationAuditRedactWorkflowID,
+	FrontendEnableUpdateWorkflowExecution,
+	FrontendEnableUpdateWorkflowExecutionAsyncAccepted,
+	FrontendEnableWorkerVersioningDataAPIs,
+	FrontendEnableWorkerVersioningWorkflowAPIs,
+	DeleteNamespaceDeleteActivityRPS,
+	DeleteNamespacePageSize,
+	DeleteNamespacePagesPerExecution,
+	DeleteNamespaceConcurrentDeleteExecutionsActivities,
+	DeleteNamespaceNamespaceDeleteDelay,
+	MatchingRPS,
+	MatchingPersistenceMaxQPS,
+	MatchingPersistenceGlobalMaxQPS,
+	MatchingPersistenceNamespaceMaxQPS,
+	MatchingPersistenceGlobalNamespaceMaxQPS,
+	MatchingEnablePersistencePriorityRateLimiting,
+	MatchingPersistenceDynamicRateLimitingParams,
+	MatchingMinTaskThrottlingBurstSize,
+	MatchingGetTasksBatchSize,
+	MatchingLongPollExpirationInterval,
+	MatchingSyncMatchWaitDuration,
+	MatchingHistoryMaxPageSize,
+	MatchingLoadUserData,
+	MatchingUpdateAckInterval,
+	MatchingMaxTaskQueueIdleTime,
+	MatchingOutstandingTaskAppendsThreshold,
+	MatchingMaxTaskBatchSize,
+	MatchingMaxTaskDeleteBatchSize,
+	MatchingThrottledLogRPS,
+	MatchingNumTaskqueueWritePartitions,
+	MatchingNumTaskqueueReadPartitions,
+	MatchingForwarderMaxOutstandingPolls,
+	MatchingForwarderMaxOutstandingTasks,
+	MatchingForwarderMaxRatePerSecond,
+	MatchingForwarderMaxChildrenPerNode,
+	MatchingShutdownDrainDuration,
+	MatchingGetUserDataLongPollTimeout,
+	MatchingBacklogNegligibleAge,
+	MatchingMaxWaitForPollerBeforeFwd,
+	QueryPollerUnavailableWindow,
+	MatchingMembershipUnloadDelay,
+	TestMatchingDisableSyncMatch,
+	TestMatchingLBForceReadPartition,
+	TestMatchingLBForceWritePartition,
+	EnableReplicationStream,
+	EnableHistoryReplicationDLQV2,
+	HistoryRPS,
+	HistoryPersistenceMaxQPS,
+	HistoryPersistenceGlobalMaxQPS,
+	HistoryPersistenceNamespaceMaxQPS,
+	HistoryPersistenceGlobalNamespaceMaxQPS,
+	HistoryPersistencePerShardNamespaceMaxQPS,
+	HistoryEnablePersistencePriorityRateLimiting,
+	HistoryPersistenceDynamicRateLimitingParams,
+	HistoryLongPollExpirationInterval,
+	HistoryCacheInitialSize,
+	HistoryCacheMaxSize,
+	HistoryCacheTTL,
+	HistoryCacheNonUserContextLockTimeout,
+	EnableHostHistoryCache,
+	HistoryCacheHostLevelMaxSize,
+	EnableAPIGetCurrentRunIDLock,
+	HistoryStartupMembershipJoinDelay,
+	HistoryShutdownDrainDuration,
+	XDCCacheMaxSizeBytes,
+	EventsCacheMaxSizeBytes,
+	EventsHostLevelCacheMaxSizeBytes,
+	EventsCacheTTL,
+	EnableHostLevelEventsCache,
+	AcquireShardInterval,
+	AcquireShardConcurrency,
+	ShardLingerOwnershipCheckQPS,
+	ShardLingerTimeLimit,
+	ShardOwnershipAssertionEnabled,
+	HistoryClientOwnershipCachingEnabled,
+	ShardIOConcurrency,
+	StandbyClusterDelay,
+	StandbyTaskMissingEventsResendDelay,
+	StandbyTaskMissingEventsDiscardDelay,
+	QueuePendingTaskCriticalCount,
+	QueueReaderStuckCriticalAttempts,
+	QueueCriticalSlicesCount,
+	QueuePendingTaskMaxCount,
+	ContinueAsNewMinInterval,
+	TaskSchedulerEnableRateLimiter,
+	TaskSchedulerEnableRateLimiterShadowMode,
+	TaskSchedulerRateLimiterStartupDelay,
+	TaskSchedulerGlobalMaxQPS,
+	TaskSchedulerMaxQPS,
+	TaskSchedulerGlobalNamespaceMaxQPS,
+	TaskSchedulerNamespaceMaxQPS,
+	TimerTaskBatchSize,
+	TimerProcessorSchedulerWorkerCount,
+	TimerProcessorSchedulerActiveRoundRobinWeights,
+	TimerProcessorSchedulerStandbyRoundRobinWeights,
+	TimerProcessorUpdateAckInterval,
+	TimerProcessorUpdateAckIntervalJitterCoefficient,
+	TimerProcessorMaxPollRPS,
+	TimerProcessorMaxPollHostRPS,
+	TimerProcessorMaxPollInterval,
+	TimerProcessorMaxPollIntervalJitterCoefficient,
+	TimerProcessorPollBackoffInterval,
+	TimerProcessorMaxTimeShift,
+	TimerQueueMaxReaderCount,
+	RetentionTimerJitterDuration,
+	MemoryTimerProcessorSchedulerWorkerCount,
+	TransferTaskBatchSize,
+	TransferProcessorMaxPollRPS,
+	TransferProcessorMaxPollHostRPS,
+	TransferProcessorSchedulerWorkerCount,
+	TransferProcessorSchedulerActiveRoundRobinWeights,
+	TransferProcessorSchedulerStandbyRoundRobinWeights,
+	TransferProcessorMaxPollInterval,
+	TransferProcessorMaxPollIntervalJitterCoefficient,
+	TransferProcessorUpdateAckInterval,
+	TransferProcessorUpdateAckIntervalJitterCoefficient,
+	TransferProcessorPollBackoffInterval,
+	TransferProcessorEnsureCloseBeforeDelete,
+	TransferQueueMaxReaderCount,
+	VisibilityTaskBatchSize,
+	VisibilityProcessorMaxPollRPS,
+	VisibilityProcessorMaxPollHostRPS,
+	VisibilityProcessorSchedulerWorkerCount,
+	VisibilityProcessorSchedulerActiveRoundRobinWeights,
+	VisibilityProcessorSchedulerStandbyRoundRobinWeights,
+	VisibilityProcessorMaxPollInterval,
+	VisibilityProcessorMaxPollIntervalJitterCoefficient,
+	VisibilityProcessorUpdateAckInterval,
+	VisibilityProcessorUpdateAckIntervalJitterCoefficient,
+	VisibilityProcessorPollBackoffInterval,
+	VisibilityProcessorEnsureCloseBeforeDelete,
+	VisibilityProcessorEnableCloseWorkflowCleanup,
+	VisibilityQueueMaxReaderCount,
+	VisibilityWatchEnabled,
+	VisibilityWatchBufferSize,
+	ArchivalTaskBatchSize,
+	ArchivalProcessorMaxPollRPS,
+	ArchivalProcessorMaxPollHostRPS,
+	ArchivalProcessorSchedulerWorkerCount,
+	ArchivalProcessorMaxPollInterval,
+	ArchivalProcessorMaxPollIntervalJitterCoefficient,
+	ArchivalProcessorUpdateAckInterval,
+	ArchivalProcessorUpdateAckIntervalJitterCoefficient,
+	ArchivalProcessorPollBackoffInterval,
+	ArchivalProcessorArchiveDelay,
+	ArchivalBackendMaxRPS,
+	ArchivalQueueMaxReaderCount,
+	WorkflowExecutionMaxInFlightUpdates,
+	WorkflowExecutionMaxTotalUpdates,
+	ReplicatorTaskBatchSize,
+	ReplicatorMaxSkipTaskCount,
+	ReplicatorProcessorMaxPollInterval,
+	ReplicatorProcessorMaxPollIntervalJitterCoefficient,
+	MaximumBufferedEventsBatch,
+	MaximumBufferedEventsSizeInBytes,
+	MaximumSignalsPerExecution,
+	ShardUpdateMinInterval,
+	ShardUpdateMinTasksCompleted,
+	ShardSyncMinInterval,
+	EmitShardLagLog,
+	DefaultEventEncoding,
+	DefaultActivityRetryPolicy,
+	DefaultWorkflowRetryPolicy,
+	HistoryMaxAutoResetPoints,
+	EnableParentClosePolicy,
+	ParentClosePolicyThreshold,
+	NumParentClosePolicySystemWorkflows,
+	HistoryThrottledLogRPS,
+	StickyTTL,
+	WorkflowTaskHeartbeatTimeout,
+	WorkflowTaskCriticalAttempts,
+	WorkflowTaskRetryMaxInterval,
+	DefaultWorkflowTaskTimeout,
+	SkipReapplicationByNamespaceID,
+	StandbyTaskReReplicationContextTimeout,
+	MaxBufferedQueryCount,
+	MutableStateChecksumGenProbability,
+	MutableStateChecksumVerifyProbability,
+	MutableStateChecksumInvalidateBefore,
+	ReplicationTaskFetcherParallelism,
+	ReplicationTaskFetcherAggregationInterval,
+	ReplicationTaskFetcherTimerJitterCoefficient,
+	ReplicationTaskFetcherErrorRetryWait,
+	ReplicationTaskProcessorErrorRetryWait,
+	ReplicationTaskProcessorErrorRetryBackoffCoefficient,
+	ReplicationTaskProcessorErrorRetryMaxInterval,
+	ReplicationTaskProcessorErrorRetryMaxAttempts,
+	ReplicationTaskProcessorErrorRetryExpiration,
+	ReplicationTaskProcessorNoTaskInitialWait,
+	ReplicationTaskProcessorCleanupInterval,
+	ReplicationTaskProcessorCleanupJitterCoefficient,
+	ReplicationTaskProcessorStartWait,
+	ReplicationTaskProcessorHostQPS,
+	ReplicationTaskProcessorShardQPS,
+	ReplicationEnableDLQMetrics,
+	HistoryTaskDLQEnabled,
+	HistoryTaskDLQUnexpectedErrorAttempts,
+	HistoryTaskDLQInternalErrors,
+	ReplicationStreamSyncStatusDuration,
+	ReplicationStreamMinReconnectDuration,
+	ReplicationProcessorSchedulerQueueSize,
+	ReplicationProcessorSchedulerWorkerCount,
+	EnableEagerNamespaceRefresher,
+	EnableReplicationTaskBatching,
+	EnableReplicateLocalGeneratedEvents,
+	WorkerPersistenceMaxQPS,
+	WorkerPersistenceGlobalMaxQPS,
+	WorkerPersistenceNamespaceMaxQPS,
+	WorkerPersistenceGlobalNamespaceMaxQPS,
+	WorkerEnablePersistencePriorityRateLimiting,
+	WorkerPersistenceDynamicRateLimitingParams,
+	WorkerIndexerConcurrency,
+	WorkerESProcessorNumOfWorkers,
+	WorkerESProcessorBulkActions,
+	WorkerESProcessorBulkSize,
+	WorkerESProcessorFlushInterval,
+	WorkerESProcessorAckTimeout,
+	WorkerThrottledLogRPS,
+	WorkerScannerMaxConcurrentActivityExecutionSize,
+	WorkerScannerMaxConcurrentWorkflowTaskExecutionSize,
+	WorkerScannerMaxConcurrentActivityTaskPollers,
+	WorkerScannerMaxConcurrentWorkflowTaskPollers,
+	ScannerPersistenceMaxQPS,
+	ExecutionScannerPerHostQPS,
+	ExecutionScannerPerShardQPS,
+	ExecutionDataDurationBuffer,
+	ExecutionScannerWorkerCount,
+	ExecutionScannerHistoryEventIdValidator,
+	VisibilityScannerPerHostQPS,
+	VisibilityScannerPerShardQPS,
+	VisibilityScannerWorkerCount,
+	VisibilityScannerSampleRate,
+	VisibilityScannerMinAge,
+	VisibilityScannerRepairEnabled,
+	VisibilityRetentionScannerRPS,
+	StuckWorkflowScannerPerHostQPS,
+	StuckWorkflowScannerPerShardQPS,
+	StuckWorkflowScannerWorkerCount,
+	StuckWorkflowScannerWorkflowTaskMaxAge,
+	StuckWorkflowScannerActivityTimeoutBuffer,
+	StuckWorkflowScannerMaxBufferedEvents,
+	StuckWorkflowScannerRemediationEnabled,
+	TaskQueueScannerEnabled,
+	BuildIdScavengerEnabled,
+	HistoryScannerEnabled,
+	ExecutionsScannerEnabled,
+	VisibilityScannerEnabled,
+	StuckWorkflowScannerEnabled,
+	VisibilityRetentionScannerEnabled,
+	HistoryScannerDataMinAge,
+	HistoryScannerVerifyRetention,
+	EnableBatcher,
+	BatcherRPS,
+	BatcherConcurrency,
+	WorkerParentCloseMaxConcurrentActivityExecutionSize,
+	WorkerParentCloseMaxConcurrentWorkflowTaskExecutionSize,
+	WorkerParentCloseMaxConcurrentActivityTaskPollers,
+	WorkerParentCloseMaxConcurrentWorkflowTaskPollers,
+	WorkerPerNamespaceWorkerCount,
+	WorkerPerNamespaceWorkerOptions,
+	WorkerPerNamespaceWorkerStartRate,
+	WorkerEnableScheduler,
+	WorkerStickyCacheSize,
+	SchedulerNamespaceStartWorkflowRPS,
+	WorkerDeleteNamespaceActivityLimitsConfig,
+}
+
+// staticKeyInfos contains the registrations of the keys used with a Collection getter. Default
+// is nil for defaults that are computed where the key is used.
+var staticKeyInfos = []KeyInfo{
+	{Key: AdminEnableListHistoryTasks, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: AdminMatchingNamespaceToPartitionDispatchRate, Type: ValueTypeFloat, Precedence: PrecedenceNamespace, Default: 10000},
+	{Key: AdminMatchingNamespaceTaskqueueToPartitionDispatchRate, Type: ValueTypeFloat, Precedence: PrecedenceTaskQueue, Default: 1000},
+	{Key: VisibilityPersistenceMaxReadQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 9000},
+	{Key: VisibilityPersistenceMaxWriteQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 9000},
+	{Key: EnableReadFromSecondaryVisibility, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: SecondaryVisibilityWritingMode, Type: ValueTypeString, Precedence: PrecedenceGlobal},
+	{Key: VisibilityDisableOrderByClause, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: VisibilityEnableManualPagination, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: VisibilityAllowList, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: HistoryArchivalState, Type: ValueTypeString, Precedence: PrecedenceGlobal},
+	{Key: EnableReadFromHistoryArchival, Type: ValueTypeBool, Precedence: PrecedenceGlobal},
+	{Key: VisibilityArchivalState, Type: ValueTypeString, Precedence: PrecedenceGlobal},
+	{Key: EnableReadFromVisibilityArchival, Type: ValueTypeBool, Precedence: PrecedenceGlobal},
+	{Key: EnableNamespaceNotActiveAutoForwarding, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: TransactionSizeLimit, Type: ValueTypeInt, Precedence: PrecedenceGlobal},
+	{Key: DisallowQuery, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: EnableCrossNamespaceCommands, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: ClusterMetadataRefreshInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal},
+	{Key: ForceSearchAttributesCacheRefreshOnRead, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: EnableRingpopTLS, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: RingpopApproximateMaxPropagationTime, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 3 * time.Second},
+	{Key: EnableParentClosePolicyWorker, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: EnableStickyQuery, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: EnableActivityEagerExecution, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: EnableEagerWorkflowStart, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: NamespaceCacheRefreshInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 10 * time.Second},
+	{Key: PersistenceHealthSignalMetricsEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: PersistenceHealthSignalAggregationEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: PersistenceHealthSignalWindowSize, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 10 * time.Second},
+	{Key: PersistenceHealthSignalBufferSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 5000},
+	{Key: ShardRPSWarnLimit, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 50},
+	{Key: ShardPerNsRPSWarnPercent, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.8},
+	{Key: OperatorRPSRatio, Type: ValueTypeFloat, Precedence: PrecedenceGlobal},
+	{Key: DeadlockDumpGoroutines, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: DeadlockFailHealthCheck, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: DeadlockAbortProcess, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: DeadlockInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 30 * time.Second},
+	{Key: DeadlockMaxWorkersPerRoot, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10},
+	{Key: BlobSizeLimitError, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2 * 1024 * 1024},
+	{Key: BlobSizeLimitWarn, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 256 * 1024},
+	{Key: MemoSizeLimitError, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2 * 1024 * 1024},
+	{Key: MemoSizeLimitWarn, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2 * 1024},
+	{Key: NumPendingChildExecutionsLimitError, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2000},
+	{Key: NumPendingActivitiesLimitError, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2000},
+	{Key: NumPendingSignalsLimitError, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2000},
+	{Key: NumPendingCancelRequestsLimitError, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2000},
+	{Key: HistorySizeLimitError, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 50 * 1024 * 1024},
+	{Key: HistorySizeLimitWarn, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10 * 1024 * 1024},
+	{Key: HistorySizeSuggestContinueAsNew, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 4 * 1024 * 1024},
+	{Key: HistoryCountLimitError, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 50 * 1024},
+	{Key: HistoryCountLimitWarn, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10 * 1024},
+	{Key: MutableStateActivityFailureSizeLimitError, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 4 * 1024},
+	{Key: MutableStateActivityFailureSizeLimitWarn, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2 * 1024},
+	{Key: MutableStateSizeLimitError, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 8 * 1024 * 1024},
+	{Key: MutableStateSizeLimitWarn, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1 * 1024 * 1024},
+	{Key: HistoryCountSuggestContinueAsNew, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 4 * 1024},
+	{Key: HistoryMaxPageSize, Type: ValueTypeInt, Precedence: PrecedenceNamespace},
+	{Key: MaxIDLengthLimit, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1000},
+	{Key: WorkerBuildIdSizeLimit, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 255},
+	{Key: VersionCompatibleSetLimitPerQueue, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10},
+	{Key: VersionBuildIdLimitPerQueue, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 100},
+	{Key: ReachabilityTaskQueueScanLimit, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 20},
+	{Key: ReachabilityQueryBuildIdLimit, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 5},
+	{Key: ReachabilityQuerySetDurationSinceDefault, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Minute},
+	{Key: TaskQueuesPerBuildIdLimit, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 20},
+	{Key: RemovableBuildIdDurationSinceDefault, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: time.Hour},
+	{Key: BuildIdScavenengerVisibilityRPS, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 1.0},
+	{Key: NexusIncomingServiceNameMaxLength, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 200},
+	{Key: NexusIncomingServiceMaxSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 4 * 1024},
+	{Key: NexusIncomingServiceListDefaultPageSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: NexusIncomingServiceListMaxPageSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1000},
+	{Key: FrontendPersistenceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 2000},
+	{Key: FrontendPersistenceGlobalMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: FrontendPersistenceNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: FrontendPersistenceGlobalNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: FrontendEnablePersistencePriorityRateLimiting, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: FrontendPersistenceDynamicRateLimitingParams, Type: ValueTypeMap, Precedence: PrecedenceGlobal},
+	{Key: FrontendVisibilityMaxPageSize, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 1000},
+	{Key: FrontendHistoryMaxPageSize, Type: ValueTypeInt, Precedence: PrecedenceNamespace},
+	{Key: FrontendRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 2400},
+	{Key: FrontendGlobalRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: FrontendNamespaceReplicationInducingAPIsRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 20},
+	{Key: FrontendMaxNamespaceRPSPerInstance, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2400},
+	{Key: FrontendMaxNamespaceBurstPerInstance, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 4800},
+	{Key: FrontendMaxConcurrentLongRunningRequestsPerInstance, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 1200},
+	{Key: FrontendGlobalMaxConcurrentLongRunningRequests, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: FrontendMaxNamespaceVisibilityRPSPerInstance, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10},
+	{Key: FrontendMaxNamespaceNamespaceReplicationInducingAPIsRPSPerInstance, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 1},
+	{Key: FrontendMaxNamespaceVisibilityBurstPerInstance, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10},
+	{Key: FrontendMaxNamespaceNamespaceReplicationInducingAPIsBurstPerInstance, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10},
+	{Key: FrontendGlobalNamespaceRPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: InternalFrontendGlobalNamespaceRPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: FrontendGlobalNamespaceVisibilityRPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: FrontendGlobalNamespaceNamespaceReplicationInducingAPIsRPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10},
+	{Key: InternalFrontendGlobalNamespaceVisibilityRPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: FrontendThrottledLogRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 20},
+	{Key: FrontendShutdownDrainDuration, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 0 * time.Second},
+	{Key: FrontendShutdownFailHealthCheckDuration, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 0 * time.Second},
+	{Key: FrontendMaxConcurrentVisibilityWatches, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: FrontendMaxBadBinaries, Type: ValueTypeInt, Precedence: PrecedenceNamespace},
+	{Key: SendRawWorkflowHistory, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: SearchAttributesNumberOfKeysLimit, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 100},
+	{Key: SearchAttributesSizeOfValueLimit, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2 * 1024},
+	{Key: SearchAttributesTotalSizeLimit, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 40 * 1024},
+	{Key: VisibilityArchivalQueryMaxPageSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10000},
+	{Key: EnableServerVersionCheck, Type: ValueTypeBool, Precedence: PrecedenceGlobal},
+	{Key: EnableTokenNamespaceEnforcement, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: DisableListVisibilityByFilter, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: KeepAliveMinTime, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 10 * time.Second},
+	{Key: KeepAlivePermitWithoutStream, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: KeepAliveMaxConnectionIdle, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 2 * time.Minute},
+	{Key: KeepAliveMaxConnectionAge, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Minute},
+	{Key: KeepAliveMaxConnectionAgeGrace, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 70 * time.Second},
+	{Key: KeepAliveTime, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 1 * time.Minute},
+	{Key: KeepAliveTimeout, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 10 * time.Second},
+	{Key: FrontendEnableSchedules, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: FrontendMaxConcurrentBatchOperationPerNamespace, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 1},
+	{Key: FrontendMaxExecutionCountBatchOperationPerNamespace, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 1000},
+	{Key: FrontendEnableBatcher, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: FrontendAccessHistoryFraction, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.0},
+	{Key: FrontendAdminDeleteAccessHistoryFraction, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.0},
+	{Key: FrontendAuthorizationAuditSamplingRate, Type: ValueTypeFloat, Precedence: PrecedenceNamespace, Default: 1.0},
+	{Key: FrontendAuthorizationAuditRedactSubject, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: FrontendAuthorizationAuditRedactWorkflowID, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: FrontendEnableUpdateWorkflowExecution, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: FrontendEnableUpdateWorkflowExecutionAsyncAccepted, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: FrontendEnableWorkerVersioningDataAPIs, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: FrontendEnableWorkerVersioningWorkflowAPIs, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: DeleteNamespaceDeleteActivityRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: DeleteNamespacePageSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1000},
+	{Key: DeleteNamespacePagesPerExecution, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 256},
+	{Key: DeleteNamespaceConcurrentDeleteExecutionsActivities, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 4},
+	{Key: DeleteNamespaceNamespaceDeleteDelay, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 0 * time.Hour},
+	{Key: MatchingRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1200},
+	{Key: MatchingPersistenceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 3000},
+	{Key: MatchingPersistenceGlobalMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: MatchingPersistenceNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: MatchingPersistenceGlobalNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: MatchingEnablePersistencePriorityRateLimiting, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: MatchingPersistenceDynamicRateLimitingParams, Type: ValueTypeMap, Precedence: PrecedenceGlobal},
+	{Key: MatchingMinTaskThrottlingBurstSize, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: 1},
+	{Key: MatchingGetTasksBatchSize, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: 1000},
+	{Key: MatchingLongPollExpirationInterval, Type: ValueTypeDuration, Precedence: PrecedenceTaskQueue, Default: time.Minute},
+	{Key: MatchingSyncMatchWaitDuration, Type: ValueTypeDuration, Precedence: PrecedenceTaskQueue, Default: 200 * time.Millisecond},
+	{Key: MatchingHistoryMaxPageSize, Type: ValueTypeInt, Precedence: PrecedenceNamespace},
+	{Key: MatchingLoadUserData, Type: ValueTypeBool, Precedence: PrecedenceTaskQueue, Default: true},
+	{Key: MatchingUpdateAckInterval, Type: ValueTypeDuration, Precedence: PrecedenceTaskQueue},
+	{Key: MatchingMaxTaskQueueIdleTime, Type: ValueTypeDuration, Precedence: PrecedenceTaskQueue, Default: 5 * time.Minute},
+	{Key: MatchingOutstandingTaskAppendsThreshold, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: 250},
+	{Key: MatchingMaxTaskBatchSize, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: 100},
+	{Key: MatchingMaxTaskDeleteBatchSize, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: 100},
+	{Key: MatchingThrottledLogRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 20},
+	{Key: MatchingNumTaskqueueWritePartitions, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: defaultNumTaskQueuePartitions},
+	{Key: MatchingNumTaskqueueReadPartitions, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: defaultNumTaskQueuePartitions},
+	{Key: MatchingForwarderMaxOutstandingPolls, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: 1},
+	{Key: MatchingForwarderMaxOutstandingTasks, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: 1},
+	{Key: MatchingForwarderMaxRatePerSecond, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: 10},
+	{Key: MatchingForwarderMaxChildrenPerNode, Type: ValueTypeInt, Precedence: PrecedenceTaskQueue, Default: 20},
+	{Key: MatchingShutdownDrainDuration, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 0 * time.Second},
+	{Key: MatchingGetUserDataLongPollTimeout, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5*time.Minute - 10*time.Second},
+	{Key: MatchingBacklogNegligibleAge, Type: ValueTypeDuration, Precedence: PrecedenceTaskQueue, Default: 24 * 365 * 10 * time.Hour},
+	{Key: MatchingMaxWaitForPollerBeforeFwd, Type: ValueTypeDuration, Precedence: PrecedenceTaskQueue, Default: 200 * time.Millisecond},
+	{Key: QueryPollerUnavailableWindow, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 20 * time.Second},
+	{Key: MatchingMembershipUnloadDelay, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 3 * time.Second},
+	{Key: TestMatchingDisableSyncMatch, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: TestMatchingLBForceReadPartition, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: -1},
+	{Key: TestMatchingLBForceWritePartition, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: -1},
+	{Key: EnableReplicationStream, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: EnableHistoryReplicationDLQV2, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: HistoryRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 3000},
+	{Key: HistoryPersistenceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 9000},
+	{Key: HistoryPersistenceGlobalMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: HistoryPersistenceNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: HistoryPersistenceGlobalNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: HistoryPersistencePerShardNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: HistoryEnablePersistencePriorityRateLimiting, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: HistoryPersistenceDynamicRateLimitingParams, Type: ValueTypeMap, Precedence: PrecedenceGlobal},
+	{Key: HistoryLongPollExpirationInterval, Type: ValueTypeDuration, Precedence: PrecedenceNamespace, Default: time.Second * 20},
+	{Key: HistoryCacheInitialSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 128},
+	{Key: HistoryCacheMaxSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 512},
+	{Key: HistoryCacheTTL, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: time.Hour},
+	{Key: HistoryCacheNonUserContextLockTimeout, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 500 * time.Millisecond},
+	{Key: EnableHostHistoryCache, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: HistoryCacheHostLevelMaxSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 256000},
+	{Key: EnableAPIGetCurrentRunIDLock, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: HistoryStartupMembershipJoinDelay, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 0 * time.Second},
+	{Key: HistoryShutdownDrainDuration, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 0 * time.Second},
+	{Key: XDCCacheMaxSizeBytes, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 8 * 1024 * 1024},
+	{Key: EventsCacheMaxSizeBytes, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 512 * 1024},
+	{Key: EventsHostLevelCacheMaxSizeBytes, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 512 * 512 * 1024},
+	{Key: EventsCacheTTL, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: time.Hour},
+	{Key: EnableHostLevelEventsCache, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: AcquireShardInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: time.Minute},
+	{Key: AcquireShardConcurrency, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10},
+	{Key: ShardLingerOwnershipCheckQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 4},
+	{Key: ShardLingerTimeLimit, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: ShardOwnershipAssertionEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: HistoryClientOwnershipCachingEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: ShardIOConcurrency, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1},
+	{Key: StandbyClusterDelay, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Minute},
+	{Key: StandbyTaskMissingEventsResendDelay, Type: ValueTypeDuration, Precedence: PrecedenceTaskType, Default: 10 * time.Minute},
+	{Key: StandbyTaskMissingEventsDiscardDelay, Type: ValueTypeDuration, Precedence: PrecedenceTaskType, Default: 15 * time.Minute},
+	{Key: QueuePendingTaskCriticalCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 9000},
+	{Key: QueueReaderStuckCriticalAttempts, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 3},
+	{Key: QueueCriticalSlicesCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 50},
+	{Key: QueuePendingTaskMaxCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10000},
+	{Key: ContinueAsNewMinInterval, Type: ValueTypeDuration, Precedence: PrecedenceNamespace, Default: time.Second},
+	{Key: TaskSchedulerEnableRateLimiter, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: TaskSchedulerEnableRateLimiterShadowMode, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: TaskSchedulerRateLimiterStartupDelay, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Second},
+	{Key: TaskSchedulerGlobalMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: TaskSchedulerMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: TaskSchedulerGlobalNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: TaskSchedulerNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: TimerTaskBatchSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: TimerProcessorSchedulerWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 512},
+	{Key: TimerProcessorSchedulerActiveRoundRobinWeights, Type: ValueTypeMap, Precedence: PrecedenceNamespace},
+	{Key: TimerProcessorSchedulerStandbyRoundRobinWeights, Type: ValueTypeMap, Precedence: PrecedenceNamespace},
+	{Key: TimerProcessorUpdateAckInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 30 * time.Second},
+	{Key: TimerProcessorUpdateAckIntervalJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: TimerProcessorMaxPollRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 20},
+	{Key: TimerProcessorMaxPollHostRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: TimerProcessorMaxPollInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Minute},
+	{Key: TimerProcessorMaxPollIntervalJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: TimerProcessorPollBackoffInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Second},
+	{Key: TimerProcessorMaxTimeShift, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 1 * time.Second},
+	{Key: TimerQueueMaxReaderCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 2},
+	{Key: RetentionTimerJitterDuration, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 30 * time.Minute},
+	{Key: MemoryTimerProcessorSchedulerWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 64},
+	{Key: TransferTaskBatchSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: TransferProcessorMaxPollRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 20},
+	{Key: TransferProcessorMaxPollHostRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: TransferProcessorSchedulerWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 512},
+	{Key: TransferProcessorSchedulerActiveRoundRobinWeights, Type: ValueTypeMap, Precedence: PrecedenceNamespace},
+	{Key: TransferProcessorSchedulerStandbyRoundRobinWeights, Type: ValueTypeMap, Precedence: PrecedenceNamespace},
+	{Key: TransferProcessorMaxPollInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 1 * time.Minute},
+	{Key: TransferProcessorMaxPollIntervalJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: TransferProcessorUpdateAckInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 30 * time.Second},
+	{Key: TransferProcessorUpdateAckIntervalJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: TransferProcessorPollBackoffInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Second},
+	{Key: TransferProcessorEnsureCloseBeforeDelete, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: TransferQueueMaxReaderCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 2},
+	{Key: VisibilityTaskBatchSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: VisibilityProcessorMaxPollRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 20},
+	{Key: VisibilityProcessorMaxPollHostRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: VisibilityProcessorSchedulerWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 512},
+	{Key: VisibilityProcessorSchedulerActiveRoundRobinWeights, Type: ValueTypeMap, Precedence: PrecedenceNamespace},
+	{Key: VisibilityProcessorSchedulerStandbyRoundRobinWeights, Type: ValueTypeMap, Precedence: PrecedenceNamespace},
+	{Key: VisibilityProcessorMaxPollInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 1 * time.Minute},
+	{Key: VisibilityProcessorMaxPollIntervalJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: VisibilityProcessorUpdateAckInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 30 * time.Second},
+	{Key: VisibilityProcessorUpdateAckIntervalJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: VisibilityProcessorPollBackoffInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Second},
+	{Key: VisibilityProcessorEnsureCloseBeforeDelete, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: VisibilityProcessorEnableCloseWorkflowCleanup, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: false},
+	{Key: VisibilityQueueMaxReaderCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 2},
+	{Key: VisibilityWatchEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: VisibilityWatchBufferSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1000},
+	{Key: ArchivalTaskBatchSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: ArchivalProcessorMaxPollRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 20},
+	{Key: ArchivalProcessorMaxPollHostRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: ArchivalProcessorSchedulerWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 512},
+	{Key: ArchivalProcessorMaxPollInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Minute},
+	{Key: ArchivalProcessorMaxPollIntervalJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: ArchivalProcessorUpdateAckInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 30 * time.Second},
+	{Key: ArchivalProcessorUpdateAckIntervalJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: ArchivalProcessorPollBackoffInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Second},
+	{Key: ArchivalProcessorArchiveDelay, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Minute},
+	{Key: ArchivalBackendMaxRPS, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 10000.0},
+	{Key: ArchivalQueueMaxReaderCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 2},
+	{Key: WorkflowExecutionMaxInFlightUpdates, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10},
+	{Key: WorkflowExecutionMaxTotalUpdates, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 2000},
+	{Key: ReplicatorTaskBatchSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 25},
+	{Key: ReplicatorMaxSkipTaskCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 250},
+	{Key: ReplicatorProcessorMaxPollInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 1 * time.Minute},
+	{Key: ReplicatorProcessorMaxPollIntervalJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: MaximumBufferedEventsBatch, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: MaximumBufferedEventsSizeInBytes, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 2 * 1024 * 1024},
+	{Key: MaximumSignalsPerExecution, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10000},
+	{Key: ShardUpdateMinInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Minute},
+	{Key: ShardUpdateMinTasksCompleted, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1000},
+	{Key: ShardSyncMinInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 5 * time.Minute},
+	{Key: EmitShardLagLog, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: DefaultEventEncoding, Type: ValueTypeString, Precedence: PrecedenceNamespace},
+	{Key: DefaultActivityRetryPolicy, Type: ValueTypeMap, Precedence: PrecedenceNamespace},
+	{Key: DefaultWorkflowRetryPolicy, Type: ValueTypeMap, Precedence: PrecedenceNamespace},
+	{Key: HistoryMaxAutoResetPoints, Type: ValueTypeInt, Precedence: PrecedenceNamespace},
+	{Key: EnableParentClosePolicy, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: ParentClosePolicyThreshold, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 10},
+	{Key: NumParentClosePolicySystemWorkflows, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10},
+	{Key: HistoryThrottledLogRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 4},
+	{Key: WorkflowTaskHeartbeatTimeout, Type: ValueTypeDuration, Precedence: PrecedenceNamespace, Default: time.Minute * 30},
+	{Key: WorkflowTaskCriticalAttempts, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10},
+	{Key: WorkflowTaskRetryMaxInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: time.Minute * 10},
+	{Key: DefaultWorkflowTaskTimeout, Type: ValueTypeDuration, Precedence: PrecedenceNamespace},
+	{Key: SkipReapplicationByNamespaceID, Type: ValueTypeBool, Precedence: PrecedenceNamespaceID, Default: false},
+	{Key: StandbyTaskReReplicationContextTimeout, Type: ValueTypeDuration, Precedence: PrecedenceNamespaceID, Default: 30 * time.Second},
+	{Key: MaxBufferedQueryCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1},
+	{Key: MutableStateChecksumGenProbability, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: MutableStateChecksumVerifyProbability, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: MutableStateChecksumInvalidateBefore, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: ReplicationTaskFetcherParallelism, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 4},
+	{Key: ReplicationTaskFetcherAggregationInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 2 * time.Second},
+	{Key: ReplicationTaskFetcherTimerJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 0.15},
+	{Key: ReplicationTaskFetcherErrorRetryWait, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: time.Second},
+	{Key: ReplicationTaskProcessorErrorRetryWait, Type: ValueTypeDuration, Precedence: PrecedenceShardID, Default: 1 * time.Second},
+	{Key: ReplicationTaskProcessorErrorRetryBackoffCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceShardID, Default: 1.2},
+	{Key: ReplicationTaskProcessorErrorRetryMaxInterval, Type: ValueTypeDuration, Precedence: PrecedenceShardID, Default: 5 * time.Second},
+	{Key: ReplicationTaskProcessorErrorRetryMaxAttempts, Type: ValueTypeInt, Precedence: PrecedenceShardID, Default: 80},
+	{Key: ReplicationTaskProcessorErrorRetryExpiration, Type: ValueTypeDuration, Precedence: PrecedenceShardID, Default: 5 * time.Minute},
+	{Key: ReplicationTaskProcessorNoTaskInitialWait, Type: ValueTypeDuration, Precedence: PrecedenceShardID, Default: 2 * time.Second},
+	{Key: ReplicationTaskProcessorCleanupInterval, Type: ValueTypeDuration, Precedence: PrecedenceShardID, Default: 1 * time.Minute},
+	{Key: ReplicationTaskProcessorCleanupJitterCoefficient, Type: ValueTypeFloat, Precedence: PrecedenceShardID, Default: 0.15},
+	{Key: ReplicationTaskProcessorHostQPS, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 1500},
+	{Key: ReplicationTaskProcessorShardQPS, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 30},
+	{Key: ReplicationEnableDLQMetrics, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: HistoryTaskDLQEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: HistoryTaskDLQUnexpectedErrorAttempts, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: HistoryTaskDLQInternalErrors, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: ReplicationStreamSyncStatusDuration, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 1 * time.Second},
+	{Key: ReplicationProcessorSchedulerQueueSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 128},
+	{Key: ReplicationProcessorSchedulerWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 512},
+	{Key: EnableEagerNamespaceRefresher, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: EnableReplicationTaskBatching, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: EnableReplicateLocalGeneratedEvents, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: WorkerPersistenceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 500},
+	{Key: WorkerPersistenceGlobalMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: WorkerPersistenceNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: WorkerPersistenceGlobalNamespaceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 0},
+	{Key: WorkerEnablePersistencePriorityRateLimiting, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: WorkerPersistenceDynamicRateLimitingParams, Type: ValueTypeMap, Precedence: PrecedenceGlobal},
+	{Key: WorkerIndexerConcurrency, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: WorkerESProcessorNumOfWorkers, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 2},
+	{Key: WorkerESProcessorBulkActions, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 500},
+	{Key: WorkerESProcessorBulkSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 16 * 1024 * 1024},
+	{Key: WorkerESProcessorFlushInterval, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 1 * time.Second},
+	{Key: WorkerESProcessorAckTimeout, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 30 * time.Second},
+	{Key: WorkerThrottledLogRPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 20},
+	{Key: WorkerScannerMaxConcurrentActivityExecutionSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10},
+	{Key: WorkerScannerMaxConcurrentWorkflowTaskExecutionSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10},
+	{Key: WorkerScannerMaxConcurrentActivityTaskPollers, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 8},
+	{Key: WorkerScannerMaxConcurrentWorkflowTaskPollers, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 8},
+	{Key: ScannerPersistenceMaxQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: ExecutionScannerPerHostQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10},
+	{Key: ExecutionScannerPerShardQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1},
+	{Key: ExecutionDataDurationBuffer, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: time.Hour * 24 * 90},
+	{Key: ExecutionScannerWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 8},
+	{Key: ExecutionScannerHistoryEventIdValidator, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: VisibilityScannerPerHostQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10},
+	{Key: VisibilityScannerPerShardQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1},
+	{Key: VisibilityScannerWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 8},
+	{Key: VisibilityScannerSampleRate, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 1.0},
+	{Key: VisibilityScannerMinAge, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 10 * time.Minute},
+	{Key: VisibilityScannerRepairEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: VisibilityRetentionScannerRPS, Type: ValueTypeFloat, Precedence: PrecedenceGlobal, Default: 10.0},
+	{Key: StuckWorkflowScannerPerHostQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 10},
+	{Key: StuckWorkflowScannerPerShardQPS, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1},
+	{Key: StuckWorkflowScannerWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 8},
+	{Key: StuckWorkflowScannerWorkflowTaskMaxAge, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: time.Hour},
+	{Key: StuckWorkflowScannerActivityTimeoutBuffer, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: time.Hour},
+	{Key: StuckWorkflowScannerMaxBufferedEvents, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 100},
+	{Key: StuckWorkflowScannerRemediationEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: TaskQueueScannerEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: BuildIdScavengerEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: HistoryScannerEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: ExecutionsScannerEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: VisibilityScannerEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: StuckWorkflowScannerEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: VisibilityRetentionScannerEnabled, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: false},
+	{Key: HistoryScannerDataMinAge, Type: ValueTypeDuration, Precedence: PrecedenceGlobal, Default: 60 * 24 * time.Hour},
+	{Key: HistoryScannerVerifyRetention, Type: ValueTypeBool, Precedence: PrecedenceGlobal, Default: true},
+	{Key: EnableBatcher, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: BatcherRPS, Type: ValueTypeInt, Precedence: PrecedenceNamespace},
+	{Key: BatcherConcurrency, Type: ValueTypeInt, Precedence: PrecedenceNamespace},
+	{Key: WorkerParentCloseMaxConcurrentActivityExecutionSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1000},
+	{Key: WorkerParentCloseMaxConcurrentWorkflowTaskExecutionSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 1000},
+	{Key: WorkerParentCloseMaxConcurrentActivityTaskPollers, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 4},
+	{Key: WorkerParentCloseMaxConcurrentWorkflowTaskPollers, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 4},
+	{Key: WorkerPerNamespaceWorkerCount, Type: ValueTypeInt, Precedence: PrecedenceNamespace, Default: 1},
+	{Key: WorkerPerNamespaceWorkerOptions, Type: ValueTypeMap, Precedence: PrecedenceNamespace},
+	{Key: WorkerEnableScheduler, Type: ValueTypeBool, Precedence: PrecedenceNamespace, Default: true},
+	{Key: WorkerStickyCacheSize, Type: ValueTypeInt, Precedence: PrecedenceGlobal, Default: 0},
+	{Key: SchedulerNamespaceStartWorkflowRPS, Type: ValueTypeFloat, Precedence: PrecedenceNamespace, Default: 30.0},
+}
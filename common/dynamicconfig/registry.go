@@ -0,0 +1,165 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+type (
+	// ValueType is the type a key's value is converted to
+	ValueType string
+
+	// Precedence identifies the constraints a key is looked up with
+	Precedence string
+
+	// KeyInfo describes how the server uses a key. Keys passed to a Collection getter anywhere in
+	// the server are registered statically from keys_gen.go, so keys used only by services running
+	// in other processes are known as well. The registration is replaced the first time a property
+	// for the key is created from a Collection in this process.
+	KeyInfo struct {
+		Key        Key
+		Type       ValueType
+		Precedence Precedence
+		// Default is the default value passed when the property was created. It may be a
+		// []ConstrainedValue for keys with constrained defaults. It is nil for static
+		// registrations of keys whose default is computed where the key is used.
+		Default any
+
+		static bool
+	}
+)
+
+const (
+	ValueTypeBool     ValueType = "bool"
+	ValueTypeInt      ValueType = "int"
+	ValueTypeFloat    ValueType = "float"
+	ValueTypeDuration ValueType = "duration"
+	ValueTypeString   ValueType = "string"
+	ValueTypeMap      ValueType = "map"
+)
+
+const (
+	PrecedenceGlobal      Precedence = "global"
+	PrecedenceNamespace   Precedence = "namespace"
+	PrecedenceNamespaceID Precedence = "namespaceID"
+	PrecedenceTaskQueue   Precedence = "taskQueue"
+	PrecedenceShardID     Precedence = "shardID"
+	PrecedenceTaskType    Precedence = "taskType"
+)
+
+// registeredKeys maps lower-cased keys to their KeyInfo
+var (
+	registeredKeys   sync.Map
+	registrationLock sync.Mutex
+)
+
+func init() {
+	for _, info := range staticKeyInfos {
+		info.static = true
+		registeredKeys.Store(strings.ToLower(info.Key.String()), info)
+	}
+}
+
+func registerKey(key Key, defaultValue any, valueType ValueType, precedence Precedence) {
+	lowerKey := strings.ToLower(key.String())
+	if existing, ok := registeredKeys.Load(lowerKey); ok && !existing.(KeyInfo).static {
+		return
+	}
+
+	registrationLock.Lock()
+	defer registrationLock.Unlock()
+	// the default value of the first property created in this process takes over the static one
+	if existing, ok := registeredKeys.Load(lowerKey); ok && !existing.(KeyInfo).static {
+		return
+	}
+	registeredKeys.Store(lowerKey, KeyInfo{
+		Key:        key,
+		Type:       valueType,
+		Precedence: precedence,
+		Default:    defaultValue,
+	})
+}
+
+// GetKeyInfo returns the registration of key. Keys are case-insensitive.
+func GetKeyInfo(key Key) (KeyInfo, bool) {
+	info, ok := registeredKeys.Load(strings.ToLower(key.String()))
+	if !ok {
+		return KeyInfo{}, false
+	}
+	return info.(KeyInfo), true
+}
+
+// RegisteredKeys returns all registered keys sorted by key.
+func RegisteredKeys() []KeyInfo {
+	var keys []KeyInfo
+	registeredKeys.Range(func(_, info any) bool {
+		keys = append(keys, info.(KeyInfo))
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.ToLower(keys[i].Key.String()) < strings.ToLower(keys[j].Key.String())
+	})
+	return keys
+}
+
+// constraintsPrecedence returns the constraints to look up a key with, from most to least specific.
+func (p Precedence) constraintsPrecedence(cs Constraints) []Constraints {
+	switch p {
+	case PrecedenceNamespace:
+		return namespacePrecedence(cs.Namespace)
+	case PrecedenceNamespaceID:
+		return namespaceIDPrecedence(cs.NamespaceID)
+	case PrecedenceTaskQueue:
+		return taskQueuePrecedence(cs.Namespace, cs.TaskQueueName, cs.TaskQueueType)
+	case PrecedenceShardID:
+		return shardIDPrecedence(cs.ShardID)
+	case PrecedenceTaskType:
+		return taskTypePrecedence(cs.TaskType)
+	default:
+		return globalPrecedence()
+	}
+}
+
+func (t ValueType) convert(val any) (any, error) {
+	switch t {
+	case ValueTypeBool:
+		return convertBool(val)
+	case ValueTypeInt:
+		return convertInt(val)
+	case ValueTypeFloat:
+		return convertFloat(val)
+	case ValueTypeDuration:
+		return convertDuration(val)
+	case ValueTypeString:
+		return convertString(val)
+	case ValueTypeMap:
+		return convertMap(val)
+	default:
+		return val, nil
+	}
+}
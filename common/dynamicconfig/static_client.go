@@ -42,6 +42,14 @@ func (s StaticClient) GetValue(key Key) []ConstrainedValue {
 	return nil
 }
 
+func (s StaticClient) GetAllValues() map[Key][]ConstrainedValue {
+	allValues := make(map[Key][]ConstrainedValue, len(s))
+	for key := range s {
+		allValues[key] = s.GetValue(key)
+	}
+	return allValues
+}
+
 // NewNoopClient returns a Client that has no keys (a Collection using it will always return
 // default values).
 func NewNoopClient() Client {
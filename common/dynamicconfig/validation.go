@@ -0,0 +1,238 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	enumspb "go.temporal.io/api/enums/v1"
+
+	enumsspb "go.temporal.io/server/api/enums/v1"
+)
+
+type (
+	// ValidationErrorKind is the kind of problem found with a configured value
+	ValidationErrorKind string
+
+	// ValidationError describes a configured value that will not be used as intended
+	ValidationError struct {
+		Kind        ValidationErrorKind
+		Key         Key
+		Constraints Constraints
+		// Source is where the value was configured, if the Client keeps track of it
+		Source  string
+		Message string
+	}
+)
+
+const (
+	// ValidationErrorUnknownKey is reported for keys that are not declared by the server. These
+	// are most likely typos.
+	ValidationErrorUnknownKey ValidationErrorKind = "unknown_key"
+	// ValidationErrorWrongType is reported for values that can't be converted to the type of
+	// their key. The default value is used instead.
+	ValidationErrorWrongType ValidationErrorKind = "wrong_type"
+	// ValidationErrorUnusedConstraints is reported for values with constraints that the key is
+	// never looked up with, e.g. shardID on a key that is looked up by namespace.
+	ValidationErrorUnusedConstraints ValidationErrorKind = "unused_constraints"
+)
+
+// maxSuggestionDistance is the maximum edit distance between an unknown key and a known key
+// for the known key to be suggested
+const maxSuggestionDistance = 3
+
+func (e ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: key %q", e.Kind, e.Key)
+	if e.Constraints != (Constraints{}) {
+		fmt.Fprintf(&b, " with constraints %s", formatConstraints(e.Constraints))
+	}
+	if e.Source != "" {
+		fmt.Fprintf(&b, " in %s", e.Source)
+	}
+	fmt.Fprintf(&b, ": %s", e.Message)
+	return b.String()
+}
+
+// formatConstraints formats the set fields of cs like they are written in the config file.
+func formatConstraints(cs Constraints) string {
+	var fields []string
+	if cs.Namespace != "" {
+		fields = append(fields, fmt.Sprintf("namespace: %q", cs.Namespace))
+	}
+	if cs.NamespaceID != "" {
+		fields = append(fields, fmt.Sprintf("namespaceID: %q", cs.NamespaceID))
+	}
+	if cs.TaskQueueName != "" {
+		fields = append(fields, fmt.Sprintf("taskQueueName: %q", cs.TaskQueueName))
+	}
+	if cs.TaskQueueType != enumspb.TASK_QUEUE_TYPE_UNSPECIFIED {
+		fields = append(fields, fmt.Sprintf("taskType: %s", cs.TaskQueueType))
+	}
+	if cs.ShardID != 0 {
+		fields = append(fields, fmt.Sprintf("shardID: %d", cs.ShardID))
+	}
+	if cs.TaskType != enumsspb.TASK_TYPE_UNSPECIFIED {
+		fields = append(fields, fmt.Sprintf("historyTaskType: %s", cs.TaskType))
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+// Validate checks all values of client against the declared and registered keys. Types and
+// constraints can only be checked for keys that are registered, i.e. that are used with a
+// Collection getter. It returns nil if client does not implement ListableClient.
+func Validate(client Client) []ValidationError {
+	lc, ok := client.(ListableClient)
+	if !ok {
+		return nil
+	}
+	sc, _ := client.(ValueSourceClient)
+
+	allValues := lc.GetAllValues()
+	keys := make([]Key, 0, len(allValues))
+	for key := range allValues {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var errs []ValidationError
+	for _, key := range keys {
+		for _, cv := range allValues[key] {
+			if err, ok := validateValue(key, cv); ok {
+				if sc != nil {
+					err.Source, _ = sc.GetValueSource(key, cv.Constraints)
+				}
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+func validateValue(key Key, cv ConstrainedValue) (ValidationError, bool) {
+	info, registered := GetKeyInfo(key)
+	if !registered {
+		if isDeclaredKey(key) {
+			return ValidationError{}, false
+		}
+		message := "key is not declared by the server"
+		if suggestion, ok := suggestKey(key); ok {
+			message += fmt.Sprintf(", did you mean %q?", suggestion)
+		}
+		return ValidationError{Kind: ValidationErrorUnknownKey, Key: key, Constraints: cv.Constraints, Message: message}, true
+	}
+
+	if _, err := info.Type.convert(cv.Value); err != nil {
+		return ValidationError{
+			Kind:        ValidationErrorWrongType,
+			Key:         info.Key,
+			Constraints: cv.Constraints,
+			Message:     fmt.Sprintf("value %v is not a valid %s, the default value is used instead", cv.Value, info.Type),
+		}, true
+	}
+
+	if !info.Precedence.usesConstraints(cv.Constraints) {
+		return ValidationError{
+			Kind:        ValidationErrorUnusedConstraints,
+			Key:         info.Key,
+			Constraints: cv.Constraints,
+			Message:     fmt.Sprintf("key is looked up by %s, the value is never used", info.Precedence),
+		}, true
+	}
+	return ValidationError{}, false
+}
+
+// usesConstraints returns true if a value with the given constraints can be matched by a lookup
+// with precedence p.
+func (p Precedence) usesConstraints(cs Constraints) bool {
+	unused := cs
+	switch p {
+	case PrecedenceNamespace:
+		unused.Namespace = ""
+	case PrecedenceNamespaceID:
+		unused.NamespaceID = ""
+	case PrecedenceTaskQueue:
+		if cs.Namespace != "" && cs.TaskQueueName != "" {
+			unused.TaskQueueType = 0
+		}
+		unused.Namespace = ""
+		unused.TaskQueueName = ""
+	case PrecedenceShardID:
+		unused.ShardID = 0
+	case PrecedenceTaskType:
+		unused.TaskType = 0
+	}
+	return unused == Constraints{}
+}
+
+func isDeclaredKey(key Key) bool {
+	for _, k := range allKeys {
+		if strings.EqualFold(k.String(), key.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestKey returns the declared or registered key closest to key.
+func suggestKey(key Key) (Key, bool) {
+	candidates := append([]Key(nil), allKeys...)
+	for _, info := range RegisteredKeys() {
+		candidates = append(candidates, info.Key)
+	}
+
+	lowerKey := strings.ToLower(key.String())
+	var suggestion Key
+	bestDistance := maxSuggestionDistance + 1
+	for _, candidate := range candidates {
+		if d := editDistance(lowerKey, strings.ToLower(candidate.String())); d < bestDistance {
+			suggestion, bestDistance = candidate, d
+		}
+	}
+	return suggestion, suggestion != ""
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
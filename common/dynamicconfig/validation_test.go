@@ -0,0 +1,117 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/server/common/log"
+)
+
+const (
+	testValidateIntKey       = "testValidateIntKey"
+	testValidateNamespaceKey = "testValidateNamespaceKey"
+	testValidateTaskQueueKey = "testValidateTaskQueueKey"
+)
+
+func TestValidate(t *testing.T) {
+	cln := NewCollection(NewNoopClient(), log.NewNoopLogger())
+	cln.GetIntProperty(testValidateIntKey, 1)
+	cln.GetIntPropertyFilteredByNamespace(testValidateNamespaceKey, 1)
+	cln.GetIntPropertyFilteredByTaskQueueInfo(testValidateTaskQueueKey, 1)
+
+	client := StaticClient{
+		testValidateIntKey: "not an int",
+		testValidateNamespaceKey: []ConstrainedValue{
+			{Constraints: Constraints{Namespace: "ns"}, Value: 1},
+			{Constraints: Constraints{ShardID: 1}, Value: 2},
+		},
+		testValidateTaskQueueKey: []ConstrainedValue{
+			{Constraints: Constraints{Namespace: "ns", TaskQueueName: "tq", TaskQueueType: 1}, Value: 1},
+			{Constraints: Constraints{TaskQueueName: "tq", TaskQueueType: 1}, Value: 2},
+		},
+		// registered from keys_gen.go
+		FrontendPersistenceMaxQPS: "not an int",
+		// declared but not registered
+		StickyTTL:                    "not a duration",
+		"frontend.persistenceMaxQSP": 10,
+		"completelyUnknownKey":       10,
+	}
+
+	errs := Validate(client)
+	require.Equal(t, []ValidationError{
+		{
+			Kind:    ValidationErrorUnknownKey,
+			Key:     "completelyUnknownKey",
+			Message: "key is not declared by the server",
+		},
+		{
+			Kind:    ValidationErrorWrongType,
+			Key:     FrontendPersistenceMaxQPS,
+			Message: "value not an int is not a valid int, the default value is used instead",
+		},
+		{
+			Kind:    ValidationErrorUnknownKey,
+			Key:     "frontend.persistenceMaxQSP",
+			Message: `key is not declared by the server, did you mean "frontend.persistenceMaxQPS"?`,
+		},
+		{
+			Kind:    ValidationErrorWrongType,
+			Key:     testValidateIntKey,
+			Message: "value not an int is not a valid int, the default value is used instead",
+		},
+		{
+			Kind:        ValidationErrorUnusedConstraints,
+			Key:         testValidateNamespaceKey,
+			Constraints: Constraints{ShardID: 1},
+			Message:     "key is looked up by namespace, the value is never used",
+		},
+		{
+			Kind:        ValidationErrorUnusedConstraints,
+			Key:         testValidateTaskQueueKey,
+			Constraints: Constraints{TaskQueueName: "tq", TaskQueueType: 1},
+			Message:     "key is looked up by taskQueue, the value is never used",
+		},
+	}, errs)
+	require.Equal(t,
+		`unused_constraints: key "testValidateNamespaceKey" with constraints {shardID: 1}: key is looked up by namespace, the value is never used`,
+		errs[4].Error(),
+	)
+
+	require.Nil(t, Validate(fakeClient{}))
+}
+
+func TestEditDistance(t *testing.T) {
+	require.Equal(t, 0, editDistance("abc", "abc"))
+	require.Equal(t, 1, editDistance("abc", "abd"))
+	require.Equal(t, 2, editDistance("abc", "acb"))
+	require.Equal(t, 3, editDistance("", "abc"))
+}
+
+type fakeClient struct{}
+
+func (fakeClient) GetValue(Key) []ConstrainedValue { return nil }
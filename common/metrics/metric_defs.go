@@ -78,12 +78,16 @@ const (
 const (
 	// AdminClientStreamWorkflowReplicationMessagesScope tracks RPC calls to admin service
 	AdminClientStreamWorkflowReplicationMessagesScope = "AdminClientStreamWorkflowReplicationMessages"
+	// AdminClientWatchWorkflowExecutionsScope tracks RPC calls to admin service
+	AdminClientWatchWorkflowExecutionsScope = "AdminClientWatchWorkflowExecutions"
 )
 
 // History Client Operations
 const (
 	// HistoryClientStreamWorkflowReplicationMessagesScope tracks RPC calls to history service
 	HistoryClientStreamWorkflowReplicationMessagesScope = "HistoryClientStreamWorkflowReplicationMessages"
+	// HistoryClientStreamVisibilityChangesScope tracks RPC calls to history service
+	HistoryClientStreamVisibilityChangesScope = "HistoryClientStreamVisibilityChanges"
 )
 
 // Matching Client Operations
@@ -106,6 +110,12 @@ const (
 	TaskQueueScavengerScope = "TaskQueueScavenger"
 	// ExecutionsScavengerScope is scope used by all metrics emitted by worker.executions.Scavenger module
 	ExecutionsScavengerScope = "ExecutionsScavenger"
+	// VisibilityScavengerScope is scope used by all metrics emitted by worker.scanner.visibility.Scavenger module
+	VisibilityScavengerScope = "VisibilityScavenger"
+	// StuckWorkflowScavengerScope is scope used by all metrics emitted by worker.scanner.stuck_workflows.Scavenger module
+	StuckWorkflowScavengerScope = "StuckWorkflowScavenger"
+	// VisibilityRetentionScavengerScope is scope used by all metrics emitted by worker.scanner.visibility_retention module
+	VisibilityRetentionScavengerScope = "VisibilityRetentionScavenger"
 )
 
 const (
@@ -167,6 +177,14 @@ const (
 	PersistencePruneClusterMembershipScope = "PruneClusterMembership"
 	// PersistenceGetClusterMembersScope tracks GetClusterMembers calls made by service to persistence layer
 	PersistenceGetClusterMembersScope = "GetClusterMembers"
+	// PersistenceGetNexusIncomingServiceScope tracks GetNexusIncomingService calls made by service to persistence layer
+	PersistenceGetNexusIncomingServiceScope = "GetNexusIncomingService"
+	// PersistenceListNexusIncomingServicesScope tracks ListNexusIncomingServices calls made by service to persistence layer
+	PersistenceListNexusIncomingServicesScope = "ListNexusIncomingServices"
+	// PersistenceCreateOrUpdateNexusIncomingServiceScope tracks CreateOrUpdateNexusIncomingService calls made by service to persistence layer
+	PersistenceCreateOrUpdateNexusIncomingServiceScope = "CreateOrUpdateNexusIncomingService"
+	// PersistenceDeleteNexusIncomingServiceScope tracks DeleteNexusIncomingService calls made by service to persistence layer
+	PersistenceDeleteNexusIncomingServiceScope = "DeleteNexusIncomingService"
 	// PersistenceGetOrCreateShardScope tracks GetOrCreateShard calls made by service to persistence layer
 	PersistenceGetOrCreateShardScope = "GetOrCreateShard"
 	// PersistenceUpdateShardScope tracks UpdateShard calls made by service to persistence layer
@@ -526,6 +544,8 @@ const (
 	DeleteNamespaceWorkflowScope    = "DeleteNamespaceWorkflow"
 	ReclaimResourcesWorkflowScope   = "ReclaimResourcesWorkflow"
 	DeleteExecutionsWorkflowScope   = "DeleteExecutionsWorkflow"
+	// VisibilityBackfillWorkflowScope is scope used by all metrics emitted by worker.VisibilityBackfill module
+	VisibilityBackfillWorkflowScope = "VisibilityBackfillWorkflow"
 )
 
 // History task type
@@ -600,7 +620,9 @@ var (
 	ActionCounter                            = NewCounterDef("action")
 	TlsCertsExpired                          = NewGaugeDef("certificates_expired")
 	TlsCertsExpiring                         = NewGaugeDef("certificates_expiring")
+	DynamicConfigValidationErrors            = NewGaugeDef("dynamic_config_validation_errors")
 	ServiceAuthorizationLatency              = NewTimerDef("service_authorization_latency")
+	AuthorizationAuditRecordsDropped         = NewCounterDef("authorization_audit_records_dropped")
 	EventBlobSize                            = NewBytesHistogramDef("event_blob_size")
 	LockRequests                             = NewCounterDef("lock_requests")
 	LockLatency                              = NewTimerDef("lock_latency")
@@ -996,6 +1018,8 @@ var (
 	ScavengerValidationRequestsCount                = NewCounterDef("scavenger_validation_requests")
 	ScavengerValidationFailuresCount                = NewCounterDef("scavenger_validation_failures")
 	ScavengerValidationSkipsCount                   = NewCounterDef("scavenger_validation_skips")
+	ScavengerRepairRequestsCount                    = NewCounterDef("scavenger_repair_requests")
+	ScavengerRepairFailuresCount                    = NewCounterDef("scavenger_repair_failures")
 	AddSearchAttributesFailuresCount                = NewCounterDef("add_search_attributes_failures")
 	DeleteNamespaceSuccessCount                     = NewCounterDef("delete_namespace_success")
 	RenameNamespaceSuccessCount                     = NewCounterDef("rename_namespace_success")
@@ -1082,6 +1106,14 @@ var (
 	VerifyReplicationTasksLatency       = NewTimerDef("verify_replication_tasks_latency")
 	VerifyDescribeMutableStateLatency   = NewTimerDef("verify_describe_mutable_state_latency")
 
+	// Visibility backfill
+	VisibilityBackfillRecordsCount       = NewCounterDef("visibility_backfill_records")
+	VisibilityBackfillCountMismatchCount = NewCounterDef("visibility_backfill_count_mismatch")
+
+	// Visibility retention
+	VisibilityRetentionDeletedCount       = NewCounterDef("visibility_retention_deleted")
+	VisibilityRetentionDeleteFailureCount = NewCounterDef("visibility_retention_delete_failures")
+
 	// Replication
 	NamespaceReplicationTaskAckLevelGauge = NewGaugeDef("namespace_replication_task_ack_level")
 	NamespaceReplicationDLQAckLevelGauge  = NewGaugeDef("namespace_dlq_ack_level")
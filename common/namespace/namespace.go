@@ -26,6 +26,7 @@ package namespace
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -318,6 +319,16 @@ func (ns *Namespace) Retention() time.Duration {
 	return ns.config.Retention.AsDuration()
 }
 
+// VisibilityRetention returns retention of the visibility records of closed workflows.
+// Zero means visibility records are deleted together with the workflow history.
+func (ns *Namespace) VisibilityRetention() time.Duration {
+	if ns.config.VisibilityRetention == nil {
+		return 0
+	}
+
+	return ns.config.VisibilityRetention.AsDuration()
+}
+
 func (ns *Namespace) CustomSearchAttributesMapper() CustomSearchAttributesMapper {
 	return ns.customSearchAttributesMapper
 }
@@ -363,6 +374,23 @@ func (n Name) IsEmpty() bool {
 	return n == EmptyName
 }
 
+// DeletedNameSuffix returns the suffix appended to the name of a namespace when it is renamed
+// for deletion. It is built from the first length characters of the namespace ID.
+func DeletedNameSuffix(id ID, length int) string {
+	return fmt.Sprintf("-deleted-%s", id.String()[:length])
+}
+
+// NameBeforeDeletion returns the name the namespace had before it was renamed for deletion,
+// or name if the namespace wasn't renamed.
+func NameBeforeDeletion(name Name, id ID) Name {
+	for length := 1; length <= len(id); length++ {
+		if suffix := DeletedNameSuffix(id, length); strings.HasSuffix(name.String(), suffix) {
+			return Name(strings.TrimSuffix(name.String(), suffix))
+		}
+	}
+	return name
+}
+
 func (m *CustomSearchAttributesMapper) GetAlias(fieldName string, namespace string) (string, error) {
 	alias, ok := m.fieldToAlias[fieldName]
 	if !ok {
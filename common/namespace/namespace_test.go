@@ -142,3 +142,11 @@ func TestNamespace_GetCustomData(t *testing.T) {
 	data2 := ns.GetCustomData("fake")
 	assert.Equal(t, "", data2)
 }
+
+func TestNameBeforeDeletion(t *testing.T) {
+	id := namespace.ID("7a1b2c3d-0000-0000-0000-000000000000")
+	assert.Equal(t, namespace.Name("tenant"), namespace.NameBeforeDeletion("tenant-deleted-7a1b2", id))
+	assert.Equal(t, namespace.Name("tenant"), namespace.NameBeforeDeletion("tenant-deleted-7a1b2c", id))
+	assert.Equal(t, namespace.Name("tenant"), namespace.NameBeforeDeletion("tenant", id))
+	assert.Equal(t, namespace.Name("tenant-deleted-99999"), namespace.NameBeforeDeletion("tenant-deleted-99999", id))
+}
@@ -393,6 +393,7 @@ func (s *namespaceReplicationTaskExecutorSuite) TestExecute_UpdateNamespaceTask_
 	updateOwnerEmail := "other random namespace test owner"
 	updatedData := map[string]string{"k": "v1"}
 	updateRetention := 122 * time.Hour * 24
+	visibilityRetention := 365 * time.Hour * 24
 	updateHistoryArchivalState := enumspb.ARCHIVAL_STATE_DISABLED
 	updateHistoryArchivalURI := "some updated history archival uri"
 	updateVisibilityArchivalState := enumspb.ARCHIVAL_STATE_DISABLED
@@ -449,6 +450,9 @@ func (s *namespaceReplicationTaskExecutorSuite) TestExecute_UpdateNamespaceTask_
 		Info: &persistencespb.NamespaceInfo{
 			Id: id,
 		},
+		Config: &persistencespb.NamespaceConfig{
+			VisibilityRetention: durationpb.New(visibilityRetention),
+		},
 		ReplicationConfig: &persistencespb.NamespaceReplicationConfig{},
 	}}, nil).Times(2)
 	s.mockMetadataMgr.EXPECT().GetMetadata(gomock.Any()).Return(&persistence.GetMetadataResponse{
@@ -470,6 +474,7 @@ func (s *namespaceReplicationTaskExecutorSuite) TestExecute_UpdateNamespaceTask_
 				HistoryArchivalUri:      updateTask.Config.HistoryArchivalUri,
 				VisibilityArchivalState: updateTask.Config.VisibilityArchivalState,
 				VisibilityArchivalUri:   updateTask.Config.VisibilityArchivalUri,
+				VisibilityRetention:     durationpb.New(visibilityRetention),
 			},
 			ReplicationConfig: &persistencespb.NamespaceReplicationConfig{
 				ActiveClusterName: updateTask.ReplicationConfig.ActiveClusterName,
@@ -106,6 +106,11 @@ func (f *Factory) NewClusterMetadataStore() (p.ClusterMetadataStore, error) {
 	return NewClusterMetadataStore(f.session, f.logger)
 }
 
+// NewNexusIncomingServiceStore returns a new NexusIncomingServiceStore
+func (f *Factory) NewNexusIncomingServiceStore() (p.NexusIncomingServiceStore, error) {
+	return NewNexusIncomingServiceStore(f.session, f.logger)
+}
+
 // NewExecutionStore returns a new ExecutionStore.
 func (f *Factory) NewExecutionStore() (p.ExecutionStore, error) {
 	return NewExecutionStore(f.session, f.logger), nil
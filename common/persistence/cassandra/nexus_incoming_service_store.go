@@ -0,0 +1,294 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cassandra
+
+import (
+	"context"
+	"fmt"
+
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/common/log"
+	p "go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/nosql/nosqlplugin/cassandra/gocql"
+)
+
+const (
+	// Nexus incoming services are stored in a single partition so that mutations of individual services and the
+	// table version can be applied atomically with a lightweight transaction batch.
+	nexusIncomingServicesPartition = 0
+
+	rowTypeNexusIncomingServicesPartitionStatus = 0
+	rowTypeNexusIncomingService                 = 1
+
+	nexusIncomingServicesPartitionStatusName = ""
+)
+
+const (
+	templateGetNexusIncomingServicesTableVersion    = `SELECT version FROM nexus_incoming_services WHERE partition = ? AND type = ? AND service_name = ?`
+	templateCreateNexusIncomingServicesTableVersion = `INSERT INTO nexus_incoming_services (partition, type, service_name, version) VALUES (?, ?, ?, ?) IF NOT EXISTS`
+	templateUpdateNexusIncomingServicesTableVersion = `UPDATE nexus_incoming_services SET version = ? WHERE partition = ? AND type = ? AND service_name = ? IF version = ?`
+
+	templateGetNexusIncomingService    = `SELECT service_name, data, data_encoding, version FROM nexus_incoming_services WHERE partition = ? AND type = ? AND service_name = ?`
+	templateListNexusIncomingServices  = `SELECT service_name, data, data_encoding, version FROM nexus_incoming_services WHERE partition = ? AND type = ?`
+	templateCreateNexusIncomingService = `INSERT INTO nexus_incoming_services (partition, type, service_name, data, data_encoding, version) VALUES (?, ?, ?, ?, ?, ?) IF NOT EXISTS`
+	templateUpdateNexusIncomingService = `UPDATE nexus_incoming_services SET data = ?, data_encoding = ?, version = ? WHERE partition = ? AND type = ? AND service_name = ? IF version = ?`
+	templateDeleteNexusIncomingService = `DELETE FROM nexus_incoming_services WHERE partition = ? AND type = ? AND service_name = ? IF EXISTS`
+)
+
+type (
+	// NexusIncomingServiceStore implements the NexusIncomingServiceStore interface on top of the
+	// nexus_incoming_services table. The schema is located at:
+	//	schema/cassandra/temporal/versioned/v1.10/nexus_incoming_services.cql
+	NexusIncomingServiceStore struct {
+		session gocql.Session
+		logger  log.Logger
+	}
+)
+
+var _ p.NexusIncomingServiceStore = (*NexusIncomingServiceStore)(nil)
+
+// NewNexusIncomingServiceStore is used to create an instance of NexusIncomingServiceStore implementation
+func NewNexusIncomingServiceStore(
+	session gocql.Session,
+	logger log.Logger,
+) (p.NexusIncomingServiceStore, error) {
+	return &NexusIncomingServiceStore{
+		session: session,
+		logger:  logger,
+	}, nil
+}
+
+func (s *NexusIncomingServiceStore) GetName() string {
+	return cassandraPersistenceName
+}
+
+func (s *NexusIncomingServiceStore) Close() {
+	if s.session != nil {
+		s.session.Close()
+	}
+}
+
+func (s *NexusIncomingServiceStore) GetNexusIncomingService(
+	ctx context.Context,
+	request *p.GetNexusIncomingServiceRequest,
+) (*p.InternalNexusIncomingService, error) {
+	query := s.session.Query(templateGetNexusIncomingService,
+		nexusIncomingServicesPartition,
+		rowTypeNexusIncomingService,
+		request.Name,
+	).WithContext(ctx)
+
+	var (
+		name         string
+		data         []byte
+		dataEncoding string
+		version      int64
+	)
+	if err := query.Scan(&name, &data, &dataEncoding, &version); err != nil {
+		return nil, gocql.ConvertError("GetNexusIncomingService", err)
+	}
+
+	return &p.InternalNexusIncomingService{
+		Name:    name,
+		Version: version,
+		Data:    p.NewDataBlob(data, dataEncoding),
+	}, nil
+}
+
+func (s *NexusIncomingServiceStore) ListNexusIncomingServices(
+	ctx context.Context,
+	request *p.ListNexusIncomingServicesRequest,
+) (*p.InternalListNexusIncomingServicesResponse, error) {
+	tableVersion, err := s.getTableVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if request.LastKnownTableVersion != 0 && request.LastKnownTableVersion != tableVersion {
+		return nil, p.ErrNexusTableVersionConflict
+	}
+
+	query := s.session.Query(templateListNexusIncomingServices,
+		nexusIncomingServicesPartition,
+		rowTypeNexusIncomingService,
+	).WithContext(ctx)
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+
+	response := &p.InternalListNexusIncomingServicesResponse{
+		TableVersion: tableVersion,
+	}
+	var (
+		name         string
+		data         []byte
+		dataEncoding string
+		version      int64
+	)
+	for iter.Scan(&name, &data, &dataEncoding, &version) {
+		response.Services = append(response.Services, p.InternalNexusIncomingService{
+			Name:    name,
+			Version: version,
+			Data:    p.NewDataBlob(data, dataEncoding),
+		})
+	}
+
+	if len(iter.PageState()) > 0 {
+		response.NextPageToken = iter.PageState()
+	}
+	if err := iter.Close(); err != nil {
+		return nil, gocql.ConvertError("ListNexusIncomingServices", err)
+	}
+	return response, nil
+}
+
+func (s *NexusIncomingServiceStore) CreateOrUpdateNexusIncomingService(
+	ctx context.Context,
+	request *p.InternalCreateOrUpdateNexusIncomingServiceRequest,
+) error {
+	tableVersion, err := s.getTableVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if request.LastKnownTableVersion != 0 && request.LastKnownTableVersion != tableVersion {
+		return p.ErrNexusTableVersionConflict
+	}
+
+	batch := s.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	s.addTableVersionQuery(batch, tableVersion)
+	if request.Service.Version == 0 {
+		batch.Query(templateCreateNexusIncomingService,
+			nexusIncomingServicesPartition,
+			rowTypeNexusIncomingService,
+			request.Service.Name,
+			request.Service.Data.Data,
+			request.Service.Data.EncodingType.String(),
+			1,
+		)
+	} else {
+		batch.Query(templateUpdateNexusIncomingService,
+			request.Service.Data.Data,
+			request.Service.Data.EncodingType.String(),
+			request.Service.Version+1,
+			nexusIncomingServicesPartition,
+			rowTypeNexusIncomingService,
+			request.Service.Name,
+			request.Service.Version,
+		)
+	}
+
+	return s.executeBatch("CreateOrUpdateNexusIncomingService", batch, tableVersion, p.ErrNexusIncomingServiceVersionConflict)
+}
+
+func (s *NexusIncomingServiceStore) DeleteNexusIncomingService(
+	ctx context.Context,
+	request *p.DeleteNexusIncomingServiceRequest,
+) error {
+	tableVersion, err := s.getTableVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if request.LastKnownTableVersion != 0 && request.LastKnownTableVersion != tableVersion {
+		return p.ErrNexusTableVersionConflict
+	}
+
+	batch := s.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
+	s.addTableVersionQuery(batch, tableVersion)
+	batch.Query(templateDeleteNexusIncomingService,
+		nexusIncomingServicesPartition,
+		rowTypeNexusIncomingService,
+		request.Name,
+	)
+
+	return s.executeBatch(
+		"DeleteNexusIncomingService",
+		batch,
+		tableVersion,
+		serviceerror.NewNotFound(fmt.Sprintf("nexus incoming service %q not found", request.Name)),
+	)
+}
+
+func (s *NexusIncomingServiceStore) getTableVersion(ctx context.Context) (int64, error) {
+	query := s.session.Query(templateGetNexusIncomingServicesTableVersion,
+		nexusIncomingServicesPartition,
+		rowTypeNexusIncomingServicesPartitionStatus,
+		nexusIncomingServicesPartitionStatusName,
+	).WithContext(ctx)
+
+	var version int64
+	if err := query.Scan(&version); err != nil {
+		if gocql.IsNotFoundError(err) {
+			return 0, nil
+		}
+		return 0, gocql.ConvertError("GetNexusIncomingServicesTableVersion", err)
+	}
+	return version, nil
+}
+
+func (s *NexusIncomingServiceStore) addTableVersionQuery(batch gocql.Batch, tableVersion int64) {
+	if tableVersion == 0 {
+		batch.Query(templateCreateNexusIncomingServicesTableVersion,
+			nexusIncomingServicesPartition,
+			rowTypeNexusIncomingServicesPartitionStatus,
+			nexusIncomingServicesPartitionStatusName,
+			1,
+		)
+		return
+	}
+	batch.Query(templateUpdateNexusIncomingServicesTableVersion,
+		tableVersion+1,
+		nexusIncomingServicesPartition,
+		rowTypeNexusIncomingServicesPartitionStatus,
+		nexusIncomingServicesPartitionStatusName,
+		tableVersion,
+	)
+}
+
+// executeBatch applies the given conditional batch. When the batch is not applied, the previous value of the
+// partition status row (which sorts first within the partition) tells whether the table version or the service row
+// condition failed.
+func (s *NexusIncomingServiceStore) executeBatch(
+	operation string,
+	batch gocql.Batch,
+	tableVersion int64,
+	serviceConflictErr error,
+) error {
+	previous := make(map[string]interface{})
+	applied, iter, err := s.session.MapExecuteBatchCAS(batch, previous)
+	if err != nil {
+		return gocql.ConvertError(operation, err)
+	}
+	if err := iter.Close(); err != nil {
+		return gocql.ConvertError(operation, err)
+	}
+	if applied {
+		return nil
+	}
+
+	if rowType, ok := previous["type"].(int); ok && rowType == rowTypeNexusIncomingServicesPartitionStatus {
+		if version, ok := previous["version"].(int64); !ok || version != tableVersion {
+			return p.ErrNexusTableVersionConflict
+		}
+	}
+	return serviceConflictErr
+}
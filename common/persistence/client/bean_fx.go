@@ -47,6 +47,7 @@ var BeanDepsModule = fx.Provide(
 	ShardManagerProvider,
 	ExecutionManagerProvider,
 	HistoryTaskQueueManagerProvider,
+	NexusIncomingServiceManagerProvider,
 )
 
 func BeanProvider(
@@ -94,6 +95,10 @@ func HistoryTaskQueueManagerProvider(factory Factory) (persistence.HistoryTaskQu
 	return factory.NewHistoryTaskQueueManager()
 }
 
+func NexusIncomingServiceManagerProvider(factory Factory) (persistence.NexusIncomingServiceManager, error) {
+	return factory.NewNexusIncomingServiceManager()
+}
+
 func BeanLifetimeHooks(
 	lc fx.Lifecycle,
 	bean Bean,
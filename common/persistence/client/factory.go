@@ -60,6 +60,8 @@ type (
 		NewClusterMetadataManager() (p.ClusterMetadataManager, error)
 		// NewHistoryTaskQueueManager returns a new manager for history task queues
 		NewHistoryTaskQueueManager() (p.HistoryTaskQueueManager, error)
+		// NewNexusIncomingServiceManager returns a new manager for nexus incoming services
+		NewNexusIncomingServiceManager() (p.NexusIncomingServiceManager, error)
 	}
 
 	factoryImpl struct {
@@ -222,6 +224,24 @@ func (f *factoryImpl) NewHistoryTaskQueueManager() (p.HistoryTaskQueueManager, e
 	return p.NewHistoryTaskQueueManager(q), nil
 }
 
+// NewNexusIncomingServiceManager returns a new nexus incoming service manager
+func (f *factoryImpl) NewNexusIncomingServiceManager() (p.NexusIncomingServiceManager, error) {
+	store, err := f.dataStoreFactory.NewNexusIncomingServiceStore()
+	if err != nil {
+		return nil, err
+	}
+
+	result := p.NewNexusIncomingServiceManager(store, f.serializer, f.logger)
+	if f.ratelimiter != nil {
+		result = p.NewNexusIncomingServicePersistenceRateLimitedClient(result, f.ratelimiter, f.logger)
+	}
+	if f.metricsHandler != nil && f.healthSignals != nil {
+		result = p.NewNexusIncomingServicePersistenceMetricsClient(result, f.metricsHandler, f.healthSignals, f.logger)
+	}
+	result = p.NewNexusIncomingServicePersistenceRetryableClient(result, retryPolicy, IsPersistenceTransientError)
+	return result, nil
+}
+
 // Close closes this factory
 func (f *factoryImpl) Close() {
 	f.dataStoreFactory.Close()
@@ -49,6 +49,7 @@ type (
 		Queue          *FaultInjectionQueue
 		QueueV2        *FaultInjectionQueueV2
 		ClusterMDStore *FaultInjectionClusterMetadataStore
+		NexusStore     *FaultInjectionNexusIncomingServiceStore
 	}
 
 	FaultInjectionShardStore struct {
@@ -71,6 +72,11 @@ type (
 		ErrorGenerator ErrorGenerator
 	}
 
+	FaultInjectionNexusIncomingServiceStore struct {
+		baseNexusStore persistence.NexusIncomingServiceStore
+		ErrorGenerator ErrorGenerator
+	}
+
 	FaultInjectionExecutionStore struct {
 		persistence.HistoryBranchUtilImpl
 		baseExecutionStore persistence.ExecutionStore
@@ -294,6 +300,24 @@ func (d *FaultInjectionDataStoreFactory) NewClusterMetadataStore() (persistence.
 	return d.ClusterMDStore, nil
 }
 
+func (d *FaultInjectionDataStoreFactory) NewNexusIncomingServiceStore() (persistence.NexusIncomingServiceStore, error) {
+	if d.NexusStore == nil {
+		baseStore, err := d.baseFactory.NewNexusIncomingServiceStore()
+		if err != nil {
+			return nil, err
+		}
+		if storeConfig, ok := d.config.Targets.DataStores[config.NexusIncomingServiceStoreName]; ok {
+			d.NexusStore = &FaultInjectionNexusIncomingServiceStore{
+				baseNexusStore: baseStore,
+				ErrorGenerator: NewTargetedDataStoreErrorGenerator(&storeConfig),
+			}
+		} else {
+			d.NexusStore = NewFaultInjectionNexusIncomingServiceStore(d.ErrorGenerator.Rate(), baseStore)
+		}
+	}
+	return d.NexusStore, nil
+}
+
 func NewFaultInjectionQueue(rate float64, baseQueue persistence.Queue) (*FaultInjectionQueue, error) {
 	errorGenerator := newErrorGenerator(rate,
 		append(defaultErrors,
@@ -881,6 +905,69 @@ func (c *FaultInjectionClusterMetadataStore) UpdateRate(rate float64) {
 	c.ErrorGenerator.UpdateRate(rate)
 }
 
+func NewFaultInjectionNexusIncomingServiceStore(
+	rate float64,
+	baseStore persistence.NexusIncomingServiceStore,
+) *FaultInjectionNexusIncomingServiceStore {
+	errorGenerator := newErrorGenerator(rate, defaultErrors)
+	return &FaultInjectionNexusIncomingServiceStore{
+		baseNexusStore: baseStore,
+		ErrorGenerator: errorGenerator,
+	}
+}
+
+func (n *FaultInjectionNexusIncomingServiceStore) Close() {
+	n.baseNexusStore.Close()
+}
+
+func (n *FaultInjectionNexusIncomingServiceStore) GetName() string {
+	return n.baseNexusStore.GetName()
+}
+
+func (n *FaultInjectionNexusIncomingServiceStore) GetNexusIncomingService(
+	ctx context.Context,
+	request *persistence.GetNexusIncomingServiceRequest,
+) (*persistence.InternalNexusIncomingService, error) {
+	if err := n.ErrorGenerator.Generate(); err != nil {
+		return nil, err
+	}
+	return n.baseNexusStore.GetNexusIncomingService(ctx, request)
+}
+
+func (n *FaultInjectionNexusIncomingServiceStore) ListNexusIncomingServices(
+	ctx context.Context,
+	request *persistence.ListNexusIncomingServicesRequest,
+) (*persistence.InternalListNexusIncomingServicesResponse, error) {
+	if err := n.ErrorGenerator.Generate(); err != nil {
+		return nil, err
+	}
+	return n.baseNexusStore.ListNexusIncomingServices(ctx, request)
+}
+
+func (n *FaultInjectionNexusIncomingServiceStore) CreateOrUpdateNexusIncomingService(
+	ctx context.Context,
+	request *persistence.InternalCreateOrUpdateNexusIncomingServiceRequest,
+) error {
+	if err := n.ErrorGenerator.Generate(); err != nil {
+		return err
+	}
+	return n.baseNexusStore.CreateOrUpdateNexusIncomingService(ctx, request)
+}
+
+func (n *FaultInjectionNexusIncomingServiceStore) DeleteNexusIncomingService(
+	ctx context.Context,
+	request *persistence.DeleteNexusIncomingServiceRequest,
+) error {
+	if err := n.ErrorGenerator.Generate(); err != nil {
+		return err
+	}
+	return n.baseNexusStore.DeleteNexusIncomingService(ctx, request)
+}
+
+func (n *FaultInjectionNexusIncomingServiceStore) UpdateRate(rate float64) {
+	n.ErrorGenerator.UpdateRate(rate)
+}
+
 func NewFaultInjectionMetadataStore(
 	rate float64,
 	metadataStore persistence.MetadataStore,
@@ -976,6 +976,45 @@ type (
 		ClusterName string
 	}
 
+	// GetNexusIncomingServiceRequest is the request to GetNexusIncomingService
+	GetNexusIncomingServiceRequest struct {
+		Name string
+	}
+
+	// ListNexusIncomingServicesRequest is the request to ListNexusIncomingServices
+	ListNexusIncomingServicesRequest struct {
+		// LastKnownTableVersion, when non-zero, fails the request with ErrNexusTableVersionConflict if the
+		// table has been modified since the given version was observed.
+		LastKnownTableVersion int64
+		NextPageToken         []byte
+		PageSize              int
+	}
+
+	// ListNexusIncomingServicesResponse is the response to ListNexusIncomingServices
+	ListNexusIncomingServicesResponse struct {
+		TableVersion  int64
+		NextPageToken []byte
+		Entries       []*persistencespb.NexusIncomingServiceEntry
+	}
+
+	// CreateOrUpdateNexusIncomingServiceRequest is the request to CreateOrUpdateNexusIncomingService.
+	// A new service is created when Entry.Version is 0, otherwise Entry.Version must match the persisted version.
+	CreateOrUpdateNexusIncomingServiceRequest struct {
+		LastKnownTableVersion int64
+		Entry                 *persistencespb.NexusIncomingServiceEntry
+	}
+
+	// CreateOrUpdateNexusIncomingServiceResponse is the response to CreateOrUpdateNexusIncomingService
+	CreateOrUpdateNexusIncomingServiceResponse struct {
+		Version int64
+	}
+
+	// DeleteNexusIncomingServiceRequest is the request to DeleteNexusIncomingService
+	DeleteNexusIncomingServiceRequest struct {
+		LastKnownTableVersion int64
+		Name                  string
+	}
+
 	// GetClusterMembersRequest is the request to GetClusterMembers
 	GetClusterMembersRequest struct {
 		LastHeartbeatWithin time.Duration
@@ -1161,6 +1200,17 @@ type (
 		DeleteClusterMetadata(ctx context.Context, request *DeleteClusterMetadataRequest) error
 	}
 
+	// NexusIncomingServiceManager is used to manage CRUD for Nexus incoming services.
+	// Every mutation bumps the version of the individual service as well as the version of the table as a whole.
+	NexusIncomingServiceManager interface {
+		Closeable
+		GetName() string
+		GetNexusIncomingService(ctx context.Context, request *GetNexusIncomingServiceRequest) (*persistencespb.NexusIncomingServiceEntry, error)
+		ListNexusIncomingServices(ctx context.Context, request *ListNexusIncomingServicesRequest) (*ListNexusIncomingServicesResponse, error)
+		CreateOrUpdateNexusIncomingService(ctx context.Context, request *CreateOrUpdateNexusIncomingServiceRequest) (*CreateOrUpdateNexusIncomingServiceResponse, error)
+		DeleteNexusIncomingService(ctx context.Context, request *DeleteNexusIncomingServiceRequest) error
+	}
+
 	// HistoryTaskQueueManager is responsible for managing a queue of internal history tasks. This is called a history
 	// task queue manager, but the actual history task queues are not managed by this object. Instead, this object is
 	// responsible for managing a generic queue of history tasks (which is what the history task DLQ is).
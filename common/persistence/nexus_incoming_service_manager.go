@@ -0,0 +1,174 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence/serialization"
+)
+
+const (
+	nexusIncomingServiceEncoding = enumspb.ENCODING_TYPE_PROTO3
+)
+
+var (
+	// ErrNexusTableVersionConflict is returned when the nexus incoming services table was modified since the version
+	// provided by the caller was observed.
+	ErrNexusTableVersionConflict = &ConditionFailedError{Msg: "nexus incoming services table version mismatch"}
+	// ErrNexusIncomingServiceVersionConflict is returned when a nexus incoming service is created but already exists,
+	// or is updated with a version that does not match the persisted one.
+	ErrNexusIncomingServiceVersionConflict = &ConditionFailedError{Msg: "nexus incoming service version mismatch"}
+)
+
+type (
+	// nexusIncomingServiceManagerImpl implements NexusIncomingServiceManager based on NexusIncomingServiceStore and Serializer
+	nexusIncomingServiceManagerImpl struct {
+		serializer  serialization.Serializer
+		persistence NexusIncomingServiceStore
+		logger      log.Logger
+	}
+)
+
+var _ NexusIncomingServiceManager = (*nexusIncomingServiceManagerImpl)(nil)
+
+// NewNexusIncomingServiceManager returns new NexusIncomingServiceManager
+func NewNexusIncomingServiceManager(
+	persistence NexusIncomingServiceStore,
+	serializer serialization.Serializer,
+	logger log.Logger,
+) NexusIncomingServiceManager {
+	return &nexusIncomingServiceManagerImpl{
+		serializer:  serializer,
+		persistence: persistence,
+		logger:      logger,
+	}
+}
+
+func (m *nexusIncomingServiceManagerImpl) GetName() string {
+	return m.persistence.GetName()
+}
+
+func (m *nexusIncomingServiceManagerImpl) Close() {
+	m.persistence.Close()
+}
+
+func (m *nexusIncomingServiceManagerImpl) GetNexusIncomingService(
+	ctx context.Context,
+	request *GetNexusIncomingServiceRequest,
+) (*persistencespb.NexusIncomingServiceEntry, error) {
+	internalService, err := m.persistence.GetNexusIncomingService(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return m.toEntry(internalService)
+}
+
+func (m *nexusIncomingServiceManagerImpl) ListNexusIncomingServices(
+	ctx context.Context,
+	request *ListNexusIncomingServicesRequest,
+) (*ListNexusIncomingServicesResponse, error) {
+	if request.PageSize <= 0 {
+		return nil, serviceerror.NewInvalidArgument("page size must be positive")
+	}
+
+	resp, err := m.persistence.ListNexusIncomingServices(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*persistencespb.NexusIncomingServiceEntry, 0, len(resp.Services))
+	for i := range resp.Services {
+		entry, err := m.toEntry(&resp.Services[i])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return &ListNexusIncomingServicesResponse{
+		TableVersion:  resp.TableVersion,
+		NextPageToken: resp.NextPageToken,
+		Entries:       entries,
+	}, nil
+}
+
+func (m *nexusIncomingServiceManagerImpl) CreateOrUpdateNexusIncomingService(
+	ctx context.Context,
+	request *CreateOrUpdateNexusIncomingServiceRequest,
+) (*CreateOrUpdateNexusIncomingServiceResponse, error) {
+	if request.Entry.GetName() == "" {
+		return nil, serviceerror.NewInvalidArgument("nexus incoming service name must not be empty")
+	}
+	if request.Entry.GetVersion() < 0 {
+		return nil, serviceerror.NewInvalidArgument("nexus incoming service version must not be negative")
+	}
+
+	blob, err := m.serializer.NexusIncomingServiceToBlob(request.Entry.Service, nexusIncomingServiceEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.persistence.CreateOrUpdateNexusIncomingService(ctx, &InternalCreateOrUpdateNexusIncomingServiceRequest{
+		LastKnownTableVersion: request.LastKnownTableVersion,
+		Service: InternalNexusIncomingService{
+			Name:    request.Entry.Name,
+			Version: request.Entry.Version,
+			Data:    blob,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CreateOrUpdateNexusIncomingServiceResponse{Version: request.Entry.Version + 1}, nil
+}
+
+func (m *nexusIncomingServiceManagerImpl) DeleteNexusIncomingService(
+	ctx context.Context,
+	request *DeleteNexusIncomingServiceRequest,
+) error {
+	if request.Name == "" {
+		return serviceerror.NewInvalidArgument("nexus incoming service name must not be empty")
+	}
+	return m.persistence.DeleteNexusIncomingService(ctx, request)
+}
+
+func (m *nexusIncomingServiceManagerImpl) toEntry(
+	internalService *InternalNexusIncomingService,
+) (*persistencespb.NexusIncomingServiceEntry, error) {
+	service, err := m.serializer.NexusIncomingServiceFromBlob(internalService.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &persistencespb.NexusIncomingServiceEntry{
+		Version: internalService.Version,
+		Name:    internalService.Name,
+		Service: service,
+	}, nil
+}
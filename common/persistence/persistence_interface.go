@@ -110,6 +110,16 @@ type (
 		PruneClusterMembership(ctx context.Context, request *PruneClusterMembershipRequest) error
 	}
 
+	// NexusIncomingServiceStore is a lower level of NexusIncomingServiceManager
+	NexusIncomingServiceStore interface {
+		Closeable
+		GetName() string
+		GetNexusIncomingService(ctx context.Context, request *GetNexusIncomingServiceRequest) (*InternalNexusIncomingService, error)
+		ListNexusIncomingServices(ctx context.Context, request *ListNexusIncomingServicesRequest) (*InternalListNexusIncomingServicesResponse, error)
+		CreateOrUpdateNexusIncomingService(ctx context.Context, request *InternalCreateOrUpdateNexusIncomingServiceRequest) error
+		DeleteNexusIncomingService(ctx context.Context, request *DeleteNexusIncomingServiceRequest) error
+	}
+
 	// ExecutionStore is used to manage workflow execution including mutable states / history / tasks.
 	ExecutionStore interface {
 		Closeable
@@ -727,6 +737,27 @@ type (
 		ClusterName string
 	}
 
+	// InternalNexusIncomingService is the persisted representation of a Nexus incoming service
+	InternalNexusIncomingService struct {
+		Name    string
+		Version int64
+		// Serialized NexusIncomingService.
+		Data *commonpb.DataBlob
+	}
+
+	// InternalListNexusIncomingServicesResponse is the response for ListNexusIncomingServices
+	InternalListNexusIncomingServicesResponse struct {
+		TableVersion  int64
+		NextPageToken []byte
+		Services      []InternalNexusIncomingService
+	}
+
+	// InternalCreateOrUpdateNexusIncomingServiceRequest is the request for CreateOrUpdateNexusIncomingService
+	InternalCreateOrUpdateNexusIncomingServiceRequest struct {
+		LastKnownTableVersion int64
+		Service               InternalNexusIncomingService
+	}
+
 	// InternalUpsertClusterMembershipRequest is the request to UpsertClusterMembership
 	InternalUpsertClusterMembershipRequest struct {
 		ClusterMember
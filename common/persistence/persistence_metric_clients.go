@@ -31,6 +31,7 @@ import (
 
 	commonpb "go.temporal.io/api/common/v1"
 	"go.temporal.io/api/serviceerror"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common/headers"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
@@ -79,6 +80,12 @@ type (
 		healthSignals HealthSignalAggregator
 		persistence   Queue
 	}
+
+	nexusIncomingServicePersistenceClient struct {
+		metricEmitter
+		healthSignals HealthSignalAggregator
+		persistence   NexusIncomingServiceManager
+	}
 )
 
 var _ ShardManager = (*shardPersistenceClient)(nil)
@@ -87,6 +94,7 @@ var _ TaskManager = (*taskPersistenceClient)(nil)
 var _ MetadataManager = (*metadataPersistenceClient)(nil)
 var _ ClusterMetadataManager = (*clusterMetadataPersistenceClient)(nil)
 var _ Queue = (*queuePersistenceClient)(nil)
+var _ NexusIncomingServiceManager = (*nexusIncomingServicePersistenceClient)(nil)
 
 // NewShardPersistenceMetricsClient creates a client to manage shards
 func NewShardPersistenceMetricsClient(persistence ShardManager, metricsHandler metrics.Handler, healthSignals HealthSignalAggregator, logger log.Logger) ShardManager {
@@ -160,6 +168,18 @@ func NewQueuePersistenceMetricsClient(persistence Queue, metricsHandler metrics.
 	}
 }
 
+// NewNexusIncomingServicePersistenceMetricsClient creates a NexusIncomingServiceManager client to manage nexus incoming services
+func NewNexusIncomingServicePersistenceMetricsClient(persistence NexusIncomingServiceManager, metricsHandler metrics.Handler, healthSignals HealthSignalAggregator, logger log.Logger) NexusIncomingServiceManager {
+	return &nexusIncomingServicePersistenceClient{
+		metricEmitter: metricEmitter{
+			metricsHandler: metricsHandler,
+			logger:         logger,
+		},
+		healthSignals: healthSignals,
+		persistence:   persistence,
+	}
+}
+
 func (p *shardPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
@@ -1210,6 +1230,66 @@ func (p *metadataPersistenceClient) InitializeSystemNamespaces(
 	return p.persistence.InitializeSystemNamespaces(ctx, currentClusterName)
 }
 
+func (p *nexusIncomingServicePersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *nexusIncomingServicePersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *nexusIncomingServicePersistenceClient) GetNexusIncomingService(
+	ctx context.Context,
+	request *GetNexusIncomingServiceRequest,
+) (_ *persistencespb.NexusIncomingServiceEntry, retErr error) {
+	caller := headers.GetCallerInfo(ctx).CallerName
+	startTime := time.Now().UTC()
+	defer func() {
+		p.healthSignals.Record(CallerSegmentMissing, caller, time.Since(startTime), retErr)
+		p.recordRequestMetrics(metrics.PersistenceGetNexusIncomingServiceScope, caller, time.Since(startTime), retErr)
+	}()
+	return p.persistence.GetNexusIncomingService(ctx, request)
+}
+
+func (p *nexusIncomingServicePersistenceClient) ListNexusIncomingServices(
+	ctx context.Context,
+	request *ListNexusIncomingServicesRequest,
+) (_ *ListNexusIncomingServicesResponse, retErr error) {
+	caller := headers.GetCallerInfo(ctx).CallerName
+	startTime := time.Now().UTC()
+	defer func() {
+		p.healthSignals.Record(CallerSegmentMissing, caller, time.Since(startTime), retErr)
+		p.recordRequestMetrics(metrics.PersistenceListNexusIncomingServicesScope, caller, time.Since(startTime), retErr)
+	}()
+	return p.persistence.ListNexusIncomingServices(ctx, request)
+}
+
+func (p *nexusIncomingServicePersistenceClient) CreateOrUpdateNexusIncomingService(
+	ctx context.Context,
+	request *CreateOrUpdateNexusIncomingServiceRequest,
+) (_ *CreateOrUpdateNexusIncomingServiceResponse, retErr error) {
+	caller := headers.GetCallerInfo(ctx).CallerName
+	startTime := time.Now().UTC()
+	defer func() {
+		p.healthSignals.Record(CallerSegmentMissing, caller, time.Since(startTime), retErr)
+		p.recordRequestMetrics(metrics.PersistenceCreateOrUpdateNexusIncomingServiceScope, caller, time.Since(startTime), retErr)
+	}()
+	return p.persistence.CreateOrUpdateNexusIncomingService(ctx, request)
+}
+
+func (p *nexusIncomingServicePersistenceClient) DeleteNexusIncomingService(
+	ctx context.Context,
+	request *DeleteNexusIncomingServiceRequest,
+) (retErr error) {
+	caller := headers.GetCallerInfo(ctx).CallerName
+	startTime := time.Now().UTC()
+	defer func() {
+		p.healthSignals.Record(CallerSegmentMissing, caller, time.Since(startTime), retErr)
+		p.recordRequestMetrics(metrics.PersistenceDeleteNexusIncomingServiceScope, caller, time.Since(startTime), retErr)
+	}()
+	return p.persistence.DeleteNexusIncomingService(ctx, request)
+}
+
 func (p *metricEmitter) recordRequestMetrics(operation string, caller string, latency time.Duration, err error) {
 	handler := p.metricsHandler.WithTags(metrics.OperationTag(operation), metrics.NamespaceTag(caller))
 	handler.Counter(metrics.PersistenceRequests.Name()).Record(1)
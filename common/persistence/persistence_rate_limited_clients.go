@@ -31,6 +31,7 @@ import (
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common/headers"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/quotas"
@@ -83,6 +84,12 @@ type (
 		persistence Queue
 		logger      log.Logger
 	}
+
+	nexusIncomingServiceRateLimitedPersistenceClient struct {
+		rateLimiter quotas.RequestRateLimiter
+		persistence NexusIncomingServiceManager
+		logger      log.Logger
+	}
 )
 
 var _ ShardManager = (*shardRateLimitedPersistenceClient)(nil)
@@ -91,6 +98,7 @@ var _ TaskManager = (*taskRateLimitedPersistenceClient)(nil)
 var _ MetadataManager = (*metadataRateLimitedPersistenceClient)(nil)
 var _ ClusterMetadataManager = (*clusterMetadataRateLimitedPersistenceClient)(nil)
 var _ Queue = (*queueRateLimitedPersistenceClient)(nil)
+var _ NexusIncomingServiceManager = (*nexusIncomingServiceRateLimitedPersistenceClient)(nil)
 
 // NewShardPersistenceRateLimitedClient creates a client to manage shards
 func NewShardPersistenceRateLimitedClient(persistence ShardManager, rateLimiter quotas.RequestRateLimiter, logger log.Logger) ShardManager {
@@ -146,6 +154,15 @@ func NewQueuePersistenceRateLimitedClient(persistence Queue, rateLimiter quotas.
 	}
 }
 
+// NewNexusIncomingServicePersistenceRateLimitedClient creates a client to manage nexus incoming services
+func NewNexusIncomingServicePersistenceRateLimitedClient(persistence NexusIncomingServiceManager, rateLimiter quotas.RequestRateLimiter, logger log.Logger) NexusIncomingServiceManager {
+	return &nexusIncomingServiceRateLimitedPersistenceClient{
+		persistence: persistence,
+		rateLimiter: rateLimiter,
+		logger:      logger,
+	}
+}
+
 func (p *shardRateLimitedPersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
@@ -1014,6 +1031,54 @@ func (c *clusterMetadataRateLimitedPersistenceClient) DeleteClusterMetadata(
 	return c.persistence.DeleteClusterMetadata(ctx, request)
 }
 
+func (c *nexusIncomingServiceRateLimitedPersistenceClient) Close() {
+	c.persistence.Close()
+}
+
+func (c *nexusIncomingServiceRateLimitedPersistenceClient) GetName() string {
+	return c.persistence.GetName()
+}
+
+func (c *nexusIncomingServiceRateLimitedPersistenceClient) GetNexusIncomingService(
+	ctx context.Context,
+	request *GetNexusIncomingServiceRequest,
+) (*persistencespb.NexusIncomingServiceEntry, error) {
+	if ok := allow(ctx, "GetNexusIncomingService", CallerSegmentMissing, c.rateLimiter); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+	return c.persistence.GetNexusIncomingService(ctx, request)
+}
+
+func (c *nexusIncomingServiceRateLimitedPersistenceClient) ListNexusIncomingServices(
+	ctx context.Context,
+	request *ListNexusIncomingServicesRequest,
+) (*ListNexusIncomingServicesResponse, error) {
+	if ok := allow(ctx, "ListNexusIncomingServices", CallerSegmentMissing, c.rateLimiter); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+	return c.persistence.ListNexusIncomingServices(ctx, request)
+}
+
+func (c *nexusIncomingServiceRateLimitedPersistenceClient) CreateOrUpdateNexusIncomingService(
+	ctx context.Context,
+	request *CreateOrUpdateNexusIncomingServiceRequest,
+) (*CreateOrUpdateNexusIncomingServiceResponse, error) {
+	if ok := allow(ctx, "CreateOrUpdateNexusIncomingService", CallerSegmentMissing, c.rateLimiter); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+	return c.persistence.CreateOrUpdateNexusIncomingService(ctx, request)
+}
+
+func (c *nexusIncomingServiceRateLimitedPersistenceClient) DeleteNexusIncomingService(
+	ctx context.Context,
+	request *DeleteNexusIncomingServiceRequest,
+) error {
+	if ok := allow(ctx, "DeleteNexusIncomingService", CallerSegmentMissing, c.rateLimiter); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+	return c.persistence.DeleteNexusIncomingService(ctx, request)
+}
+
 func allow(
 	ctx context.Context,
 	api string,
@@ -29,6 +29,7 @@ import (
 
 	commonpb "go.temporal.io/api/common/v1"
 
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common/backoff"
 )
 
@@ -68,6 +69,12 @@ type (
 		policy      backoff.RetryPolicy
 		isRetryable backoff.IsRetryable
 	}
+
+	nexusIncomingServiceRetryablePersistenceClient struct {
+		persistence NexusIncomingServiceManager
+		policy      backoff.RetryPolicy
+		isRetryable backoff.IsRetryable
+	}
 )
 
 var _ ShardManager = (*shardRetryablePersistenceClient)(nil)
@@ -76,6 +83,7 @@ var _ TaskManager = (*taskRetryablePersistenceClient)(nil)
 var _ MetadataManager = (*metadataRetryablePersistenceClient)(nil)
 var _ ClusterMetadataManager = (*clusterMetadataRetryablePersistenceClient)(nil)
 var _ Queue = (*queueRetryablePersistenceClient)(nil)
+var _ NexusIncomingServiceManager = (*nexusIncomingServiceRetryablePersistenceClient)(nil)
 
 // NewShardPersistenceRetryableClient creates a client to manage shards
 func NewShardPersistenceRetryableClient(
@@ -155,6 +163,19 @@ func NewQueuePersistenceRetryableClient(
 	}
 }
 
+// NewNexusIncomingServicePersistenceRetryableClient creates a client to manage nexus incoming services
+func NewNexusIncomingServicePersistenceRetryableClient(
+	persistence NexusIncomingServiceManager,
+	policy backoff.RetryPolicy,
+	isRetryable backoff.IsRetryable,
+) NexusIncomingServiceManager {
+	return &nexusIncomingServiceRetryablePersistenceClient{
+		persistence: persistence,
+		policy:      policy,
+		isRetryable: isRetryable,
+	}
+}
+
 func (p *shardRetryablePersistenceClient) GetName() string {
 	return p.persistence.GetName()
 }
@@ -1201,3 +1222,67 @@ func (p *queueRetryablePersistenceClient) DeleteMessageFromDLQ(
 func (p *queueRetryablePersistenceClient) Close() {
 	p.persistence.Close()
 }
+
+func (p *nexusIncomingServiceRetryablePersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *nexusIncomingServiceRetryablePersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *nexusIncomingServiceRetryablePersistenceClient) GetNexusIncomingService(
+	ctx context.Context,
+	request *GetNexusIncomingServiceRequest,
+) (*persistencespb.NexusIncomingServiceEntry, error) {
+	var response *persistencespb.NexusIncomingServiceEntry
+	op := func(ctx context.Context) error {
+		var err error
+		response, err = p.persistence.GetNexusIncomingService(ctx, request)
+		return err
+	}
+
+	err := backoff.ThrottleRetryContext(ctx, op, p.policy, p.isRetryable)
+	return response, err
+}
+
+func (p *nexusIncomingServiceRetryablePersistenceClient) ListNexusIncomingServices(
+	ctx context.Context,
+	request *ListNexusIncomingServicesRequest,
+) (*ListNexusIncomingServicesResponse, error) {
+	var response *ListNexusIncomingServicesResponse
+	op := func(ctx context.Context) error {
+		var err error
+		response, err = p.persistence.ListNexusIncomingServices(ctx, request)
+		return err
+	}
+
+	err := backoff.ThrottleRetryContext(ctx, op, p.policy, p.isRetryable)
+	return response, err
+}
+
+func (p *nexusIncomingServiceRetryablePersistenceClient) CreateOrUpdateNexusIncomingService(
+	ctx context.Context,
+	request *CreateOrUpdateNexusIncomingServiceRequest,
+) (*CreateOrUpdateNexusIncomingServiceResponse, error) {
+	var response *CreateOrUpdateNexusIncomingServiceResponse
+	op := func(ctx context.Context) error {
+		var err error
+		response, err = p.persistence.CreateOrUpdateNexusIncomingService(ctx, request)
+		return err
+	}
+
+	err := backoff.ThrottleRetryContext(ctx, op, p.policy, p.isRetryable)
+	return response, err
+}
+
+func (p *nexusIncomingServiceRetryablePersistenceClient) DeleteNexusIncomingService(
+	ctx context.Context,
+	request *DeleteNexusIncomingServiceRequest,
+) error {
+	op := func(ctx context.Context) error {
+		return p.persistence.DeleteNexusIncomingService(ctx, request)
+	}
+
+	return backoff.ThrottleRetryContext(ctx, op, p.policy, p.isRetryable)
+}
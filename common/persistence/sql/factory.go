@@ -120,6 +120,15 @@ func (f *Factory) NewClusterMetadataStore() (p.ClusterMetadataStore, error) {
 	return newClusterMetadataPersistence(conn, f.logger)
 }
 
+// NewNexusIncomingServiceStore returns a new NexusIncomingService store
+func (f *Factory) NewNexusIncomingServiceStore() (p.NexusIncomingServiceStore, error) {
+	conn, err := f.mainDBConn.Get()
+	if err != nil {
+		return nil, err
+	}
+	return newNexusIncomingServicePersistence(conn, f.logger)
+}
+
 // NewExecutionStore returns a new ExecutionStore
 func (f *Factory) NewExecutionStore() (p.ExecutionStore, error) {
 	conn, err := f.mainDBConn.Get()
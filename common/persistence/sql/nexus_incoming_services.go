@@ -0,0 +1,218 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/common/log"
+	p "go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+type sqlNexusIncomingServiceStore struct {
+	SqlStore
+}
+
+var _ p.NexusIncomingServiceStore = (*sqlNexusIncomingServiceStore)(nil)
+
+func newNexusIncomingServicePersistence(
+	db sqlplugin.DB,
+	logger log.Logger,
+) (p.NexusIncomingServiceStore, error) {
+	return &sqlNexusIncomingServiceStore{
+		SqlStore: NewSqlStore(db, logger),
+	}, nil
+}
+
+func (s *sqlNexusIncomingServiceStore) GetNexusIncomingService(
+	ctx context.Context,
+	request *p.GetNexusIncomingServiceRequest,
+) (*p.InternalNexusIncomingService, error) {
+	row, err := s.Db.GetNexusIncomingService(ctx, request.Name)
+	if err != nil {
+		return nil, convertCommonErrors("GetNexusIncomingService", err)
+	}
+	return newInternalNexusIncomingService(row), nil
+}
+
+func (s *sqlNexusIncomingServiceStore) ListNexusIncomingServices(
+	ctx context.Context,
+	request *p.ListNexusIncomingServicesRequest,
+) (*p.InternalListNexusIncomingServicesResponse, error) {
+	var lastServiceName string
+	if len(request.NextPageToken) != 0 {
+		if err := gobDeserialize(request.NextPageToken, &lastServiceName); err != nil {
+			return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("error deserializing page token: %v", err))
+		}
+	}
+
+	var resp *p.InternalListNexusIncomingServicesResponse
+	err := s.txExecute(ctx, "ListNexusIncomingServices", func(tx sqlplugin.Tx) error {
+		tableVersion, err := tx.GetNexusIncomingServicesTableVersion(ctx)
+		if err != nil {
+			return convertCommonErrors("ListNexusIncomingServices", err)
+		}
+		if request.LastKnownTableVersion != 0 && request.LastKnownTableVersion != tableVersion {
+			return p.ErrNexusTableVersionConflict
+		}
+
+		rows, err := tx.ListNexusIncomingServices(ctx, &sqlplugin.ListNexusIncomingServicesRequest{
+			LastServiceName: lastServiceName,
+			Limit:           request.PageSize,
+		})
+		if err != nil && err != sql.ErrNoRows {
+			return convertCommonErrors("ListNexusIncomingServices", err)
+		}
+
+		resp = &p.InternalListNexusIncomingServicesResponse{
+			TableVersion: tableVersion,
+			Services:     make([]p.InternalNexusIncomingService, 0, len(rows)),
+		}
+		for i := range rows {
+			resp.Services = append(resp.Services, *newInternalNexusIncomingService(&rows[i]))
+		}
+		if len(rows) >= request.PageSize {
+			nextPageToken, err := gobSerialize(rows[len(rows)-1].ServiceName)
+			if err != nil {
+				return err
+			}
+			resp.NextPageToken = nextPageToken
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *sqlNexusIncomingServiceStore) CreateOrUpdateNexusIncomingService(
+	ctx context.Context,
+	request *p.InternalCreateOrUpdateNexusIncomingServiceRequest,
+) error {
+	return s.txExecute(ctx, "CreateOrUpdateNexusIncomingService", func(tx sqlplugin.Tx) error {
+		if err := s.incrementTableVersion(ctx, tx, request.LastKnownTableVersion); err != nil {
+			return err
+		}
+
+		row := &sqlplugin.NexusIncomingServicesRow{
+			ServiceName:  request.Service.Name,
+			Version:      request.Service.Version,
+			Data:         request.Service.Data.Data,
+			DataEncoding: request.Service.Data.EncodingType.String(),
+		}
+		if request.Service.Version == 0 {
+			if _, err := tx.InsertIntoNexusIncomingServices(ctx, row); err != nil {
+				if s.Db.IsDupEntryError(err) {
+					return p.ErrNexusIncomingServiceVersionConflict
+				}
+				return convertCommonErrors("CreateOrUpdateNexusIncomingService", err)
+			}
+			return nil
+		}
+
+		result, err := tx.UpdateNexusIncomingService(ctx, row)
+		if err != nil {
+			return convertCommonErrors("CreateOrUpdateNexusIncomingService", err)
+		}
+		return checkNexusRowsAffected(result, p.ErrNexusIncomingServiceVersionConflict)
+	})
+}
+
+func (s *sqlNexusIncomingServiceStore) DeleteNexusIncomingService(
+	ctx context.Context,
+	request *p.DeleteNexusIncomingServiceRequest,
+) error {
+	return s.txExecute(ctx, "DeleteNexusIncomingService", func(tx sqlplugin.Tx) error {
+		if err := s.incrementTableVersion(ctx, tx, request.LastKnownTableVersion); err != nil {
+			return err
+		}
+
+		result, err := tx.DeleteFromNexusIncomingServices(ctx, request.Name)
+		if err != nil {
+			return convertCommonErrors("DeleteNexusIncomingService", err)
+		}
+		return checkNexusRowsAffected(
+			result,
+			serviceerror.NewNotFound(fmt.Sprintf("nexus incoming service %q not found", request.Name)),
+		)
+	})
+}
+
+// incrementTableVersion bumps the version of the nexus_incoming_services table within the given transaction. The
+// table version row is created lazily on the first mutation of the table.
+func (s *sqlNexusIncomingServiceStore) incrementTableVersion(
+	ctx context.Context,
+	tx sqlplugin.Tx,
+	lastKnownTableVersion int64,
+) error {
+	tableVersion, err := tx.GetNexusIncomingServicesTableVersion(ctx)
+	if err != nil {
+		return convertCommonErrors("GetNexusIncomingServicesTableVersion", err)
+	}
+	if lastKnownTableVersion != 0 && lastKnownTableVersion != tableVersion {
+		return p.ErrNexusTableVersionConflict
+	}
+
+	if tableVersion == 0 {
+		if _, err := tx.InitializeNexusIncomingServicesTableVersion(ctx); err != nil {
+			if s.Db.IsDupEntryError(err) {
+				return p.ErrNexusTableVersionConflict
+			}
+			return convertCommonErrors("InitializeNexusIncomingServicesTableVersion", err)
+		}
+		return nil
+	}
+
+	result, err := tx.IncrementNexusIncomingServicesTableVersion(ctx, tableVersion)
+	if err != nil {
+		return convertCommonErrors("IncrementNexusIncomingServicesTableVersion", err)
+	}
+	return checkNexusRowsAffected(result, p.ErrNexusTableVersionConflict)
+}
+
+func checkNexusRowsAffected(result sql.Result, noRowsErr error) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return serviceerror.NewUnavailable(fmt.Sprintf("failed to get rows affected. Error: %v", err))
+	}
+	if rowsAffected != 1 {
+		return noRowsErr
+	}
+	return nil
+}
+
+func newInternalNexusIncomingService(row *sqlplugin.NexusIncomingServicesRow) *p.InternalNexusIncomingService {
+	return &p.InternalNexusIncomingService{
+		Name:    row.ServiceName,
+		Version: row.Version,
+		Data:    p.NewDataBlob(row.Data, row.DataEncoding),
+	}
+}
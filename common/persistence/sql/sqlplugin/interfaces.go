@@ -59,6 +59,7 @@ type (
 		QueueMetadata
 		QueueV2Message
 		QueueV2Metadata
+		NexusIncomingServices
 
 		MatchingTask
 		MatchingTaskQueue
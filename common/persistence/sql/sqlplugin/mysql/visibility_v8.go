@@ -255,7 +255,7 @@ func (mdb *dbV8) CountGroupByFromVisibility(
 		return nil, err
 	}
 	defer rows.Close()
-	return sqlplugin.ParseCountGroupByRows(rows, filter.GroupBy)
+	return sqlplugin.ParseCountGroupByRows(rows, filter.GroupBy, filter.Aggregations)
 }
 
 func (mdb *dbV8) prepareRowForDB(row *sqlplugin.VisibilityRow) *sqlplugin.VisibilityRow {
@@ -0,0 +1,62 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sqlplugin
+
+import (
+	"context"
+	"database/sql"
+)
+
+type (
+	// NexusIncomingServicesRow represents a row in the nexus_incoming_services table
+	NexusIncomingServicesRow struct {
+		ServiceName  string
+		Version      int64
+		Data         []byte
+		DataEncoding string
+	}
+
+	// ListNexusIncomingServicesRequest is used to page through the nexus_incoming_services table
+	ListNexusIncomingServicesRequest struct {
+		// LastServiceName is exclusive. An empty name starts from the beginning of the table.
+		LastServiceName string
+		Limit           int
+	}
+
+	// NexusIncomingServices is the SQL persistence interface for Nexus incoming services
+	NexusIncomingServices interface {
+		InitializeNexusIncomingServicesTableVersion(ctx context.Context) (sql.Result, error)
+		IncrementNexusIncomingServicesTableVersion(ctx context.Context, lastKnownTableVersion int64) (sql.Result, error)
+		GetNexusIncomingServicesTableVersion(ctx context.Context) (int64, error)
+
+		InsertIntoNexusIncomingServices(ctx context.Context, row *NexusIncomingServicesRow) (sql.Result, error)
+		// UpdateNexusIncomingService updates the row matching both the service name and row.Version, and
+		// sets the version to row.Version+1.
+		UpdateNexusIncomingService(ctx context.Context, row *NexusIncomingServicesRow) (sql.Result, error)
+		GetNexusIncomingService(ctx context.Context, serviceName string) (*NexusIncomingServicesRow, error)
+		ListNexusIncomingServices(ctx context.Context, request *ListNexusIncomingServicesRequest) ([]NexusIncomingServicesRow, error)
+		DeleteFromNexusIncomingServices(ctx context.Context, serviceName string) (sql.Result, error)
+	}
+)
@@ -173,7 +173,7 @@ func (pdb *dbV12) CountGroupByFromVisibility(
 		return nil, err
 	}
 	defer rows.Close()
-	return sqlplugin.ParseCountGroupByRows(rows, filter.GroupBy)
+	return sqlplugin.ParseCountGroupByRows(rows, filter.GroupBy, filter.Aggregations)
 }
 
 func (pdb *dbV12) prepareRowForDB(row *sqlplugin.VisibilityRow) *sqlplugin.VisibilityRow {
@@ -0,0 +1,131 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
+)
+
+const (
+	getNexusIncomingServicesTableVersionQuery        = `SELECT version FROM nexus_incoming_services_partition_status WHERE id = 0`
+	initializeNexusIncomingServicesTableVersionQuery = `INSERT INTO nexus_incoming_services_partition_status(id, version) VALUES (0, 1)`
+	incrementNexusIncomingServicesTableVersionQuery  = `UPDATE nexus_incoming_services_partition_status SET version = ? WHERE id = 0 AND version = ?`
+
+	createNexusIncomingServiceQuery = `INSERT INTO nexus_incoming_services(service_name, data, data_encoding, version) VALUES (?, ?, ?, 1)`
+	updateNexusIncomingServiceQuery = `UPDATE nexus_incoming_services SET data = ?, data_encoding = ?, version = ? WHERE service_name = ? AND version = ?`
+	deleteNexusIncomingServiceQuery = `DELETE FROM nexus_incoming_services WHERE service_name = ?`
+	getNexusIncomingServiceQuery    = `SELECT service_name, data, data_encoding, version FROM nexus_incoming_services WHERE service_name = ?`
+	listNexusIncomingServicesQuery  = `SELECT service_name, data, data_encoding, version FROM nexus_incoming_services WHERE service_name > ? ORDER BY service_name LIMIT ?`
+)
+
+func (mdb *db) InitializeNexusIncomingServicesTableVersion(ctx context.Context) (sql.Result, error) {
+	return mdb.conn.ExecContext(ctx, initializeNexusIncomingServicesTableVersionQuery)
+}
+
+func (mdb *db) IncrementNexusIncomingServicesTableVersion(
+	ctx context.Context,
+	lastKnownTableVersion int64,
+) (sql.Result, error) {
+	return mdb.conn.ExecContext(ctx,
+		incrementNexusIncomingServicesTableVersionQuery,
+		lastKnownTableVersion+1,
+		lastKnownTableVersion,
+	)
+}
+
+func (mdb *db) GetNexusIncomingServicesTableVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := mdb.conn.GetContext(ctx, &version, getNexusIncomingServicesTableVersionQuery)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return version, err
+}
+
+func (mdb *db) InsertIntoNexusIncomingServices(
+	ctx context.Context,
+	row *sqlplugin.NexusIncomingServicesRow,
+) (sql.Result, error) {
+	return mdb.conn.ExecContext(ctx,
+		createNexusIncomingServiceQuery,
+		row.ServiceName,
+		row.Data,
+		row.DataEncoding,
+	)
+}
+
+func (mdb *db) UpdateNexusIncomingService(
+	ctx context.Context,
+	row *sqlplugin.NexusIncomingServicesRow,
+) (sql.Result, error) {
+	return mdb.conn.ExecContext(ctx,
+		updateNexusIncomingServiceQuery,
+		row.Data,
+		row.DataEncoding,
+		row.Version+1,
+		row.ServiceName,
+		row.Version,
+	)
+}
+
+func (mdb *db) GetNexusIncomingService(
+	ctx context.Context,
+	serviceName string,
+) (*sqlplugin.NexusIncomingServicesRow, error) {
+	var row sqlplugin.NexusIncomingServicesRow
+	err := mdb.conn.GetContext(ctx,
+		&row,
+		getNexusIncomingServiceQuery,
+		serviceName,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+func (mdb *db) ListNexusIncomingServices(
+	ctx context.Context,
+	request *sqlplugin.ListNexusIncomingServicesRequest,
+) ([]sqlplugin.NexusIncomingServicesRow, error) {
+	var rows []sqlplugin.NexusIncomingServicesRow
+	err := mdb.conn.SelectContext(ctx,
+		&rows,
+		listNexusIncomingServicesQuery,
+		request.LastServiceName,
+		request.Limit,
+	)
+	return rows, err
+}
+
+func (mdb *db) DeleteFromNexusIncomingServices(
+	ctx context.Context,
+	serviceName string,
+) (sql.Result, error) {
+	return mdb.conn.ExecContext(ctx, deleteNexusIncomingServiceQuery, serviceName)
+}
@@ -66,6 +66,10 @@ type (
 		SearchAttributes     *VisibilitySearchAttributes
 		ParentWorkflowID     *string
 		ParentRunID          *string
+
+		// Relevance is the full-text relevance score of the row. It's only selected by list
+		// queries filtering on Text search attributes and it's not stored in the table.
+		Relevance *float64 `stored:"false"`
 	}
 
 	// VisibilitySelectFilter contains the column names within executions_visibility table that
@@ -80,9 +84,16 @@ type (
 		MaxTime          *time.Time
 		PageSize         *int
 
-		Query     string
-		QueryArgs []interface{}
-		GroupBy   []string
+		Query        string
+		QueryArgs    []interface{}
+		GroupBy      []string
+		Aggregations []VisibilityAggregation
+	}
+
+	// VisibilityAggregation is an aggregate function selected by count query after the group by fields.
+	VisibilityAggregation struct {
+		Func      string
+		FieldName string
 	}
 
 	VisibilityGetFilter struct {
@@ -97,7 +108,9 @@ type (
 
 	VisibilityCountRow struct {
 		GroupValues []any
-		Count       int64
+		// AggregationValues are values of aggregate functions as returned by DB driver.
+		AggregationValues []any
+		Count             int64
 	}
 
 	Visibility interface {
@@ -148,9 +161,13 @@ func (vsa VisibilitySearchAttributes) Value() (driver.Value, error) {
 	return json.Marshal(vsa)
 }
 
-func ParseCountGroupByRows(rows *sql.Rows, groupBy []string) ([]VisibilityCountRow, error) {
-	// Number of columns is number of group by fields plus the count column.
-	rowValues := make([]any, len(groupBy)+1)
+func ParseCountGroupByRows(
+	rows *sql.Rows,
+	groupBy []string,
+	aggregations []VisibilityAggregation,
+) ([]VisibilityCountRow, error) {
+	// Number of columns is number of group by fields plus number of aggregations plus the count column.
+	rowValues := make([]any, len(groupBy)+len(aggregations)+1)
 	for i := range rowValues {
 		rowValues[i] = new(any)
 	}
@@ -168,10 +185,15 @@ func ParseCountGroupByRows(rows *sql.Rows, groupBy []string) ([]VisibilityCountR
 				return nil, err
 			}
 		}
+		aggregationValues := make([]any, len(aggregations))
+		for i := range aggregations {
+			aggregationValues[i] = *(rowValues[len(groupBy)+i].(*any))
+		}
 		count := *(rowValues[len(rowValues)-1].(*any))
 		res = append(res, VisibilityCountRow{
-			GroupValues: groupValues,
-			Count:       count.(int64),
+			GroupValues:       groupValues,
+			AggregationValues: aggregationValues,
+			Count:             count.(int64),
 		})
 	}
 	return res, nil
@@ -199,19 +221,27 @@ func parseCountGroupByGroupValue(fieldName string, value any) (any, error) {
 			)
 		}
 	default:
+		// Some drivers (eg: MySQL) return strings as bytes.
+		if bytesValue, isBytes := value.([]byte); isBytes {
+			return string(bytesValue), nil
+		}
 		return value, nil
 	}
 }
 
 func getDbFields() []string {
 	t := reflect.TypeOf(VisibilityRow{})
-	dbFields := make([]string, t.NumField())
+	dbFields := make([]string, 0, t.NumField())
 	for i := 0; i < t.NumField(); i++ {
 		f := t.Field(i)
-		dbFields[i] = f.Tag.Get("db")
-		if dbFields[i] == "" {
-			dbFields[i] = strcase.ToSnake(f.Name)
+		if f.Tag.Get("stored") == "false" {
+			continue
+		}
+		dbField := f.Tag.Get("db")
+		if dbField == "" {
+			dbField = strcase.ToSnake(f.Name)
 		}
+		dbFields = append(dbFields, dbField)
 	}
 	return dbFields
 }
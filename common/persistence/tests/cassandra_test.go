@@ -296,6 +296,18 @@ func TestCassandraQueueV2Persistence(t *testing.T) {
 	})
 }
 
+func TestCassandraNexusIncomingServicePersistence(t *testing.T) {
+	t.Parallel()
+
+	cluster := persistencetests.NewTestClusterForCassandra(&persistencetests.TestBaseOptions{}, log.NewNoopLogger())
+	cluster.SetupTestDatabase()
+	t.Cleanup(cluster.TearDownTestDatabase)
+
+	store, err := cassandra.NewNexusIncomingServiceStore(cluster.GetSession(), log.NewTestLogger())
+	require.NoError(t, err)
+	RunNexusIncomingServiceTestSuite(t, store)
+}
+
 func testCassandraQueueV2DataCorruption(t *testing.T, cluster *cassandra.TestCluster) {
 	t.Run("ErrInvalidQueueMessageEncodingType", func(t *testing.T) {
 		t.Parallel()
@@ -27,6 +27,7 @@ package tests
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	persistencetests "go.temporal.io/server/common/persistence/persistence-tests"
@@ -623,3 +624,11 @@ func TestMySQLQueueV2(t *testing.T) {
 	t.Cleanup(tearDown)
 	RunQueueV2TestSuiteForSQL(t, testData.Factory)
 }
+
+func TestMySQLNexusIncomingServicePersistence(t *testing.T) {
+	testData, tearDown := setUpMySQLTest(t)
+	t.Cleanup(tearDown)
+	store, err := testData.Factory.NewNexusIncomingServiceStore()
+	require.NoError(t, err)
+	RunNexusIncomingServiceTestSuite(t, store)
+}
@@ -0,0 +1,216 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+)
+
+// RunNexusIncomingServiceTestSuite executes interface-level tests for a Nexus incoming service persistence-layer
+// implementation. The store is expected to start out with an empty nexus_incoming_services table.
+func RunNexusIncomingServiceTestSuite(t *testing.T, store persistence.NexusIncomingServiceStore) {
+	ctx := context.Background()
+	manager := persistence.NewNexusIncomingServiceManager(store, serialization.NewSerializer(), log.NewTestLogger())
+
+	// Subtests are not run in parallel since they all mutate the table version.
+	t.Run("TestCreateGetUpdateDelete", func(t *testing.T) {
+		testNexusIncomingServiceLifecycle(ctx, t, manager)
+	})
+	t.Run("TestVersionConflicts", func(t *testing.T) {
+		testNexusIncomingServiceVersionConflicts(ctx, t, manager)
+	})
+	t.Run("TestList", func(t *testing.T) {
+		testListNexusIncomingServices(ctx, t, manager)
+	})
+}
+
+func newTestNexusIncomingServiceEntry(name string, version int64) *persistencespb.NexusIncomingServiceEntry {
+	return &persistencespb.NexusIncomingServiceEntry{
+		Version: version,
+		Name:    name,
+		Service: &persistencespb.NexusIncomingService{
+			NamespaceId: "test-namespace-id",
+			TaskQueue:   "test-task-queue",
+		},
+	}
+}
+
+func testNexusIncomingServiceLifecycle(
+	ctx context.Context,
+	t *testing.T,
+	manager persistence.NexusIncomingServiceManager,
+) {
+	name := "test-service-lifecycle"
+
+	_, err := manager.GetNexusIncomingService(ctx, &persistence.GetNexusIncomingServiceRequest{Name: name})
+	assert.ErrorAs(t, err, new(*serviceerror.NotFound))
+
+	resp, err := manager.CreateOrUpdateNexusIncomingService(ctx, &persistence.CreateOrUpdateNexusIncomingServiceRequest{
+		Entry: newTestNexusIncomingServiceEntry(name, 0),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Version)
+
+	entry, err := manager.GetNexusIncomingService(ctx, &persistence.GetNexusIncomingServiceRequest{Name: name})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), entry.GetVersion())
+	assert.Equal(t, name, entry.GetName())
+	assert.Equal(t, "test-task-queue", entry.GetService().GetTaskQueue())
+
+	update := newTestNexusIncomingServiceEntry(name, 1)
+	update.Service.TaskQueue = "updated-task-queue"
+	resp, err = manager.CreateOrUpdateNexusIncomingService(ctx, &persistence.CreateOrUpdateNexusIncomingServiceRequest{
+		Entry: update,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Version)
+
+	entry, err = manager.GetNexusIncomingService(ctx, &persistence.GetNexusIncomingServiceRequest{Name: name})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entry.GetVersion())
+	assert.Equal(t, "updated-task-queue", entry.GetService().GetTaskQueue())
+
+	err = manager.DeleteNexusIncomingService(ctx, &persistence.DeleteNexusIncomingServiceRequest{Name: name})
+	require.NoError(t, err)
+
+	_, err = manager.GetNexusIncomingService(ctx, &persistence.GetNexusIncomingServiceRequest{Name: name})
+	assert.ErrorAs(t, err, new(*serviceerror.NotFound))
+
+	err = manager.DeleteNexusIncomingService(ctx, &persistence.DeleteNexusIncomingServiceRequest{Name: name})
+	assert.ErrorAs(t, err, new(*serviceerror.NotFound))
+}
+
+func testNexusIncomingServiceVersionConflicts(
+	ctx context.Context,
+	t *testing.T,
+	manager persistence.NexusIncomingServiceManager,
+) {
+	name := "test-service-conflicts"
+
+	_, err := manager.CreateOrUpdateNexusIncomingService(ctx, &persistence.CreateOrUpdateNexusIncomingServiceRequest{
+		Entry: newTestNexusIncomingServiceEntry(name, 0),
+	})
+	require.NoError(t, err)
+
+	// Creating the same service twice fails.
+	_, err = manager.CreateOrUpdateNexusIncomingService(ctx, &persistence.CreateOrUpdateNexusIncomingServiceRequest{
+		Entry: newTestNexusIncomingServiceEntry(name, 0),
+	})
+	assert.ErrorIs(t, err, persistence.ErrNexusIncomingServiceVersionConflict)
+
+	// Updating with a stale version fails.
+	_, err = manager.CreateOrUpdateNexusIncomingService(ctx, &persistence.CreateOrUpdateNexusIncomingServiceRequest{
+		Entry: newTestNexusIncomingServiceEntry(name, 5),
+	})
+	assert.ErrorIs(t, err, persistence.ErrNexusIncomingServiceVersionConflict)
+
+	// Mutating with a stale table version fails.
+	list, err := manager.ListNexusIncomingServices(ctx, &persistence.ListNexusIncomingServicesRequest{PageSize: 1})
+	require.NoError(t, err)
+	_, err = manager.CreateOrUpdateNexusIncomingService(ctx, &persistence.CreateOrUpdateNexusIncomingServiceRequest{
+		LastKnownTableVersion: list.TableVersion + 1,
+		Entry:                 newTestNexusIncomingServiceEntry(name, 1),
+	})
+	assert.ErrorIs(t, err, persistence.ErrNexusTableVersionConflict)
+	err = manager.DeleteNexusIncomingService(ctx, &persistence.DeleteNexusIncomingServiceRequest{
+		LastKnownTableVersion: list.TableVersion + 1,
+		Name:                  name,
+	})
+	assert.ErrorIs(t, err, persistence.ErrNexusTableVersionConflict)
+
+	err = manager.DeleteNexusIncomingService(ctx, &persistence.DeleteNexusIncomingServiceRequest{
+		LastKnownTableVersion: list.TableVersion,
+		Name:                  name,
+	})
+	require.NoError(t, err)
+}
+
+func testListNexusIncomingServices(
+	ctx context.Context,
+	t *testing.T,
+	manager persistence.NexusIncomingServiceManager,
+) {
+	_, err := manager.ListNexusIncomingServices(ctx, &persistence.ListNexusIncomingServicesRequest{PageSize: 0})
+	assert.Error(t, err)
+
+	initial, err := manager.ListNexusIncomingServices(ctx, &persistence.ListNexusIncomingServicesRequest{PageSize: 10})
+	require.NoError(t, err)
+	require.Empty(t, initial.Entries)
+
+	numServices := 5
+	for i := 0; i < numServices; i++ {
+		_, err := manager.CreateOrUpdateNexusIncomingService(ctx, &persistence.CreateOrUpdateNexusIncomingServiceRequest{
+			Entry: newTestNexusIncomingServiceEntry(fmt.Sprintf("test-service-list-%d", i), 0),
+		})
+		require.NoError(t, err)
+	}
+
+	var (
+		names         []string
+		nextPageToken []byte
+		tableVersion  int64
+	)
+	for {
+		resp, err := manager.ListNexusIncomingServices(ctx, &persistence.ListNexusIncomingServicesRequest{
+			LastKnownTableVersion: tableVersion,
+			NextPageToken:         nextPageToken,
+			PageSize:              2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, initial.TableVersion+int64(numServices), resp.TableVersion)
+		for _, entry := range resp.Entries {
+			names = append(names, entry.GetName())
+		}
+		tableVersion = resp.TableVersion
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+	assert.Equal(t, []string{
+		"test-service-list-0",
+		"test-service-list-1",
+		"test-service-list-2",
+		"test-service-list-3",
+		"test-service-list-4",
+	}, names)
+
+	_, err = manager.ListNexusIncomingServices(ctx, &persistence.ListNexusIncomingServicesRequest{
+		LastKnownTableVersion: tableVersion - 1,
+		PageSize:              2,
+	})
+	assert.ErrorIs(t, err, persistence.ErrNexusTableVersionConflict)
+}
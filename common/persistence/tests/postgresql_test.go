@@ -629,6 +629,14 @@ func (p *PostgreSQLSuite) TestPGQueueV2() {
 	RunQueueV2TestSuiteForSQL(p.T(), testData.Factory)
 }
 
+func (p *PostgreSQLSuite) TestPGNexusIncomingServicePersistence() {
+	testData, tearDown := setUpPostgreSQLTest(p.T(), p.pluginName)
+	p.T().Cleanup(tearDown)
+	store, err := testData.Factory.NewNexusIncomingServiceStore()
+	p.Require().NoError(err)
+	RunNexusIncomingServiceTestSuite(p.T(), store)
+}
+
 func TestPQ(t *testing.T) {
 	s := &PostgreSQLSuite{pluginName: "postgres12"}
 	suite.Run(t, s)
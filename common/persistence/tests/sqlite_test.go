@@ -31,6 +31,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"go.temporal.io/server/common/config"
@@ -1081,3 +1082,22 @@ func TestSQLiteQueueV2(t *testing.T) {
 	})
 	RunQueueV2TestSuiteForSQL(t, factory)
 }
+
+func TestSQLiteNexusIncomingServicePersistence(t *testing.T) {
+	cfg := NewSQLiteFileConfig()
+	SetupSQLiteDatabase(cfg)
+	logger := log.NewNoopLogger()
+	factory := sql.NewFactory(
+		*cfg,
+		resolver.NewNoopResolver(),
+		testSQLiteClusterName,
+		logger,
+	)
+	t.Cleanup(func() {
+		factory.Close()
+		assert.NoError(t, os.Remove(cfg.DatabaseName))
+	})
+	store, err := factory.NewNexusIncomingServiceStore()
+	require.NoError(t, err)
+	RunNexusIncomingServiceTestSuite(t, store)
+}
@@ -34,8 +34,8 @@ import (
 	"github.com/stretchr/testify/require"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
 	workflowpb "go.temporal.io/api/workflow/v1"
-	"go.temporal.io/api/workflowservice/v1"
 
 	"go.temporal.io/server/common/debug"
 	"go.temporal.io/server/common/dynamicconfig"
@@ -735,6 +735,16 @@ func (s *VisibilityPersistenceSuite) TestGetWorkflowExecution() {
 		s.NoError(err)
 		s.assertClosedExecutionEquals(req, resp.Execution)
 	}
+
+	_, err := s.VisibilityMgr.GetWorkflowExecution(
+		s.ctx,
+		&manager.GetWorkflowExecutionRequest{
+			NamespaceID: testNamespaceUUID,
+			RunID:       uuid.New(),
+		},
+	)
+	var notFound *serviceerror.NotFound
+	s.ErrorAs(err, &notFound)
 }
 
 // TestAdvancedVisibilityPagination test
@@ -849,7 +859,7 @@ func (s *VisibilityPersistenceSuite) TestCountGroupByWorkflowExecutions() {
 	s.NoError(err)
 	s.Equal(int64(5), resp.Count)
 	s.Equal(
-		[]*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+		[]*manager.AggregationGroup{
 			{
 				GroupValues: []*commonpb.Payload{runningStatusPayload},
 				Count:       int64(5),
@@ -873,6 +883,194 @@ func (s *VisibilityPersistenceSuite) TestCountGroupByWorkflowExecutions() {
 	s.Equal(int64(5), resp.Count)
 }
 
+func (s *VisibilityPersistenceSuite) TestCountGroupByWorkflowExecutions_Aggregations() {
+	switch s.VisibilityMgr.GetStoreNames()[0] {
+	case mysql.PluginName, postgresql.PluginName, postgresql.PluginNamePGX:
+		s.T().Skip("Not supported by standard visibility")
+	}
+
+	testNamespaceUUID := namespace.ID(uuid.New())
+	closeTime := time.Now().UTC().Truncate(time.Millisecond)
+	startTime := closeTime.Add(-5 * time.Second)
+
+	var startRequests []*manager.RecordWorkflowExecutionStartedRequest
+	for i := 0; i < 4; i++ {
+		startRequests = append(
+			startRequests,
+			s.createOpenWorkflowRecord(
+				testNamespaceUUID,
+				fmt.Sprintf("visibility-workflow-test-%d", i),
+				fmt.Sprintf("visibility-workflow-%d", i%2),
+				startTime.Add(time.Duration(i)*time.Second),
+				"test-queue",
+			),
+		)
+	}
+	// Close workflow 0 and 2 of type visibility-workflow-0 with history length 5.
+	s.createClosedWorkflowRecord(startRequests[0], closeTime)
+	s.createClosedWorkflowRecord(startRequests[2], closeTime.Add(time.Second))
+
+	resp, err := s.VisibilityMgr.CountWorkflowExecutions(
+		s.ctx,
+		&manager.CountWorkflowExecutionsRequest{
+			NamespaceID: testNamespaceUUID,
+			Query:       "SELECT MIN(StartTime), MAX(CloseTime), AVG(HistoryLength) GROUP BY WorkflowType, ExecutionStatus",
+		},
+	)
+	s.NoError(err)
+	s.Equal(int64(4), resp.Count)
+	s.Len(resp.Groups, 2)
+
+	groups := make(map[string][]any)
+	for _, group := range resp.Groups {
+		s.Len(group.GroupValues, 2)
+		s.Len(group.AggregationValues, 3)
+		var values []any
+		for _, payload := range append(group.GroupValues, group.AggregationValues...) {
+			value, err := searchattribute.DecodeValue(payload, enumspb.INDEXED_VALUE_TYPE_UNSPECIFIED, false)
+			s.NoError(err)
+			values = append(values, value)
+		}
+		s.Equal(int64(2), group.Count)
+		groups[fmt.Sprintf("%v/%v", values[0], values[1])] = values[2:]
+	}
+
+	closed := groups["visibility-workflow-0/"+enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED.String()]
+	s.Len(closed, 3)
+	s.True(startTime.Equal(closed[0].(time.Time)), "min start time: %v", closed[0])
+	s.True(closeTime.Add(time.Second).Equal(closed[1].(time.Time)), "max close time: %v", closed[1])
+	s.Equal(float64(5), closed[2])
+
+	running := groups["visibility-workflow-1/"+enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING.String()]
+	s.Len(running, 3)
+	s.True(startTime.Add(time.Second).Equal(running[0].(time.Time)), "min start time: %v", running[0])
+	s.Nil(running[1])
+	// History length is not set for running workflows.
+	s.Nil(running[2])
+}
+
+func (s *VisibilityPersistenceSuite) TestCountWorkflowExecutions_Functions() {
+	switch s.VisibilityMgr.GetStoreNames()[0] {
+	case mysql.PluginName, postgresql.PluginName, postgresql.PluginNamePGX:
+		s.T().Skip("Not supported by standard visibility")
+	}
+
+	testNamespaceUUID := namespace.ID(uuid.New())
+	hour10 := time.Date(2023, 11, 14, 10, 0, 0, 0, time.UTC)
+	hour11 := hour10.Add(time.Hour)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	s.createOpenWorkflowRecord(testNamespaceUUID, "wid-1", "Visibility-Workflow-A", hour10.Add(15*time.Minute), "test-queue")
+	s.createOpenWorkflowRecord(testNamespaceUUID, "wid-2", "visibility-workflow-b", hour10.Add(45*time.Minute), "test-queue")
+	s.createOpenWorkflowRecord(testNamespaceUUID, "wid-3", "VISIBILITY-WORKFLOW-A", hour11.Add(5*time.Minute), "test-queue")
+	s.createOpenWorkflowRecord(testNamespaceUUID, "wid-4", "visibility-workflow-b", now, "test-queue")
+
+	counts := map[string]int64{
+		"lower(WorkflowType) = 'visibility-workflow-a'":                                              2,
+		"lower(WorkflowType) starts_with 'VISIBILITY-'":                                              4,
+		"NOT WorkflowType = 'visibility-workflow-b'":                                                 2,
+		"date_trunc('hour', StartTime) = '2023-11-14T10:00:00Z'":                                     2,
+		"date_trunc('day', StartTime) = '2023-11-14T00:00:00Z' AND NOT WorkflowId = 'wid-1'":         2,
+		"StartTime > now() - interval 1 hour":                                                        1,
+		"StartTime between '2023-11-14T00:00:00Z' and now() - interval 1 day":                        3,
+		"date_trunc('week', StartTime) = '2023-11-13T00:00:00Z' AND StartTime < now()":               3,
+		"NOT (StartTime > now() - interval 1 hour OR lower(WorkflowType) = 'visibility-workflow-a')": 1,
+	}
+	for query, expectedCount := range counts {
+		resp, err := s.VisibilityMgr.CountWorkflowExecutions(
+			s.ctx,
+			&manager.CountWorkflowExecutionsRequest{
+				NamespaceID: testNamespaceUUID,
+				Query:       query,
+			},
+		)
+		s.NoError(err, query)
+		s.Equal(expectedCount, resp.Count, query)
+	}
+
+	resp, err := s.VisibilityMgr.CountWorkflowExecutions(
+		s.ctx,
+		&manager.CountWorkflowExecutionsRequest{
+			NamespaceID: testNamespaceUUID,
+			Query:       "StartTime < '2023-11-15T00:00:00Z' GROUP BY date_trunc('hour', StartTime)",
+		},
+	)
+	s.NoError(err)
+	s.Equal(int64(3), resp.Count)
+	buckets := make(map[time.Time]int64)
+	for _, group := range resp.Groups {
+		s.Len(group.GroupValues, 1)
+		value, err := searchattribute.DecodeValue(group.GroupValues[0], enumspb.INDEXED_VALUE_TYPE_UNSPECIFIED, false)
+		s.NoError(err)
+		buckets[value.(time.Time)] = group.Count
+	}
+	s.Equal(map[time.Time]int64{hour10: 2, hour11: 1}, buckets)
+}
+
+func (s *VisibilityPersistenceSuite) TestListWorkflowExecutions_TextSearch() {
+	switch s.VisibilityMgr.GetStoreNames()[0] {
+	case mysql.PluginName, postgresql.PluginName, postgresql.PluginNamePGX:
+		s.T().Skip("Not supported by standard visibility")
+	}
+
+	testNamespaceUUID := namespace.ID(uuid.New())
+	startTime := time.Now().UTC().Add(-time.Hour)
+	texts := map[string]string{
+		"wid-1": "foo bar",
+		"wid-2": "foo baz qux",
+		"wid-3": "quux",
+		"wid-4": "foobar",
+	}
+	// Workflows with better match are started earlier, so relevance order is not the default order.
+	for i, wid := range []string{"wid-1", "wid-2", "wid-3", "wid-4"} {
+		textPayload, err := searchattribute.EncodeValue(texts[wid], enumspb.INDEXED_VALUE_TYPE_TEXT)
+		s.NoError(err)
+		err = s.VisibilityMgr.RecordWorkflowExecutionStarted(s.ctx, &manager.RecordWorkflowExecutionStartedRequest{
+			VisibilityRequestBase: &manager.VisibilityRequestBase{
+				NamespaceID:      testNamespaceUUID,
+				Execution:        &commonpb.WorkflowExecution{WorkflowId: wid, RunId: uuid.New()},
+				WorkflowTypeName: "visibility-workflow",
+				StartTime:        startTime.Add(time.Duration(i) * time.Minute),
+				Status:           enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+				TaskQueue:        "test-queue",
+				SearchAttributes: &commonpb.SearchAttributes{
+					IndexedFields: map[string]*commonpb.Payload{"Text01": textPayload},
+				},
+			},
+		})
+		s.NoError(err)
+	}
+
+	listWorkflowIDs := func(query string, pageSize int) []string {
+		var workflowIDs []string
+		var nextPageToken []byte
+		for {
+			resp, err := s.VisibilityMgr.ListWorkflowExecutions(s.ctx, &manager.ListWorkflowExecutionsRequestV2{
+				NamespaceID:   testNamespaceUUID,
+				PageSize:      pageSize,
+				Query:         query,
+				NextPageToken: nextPageToken,
+			})
+			s.NoError(err, query)
+			for _, execution := range resp.Executions {
+				workflowIDs = append(workflowIDs, execution.GetExecution().GetWorkflowId())
+			}
+			if len(resp.NextPageToken) == 0 {
+				return workflowIDs
+			}
+			nextPageToken = resp.NextPageToken
+		}
+	}
+
+	// Matching more tokens is more relevant.
+	s.Equal([]string{"wid-1", "wid-2"}, listWorkflowIDs("Text01 = 'foo bar'", 10))
+	s.Equal([]string{"wid-1", "wid-2"}, listWorkflowIDs("Text01 = 'foo bar'", 1))
+	s.ElementsMatch([]string{"wid-1", "wid-2", "wid-4"}, listWorkflowIDs("Text01 starts_with 'foo'", 10))
+	s.ElementsMatch([]string{"wid-1", "wid-2", "wid-4"}, listWorkflowIDs("Text01 starts_with 'foo'", 1))
+	// Negated search keeps the default order.
+	s.Equal([]string{"wid-4", "wid-3"}, listWorkflowIDs("Text01 != 'foo bar'", 1))
+	s.Equal([]string{"wid-3"}, listWorkflowIDs("Text01 not starts_with 'fo'", 10))
+}
+
 func (s *VisibilityPersistenceSuite) listWithPagination(namespaceID namespace.ID, pageSize int) []*workflowpb.WorkflowExecutionInfo {
 	var executions []*workflowpb.WorkflowExecutionInfo
 	resp, err := s.VisibilityMgr.ListWorkflowExecutions(s.ctx, &manager.ListWorkflowExecutionsRequestV2{
@@ -37,6 +37,7 @@ import (
 	"go.temporal.io/server/common/persistence/visibility/store"
 	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch"
 	esclient "go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
+	"go.temporal.io/server/common/persistence/visibility/store/embedded"
 	"go.temporal.io/server/common/persistence/visibility/store/sql"
 	"go.temporal.io/server/common/persistence/visibility/store/standard"
 	standardSql "go.temporal.io/server/common/persistence/visibility/store/standard/sql"
@@ -278,6 +279,7 @@ func newVisibilityStoreFromDataStoreConfig(
 	} else if dsConfig.Elasticsearch != nil {
 		visStore = newElasticsearchVisibilityStore(
 			dsConfig.Elasticsearch.GetVisibilityIndex(),
+			dsConfig.Elasticsearch.NamespaceIndices,
 			esClient,
 			esProcessorConfig,
 			searchAttributesProvider,
@@ -287,6 +289,13 @@ func newVisibilityStoreFromDataStoreConfig(
 			metricsHandler,
 			logger,
 		)
+	} else if dsConfig.Embedded != nil {
+		visStore, err = embedded.NewVisibilityStore(
+			*dsConfig.Embedded,
+			searchAttributesProvider,
+			searchAttributesMapperProvider,
+			logger,
+		)
 	} else if dsConfig.CustomDataStoreConfig != nil {
 		visStore, err = customVisibilityStoreFactory.NewVisibilityStore(
 			*dsConfig.CustomDataStoreConfig,
@@ -333,6 +342,7 @@ func newStandardVisibilityStore(
 
 func newElasticsearchVisibilityStore(
 	defaultIndexName string,
+	namespaceIndices esclient.NamespaceIndices,
 	esClient esclient.Client,
 	esProcessorConfig *elasticsearch.ProcessorConfig,
 	searchAttributesProvider searchattribute.Provider,
@@ -358,6 +368,7 @@ func newElasticsearchVisibilityStore(
 	s := elasticsearch.NewVisibilityStore(
 		esClient,
 		defaultIndexName,
+		namespaceIndices,
 		searchAttributesProvider,
 		searchAttributesMapperProvider,
 		esProcessor,
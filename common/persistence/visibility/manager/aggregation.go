@@ -0,0 +1,99 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package manager
+
+import (
+	"fmt"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// AggregationValuesFieldNumber is the field number of the aggregation values in
+// CountWorkflowExecutionsResponse.AggregationGroup:
+//
+//	repeated temporal.api.common.v1.Payload aggregation_values = 3;
+//
+// The field is not declared by the public API yet, so it's sent as an unknown field
+// which clients without it ignore. Use GetAggregationValues to read it.
+const AggregationValuesFieldNumber protowire.Number = 3
+
+// ToProto converts the group to CountWorkflowExecutionsResponse.AggregationGroup.
+func (g *AggregationGroup) ToProto() (*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup, error) {
+	group := &workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+		GroupValues: g.GroupValues,
+		Count:       g.Count,
+	}
+	if len(g.AggregationValues) == 0 {
+		return group, nil
+	}
+	var unknown []byte
+	for _, value := range g.AggregationValues {
+		data, err := proto.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal aggregation value: %w", err)
+		}
+		unknown = protowire.AppendTag(unknown, AggregationValuesFieldNumber, protowire.BytesType)
+		unknown = protowire.AppendBytes(unknown, data)
+	}
+	group.ProtoReflect().SetUnknown(unknown)
+	return group, nil
+}
+
+// GetAggregationValues returns the aggregation values of CountWorkflowExecutionsResponse.AggregationGroup.
+func GetAggregationValues(
+	group *workflowservice.CountWorkflowExecutionsResponse_AggregationGroup,
+) ([]*commonpb.Payload, error) {
+	var values []*commonpb.Payload
+	unknown := group.ProtoReflect().GetUnknown()
+	for len(unknown) > 0 {
+		num, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		unknown = unknown[n:]
+		if num != AggregationValuesFieldNumber || typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, unknown)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			unknown = unknown[n:]
+			continue
+		}
+		data, n := protowire.ConsumeBytes(unknown)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		unknown = unknown[n:]
+		value := &commonpb.Payload{}
+		if err := proto.Unmarshal(data, value); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal aggregation value: %w", err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
@@ -34,7 +34,6 @@ import (
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	workflowpb "go.temporal.io/api/workflow/v1"
-	"go.temporal.io/api/workflowservice/v1"
 
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
@@ -148,7 +147,16 @@ type (
 	// CountWorkflowExecutionsResponse is response to CountWorkflowExecutions
 	CountWorkflowExecutionsResponse struct {
 		Count  int64 // sum of counts in Groups
-		Groups []*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup
+		Groups []*AggregationGroup
+	}
+
+	// AggregationGroup is a group of workflow executions in CountWorkflowExecutionsResponse
+	AggregationGroup struct {
+		// Values of the 'group by' fields.
+		GroupValues []*commonpb.Payload
+		// Values of the aggregate functions of the select list, in the select list order.
+		AggregationValues []*commonpb.Payload
+		Count             int64
 	}
 
 	// ListWorkflowExecutionsByTypeRequest is used to list executions of
@@ -175,9 +183,11 @@ type (
 	// VisibilityDeleteWorkflowExecutionRequest contains the request params for DeleteWorkflowExecution call
 	VisibilityDeleteWorkflowExecutionRequest struct {
 		NamespaceID namespace.ID
-		RunID       string
-		WorkflowID  string
-		TaskID      int64
+		// Namespace is used by stores routing namespaces to different indices.
+		Namespace  namespace.Name
+		RunID      string
+		WorkflowID string
+		TaskID     int64
 	}
 
 	// GetWorkflowExecutionRequest is request from GetWorkflowExecution
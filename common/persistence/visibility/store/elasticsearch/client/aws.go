@@ -25,15 +25,35 @@
 package client
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
-	elasticaws "github.com/olivere/elastic/v7/aws/v4"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+const (
+	// awsSigningServiceES is used for Amazon Elasticsearch/OpenSearch Service domains.
+	awsSigningServiceES = "es"
+)
+
+type (
+	// awsSigningTransport signs every request with AWS SigV4 before passing it to the underlying transport.
+	awsSigningTransport struct {
+		transport http.RoundTripper
+		signer    *v4.Signer
+		service   string
+		region    string
+	}
 )
 
 func NewAwsHttpClient(config ESAWSRequestSigningConfig) (*http.Client, error) {
@@ -73,5 +93,50 @@ func NewAwsHttpClient(config ESAWSRequestSigningConfig) (*http.Client, error) {
 		return nil, fmt.Errorf("unknown AWS credential provider specified: %+v. Accepted options are 'static', 'environment' or 'session'", config.CredentialProvider)
 	}
 
-	return elasticaws.NewV4SigningClient(awsCredentials, config.Region), nil
+	service := config.Service
+	if service == "" {
+		service = awsSigningServiceES
+	}
+
+	return &http.Client{
+		Transport: &awsSigningTransport{
+			transport: http.DefaultTransport,
+			signer:    v4.NewSigner(awsCredentials),
+			service:   service,
+			region:    config.Region,
+		},
+	}, nil
+}
+
+func (t *awsSigningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if h := req.Header.Get("Authorization"); strings.HasPrefix(h, "AWS4") {
+		// Request is already signed.
+		return t.transport.RoundTrip(req)
+	}
+
+	var buf []byte
+	if req.Body != nil {
+		var err error
+		buf, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// RoundTripper must not modify the original request.
+	req = req.Clone(req.Context())
+	// OpenSearch Serverless requires payload hash header which signer sets only for S3.
+	payloadHash := sha256.Sum256(buf)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	var body io.ReadSeeker
+	if req.Body != nil {
+		body = bytes.NewReader(buf)
+	}
+	// Signer attaches body back to the request.
+	if _, err := t.signer.Sign(req, body, t.service, t.region, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return t.transport.RoundTrip(req)
 }
@@ -0,0 +1,225 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"github.com/olivere/elastic/v7"
+	"github.com/olivere/elastic/v7/uritemplates"
+
+	"go.temporal.io/server/common/log"
+)
+
+type (
+	// openSearchClientImpl implements Client for OpenSearch 2.x.
+	// OpenSearch 2.x is wire compatible with Elasticsearch 7.10 for search, bulk, and mapping APIs,
+	// therefore only APIs which differ (point in time and index templates) are overridden.
+	openSearchClientImpl struct {
+		*clientImpl
+
+		initIsPointInTimeSupported sync.Once
+		isPointInTimeSupported     bool
+	}
+
+	openSearchRootResponse struct {
+		Version struct {
+			Distribution string `json:"distribution"`
+			Number       string `json:"number"`
+		} `json:"version"`
+	}
+
+	openSearchOpenPointInTimeResponse struct {
+		PitID string `json:"pit_id"`
+	}
+
+	openSearchClosePointInTimeResponse struct {
+		Pits []struct {
+			PitID      string `json:"pit_id"`
+			Successful bool   `json:"successful"`
+		} `json:"pits"`
+	}
+
+	openSearchAcknowledgedResponse struct {
+		Acknowledged bool `json:"acknowledged"`
+	}
+)
+
+const (
+	openSearchDistribution = "opensearch"
+)
+
+var (
+	openSearchPointInTimeSupportedIn = semver.MustParseRange(">=2.4.0")
+)
+
+var _ Client = (*openSearchClientImpl)(nil)
+var _ CLIClient = (*openSearchClientImpl)(nil)
+var _ IntegrationTestsClient = (*openSearchClientImpl)(nil)
+
+// newOpenSearchClient create an OpenSearch 2.x client
+func newOpenSearchClient(cfg *Config, httpClient *http.Client, logger log.Logger) (*openSearchClientImpl, error) {
+	client, err := newClient(cfg, httpClient, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &openSearchClientImpl{
+		clientImpl: client,
+	}, nil
+}
+
+func (c *openSearchClientImpl) IsPointInTimeSupported(ctx context.Context) bool {
+	c.initIsPointInTimeSupported.Do(func() {
+		c.isPointInTimeSupported = c.queryPointInTimeSupported(ctx)
+	})
+	return c.isPointInTimeSupported
+}
+
+func (c *openSearchClientImpl) queryPointInTimeSupported(ctx context.Context) bool {
+	var result openSearchRootResponse
+	if err := c.performRequest(ctx, http.MethodGet, "/", nil, nil, &result); err != nil {
+		return false
+	}
+	if result.Version.Distribution != openSearchDistribution {
+		return false
+	}
+	osVersion, err := semver.ParseTolerant(result.Version.Number)
+	if err != nil {
+		return false
+	}
+	return openSearchPointInTimeSupportedIn(osVersion)
+}
+
+func (c *openSearchClientImpl) OpenPointInTime(ctx context.Context, index string, keepAliveInterval string) (string, error) {
+	path, err := uritemplates.Expand("/{index}/_search/point_in_time", map[string]string{
+		"index": index,
+	})
+	if err != nil {
+		return "", err
+	}
+	params := url.Values{}
+	params.Set("keep_alive", keepAliveInterval)
+
+	var resp openSearchOpenPointInTimeResponse
+	if err := c.performRequest(ctx, http.MethodPost, path, params, nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.PitID, nil
+}
+
+func (c *openSearchClientImpl) ClosePointInTime(ctx context.Context, id string) (bool, error) {
+	body := map[string]interface{}{
+		"pit_id": []string{id},
+	}
+
+	var resp openSearchClosePointInTimeResponse
+	if err := c.performRequest(ctx, http.MethodDelete, "/_search/point_in_time", nil, body, &resp); err != nil {
+		return false, err
+	}
+	for _, pit := range resp.Pits {
+		if pit.PitID == id {
+			return pit.Successful, nil
+		}
+	}
+	return false, nil
+}
+
+// IndexPutTemplate creates composable index template. OpenSearch deprecated legacy templates,
+// therefore legacy template body (used by Elasticsearch schema) is converted to the composable format.
+func (c *openSearchClientImpl) IndexPutTemplate(ctx context.Context, templateName string, bodyString string) (bool, error) {
+	body, err := buildIndexTemplateBody(bodyString)
+	if err != nil {
+		return false, err
+	}
+	path, err := uritemplates.Expand("/_index_template/{name}", map[string]string{
+		"name": templateName,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var resp openSearchAcknowledgedResponse
+	if err := c.performRequest(ctx, http.MethodPut, path, nil, body, &resp); err != nil {
+		return false, err
+	}
+	return resp.Acknowledged, nil
+}
+
+func (c *openSearchClientImpl) performRequest(
+	ctx context.Context,
+	method string,
+	path string,
+	params url.Values,
+	body interface{},
+	result interface{},
+) error {
+	res, err := c.esClient.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method:  method,
+		Path:    path,
+		Params:  params,
+		Body:    body,
+		Headers: http.Header{},
+	})
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(res.Body, result); err != nil {
+		return fmt.Errorf("unable to decode OpenSearch response: %w", err)
+	}
+	return nil
+}
+
+// buildIndexTemplateBody converts legacy index template body to composable index template body.
+// Body which is already in composable format is returned as is.
+func buildIndexTemplateBody(bodyString string) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(bodyString), &body); err != nil {
+		return nil, fmt.Errorf("unable to parse index template: %w", err)
+	}
+	if _, ok := body["template"]; ok {
+		return body, nil
+	}
+
+	template := make(map[string]interface{})
+	for _, key := range []string{"settings", "mappings", "aliases"} {
+		if value, ok := body[key]; ok {
+			template[key] = value
+			delete(body, key)
+		}
+	}
+	body["template"] = template
+
+	if order, ok := body["order"]; ok {
+		body["priority"] = order
+		delete(body, "order")
+	}
+	return body, nil
+}
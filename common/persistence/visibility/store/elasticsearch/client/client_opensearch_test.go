@@ -0,0 +1,311 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/olivere/elastic/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/server/common/log"
+)
+
+type (
+	// openSearchFixture is a recorded OpenSearch 2.x exchange.
+	openSearchFixture struct {
+		method       string
+		path         string
+		query        string
+		requestBody  string
+		responseCode int
+		responseBody string
+	}
+
+	openSearchFixtureServer struct {
+		t        *testing.T
+		mu       sync.Mutex
+		fixtures []openSearchFixture
+		headers  []http.Header
+	}
+)
+
+const (
+	openSearchRootFixture = `{
+  "name" : "opensearch-node1",
+  "cluster_name" : "opensearch-cluster",
+  "version" : {
+    "distribution" : "opensearch",
+    "number" : "2.11.1",
+    "build_type" : "tar",
+    "lucene_version" : "9.7.0",
+    "minimum_wire_compatibility_version" : "7.10.0",
+    "minimum_index_compatibility_version" : "7.0.0"
+  },
+  "tagline" : "The OpenSearch Project: https://opensearch.org/"
+}`
+	openSearchPitID = "o463QQEPbXktaW5kZXgtMDAwMDAxFnNOWU43ckt3U3IyaFVpbGE1UWEtMncAFjFyeXBsRGJmVFM2RTB6eVg1aVVqQncAAAAAAAAAAAIWcDVrM3ZIX0pRNS1XejE5YXRPRFhzUQEWc05ZTjdyS3dTcjJoVWlsYTVRYS0ydwAA"
+)
+
+func newOpenSearchFixtureServer(t *testing.T, fixtures ...openSearchFixture) (*openSearchClientImpl, *openSearchFixtureServer) {
+	fs := &openSearchFixtureServer{t: t, fixtures: fixtures}
+	server := httptest.NewServer(fs)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client, err := newOpenSearchClient(&Config{URL: *serverURL}, server.Client(), log.NewNoopLogger())
+	require.NoError(t, err)
+	return client, fs
+}
+
+func (fs *openSearchFixtureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	if len(fs.fixtures) == 0 {
+		fs.t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	fixture := fs.fixtures[0]
+	fs.fixtures = fs.fixtures[1:]
+	fs.headers = append(fs.headers, r.Header.Clone())
+
+	assert.Equal(fs.t, fixture.method, r.Method)
+	assert.Equal(fs.t, fixture.path, r.URL.Path)
+	if fixture.query != "" {
+		assert.Equal(fs.t, fixture.query, r.URL.RawQuery)
+	}
+	if fixture.requestBody != "" {
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gzipReader, err := gzip.NewReader(r.Body)
+			assert.NoError(fs.t, err)
+			reader = gzipReader
+		}
+		body, err := io.ReadAll(reader)
+		assert.NoError(fs.t, err)
+		assert.JSONEq(fs.t, fixture.requestBody, string(body))
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(fixture.responseCode)
+	_, _ = w.Write([]byte(fixture.responseBody))
+}
+
+func (fs *openSearchFixtureServer) assertAllServed() {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	assert.Empty(fs.t, fs.fixtures, "not all recorded requests were made")
+}
+
+func TestOpenSearch_IsPointInTimeSupported(t *testing.T) {
+	tests := []struct {
+		name     string
+		root     string
+		expected bool
+	}{
+		{
+			name:     "OpenSearch 2.11",
+			root:     openSearchRootFixture,
+			expected: true,
+		},
+		{
+			name:     "OpenSearch 2.3",
+			root:     strings.Replace(openSearchRootFixture, "2.11.1", "2.3.0", 1),
+			expected: false,
+		},
+		{
+			name:     "Elasticsearch",
+			root:     `{"version":{"number":"7.17.0","build_flavor":"default"}}`,
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, fs := newOpenSearchFixtureServer(t, openSearchFixture{
+				method:       http.MethodGet,
+				path:         "/",
+				responseCode: http.StatusOK,
+				responseBody: tc.root,
+			})
+			assert.Equal(t, tc.expected, client.IsPointInTimeSupported(context.Background()))
+			// Result is cached.
+			assert.Equal(t, tc.expected, client.IsPointInTimeSupported(context.Background()))
+			fs.assertAllServed()
+		})
+	}
+}
+
+func TestOpenSearch_PointInTime(t *testing.T) {
+	client, fs := newOpenSearchFixtureServer(t,
+		openSearchFixture{
+			method:       http.MethodPost,
+			path:         "/temporal_visibility_v1/_search/point_in_time",
+			query:        "keep_alive=1m",
+			responseCode: http.StatusOK,
+			responseBody: `{"pit_id":"` + openSearchPitID + `","_shards":{"total":1,"successful":1,"skipped":0,"failed":0},"creation_time":1700000000000}`,
+		},
+		openSearchFixture{
+			method:       http.MethodPost,
+			path:         "/_search",
+			requestBody:  `{"pit":{"id":"` + openSearchPitID + `","keep_alive":"1m"},"query":{"term":{"NamespaceId":"ns"}},"size":1,"sort":[{"CloseTime":{"order":"desc"}}]}`,
+			responseCode: http.StatusOK,
+			responseBody: `{"pit_id":"` + openSearchPitID + `","took":3,"timed_out":false,"_shards":{"total":1,"successful":1,"skipped":0,"failed":0},"hits":{"total":{"value":2,"relation":"eq"},"max_score":null,"hits":[{"_index":"temporal_visibility_v1","_id":"wid~rid","_score":null,"_source":{"WorkflowId":"wid","RunId":"rid","StartTime":"2023-11-14T22:13:20.123456789Z"},"sort":[1700000000123456789]}]}}`,
+		},
+		openSearchFixture{
+			method:       http.MethodDelete,
+			path:         "/_search/point_in_time",
+			requestBody:  `{"pit_id":["` + openSearchPitID + `"]}`,
+			responseCode: http.StatusOK,
+			responseBody: `{"pits":[{"successful":true,"pit_id":"` + openSearchPitID + `"}]}`,
+		},
+	)
+	ctx := context.Background()
+
+	pitID, err := client.OpenPointInTime(ctx, "temporal_visibility_v1", "1m")
+	require.NoError(t, err)
+	assert.Equal(t, openSearchPitID, pitID)
+
+	result, err := client.Search(ctx, &SearchParameters{
+		Index:       "temporal_visibility_v1",
+		Query:       elastic.NewTermQuery("NamespaceId", "ns"),
+		PageSize:    1,
+		Sorter:      []elastic.Sorter{elastic.NewFieldSort("CloseTime").Desc()},
+		PointInTime: elastic.NewPointInTimeWithKeepAlive(pitID, "1m"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, openSearchPitID, result.PitId)
+	assert.Equal(t, int64(2), result.TotalHits())
+	require.Len(t, result.Hits.Hits, 1)
+	require.Len(t, result.Hits.Hits[0].Sort, 1)
+	// int64 sort value must not lose precision.
+	assert.Equal(t, json.Number("1700000000123456789"), result.Hits.Hits[0].Sort[0])
+
+	closed, err := client.ClosePointInTime(ctx, pitID)
+	require.NoError(t, err)
+	assert.True(t, closed)
+	fs.assertAllServed()
+}
+
+func TestOpenSearch_PointInTime_Error(t *testing.T) {
+	client, fs := newOpenSearchFixtureServer(t,
+		openSearchFixture{
+			method:       http.MethodDelete,
+			path:         "/_search/point_in_time",
+			responseCode: http.StatusNotFound,
+			responseBody: `{"error":{"root_cause":[{"type":"resource_not_found_exception","reason":"pit is not found"}],"type":"resource_not_found_exception","reason":"pit is not found"},"status":404}`,
+		},
+	)
+
+	_, err := client.ClosePointInTime(context.Background(), openSearchPitID)
+	require.Error(t, err)
+	// Errors have the same type as for Elasticsearch to be handled by visibility store.
+	var esErr *elastic.Error
+	require.ErrorAs(t, err, &esErr)
+	assert.Equal(t, http.StatusNotFound, esErr.Status)
+	fs.assertAllServed()
+}
+
+func TestOpenSearch_IndexPutTemplate(t *testing.T) {
+	client, fs := newOpenSearchFixtureServer(t,
+		openSearchFixture{
+			method:       http.MethodPut,
+			path:         "/_index_template/temporal_visibility_v1_template",
+			requestBody:  `{"priority":0,"index_patterns":["temporal_visibility_v1*"],"template":{"settings":{"index":{"number_of_shards":"1"}},"mappings":{"dynamic":"false","properties":{"RunId":{"type":"keyword"}}}}}`,
+			responseCode: http.StatusOK,
+			responseBody: `{"acknowledged":true}`,
+		},
+	)
+
+	ack, err := client.IndexPutTemplate(
+		context.Background(),
+		"temporal_visibility_v1_template",
+		`{"order":0,"index_patterns":["temporal_visibility_v1*"],"settings":{"index":{"number_of_shards":"1"}},"mappings":{"dynamic":"false","properties":{"RunId":{"type":"keyword"}}}}`,
+	)
+	require.NoError(t, err)
+	assert.True(t, ack)
+	fs.assertAllServed()
+}
+
+func Test_BuildIndexTemplateBody(t *testing.T) {
+	composable := `{"index_patterns":["v1*"],"priority":1,"template":{"settings":{}}}`
+	body, err := buildIndexTemplateBody(composable)
+	require.NoError(t, err)
+	actual, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, composable, string(actual))
+
+	_, err = buildIndexTemplateBody("not a json")
+	assert.Error(t, err)
+}
+
+func TestOpenSearch_AwsRequestSigning(t *testing.T) {
+	client, fs := newOpenSearchFixtureServer(t,
+		openSearchFixture{
+			method:       http.MethodPut,
+			path:         "/_index_template/template",
+			requestBody:  `{"index_patterns":["v1*"],"template":{}}`,
+			responseCode: http.StatusOK,
+			responseBody: `{"acknowledged":true}`,
+		},
+	)
+	httpClient, err := NewAwsHttpClient(ESAWSRequestSigningConfig{
+		Enabled:            true,
+		Region:             "us-west-2",
+		Service:            "aoss",
+		CredentialProvider: "static",
+		Static: ESAWSStaticCredentialProvider{
+			AccessKeyID:     "AKIDEXAMPLE",
+			SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		},
+	})
+	require.NoError(t, err)
+	client.clientImpl, err = newClient(&Config{URL: client.url}, httpClient, log.NewNoopLogger())
+	require.NoError(t, err)
+
+	_, err = client.IndexPutTemplate(context.Background(), "template", `{"index_patterns":["v1*"]}`)
+	require.NoError(t, err)
+	fs.assertAllServed()
+
+	require.Len(t, fs.headers, 1)
+	authorization := fs.headers[0].Get("Authorization")
+	assert.True(t, strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"), authorization)
+	assert.Contains(t, authorization, "/us-west-2/aoss/aws4_request")
+	assert.Contains(t, authorization, "x-amz-content-sha256")
+	assert.NotEmpty(t, fs.headers[0].Get("X-Amz-Content-Sha256"))
+}
@@ -28,6 +28,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"path"
 	"time"
 
 	"go.temporal.io/server/common/auth"
@@ -42,12 +43,14 @@ const (
 // Config for connecting to Elasticsearch
 type (
 	Config struct {
+		// Version is one of "v7" (default), "v8" for Elasticsearch, or "opensearch2" for OpenSearch 2.x.
 		Version                      string                    `yaml:"version"`
 		URL                          url.URL                   `yaml:"url"`
 		URLs                         []url.URL                 `yaml:"urls"`
 		Username                     string                    `yaml:"username"`
 		Password                     string                    `yaml:"password"`
 		Indices                      map[string]string         `yaml:"indices"`
+		NamespaceIndices             NamespaceIndices          `yaml:"namespaceIndices"`
 		LogLevel                     string                    `yaml:"logLevel"`
 		AWSRequestSigning            ESAWSRequestSigningConfig `yaml:"aws-request-signing"`
 		CloseIdleConnectionsInterval time.Duration             `yaml:"closeIdleConnectionsInterval"`
@@ -56,10 +59,26 @@ type (
 		TLS                          *auth.TLS                 `yaml:"tls"`
 	}
 
+	// NamespaceIndices routes the visibility records of namespaces to dedicated indices instead of
+	// the visibility index. Entries are matched in order and the first matching entry is used.
+	NamespaceIndices []NamespaceIndex
+
+	// NamespaceIndex routes the visibility records of the matching namespaces to an index or alias.
+	// Search attributes of the index are managed together with the ones of the visibility index.
+	// Namespaces renamed for deletion are matched by the name they had before.
+	NamespaceIndex struct {
+		// Namespace is a namespace name or a pattern in path.Match syntax, e.g. "tenant-*".
+		Namespace string `yaml:"namespace"`
+		Index     string `yaml:"index"`
+	}
+
 	// ESAWSRequestSigningConfig represents configuration for signing ES requests to AWS
 	ESAWSRequestSigningConfig struct {
 		Enabled bool   `yaml:"enabled"`
 		Region  string `yaml:"region"`
+		// Service is the name of the service to sign requests for: "es" (default) for Amazon OpenSearch Service
+		// domains or "aoss" for Amazon OpenSearch Serverless collections.
+		Service string `yaml:"service"`
 
 		// Possible options for CredentialProvider include:
 		//   1) static (fill out static Credential Provider)
@@ -109,5 +128,46 @@ func (cfg *Config) Validate() error {
 	if cfg.Indices[VisibilityAppName] == "" {
 		return fmt.Errorf("elasticsearch config: indices configuration: missing %q key", VisibilityAppName)
 	}
+	if err := cfg.NamespaceIndices.Validate(); err != nil {
+		return fmt.Errorf("elasticsearch config: namespaceIndices configuration: %w", err)
+	}
+	return nil
+}
+
+// Validate returns an error if an entry is incomplete or has a malformed namespace pattern.
+func (n NamespaceIndices) Validate() error {
+	for i, namespaceIndex := range n {
+		if namespaceIndex.Namespace == "" || namespaceIndex.Index == "" {
+			return fmt.Errorf("entry %d: namespace and index must be set", i)
+		}
+		if _, err := path.Match(namespaceIndex.Namespace, ""); err != nil {
+			return fmt.Errorf("entry %d: invalid namespace pattern %q: %w", i, namespaceIndex.Namespace, err)
+		}
+	}
 	return nil
 }
+
+// GetIndex returns the index the visibility records of the namespace are routed to,
+// or defaultIndex if no entry matches the namespace.
+func (n NamespaceIndices) GetIndex(namespaceName string, defaultIndex string) string {
+	for _, namespaceIndex := range n {
+		if match, _ := path.Match(namespaceIndex.Namespace, namespaceName); match {
+			return namespaceIndex.Index
+		}
+	}
+	return defaultIndex
+}
+
+// GetIndexNames returns the distinct indices of the entries.
+func (n NamespaceIndices) GetIndexNames() []string {
+	var indexNames []string
+	seen := make(map[string]struct{}, len(n))
+	for _, namespaceIndex := range n {
+		if _, ok := seen[namespaceIndex.Index]; ok {
+			continue
+		}
+		seen[namespaceIndex.Index] = struct{}{}
+		indexNames = append(indexNames, namespaceIndex.Index)
+	}
+	return indexNames
+}
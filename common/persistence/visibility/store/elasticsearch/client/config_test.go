@@ -0,0 +1,53 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceIndices_GetIndex(t *testing.T) {
+	namespaceIndices := NamespaceIndices{
+		{Namespace: "tenant-a", Index: "tenant-a-index"},
+		{Namespace: "tenant-*", Index: "tenants-index"},
+		{Namespace: "big-?", Index: "tenants-index"},
+	}
+
+	require.Equal(t, "tenant-a-index", namespaceIndices.GetIndex("tenant-a", "default"))
+	require.Equal(t, "tenants-index", namespaceIndices.GetIndex("tenant-b", "default"))
+	require.Equal(t, "tenants-index", namespaceIndices.GetIndex("big-1", "default"))
+	require.Equal(t, "default", namespaceIndices.GetIndex("big-10", "default"))
+	require.Equal(t, "default", NamespaceIndices(nil).GetIndex("tenant-a", "default"))
+	require.Equal(t, []string{"tenant-a-index", "tenants-index"}, namespaceIndices.GetIndexNames())
+}
+
+func TestNamespaceIndices_Validate(t *testing.T) {
+	require.NoError(t, NamespaceIndices{{Namespace: "tenant-*", Index: "tenants-index"}}.Validate())
+	require.Error(t, NamespaceIndices{{Namespace: "tenant-*"}}.Validate())
+	require.Error(t, NamespaceIndices{{Index: "tenants-index"}}.Validate())
+	require.Error(t, NamespaceIndices{{Namespace: "tenant-[", Index: "tenants-index"}}.Validate())
+}
@@ -68,6 +68,7 @@ func newQueryConverter(
 	}
 	whereConverter.And = query.NewAndConverter(whereConverter)
 	whereConverter.Or = query.NewOrConverter(whereConverter)
+	whereConverter.Not = query.NewNotConverter(whereConverter)
 
 	return query.NewConverter(fnInterceptor, whereConverter)
 }
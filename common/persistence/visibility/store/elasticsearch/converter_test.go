@@ -30,28 +30,46 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/assert"
 
 	"go.temporal.io/server/common/persistence/visibility/store/query"
 )
 
 var errorCases = map[string]string{
-	"delete":                                 query.MalformedSqlQueryErrMessage,
-	"update x":                               query.MalformedSqlQueryErrMessage,
-	"insert ":                                query.MalformedSqlQueryErrMessage,
-	"insert into a values(1,2)":              query.NotSupportedErrMessage,
-	"update a set id = 1":                    query.NotSupportedErrMessage,
-	"delete from a where id=1":               query.NotSupportedErrMessage,
-	"select * from a where NOT(id=1)":        query.NotSupportedErrMessage,
-	"select * from a where 1 = 1":            query.InvalidExpressionErrMessage,
-	"select * from a where 1=a":              query.InvalidExpressionErrMessage,
-	"select * from a where zz(k=2)":          query.NotSupportedErrMessage,
-	"select * from a group by k, m":          query.NotSupportedErrMessage,
-	"select * from a group by k order by id": query.NotSupportedErrMessage,
-	"select * from a where a like '%a%'":     "operator 'like' not allowed in comparison expression",
-	"select * from a where a not like '%a%'": "operator 'not like' not allowed in comparison expression",
-	"invalid query":                          query.MalformedSqlQueryErrMessage,
+	"delete":                                  query.MalformedSqlQueryErrMessage,
+	"update x":                                query.MalformedSqlQueryErrMessage,
+	"insert ":                                 query.MalformedSqlQueryErrMessage,
+	"insert into a values(1,2)":               query.NotSupportedErrMessage,
+	"update a set id = 1":                     query.NotSupportedErrMessage,
+	"delete from a where id=1":                query.NotSupportedErrMessage,
+	"select * from a where 1 = 1":             query.InvalidExpressionErrMessage,
+	"select * from a where 1=a":               query.InvalidExpressionErrMessage,
+	"select * from a where zz(k=2)":           query.NotSupportedErrMessage,
+	"select * from a where zz(k) = 2":         query.NotSupportedErrMessage,
+	"select * from a where lower(k) > 'a'":    query.NotSupportedErrMessage,
+	"select * from a where lower(k) = 1":      query.InvalidExpressionErrMessage,
+	"select * from a where lower(k, m) = 'a'": query.InvalidExpressionErrMessage,
+	"select * from a where date_trunc('fortnight', t) = '2023-01-01T10:00:00Z'": query.InvalidExpressionErrMessage,
+	"select * from a where date_trunc(hour, t) = '2023-01-01T10:00:00Z'":        query.InvalidExpressionErrMessage,
+	"select * from a where date_trunc('hour', t) in ('2023-01-01T10:00:00Z')":   query.NotSupportedErrMessage,
+	"select * from a where date_trunc('hour', t) = 'yesterday'":                 query.InvalidExpressionErrMessage,
+	"select * from a where t > now(1)":                                          query.InvalidExpressionErrMessage,
+	"select * from a where t > now() * 2":                                       query.NotSupportedErrMessage,
+	"select * from a where t > now() - 1":                                       query.InvalidExpressionErrMessage,
+	"select * from a where t > now() - interval 1 fortnight":                    query.InvalidExpressionErrMessage,
+	"select * from a where t > zz()":                                            query.NotSupportedErrMessage,
+	"select * from a group by date_trunc('day', t), date_trunc('hour', t)":      query.InvalidExpressionErrMessage,
+	"select sum(m) from a group by k":                                           query.NotSupportedErrMessage,
+	"select * from a group by k, max(m)":                                        query.NotSupportedErrMessage,
+	"select k from a group by k":                                                query.NotSupportedErrMessage,
+	"select max(m) from a order by id":                                          query.NotSupportedErrMessage,
+	"select * from a group by k order by id":                                    query.NotSupportedErrMessage,
+	"select * from a where a like '%a%'":                                        "operator 'like' not allowed in comparison expression",
+	"select * from a where a not like '%a%'":                                    "operator 'not like' not allowed in comparison expression",
+	"invalid query":                                                             query.MalformedSqlQueryErrMessage,
 	"select * from a where  a= 1 and multi_match(zz=1, query='this is a test', fields=(title,title.origin), type=phrase)": query.NotSupportedErrMessage,
 }
 
@@ -93,8 +111,21 @@ var supportedWhereCases = map[string]string{
 	"create_time BETWEEN '2015-01-01 00:00:00' and '2016-02-02 00:00:00'":     `{"bool":{"filter":{"range":{"create_time":{"from":"2015-01-01 00:00:00","include_lower":true,"include_upper":true,"to":"2016-02-02 00:00:00"}}}}}`,
 	"create_time nOt between '2015-01-01 00:00:00' and '2016-02-02 00:00:00'": `{"bool":{"must_not":{"range":{"create_time":{"from":"2015-01-01 00:00:00","include_lower":true,"include_upper":true,"to":"2016-02-02 00:00:00"}}}}}`,
 	"create_time between '2015-01-01T00:00:00+0800' and '2017-01-01T00:00:00+0800' and process_id = 0 and status >= 1 and content = '三个男人' and phone = '15810324322'": `{"bool":{"filter":[{"range":{"create_time":{"from":"2015-01-01T00:00:00+0800","include_lower":true,"include_upper":true,"to":"2017-01-01T00:00:00+0800"}}},{"match":{"process_id":{"query":0}}},{"range":{"status":{"from":1,"include_lower":true,"include_upper":true,"to":null}}},{"match":{"content":{"query":"三个男人"}}},{"match":{"phone":{"query":"15810324322"}}}]}}`,
-	"value starts_with 'prefix'":     `{"bool":{"filter":{"prefix":{"value":"prefix"}}}}`,
-	"value not starts_with 'prefix'": `{"bool":{"must_not":{"prefix":{"value":"prefix"}}}}`,
+	"value starts_with 'prefix'":                      `{"bool":{"filter":{"prefix":{"value":"prefix"}}}}`,
+	"value not starts_with 'prefix'":                  `{"bool":{"must_not":{"prefix":{"value":"prefix"}}}}`,
+	"NOT(id=1)":                                       `{"bool":{"must_not":{"match":{"id":{"query":1}}}}}`,
+	"not (id = 1 or id = 2) and x = 1":                `{"bool":{"filter":[{"bool":{"must_not":{"bool":{"should":[{"match":{"id":{"query":1}}},{"match":{"id":{"query":2}}}]}}}},{"match":{"x":{"query":1}}}]}}`,
+	"lower(a) = 'TeXt'":                               `{"bool":{"filter":{"term":{"a":{"case_insensitive":true,"value":"text"}}}}}`,
+	"lower(a) != 'TeXt'":                              `{"bool":{"must_not":{"term":{"a":{"case_insensitive":true,"value":"text"}}}}}`,
+	"lower(a) in ('A', 'b')":                          `{"bool":{"should":[{"term":{"a":{"case_insensitive":true,"value":"a"}}},{"term":{"a":{"case_insensitive":true,"value":"b"}}}]}}`,
+	"lower(a) not in ('A', 'b')":                      `{"bool":{"must_not":[{"term":{"a":{"case_insensitive":true,"value":"a"}}},{"term":{"a":{"case_insensitive":true,"value":"b"}}}]}}`,
+	"lower(a) starts_with 'Pre'":                      `{"bool":{"filter":{"prefix":{"a":{"case_insensitive":true,"value":"pre"}}}}}`,
+	"date_trunc('hour', t) = '2023-01-01T10:00:00Z'":  `{"bool":{"filter":{"range":{"t":{"from":"2023-01-01T10:00:00Z","include_lower":true,"include_upper":false,"to":"2023-01-01T11:00:00Z"}}}}}`,
+	"date_trunc('HOUR', t) != '2023-01-01T10:00:00Z'": `{"bool":{"must_not":{"range":{"t":{"from":"2023-01-01T10:00:00Z","include_lower":true,"include_upper":false,"to":"2023-01-01T11:00:00Z"}}}}}`,
+	"date_trunc('hour', t) = '2023-01-01T10:30:00Z'":  `{"bool":{"must_not":{"match_all":{}}}}`,
+	"date_trunc('hour', t) != '2023-01-01T10:30:00Z'": `{"bool":{"filter":{"exists":{"field":"t"}}}}`,
+	"date_trunc('day', t) < '2023-01-01T10:00:00Z'":   `{"bool":{"filter":{"range":{"t":{"from":null,"include_lower":true,"include_upper":false,"to":"2023-01-02T00:00:00Z"}}}}}`,
+	"date_trunc('week', t) >= '2023-01-04T10:00:00Z'": `{"bool":{"filter":{"range":{"t":{"from":"2023-01-09T00:00:00Z","include_lower":true,"include_upper":true,"to":null}}}}}`,
 }
 
 var supportedWhereOrderCases = map[string]struct {
@@ -116,9 +147,16 @@ var supportedWhereOrderCases = map[string]struct {
 }
 
 var supportedWhereGroupByCases = map[string]struct {
-	query   string
-	groupBy []string
+	query            string
+	groupBy          []string
+	aggregations     []query.Aggregation
+	groupByDateTrunc map[string]query.DateTruncUnit
 }{
+	"group by date_trunc('day', start_time), status": {
+		query:            ``,
+		groupBy:          []string{"start_time", "status"},
+		groupByDateTrunc: map[string]query.DateTruncUnit{"start_time": query.DateTruncUnitDay},
+	},
 	"group by status": {
 		query:   ``,
 		groupBy: []string{"status"},
@@ -127,6 +165,24 @@ var supportedWhereGroupByCases = map[string]struct {
 		query:   `{"bool":{"filter":{"match":{"id":{"query":1}}}}}`,
 		groupBy: []string{"status"},
 	},
+	"group by status, type": {
+		query:   ``,
+		groupBy: []string{"status", "type"},
+	},
+	"SELECT MAX(close_time), avg(duration) GROUP BY status": {
+		query:   ``,
+		groupBy: []string{"status"},
+		aggregations: []query.Aggregation{
+			{Func: query.AggregationFuncMax, FieldName: "close_time"},
+			{Func: query.AggregationFuncAvg, FieldName: "duration"},
+		},
+	},
+	"select min(start_time) where id = 1": {
+		query: `{"bool":{"filter":{"match":{"id":{"query":1}}}}}`,
+		aggregations: []query.Aggregation{
+			{Func: query.AggregationFuncMin, FieldName: "start_time"},
+		},
+	},
 }
 
 func TestSupportedSelectWhere(t *testing.T) {
@@ -196,7 +252,36 @@ func TestSupportedSelectWhereGroupBy(t *testing.T) {
 			assert.Nil(t, queryParams.Query)
 		}
 		assert.Equal(t, expectedJson.groupBy, queryParams.GroupBy)
+		assert.Equal(t, expectedJson.aggregations, queryParams.Aggregations)
+		assert.Equal(t, expectedJson.groupByDateTrunc, queryParams.GroupByDateTrunc)
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	c := newQueryConverter(nil, nil)
+	before := time.Now().UTC()
+	queryParams, err := c.ConvertWhereOrderBy("t > now() - interval 1 hour and t between now() - interval 2 day and now()")
+	after := time.Now().UTC()
+	assert.NoError(t, err)
+
+	boolQuery, ok := queryParams.Query.(*elastic.BoolQuery)
+	assert.True(t, ok)
+	src, err := boolQuery.Source()
+	assert.NoError(t, err)
+	filters := src.(map[string]interface{})["bool"].(map[string]interface{})["filter"].([]interface{})
+	assert.Len(t, filters, 2)
+
+	assertTimeBetween := func(value interface{}, from time.Time, to time.Time) {
+		tm, err := time.Parse(time.RFC3339Nano, value.(string))
+		assert.NoError(t, err)
+		assert.False(t, tm.Before(from), tm)
+		assert.False(t, tm.After(to), tm)
 	}
+	gtRange := filters[0].(map[string]interface{})["range"].(map[string]interface{})["t"].(map[string]interface{})
+	assertTimeBetween(gtRange["from"], before.Add(-time.Hour), after.Add(-time.Hour))
+	betweenRange := filters[1].(map[string]interface{})["range"].(map[string]interface{})["t"].(map[string]interface{})
+	assertTimeBetween(betweenRange["from"], before.AddDate(0, 0, -2), after.AddDate(0, 0, -2))
+	assertTimeBetween(betweenRange["to"], before, after)
 }
 
 func TestErrors(t *testing.T) {
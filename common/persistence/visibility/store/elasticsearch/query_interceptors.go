@@ -112,11 +112,16 @@ func (ni *nameInterceptor) Name(name string, usage query.FieldNameUsage) (string
 			)
 		}
 	case query.FieldNameGroupBy:
-		if fieldName != searchattribute.ExecutionStatus {
-			return "", query.NewConverterError(
-				"'group by' clause is only supported for %s search attribute",
-				searchattribute.ExecutionStatus,
-			)
+		if err := query.ValidateGroupByFieldType(name, fieldType); err != nil {
+			return "", err
+		}
+	case query.FieldNameLowerFuncArg:
+		if err := query.ValidateFuncFieldType(query.LowerFuncName, name, fieldType); err != nil {
+			return "", err
+		}
+	case query.FieldNameDateTruncFuncArg:
+		if err := query.ValidateFuncFieldType(query.DateTruncFuncName, name, fieldType); err != nil {
+			return "", err
 		}
 	}
 
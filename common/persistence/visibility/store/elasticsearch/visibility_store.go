@@ -41,7 +41,6 @@ import (
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
-	"go.temporal.io/api/workflowservice/v1"
 
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/metrics"
@@ -60,12 +59,16 @@ const (
 	delimiter                    = "~"
 	scrollKeepAliveInterval      = "1m"
 	pointInTimeKeepAliveInterval = "1m"
+
+	// allDocumentsAggName is the name of aggregation used for aggregate functions without group by fields.
+	allDocumentsAggName = "all"
 )
 
 type (
 	visibilityStore struct {
 		esClient                       client.Client
 		index                          string
+		namespaceIndices               client.NamespaceIndices
 		searchAttributesProvider       searchattribute.Provider
 		searchAttributesMapperProvider searchattribute.MapperProvider
 		processor                      Processor
@@ -133,6 +136,7 @@ var (
 func NewVisibilityStore(
 	esClient client.Client,
 	index string,
+	namespaceIndices client.NamespaceIndices,
 	searchAttributesProvider searchattribute.Provider,
 	searchAttributesMapperProvider searchattribute.MapperProvider,
 	processor Processor,
@@ -145,6 +149,7 @@ func NewVisibilityStore(
 	return &visibilityStore{
 		esClient:                       esClient,
 		index:                          index,
+		namespaceIndices:               namespaceIndices,
 		searchAttributesProvider:       searchAttributesProvider,
 		searchAttributesMapperProvider: searchAttributesMapperProvider,
 		processor:                      processor,
@@ -170,6 +175,14 @@ func (s *visibilityStore) GetIndexName() string {
 	return s.index
 }
 
+// getNamespaceIndexName returns the index the visibility records of the namespace are routed to.
+// Search attributes are always read for the visibility index, because they are managed
+// together for all indices. Namespaces renamed for deletion keep being routed by their
+// previous name, so that their executions can still be found and deleted.
+func (s *visibilityStore) getNamespaceIndexName(namespaceID namespace.ID, namespaceName namespace.Name) string {
+	return s.namespaceIndices.GetIndex(namespace.NameBeforeDeletion(namespaceName, namespaceID).String(), s.index)
+}
+
 func (s *visibilityStore) ValidateCustomSearchAttributes(
 	searchAttributes map[string]any,
 ) (map[string]any, error) {
@@ -212,7 +225,7 @@ func (s *visibilityStore) RecordWorkflowExecutionStarted(
 	ctx context.Context,
 	request *store.InternalRecordWorkflowExecutionStartedRequest,
 ) error {
-	visibilityTaskKey := getVisibilityTaskKey(request.ShardID, request.TaskID)
+	visibilityTaskKey := getRequestVisibilityTaskKey(request.InternalVisibilityRequestBase)
 	doc, err := s.generateESDoc(request.InternalVisibilityRequestBase, visibilityTaskKey)
 	if err != nil {
 		return err
@@ -225,7 +238,7 @@ func (s *visibilityStore) RecordWorkflowExecutionClosed(
 	ctx context.Context,
 	request *store.InternalRecordWorkflowExecutionClosedRequest,
 ) error {
-	visibilityTaskKey := getVisibilityTaskKey(request.ShardID, request.TaskID)
+	visibilityTaskKey := getRequestVisibilityTaskKey(request.InternalVisibilityRequestBase)
 	doc, err := s.generateESDoc(request.InternalVisibilityRequestBase, visibilityTaskKey)
 	if err != nil {
 		return err
@@ -244,7 +257,7 @@ func (s *visibilityStore) UpsertWorkflowExecution(
 	ctx context.Context,
 	request *store.InternalUpsertWorkflowExecutionRequest,
 ) error {
-	visibilityTaskKey := getVisibilityTaskKey(request.ShardID, request.TaskID)
+	visibilityTaskKey := getRequestVisibilityTaskKey(request.InternalVisibilityRequestBase)
 	doc, err := s.generateESDoc(request.InternalVisibilityRequestBase, visibilityTaskKey)
 	if err != nil {
 		return err
@@ -260,7 +273,7 @@ func (s *visibilityStore) DeleteWorkflowExecution(
 	docID := getDocID(request.WorkflowID, request.RunID)
 
 	bulkDeleteRequest := &client.BulkableRequest{
-		Index:       s.index,
+		Index:       s.getNamespaceIndexName(request.NamespaceID, request.Namespace),
 		ID:          docID,
 		Version:     request.TaskID,
 		RequestType: client.BulkableRequestTypeDelete,
@@ -291,6 +304,16 @@ func getVisibilityTaskKey(shardID int32, taskID int64) string {
 	return strconv.FormatInt(int64(shardID), 10) + delimiter + strconv.FormatInt(taskID, 10)
 }
 
+// getRequestVisibilityTaskKey returns the key of the request in the bulk processor. Requests which are
+// not written by a visibility task (e.g. visibility backfill) don't have a task ID, so they are keyed
+// by the document ID, same as deletes, to not be dropped as duplicates of each other.
+func getRequestVisibilityTaskKey(request *store.InternalVisibilityRequestBase) string {
+	if request.TaskID == 0 {
+		return getDocID(request.WorkflowID, request.RunID)
+	}
+	return getVisibilityTaskKey(request.ShardID, request.TaskID)
+}
+
 func (s *visibilityStore) addBulkIndexRequestAndWait(
 	ctx context.Context,
 	request *store.InternalVisibilityRequestBase,
@@ -298,7 +321,7 @@ func (s *visibilityStore) addBulkIndexRequestAndWait(
 	visibilityTaskKey string,
 ) error {
 	bulkIndexRequest := &client.BulkableRequest{
-		Index:       s.index,
+		Index:       s.getNamespaceIndexName(namespace.ID(request.NamespaceID), namespace.Name(request.Namespace)),
 		ID:          getDocID(request.WorkflowID, request.RunID),
 		Version:     request.TaskID,
 		RequestType: client.BulkableRequestTypeIndex,
@@ -586,7 +609,7 @@ func (s *visibilityStore) scanWorkflowExecutionsWithPit(
 
 	// First call doesn't have token with PointInTimeID.
 	if len(request.NextPageToken) == 0 {
-		pitID, err := s.esClient.OpenPointInTime(ctx, s.index, pointInTimeKeepAliveInterval)
+		pitID, err := s.esClient.OpenPointInTime(ctx, s.getNamespaceIndexName(request.NamespaceID, request.Namespace), pointInTimeKeepAliveInterval)
 		if err != nil {
 			return nil, convertElasticsearchClientError("Unable to create point in time", err)
 		}
@@ -620,11 +643,12 @@ func (s *visibilityStore) CountWorkflowExecutions(
 		return nil, err
 	}
 
-	if len(queryParams.GroupBy) > 0 {
-		return s.countGroupByWorkflowExecutions(ctx, queryParams)
+	indexName := s.getNamespaceIndexName(request.NamespaceID, request.Namespace)
+	if queryParams.IsAggregation() {
+		return s.countGroupByWorkflowExecutions(ctx, indexName, queryParams)
 	}
 
-	count, err := s.esClient.Count(ctx, s.index, queryParams.Query)
+	count, err := s.esClient.Count(ctx, indexName, queryParams.Query)
 	if err != nil {
 		return nil, convertElasticsearchClientError("CountWorkflowExecutions failed", err)
 	}
@@ -635,10 +659,55 @@ func (s *visibilityStore) CountWorkflowExecutions(
 
 func (s *visibilityStore) countGroupByWorkflowExecutions(
 	ctx context.Context,
+	indexName string,
 	queryParams *query.QueryParams,
 ) (*manager.CountWorkflowExecutionsResponse, error) {
 	groupByFields := queryParams.GroupBy
 
+	typeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.index, false)
+	if err != nil {
+		return nil, serviceerror.NewUnavailable(
+			fmt.Sprintf("Unable to read search attribute types: %v", err),
+		)
+	}
+	aggValueTypes := make([]enumspb.IndexedValueType, len(queryParams.Aggregations))
+	metricAggs := make(map[string]elastic.Aggregation, len(queryParams.Aggregations))
+	for i, aggregation := range queryParams.Aggregations {
+		fieldType, err := typeMap.GetType(aggregation.FieldName)
+		if err != nil {
+			return nil, err
+		}
+		err = query.ValidateAggregationFieldType(aggregation.Func, aggregation.FieldName, fieldType)
+		if err != nil {
+			var converterErr *query.ConverterError
+			if errors.As(err, &converterErr) {
+				return nil, converterErr.ToInvalidArgument()
+			}
+			return nil, err
+		}
+		aggValueTypes[i] = query.AggregationValueType(aggregation.Func, fieldType)
+		metricAggs[getMetricAggName(i, aggregation)] = buildMetricAggregation(aggregation)
+	}
+
+	if len(groupByFields) == 0 {
+		// Aggregate functions without group by fields produce single group with all documents.
+		filterAgg := elastic.NewFilterAggregation().Filter(elastic.NewMatchAllQuery())
+		for name, metricAgg := range metricAggs {
+			filterAgg.SubAggregation(name, metricAgg)
+		}
+		esResponse, err := s.esClient.CountGroupBy(
+			ctx,
+			indexName,
+			queryParams.Query,
+			allDocumentsAggName,
+			filterAgg,
+		)
+		if err != nil {
+			return nil, convertElasticsearchClientError("CountWorkflowExecutions failed", err)
+		}
+		return s.parseCountGroupByResponse(esResponse, queryParams, typeMap, aggValueTypes)
+	}
+
 	// Elasticsearch aggregation is nested. so need to loop backwards to build it.
 	// Example: when grouping by (field1, field2), the object looks like
 	// {
@@ -657,23 +726,75 @@ func (s *visibilityStore) countGroupByWorkflowExecutions(
 	//     }
 	//   }
 	// }
-	termsAgg := elastic.NewTermsAggregation().Field(groupByFields[len(groupByFields)-1])
+	// Metric aggregations (min, max, avg) are sub-aggregations of the innermost terms aggregation.
+	// Fields bucketed with date_trunc function use date_histogram aggregation instead of terms aggregation.
+	groupByAgg := buildGroupByAggregation(
+		groupByFields[len(groupByFields)-1],
+		queryParams.GroupByDateTrunc[groupByFields[len(groupByFields)-1]],
+		metricAggs,
+	)
 	for i := len(groupByFields) - 2; i >= 0; i-- {
-		termsAgg = elastic.NewTermsAggregation().
-			Field(groupByFields[i]).
-			SubAggregation(groupByFields[i+1], termsAgg)
+		groupByAgg = buildGroupByAggregation(
+			groupByFields[i],
+			queryParams.GroupByDateTrunc[groupByFields[i]],
+			map[string]elastic.Aggregation{groupByFields[i+1]: groupByAgg},
+		)
 	}
 	esResponse, err := s.esClient.CountGroupBy(
 		ctx,
-		s.index,
+		indexName,
 		queryParams.Query,
 		groupByFields[0],
-		termsAgg,
+		groupByAgg,
 	)
 	if err != nil {
-		return nil, err
+		return nil, convertElasticsearchClientError("CountWorkflowExecutions failed", err)
+	}
+	return s.parseCountGroupByResponse(esResponse, queryParams, typeMap, aggValueTypes)
+}
+
+func buildGroupByAggregation(
+	fieldName string,
+	dateTruncUnit query.DateTruncUnit,
+	subAggs map[string]elastic.Aggregation,
+) elastic.Aggregation {
+	if dateTruncUnit == "" {
+		termsAgg := elastic.NewTermsAggregation().Field(fieldName)
+		for name, subAgg := range subAggs {
+			termsAgg.SubAggregation(name, subAgg)
+		}
+		return termsAgg
+	}
+
+	// Empty buckets are skipped to match terms aggregation and SQL group by.
+	dateHistogramAgg := elastic.NewDateHistogramAggregation().Field(fieldName).MinDocCount(1)
+	if dateTruncUnit == query.DateTruncUnitSecond {
+		// Second is not a calendar interval.
+		dateHistogramAgg.FixedInterval("1s")
+	} else {
+		dateHistogramAgg.CalendarInterval(string(dateTruncUnit))
+	}
+	for name, subAgg := range subAggs {
+		dateHistogramAgg.SubAggregation(name, subAgg)
+	}
+	return dateHistogramAgg
+}
+
+func getMetricAggName(index int, aggregation query.Aggregation) string {
+	// Index makes name unique when the same aggregation is requested more than once.
+	return fmt.Sprintf("%s_%d", aggregation.Func, index)
+}
+
+func buildMetricAggregation(aggregation query.Aggregation) elastic.Aggregation {
+	switch aggregation.Func {
+	case query.AggregationFuncMin:
+		return elastic.NewMinAggregation().Field(aggregation.FieldName)
+	case query.AggregationFuncMax:
+		return elastic.NewMaxAggregation().Field(aggregation.FieldName)
+	case query.AggregationFuncAvg:
+		return elastic.NewAvgAggregation().Field(aggregation.FieldName)
 	}
-	return s.parseCountGroupByResponse(esResponse, groupByFields)
+	panic(fmt.Sprintf("Unknown aggregation function: %v", aggregation.Func))
 }
 
 func (s *visibilityStore) GetWorkflowExecution(
@@ -681,7 +802,7 @@ func (s *visibilityStore) GetWorkflowExecution(
 	request *manager.GetWorkflowExecutionRequest,
 ) (*store.InternalGetWorkflowExecutionResponse, error) {
 	docID := getDocID(request.WorkflowID, request.RunID)
-	result, err := s.esClient.Get(ctx, s.index, docID)
+	result, err := s.esClient.Get(ctx, s.getNamespaceIndexName(request.NamespaceID, request.Namespace), docID)
 	if err != nil {
 		return nil, convertElasticsearchClientError("GetWorkflowExecution failed", err)
 	}
@@ -747,7 +868,7 @@ func (s *visibilityStore) buildSearchParameters(
 	}
 
 	params := &client.SearchParameters{
-		Index:    s.index,
+		Index:    s.getNamespaceIndexName(request.NamespaceID, request.Namespace),
 		Query:    boolQuery,
 		PageSize: request.PageSize,
 		Sorter:   defaultSorter,
@@ -774,13 +895,13 @@ func (s *visibilityStore) buildSearchParametersV2(
 	}
 
 	searchParams := &client.SearchParameters{
-		Index:    s.index,
+		Index:    s.getNamespaceIndexName(request.NamespaceID, request.Namespace),
 		PageSize: request.PageSize,
 		Query:    queryParams.Query,
 	}
 
-	if len(queryParams.GroupBy) > 0 {
-		return nil, serviceerror.NewInvalidArgument("GROUP BY clause is not supported")
+	if queryParams.IsAggregation() {
+		return nil, serviceerror.NewInvalidArgument("GROUP BY clause and aggregate functions are not supported")
 	}
 
 	// TODO(rodrigozhou): investigate possible solutions to slow ORDER BY.
@@ -1203,15 +1324,12 @@ func (s *visibilityStore) parseESDoc(docID string, docSource json.RawMessage, sa
 //nolint:revive // cognitive complexity 27 (> max enabled 25)
 func (s *visibilityStore) parseCountGroupByResponse(
 	searchResult *elastic.SearchResult,
-	groupByFields []string,
+	queryParams *query.QueryParams,
+	typeMap searchattribute.NameTypeMap,
+	aggValueTypes []enumspb.IndexedValueType,
 ) (*manager.CountWorkflowExecutionsResponse, error) {
+	groupByFields := queryParams.GroupBy
 	response := &manager.CountWorkflowExecutionsResponse{}
-	typeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.index, false)
-	if err != nil {
-		return nil, serviceerror.NewUnavailable(
-			fmt.Sprintf("Unable to read search attribute types: %v", err),
-		)
-	}
 	groupByTypes := make([]enumspb.IndexedValueType, len(groupByFields))
 	for i, saName := range groupByFields {
 		tp, err := typeMap.GetType(saName)
@@ -1240,11 +1358,20 @@ func (s *visibilityStore) parseCountGroupByResponse(
 			for i := range bucketValues {
 				groupValues[i] = bucketValues[i]
 			}
+			var aggregationValues []*commonpb.Payload
+			for i, aggregation := range queryParams.Aggregations {
+				payload, err := parseMetricAggValue(aggs[getMetricAggName(i, aggregation)], aggValueTypes[i])
+				if err != nil {
+					return fmt.Errorf("Failed to parse %s value: %w", aggregation, err)
+				}
+				aggregationValues = append(aggregationValues, payload)
+			}
 			response.Groups = append(
 				response.Groups,
-				&workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
-					GroupValues: groupValues,
-					Count:       cnt,
+				&manager.AggregationGroup{
+					GroupValues:       groupValues,
+					AggregationValues: aggregationValues,
+					Count:             cnt,
 				},
 			)
 			response.Count += cnt
@@ -1254,9 +1381,19 @@ func (s *visibilityStore) parseCountGroupByResponse(
 		index := len(bucketValues)
 		fieldName := groupByFields[index]
 		buckets := aggs[fieldName].(map[string]any)["buckets"].([]any)
+		_, isDateTrunc := queryParams.GroupByDateTrunc[fieldName]
 		for i := range buckets {
 			bucket := buckets[i].(map[string]any)
-			value, err := finishParseJSONValue(bucket["key"], groupByTypes[index])
+			var value any
+			var err error
+			if isDateTrunc {
+				// Date histogram bucket key is the start of the bucket in epoch millis.
+				var millis int64
+				millis, err = parseJsonNumber(bucket["key"])
+				value = time.UnixMilli(millis).UTC()
+			} else {
+				value, err = finishParseJSONValue(bucket["key"], groupByTypes[index])
+			}
 			if err != nil {
 				return fmt.Errorf("Failed to parse value %v: %w", bucket["key"], err)
 			}
@@ -1272,18 +1409,80 @@ func (s *visibilityStore) parseCountGroupByResponse(
 		return nil
 	}
 
+	aggName := allDocumentsAggName
+	if len(groupByFields) > 0 {
+		aggName = groupByFields[0]
+	}
 	var bucketsJson map[string]any
-	dec := json.NewDecoder(bytes.NewReader(searchResult.Aggregations[groupByFields[0]]))
+	dec := json.NewDecoder(bytes.NewReader(searchResult.Aggregations[aggName]))
 	dec.UseNumber()
 	if err := dec.Decode(&bucketsJson); err != nil {
 		return nil, serviceerror.NewInternal(fmt.Sprintf("unable to unmarshal json response: %v", err))
 	}
+	if len(groupByFields) == 0 {
+		// Filter aggregation is a single bucket.
+		if err := parseInternal(bucketsJson, nil); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
 	if err := parseInternal(map[string]any{groupByFields[0]: bucketsJson}, nil); err != nil {
 		return nil, err
 	}
 	return response, nil
 }
 
+// parseMetricAggValue parses result of min, max, or avg aggregation:
+//
+//	{"value": 1.7E12, "value_as_string": "2023-11-14T22:13:20.000Z"}
+//
+// Value is null if there are no documents with the field.
+func parseMetricAggValue(agg any, t enumspb.IndexedValueType) (*commonpb.Payload, error) {
+	aggMap, isMap := agg.(map[string]any)
+	if !isMap {
+		return nil, fmt.Errorf("%w: expected object got %T", errUnexpectedJSONFieldType, agg)
+	}
+	if aggMap["value"] == nil {
+		return searchattribute.EncodeValue(nil, t)
+	}
+	numberVal, isNumber := aggMap["value"].(json.Number)
+	if !isNumber {
+		return nil, fmt.Errorf("%w: expected json.Number got %T", errUnexpectedJSONFieldType, aggMap["value"])
+	}
+
+	var value any
+	switch t {
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		if stringVal, isString := aggMap["value_as_string"].(string); isString {
+			timeVal, err := time.Parse(time.RFC3339Nano, stringVal)
+			if err != nil {
+				return nil, err
+			}
+			value = timeVal
+			break
+		}
+		millis, err := numberVal.Float64()
+		if err != nil {
+			return nil, err
+		}
+		value = time.UnixMilli(int64(millis)).UTC()
+	case enumspb.INDEXED_VALUE_TYPE_INT:
+		// Elasticsearch returns metric values as doubles.
+		floatVal, err := numberVal.Float64()
+		if err != nil {
+			return nil, err
+		}
+		value = int64(math.Round(floatVal))
+	default:
+		floatVal, err := numberVal.Float64()
+		if err != nil {
+			return nil, err
+		}
+		value = floatVal
+	}
+	return searchattribute.EncodeValue(value, t)
+}
+
 // finishParseJSONValue finishes JSON parsing after json.Decode.
 // json.Decode returns:
 //
@@ -41,11 +41,11 @@ import (
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
-	"go.temporal.io/api/workflowservice/v1"
 
 	"go.temporal.io/api/temporalproto"
 	"go.temporal.io/server/common/debug"
 	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/future"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence/visibility/manager"
@@ -136,6 +136,7 @@ func (s *ESVisibilitySuite) SetupTest() {
 	s.visibilityStore = NewVisibilityStore(
 		s.mockESClient,
 		testIndex,
+		nil,
 		searchattribute.NewTestProvider(),
 		searchattribute.NewTestMapperProvider(nil),
 		s.mockProcessor,
@@ -707,6 +708,17 @@ func (s *ESVisibilitySuite) Test_convertQuery() {
 	s.IsType(&serviceerror.InvalidArgument{}, err)
 	s.Equal(err.Error(), "invalid query: unable to convert filter expression: unable to convert values of comparison expression: invalid value for search attribute ExecutionTime of type Datetime: \"unable to parse\"")
 
+	query = `not lower(WorkflowType) = 'MyWorkflow' and date_trunc('hour', StartTime) = 1528358400000000000`
+	queryParams, err = s.visibilityStore.convertQuery(testNamespace, testNamespaceID, query)
+	s.NoError(err)
+	s.Equal(`{"bool":{"filter":[{"term":{"NamespaceId":"bfd5c907-f899-4baf-a7b2-2ab85e623ebd"}},{"bool":{"filter":[{"bool":{"must_not":{"term":{"WorkflowType":{"case_insensitive":true,"value":"myworkflow"}}}}},{"range":{"StartTime":{"from":"2018-06-07T08:00:00Z","include_lower":true,"include_upper":false,"to":"2018-06-07T09:00:00Z"}}}]}}],"must_not":{"exists":{"field":"TemporalNamespaceDivision"}}}}`, s.queryToJSON(queryParams.Query))
+
+	query = `lower(CustomTextField) = 'text'`
+	_, err = s.visibilityStore.convertQuery(testNamespace, testNamespaceID, query)
+	s.Error(err)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+	s.Contains(err.Error(), "function 'lower' is only supported for Keyword search attributes")
+
 	// invalid union injection
 	query = `WorkflowId = 'wid' union select * from dummy`
 	queryParams, err = s.visibilityStore.convertQuery(testNamespace, testNamespaceID, query)
@@ -1338,6 +1350,77 @@ func (s *ESVisibilitySuite) TestScanWorkflowExecutions_Pit() {
 	s.Contains(err.Error(), "ScanWorkflowExecutions failed")
 }
 
+func (s *ESVisibilitySuite) TestNamespaceIndex_Read() {
+	s.visibilityStore.namespaceIndices = client.NamespaceIndices{
+		{Namespace: "other-namespace", Index: "other-index"},
+		{Namespace: "test-*", Index: "tenant-index"},
+	}
+
+	s.mockESClient.EXPECT().Count(gomock.Any(), "tenant-index", gomock.Any()).Return(int64(1), nil)
+	countResponse, err := s.visibilityStore.CountWorkflowExecutions(context.Background(), &manager.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+	})
+	s.NoError(err)
+	s.Equal(int64(1), countResponse.Count)
+
+	s.mockESClient.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, p *client.SearchParameters) (*elastic.SearchResult, error) {
+			s.Equal("tenant-index", p.Index)
+			return testSearchResult, nil
+		})
+	_, err = s.visibilityStore.ListWorkflowExecutions(context.Background(), &manager.ListWorkflowExecutionsRequestV2{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		PageSize:    testPageSize,
+	})
+	s.NoError(err)
+}
+
+func (s *ESVisibilitySuite) TestNamespaceIndex_DeletedNamespace() {
+	s.visibilityStore.namespaceIndices = client.NamespaceIndices{
+		{Namespace: "test-*", Index: "tenant-index"},
+	}
+	// Delete namespace workflow renames the namespace before deleting its executions.
+	deletedNamespace := namespace.Name("test-namespace-deleted-bfd5c")
+
+	s.mockESClient.EXPECT().Count(gomock.Any(), "tenant-index", gomock.Any()).Return(int64(1), nil)
+	countResponse, err := s.visibilityStore.CountWorkflowExecutions(context.Background(), &manager.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   deletedNamespace,
+	})
+	s.NoError(err)
+	s.Equal(int64(1), countResponse.Count)
+
+	s.mockESClient.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, p *client.SearchParameters) (*elastic.SearchResult, error) {
+			s.Equal("tenant-index", p.Index)
+			return testSearchResult, nil
+		})
+	_, err = s.visibilityStore.ListWorkflowExecutions(context.Background(), &manager.ListWorkflowExecutionsRequestV2{
+		NamespaceID: testNamespaceID,
+		Namespace:   deletedNamespace,
+		PageSize:    testPageSize,
+		Query:       searchattribute.QueryWithAnyNamespaceDivision(""),
+	})
+	s.NoError(err)
+
+	s.mockProcessor.EXPECT().Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(bulkRequest *client.BulkableRequest, visibilityTaskKey string) future.Future[bool] {
+			s.Equal("tenant-index", bulkRequest.Index)
+			f := future.NewFuture[bool]()
+			f.Set(true, nil)
+			return f
+		})
+	err = s.visibilityStore.DeleteWorkflowExecution(context.Background(), &manager.VisibilityDeleteWorkflowExecutionRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   deletedNamespace,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	})
+	s.NoError(err)
+}
+
 func (s *ESVisibilitySuite) TestCountWorkflowExecutions() {
 	s.mockESClient.EXPECT().Count(gomock.Any(), testIndex, gomock.Any()).DoAndReturn(
 		func(ctx context.Context, index string, query elastic.Query) (int64, error) {
@@ -1427,7 +1510,7 @@ func (s *ESVisibilitySuite) TestCountWorkflowExecutions_GroupBy() {
 	s.True(temporalproto.DeepEqual(
 		&manager.CountWorkflowExecutionsResponse{
 			Count: 110,
-			Groups: []*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+			Groups: []*manager.AggregationGroup{
 				{
 					GroupValues: []*commonpb.Payload{payload1},
 					Count:       100,
@@ -1441,18 +1524,162 @@ func (s *ESVisibilitySuite) TestCountWorkflowExecutions_GroupBy() {
 		resp),
 	)
 
-	// test only allowed to group by a single field
-	request.Query = "GROUP BY ExecutionStatus, WorkflowType"
+	// test only allowed to group by keyword fields
+	request.Query = "GROUP BY CustomIntField"
+	resp, err = s.visibilityStore.CountWorkflowExecutions(context.Background(), request)
+	s.Error(err)
+	s.Contains(err.Error(), "'group by' clause is only supported for Keyword search attributes")
+	s.Nil(resp)
+
+	// test avg is not allowed for datetime fields
+	request.Query = "SELECT AVG(StartTime) GROUP BY ExecutionStatus"
 	resp, err = s.visibilityStore.CountWorkflowExecutions(context.Background(), request)
 	s.Error(err)
-	s.Contains(err.Error(), "'group by' clause supports only a single field")
+	s.Contains(err.Error(), "function 'avg' is not supported for StartTime")
 	s.Nil(resp)
+}
+
+func (s *ESVisibilitySuite) TestCountWorkflowExecutions_Aggregations() {
+	request := &manager.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		Query:       "SELECT MIN(StartTime), MAX(CloseTime), AVG(ExecutionDuration)",
+	}
+	s.mockESClient.EXPECT().
+		CountGroupBy(
+			gomock.Any(),
+			testIndex,
+			elastic.NewBoolQuery().
+				Filter(elastic.NewTermQuery(searchattribute.NamespaceID, testNamespaceID.String())).
+				MustNot(namespaceDivisionExists),
+			allDocumentsAggName,
+			elastic.NewFilterAggregation().
+				Filter(elastic.NewMatchAllQuery()).
+				SubAggregation("min_0", elastic.NewMinAggregation().Field(searchattribute.StartTime)).
+				SubAggregation("max_1", elastic.NewMaxAggregation().Field(searchattribute.CloseTime)).
+				SubAggregation("avg_2", elastic.NewAvgAggregation().Field(searchattribute.ExecutionDuration)),
+		).
+		Return(
+			&elastic.SearchResult{
+				Aggregations: map[string]json.RawMessage{
+					allDocumentsAggName: json.RawMessage(
+						`{
+							"doc_count": 110,
+							"min_0": {"value": 1.6999776E12, "value_as_string": "2023-11-14T05:20:00.000Z"},
+							"max_1": {"value": null},
+							"avg_2": {"value": 2.5E9}
+						}`,
+					),
+				},
+			},
+			nil,
+		)
+	resp, err := s.visibilityStore.CountWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+	minStartTimePayload, _ := searchattribute.EncodeValue(
+		time.Date(2023, 11, 14, 5, 20, 0, 0, time.UTC),
+		enumspb.INDEXED_VALUE_TYPE_DATETIME,
+	)
+	maxCloseTimePayload, _ := searchattribute.EncodeValue(nil, enumspb.INDEXED_VALUE_TYPE_DATETIME)
+	avgDurationPayload, _ := searchattribute.EncodeValue(2.5e9, enumspb.INDEXED_VALUE_TYPE_DOUBLE)
+	s.True(temporalproto.DeepEqual(
+		&manager.CountWorkflowExecutionsResponse{
+			Count: 110,
+			Groups: []*manager.AggregationGroup{
+				{
+					GroupValues:       []*commonpb.Payload{},
+					AggregationValues: []*commonpb.Payload{minStartTimePayload, maxCloseTimePayload, avgDurationPayload},
+					Count:             110,
+				},
+			},
+		},
+		resp),
+	)
+}
+
+func (s *ESVisibilitySuite) TestCountWorkflowExecutions_GroupByDateTrunc() {
+	request := &manager.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		Query:       "GROUP BY date_trunc('day', StartTime), ExecutionStatus",
+	}
+	s.mockESClient.EXPECT().
+		CountGroupBy(
+			gomock.Any(),
+			testIndex,
+			elastic.NewBoolQuery().
+				Filter(elastic.NewTermQuery(searchattribute.NamespaceID, testNamespaceID.String())).
+				MustNot(namespaceDivisionExists),
+			searchattribute.StartTime,
+			elastic.NewDateHistogramAggregation().
+				Field(searchattribute.StartTime).
+				MinDocCount(1).
+				CalendarInterval("day").
+				SubAggregation(
+					searchattribute.ExecutionStatus,
+					elastic.NewTermsAggregation().Field(searchattribute.ExecutionStatus),
+				),
+		).
+		Return(
+			&elastic.SearchResult{
+				Aggregations: map[string]json.RawMessage{
+					searchattribute.StartTime: json.RawMessage(
+						`{
+							"buckets":[
+								{
+									"key_as_string": "2023-11-14T00:00:00.000Z",
+									"key": 1699920000000,
+									"doc_count": 15,
+									"ExecutionStatus": {
+										"buckets": [
+											{"key": "Completed", "doc_count": 10},
+											{"key": "Running", "doc_count": 5}
+										]
+									}
+								}
+							]
+						}`,
+					),
+				},
+			},
+			nil,
+		)
+	resp, err := s.visibilityStore.CountWorkflowExecutions(context.Background(), request)
+	s.NoError(err)
+	dayPayload, _ := searchattribute.EncodeValue(
+		time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC),
+		enumspb.INDEXED_VALUE_TYPE_DATETIME,
+	)
+	statusCompletedPayload, _ := searchattribute.EncodeValue(
+		enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	)
+	statusRunningPayload, _ := searchattribute.EncodeValue(
+		enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	)
+	s.True(temporalproto.DeepEqual(
+		&manager.CountWorkflowExecutionsResponse{
+			Count: 15,
+			Groups: []*manager.AggregationGroup{
+				{
+					GroupValues: []*commonpb.Payload{dayPayload, statusCompletedPayload},
+					Count:       10,
+				},
+				{
+					GroupValues: []*commonpb.Payload{dayPayload, statusRunningPayload},
+					Count:       5,
+				},
+			},
+		},
+		resp),
+	)
 
-	// test only allowed to group by ExecutionStatus
-	request.Query = "GROUP BY WorkflowType"
+	// test date_trunc is only allowed for datetime fields
+	request.Query = "GROUP BY date_trunc('day', WorkflowType)"
 	resp, err = s.visibilityStore.CountWorkflowExecutions(context.Background(), request)
 	s.Error(err)
-	s.Contains(err.Error(), "'group by' clause is only supported for ExecutionStatus search attribute")
+	s.Contains(err.Error(), "function 'date_trunc' is only supported for Datetime search attributes")
 	s.Nil(resp)
 }
 
@@ -1476,6 +1703,7 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 	testCases := []struct {
 		name         string
 		groupBy      []string
+		aggregations []query.Aggregation
 		aggName      string
 		agg          elastic.Aggregation
 		mockResponse *elastic.SearchResult
@@ -1506,7 +1734,7 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 			},
 			response: &manager.CountWorkflowExecutionsResponse{
 				Count: 110,
-				Groups: []*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+				Groups: []*manager.AggregationGroup{
 					{
 						GroupValues: []*commonpb.Payload{statusCompletedPayload},
 						Count:       100,
@@ -1571,7 +1799,7 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 			},
 			response: &manager.CountWorkflowExecutionsResponse{
 				Count: 110,
-				Groups: []*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+				Groups: []*manager.AggregationGroup{
 					{
 						GroupValues: []*commonpb.Payload{statusCompletedPayload, wfType1Payload},
 						Count:       75,
@@ -1687,7 +1915,7 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 			},
 			response: &manager.CountWorkflowExecutionsResponse{
 				Count: 110,
-				Groups: []*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
+				Groups: []*manager.AggregationGroup{
 					{
 						GroupValues: []*commonpb.Payload{statusCompletedPayload, wfType1Payload, wfId1Payload},
 						Count:       75,
@@ -1713,13 +1941,81 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 		},
 	}
 
+	maxCloseTimePayload, _ := searchattribute.EncodeValue(
+		time.Date(2023, 11, 14, 5, 20, 0, 123000000, time.UTC),
+		enumspb.INDEXED_VALUE_TYPE_DATETIME,
+	)
+	maxDurationPayload, _ := searchattribute.EncodeValue(int64(3000), enumspb.INDEXED_VALUE_TYPE_INT)
+	testCases = append(testCases, struct {
+		name         string
+		groupBy      []string
+		aggregations []query.Aggregation
+		aggName      string
+		agg          elastic.Aggregation
+		mockResponse *elastic.SearchResult
+		response     *manager.CountWorkflowExecutionsResponse
+	}{
+		name:    "group by two fields with aggregations",
+		groupBy: []string{searchattribute.ExecutionStatus, searchattribute.WorkflowType},
+		aggregations: []query.Aggregation{
+			{Func: query.AggregationFuncMax, FieldName: searchattribute.CloseTime},
+			{Func: query.AggregationFuncMax, FieldName: searchattribute.ExecutionDuration},
+		},
+		aggName: searchattribute.ExecutionStatus,
+		agg: elastic.NewTermsAggregation().Field(searchattribute.ExecutionStatus).SubAggregation(
+			searchattribute.WorkflowType,
+			elastic.NewTermsAggregation().Field(searchattribute.WorkflowType).
+				SubAggregation("max_0", elastic.NewMaxAggregation().Field(searchattribute.CloseTime)).
+				SubAggregation("max_1", elastic.NewMaxAggregation().Field(searchattribute.ExecutionDuration)),
+		),
+		mockResponse: &elastic.SearchResult{
+			Aggregations: map[string]json.RawMessage{
+				searchattribute.ExecutionStatus: json.RawMessage(
+					`{
+						"buckets":[
+							{
+								"key": "Completed",
+								"doc_count": 100,
+								"WorkflowType": {
+									"buckets": [
+										{
+											"key": "wf-type-1",
+											"doc_count": 100,
+											"max_0": {"value": 1.699939200123E12, "value_as_string": "2023-11-14T05:20:00.123Z"},
+											"max_1": {"value": 3000.0}
+										}
+									]
+								}
+							}
+						]
+					}`,
+				),
+			},
+		},
+		response: &manager.CountWorkflowExecutionsResponse{
+			Count: 100,
+			Groups: []*manager.AggregationGroup{
+				{
+					GroupValues: []*commonpb.Payload{
+						statusCompletedPayload,
+						wfType1Payload,
+						maxCloseTimePayload,
+						maxDurationPayload,
+					},
+					Count: 100,
+				},
+			},
+		},
+	})
+
 	for _, tc := range testCases {
 		s.T().Run(tc.name, func(t *testing.T) {
 			searchParams := &query.QueryParams{
 				Query: elastic.NewBoolQuery().
 					Filter(elastic.NewTermQuery(searchattribute.NamespaceID, testNamespaceID.String())).
 					MustNot(namespaceDivisionExists),
-				GroupBy: tc.groupBy,
+				GroupBy:      tc.groupBy,
+				Aggregations: tc.aggregations,
 			}
 			s.mockESClient.EXPECT().
 				CountGroupBy(
@@ -1732,7 +2028,7 @@ func (s *ESVisibilitySuite) TestCountGroupByWorkflowExecutions() {
 					tc.agg,
 				).
 				Return(tc.mockResponse, nil)
-			resp, err := s.visibilityStore.countGroupByWorkflowExecutions(context.Background(), searchParams)
+			resp, err := s.visibilityStore.countGroupByWorkflowExecutions(context.Background(), testIndex, searchParams)
 			s.NoError(err)
 			s.True(temporalproto.DeepEqual(tc.response, resp))
 		})
@@ -1932,6 +2228,7 @@ func (s *ESVisibilitySuite) TestProcessPageToken() {
 			visibilityStore := NewVisibilityStore(
 				s.mockESClient,
 				testIndex,
+				nil,
 				searchattribute.NewTestProvider(),
 				searchattribute.NewTestMapperProvider(nil),
 				s.mockProcessor,
@@ -112,7 +112,8 @@ func (s *ESVisibilitySuite) TestRecordWorkflowExecutionStarted_EmptyRequest() {
 
 	s.mockProcessor.EXPECT().Add(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(bulkRequest *client.BulkableRequest, visibilityTaskKey string) future.Future[bool] {
-			s.Equal("0~0", visibilityTaskKey)
+			// Requests without a task ID are keyed by the document ID.
+			s.Equal("~", visibilityTaskKey)
 
 			body := bulkRequest.Doc
 
@@ -202,7 +203,8 @@ func (s *ESVisibilitySuite) TestRecordWorkflowExecutionClosed_EmptyRequest() {
 
 	s.mockProcessor.EXPECT().Add(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(bulkRequest *client.BulkableRequest, visibilityTaskKey string) future.Future[bool] {
-			s.Equal("0~0", visibilityTaskKey)
+			// Requests without a task ID are keyed by the document ID.
+			s.Equal("~", visibilityTaskKey)
 
 			body := bulkRequest.Doc
 
@@ -252,6 +254,48 @@ func (s *ESVisibilitySuite) TestDeleteExecution() {
 	s.NoError(err)
 }
 
+func (s *ESVisibilitySuite) TestNamespaceIndex_Write() {
+	s.visibilityStore.namespaceIndices = client.NamespaceIndices{
+		{Namespace: "tenant-*", Index: "tenant-index"},
+	}
+	startedRequest := &store.InternalRecordWorkflowExecutionStartedRequest{
+		InternalVisibilityRequestBase: &store.InternalVisibilityRequestBase{
+			NamespaceID: "namespaceID",
+			Namespace:   "tenant-a",
+			WorkflowID:  "wid",
+			RunID:       "rid",
+			Memo:        &commonpb.DataBlob{},
+		},
+	}
+
+	var indices []string
+	s.mockProcessor.EXPECT().Add(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(bulkRequest *client.BulkableRequest, visibilityTaskKey string) future.Future[bool] {
+			indices = append(indices, bulkRequest.Index)
+			f := future.NewFuture[bool]()
+			f.Set(true, nil)
+			return f
+		}).Times(3)
+
+	err := s.visibilityStore.RecordWorkflowExecutionStarted(context.Background(), startedRequest)
+	s.NoError(err)
+	err = s.visibilityStore.DeleteWorkflowExecution(context.Background(), &manager.VisibilityDeleteWorkflowExecutionRequest{
+		NamespaceID: "namespaceID",
+		Namespace:   "tenant-a",
+		WorkflowID:  "wid",
+		RunID:       "rid",
+	})
+	s.NoError(err)
+	err = s.visibilityStore.DeleteWorkflowExecution(context.Background(), &manager.VisibilityDeleteWorkflowExecutionRequest{
+		NamespaceID: "namespaceID",
+		Namespace:   "other-namespace",
+		WorkflowID:  "wid",
+		RunID:       "rid",
+	})
+	s.NoError(err)
+	s.Equal([]string{"tenant-index", "tenant-index", "test-index"}, indices)
+}
+
 func (s *ESVisibilitySuite) TestDeleteExecution_EmptyRequest() {
 	// test empty request
 	request := &manager.VisibilityDeleteWorkflowExecutionRequest{}
@@ -297,3 +341,16 @@ func (s *ESVisibilitySuite) Test_getVisibilityTaskKey() {
 	s.Equal("22~8", getVisibilityTaskKey(22, 8))
 	s.Equal("228~1978", getVisibilityTaskKey(228, 1978))
 }
+
+func (s *ESVisibilitySuite) Test_getRequestVisibilityTaskKey() {
+	s.Equal("22~8", getRequestVisibilityTaskKey(&store.InternalVisibilityRequestBase{
+		WorkflowID: "wid",
+		RunID:      "rid",
+		ShardID:    22,
+		TaskID:     8,
+	}))
+	s.Equal("wid~rid", getRequestVisibilityTaskKey(&store.InternalVisibilityRequestBase{
+		WorkflowID: "wid",
+		RunID:      "rid",
+	}))
+}
@@ -0,0 +1,182 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package embedded
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/visibility/store"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	docKey struct {
+		namespaceID string
+		runID       string
+	}
+
+	// document is a visibility record. Exported fields are persisted in the snapshot,
+	// the others are derived from them when the document is indexed.
+	document struct {
+		NamespaceID          string
+		TaskID               int64
+		WorkflowID           string
+		RunID                string
+		WorkflowTypeName     string
+		StartTime            time.Time
+		ExecutionTime        time.Time
+		CloseTime            time.Time
+		Status               enumspb.WorkflowExecutionStatus
+		HistoryLength        int64
+		HistorySizeBytes     int64
+		StateTransitionCount int64
+		ExecutionDuration    time.Duration
+		TaskQueue            string
+		Memo                 []byte
+		MemoEncoding         string
+		ParentWorkflowID     string
+		ParentRunID          string
+		// SearchAttributes are predefined and custom search attributes with their type set in metadata.
+		SearchAttributes *commonpb.SearchAttributes
+
+		// fields are values of all search attributes set in the document keyed by field name.
+		// Values are string, []string, int64, float64, bool, or time.Time.
+		fields map[string]any
+		// tokens are the number of occurrences of each token of the Text search attributes.
+		tokens map[string]map[string]int
+	}
+)
+
+func (d *document) key() docKey {
+	return docKey{namespaceID: d.NamespaceID, runID: d.RunID}
+}
+
+func (d *document) isClosed() bool {
+	return !d.CloseTime.IsZero()
+}
+
+// build computes the fields and tokens of the document.
+func (d *document) build() error {
+	d.fields = map[string]any{
+		searchattribute.WorkflowID:      d.WorkflowID,
+		searchattribute.RunID:           d.RunID,
+		searchattribute.WorkflowType:    d.WorkflowTypeName,
+		searchattribute.StartTime:       d.StartTime,
+		searchattribute.ExecutionTime:   d.ExecutionTime,
+		searchattribute.ExecutionStatus: d.Status.String(),
+		searchattribute.TaskQueue:       d.TaskQueue,
+	}
+	d.tokens = nil
+	if d.isClosed() {
+		d.fields[searchattribute.CloseTime] = d.CloseTime
+		d.fields[searchattribute.HistoryLength] = d.HistoryLength
+		d.fields[searchattribute.HistorySizeBytes] = d.HistorySizeBytes
+		d.fields[searchattribute.StateTransitionCount] = d.StateTransitionCount
+		d.fields[searchattribute.ExecutionDuration] = d.ExecutionDuration.Nanoseconds()
+	}
+	if d.ParentWorkflowID != "" {
+		d.fields[searchattribute.ParentWorkflowID] = d.ParentWorkflowID
+	}
+	if d.ParentRunID != "" {
+		d.fields[searchattribute.ParentRunID] = d.ParentRunID
+	}
+
+	// Type of each search attribute is taken from the payload metadata.
+	values, err := searchattribute.Decode(d.SearchAttributes, nil, false)
+	if err != nil {
+		return err
+	}
+	for name, value := range values {
+		if value == nil {
+			continue
+		}
+		d.fields[name] = value
+		saType, _ := enumspb.IndexedValueTypeFromString(
+			string(d.SearchAttributes.GetIndexedFields()[name].GetMetadata()[searchattribute.MetadataType]),
+		)
+		if text, ok := value.(string); ok && saType == enumspb.INDEXED_VALUE_TYPE_TEXT {
+			if d.tokens == nil {
+				d.tokens = make(map[string]map[string]int)
+			}
+			d.tokens[name] = countTokens(text)
+		}
+	}
+	return nil
+}
+
+// toInfo returns the visibility info of the document. Search attributes are returned with field
+// names and only if they are defined in saTypeMap.
+func (d *document) toInfo(saTypeMap searchattribute.NameTypeMap) *store.InternalWorkflowExecutionInfo {
+	info := &store.InternalWorkflowExecutionInfo{
+		WorkflowID:           d.WorkflowID,
+		RunID:                d.RunID,
+		TypeName:             d.WorkflowTypeName,
+		StartTime:            d.StartTime,
+		ExecutionTime:        d.ExecutionTime,
+		CloseTime:            d.CloseTime,
+		Status:               d.Status,
+		HistoryLength:        d.HistoryLength,
+		HistorySizeBytes:     d.HistorySizeBytes,
+		StateTransitionCount: d.StateTransitionCount,
+		Memo:                 persistence.NewDataBlob(d.Memo, d.MemoEncoding),
+		TaskQueue:            d.TaskQueue,
+		ParentWorkflowID:     d.ParentWorkflowID,
+		ParentRunID:          d.ParentRunID,
+	}
+	if info.ExecutionTime.IsZero() {
+		info.ExecutionTime = info.StartTime
+	}
+	indexedFields := make(map[string]*commonpb.Payload, len(d.SearchAttributes.GetIndexedFields()))
+	for name, value := range d.SearchAttributes.GetIndexedFields() {
+		if saTypeMap.IsDefined(name) {
+			indexedFields[name] = value
+		}
+	}
+	if len(indexedFields) > 0 {
+		info.SearchAttributes = &commonpb.SearchAttributes{IndexedFields: indexedFields}
+	}
+	return info
+}
+
+// tokenize splits text into lower case tokens of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func countTokens(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, token := range tokenize(text) {
+		counts[token]++
+	}
+	return counts
+}
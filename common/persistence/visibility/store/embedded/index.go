@@ -0,0 +1,205 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package embedded
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+type (
+	docSet map[docKey]struct{}
+
+	// index keeps visibility documents in memory together with inverted indices of
+	// Keyword, KeywordList, and Text search attribute values. It's safe for concurrent use.
+	index struct {
+		sync.RWMutex
+		docs map[docKey]*document
+		// namespaces are documents of each namespace keyed by namespace ID.
+		namespaces map[string]docSet
+		// postings are documents with Keyword or KeywordList value keyed by field name and value.
+		postings map[string]map[string]docSet
+		// terms are documents with Text value token keyed by field name and token.
+		terms map[string]map[string]docSet
+	}
+)
+
+func newIndex() *index {
+	return &index{
+		docs:       make(map[docKey]*document),
+		namespaces: make(map[string]docSet),
+		postings:   make(map[string]map[string]docSet),
+		terms:      make(map[string]map[string]docSet),
+	}
+}
+
+// put adds or replaces the document unless the stored document was written by a newer task.
+// Must be called with the write lock held.
+func (idx *index) put(doc *document) {
+	key := doc.key()
+	if old, ok := idx.docs[key]; ok {
+		if old.TaskID > doc.TaskID {
+			return
+		}
+		idx.unindex(old)
+	}
+	idx.docs[key] = doc
+	addToSet(idx.namespaces, doc.NamespaceID, key)
+	for field, value := range doc.fields {
+		switch v := value.(type) {
+		case string:
+			if _, isText := doc.tokens[field]; !isText {
+				addToFieldSet(idx.postings, field, v, key)
+			}
+		case []string:
+			for _, item := range v {
+				addToFieldSet(idx.postings, field, item, key)
+			}
+		}
+	}
+	for field, tokens := range doc.tokens {
+		for token := range tokens {
+			addToFieldSet(idx.terms, field, token, key)
+		}
+	}
+}
+
+// delete removes the document. Must be called with the write lock held.
+func (idx *index) delete(key docKey) {
+	doc, ok := idx.docs[key]
+	if !ok {
+		return
+	}
+	idx.unindex(doc)
+	delete(idx.docs, key)
+}
+
+func (idx *index) unindex(doc *document) {
+	key := doc.key()
+	removeFromSet(idx.namespaces, doc.NamespaceID, key)
+	for field, value := range doc.fields {
+		switch v := value.(type) {
+		case string:
+			if _, isText := doc.tokens[field]; !isText {
+				removeFromFieldSet(idx.postings, field, v, key)
+			}
+		case []string:
+			for _, item := range v {
+				removeFromFieldSet(idx.postings, field, item, key)
+			}
+		}
+	}
+	for field, tokens := range doc.tokens {
+		for token := range tokens {
+			removeFromFieldSet(idx.terms, field, token, key)
+		}
+	}
+}
+
+// keywordDocs returns documents with the Keyword or KeywordList value.
+func (idx *index) keywordDocs(field string, value string) docSet {
+	return idx.postings[field][value]
+}
+
+// textDocs returns documents with Text value containing the token, or any token
+// starting with it if prefix is true.
+func (idx *index) textDocs(field string, token string, prefix bool) docSet {
+	if !prefix {
+		return idx.terms[field][token]
+	}
+	result := docSet{}
+	for t, docs := range idx.terms[field] {
+		if strings.HasPrefix(t, token) {
+			unionInto(result, docs)
+		}
+	}
+	return result
+}
+
+// idf returns the inverse document frequency of the Text value token.
+func (idx *index) idf(field string, token string, prefix bool) float64 {
+	df := len(idx.textDocs(field, token, prefix))
+	return math.Log(1 + float64(len(idx.docs))/float64(1+df))
+}
+
+func addToSet(sets map[string]docSet, name string, key docKey) {
+	set, ok := sets[name]
+	if !ok {
+		set = docSet{}
+		sets[name] = set
+	}
+	set[key] = struct{}{}
+}
+
+func removeFromSet(sets map[string]docSet, name string, key docKey) {
+	set, ok := sets[name]
+	if !ok {
+		return
+	}
+	delete(set, key)
+	if len(set) == 0 {
+		delete(sets, name)
+	}
+}
+
+func addToFieldSet(fieldSets map[string]map[string]docSet, field string, value string, key docKey) {
+	sets, ok := fieldSets[field]
+	if !ok {
+		sets = make(map[string]docSet)
+		fieldSets[field] = sets
+	}
+	addToSet(sets, value, key)
+}
+
+func removeFromFieldSet(fieldSets map[string]map[string]docSet, field string, value string, key docKey) {
+	sets, ok := fieldSets[field]
+	if !ok {
+		return
+	}
+	removeFromSet(sets, value, key)
+	if len(sets) == 0 {
+		delete(fieldSets, field)
+	}
+}
+
+func unionInto(dst docSet, src docSet) {
+	for key := range src {
+		dst[key] = struct{}{}
+	}
+}
+
+func intersect(a docSet, b docSet) docSet {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	result := docSet{}
+	for key := range a {
+		if _, ok := b[key]; ok {
+			result[key] = struct{}{}
+		}
+	}
+	return result
+}
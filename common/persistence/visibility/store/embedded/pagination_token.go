@@ -0,0 +1,214 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package embedded
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	// pageToken is the sort values of the last document of the page, the next page
+	// starts right after it (same as search_after in Elasticsearch).
+	pageToken struct {
+		SortValues []any
+		RunID      string
+	}
+
+	// sortColumn is a column of the result order. Datetime values are compared as Unix nanoseconds.
+	sortColumn struct {
+		fieldName string
+		valueType enumspb.IndexedValueType
+		desc      bool
+		// relevance is true if the column is the full-text search relevance score.
+		relevance bool
+		// missingValue replaces missing field value. Documents with missing value are
+		// placed last if it's nil.
+		missingValue any
+	}
+
+	// sortRow is the document with its values of the sort columns.
+	sortRow struct {
+		doc    *document
+		values []any
+	}
+)
+
+// newSortColumns returns the columns of the result order. If no order is specified, results are
+// sorted by relevance if the query has full-text search, then by CloseTime (open workflows first)
+// and StartTime in descending order.
+func newSortColumns(
+	orderBy []sortField,
+	textPredicates []*textPredicate,
+	saTypeMap searchattribute.NameTypeMap,
+) ([]sortColumn, error) {
+	if len(orderBy) > 0 {
+		columns := make([]sortColumn, len(orderBy))
+		for i, field := range orderBy {
+			valueType, err := saTypeMap.GetType(field.fieldName)
+			if err != nil {
+				return nil, err
+			}
+			columns[i] = sortColumn{fieldName: field.fieldName, valueType: valueType, desc: field.desc}
+		}
+		return columns, nil
+	}
+
+	var columns []sortColumn
+	if len(textPredicates) > 0 {
+		columns = append(columns, sortColumn{
+			valueType: enumspb.INDEXED_VALUE_TYPE_DOUBLE,
+			desc:      true,
+			relevance: true,
+		})
+	}
+	return append(
+		columns,
+		sortColumn{
+			fieldName:    searchattribute.CloseTime,
+			valueType:    enumspb.INDEXED_VALUE_TYPE_DATETIME,
+			desc:         true,
+			missingValue: int64(math.MaxInt64),
+		},
+		sortColumn{
+			fieldName: searchattribute.StartTime,
+			valueType: enumspb.INDEXED_VALUE_TYPE_DATETIME,
+			desc:      true,
+		},
+	), nil
+}
+
+func newSortRow(doc *document, columns []sortColumn, idx *index, textPredicates []*textPredicate) sortRow {
+	values := make([]any, len(columns))
+	for i, column := range columns {
+		if column.relevance {
+			score := 0.0
+			for _, p := range textPredicates {
+				score += p.relevance(idx, doc)
+			}
+			values[i] = score
+			continue
+		}
+		value, ok := doc.fields[column.fieldName]
+		if !ok {
+			values[i] = column.missingValue
+			continue
+		}
+		if tm, isTime := value.(time.Time); isTime {
+			value = tm.UnixNano()
+		}
+		values[i] = value
+	}
+	return sortRow{doc: doc, values: values}
+}
+
+// compareSortRows compares rows by sort values and then by run ID.
+func compareSortRows(a sortRow, b sortRow, columns []sortColumn) int {
+	if c := compareSortValues(a.values, b.values, columns); c != 0 {
+		return c
+	}
+	return strings.Compare(a.doc.RunID, b.doc.RunID)
+}
+
+// isAfterToken returns true if the row is placed after the last row of the previous page.
+func isAfterToken(row sortRow, token *pageToken, columns []sortColumn) bool {
+	if c := compareSortValues(row.values, token.SortValues, columns); c != 0 {
+		return c > 0
+	}
+	return row.doc.RunID > token.RunID
+}
+
+func compareSortValues(a []any, b []any, columns []sortColumn) int {
+	for i, column := range columns {
+		av, bv := a[i], b[i]
+		switch {
+		case av == nil && bv == nil:
+			continue
+		case av == nil:
+			return 1
+		case bv == nil:
+			return -1
+		}
+		c, _ := compareValues(av, bv)
+		if column.desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func serializePageToken(token *pageToken) ([]byte, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("unable to serialize page token: %v", err))
+	}
+	return data, nil
+}
+
+func deserializePageToken(data []byte, columns []sortColumn) (*pageToken, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var token pageToken
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// UseNumber will not lose precision on big int64.
+	decoder.UseNumber()
+	if err := decoder.Decode(&token); err != nil {
+		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("unable to deserialize page token: %v", err))
+	}
+	if len(token.SortValues) != len(columns) {
+		return nil, serviceerror.NewInvalidArgument(
+			"invalid page token: number of sort values doesn't match the query order")
+	}
+	for i, column := range columns {
+		number, isNumber := token.SortValues[i].(json.Number)
+		if !isNumber {
+			continue
+		}
+		var err error
+		if column.valueType == enumspb.INDEXED_VALUE_TYPE_DOUBLE {
+			token.SortValues[i], err = number.Float64()
+		} else {
+			token.SortValues[i], err = number.Int64()
+		}
+		if err != nil {
+			return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("invalid page token sort value: %v", err))
+		}
+	}
+	return &token, nil
+}
@@ -0,0 +1,352 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package embedded
+
+import (
+	"strings"
+	"time"
+
+	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+type (
+	// predicate is a compiled condition of the visibility query.
+	predicate interface {
+		match(doc *document) bool
+		// candidates returns a superset of the documents matching the predicate using the
+		// inverted indices. It returns false if the indices can't be used for the predicate.
+		candidates(idx *index) (docSet, bool)
+	}
+
+	andPredicate struct {
+		left  predicate
+		right predicate
+	}
+
+	orPredicate struct {
+		left  predicate
+		right predicate
+	}
+
+	notPredicate struct {
+		expr predicate
+	}
+
+	constPredicate bool
+
+	isNullPredicate struct {
+		field string
+	}
+
+	// comparisonPredicate compares the field value with the value using the operator, or checks
+	// if the field value is one of the values if operator is IN.
+	comparisonPredicate struct {
+		field    string
+		saType   enumspb.IndexedValueType
+		operator string
+		values   []any
+		// lower is true if the field value is lowercased before comparison.
+		lower bool
+	}
+
+	// keywordListPredicate checks if the KeywordList field contains any of the values.
+	keywordListPredicate struct {
+		field  string
+		values []string
+	}
+
+	// textPredicate checks if the Text field contains any of the tokens, or any token
+	// starting with one of them if prefix is true.
+	textPredicate struct {
+		field  string
+		tokens []string
+		prefix bool
+	}
+)
+
+var (
+	_ predicate = (*andPredicate)(nil)
+	_ predicate = (*orPredicate)(nil)
+	_ predicate = (*notPredicate)(nil)
+	_ predicate = constPredicate(false)
+	_ predicate = (*isNullPredicate)(nil)
+	_ predicate = (*comparisonPredicate)(nil)
+	_ predicate = (*keywordListPredicate)(nil)
+	_ predicate = (*textPredicate)(nil)
+)
+
+func (p *andPredicate) match(doc *document) bool {
+	return p.left.match(doc) && p.right.match(doc)
+}
+
+func (p *andPredicate) candidates(idx *index) (docSet, bool) {
+	left, leftOk := p.left.candidates(idx)
+	right, rightOk := p.right.candidates(idx)
+	switch {
+	case leftOk && rightOk:
+		return intersect(left, right), true
+	case leftOk:
+		return left, true
+	case rightOk:
+		return right, true
+	}
+	return nil, false
+}
+
+func (p *orPredicate) match(doc *document) bool {
+	return p.left.match(doc) || p.right.match(doc)
+}
+
+func (p *orPredicate) candidates(idx *index) (docSet, bool) {
+	left, ok := p.left.candidates(idx)
+	if !ok {
+		return nil, false
+	}
+	right, ok := p.right.candidates(idx)
+	if !ok {
+		return nil, false
+	}
+	result := make(docSet, len(left)+len(right))
+	unionInto(result, left)
+	unionInto(result, right)
+	return result, true
+}
+
+func (p *notPredicate) match(doc *document) bool {
+	return !p.expr.match(doc)
+}
+
+func (p *notPredicate) candidates(_ *index) (docSet, bool) {
+	return nil, false
+}
+
+func (p constPredicate) match(_ *document) bool {
+	return bool(p)
+}
+
+func (p constPredicate) candidates(_ *index) (docSet, bool) {
+	if p {
+		return nil, false
+	}
+	return docSet{}, true
+}
+
+func (p *isNullPredicate) match(doc *document) bool {
+	_, ok := doc.fields[p.field]
+	return !ok
+}
+
+func (p *isNullPredicate) candidates(_ *index) (docSet, bool) {
+	return nil, false
+}
+
+func (p *comparisonPredicate) match(doc *document) bool {
+	value, ok := doc.fields[p.field]
+	if !ok {
+		return false
+	}
+	if p.lower {
+		strValue, ok := value.(string)
+		if !ok {
+			return false
+		}
+		value = strings.ToLower(strValue)
+	}
+
+	switch p.operator {
+	case sqlparser.InStr:
+		for _, v := range p.values {
+			if c, ok := compareValues(value, v); ok && c == 0 {
+				return true
+			}
+		}
+		return false
+	case sqlparser.StartsWithStr:
+		strValue, ok := value.(string)
+		return ok && strings.HasPrefix(strValue, p.values[0].(string))
+	}
+
+	c, ok := compareValues(value, p.values[0])
+	if !ok {
+		return false
+	}
+	switch p.operator {
+	case sqlparser.EqualStr:
+		return c == 0
+	case sqlparser.LessThanStr:
+		return c < 0
+	case sqlparser.GreaterThanStr:
+		return c > 0
+	case sqlparser.LessEqualStr:
+		return c <= 0
+	case sqlparser.GreaterEqualStr:
+		return c >= 0
+	}
+	return false
+}
+
+func (p *comparisonPredicate) candidates(idx *index) (docSet, bool) {
+	if p.lower || p.saType != enumspb.INDEXED_VALUE_TYPE_KEYWORD {
+		return nil, false
+	}
+	if p.operator != sqlparser.EqualStr && p.operator != sqlparser.InStr {
+		return nil, false
+	}
+	result := docSet{}
+	for _, v := range p.values {
+		if strValue, ok := v.(string); ok {
+			unionInto(result, idx.keywordDocs(p.field, strValue))
+		}
+	}
+	return result, true
+}
+
+func (p *keywordListPredicate) match(doc *document) bool {
+	switch docValue := doc.fields[p.field].(type) {
+	case []string:
+		for _, item := range docValue {
+			for _, v := range p.values {
+				if item == v {
+					return true
+				}
+			}
+		}
+	case string:
+		// KeywordList with single value might be decoded as string.
+		for _, v := range p.values {
+			if docValue == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *keywordListPredicate) candidates(idx *index) (docSet, bool) {
+	result := docSet{}
+	for _, v := range p.values {
+		unionInto(result, idx.keywordDocs(p.field, v))
+	}
+	return result, true
+}
+
+func (p *textPredicate) match(doc *document) bool {
+	return p.termFrequency(doc) > 0
+}
+
+func (p *textPredicate) candidates(idx *index) (docSet, bool) {
+	result := docSet{}
+	for _, token := range p.tokens {
+		unionInto(result, idx.textDocs(p.field, token, p.prefix))
+	}
+	return result, true
+}
+
+// termFrequency returns the number of occurrences of the tokens in the Text field.
+func (p *textPredicate) termFrequency(doc *document) int {
+	docTokens := doc.tokens[p.field]
+	count := 0
+	for _, token := range p.tokens {
+		if !p.prefix {
+			count += docTokens[token]
+			continue
+		}
+		for docToken, n := range docTokens {
+			if strings.HasPrefix(docToken, token) {
+				count += n
+			}
+		}
+	}
+	return count
+}
+
+// relevance returns the full-text relevance score of the document, which is
+// the sum of the frequency of each token weighted by its inverse document frequency.
+func (p *textPredicate) relevance(idx *index, doc *document) float64 {
+	docTokens := doc.tokens[p.field]
+	if len(docTokens) == 0 {
+		return 0
+	}
+	score := 0.0
+	for _, token := range p.tokens {
+		tf := (&textPredicate{field: p.field, tokens: []string{token}, prefix: p.prefix}).termFrequency(doc)
+		if tf > 0 {
+			score += float64(tf) * idx.idf(p.field, token, p.prefix)
+		}
+	}
+	return score
+}
+
+// compareValues compares the values of the same search attribute type. It returns false if
+// the values are not comparable.
+func compareValues(a any, b any) (int, bool) {
+	switch av := a.(type) {
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv), true
+		}
+	case int64:
+		switch bv := b.(type) {
+		case int64:
+			return compareOrdered(av, bv), true
+		case float64:
+			return compareOrdered(float64(av), bv), true
+		}
+	case float64:
+		switch bv := b.(type) {
+		case float64:
+			return compareOrdered(av, bv), true
+		case int64:
+			return compareOrdered(av, float64(bv)), true
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			switch {
+			case av == bv:
+				return 0, true
+			case bv:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	case time.Time:
+		if bv, ok := b.(time.Time); ok {
+			return av.Compare(bv), true
+		}
+	}
+	return 0, false
+}
+
+func compareOrdered[T int64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
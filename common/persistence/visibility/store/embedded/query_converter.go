@@ -0,0 +1,871 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package embedded
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	// queryConverter compiles visibility query to predicate evaluated against the documents of the index.
+	queryConverter struct {
+		namespaceName namespace.Name
+		saTypeMap     searchattribute.NameTypeMap
+		saMapper      searchattribute.Mapper
+		now           time.Time
+
+		seenNamespaceDivision bool
+		// Text search attributes comparisons used to compute the relevance score.
+		textPredicates []*textPredicate
+	}
+
+	queryParams struct {
+		filter  predicate
+		orderBy []sortField
+		// List of search attributes to group by (field name, not alias).
+		groupBy []string
+		// Group by fields which are bucketed with date_trunc function.
+		groupByDateTrunc map[string]query.DateTruncUnit
+		// List of aggregate functions of the select list.
+		aggregations []query.Aggregation
+		// Text search attributes comparisons matched in the query, empty if there's no full-text search.
+		textPredicates []*textPredicate
+	}
+
+	sortField struct {
+		fieldName string
+		desc      bool
+	}
+
+	colName struct {
+		alias     string
+		fieldName string
+		valueType enumspb.IndexedValueType
+	}
+)
+
+var (
+	supportedComparisonOperators = []string{
+		sqlparser.EqualStr,
+		sqlparser.NotEqualStr,
+		sqlparser.LessThanStr,
+		sqlparser.GreaterThanStr,
+		sqlparser.LessEqualStr,
+		sqlparser.GreaterEqualStr,
+		sqlparser.InStr,
+		sqlparser.NotInStr,
+		sqlparser.StartsWithStr,
+		sqlparser.NotStartsWithStr,
+	}
+
+	supportedKeywordListOperators = []string{
+		sqlparser.EqualStr,
+		sqlparser.NotEqualStr,
+		sqlparser.InStr,
+		sqlparser.NotInStr,
+	}
+
+	supportedTextOperators = []string{
+		sqlparser.EqualStr,
+		sqlparser.NotEqualStr,
+		sqlparser.StartsWithStr,
+		sqlparser.NotStartsWithStr,
+	}
+
+	supportedLowerFuncOperators = []string{
+		sqlparser.EqualStr,
+		sqlparser.NotEqualStr,
+		sqlparser.InStr,
+		sqlparser.NotInStr,
+		sqlparser.StartsWithStr,
+		sqlparser.NotStartsWithStr,
+	}
+
+	supportedTypesRangeCond = []enumspb.IndexedValueType{
+		enumspb.INDEXED_VALUE_TYPE_DATETIME,
+		enumspb.INDEXED_VALUE_TYPE_DOUBLE,
+		enumspb.INDEXED_VALUE_TYPE_INT,
+		enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	}
+
+	// negatedOperators maps negative operators to the positive ones.
+	negatedOperators = map[string]string{
+		sqlparser.NotEqualStr:      sqlparser.EqualStr,
+		sqlparser.NotInStr:         sqlparser.InStr,
+		sqlparser.NotStartsWithStr: sqlparser.StartsWithStr,
+	}
+)
+
+func newQueryConverter(
+	namespaceName namespace.Name,
+	saTypeMap searchattribute.NameTypeMap,
+	saMapper searchattribute.Mapper,
+) *queryConverter {
+	return &queryConverter{
+		namespaceName: namespaceName,
+		saTypeMap:     saTypeMap,
+		saMapper:      saMapper,
+		now:           time.Now(),
+	}
+}
+
+func (c *queryConverter) convertQuery(queryString string) (*queryParams, error) {
+	stmt, err := sqlparser.Parse(query.SelectStatement(queryString))
+	if err != nil {
+		return nil, query.NewConverterError("%s: %v", query.MalformedSqlQueryErrMessage, err)
+	}
+	sel, _ := stmt.(*sqlparser.Select)
+	if sel.Limit != nil {
+		return nil, query.NewConverterError("%s: 'limit' clause", query.NotSupportedErrMessage)
+	}
+
+	res := &queryParams{filter: constPredicate(true)}
+	if sel.Where != nil && sel.Where.Expr != nil {
+		res.filter, err = c.convertWhereExpr(sel.Where.Expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// This logic comes from elasticsearch/visibility_store.go#convertQuery function.
+	// If the query did not explicitly filter on TemporalNamespaceDivision,
+	// then add "is null" query to it.
+	if !c.seenNamespaceDivision {
+		res.filter = &andPredicate{
+			left:  res.filter,
+			right: &isNullPredicate{field: searchattribute.TemporalNamespaceDivision},
+		}
+	}
+	res.textPredicates = c.textPredicates
+
+	aggregationExprs, err := query.SelectedAggregations(sel)
+	if err != nil {
+		return nil, err
+	}
+	for _, funcExpr := range aggregationExprs {
+		aggregation, err := c.convertAggregation(funcExpr)
+		if err != nil {
+			return nil, err
+		}
+		res.aggregations = append(res.aggregations, aggregation)
+	}
+
+	for _, groupByExpr := range sel.GroupBy {
+		if funcExpr, isFuncExpr := groupByExpr.(*sqlparser.FuncExpr); isFuncExpr {
+			if strings.EqualFold(funcExpr.Name.String(), query.DateTruncFuncName) {
+				if err := c.convertDateTruncGroupBy(funcExpr, res); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, query.NewAggregationInGroupByError(funcExpr)
+		}
+		col, err := c.convertColName(groupByExpr)
+		if err != nil {
+			return nil, err
+		}
+		if err := query.ValidateGroupByFieldType(col.alias, col.valueType); err != nil {
+			return nil, err
+		}
+		res.groupBy = append(res.groupBy, col.fieldName)
+	}
+
+	for _, orderByExpr := range sel.OrderBy {
+		col, err := c.convertColName(orderByExpr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		if col.valueType == enumspb.INDEXED_VALUE_TYPE_TEXT || col.valueType == enumspb.INDEXED_VALUE_TYPE_KEYWORD_LIST {
+			return nil, query.NewConverterError(
+				"%s: unable to sort by field of %s type, use field of type %s",
+				query.NotSupportedErrMessage,
+				col.valueType.String(),
+				enumspb.INDEXED_VALUE_TYPE_KEYWORD.String(),
+			)
+		}
+		res.orderBy = append(res.orderBy, sortField{
+			fieldName: col.fieldName,
+			desc:      orderByExpr.Direction == sqlparser.DescScr,
+		})
+	}
+
+	if (len(res.groupBy) > 0 || len(res.aggregations) > 0) && len(res.orderBy) > 0 {
+		return nil, query.NewConverterError(
+			"%s: 'order by' clause is not supported with 'group by' clause",
+			query.NotSupportedErrMessage,
+		)
+	}
+	return res, nil
+}
+
+func (c *queryConverter) convertAggregation(funcExpr *sqlparser.FuncExpr) (query.Aggregation, error) {
+	funcName, argExpr, err := query.ParseAggregationFuncExpr(funcExpr)
+	if err != nil {
+		return query.Aggregation{}, err
+	}
+	col, err := c.convertColName(argExpr)
+	if err != nil {
+		return query.Aggregation{}, err
+	}
+	if err := query.ValidateAggregationFieldType(funcName, col.alias, col.valueType); err != nil {
+		return query.Aggregation{}, err
+	}
+	return query.Aggregation{Func: funcName, FieldName: col.fieldName}, nil
+}
+
+func (c *queryConverter) convertDateTruncGroupBy(funcExpr *sqlparser.FuncExpr, res *queryParams) error {
+	unit, argExpr, err := query.ParseDateTruncFuncExpr(funcExpr)
+	if err != nil {
+		return err
+	}
+	col, err := c.convertColName(argExpr)
+	if err != nil {
+		return err
+	}
+	if err := query.ValidateFuncFieldType(query.DateTruncFuncName, col.alias, col.valueType); err != nil {
+		return err
+	}
+	if _, ok := res.groupByDateTrunc[col.fieldName]; ok {
+		return query.NewConverterError(
+			"%s: field %s is bucketed more than once in 'group by' clause",
+			query.InvalidExpressionErrMessage,
+			col.alias,
+		)
+	}
+	if res.groupByDateTrunc == nil {
+		res.groupByDateTrunc = make(map[string]query.DateTruncUnit)
+	}
+	res.groupByDateTrunc[col.fieldName] = unit
+	res.groupBy = append(res.groupBy, col.fieldName)
+	return nil
+}
+
+func (c *queryConverter) convertWhereExpr(expr sqlparser.Expr) (predicate, error) {
+	if expr == nil {
+		return nil, errors.New("cannot be nil")
+	}
+
+	switch e := expr.(type) {
+	case *sqlparser.ParenExpr:
+		return c.convertWhereExpr(e.Expr)
+	case *sqlparser.NotExpr:
+		p, err := c.convertWhereExpr(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &notPredicate{expr: p}, nil
+	case *sqlparser.AndExpr:
+		left, err := c.convertWhereExpr(e.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.convertWhereExpr(e.Right)
+		if err != nil {
+			return nil, err
+		}
+		return &andPredicate{left: left, right: right}, nil
+	case *sqlparser.OrExpr:
+		left, err := c.convertWhereExpr(e.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := c.convertWhereExpr(e.Right)
+		if err != nil {
+			return nil, err
+		}
+		return &orPredicate{left: left, right: right}, nil
+	case *sqlparser.ComparisonExpr:
+		return c.convertComparisonExpr(e)
+	case *sqlparser.RangeCond:
+		return c.convertRangeCond(e)
+	case *sqlparser.IsExpr:
+		return c.convertIsExpr(e)
+	case *sqlparser.FuncExpr:
+		return nil, query.NewConverterError("%s: function expression", query.NotSupportedErrMessage)
+	case *sqlparser.ColName:
+		return nil, query.NewConverterError("%s: incomplete expression", query.InvalidExpressionErrMessage)
+	default:
+		return nil, query.NewConverterError("%s: expression of type %T", query.NotSupportedErrMessage, e)
+	}
+}
+
+func (c *queryConverter) convertComparisonExpr(expr *sqlparser.ComparisonExpr) (predicate, error) {
+	if !isSupportedOperator(supportedComparisonOperators, expr.Operator) {
+		return nil, query.NewConverterError(
+			"%s: invalid operator '%s' in `%s`",
+			query.InvalidExpressionErrMessage,
+			expr.Operator,
+			sqlparser.String(expr),
+		)
+	}
+
+	if funcExpr, isFuncExpr := expr.Left.(*sqlparser.FuncExpr); isFuncExpr {
+		switch strings.ToLower(funcExpr.Name.String()) {
+		case query.LowerFuncName:
+			return c.convertLowerComparisonExpr(expr, funcExpr)
+		case query.DateTruncFuncName:
+			return c.convertDateTruncComparisonExpr(expr, funcExpr)
+		default:
+			return nil, query.NewConverterError(
+				"%s: function '%s', only '%s' and '%s' are supported on the left side of comparison expression",
+				query.NotSupportedErrMessage,
+				funcExpr.Name.String(),
+				query.LowerFuncName,
+				query.DateTruncFuncName,
+			)
+		}
+	}
+
+	col, err := c.convertColName(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+	switch col.valueType {
+	case enumspb.INDEXED_VALUE_TYPE_KEYWORD_LIST:
+		return c.convertKeywordListComparisonExpr(expr, col)
+	case enumspb.INDEXED_VALUE_TYPE_TEXT:
+		return c.convertTextComparisonExpr(expr, col)
+	}
+
+	if (expr.Operator == sqlparser.StartsWithStr || expr.Operator == sqlparser.NotStartsWithStr) &&
+		col.valueType != enumspb.INDEXED_VALUE_TYPE_KEYWORD {
+		return nil, query.NewConverterError(
+			"%s: operator '%s' not supported for %s type search attribute in `%s`",
+			query.InvalidExpressionErrMessage,
+			expr.Operator,
+			col.valueType.String(),
+			formatComparisonExprStringForError(expr, col),
+		)
+	}
+	values, err := c.convertComparisonValues(expr, col)
+	if err != nil {
+		return nil, err
+	}
+	return newComparisonPredicate(col, expr.Operator, values, false), nil
+}
+
+func (c *queryConverter) convertKeywordListComparisonExpr(
+	expr *sqlparser.ComparisonExpr,
+	col *colName,
+) (predicate, error) {
+	if !isSupportedOperator(supportedKeywordListOperators, expr.Operator) {
+		return nil, query.NewConverterError(
+			"%s: operator '%s' not supported for KeywordList type search attribute in `%s`",
+			query.InvalidExpressionErrMessage,
+			expr.Operator,
+			formatComparisonExprStringForError(expr, col),
+		)
+	}
+	values, err := c.convertComparisonValues(expr, col)
+	if err != nil {
+		return nil, err
+	}
+	p := &keywordListPredicate{field: col.fieldName}
+	for _, v := range values {
+		strValue, ok := v.(string)
+		if !ok {
+			return nil, query.NewConverterError(
+				"%s: unexpected value type (expected string, got %v)",
+				query.InvalidExpressionErrMessage,
+				v,
+			)
+		}
+		p.values = append(p.values, strValue)
+	}
+	if _, isNegated := negatedOperators[expr.Operator]; isNegated {
+		return &notPredicate{expr: p}, nil
+	}
+	return p, nil
+}
+
+func (c *queryConverter) convertTextComparisonExpr(
+	expr *sqlparser.ComparisonExpr,
+	col *colName,
+) (predicate, error) {
+	if !isSupportedOperator(supportedTextOperators, expr.Operator) {
+		return nil, query.NewConverterError(
+			"%s: operator '%s' not supported for Text type search attribute in `%s`",
+			query.InvalidExpressionErrMessage,
+			expr.Operator,
+			formatComparisonExprStringForError(expr, col),
+		)
+	}
+	value, err := c.convertValueExpr(expr.Right, col)
+	if err != nil {
+		return nil, err
+	}
+	strValue, ok := value.(string)
+	if !ok {
+		return nil, query.NewConverterError(
+			"%s: unexpected value type (expected string, got %s)",
+			query.InvalidExpressionErrMessage,
+			sqlparser.String(expr.Right),
+		)
+	}
+	tokens := tokenize(strValue)
+	if len(tokens) == 0 {
+		return nil, query.NewConverterError(
+			"%s: unexpected value for Text type search attribute (no tokens found in %s)",
+			query.InvalidExpressionErrMessage,
+			sqlparser.String(expr.Right),
+		)
+	}
+	p := &textPredicate{
+		field:  col.fieldName,
+		tokens: tokens,
+		prefix: expr.Operator == sqlparser.StartsWithStr || expr.Operator == sqlparser.NotStartsWithStr,
+	}
+	if _, isNegated := negatedOperators[expr.Operator]; isNegated {
+		return &notPredicate{expr: p}, nil
+	}
+	c.textPredicates = append(c.textPredicates, p)
+	return p, nil
+}
+
+// convertLowerComparisonExpr converts comparison of lower(field) by lowercasing the values too,
+// so lower(WorkflowType) = 'MyWorkflow' matches 'myworkflow' and 'MYWORKFLOW'.
+func (c *queryConverter) convertLowerComparisonExpr(
+	expr *sqlparser.ComparisonExpr,
+	funcExpr *sqlparser.FuncExpr,
+) (predicate, error) {
+	argExpr, err := query.ParseLowerFuncExpr(funcExpr)
+	if err != nil {
+		return nil, err
+	}
+	col, err := c.convertColName(argExpr)
+	if err != nil {
+		return nil, err
+	}
+	if err := query.ValidateFuncFieldType(query.LowerFuncName, col.alias, col.valueType); err != nil {
+		return nil, err
+	}
+	if !isSupportedOperator(supportedLowerFuncOperators, expr.Operator) {
+		return nil, query.NewConverterError(
+			"%s: operator '%s' with function '%s'",
+			query.NotSupportedErrMessage,
+			expr.Operator,
+			query.LowerFuncName,
+		)
+	}
+	values, err := c.convertComparisonValues(expr, col)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		strValue, ok := v.(string)
+		if !ok {
+			return nil, query.NewConverterError(
+				"%s: value compared to '%s' function must be a string (got: %v)",
+				query.InvalidExpressionErrMessage,
+				query.LowerFuncName,
+				v,
+			)
+		}
+		values[i] = strings.ToLower(strValue)
+	}
+	return newComparisonPredicate(col, expr.Operator, values, true), nil
+}
+
+// convertDateTruncComparisonExpr converts comparison of date_trunc(unit, field) to range
+// condition on the field.
+func (c *queryConverter) convertDateTruncComparisonExpr(
+	expr *sqlparser.ComparisonExpr,
+	funcExpr *sqlparser.FuncExpr,
+) (predicate, error) {
+	unit, argExpr, err := query.ParseDateTruncFuncExpr(funcExpr)
+	if err != nil {
+		return nil, err
+	}
+	col, err := c.convertColName(argExpr)
+	if err != nil {
+		return nil, err
+	}
+	if err := query.ValidateFuncFieldType(query.DateTruncFuncName, col.alias, col.valueType); err != nil {
+		return nil, err
+	}
+	if _, isValTuple := expr.Right.(sqlparser.ValTuple); isValTuple {
+		return nil, query.NewConverterError(
+			"%s: operator '%s' with function '%s'",
+			query.NotSupportedErrMessage,
+			expr.Operator,
+			query.DateTruncFuncName,
+		)
+	}
+	value, err := c.convertValueExpr(expr.Right, col)
+	if err != nil {
+		return nil, err
+	}
+	dateTruncRange, err := query.NewDateTruncRange(unit, expr.Operator, value.(time.Time))
+	if err != nil {
+		return nil, err
+	}
+
+	if dateTruncRange.Empty {
+		return constPredicate(false), nil
+	}
+	var p predicate = &notPredicate{expr: &isNullPredicate{field: col.fieldName}}
+	if !dateTruncRange.From.IsZero() {
+		p = newComparisonPredicate(col, sqlparser.GreaterEqualStr, []any{dateTruncRange.From}, false)
+	}
+	if !dateTruncRange.To.IsZero() {
+		to := newComparisonPredicate(col, sqlparser.LessThanStr, []any{dateTruncRange.To}, false)
+		if dateTruncRange.From.IsZero() {
+			p = to
+		} else {
+			p = &andPredicate{left: p, right: to}
+		}
+	}
+	if dateTruncRange.Negate {
+		p = &notPredicate{expr: p}
+	}
+	return p, nil
+}
+
+func (c *queryConverter) convertRangeCond(expr *sqlparser.RangeCond) (predicate, error) {
+	col, err := c.convertColName(expr.Left)
+	if err != nil {
+		return nil, err
+	}
+	if !isSupportedTypeRangeCond(col.valueType) {
+		return nil, query.NewConverterError(
+			"%s: cannot do range condition on search attribute '%s' of type %s",
+			query.InvalidExpressionErrMessage,
+			col.alias,
+			col.valueType.String(),
+		)
+	}
+	from, err := c.convertValueExpr(expr.From, col)
+	if err != nil {
+		return nil, err
+	}
+	to, err := c.convertValueExpr(expr.To, col)
+	if err != nil {
+		return nil, err
+	}
+	var p predicate = &andPredicate{
+		left:  newComparisonPredicate(col, sqlparser.GreaterEqualStr, []any{from}, false),
+		right: newComparisonPredicate(col, sqlparser.LessEqualStr, []any{to}, false),
+	}
+	if expr.Operator == sqlparser.NotBetweenStr {
+		p = &notPredicate{expr: p}
+	}
+	return p, nil
+}
+
+func (c *queryConverter) convertIsExpr(expr *sqlparser.IsExpr) (predicate, error) {
+	col, err := c.convertColName(expr.Expr)
+	if err != nil {
+		return nil, err
+	}
+	switch expr.Operator {
+	case sqlparser.IsNullStr:
+		return &isNullPredicate{field: col.fieldName}, nil
+	case sqlparser.IsNotNullStr:
+		return &notPredicate{expr: &isNullPredicate{field: col.fieldName}}, nil
+	default:
+		return nil, query.NewConverterError(
+			"%s: 'IS' operator can only be used with 'NULL' or 'NOT NULL'",
+			query.InvalidExpressionErrMessage,
+		)
+	}
+}
+
+func (c *queryConverter) convertColName(expr sqlparser.Expr) (*colName, error) {
+	colNameExpr, ok := expr.(*sqlparser.ColName)
+	if !ok {
+		return nil, query.NewConverterError(
+			"%s: must be a column name but was %T",
+			query.InvalidExpressionErrMessage,
+			expr,
+		)
+	}
+	saAlias := strings.ReplaceAll(sqlparser.String(colNameExpr), "`", "")
+	saFieldName := saAlias
+	if searchattribute.IsMappable(saAlias) {
+		var err error
+		saFieldName, err = c.saMapper.GetFieldName(saAlias, c.namespaceName.String())
+		if err != nil {
+			return nil, query.NewConverterError(
+				"%s: column name '%s' is not a valid search attribute",
+				query.InvalidExpressionErrMessage,
+				saAlias,
+			)
+		}
+	}
+	saType, err := c.saTypeMap.GetType(saFieldName)
+	if err != nil {
+		return nil, query.NewConverterError(
+			"%s: column name '%s' is not a valid search attribute",
+			query.InvalidExpressionErrMessage,
+			saAlias,
+		)
+	}
+	if saFieldName == searchattribute.TemporalNamespaceDivision {
+		c.seenNamespaceDivision = true
+	}
+	return &colName{
+		alias:     saAlias,
+		fieldName: saFieldName,
+		valueType: saType,
+	}, nil
+}
+
+// convertComparisonValues returns the values on the right side of the comparison. It's a tuple
+// for IN and NOT IN operators, and a single value otherwise.
+func (c *queryConverter) convertComparisonValues(expr *sqlparser.ComparisonExpr, col *colName) ([]any, error) {
+	valTuple, isValTuple := expr.Right.(sqlparser.ValTuple)
+	isInOperator := expr.Operator == sqlparser.InStr || expr.Operator == sqlparser.NotInStr
+	if isInOperator != isValTuple {
+		return nil, query.NewConverterError(
+			"%s: operator '%s' can't be used with value %s",
+			query.InvalidExpressionErrMessage,
+			expr.Operator,
+			sqlparser.String(expr.Right),
+		)
+	}
+	exprs := []sqlparser.Expr{expr.Right}
+	if isValTuple {
+		exprs = valTuple
+	}
+	values := make([]any, len(exprs))
+	for i, e := range exprs {
+		var err error
+		values[i], err = c.convertValueExpr(e, col)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if expr.Operator == sqlparser.StartsWithStr || expr.Operator == sqlparser.NotStartsWithStr {
+		if _, ok := values[0].(string); !ok {
+			return nil, query.NewConverterError(
+				"%s: right-hand side of '%s' must be a literal string (got: %v)",
+				query.InvalidExpressionErrMessage,
+				expr.Operator,
+				sqlparser.String(expr.Right),
+			)
+		}
+	}
+	return values, nil
+}
+
+// convertValueExpr returns the value of the search attribute type: string for Keyword, KeywordList and
+// Text, int64 for Int, float64 for Double, bool for Bool, and time.Time for Datetime.
+func (c *queryConverter) convertValueExpr(expr sqlparser.Expr, col *colName) (any, error) {
+	switch e := expr.(type) {
+	case *sqlparser.SQLVal:
+		var sqlValue string
+		switch e.Type {
+		case sqlparser.StrVal:
+			sqlValue = fmt.Sprintf(`'%s'`, e.Val)
+		default:
+			sqlValue = string(e.Val)
+		}
+		value, err := query.ParseSqlValue(sqlValue)
+		if err != nil {
+			return nil, err
+		}
+		return c.convertValue(value, col)
+	case sqlparser.BoolVal:
+		if col.valueType != enumspb.INDEXED_VALUE_TYPE_BOOL {
+			return nil, newUnexpectedValueTypeError(e, col)
+		}
+		return bool(e), nil
+	case *sqlparser.FuncExpr, *sqlparser.BinaryExpr:
+		// This is relative time case, eg: now() - interval 1 hour.
+		value, err := query.ParseRelativeTime(expr, c.now)
+		if err != nil {
+			return nil, err
+		}
+		if col.valueType != enumspb.INDEXED_VALUE_TYPE_DATETIME {
+			return nil, query.NewConverterError(
+				"%s: relative time can only be compared to %s search attributes, %s has type %s",
+				query.InvalidExpressionErrMessage,
+				enumspb.INDEXED_VALUE_TYPE_DATETIME.String(),
+				col.alias,
+				col.valueType.String(),
+			)
+		}
+		return value.UTC(), nil
+	case *sqlparser.ColName:
+		return nil, query.NewConverterError(
+			"%s: column name on the right side of comparison expression (did you forget to quote '%s'?)",
+			query.NotSupportedErrMessage,
+			sqlparser.String(expr),
+		)
+	default:
+		return nil, query.NewConverterError(
+			"%s: unexpected value type %T",
+			query.InvalidExpressionErrMessage,
+			expr,
+		)
+	}
+}
+
+// convertValue converts parsed value (string, int64, or float64) to the search attribute type.
+func (c *queryConverter) convertValue(value any, col *colName) (any, error) {
+	switch col.fieldName {
+	case searchattribute.ExecutionStatus:
+		switch v := value.(type) {
+		case int64:
+			if _, ok := enumspb.WorkflowExecutionStatus_name[int32(v)]; ok {
+				return enumspb.WorkflowExecutionStatus(v).String(), nil
+			}
+		case string:
+			if status, err := enumspb.WorkflowExecutionStatusFromString(v); err == nil {
+				return status.String(), nil
+			}
+		}
+		return nil, query.NewConverterError(
+			"%s: invalid ExecutionStatus value '%v'",
+			query.InvalidExpressionErrMessage,
+			value,
+		)
+	case searchattribute.ExecutionDuration:
+		if durationStr, isString := value.(string); isString {
+			// To support durations passed as golang durations such as "300ms", "-1.5h" or "2h45m".
+			// Custom timestamp.ParseDuration also supports "d" as additional unit for days.
+			if duration, err := timestamp.ParseDuration(durationStr); err == nil {
+				return duration.Nanoseconds(), nil
+			}
+			// To support "hh:mm:ss" durations.
+			duration, err := timestamp.ParseHHMMSSDuration(durationStr)
+			if err != nil {
+				return nil, err
+			}
+			return duration.Nanoseconds(), nil
+		}
+	}
+
+	switch col.valueType {
+	case enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		enumspb.INDEXED_VALUE_TYPE_KEYWORD_LIST,
+		enumspb.INDEXED_VALUE_TYPE_TEXT:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+	case enumspb.INDEXED_VALUE_TYPE_INT:
+		if v, ok := value.(int64); ok {
+			return v, nil
+		}
+	case enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+		switch v := value.(type) {
+		case int64:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case enumspb.INDEXED_VALUE_TYPE_BOOL:
+		if v, ok := value.(string); ok {
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		switch v := value.(type) {
+		case int64:
+			return time.Unix(0, v).UTC(), nil
+		case string:
+			tm, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, query.NewConverterError(
+					"%s: unable to parse datetime '%s'",
+					query.InvalidExpressionErrMessage,
+					v,
+				)
+			}
+			return tm.UTC(), nil
+		}
+	}
+	return nil, newUnexpectedValueTypeError(value, col)
+}
+
+func newComparisonPredicate(col *colName, operator string, values []any, lower bool) predicate {
+	positiveOperator, isNegated := negatedOperators[operator]
+	if isNegated {
+		operator = positiveOperator
+	}
+	var p predicate = &comparisonPredicate{
+		field:    col.fieldName,
+		saType:   col.valueType,
+		operator: operator,
+		values:   values,
+		lower:    lower,
+	}
+	if isNegated {
+		p = &notPredicate{expr: p}
+	}
+	return p
+}
+
+func newUnexpectedValueTypeError(value any, col *colName) error {
+	return query.NewConverterError(
+		"%s: unexpected value %v for search attribute %s of type %s",
+		query.InvalidExpressionErrMessage,
+		value,
+		col.alias,
+		col.valueType.String(),
+	)
+}
+
+// formatComparisonExprStringForError formats comparison expression with search attribute alias.
+func formatComparisonExprStringForError(expr *sqlparser.ComparisonExpr, col *colName) string {
+	exprCopy := *expr
+	exprCopy.Left = &sqlparser.ColName{Name: sqlparser.NewColIdent(col.alias)}
+	return sqlparser.String(&exprCopy)
+}
+
+func isSupportedOperator(supportedOperators []string, operator string) bool {
+	for _, op := range supportedOperators {
+		if operator == op {
+			return true
+		}
+	}
+	return false
+}
+
+func isSupportedTypeRangeCond(saType enumspb.IndexedValueType) bool {
+	for _, tp := range supportedTypesRangeCond {
+		if saType == tp {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package embedded
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+func newTestDocument(t *testing.T, values map[string]any) *document {
+	searchAttributes, err := searchattribute.Encode(values, &searchattribute.TestNameTypeMap)
+	require.NoError(t, err)
+	doc := &document{
+		NamespaceID:      "test-namespace-id",
+		WorkflowID:       "wid",
+		RunID:            "rid",
+		WorkflowTypeName: "TypeA",
+		StartTime:        time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC),
+		SearchAttributes: searchAttributes,
+	}
+	require.NoError(t, doc.build())
+	return doc
+}
+
+func newTestQueryConverter(t *testing.T) *queryConverter {
+	saMapper, err := searchattribute.NewTestMapperProvider(nil).GetMapper(testNamespace)
+	require.NoError(t, err)
+	return newQueryConverter(testNamespace, searchattribute.TestNameTypeMap, saMapper)
+}
+
+func TestQueryConverter_Match(t *testing.T) {
+	doc := newTestDocument(t, map[string]any{
+		"CustomKeywordField": "Value",
+		"CustomDoubleField":  1.5,
+		"CustomBoolField":    true,
+	})
+
+	tests := []struct {
+		query   string
+		matches bool
+	}{
+		{query: "", matches: true},
+		{query: "CustomKeywordField = 'Value'", matches: true},
+		{query: "CustomKeywordField = 'value'", matches: false},
+		{query: "lower(CustomKeywordField) = 'VALUE'", matches: true},
+		{query: "lower(CustomKeywordField) NOT STARTS_WITH 'va'", matches: false},
+		{query: "CustomDoubleField > 1", matches: true},
+		{query: "CustomDoubleField NOT BETWEEN 1 AND 2", matches: false},
+		{query: "CustomBoolField = true", matches: true},
+		{query: "CustomBoolField = 'false'", matches: false},
+		{query: "CustomIntField IS NULL", matches: true},
+		{query: "CustomIntField != 1", matches: true},
+		{query: "date_trunc('day', StartTime) = '2024-03-15T00:00:00Z'", matches: true},
+		{query: "date_trunc('month', StartTime) > '2024-03-01T00:00:00Z'", matches: false},
+		{query: "ExecutionDuration > '1h'", matches: false},
+		{query: "TemporalNamespaceDivision IS NOT NULL", matches: false},
+		{query: "(WorkflowType = 'TypeB' OR WorkflowId = 'wid') AND RunId = 'rid'", matches: true},
+	}
+	for _, tc := range tests {
+		converter := newTestQueryConverter(t)
+		params, err := converter.convertQuery(tc.query)
+		require.NoError(t, err, tc.query)
+		require.Equal(t, tc.matches, params.filter.match(doc), tc.query)
+	}
+}
+
+func TestQueryConverter_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{
+			query: "CustomKeywordField = 'a' LIMIT 10",
+			err:   "operation is not supported: 'limit' clause",
+		},
+		{
+			query: "CustomKeywordField",
+			err:   "invalid expression: incomplete expression",
+		},
+		{
+			query: "CustomIntField STARTS_WITH '1'",
+			err:   "invalid expression: operator 'starts_with' not supported for Int type search attribute in `CustomIntField starts_with '1'`",
+		},
+		{
+			query: "KeywordList01 > 'a'",
+			err:   "invalid expression: operator '>' not supported for KeywordList type search attribute in `KeywordList01 > 'a'`",
+		},
+		{
+			query: "CustomTextField = '!!'",
+			err:   "invalid expression: unexpected value for Text type search attribute (no tokens found in '!!')",
+		},
+		{
+			query: "CustomIntField = 'a'",
+			err:   "invalid expression: unexpected value a for search attribute CustomIntField of type Int",
+		},
+		{
+			query: "CustomIntField > now()",
+			err:   "invalid expression: relative time can only be compared to Datetime search attributes, CustomIntField has type Int",
+		},
+		{
+			query: "ORDER BY KeywordList01",
+			err:   "operation is not supported: unable to sort by field of KeywordList type, use field of type Keyword",
+		},
+		{
+			query: "GROUP BY WorkflowType ORDER BY StartTime",
+			err:   "operation is not supported: 'order by' clause is not supported with 'group by' clause",
+		},
+		{
+			query: "GROUP BY CustomIntField",
+			err:   "operation is not supported: 'group by' clause is only supported for Keyword search attributes, CustomIntField has type Int",
+		},
+	}
+	for _, tc := range tests {
+		converter := newTestQueryConverter(t)
+		_, err := converter.convertQuery(tc.query)
+		var converterErr *query.ConverterError
+		require.ErrorAs(t, err, &converterErr, tc.query)
+		require.Equal(t, tc.err, err.Error(), tc.query)
+	}
+}
@@ -0,0 +1,583 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package embedded
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"google.golang.org/protobuf/proto"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+)
+
+type (
+	// sharedIndex is the index shared by all visibility stores with the same index name in the process,
+	// so records written by history service are visible to frontend service.
+	//
+	// If the index is persisted, every change is appended to a write-ahead log and synced to disk
+	// before it's applied. The index is periodically written to a snapshot, which replaces the
+	// log entries it contains.
+	sharedIndex struct {
+		*index
+		cfg      config.EmbeddedVisibility
+		logger   log.Logger
+		refCount int
+		// wal is the write-ahead log file, nil if the index isn't persisted.
+		wal *os.File
+		// walSize is the size of the valid entries of the write-ahead log.
+		walSize int64
+		// walSeq is the sequence number of the last entry appended to the write-ahead log.
+		walSeq int64
+		// snapshotWALSeq is the sequence number of the last log entry contained in the last snapshot.
+		snapshotWALSeq int64
+		stopCh         chan struct{}
+		doneCh         chan struct{}
+	}
+
+	snapshotRecord struct {
+		NamespaceID          string
+		TaskID               int64
+		WorkflowID           string
+		RunID                string
+		WorkflowTypeName     string
+		StartTime            time.Time
+		ExecutionTime        time.Time
+		CloseTime            time.Time
+		Status               int32
+		HistoryLength        int64
+		HistorySizeBytes     int64
+		StateTransitionCount int64
+		ExecutionDuration    time.Duration
+		TaskQueue            string
+		Memo                 []byte
+		MemoEncoding         string
+		ParentWorkflowID     string
+		ParentRunID          string
+		SearchAttributes     []byte
+	}
+
+	// walEntry is a change of the index in the write-ahead log.
+	walEntry struct {
+		Seq int64
+		// Delete is set if the document with NamespaceID and RunID is deleted,
+		// otherwise Record is put.
+		Delete      bool
+		NamespaceID string
+		RunID       string
+		Record      snapshotRecord
+	}
+)
+
+const (
+	defaultSnapshotInterval = 10 * time.Second
+	snapshotFormatVersion   = 2
+	walSuffix               = ".wal"
+	// walEntryHeaderSize is the size of the length and checksum preceding each log entry.
+	walEntryHeaderSize = 8
+)
+
+var (
+	sharedIndicesLock sync.Mutex
+	sharedIndices     = make(map[string]*sharedIndex)
+)
+
+// acquireIndex returns the shared index for the config, loading it from the snapshot and the
+// write-ahead log if it's not open yet. Each call must be followed by a release call.
+func acquireIndex(cfg config.EmbeddedVisibility, logger log.Logger) (*sharedIndex, error) {
+	sharedIndicesLock.Lock()
+	defer sharedIndicesLock.Unlock()
+
+	if s, ok := sharedIndices[cfg.IndexName]; ok {
+		s.refCount++
+		return s, nil
+	}
+
+	s := &sharedIndex{
+		index:    newIndex(),
+		cfg:      cfg,
+		logger:   logger,
+		refCount: 1,
+	}
+	if cfg.SnapshotPath != "" {
+		if err := s.loadSnapshot(); err != nil {
+			return nil, err
+		}
+		if err := s.openWAL(); err != nil {
+			return nil, err
+		}
+		interval := cfg.SnapshotInterval
+		if interval <= 0 {
+			interval = defaultSnapshotInterval
+		}
+		s.stopCh = make(chan struct{})
+		s.doneCh = make(chan struct{})
+		go s.snapshotLoop(interval)
+	}
+	sharedIndices[cfg.IndexName] = s
+	return s, nil
+}
+
+// release closes the index when it's released by all the stores.
+func (s *sharedIndex) release() {
+	sharedIndicesLock.Lock()
+	defer sharedIndicesLock.Unlock()
+
+	s.refCount--
+	if s.refCount > 0 {
+		return
+	}
+	delete(sharedIndices, s.cfg.IndexName)
+	if s.stopCh != nil {
+		close(s.stopCh)
+		<-s.doneCh
+		if err := s.writeSnapshot(); err != nil {
+			s.logger.Error("Unable to write embedded visibility snapshot.", tag.Error(err))
+		}
+		if err := s.wal.Close(); err != nil {
+			s.logger.Error("Unable to close embedded visibility write-ahead log.", tag.Error(err))
+		}
+	}
+}
+
+// putDocument adds the document to the index once the change is persisted.
+func (s *sharedIndex) putDocument(doc *document) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.wal != nil {
+		record, err := newSnapshotRecord(doc)
+		if err != nil {
+			return err
+		}
+		if err := s.appendWAL(&walEntry{Record: record}); err != nil {
+			return err
+		}
+	}
+	s.put(doc)
+	return nil
+}
+
+// deleteDocument deletes the document from the index once the change is persisted.
+func (s *sharedIndex) deleteDocument(key docKey) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.wal != nil {
+		if err := s.appendWAL(&walEntry{Delete: true, NamespaceID: key.namespaceID, RunID: key.runID}); err != nil {
+			return err
+		}
+	}
+	s.delete(key)
+	return nil
+}
+
+func (s *sharedIndex) snapshotLoop(interval time.Duration) {
+	defer close(s.doneCh)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			if err := s.writeSnapshot(); err != nil {
+				s.logger.Error("Unable to write embedded visibility snapshot.", tag.Error(err))
+			}
+		}
+	}
+}
+
+func (s *sharedIndex) loadSnapshot() error {
+	f, err := os.Open(s.cfg.SnapshotPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to open embedded visibility snapshot: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	decoder := gob.NewDecoder(f)
+	var formatVersion int
+	if err := decoder.Decode(&formatVersion); err != nil {
+		return fmt.Errorf("unable to read embedded visibility snapshot: %w", err)
+	}
+	if formatVersion != snapshotFormatVersion {
+		return fmt.Errorf("unsupported embedded visibility snapshot version %d", formatVersion)
+	}
+	var walSeq int64
+	if err := decoder.Decode(&walSeq); err != nil {
+		return fmt.Errorf("unable to read embedded visibility snapshot: %w", err)
+	}
+	var records []snapshotRecord
+	if err := decoder.Decode(&records); err != nil {
+		return fmt.Errorf("unable to read embedded visibility snapshot: %w", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	for _, record := range records {
+		doc, err := record.toDocument()
+		if err != nil {
+			return fmt.Errorf("unable to read embedded visibility snapshot: %w", err)
+		}
+		s.put(doc)
+	}
+	s.walSeq = walSeq
+	s.snapshotWALSeq = walSeq
+	return nil
+}
+
+// openWAL applies the log entries which aren't contained in the snapshot and opens the log
+// for appending. A partially written last entry is dropped.
+func (s *sharedIndex) openWAL() error {
+	walPath := s.cfg.SnapshotPath + walSuffix
+	var entries []*walEntry
+	validSize, err := readWAL(walPath, func(entry *walEntry) {
+		if entry.Seq > s.walSeq {
+			entries = append(entries, entry)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("unable to read embedded visibility write-ahead log: %w", err)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	for _, entry := range entries {
+		if err := s.apply(entry); err != nil {
+			return fmt.Errorf("unable to read embedded visibility write-ahead log: %w", err)
+		}
+		s.walSeq = entry.Seq
+	}
+
+	f, err := os.OpenFile(walPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to open embedded visibility write-ahead log: %w", err)
+	}
+	if err := f.Truncate(validSize); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to open embedded visibility write-ahead log: %w", err)
+	}
+	if err := syncDir(walPath); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("unable to open embedded visibility write-ahead log: %w", err)
+	}
+	s.wal = f
+	s.walSize = validSize
+	return nil
+}
+
+// apply applies the log entry to the index. Must be called with the write lock held.
+func (s *sharedIndex) apply(entry *walEntry) error {
+	if entry.Delete {
+		s.delete(docKey{namespaceID: entry.NamespaceID, runID: entry.RunID})
+		return nil
+	}
+	doc, err := entry.Record.toDocument()
+	if err != nil {
+		return err
+	}
+	s.put(doc)
+	return nil
+}
+
+// appendWAL appends the entry to the write-ahead log and syncs it to disk.
+// Must be called with the write lock held.
+func (s *sharedIndex) appendWAL(entry *walEntry) error {
+	entry.Seq = s.walSeq + 1
+	data, err := encodeWALEntry(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := s.wal.Write(data); err != nil {
+		// Drop the partially written entry, so the following entries can be read.
+		_ = s.wal.Truncate(s.walSize)
+		return err
+	}
+	if err := s.wal.Sync(); err != nil {
+		_ = s.wal.Truncate(s.walSize)
+		return err
+	}
+	s.walSize += int64(len(data))
+	s.walSeq = entry.Seq
+	return nil
+}
+
+// writeSnapshot writes the index to the snapshot and removes the log entries it contains.
+func (s *sharedIndex) writeSnapshot() error {
+	s.RLock()
+	if s.walSeq == s.snapshotWALSeq {
+		s.RUnlock()
+		return nil
+	}
+	walSeq := s.walSeq
+	records := make([]snapshotRecord, 0, len(s.docs))
+	for _, doc := range s.docs {
+		record, err := newSnapshotRecord(doc)
+		if err != nil {
+			s.RUnlock()
+			return err
+		}
+		records = append(records, record)
+	}
+	s.RUnlock()
+
+	err := writeFileAtomically(s.cfg.SnapshotPath, func(w io.Writer) error {
+		encoder := gob.NewEncoder(w)
+		if err := encoder.Encode(snapshotFormatVersion); err != nil {
+			return err
+		}
+		if err := encoder.Encode(walSeq); err != nil {
+			return err
+		}
+		return encoder.Encode(records)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.snapshotWALSeq = walSeq
+	return s.compactWAL(walSeq)
+}
+
+// compactWAL removes the log entries up to walSeq. Must be called with the write lock held.
+func (s *sharedIndex) compactWAL(walSeq int64) error {
+	if s.walSeq == walSeq {
+		if err := s.wal.Truncate(0); err != nil {
+			return err
+		}
+		s.walSize = 0
+		return s.wal.Sync()
+	}
+
+	// Entries were appended while the snapshot was written and need to be kept.
+	walPath := s.cfg.SnapshotPath + walSuffix
+	var entries []*walEntry
+	if _, err := readWAL(walPath, func(entry *walEntry) {
+		if entry.Seq > walSeq {
+			entries = append(entries, entry)
+		}
+	}); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		data, err := encodeWALEntry(entry)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	if err := writeFileAtomically(walPath, func(w io.Writer) error {
+		_, err := w.Write(buf.Bytes())
+		return err
+	}); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(walPath, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	_ = s.wal.Close()
+	s.wal = f
+	s.walSize = int64(buf.Len())
+	return nil
+}
+
+// readWAL calls fn for each entry of the write-ahead log and returns the size of the valid
+// entries. Reading stops at the first partially written or corrupted entry.
+func readWAL(walPath string, fn func(entry *walEntry)) (int64, error) {
+	f, err := os.Open(walPath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	r := bufio.NewReader(f)
+	var size int64
+	header := make([]byte, walEntryHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return size, nil
+			}
+			return 0, err
+		}
+		data := make([]byte, binary.BigEndian.Uint32(header[:4]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return size, nil
+			}
+			return 0, err
+		}
+		if crc32.ChecksumIEEE(data) != binary.BigEndian.Uint32(header[4:]) {
+			return size, nil
+		}
+		entry := &walEntry{}
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(entry); err != nil {
+			return size, nil
+		}
+		fn(entry)
+		size += int64(len(header) + len(data))
+	}
+}
+
+func encodeWALEntry(entry *walEntry) ([]byte, error) {
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(entry); err != nil {
+		return nil, err
+	}
+	result := make([]byte, walEntryHeaderSize, walEntryHeaderSize+data.Len())
+	binary.BigEndian.PutUint32(result[:4], uint32(data.Len()))
+	binary.BigEndian.PutUint32(result[4:], crc32.ChecksumIEEE(data.Bytes()))
+	return append(result, data.Bytes()...), nil
+}
+
+// writeFileAtomically writes a temporary file first and renames it to path,
+// so the file is replaced atomically.
+func writeFileAtomically(path string, write func(w io.Writer) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	w := bufio.NewWriter(f)
+	if err := write(w); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(path)
+}
+
+// syncDir syncs the directory of the file, so that the file creation or rename is persisted.
+func syncDir(path string) error {
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dir.Close() }()
+	return dir.Sync()
+}
+
+func newSnapshotRecord(doc *document) (snapshotRecord, error) {
+	var searchAttributes []byte
+	if doc.SearchAttributes != nil {
+		var err error
+		searchAttributes, err = proto.Marshal(doc.SearchAttributes)
+		if err != nil {
+			return snapshotRecord{}, err
+		}
+	}
+	return snapshotRecord{
+		NamespaceID:          doc.NamespaceID,
+		TaskID:               doc.TaskID,
+		WorkflowID:           doc.WorkflowID,
+		RunID:                doc.RunID,
+		WorkflowTypeName:     doc.WorkflowTypeName,
+		StartTime:            doc.StartTime,
+		ExecutionTime:        doc.ExecutionTime,
+		CloseTime:            doc.CloseTime,
+		Status:               int32(doc.Status),
+		HistoryLength:        doc.HistoryLength,
+		HistorySizeBytes:     doc.HistorySizeBytes,
+		StateTransitionCount: doc.StateTransitionCount,
+		ExecutionDuration:    doc.ExecutionDuration,
+		TaskQueue:            doc.TaskQueue,
+		Memo:                 doc.Memo,
+		MemoEncoding:         doc.MemoEncoding,
+		ParentWorkflowID:     doc.ParentWorkflowID,
+		ParentRunID:          doc.ParentRunID,
+		SearchAttributes:     searchAttributes,
+	}, nil
+}
+
+func (r snapshotRecord) toDocument() (*document, error) {
+	doc := &document{
+		NamespaceID:          r.NamespaceID,
+		TaskID:               r.TaskID,
+		WorkflowID:           r.WorkflowID,
+		RunID:                r.RunID,
+		WorkflowTypeName:     r.WorkflowTypeName,
+		StartTime:            r.StartTime,
+		ExecutionTime:        r.ExecutionTime,
+		CloseTime:            r.CloseTime,
+		Status:               enumspb.WorkflowExecutionStatus(r.Status),
+		HistoryLength:        r.HistoryLength,
+		HistorySizeBytes:     r.HistorySizeBytes,
+		StateTransitionCount: r.StateTransitionCount,
+		ExecutionDuration:    r.ExecutionDuration,
+		TaskQueue:            r.TaskQueue,
+		Memo:                 r.Memo,
+		MemoEncoding:         r.MemoEncoding,
+		ParentWorkflowID:     r.ParentWorkflowID,
+		ParentRunID:          r.ParentRunID,
+	}
+	if len(r.SearchAttributes) > 0 {
+		doc.SearchAttributes = &commonpb.SearchAttributes{}
+		if err := proto.Unmarshal(r.SearchAttributes, doc.SearchAttributes); err != nil {
+			return nil, err
+		}
+	}
+	if err := doc.build(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,763 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package embedded
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"golang.org/x/exp/slices"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/persistence/visibility/store"
+	"go.temporal.io/server/common/persistence/visibility/store/query"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+const (
+	PersistenceName = "embedded"
+)
+
+type (
+	// VisibilityStore is the visibility store which keeps the records in the process memory and
+	// periodically snapshots them to the local file. It supports the same query language as
+	// Elasticsearch and SQL visibility stores and is intended for single binary deployments and tests.
+	VisibilityStore struct {
+		idx                            *sharedIndex
+		searchAttributesProvider       searchattribute.Provider
+		searchAttributesMapperProvider searchattribute.MapperProvider
+	}
+
+	// countGroup is the group of documents with the same values of group by fields.
+	countGroup struct {
+		values       []any
+		count        int64
+		aggregations []*aggregationState
+	}
+
+	aggregationState struct {
+		value any
+		sum   float64
+		count int64
+	}
+)
+
+var _ store.VisibilityStore = (*VisibilityStore)(nil)
+
+// NewVisibilityStore creates an instance of VisibilityStore. Stores created with the same index name
+// share the documents.
+func NewVisibilityStore(
+	cfg config.EmbeddedVisibility,
+	searchAttributesProvider searchattribute.Provider,
+	searchAttributesMapperProvider searchattribute.MapperProvider,
+	logger log.Logger,
+) (*VisibilityStore, error) {
+	idx, err := acquireIndex(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &VisibilityStore{
+		idx:                            idx,
+		searchAttributesProvider:       searchAttributesProvider,
+		searchAttributesMapperProvider: searchAttributesMapperProvider,
+	}, nil
+}
+
+func (s *VisibilityStore) Close() {
+	s.idx.release()
+}
+
+func (s *VisibilityStore) GetName() string {
+	return PersistenceName
+}
+
+func (s *VisibilityStore) GetIndexName() string {
+	return s.idx.cfg.IndexName
+}
+
+func (s *VisibilityStore) ValidateCustomSearchAttributes(
+	searchAttributes map[string]any,
+) (map[string]any, error) {
+	return searchAttributes, nil
+}
+
+func (s *VisibilityStore) RecordWorkflowExecutionStarted(
+	_ context.Context,
+	request *store.InternalRecordWorkflowExecutionStartedRequest,
+) error {
+	doc, err := s.generateDocument(request.InternalVisibilityRequestBase)
+	if err != nil {
+		return err
+	}
+	return s.putDocument(doc)
+}
+
+func (s *VisibilityStore) RecordWorkflowExecutionClosed(
+	_ context.Context,
+	request *store.InternalRecordWorkflowExecutionClosedRequest,
+) error {
+	doc, err := s.generateDocument(request.InternalVisibilityRequestBase)
+	if err != nil {
+		return err
+	}
+	doc.CloseTime = request.CloseTime
+	doc.HistoryLength = request.HistoryLength
+	doc.HistorySizeBytes = request.HistorySizeBytes
+	doc.ExecutionDuration = request.ExecutionDuration
+	doc.StateTransitionCount = request.StateTransitionCount
+	return s.putDocument(doc)
+}
+
+func (s *VisibilityStore) UpsertWorkflowExecution(
+	_ context.Context,
+	request *store.InternalUpsertWorkflowExecutionRequest,
+) error {
+	doc, err := s.generateDocument(request.InternalVisibilityRequestBase)
+	if err != nil {
+		return err
+	}
+	return s.putDocument(doc)
+}
+
+func (s *VisibilityStore) DeleteWorkflowExecution(
+	_ context.Context,
+	request *manager.VisibilityDeleteWorkflowExecutionRequest,
+) error {
+	return s.idx.deleteDocument(docKey{namespaceID: request.NamespaceID.String(), runID: request.RunID})
+}
+
+func (s *VisibilityStore) ListOpenWorkflowExecutions(
+	ctx context.Context,
+	request *manager.ListWorkflowExecutionsRequest,
+) (*store.InternalListWorkflowExecutionsResponse, error) {
+	return s.ListWorkflowExecutions(
+		ctx,
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID:   request.NamespaceID,
+			Namespace:     request.Namespace,
+			PageSize:      request.PageSize,
+			NextPageToken: request.NextPageToken,
+			Query: buildQueryStringFromListRequest(
+				request,
+				enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+				"",
+				"",
+			),
+		},
+	)
+}
+
+func (s *VisibilityStore) ListClosedWorkflowExecutions(
+	ctx context.Context,
+	request *manager.ListWorkflowExecutionsRequest,
+) (*store.InternalListWorkflowExecutionsResponse, error) {
+	return s.ListWorkflowExecutions(
+		ctx,
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID:   request.NamespaceID,
+			Namespace:     request.Namespace,
+			PageSize:      request.PageSize,
+			NextPageToken: request.NextPageToken,
+			Query: buildQueryStringFromListRequest(
+				request,
+				enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED,
+				"",
+				"",
+			),
+		},
+	)
+}
+
+func (s *VisibilityStore) ListOpenWorkflowExecutionsByType(
+	ctx context.Context,
+	request *manager.ListWorkflowExecutionsByTypeRequest,
+) (*store.InternalListWorkflowExecutionsResponse, error) {
+	return s.ListWorkflowExecutions(
+		ctx,
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID:   request.NamespaceID,
+			Namespace:     request.Namespace,
+			PageSize:      request.PageSize,
+			NextPageToken: request.NextPageToken,
+			Query: buildQueryStringFromListRequest(
+				request.ListWorkflowExecutionsRequest,
+				enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+				"",
+				request.WorkflowTypeName,
+			),
+		},
+	)
+}
+
+func (s *VisibilityStore) ListClosedWorkflowExecutionsByType(
+	ctx context.Context,
+	request *manager.ListWorkflowExecutionsByTypeRequest,
+) (*store.InternalListWorkflowExecutionsResponse, error) {
+	return s.ListWorkflowExecutions(
+		ctx,
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID:   request.NamespaceID,
+			Namespace:     request.Namespace,
+			PageSize:      request.PageSize,
+			NextPageToken: request.NextPageToken,
+			Query: buildQueryStringFromListRequest(
+				request.ListWorkflowExecutionsRequest,
+				enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED,
+				"",
+				request.WorkflowTypeName,
+			),
+		},
+	)
+}
+
+func (s *VisibilityStore) ListOpenWorkflowExecutionsByWorkflowID(
+	ctx context.Context,
+	request *manager.ListWorkflowExecutionsByWorkflowIDRequest,
+) (*store.InternalListWorkflowExecutionsResponse, error) {
+	return s.ListWorkflowExecutions(
+		ctx,
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID:   request.NamespaceID,
+			Namespace:     request.Namespace,
+			PageSize:      request.PageSize,
+			NextPageToken: request.NextPageToken,
+			Query: buildQueryStringFromListRequest(
+				request.ListWorkflowExecutionsRequest,
+				enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+				request.WorkflowID,
+				"",
+			),
+		},
+	)
+}
+
+func (s *VisibilityStore) ListClosedWorkflowExecutionsByWorkflowID(
+	ctx context.Context,
+	request *manager.ListWorkflowExecutionsByWorkflowIDRequest,
+) (*store.InternalListWorkflowExecutionsResponse, error) {
+	return s.ListWorkflowExecutions(
+		ctx,
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID:   request.NamespaceID,
+			Namespace:     request.Namespace,
+			PageSize:      request.PageSize,
+			NextPageToken: request.NextPageToken,
+			Query: buildQueryStringFromListRequest(
+				request.ListWorkflowExecutionsRequest,
+				enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED,
+				request.WorkflowID,
+				"",
+			),
+		},
+	)
+}
+
+func (s *VisibilityStore) ListClosedWorkflowExecutionsByStatus(
+	ctx context.Context,
+	request *manager.ListClosedWorkflowExecutionsByStatusRequest,
+) (*store.InternalListWorkflowExecutionsResponse, error) {
+	return s.ListWorkflowExecutions(
+		ctx,
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID:   request.NamespaceID,
+			Namespace:     request.Namespace,
+			PageSize:      request.PageSize,
+			NextPageToken: request.NextPageToken,
+			Query: buildQueryStringFromListRequest(
+				request.ListWorkflowExecutionsRequest,
+				request.Status,
+				"",
+				"",
+			),
+		},
+	)
+}
+
+func (s *VisibilityStore) ListWorkflowExecutions(
+	_ context.Context,
+	request *manager.ListWorkflowExecutionsRequestV2,
+) (*store.InternalListWorkflowExecutionsResponse, error) {
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.GetIndexName(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := s.convertQuery(request.Namespace, request.Query, saTypeMap)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.groupBy) > 0 || len(params.aggregations) > 0 {
+		return nil, serviceerror.NewInvalidArgument("GROUP BY clause and aggregate functions are not supported")
+	}
+	columns, err := newSortColumns(params.orderBy, params.textPredicates, saTypeMap)
+	if err != nil {
+		return nil, err
+	}
+	token, err := deserializePageToken(request.NextPageToken, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	s.idx.RLock()
+	var rows []sortRow
+	for _, doc := range s.matchingDocs(request.NamespaceID, params.filter) {
+		row := newSortRow(doc, columns, s.idx.index, params.textPredicates)
+		if token != nil && !isAfterToken(row, token, columns) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	s.idx.RUnlock()
+
+	slices.SortFunc(rows, func(a, b sortRow) int {
+		return compareSortRows(a, b, columns)
+	})
+
+	var nextPageToken []byte
+	if request.PageSize > 0 && len(rows) > request.PageSize {
+		rows = rows[:request.PageSize]
+		lastRow := rows[len(rows)-1]
+		nextPageToken, err = serializePageToken(&pageToken{
+			SortValues: lastRow.values,
+			RunID:      lastRow.doc.RunID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	infos := make([]*store.InternalWorkflowExecutionInfo, len(rows))
+	for i, row := range rows {
+		infos[i], err = s.documentToInfo(row.doc, saTypeMap, request.Namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &store.InternalListWorkflowExecutionsResponse{
+		Executions:    infos,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *VisibilityStore) ScanWorkflowExecutions(
+	ctx context.Context,
+	request *manager.ListWorkflowExecutionsRequestV2,
+) (*store.InternalListWorkflowExecutionsResponse, error) {
+	return s.ListWorkflowExecutions(ctx, request)
+}
+
+func (s *VisibilityStore) CountWorkflowExecutions(
+	_ context.Context,
+	request *manager.CountWorkflowExecutionsRequest,
+) (*manager.CountWorkflowExecutionsResponse, error) {
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.GetIndexName(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := s.convertQuery(request.Namespace, request.Query, saTypeMap)
+	if err != nil {
+		return nil, err
+	}
+	if len(params.orderBy) > 0 {
+		return nil, serviceerror.NewInvalidArgument("ORDER BY clause is not supported in count query")
+	}
+
+	s.idx.RLock()
+	docs := s.matchingDocs(request.NamespaceID, params.filter)
+	s.idx.RUnlock()
+
+	if len(params.groupBy) > 0 || len(params.aggregations) > 0 {
+		return countGroupByDocuments(docs, params, saTypeMap)
+	}
+	return &manager.CountWorkflowExecutionsResponse{Count: int64(len(docs))}, nil
+}
+
+func (s *VisibilityStore) GetWorkflowExecution(
+	_ context.Context,
+	request *manager.GetWorkflowExecutionRequest,
+) (*store.InternalGetWorkflowExecutionResponse, error) {
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.GetIndexName(), false)
+	if err != nil {
+		return nil, serviceerror.NewUnavailable(
+			fmt.Sprintf("Unable to read search attribute types: %v", err),
+		)
+	}
+
+	s.idx.RLock()
+	doc, ok := s.idx.docs[docKey{namespaceID: request.NamespaceID.String(), runID: request.RunID}]
+	s.idx.RUnlock()
+	if !ok {
+		return nil, serviceerror.NewNotFound(
+			fmt.Sprintf("Workflow execution with run id %s not found.", request.RunID),
+		)
+	}
+
+	info, err := s.documentToInfo(doc, saTypeMap, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return &store.InternalGetWorkflowExecutionResponse{
+		Execution: info,
+	}, nil
+}
+
+func (s *VisibilityStore) convertQuery(
+	namespaceName namespace.Name,
+	queryString string,
+	saTypeMap searchattribute.NameTypeMap,
+) (*queryParams, error) {
+	saMapper, err := s.searchAttributesMapperProvider.GetMapper(namespaceName)
+	if err != nil {
+		return nil, err
+	}
+	params, err := newQueryConverter(namespaceName, saTypeMap, saMapper).convertQuery(queryString)
+	if err != nil {
+		// Convert ConverterError to InvalidArgument and pass through all other errors (which should be only mapper errors).
+		var converterErr *query.ConverterError
+		if errors.As(err, &converterErr) {
+			return nil, converterErr.ToInvalidArgument()
+		}
+		return nil, err
+	}
+	return params, nil
+}
+
+// matchingDocs returns the documents of the namespace matching the filter.
+// Must be called with the read lock held.
+func (s *VisibilityStore) matchingDocs(namespaceID namespace.ID, filter predicate) []*document {
+	docs := s.idx.namespaces[namespaceID.String()]
+	if candidates, ok := filter.candidates(s.idx.index); ok {
+		docs = intersect(docs, candidates)
+	}
+	var result []*document
+	for key := range docs {
+		doc := s.idx.docs[key]
+		if filter.match(doc) {
+			result = append(result, doc)
+		}
+	}
+	return result
+}
+
+func (s *VisibilityStore) putDocument(doc *document) error {
+	if err := doc.build(); err != nil {
+		return err
+	}
+	return s.idx.putDocument(doc)
+}
+
+func (s *VisibilityStore) generateDocument(
+	request *store.InternalVisibilityRequestBase,
+) (*document, error) {
+	searchAttributes, err := s.prepareSearchAttributes(request.SearchAttributes)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{
+		NamespaceID:      request.NamespaceID,
+		TaskID:           request.TaskID,
+		WorkflowID:       request.WorkflowID,
+		RunID:            request.RunID,
+		WorkflowTypeName: request.WorkflowTypeName,
+		StartTime:        request.StartTime.UTC(),
+		ExecutionTime:    request.ExecutionTime.UTC(),
+		Status:           request.Status,
+		TaskQueue:        request.TaskQueue,
+		Memo:             request.Memo.GetData(),
+		MemoEncoding:     request.Memo.GetEncodingType().String(),
+		SearchAttributes: searchAttributes,
+	}
+	if request.ParentWorkflowID != nil {
+		doc.ParentWorkflowID = *request.ParentWorkflowID
+	}
+	if request.ParentRunID != nil {
+		doc.ParentRunID = *request.ParentRunID
+	}
+	return doc, nil
+}
+
+// prepareSearchAttributes returns search attributes with nil values removed and with
+// their types set in the metadata, so they can be decoded without the type map.
+func (s *VisibilityStore) prepareSearchAttributes(
+	searchAttributes *commonpb.SearchAttributes,
+) (*commonpb.SearchAttributes, error) {
+	if len(searchAttributes.GetIndexedFields()) == 0 {
+		return nil, nil
+	}
+
+	saTypeMap, err := s.searchAttributesProvider.GetSearchAttributes(s.GetIndexName(), false)
+	if err != nil {
+		return nil, serviceerror.NewUnavailable(
+			fmt.Sprintf("Unable to read search attribute types: %v", err),
+		)
+	}
+	values, err := searchattribute.Decode(searchAttributes, &saTypeMap, false)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range values {
+		if value == nil {
+			delete(values, name)
+		}
+	}
+	return searchattribute.Encode(values, &saTypeMap)
+}
+
+func (s *VisibilityStore) documentToInfo(
+	doc *document,
+	saTypeMap searchattribute.NameTypeMap,
+	namespaceName namespace.Name,
+) (*store.InternalWorkflowExecutionInfo, error) {
+	info := doc.toInfo(saTypeMap)
+	if info.SearchAttributes != nil {
+		aliasedSas, err := searchattribute.AliasFields(
+			s.searchAttributesMapperProvider,
+			info.SearchAttributes,
+			namespaceName.String(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		info.SearchAttributes = aliasedSas
+	}
+	return info, nil
+}
+
+func countGroupByDocuments(
+	docs []*document,
+	params *queryParams,
+	saTypeMap searchattribute.NameTypeMap,
+) (*manager.CountWorkflowExecutionsResponse, error) {
+	var err error
+	groupByTypes := make([]enumspb.IndexedValueType, len(params.groupBy))
+	for i, fieldName := range params.groupBy {
+		groupByTypes[i], err = saTypeMap.GetType(fieldName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	aggValueTypes := make([]enumspb.IndexedValueType, len(params.aggregations))
+	for i, aggregation := range params.aggregations {
+		fieldType, err := saTypeMap.GetType(aggregation.FieldName)
+		if err != nil {
+			return nil, err
+		}
+		aggValueTypes[i] = query.AggregationValueType(aggregation.Func, fieldType)
+	}
+
+	groups := make(map[string]*countGroup)
+	for _, doc := range docs {
+		values := make([]any, len(params.groupBy))
+		for i, fieldName := range params.groupBy {
+			values[i] = doc.fields[fieldName]
+			if unit, ok := params.groupByDateTrunc[fieldName]; ok && values[i] != nil {
+				values[i] = unit.Truncate(values[i].(time.Time))
+			}
+		}
+		groupKey, err := json.Marshal(values)
+		if err != nil {
+			return nil, serviceerror.NewInternal(fmt.Sprintf("Unable to build group key: %v", err))
+		}
+		group, ok := groups[string(groupKey)]
+		if !ok {
+			group = &countGroup{values: values, aggregations: make([]*aggregationState, len(params.aggregations))}
+			for i := range group.aggregations {
+				group.aggregations[i] = &aggregationState{}
+			}
+			groups[string(groupKey)] = group
+		}
+		group.count++
+		for i, aggregation := range params.aggregations {
+			group.aggregations[i].add(aggregation.Func, doc.fields[aggregation.FieldName])
+		}
+	}
+
+	sortedGroups := make([]*countGroup, 0, len(groups))
+	groupKeys := make(map[*countGroup]string, len(groups))
+	for key, group := range groups {
+		sortedGroups = append(sortedGroups, group)
+		groupKeys[group] = key
+	}
+	// Groups with more documents go first, same as in Elasticsearch terms aggregation.
+	slices.SortFunc(sortedGroups, func(a, b *countGroup) int {
+		if a.count != b.count {
+			return compareOrdered(b.count, a.count)
+		}
+		return strings.Compare(groupKeys[a], groupKeys[b])
+	})
+
+	resp := &manager.CountWorkflowExecutionsResponse{
+		Count:  0,
+		Groups: make([]*manager.AggregationGroup, 0, len(sortedGroups)),
+	}
+	for _, group := range sortedGroups {
+		groupValues := make([]*commonpb.Payload, 0, len(group.values))
+		for i, val := range group.values {
+			payload, err := searchattribute.EncodeValue(val, groupByTypes[i])
+			if err != nil {
+				return nil, err
+			}
+			groupValues = append(groupValues, payload)
+		}
+		var aggregationValues []*commonpb.Payload
+		for i, agg := range group.aggregations {
+			payload, err := searchattribute.EncodeValue(agg.result(params.aggregations[i].Func), aggValueTypes[i])
+			if err != nil {
+				return nil, err
+			}
+			aggregationValues = append(aggregationValues, payload)
+		}
+		resp.Groups = append(
+			resp.Groups,
+			&manager.AggregationGroup{
+				GroupValues:       groupValues,
+				AggregationValues: aggregationValues,
+				Count:             group.count,
+			},
+		)
+		resp.Count += group.count
+	}
+	return resp, nil
+}
+
+// add adds the field value to the aggregation. Missing values are ignored.
+func (a *aggregationState) add(funcName query.AggregationFunc, value any) {
+	if value == nil {
+		return
+	}
+	a.count++
+	switch funcName {
+	case query.AggregationFuncAvg:
+		switch v := value.(type) {
+		case int64:
+			a.sum += float64(v)
+		case float64:
+			a.sum += v
+		}
+	case query.AggregationFuncMin:
+		if c, ok := compareValues(value, a.value); a.value == nil || ok && c < 0 {
+			a.value = value
+		}
+	case query.AggregationFuncMax:
+		if c, ok := compareValues(value, a.value); a.value == nil || ok && c > 0 {
+			a.value = value
+		}
+	}
+}
+
+func (a *aggregationState) result(funcName query.AggregationFunc) any {
+	if a.count == 0 {
+		return nil
+	}
+	if funcName == query.AggregationFuncAvg {
+		return a.sum / float64(a.count)
+	}
+	return a.value
+}
+
+func buildQueryStringFromListRequest(
+	request *manager.ListWorkflowExecutionsRequest,
+	executionStatus enumspb.WorkflowExecutionStatus,
+	workflowID string,
+	workflowTypeName string,
+) string {
+	var queryTerms []string
+
+	switch executionStatus {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED:
+		queryTerms = append(
+			queryTerms,
+			fmt.Sprintf(
+				"%s != %d",
+				searchattribute.ExecutionStatus,
+				int32(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING),
+			),
+		)
+	default:
+		queryTerms = append(
+			queryTerms,
+			fmt.Sprintf("%s = %d", searchattribute.ExecutionStatus, int32(executionStatus)),
+		)
+	}
+
+	var timeAttr string
+	if executionStatus == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		timeAttr = searchattribute.StartTime
+	} else {
+		timeAttr = searchattribute.CloseTime
+	}
+	queryTerms = append(
+		queryTerms,
+		fmt.Sprintf(
+			"%s BETWEEN '%s' AND '%s'",
+			timeAttr,
+			request.EarliestStartTime.UTC().Format(time.RFC3339Nano),
+			request.LatestStartTime.UTC().Format(time.RFC3339Nano),
+		),
+	)
+
+	if request.NamespaceDivision != "" {
+		queryTerms = append(
+			queryTerms,
+			fmt.Sprintf(
+				"%s = '%s'",
+				searchattribute.TemporalNamespaceDivision,
+				request.NamespaceDivision,
+			),
+		)
+	} else {
+		queryTerms = append(
+			queryTerms,
+			fmt.Sprintf("%s IS NULL", searchattribute.TemporalNamespaceDivision),
+		)
+	}
+
+	if workflowID != "" {
+		queryTerms = append(
+			queryTerms,
+			fmt.Sprintf("%s = '%s'", searchattribute.WorkflowID, workflowID),
+		)
+	}
+
+	if workflowTypeName != "" {
+		queryTerms = append(
+			queryTerms,
+			fmt.Sprintf("%s = '%s'", searchattribute.WorkflowType, workflowTypeName),
+		)
+	}
+
+	return strings.Join(queryTerms, " AND ")
+}
@@ -0,0 +1,456 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package embedded
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/persistence/visibility/store"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	visibilityStoreSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		visibilityStore *VisibilityStore
+		startTime       time.Time
+	}
+)
+
+const (
+	testNamespace   = namespace.Name("test-namespace")
+	testNamespaceID = namespace.ID("test-namespace-id")
+)
+
+func TestVisibilityStoreSuite(t *testing.T) {
+	suite.Run(t, new(visibilityStoreSuite))
+}
+
+func (s *visibilityStoreSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.visibilityStore = s.newVisibilityStore(config.EmbeddedVisibility{IndexName: s.T().Name()})
+	s.startTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *visibilityStoreSuite) TearDownTest() {
+	s.visibilityStore.Close()
+}
+
+func (s *visibilityStoreSuite) newVisibilityStore(cfg config.EmbeddedVisibility) *VisibilityStore {
+	visibilityStore, err := NewVisibilityStore(
+		cfg,
+		searchattribute.NewTestProvider(),
+		searchattribute.NewTestMapperProvider(nil),
+		log.NewNoopLogger(),
+	)
+	s.NoError(err)
+	return visibilityStore
+}
+
+func (s *visibilityStoreSuite) newRequest(
+	runID string,
+	workflowType string,
+	startTime time.Time,
+	searchAttributes map[string]any,
+) *store.InternalVisibilityRequestBase {
+	request := &store.InternalVisibilityRequestBase{
+		NamespaceID:      testNamespaceID.String(),
+		Namespace:        testNamespace.String(),
+		WorkflowID:       "wid-" + runID,
+		RunID:            runID,
+		WorkflowTypeName: workflowType,
+		StartTime:        startTime,
+		ExecutionTime:    startTime,
+		Status:           enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		TaskID:           1,
+		TaskQueue:        "test-task-queue",
+		Memo:             &commonpb.DataBlob{EncodingType: enumspb.ENCODING_TYPE_PROTO3, Data: []byte("memo")},
+	}
+	if searchAttributes != nil {
+		var err error
+		request.SearchAttributes, err = searchattribute.Encode(searchAttributes, &searchattribute.TestNameTypeMap)
+		s.NoError(err)
+	}
+	return request
+}
+
+func (s *visibilityStoreSuite) recordStarted(request *store.InternalVisibilityRequestBase) {
+	err := s.visibilityStore.RecordWorkflowExecutionStarted(
+		context.Background(),
+		&store.InternalRecordWorkflowExecutionStartedRequest{InternalVisibilityRequestBase: request},
+	)
+	s.NoError(err)
+}
+
+func (s *visibilityStoreSuite) recordClosed(request *store.InternalVisibilityRequestBase, closeTime time.Time) {
+	request.Status = enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED
+	request.TaskID++
+	err := s.visibilityStore.RecordWorkflowExecutionClosed(
+		context.Background(),
+		&store.InternalRecordWorkflowExecutionClosedRequest{
+			InternalVisibilityRequestBase: request,
+			CloseTime:                     closeTime,
+			HistoryLength:                 10,
+			ExecutionDuration:             closeTime.Sub(request.ExecutionTime),
+		},
+	)
+	s.NoError(err)
+}
+
+func (s *visibilityStoreSuite) listRunIDs(query string) []string {
+	resp, err := s.visibilityStore.ListWorkflowExecutions(
+		context.Background(),
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID: testNamespaceID,
+			Namespace:   testNamespace,
+			PageSize:    100,
+			Query:       query,
+		},
+	)
+	s.NoError(err)
+	s.Nil(resp.NextPageToken)
+	runIDs := make([]string, len(resp.Executions))
+	for i, execution := range resp.Executions {
+		runIDs[i] = execution.RunID
+	}
+	return runIDs
+}
+
+func (s *visibilityStoreSuite) seedExecutions() {
+	s.recordStarted(s.newRequest("run1", "TypeA", s.startTime, map[string]any{
+		"CustomKeywordField": "alpha",
+		"CustomIntField":     int64(1),
+		"CustomTextField":    "payment failed for order",
+		"KeywordList01":      []string{"red", "green"},
+	}))
+	s.recordStarted(s.newRequest("run2", "TypeB", s.startTime.Add(time.Minute), map[string]any{
+		"CustomKeywordField": "beta",
+		"CustomIntField":     int64(2),
+		"CustomTextField":    "payment payment retry",
+	}))
+	closed := s.newRequest("run3", "TypeA", s.startTime.Add(2*time.Minute), map[string]any{
+		"CustomKeywordField": "alphabet",
+		"CustomIntField":     int64(3),
+		"KeywordList01":      []string{"blue"},
+	})
+	s.recordStarted(closed)
+	s.recordClosed(closed, s.startTime.Add(time.Hour))
+}
+
+func (s *visibilityStoreSuite) TestListWorkflowExecutions() {
+	s.seedExecutions()
+
+	tests := []struct {
+		query  string
+		runIDs []string
+	}{
+		{query: "", runIDs: []string{"run2", "run1", "run3"}},
+		{query: "WorkflowType = 'TypeA'", runIDs: []string{"run1", "run3"}},
+		{query: "ExecutionStatus = 'Running'", runIDs: []string{"run2", "run1"}},
+		{query: "ExecutionStatus != 1", runIDs: []string{"run3"}},
+		{query: "CustomKeywordField IN ('alpha', 'beta')", runIDs: []string{"run2", "run1"}},
+		{query: "CustomKeywordField STARTS_WITH 'alpha'", runIDs: []string{"run1", "run3"}},
+		{query: "lower(CustomKeywordField) = 'ALPHA'", runIDs: []string{"run1"}},
+		{query: "CustomIntField BETWEEN 2 AND 3", runIDs: []string{"run2", "run3"}},
+		{query: "CustomIntField > 1 AND NOT WorkflowType = 'TypeB'", runIDs: []string{"run3"}},
+		{query: "KeywordList01 = 'green' OR KeywordList01 = 'blue'", runIDs: []string{"run1", "run3"}},
+		{query: "KeywordList01 IS NULL", runIDs: []string{"run2"}},
+		{query: "KeywordList01 != 'red'", runIDs: []string{"run2", "run3"}},
+		{query: "CloseTime > '2024-01-01T00:30:00Z'", runIDs: []string{"run3"}},
+		{query: "StartTime < now()", runIDs: []string{"run2", "run1", "run3"}},
+		{query: "ORDER BY CustomIntField", runIDs: []string{"run1", "run2", "run3"}},
+		{query: "CustomTextField = 'payment'", runIDs: []string{"run2", "run1"}},
+		{query: "CustomTextField STARTS_WITH 'ord'", runIDs: []string{"run1"}},
+		{query: "CustomTextField != 'retry'", runIDs: []string{"run1", "run3"}},
+		{query: "TemporalNamespaceDivision = 'other'", runIDs: []string{}},
+	}
+	for _, tc := range tests {
+		s.Equal(tc.runIDs, s.listRunIDs(tc.query), tc.query)
+	}
+}
+
+func (s *visibilityStoreSuite) TestListWorkflowExecutions_InvalidQuery() {
+	s.seedExecutions()
+
+	for _, query := range []string{
+		"CustomUnknownField = 'a'",
+		"ORDER BY CustomTextField",
+		"CustomIntField STARTS_WITH '1'",
+		"WorkflowType = 'a' LIMIT 10",
+	} {
+		_, err := s.visibilityStore.ListWorkflowExecutions(
+			context.Background(),
+			&manager.ListWorkflowExecutionsRequestV2{
+				NamespaceID: testNamespaceID,
+				Namespace:   testNamespace,
+				PageSize:    10,
+				Query:       query,
+			},
+		)
+		var invalidArgumentErr *serviceerror.InvalidArgument
+		s.ErrorAs(err, &invalidArgumentErr, query)
+	}
+}
+
+func (s *visibilityStoreSuite) TestListWorkflowExecutions_Pagination() {
+	for i, runID := range []string{"run1", "run2", "run3", "run4", "run5"} {
+		s.recordStarted(s.newRequest(runID, "TypeA", s.startTime.Add(time.Duration(i%2)*time.Minute), nil))
+	}
+
+	var runIDs []string
+	var nextPageToken []byte
+	for page := 0; ; page++ {
+		s.Less(page, 3)
+		resp, err := s.visibilityStore.ListWorkflowExecutions(
+			context.Background(),
+			&manager.ListWorkflowExecutionsRequestV2{
+				NamespaceID:   testNamespaceID,
+				Namespace:     testNamespace,
+				PageSize:      2,
+				NextPageToken: nextPageToken,
+			},
+		)
+		s.NoError(err)
+		for _, execution := range resp.Executions {
+			runIDs = append(runIDs, execution.RunID)
+		}
+		nextPageToken = resp.NextPageToken
+		if nextPageToken == nil {
+			break
+		}
+	}
+	s.Equal([]string{"run2", "run4", "run1", "run3", "run5"}, runIDs)
+}
+
+func (s *visibilityStoreSuite) TestCountWorkflowExecutions() {
+	s.seedExecutions()
+
+	resp, err := s.visibilityStore.CountWorkflowExecutions(
+		context.Background(),
+		&manager.CountWorkflowExecutionsRequest{
+			NamespaceID: testNamespaceID,
+			Namespace:   testNamespace,
+			Query:       "WorkflowType = 'TypeA'",
+		},
+	)
+	s.NoError(err)
+	s.Equal(int64(2), resp.Count)
+
+	resp, err = s.visibilityStore.CountWorkflowExecutions(
+		context.Background(),
+		&manager.CountWorkflowExecutionsRequest{
+			NamespaceID: testNamespaceID,
+			Namespace:   testNamespace,
+			Query:       "SELECT max(CustomIntField) GROUP BY ExecutionStatus",
+		},
+	)
+	s.NoError(err)
+	s.Equal(int64(3), resp.Count)
+	s.Len(resp.Groups, 2)
+
+	var groupValues [][]any
+	for _, group := range resp.Groups {
+		var status string
+		var maxValue int64
+		s.NoError(payload.Decode(group.GroupValues[0], &status))
+		s.Len(group.GroupValues, 1)
+		s.Len(group.AggregationValues, 1)
+		s.NoError(payload.Decode(group.AggregationValues[0], &maxValue))
+		groupValues = append(groupValues, []any{status, maxValue, group.Count})
+	}
+	s.Equal([][]any{
+		{enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING.String(), int64(2), int64(2)},
+		{enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED.String(), int64(3), int64(1)},
+	}, groupValues)
+}
+
+func (s *visibilityStoreSuite) TestGetAndDeleteWorkflowExecution() {
+	s.seedExecutions()
+
+	resp, err := s.visibilityStore.GetWorkflowExecution(
+		context.Background(),
+		&manager.GetWorkflowExecutionRequest{NamespaceID: testNamespaceID, Namespace: testNamespace, RunID: "run3"},
+	)
+	s.NoError(err)
+	s.Equal("wid-run3", resp.Execution.WorkflowID)
+	s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, resp.Execution.Status)
+	s.Equal(s.startTime.Add(time.Hour), resp.Execution.CloseTime)
+	s.Equal(int64(10), resp.Execution.HistoryLength)
+	s.Equal([]byte("memo"), resp.Execution.Memo.Data)
+	s.Contains(resp.Execution.SearchAttributes.GetIndexedFields(), "CustomKeywordField")
+
+	err = s.visibilityStore.DeleteWorkflowExecution(
+		context.Background(),
+		&manager.VisibilityDeleteWorkflowExecutionRequest{NamespaceID: testNamespaceID, RunID: "run3"},
+	)
+	s.NoError(err)
+
+	_, err = s.visibilityStore.GetWorkflowExecution(
+		context.Background(),
+		&manager.GetWorkflowExecutionRequest{NamespaceID: testNamespaceID, Namespace: testNamespace, RunID: "run3"},
+	)
+	var notFoundErr *serviceerror.NotFound
+	s.ErrorAs(err, &notFoundErr)
+}
+
+func (s *visibilityStoreSuite) TestUpsertWorkflowExecution_StaleTask() {
+	request := s.newRequest("run1", "TypeA", s.startTime, map[string]any{"CustomKeywordField": "new"})
+	request.TaskID = 10
+	s.recordStarted(request)
+
+	stale := s.newRequest("run1", "TypeA", s.startTime, map[string]any{"CustomKeywordField": "old"})
+	stale.TaskID = 5
+	err := s.visibilityStore.UpsertWorkflowExecution(
+		context.Background(),
+		&store.InternalUpsertWorkflowExecutionRequest{InternalVisibilityRequestBase: stale},
+	)
+	s.NoError(err)
+
+	s.Equal([]string{"run1"}, s.listRunIDs("CustomKeywordField = 'new'"))
+	s.Empty(s.listRunIDs("CustomKeywordField = 'old'"))
+}
+
+func (s *visibilityStoreSuite) TestSnapshot() {
+	cfg := config.EmbeddedVisibility{
+		IndexName:    s.T().Name() + "-snapshot",
+		SnapshotPath: filepath.Join(s.T().TempDir(), "visibility.snapshot"),
+	}
+	visibilityStore := s.newVisibilityStore(cfg)
+	err := visibilityStore.RecordWorkflowExecutionStarted(
+		context.Background(),
+		&store.InternalRecordWorkflowExecutionStartedRequest{
+			InternalVisibilityRequestBase: s.newRequest("run1", "TypeA", s.startTime, map[string]any{
+				"CustomTextField": "hello world",
+			}),
+		},
+	)
+	s.NoError(err)
+	// Closing the last store writes the snapshot.
+	visibilityStore.Close()
+
+	visibilityStore = s.newVisibilityStore(cfg)
+	defer visibilityStore.Close()
+	resp, err := visibilityStore.ListWorkflowExecutions(
+		context.Background(),
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID: testNamespaceID,
+			Namespace:   testNamespace,
+			PageSize:    10,
+			Query:       "CustomTextField = 'world'",
+		},
+	)
+	s.NoError(err)
+	s.Len(resp.Executions, 1)
+	s.Equal("run1", resp.Executions[0].RunID)
+	s.Equal(s.startTime, resp.Executions[0].StartTime)
+}
+
+func (s *visibilityStoreSuite) TestWriteAheadLog() {
+	snapshotPath := filepath.Join(s.T().TempDir(), "visibility.snapshot")
+	cfg := config.EmbeddedVisibility{
+		IndexName:        s.T().Name() + "-wal",
+		SnapshotPath:     snapshotPath,
+		SnapshotInterval: time.Hour,
+	}
+	visibilityStore := s.newVisibilityStore(cfg)
+	defer visibilityStore.Close()
+	for _, runID := range []string{"run1", "run2"} {
+		err := visibilityStore.RecordWorkflowExecutionStarted(
+			context.Background(),
+			&store.InternalRecordWorkflowExecutionStartedRequest{
+				InternalVisibilityRequestBase: s.newRequest(runID, "TypeA", s.startTime, nil),
+			},
+		)
+		s.NoError(err)
+	}
+	err := visibilityStore.DeleteWorkflowExecution(context.Background(), &manager.VisibilityDeleteWorkflowExecutionRequest{
+		NamespaceID: testNamespaceID,
+		RunID:       "run1",
+	})
+	s.NoError(err)
+
+	// Simulate a crash after a partially written entry: acknowledged writes are recovered
+	// from the write-ahead log without a snapshot.
+	f, err := os.OpenFile(snapshotPath+walSuffix, os.O_WRONLY|os.O_APPEND, 0)
+	s.NoError(err)
+	_, err = f.Write([]byte{0, 0, 1})
+	s.NoError(err)
+	s.NoError(f.Close())
+	_, err = os.Stat(snapshotPath)
+	s.True(os.IsNotExist(err))
+
+	recoveredCfg := cfg
+	recoveredCfg.IndexName = cfg.IndexName + "-recovered"
+	recoveredStore := s.newVisibilityStore(recoveredCfg)
+	resp, err := recoveredStore.ListWorkflowExecutions(
+		context.Background(),
+		&manager.ListWorkflowExecutionsRequestV2{
+			NamespaceID: testNamespaceID,
+			Namespace:   testNamespace,
+			PageSize:    10,
+		},
+	)
+	s.NoError(err)
+	s.Len(resp.Executions, 1)
+	s.Equal("run2", resp.Executions[0].RunID)
+
+	// Writes after recovery are appended after the dropped partial entry.
+	err = recoveredStore.RecordWorkflowExecutionStarted(
+		context.Background(),
+		&store.InternalRecordWorkflowExecutionStartedRequest{
+			InternalVisibilityRequestBase: s.newRequest("run3", "TypeA", s.startTime, nil),
+		},
+	)
+	s.NoError(err)
+	var seqs []int64
+	_, err = readWAL(snapshotPath+walSuffix, func(entry *walEntry) {
+		seqs = append(seqs, entry.Seq)
+	})
+	s.NoError(err)
+	s.Equal([]int64{1, 2, 3, 4}, seqs)
+
+	// Writing the snapshot compacts the write-ahead log.
+	recoveredStore.Close()
+	info, err := os.Stat(snapshotPath + walSuffix)
+	s.NoError(err)
+	s.Zero(info.Size())
+}
@@ -0,0 +1,206 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+type (
+	// AggregationFunc is an aggregate function which can be selected by count query.
+	AggregationFunc string
+
+	// Aggregation is an aggregate function over search attribute. Aggregations are specified
+	// in the select list in front of the query, for example:
+	//   SELECT MAX(CloseTime), AVG(ExecutionDuration) WHERE WorkflowType = 'x' GROUP BY TaskQueue
+	// Aggregation values are computed for each group, or for all matching workflows without 'group by' clause,
+	// and returned separately from the values of group by fields.
+	Aggregation struct {
+		Func      AggregationFunc
+		FieldName string
+	}
+)
+
+const (
+	AggregationFuncMin AggregationFunc = "min"
+	AggregationFuncMax AggregationFunc = "max"
+	AggregationFuncAvg AggregationFunc = "avg"
+)
+
+func (a Aggregation) String() string {
+	return fmt.Sprintf("%s(%s)", a.Func, a.FieldName)
+}
+
+// selectListEnd matches the clause following the select list of a query.
+var selectListEnd = regexp.MustCompile(`(?i)\s+(where|group\s+by|order\s+by)\s`)
+
+// SelectStatement returns SQL statement for visibility query, which is a WHERE clause optionally followed
+// by GROUP BY or ORDER BY clauses, and optionally preceded by a select list for count queries:
+//
+//	SELECT MAX(CloseTime) WHERE WorkflowType = 'x' GROUP BY TaskQueue
+//
+// sqlparser can't parse just WHERE clause but instead accepts only valid SQL statement.
+func SelectStatement(queryString string) string {
+	queryString = strings.TrimSpace(queryString)
+	selectList := "*"
+	if strings.HasPrefix(strings.ToLower(queryString), "select ") {
+		selectList = queryString[len("select "):]
+		queryString = ""
+		if loc := selectListEnd.FindStringIndex(selectList); loc != nil {
+			selectList, queryString = selectList[:loc[0]], strings.TrimSpace(selectList[loc[0]:])
+		}
+	}
+	lowerQueryString := strings.ToLower(queryString)
+	if queryString != "" &&
+		!strings.HasPrefix(lowerQueryString, "where ") &&
+		!strings.HasPrefix(lowerQueryString, "order by ") &&
+		!strings.HasPrefix(lowerQueryString, "group by ") {
+		queryString = "where " + queryString
+	}
+	return fmt.Sprintf("select %s from table1 %s", selectList, queryString)
+}
+
+// SelectedAggregations returns the aggregate functions of the select list. Only aggregate functions
+// can be selected, '*' is the select list of queries without one.
+func SelectedAggregations(sel *sqlparser.Select) ([]*sqlparser.FuncExpr, error) {
+	var funcExprs []*sqlparser.FuncExpr
+	for _, selectExpr := range sel.SelectExprs {
+		switch e := selectExpr.(type) {
+		case *sqlparser.StarExpr:
+			if len(sel.SelectExprs) == 1 && e.TableName.IsEmpty() {
+				continue
+			}
+		case *sqlparser.AliasedExpr:
+			if funcExpr, ok := e.Expr.(*sqlparser.FuncExpr); ok && e.As.IsEmpty() {
+				funcExprs = append(funcExprs, funcExpr)
+				continue
+			}
+		}
+		return nil, NewConverterError(
+			"%s: only aggregate functions can be selected, got '%s'",
+			NotSupportedErrMessage,
+			sqlparser.String(selectExpr),
+		)
+	}
+	return funcExprs, nil
+}
+
+// NewAggregationInGroupByError returns error for aggregate function in 'group by' clause,
+// aggregate functions must be selected instead.
+func NewAggregationInGroupByError(expr *sqlparser.FuncExpr) error {
+	return NewConverterError(
+		"%s: function '%s' in 'group by' clause, aggregate functions must be in the select list: SELECT %s ... GROUP BY ...",
+		NotSupportedErrMessage,
+		expr.Name.String(),
+		sqlparser.String(expr),
+	)
+}
+
+// ParseAggregationFuncExpr returns aggregate function and its argument from function expression.
+func ParseAggregationFuncExpr(expr *sqlparser.FuncExpr) (AggregationFunc, sqlparser.Expr, error) {
+	funcName := AggregationFunc(strings.ToLower(expr.Name.String()))
+	switch funcName {
+	case AggregationFuncMin, AggregationFuncMax, AggregationFuncAvg:
+	default:
+		return "", nil, NewConverterError(
+			"%s: function '%s' in select list, only %s, %s, and %s are supported",
+			NotSupportedErrMessage,
+			expr.Name.String(),
+			AggregationFuncMin,
+			AggregationFuncMax,
+			AggregationFuncAvg,
+		)
+	}
+	if expr.Distinct {
+		return "", nil, NewConverterError("%s: 'distinct' in function '%s'", NotSupportedErrMessage, funcName)
+	}
+	if len(expr.Exprs) != 1 {
+		return "", nil, NewConverterError(
+			"%s: function '%s' expects exactly one argument",
+			InvalidExpressionErrMessage,
+			funcName,
+		)
+	}
+	arg, ok := expr.Exprs[0].(*sqlparser.AliasedExpr)
+	if !ok {
+		return "", nil, NewConverterError(
+			"%s: function '%s' argument must be a column name",
+			InvalidExpressionErrMessage,
+			funcName,
+		)
+	}
+	return funcName, arg.Expr, nil
+}
+
+// ValidateGroupByFieldType validates that field of this type can be used to group by.
+func ValidateGroupByFieldType(fieldName string, fieldType enumspb.IndexedValueType) error {
+	if fieldType != enumspb.INDEXED_VALUE_TYPE_KEYWORD {
+		return NewConverterError(
+			"%s: 'group by' clause is only supported for %s search attributes, %s has type %s",
+			NotSupportedErrMessage,
+			enumspb.INDEXED_VALUE_TYPE_KEYWORD.String(),
+			fieldName,
+			fieldType.String(),
+		)
+	}
+	return nil
+}
+
+// ValidateAggregationFieldType validates that aggregate function can be applied to field of this type.
+// MIN and MAX are supported for Datetime, Int, and Double fields. AVG is supported for Int and Double fields.
+func ValidateAggregationFieldType(
+	funcName AggregationFunc,
+	fieldName string,
+	fieldType enumspb.IndexedValueType,
+) error {
+	switch fieldType {
+	case enumspb.INDEXED_VALUE_TYPE_INT, enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+		return nil
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		if funcName != AggregationFuncAvg {
+			return nil
+		}
+	}
+	return NewConverterError(
+		"%s: function '%s' is not supported for %s, which has type %s",
+		NotSupportedErrMessage,
+		funcName,
+		fieldName,
+		fieldType.String(),
+	)
+}
+
+// AggregationValueType returns type of aggregate function result.
+func AggregationValueType(funcName AggregationFunc, fieldType enumspb.IndexedValueType) enumspb.IndexedValueType {
+	if funcName == AggregationFuncAvg {
+		return enumspb.INDEXED_VALUE_TYPE_DOUBLE
+	}
+	return fieldType
+}
@@ -32,6 +32,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/olivere/elastic/v7"
 	"github.com/temporalio/sqlparser"
@@ -53,12 +54,17 @@ type (
 		RangeCond      ExprConverter
 		ComparisonExpr ExprConverter
 		Is             ExprConverter
+		Not            ExprConverter
 	}
 
 	andConverter struct {
 		where ExprConverter
 	}
 
+	notConverter struct {
+		where ExprConverter
+	}
+
 	orConverter struct {
 		where ExprConverter
 	}
@@ -82,9 +88,12 @@ type (
 	notSupportedExprConverter struct{}
 
 	QueryParams struct {
-		Query   elastic.Query
-		Sorter  []elastic.Sorter
-		GroupBy []string
+		Query        elastic.Query
+		Sorter       []elastic.Sorter
+		GroupBy      []string
+		Aggregations []Aggregation
+		// GroupByDateTrunc maps fields from GroupBy which are bucketed with date_trunc function to the bucket unit.
+		GroupByDateTrunc map[string]DateTruncUnit
 	}
 )
 
@@ -145,6 +154,12 @@ func NewOrConverter(whereConverter ExprConverter) ExprConverter {
 	}
 }
 
+func NewNotConverter(whereConverter ExprConverter) ExprConverter {
+	return &notConverter{
+		where: whereConverter,
+	}
+}
+
 func NewRangeCondConverter(
 	fnInterceptor FieldNameInterceptor,
 	fvInterceptor FieldValuesInterceptor,
@@ -194,16 +209,7 @@ func NewNotSupportedExprConverter() ExprConverter {
 // ConvertWhereOrderBy transforms WHERE SQL statement to Elasticsearch query.
 // It also supports ORDER BY clause.
 func (c *Converter) ConvertWhereOrderBy(whereOrderBy string) (*QueryParams, error) {
-	whereOrderBy = strings.TrimSpace(whereOrderBy)
-
-	if whereOrderBy != "" &&
-		!strings.HasPrefix(strings.ToLower(whereOrderBy), "order by ") &&
-		!strings.HasPrefix(strings.ToLower(whereOrderBy), "group by ") {
-		whereOrderBy = "where " + whereOrderBy
-	}
-	// sqlparser can't parse just WHERE clause but instead accepts only valid SQL statement.
-	sql := fmt.Sprintf("select * from table1 %s", whereOrderBy)
-	return c.ConvertSql(sql)
+	return c.ConvertSql(SelectStatement(whereOrderBy))
 }
 
 // ConvertSql transforms SQL to Elasticsearch query.
@@ -239,10 +245,41 @@ func (c *Converter) convertSelect(sel *sqlparser.Select) (*QueryParams, error) {
 		queryParams.Query = query
 	}
 
-	if len(sel.GroupBy) > 1 {
-		return nil, NewConverterError("%s: 'group by' clause supports only a single field", NotSupportedErrMessage)
+	aggregationExprs, err := SelectedAggregations(sel)
+	if err != nil {
+		return nil, err
 	}
+	for _, funcExpr := range aggregationExprs {
+		aggregation, err := c.convertAggregation(funcExpr)
+		if err != nil {
+			return nil, wrapConverterError("unable to convert aggregate function", err)
+		}
+		queryParams.Aggregations = append(queryParams.Aggregations, aggregation)
+	}
+
 	for _, groupByExpr := range sel.GroupBy {
+		if funcExpr, isFuncExpr := groupByExpr.(*sqlparser.FuncExpr); isFuncExpr {
+			if strings.EqualFold(funcExpr.Name.String(), DateTruncFuncName) {
+				colName, unit, err := c.convertDateTruncGroupBy(funcExpr)
+				if err != nil {
+					return nil, wrapConverterError("unable to convert 'group by' function", err)
+				}
+				if queryParams.GroupByDateTrunc == nil {
+					queryParams.GroupByDateTrunc = make(map[string]DateTruncUnit)
+				}
+				if _, ok := queryParams.GroupByDateTrunc[colName]; ok {
+					return nil, NewConverterError(
+						"%s: field %s is bucketed more than once in 'group by' clause",
+						InvalidExpressionErrMessage,
+						colName,
+					)
+				}
+				queryParams.GroupByDateTrunc[colName] = unit
+				queryParams.GroupBy = append(queryParams.GroupBy, colName)
+				continue
+			}
+			return nil, NewAggregationInGroupByError(funcExpr)
+		}
 		colName, err := convertColName(c.fnInterceptor, groupByExpr, FieldNameGroupBy)
 		if err != nil {
 			return nil, wrapConverterError("unable to convert 'group by' column name", err)
@@ -262,7 +299,7 @@ func (c *Converter) convertSelect(sel *sqlparser.Select) (*QueryParams, error) {
 		queryParams.Sorter = append(queryParams.Sorter, fieldSort)
 	}
 
-	if len(queryParams.GroupBy) > 0 && len(queryParams.Sorter) > 0 {
+	if queryParams.IsAggregation() && len(queryParams.Sorter) > 0 {
 		return nil, NewConverterError(
 			"%s: 'order by' clause is not supported with 'group by' clause",
 			NotSupportedErrMessage,
@@ -272,6 +309,35 @@ func (c *Converter) convertSelect(sel *sqlparser.Select) (*QueryParams, error) {
 	return queryParams, nil
 }
 
+func (c *Converter) convertAggregation(funcExpr *sqlparser.FuncExpr) (Aggregation, error) {
+	funcName, argExpr, err := ParseAggregationFuncExpr(funcExpr)
+	if err != nil {
+		return Aggregation{}, err
+	}
+	colName, err := convertColName(c.fnInterceptor, argExpr, FieldNameAggregation)
+	if err != nil {
+		return Aggregation{}, err
+	}
+	return Aggregation{Func: funcName, FieldName: colName}, nil
+}
+
+func (c *Converter) convertDateTruncGroupBy(funcExpr *sqlparser.FuncExpr) (string, DateTruncUnit, error) {
+	unit, argExpr, err := ParseDateTruncFuncExpr(funcExpr)
+	if err != nil {
+		return "", "", err
+	}
+	colName, err := convertColName(c.fnInterceptor, argExpr, FieldNameDateTruncFuncArg)
+	if err != nil {
+		return "", "", err
+	}
+	return colName, unit, nil
+}
+
+// IsAggregation returns true if query has 'group by' clause or selects aggregate functions.
+func (qp *QueryParams) IsAggregation() bool {
+	return len(qp.GroupBy) > 0 || len(qp.Aggregations) > 0
+}
+
 func (w *WhereConverter) Convert(expr sqlparser.Expr) (elastic.Query, error) {
 	if expr == nil {
 		return nil, errors.New("cannot be nil")
@@ -291,7 +357,10 @@ func (w *WhereConverter) Convert(expr sqlparser.Expr) (elastic.Query, error) {
 	case *sqlparser.IsExpr:
 		return w.Is.Convert(e)
 	case *sqlparser.NotExpr:
-		return nil, NewConverterError("%s: 'not' expression", NotSupportedErrMessage)
+		if w.Not == nil {
+			return nil, NewConverterError("%s: 'not' expression", NotSupportedErrMessage)
+		}
+		return w.Not.Convert(e)
 	case *sqlparser.FuncExpr:
 		return nil, NewConverterError("%s: function expression", NotSupportedErrMessage)
 	case *sqlparser.ColName:
@@ -334,6 +403,19 @@ func (a *andConverter) Convert(expr sqlparser.Expr) (elastic.Query, error) {
 	return elastic.NewBoolQuery().Filter(leftQuery, rightQuery), nil
 }
 
+func (n *notConverter) Convert(expr sqlparser.Expr) (elastic.Query, error) {
+	notExpr, ok := expr.(*sqlparser.NotExpr)
+	if !ok {
+		return nil, NewConverterError("%v is not a 'not' expression", sqlparser.String(expr))
+	}
+
+	query, err := n.where.Convert(notExpr.Expr)
+	if err != nil {
+		return nil, err
+	}
+	return elastic.NewBoolQuery().MustNot(query), nil
+}
+
 func (o *orConverter) Convert(expr sqlparser.Expr) (elastic.Query, error) {
 	orExpr, ok := expr.(*sqlparser.OrExpr)
 	if !ok {
@@ -378,11 +460,11 @@ func (r *rangeCondConverter) Convert(expr sqlparser.Expr) (elastic.Query, error)
 		return nil, wrapConverterError("unable to convert left part of 'between' expression", err)
 	}
 
-	fromValue, err := ParseSqlValue(sqlparser.String(rangeCond.From))
+	fromValue, err := convertRangeCondValue(rangeCond.From)
 	if err != nil {
 		return nil, err
 	}
-	toValue, err := ParseSqlValue(sqlparser.String(rangeCond.To))
+	toValue, err := convertRangeCondValue(rangeCond.To)
 	if err != nil {
 		return nil, err
 	}
@@ -439,6 +521,10 @@ func (c *comparisonExprConverter) Convert(expr sqlparser.Expr) (elastic.Query, e
 		return nil, NewConverterError("%v is not a comparison expression", sqlparser.String(expr))
 	}
 
+	if funcExpr, isFuncExpr := comparisonExpr.Left.(*sqlparser.FuncExpr); isFuncExpr {
+		return c.convertFuncComparisonExpr(comparisonExpr, funcExpr)
+	}
+
 	colName, err := convertColName(c.fnInterceptor, comparisonExpr.Left, FieldNameFilter)
 	if err != nil {
 		return nil, wrapConverterError(
@@ -447,27 +533,9 @@ func (c *comparisonExprConverter) Convert(expr sqlparser.Expr) (elastic.Query, e
 		)
 	}
 
-	colValue, err := convertComparisonExprValue(comparisonExpr.Right)
+	colValues, err := c.convertValues(colName, comparisonExpr)
 	if err != nil {
-		return nil, wrapConverterError(
-			fmt.Sprintf("unable to convert right side of %q", sqlparser.String(expr)),
-			err,
-		)
-	}
-
-	colValues, isArray := colValue.([]interface{})
-	// colValue should be an array only for "in (1,2,3)" queries.
-	if !isArray {
-		colValues = []interface{}{colValue}
-	}
-
-	colValues, err = c.fvInterceptor.Values(colName, colValues...)
-	if err != nil {
-		return nil, wrapConverterError("unable to convert values of comparison expression", err)
-	}
-
-	if _, ok := c.allowedOperators[comparisonExpr.Operator]; !ok {
-		return nil, NewConverterError("operator '%v' not allowed in comparison expression", comparisonExpr.Operator)
+		return nil, err
 	}
 
 	var query elastic.Query
@@ -507,6 +575,178 @@ func (c *comparisonExprConverter) Convert(expr sqlparser.Expr) (elastic.Query, e
 	return query, nil
 }
 
+// convertValues converts right side of comparison expression and validates the operator.
+func (c *comparisonExprConverter) convertValues(
+	colName string,
+	comparisonExpr *sqlparser.ComparisonExpr,
+) ([]interface{}, error) {
+	colValue, err := convertComparisonExprValue(comparisonExpr.Right)
+	if err != nil {
+		return nil, wrapConverterError(
+			fmt.Sprintf("unable to convert right side of %q", sqlparser.String(comparisonExpr)),
+			err,
+		)
+	}
+
+	colValues, isArray := colValue.([]interface{})
+	// colValue should be an array only for "in (1,2,3)" queries.
+	if !isArray {
+		colValues = []interface{}{colValue}
+	}
+
+	colValues, err = c.fvInterceptor.Values(colName, colValues...)
+	if err != nil {
+		return nil, wrapConverterError("unable to convert values of comparison expression", err)
+	}
+
+	if _, ok := c.allowedOperators[comparisonExpr.Operator]; !ok {
+		return nil, NewConverterError("operator '%v' not allowed in comparison expression", comparisonExpr.Operator)
+	}
+	return colValues, nil
+}
+
+func (c *comparisonExprConverter) convertFuncComparisonExpr(
+	comparisonExpr *sqlparser.ComparisonExpr,
+	funcExpr *sqlparser.FuncExpr,
+) (elastic.Query, error) {
+	var query elastic.Query
+	var err error
+	switch strings.ToLower(funcExpr.Name.String()) {
+	case LowerFuncName:
+		query, err = c.convertLowerComparisonExpr(comparisonExpr, funcExpr)
+	case DateTruncFuncName:
+		query, err = c.convertDateTruncComparisonExpr(comparisonExpr, funcExpr)
+	default:
+		err = NewConverterError(
+			"%s: function '%s', only '%s' and '%s' are supported on the left side of comparison expression",
+			NotSupportedErrMessage,
+			funcExpr.Name.String(),
+			LowerFuncName,
+			DateTruncFuncName,
+		)
+	}
+	if err != nil {
+		return nil, wrapConverterError(fmt.Sprintf("unable to convert %q", sqlparser.String(comparisonExpr)), err)
+	}
+	return query, nil
+}
+
+// convertLowerComparisonExpr converts comparison of lower(field) to case-insensitive query.
+// Value is lowercased too, so lower(WorkflowType) = 'MyWorkflow' matches 'myworkflow' and 'MYWORKFLOW'.
+func (c *comparisonExprConverter) convertLowerComparisonExpr(
+	comparisonExpr *sqlparser.ComparisonExpr,
+	funcExpr *sqlparser.FuncExpr,
+) (elastic.Query, error) {
+	argExpr, err := ParseLowerFuncExpr(funcExpr)
+	if err != nil {
+		return nil, err
+	}
+	colName, err := convertColName(c.fnInterceptor, argExpr, FieldNameLowerFuncArg)
+	if err != nil {
+		return nil, err
+	}
+	colValues, err := c.convertValues(colName, comparisonExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]elastic.Query, len(colValues))
+	for i, colValue := range colValues {
+		v, ok := colValue.(string)
+		if !ok {
+			return nil, NewConverterError(
+				"%s: value compared to '%s' function must be a string",
+				InvalidExpressionErrMessage,
+				LowerFuncName,
+			)
+		}
+		v = strings.ToLower(v)
+		switch comparisonExpr.Operator {
+		case sqlparser.StartsWithStr, sqlparser.NotStartsWithStr:
+			queries[i] = elastic.NewPrefixQuery(colName, v).CaseInsensitive(true)
+		default:
+			queries[i] = elastic.NewTermQuery(colName, v).CaseInsensitive(true)
+		}
+	}
+
+	switch comparisonExpr.Operator {
+	case sqlparser.EqualStr, sqlparser.StartsWithStr:
+		return queries[0], nil
+	case sqlparser.NotEqualStr, sqlparser.NotStartsWithStr, sqlparser.NotInStr:
+		return elastic.NewBoolQuery().MustNot(queries...), nil
+	case sqlparser.InStr:
+		// Terms query doesn't support case-insensitive search.
+		return elastic.NewBoolQuery().Should(queries...), nil
+	}
+	return nil, NewConverterError(
+		"%s: operator '%s' with function '%s'",
+		NotSupportedErrMessage,
+		comparisonExpr.Operator,
+		LowerFuncName,
+	)
+}
+
+// convertDateTruncComparisonExpr converts comparison of date_trunc(unit, field) to range query on the field.
+func (c *comparisonExprConverter) convertDateTruncComparisonExpr(
+	comparisonExpr *sqlparser.ComparisonExpr,
+	funcExpr *sqlparser.FuncExpr,
+) (elastic.Query, error) {
+	unit, argExpr, err := ParseDateTruncFuncExpr(funcExpr)
+	if err != nil {
+		return nil, err
+	}
+	colName, err := convertColName(c.fnInterceptor, argExpr, FieldNameDateTruncFuncArg)
+	if err != nil {
+		return nil, err
+	}
+	if _, isValTuple := comparisonExpr.Right.(sqlparser.ValTuple); isValTuple {
+		return nil, NewConverterError(
+			"%s: operator '%s' with function '%s'",
+			NotSupportedErrMessage,
+			comparisonExpr.Operator,
+			DateTruncFuncName,
+		)
+	}
+	colValues, err := c.convertValues(colName, comparisonExpr)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := colValues[0].(string)
+	if !ok {
+		return nil, NewConverterError(
+			"%s: value compared to '%s' function must be a datetime string",
+			InvalidExpressionErrMessage,
+			DateTruncFuncName,
+		)
+	}
+	value, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return nil, NewConverterError("%s: unable to parse datetime '%s'", InvalidExpressionErrMessage, v)
+	}
+	dateTruncRange, err := NewDateTruncRange(unit, comparisonExpr.Operator, value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case dateTruncRange.Empty:
+		return elastic.NewBoolQuery().MustNot(elastic.NewMatchAllQuery()), nil
+	case dateTruncRange.From.IsZero() && dateTruncRange.To.IsZero():
+		return elastic.NewExistsQuery(colName), nil
+	}
+	rangeQuery := elastic.NewRangeQuery(colName)
+	if !dateTruncRange.From.IsZero() {
+		rangeQuery.Gte(dateTruncRange.From.Format(time.RFC3339Nano))
+	}
+	if !dateTruncRange.To.IsZero() {
+		rangeQuery.Lt(dateTruncRange.To.Format(time.RFC3339Nano))
+	}
+	if dateTruncRange.Negate {
+		return elastic.NewBoolQuery().MustNot(rangeQuery), nil
+	}
+	return rangeQuery, nil
+}
+
 // convertComparisonExprValue returns a string, int64, float64, bool or
 // a slice with each value of one of those types.
 func convertComparisonExprValue(expr sqlparser.Expr) (interface{}, error) {
@@ -533,8 +773,8 @@ func convertComparisonExprValue(expr sqlparser.Expr) (interface{}, error) {
 		return result, nil
 	case *sqlparser.GroupConcatExpr:
 		return nil, NewConverterError("%s: 'group_concat'", NotSupportedErrMessage)
-	case *sqlparser.FuncExpr:
-		return nil, NewConverterError("%s: nested func", NotSupportedErrMessage)
+	case *sqlparser.FuncExpr, *sqlparser.BinaryExpr:
+		return convertRelativeTimeValue(expr)
 	case *sqlparser.ColName:
 		return nil, NewConverterError(
 			"%s: column name on the right side of comparison expression (did you forget to quote %q?)",
@@ -546,6 +786,26 @@ func convertComparisonExprValue(expr sqlparser.Expr) (interface{}, error) {
 	}
 }
 
+// convertRangeCondValue returns a string, int64 or float64 value of 'between' expression.
+func convertRangeCondValue(expr sqlparser.Expr) (interface{}, error) {
+	switch expr.(type) {
+	case *sqlparser.FuncExpr, *sqlparser.BinaryExpr:
+		return convertRelativeTimeValue(expr)
+	default:
+		return ParseSqlValue(sqlparser.String(expr))
+	}
+}
+
+// convertRelativeTimeValue evaluates relative time expression, eg: now() - interval 1 hour,
+// and returns it as a datetime string.
+func convertRelativeTimeValue(expr sqlparser.Expr) (interface{}, error) {
+	t, err := ParseRelativeTime(expr, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
 func (n *notSupportedExprConverter) Convert(expr sqlparser.Expr) (elastic.Query, error) {
 	return nil, NewConverterError("%s: expression of type %T", NotSupportedErrMessage, expr)
 }
@@ -0,0 +1,366 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package query
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/temporalio/sqlparser"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+type (
+	// DateTruncUnit is a unit of date_trunc function which truncates datetime value to the start of the unit.
+	// All values are truncated in UTC, and weeks start on Monday.
+	DateTruncUnit string
+
+	// DateTruncRange is a range of datetime values which satisfy comparison of truncated value.
+	// For example:
+	//   date_trunc('hour', StartTime) = '2023-01-01T10:00:00Z'
+	// is the same as:
+	//   StartTime >= '2023-01-01T10:00:00Z' AND StartTime < '2023-01-01T11:00:00Z'
+	// Filtering on range allows to use index on the field instead of applying function to every value.
+	DateTruncRange struct {
+		// From is inclusive lower bound. Zero value means there is no lower bound.
+		From time.Time
+		// To is exclusive upper bound. Zero value means there is no upper bound.
+		To time.Time
+		// Negate is true if values must be outside of the range.
+		Negate bool
+		// Empty is true if there is no value which satisfies the comparison,
+		// eg: equality with value which is not the start of the unit.
+		Empty bool
+	}
+)
+
+const (
+	LowerFuncName     = "lower"
+	DateTruncFuncName = "date_trunc"
+	NowFuncName       = "now"
+
+	DateTruncUnitSecond DateTruncUnit = "second"
+	DateTruncUnitMinute DateTruncUnit = "minute"
+	DateTruncUnitHour   DateTruncUnit = "hour"
+	DateTruncUnitDay    DateTruncUnit = "day"
+	DateTruncUnitWeek   DateTruncUnit = "week"
+	DateTruncUnitMonth  DateTruncUnit = "month"
+	DateTruncUnitYear   DateTruncUnit = "year"
+)
+
+// ParseDateTruncUnit parses unit of date_trunc function or interval expression.
+func ParseDateTruncUnit(unit string) (DateTruncUnit, error) {
+	switch u := DateTruncUnit(strings.ToLower(unit)); u {
+	case DateTruncUnitSecond,
+		DateTruncUnitMinute,
+		DateTruncUnitHour,
+		DateTruncUnitDay,
+		DateTruncUnitWeek,
+		DateTruncUnitMonth,
+		DateTruncUnitYear:
+		return u, nil
+	}
+	return "", NewConverterError(
+		"%s: unknown time unit '%s', must be one of: %s, %s, %s, %s, %s, %s, %s",
+		InvalidExpressionErrMessage,
+		unit,
+		DateTruncUnitSecond,
+		DateTruncUnitMinute,
+		DateTruncUnitHour,
+		DateTruncUnitDay,
+		DateTruncUnitWeek,
+		DateTruncUnitMonth,
+		DateTruncUnitYear,
+	)
+}
+
+// Truncate returns the start of the unit which t belongs to.
+func (u DateTruncUnit) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch u {
+	case DateTruncUnitSecond:
+		return t.Truncate(time.Second)
+	case DateTruncUnitMinute:
+		return t.Truncate(time.Minute)
+	case DateTruncUnitHour:
+		return t.Truncate(time.Hour)
+	case DateTruncUnitDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	case DateTruncUnitWeek:
+		// Weekday is 0 for Sunday, but weeks start on Monday.
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+	case DateTruncUnitMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case DateTruncUnitYear:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return t
+}
+
+// Add returns t shifted by n units.
+func (u DateTruncUnit) Add(t time.Time, n int) time.Time {
+	switch u {
+	case DateTruncUnitSecond:
+		return t.Add(time.Duration(n) * time.Second)
+	case DateTruncUnitMinute:
+		return t.Add(time.Duration(n) * time.Minute)
+	case DateTruncUnitHour:
+		return t.Add(time.Duration(n) * time.Hour)
+	case DateTruncUnitDay:
+		return t.AddDate(0, 0, n)
+	case DateTruncUnitWeek:
+		return t.AddDate(0, 0, 7*n)
+	case DateTruncUnitMonth:
+		return t.AddDate(0, n, 0)
+	case DateTruncUnitYear:
+		return t.AddDate(n, 0, 0)
+	}
+	return t
+}
+
+// NewDateTruncRange returns range of values x which satisfy: date_trunc(unit, x) <operator> value.
+func NewDateTruncRange(unit DateTruncUnit, operator string, value time.Time) (DateTruncRange, error) {
+	start := unit.Truncate(value)
+	next := unit.Add(start, 1)
+	isStart := start.Equal(value)
+	// ceil is the smallest start of the unit which is not before the value.
+	ceil := next
+	if isStart {
+		ceil = start
+	}
+
+	switch operator {
+	case sqlparser.EqualStr:
+		if !isStart {
+			return DateTruncRange{Empty: true}, nil
+		}
+		return DateTruncRange{From: start, To: next}, nil
+	case sqlparser.NotEqualStr:
+		if !isStart {
+			// Any value satisfies the comparison.
+			return DateTruncRange{}, nil
+		}
+		return DateTruncRange{From: start, To: next, Negate: true}, nil
+	case sqlparser.LessThanStr:
+		return DateTruncRange{To: ceil}, nil
+	case sqlparser.LessEqualStr:
+		return DateTruncRange{To: next}, nil
+	case sqlparser.GreaterThanStr:
+		return DateTruncRange{From: next}, nil
+	case sqlparser.GreaterEqualStr:
+		return DateTruncRange{From: ceil}, nil
+	}
+	return DateTruncRange{}, NewConverterError(
+		"%s: operator '%s' with function '%s'",
+		NotSupportedErrMessage,
+		operator,
+		DateTruncFuncName,
+	)
+}
+
+// ParseDateTruncFuncExpr returns unit and column name arguments of date_trunc function:
+//
+//	date_trunc('hour', StartTime)
+func ParseDateTruncFuncExpr(expr *sqlparser.FuncExpr) (DateTruncUnit, sqlparser.Expr, error) {
+	args, err := getFuncArgs(expr, 2)
+	if err != nil {
+		return "", nil, err
+	}
+	unitVal, ok := args[0].(*sqlparser.SQLVal)
+	if !ok || unitVal.Type != sqlparser.StrVal {
+		return "", nil, NewConverterError(
+			"%s: first argument of function '%s' must be a string literal time unit",
+			InvalidExpressionErrMessage,
+			DateTruncFuncName,
+		)
+	}
+	unit, err := ParseDateTruncUnit(string(unitVal.Val))
+	if err != nil {
+		return "", nil, err
+	}
+	return unit, args[1], nil
+}
+
+// ParseLowerFuncExpr returns column name argument of lower function.
+func ParseLowerFuncExpr(expr *sqlparser.FuncExpr) (sqlparser.Expr, error) {
+	args, err := getFuncArgs(expr, 1)
+	if err != nil {
+		return nil, err
+	}
+	return args[0], nil
+}
+
+// ValidateFuncFieldType validates that function can be applied to field of this type:
+// lower is supported for Keyword fields, and date_trunc is supported for Datetime fields.
+func ValidateFuncFieldType(funcName string, fieldName string, fieldType enumspb.IndexedValueType) error {
+	var expectedType enumspb.IndexedValueType
+	switch funcName {
+	case LowerFuncName:
+		expectedType = enumspb.INDEXED_VALUE_TYPE_KEYWORD
+	case DateTruncFuncName:
+		expectedType = enumspb.INDEXED_VALUE_TYPE_DATETIME
+	default:
+		return NewConverterError("%s: function '%s'", NotSupportedErrMessage, funcName)
+	}
+	if fieldType != expectedType {
+		return NewConverterError(
+			"%s: function '%s' is only supported for %s search attributes, %s has type %s",
+			NotSupportedErrMessage,
+			funcName,
+			expectedType.String(),
+			fieldName,
+			fieldType.String(),
+		)
+	}
+	return nil
+}
+
+// ParseRelativeTime evaluates relative time expression using now as the current time:
+//
+//	now()
+//	now() - interval 1 hour
+//	now() + interval '30' minute
+func ParseRelativeTime(expr sqlparser.Expr, now time.Time) (time.Time, error) {
+	t, ok, err := evaluateRelativeTime(expr, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !ok {
+		return time.Time{}, NewConverterError(
+			"%s: expression '%s' as value, only '%s()' and '%s() +/- interval' are supported",
+			NotSupportedErrMessage,
+			sqlparser.String(expr),
+			NowFuncName,
+			NowFuncName,
+		)
+	}
+	return t, nil
+}
+
+// evaluateRelativeTime returns false if expression is not a relative time expression.
+func evaluateRelativeTime(expr sqlparser.Expr, now time.Time) (time.Time, bool, error) {
+	switch e := expr.(type) {
+	case *sqlparser.FuncExpr:
+		if !strings.EqualFold(e.Name.String(), NowFuncName) {
+			return time.Time{}, false, nil
+		}
+		if len(e.Exprs) != 0 {
+			return time.Time{}, false, NewConverterError(
+				"%s: function '%s' doesn't accept arguments",
+				InvalidExpressionErrMessage,
+				NowFuncName,
+			)
+		}
+		return now.UTC(), true, nil
+	case *sqlparser.BinaryExpr:
+		t, ok, err := evaluateRelativeTime(e.Left, now)
+		if err != nil || !ok {
+			return time.Time{}, false, err
+		}
+		if e.Operator != sqlparser.PlusStr && e.Operator != sqlparser.MinusStr {
+			return time.Time{}, false, NewConverterError(
+				"%s: operator '%s' with '%s()', only '%s' and '%s' are supported",
+				NotSupportedErrMessage,
+				e.Operator,
+				NowFuncName,
+				sqlparser.PlusStr,
+				sqlparser.MinusStr,
+			)
+		}
+		n, unit, err := parseIntervalExpr(e.Right)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		if e.Operator == sqlparser.MinusStr {
+			n = -n
+		}
+		return unit.Add(t, n), true, nil
+	}
+	return time.Time{}, false, nil
+}
+
+// parseIntervalExpr parses interval expression: interval 1 hour.
+func parseIntervalExpr(expr sqlparser.Expr) (int, DateTruncUnit, error) {
+	intervalExpr, ok := expr.(*sqlparser.IntervalExpr)
+	if !ok {
+		return 0, "", NewConverterError(
+			"%s: only interval can be added to or subtracted from '%s()', eg: %s() - interval 1 hour",
+			InvalidExpressionErrMessage,
+			NowFuncName,
+			NowFuncName,
+		)
+	}
+	val, ok := intervalExpr.Expr.(*sqlparser.SQLVal)
+	if !ok {
+		return 0, "", NewConverterError(
+			"%s: interval value must be an integer literal",
+			InvalidExpressionErrMessage,
+		)
+	}
+	n, err := strconv.Atoi(string(val.Val))
+	if err != nil {
+		return 0, "", NewConverterError(
+			"%s: interval value must be an integer, got '%s'",
+			InvalidExpressionErrMessage,
+			val.Val,
+		)
+	}
+	unit, err := ParseDateTruncUnit(intervalExpr.Unit)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, unit, nil
+}
+
+func getFuncArgs(expr *sqlparser.FuncExpr, count int) ([]sqlparser.Expr, error) {
+	funcName := strings.ToLower(expr.Name.String())
+	if expr.Distinct {
+		return nil, NewConverterError("%s: 'distinct' in function '%s'", NotSupportedErrMessage, funcName)
+	}
+	if len(expr.Exprs) != count {
+		return nil, NewConverterError(
+			"%s: function '%s' expects %d argument(s), got %d",
+			InvalidExpressionErrMessage,
+			funcName,
+			count,
+			len(expr.Exprs),
+		)
+	}
+	args := make([]sqlparser.Expr, count)
+	for i := range expr.Exprs {
+		arg, ok := expr.Exprs[i].(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, NewConverterError(
+				"%s: invalid argument of function '%s'",
+				InvalidExpressionErrMessage,
+				funcName,
+			)
+		}
+		args[i] = arg.Expr
+	}
+	return args, nil
+}
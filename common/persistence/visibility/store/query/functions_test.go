@@ -0,0 +1,124 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/temporalio/sqlparser"
+)
+
+func TestDateTruncUnit_Truncate(t *testing.T) {
+	// Wednesday.
+	value := time.Date(2023, 11, 15, 10, 20, 30, 123, time.UTC)
+	tests := map[DateTruncUnit]time.Time{
+		DateTruncUnitSecond: time.Date(2023, 11, 15, 10, 20, 30, 0, time.UTC),
+		DateTruncUnitMinute: time.Date(2023, 11, 15, 10, 20, 0, 0, time.UTC),
+		DateTruncUnitHour:   time.Date(2023, 11, 15, 10, 0, 0, 0, time.UTC),
+		DateTruncUnitDay:    time.Date(2023, 11, 15, 0, 0, 0, 0, time.UTC),
+		DateTruncUnitWeek:   time.Date(2023, 11, 13, 0, 0, 0, 0, time.UTC),
+		DateTruncUnitMonth:  time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC),
+		DateTruncUnitYear:   time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for unit, expected := range tests {
+		assert.Equal(t, expected, unit.Truncate(value), unit)
+	}
+
+	// Sunday belongs to the week which started on Monday before.
+	sunday := time.Date(2023, 11, 19, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2023, 11, 13, 0, 0, 0, 0, time.UTC), DateTruncUnitWeek.Truncate(sunday))
+}
+
+func TestNewDateTruncRange(t *testing.T) {
+	start := time.Date(2023, 11, 15, 10, 0, 0, 0, time.UTC)
+	next := start.Add(time.Hour)
+	middle := start.Add(30 * time.Minute)
+
+	tests := []struct {
+		operator string
+		value    time.Time
+		expected DateTruncRange
+	}{
+		{operator: sqlparser.EqualStr, value: start, expected: DateTruncRange{From: start, To: next}},
+		{operator: sqlparser.EqualStr, value: middle, expected: DateTruncRange{Empty: true}},
+		{operator: sqlparser.NotEqualStr, value: start, expected: DateTruncRange{From: start, To: next, Negate: true}},
+		{operator: sqlparser.NotEqualStr, value: middle, expected: DateTruncRange{}},
+		{operator: sqlparser.LessThanStr, value: start, expected: DateTruncRange{To: start}},
+		{operator: sqlparser.LessThanStr, value: middle, expected: DateTruncRange{To: next}},
+		{operator: sqlparser.LessEqualStr, value: start, expected: DateTruncRange{To: next}},
+		{operator: sqlparser.LessEqualStr, value: middle, expected: DateTruncRange{To: next}},
+		{operator: sqlparser.GreaterThanStr, value: start, expected: DateTruncRange{From: next}},
+		{operator: sqlparser.GreaterThanStr, value: middle, expected: DateTruncRange{From: next}},
+		{operator: sqlparser.GreaterEqualStr, value: start, expected: DateTruncRange{From: start}},
+		{operator: sqlparser.GreaterEqualStr, value: middle, expected: DateTruncRange{From: next}},
+	}
+	for _, tc := range tests {
+		actual, err := NewDateTruncRange(DateTruncUnitHour, tc.operator, tc.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, actual, "%s %v", tc.operator, tc.value)
+	}
+
+	_, err := NewDateTruncRange(DateTruncUnitHour, sqlparser.InStr, start)
+	assert.Error(t, err)
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	now := time.Date(2023, 11, 15, 10, 20, 30, 0, time.UTC)
+	tests := map[string]time.Time{
+		"now()":                        now,
+		"NOW()":                        now,
+		"now() - interval 1 hour":      now.Add(-time.Hour),
+		"now() + interval '30' minute": now.Add(30 * time.Minute),
+		"now() - interval 2 day":       now.AddDate(0, 0, -2),
+		"now() - interval 1 month":     now.AddDate(0, -1, 0),
+	}
+	for expr, expected := range tests {
+		actual, err := ParseRelativeTime(parseValueExpr(t, expr), now)
+		assert.NoError(t, err, expr)
+		assert.Equal(t, expected, actual, expr)
+	}
+
+	errorCases := map[string]string{
+		"now(1)":                       InvalidExpressionErrMessage,
+		"now() * 2":                    NotSupportedErrMessage,
+		"now() - 1":                    InvalidExpressionErrMessage,
+		"now() - interval 'x' hour":    InvalidExpressionErrMessage,
+		"now() - interval 1 fortnight": InvalidExpressionErrMessage,
+		"today()":                      NotSupportedErrMessage,
+	}
+	for expr, expectedErrMessage := range errorCases {
+		_, err := ParseRelativeTime(parseValueExpr(t, expr), now)
+		assert.Error(t, err, expr)
+		assert.Contains(t, err.Error(), expectedErrMessage, expr)
+	}
+}
+
+func parseValueExpr(t *testing.T, expr string) sqlparser.Expr {
+	stmt, err := sqlparser.Parse("select * from table1 where a = " + expr)
+	assert.NoError(t, err)
+	return stmt.(*sqlparser.Select).Where.Expr.(*sqlparser.ComparisonExpr).Right
+}
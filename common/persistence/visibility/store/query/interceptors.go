@@ -43,6 +43,9 @@ const (
 	FieldNameFilter FieldNameUsage = iota
 	FieldNameSorter
 	FieldNameGroupBy
+	FieldNameAggregation
+	FieldNameLowerFuncArg
+	FieldNameDateTruncFuncArg
 )
 
 func (n *NopFieldNameInterceptor) Name(name string, _ FieldNameUsage) (string, error) {
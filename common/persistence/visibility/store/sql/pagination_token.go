@@ -34,6 +34,9 @@ type (
 		CloseTime time.Time
 		StartTime time.Time
 		RunID     string
+		// Relevance is set when the results are ordered by full-text search relevance.
+		// It's the relevance score rounded to an integer by the query.
+		Relevance *float64 `json:",omitempty"`
 	}
 )
 
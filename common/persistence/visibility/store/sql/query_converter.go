@@ -47,18 +47,34 @@ type (
 
 		convertTextComparisonExpr(expr *sqlparser.ComparisonExpr) (sqlparser.Expr, error)
 
+		// buildTextRelevanceExpr returns expression which computes the full-text relevance score
+		// of the Text search attribute comparison. Higher score means more relevant. It returns
+		// nil if the comparison is not valid.
+		buildTextRelevanceExpr(expr *sqlparser.ComparisonExpr) sqlparser.Expr
+
+		// buildSelectStmt builds the list query. If relevance is not empty, results are ordered
+		// by the relevance expression first.
 		buildSelectStmt(
 			namespaceID namespace.ID,
 			queryString string,
+			relevance string,
 			pageSize int,
 			token *pageToken,
 		) (string, []any)
 
-		buildCountStmt(namespaceID namespace.ID, queryString string, groupBy []string) (string, []any)
+		buildCountStmt(
+			namespaceID namespace.ID,
+			queryString string,
+			groupBy []string,
+			aggregations []string,
+		) (string, []any)
 
 		getDatetimeFormat() string
 
 		getCoalesceCloseTimeExpr() sqlparser.Expr
+
+		// buildDateTruncExpr returns expression which truncates datetime column to the start of the unit.
+		buildDateTruncExpr(unit query.DateTruncUnit, col sqlparser.Expr) sqlparser.Expr
 	}
 
 	QueryConverter struct {
@@ -70,12 +86,20 @@ type (
 		queryString   string
 
 		seenNamespaceDivision bool
+		// Relevance expressions of the Text search attributes matched in the query.
+		textRelevanceExprs []sqlparser.Expr
 	}
 
 	queryParams struct {
 		queryString string
 		// List of search attributes to group by (field name, not db name).
 		groupBy []string
+		// List of aggregate functions of the select list.
+		aggregations []*saAggregation
+		// Group by fields (field name, not db name) which are bucketed with date_trunc function.
+		groupByDateTrunc map[string]*saDateTrunc
+		// Relevance expression of the full-text search, nil if query doesn't match Text search attributes.
+		relevance sqlparser.Expr
 	}
 )
 
@@ -123,6 +147,17 @@ var (
 	supportedTextOperators = []string{
 		sqlparser.EqualStr,
 		sqlparser.NotEqualStr,
+		sqlparser.StartsWithStr,
+		sqlparser.NotStartsWithStr,
+	}
+
+	supportedLowerFuncOperators = []string{
+		sqlparser.EqualStr,
+		sqlparser.NotEqualStr,
+		sqlparser.InStr,
+		sqlparser.NotInStr,
+		sqlparser.StartsWithStr,
+		sqlparser.NotStartsWithStr,
 	}
 
 	supportedTypesRangeCond = []enumspb.IndexedValueType{
@@ -167,12 +202,17 @@ func (c *QueryConverter) BuildSelectStmt(
 	if err != nil {
 		return nil, err
 	}
-	if len(qp.groupBy) > 0 {
+	if len(qp.groupBy) > 0 || len(qp.aggregations) > 0 {
 		return nil, query.NewConverterError("%s: 'group by' clause", query.NotSupportedErrMessage)
 	}
+	var relevance string
+	if qp.relevance != nil {
+		relevance = sqlparser.String(qp.relevance)
+	}
 	queryString, queryArgs := c.buildSelectStmt(
 		c.namespaceID,
 		qp.queryString,
+		relevance,
 		pageSize,
 		token,
 	)
@@ -186,26 +226,32 @@ func (c *QueryConverter) BuildCountStmt() (*sqlplugin.VisibilitySelectFilter, er
 	}
 	groupByDbNames := make([]string, len(qp.groupBy))
 	for i, fieldName := range qp.groupBy {
+		if dateTrunc, ok := qp.groupByDateTrunc[fieldName]; ok {
+			groupByDbNames[i] = sqlparser.String(dateTrunc)
+			continue
+		}
 		groupByDbNames[i] = searchattribute.GetSqlDbColName(fieldName)
 	}
-	queryString, queryArgs := c.buildCountStmt(c.namespaceID, qp.queryString, groupByDbNames)
+	aggregationExprs := make([]string, len(qp.aggregations))
+	var aggregations []sqlplugin.VisibilityAggregation
+	for i, aggregation := range qp.aggregations {
+		aggregationExprs[i] = sqlparser.String(aggregation)
+		aggregations = append(aggregations, sqlplugin.VisibilityAggregation{
+			Func:      string(aggregation.funcName),
+			FieldName: aggregation.col.fieldName,
+		})
+	}
+	queryString, queryArgs := c.buildCountStmt(c.namespaceID, qp.queryString, groupByDbNames, aggregationExprs)
 	return &sqlplugin.VisibilitySelectFilter{
-		Query:     queryString,
-		QueryArgs: queryArgs,
-		GroupBy:   qp.groupBy,
+		Query:        queryString,
+		QueryArgs:    queryArgs,
+		GroupBy:      qp.groupBy,
+		Aggregations: aggregations,
 	}, nil
 }
 
 func (c *QueryConverter) convertWhereString(queryString string) (*queryParams, error) {
-	where := strings.TrimSpace(queryString)
-	if where != "" &&
-		!strings.HasPrefix(strings.ToLower(where), "order by") &&
-		!strings.HasPrefix(strings.ToLower(where), "group by") {
-		where = "where " + where
-	}
-	// sqlparser can't parse just WHERE clause but instead accepts only valid SQL statement.
-	sql := "select * from table1 " + where
-	stmt, err := sqlparser.Parse(sql)
+	stmt, err := sqlparser.Parse(query.SelectStatement(queryString))
 	if err != nil {
 		return nil, query.NewConverterError("%s: %v", query.MalformedSqlQueryErrMessage, err)
 	}
@@ -220,10 +266,32 @@ func (c *QueryConverter) convertWhereString(queryString string) (*queryParams, e
 	if selectStmt.Where != nil {
 		res.queryString = sqlparser.String(selectStmt.Where.Expr)
 	}
+	res.relevance = buildRelevanceExpr(c.textRelevanceExprs)
+	for _, selectExpr := range selectStmt.SelectExprs {
+		// The parser already ensures the select list is '*' or a list of saAggregation.
+		if aliasedExpr, ok := selectExpr.(*sqlparser.AliasedExpr); ok {
+			res.aggregations = append(res.aggregations, aliasedExpr.Expr.(*saAggregation))
+		}
+	}
 	for _, groupByExpr := range selectStmt.GroupBy {
-		// The parser already ensures the type is saColName.
-		colName := groupByExpr.(*saColName)
-		res.groupBy = append(res.groupBy, colName.fieldName)
+		// The parser already ensures the type is saColName or saDateTrunc.
+		switch e := groupByExpr.(type) {
+		case *saColName:
+			res.groupBy = append(res.groupBy, e.fieldName)
+		case *saDateTrunc:
+			if _, ok := res.groupByDateTrunc[e.col.fieldName]; ok {
+				return nil, query.NewConverterError(
+					"%s: field %s is bucketed more than once in 'group by' clause",
+					query.InvalidExpressionErrMessage,
+					e.col.alias,
+				)
+			}
+			if res.groupByDateTrunc == nil {
+				res.groupByDateTrunc = make(map[string]*saDateTrunc)
+			}
+			res.groupByDateTrunc[e.col.fieldName] = e
+			res.groupBy = append(res.groupBy, e.col.fieldName)
+		}
 	}
 	return res, nil
 }
@@ -282,29 +350,76 @@ func (c *QueryConverter) convertSelectStmt(sel *sqlparser.Select) error {
 		}
 	}
 
-	if len(sel.GroupBy) > 1 {
-		return query.NewConverterError(
-			"%s: 'group by' clause supports only a single field",
-			query.NotSupportedErrMessage,
-		)
+	aggregationExprs, err := query.SelectedAggregations(sel)
+	if err != nil {
+		return err
 	}
+	for k := range aggregationExprs {
+		aggregation, err := c.convertAggregation(aggregationExprs[k])
+		if err != nil {
+			return err
+		}
+		sel.SelectExprs[k] = &sqlparser.AliasedExpr{Expr: aggregation}
+	}
+
 	for k := range sel.GroupBy {
+		if funcExpr, isFuncExpr := sel.GroupBy[k].(*sqlparser.FuncExpr); isFuncExpr {
+			if strings.EqualFold(funcExpr.Name.String(), query.DateTruncFuncName) {
+				dateTrunc, err := c.convertDateTruncGroupBy(funcExpr)
+				if err != nil {
+					return err
+				}
+				sel.GroupBy[k] = dateTrunc
+				continue
+			}
+			return query.NewAggregationInGroupByError(funcExpr)
+		}
 		colName, err := c.convertColName(&sel.GroupBy[k])
 		if err != nil {
 			return err
 		}
-		if colName.fieldName != searchattribute.ExecutionStatus {
-			return query.NewConverterError(
-				"%s: 'group by' clause is only supported for %s search attribute",
-				query.NotSupportedErrMessage,
-				searchattribute.ExecutionStatus,
-			)
+		if err := query.ValidateGroupByFieldType(colName.alias, colName.valueType); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+func (c *QueryConverter) convertAggregation(funcExpr *sqlparser.FuncExpr) (*saAggregation, error) {
+	funcName, argExpr, err := query.ParseAggregationFuncExpr(funcExpr)
+	if err != nil {
+		return nil, err
+	}
+	colName, err := c.convertColName(&argExpr)
+	if err != nil {
+		return nil, err
+	}
+	err = query.ValidateAggregationFieldType(funcName, colName.alias, colName.valueType)
+	if err != nil {
+		return nil, err
+	}
+	return newSAAggregation(funcName, colName), nil
+}
+
+func (c *QueryConverter) convertDateTruncGroupBy(funcExpr *sqlparser.FuncExpr) (*saDateTrunc, error) {
+	unit, argExpr, err := query.ParseDateTruncFuncExpr(funcExpr)
+	if err != nil {
+		return nil, err
+	}
+	colName, err := c.convertColName(&argExpr)
+	if err != nil {
+		return nil, err
+	}
+	err = query.ValidateFuncFieldType(query.DateTruncFuncName, colName.alias, colName.valueType)
+	if err != nil {
+		return nil, err
+	}
+	// Use db column directly instead of search attribute expression (eg: coalesce for CloseTime)
+	// so running workflows are grouped in null bucket.
+	return newSADateTrunc(unit, colName, c.buildDateTruncExpr(unit, colName.dbColName)), nil
+}
+
 func (c *QueryConverter) convertWhereExpr(expr *sqlparser.Expr) error {
 	if expr == nil || *expr == nil {
 		return errors.New("cannot be nil")
@@ -376,29 +491,55 @@ func (c *QueryConverter) convertComparisonExpr(exprRef *sqlparser.Expr) error {
 		)
 	}
 
-	saColNameExpr, err := c.convertColName(&expr.Left)
-	if err != nil {
-		return err
-	}
-
-	err = c.convertValueExpr(&expr.Right, saColNameExpr.alias, saColNameExpr.valueType)
-	if err != nil {
-		return err
-	}
-
-	switch saColNameExpr.valueType {
-	case enumspb.INDEXED_VALUE_TYPE_KEYWORD_LIST:
-		newExpr, err := c.convertKeywordListComparisonExpr(expr)
+	if funcExpr, isFuncExpr := expr.Left.(*sqlparser.FuncExpr); isFuncExpr {
+		switch strings.ToLower(funcExpr.Name.String()) {
+		case query.LowerFuncName:
+			if err := c.convertLowerComparisonExpr(expr, funcExpr); err != nil {
+				return err
+			}
+		case query.DateTruncFuncName:
+			return c.convertDateTruncComparisonExpr(exprRef, funcExpr)
+		default:
+			return query.NewConverterError(
+				"%s: function '%s', only '%s' and '%s' are supported on the left side of comparison expression",
+				query.NotSupportedErrMessage,
+				funcExpr.Name.String(),
+				query.LowerFuncName,
+				query.DateTruncFuncName,
+			)
+		}
+	} else {
+		saColNameExpr, err := c.convertColName(&expr.Left)
 		if err != nil {
 			return err
 		}
-		*exprRef = newExpr
-	case enumspb.INDEXED_VALUE_TYPE_TEXT:
-		newExpr, err := c.convertTextComparisonExpr(expr)
+
+		err = c.convertValueExpr(&expr.Right, saColNameExpr.alias, saColNameExpr.valueType)
 		if err != nil {
 			return err
 		}
-		*exprRef = newExpr
+
+		switch saColNameExpr.valueType {
+		case enumspb.INDEXED_VALUE_TYPE_KEYWORD_LIST:
+			newExpr, err := c.convertKeywordListComparisonExpr(expr)
+			if err != nil {
+				return err
+			}
+			*exprRef = newExpr
+		case enumspb.INDEXED_VALUE_TYPE_TEXT:
+			newExpr, err := c.convertTextComparisonExpr(expr)
+			if err != nil {
+				return err
+			}
+			if expr.Operator == sqlparser.EqualStr || expr.Operator == sqlparser.StartsWithStr {
+				if relevanceExpr := c.buildTextRelevanceExpr(expr); relevanceExpr != nil {
+					c.textRelevanceExprs = append(c.textRelevanceExprs, relevanceExpr)
+				}
+			}
+			*exprRef = newExpr
+			// Prefix search on Text search attribute is done by full-text search, not LIKE.
+			return nil
+		}
 	}
 
 	switch expr.Operator {
@@ -424,6 +565,153 @@ func (c *QueryConverter) convertComparisonExpr(exprRef *sqlparser.Expr) error {
 	return nil
 }
 
+// convertLowerComparisonExpr converts comparison of lower(field) by lowercasing the values too,
+// so lower(WorkflowType) = 'MyWorkflow' matches 'myworkflow' and 'MYWORKFLOW'.
+func (c *QueryConverter) convertLowerComparisonExpr(
+	expr *sqlparser.ComparisonExpr,
+	funcExpr *sqlparser.FuncExpr,
+) error {
+	argExpr, err := query.ParseLowerFuncExpr(funcExpr)
+	if err != nil {
+		return err
+	}
+	saColNameExpr, err := c.convertColName(&argExpr)
+	if err != nil {
+		return err
+	}
+	err = query.ValidateFuncFieldType(query.LowerFuncName, saColNameExpr.alias, saColNameExpr.valueType)
+	if err != nil {
+		return err
+	}
+	if !isSupportedOperator(supportedLowerFuncOperators, expr.Operator) {
+		return query.NewConverterError(
+			"%s: operator '%s' with function '%s'",
+			query.NotSupportedErrMessage,
+			expr.Operator,
+			query.LowerFuncName,
+		)
+	}
+	err = c.convertValueExpr(&expr.Right, saColNameExpr.alias, saColNameExpr.valueType)
+	if err != nil {
+		return err
+	}
+
+	values := []sqlparser.Expr{expr.Right}
+	if valTuple, isValTuple := expr.Right.(sqlparser.ValTuple); isValTuple {
+		values = valTuple
+	}
+	for _, value := range values {
+		strValue, ok := value.(*unsafeSQLString)
+		if !ok {
+			return query.NewConverterError(
+				"%s: value compared to '%s' function must be a string (got: %v)",
+				query.InvalidExpressionErrMessage,
+				query.LowerFuncName,
+				sqlparser.String(value),
+			)
+		}
+		strValue.Val = strings.ToLower(strValue.Val)
+	}
+	expr.Left = newFuncExpr(query.LowerFuncName, argExpr)
+	return nil
+}
+
+// convertDateTruncComparisonExpr replaces comparison of date_trunc(unit, field) with range
+// condition on the field, so the index on the field can be used.
+func (c *QueryConverter) convertDateTruncComparisonExpr(
+	exprRef *sqlparser.Expr,
+	funcExpr *sqlparser.FuncExpr,
+) error {
+	expr := (*exprRef).(*sqlparser.ComparisonExpr)
+	unit, argExpr, err := query.ParseDateTruncFuncExpr(funcExpr)
+	if err != nil {
+		return err
+	}
+	saColNameExpr, err := c.convertColName(&argExpr)
+	if err != nil {
+		return err
+	}
+	err = query.ValidateFuncFieldType(query.DateTruncFuncName, saColNameExpr.alias, saColNameExpr.valueType)
+	if err != nil {
+		return err
+	}
+	if _, isValTuple := expr.Right.(sqlparser.ValTuple); isValTuple {
+		return query.NewConverterError(
+			"%s: operator '%s' with function '%s'",
+			query.NotSupportedErrMessage,
+			expr.Operator,
+			query.DateTruncFuncName,
+		)
+	}
+
+	var value time.Time
+	switch e := expr.Right.(type) {
+	case *sqlparser.SQLVal:
+		v, err := query.ParseSqlValue(sqlparser.String(e))
+		if err != nil {
+			return err
+		}
+		value, err = parseDatetimeValue(v, saColNameExpr.alias)
+		if err != nil {
+			return err
+		}
+	case *sqlparser.FuncExpr, *sqlparser.BinaryExpr:
+		value, err = query.ParseRelativeTime(e, time.Now())
+		if err != nil {
+			return err
+		}
+	default:
+		return query.NewConverterError(
+			"%s: value compared to '%s' function must be a datetime (got: %v)",
+			query.InvalidExpressionErrMessage,
+			query.DateTruncFuncName,
+			sqlparser.String(expr.Right),
+		)
+	}
+	dateTruncRange, err := query.NewDateTruncRange(unit, expr.Operator, value)
+	if err != nil {
+		return err
+	}
+
+	if dateTruncRange.Empty {
+		*exprRef = &sqlparser.ComparisonExpr{
+			Operator: sqlparser.EqualStr,
+			Left:     sqlparser.NewIntVal([]byte("1")),
+			Right:    sqlparser.NewIntVal([]byte("0")),
+		}
+		return nil
+	}
+	var conditions []sqlparser.Expr
+	if !dateTruncRange.From.IsZero() {
+		conditions = append(conditions, &sqlparser.ComparisonExpr{
+			Operator: sqlparser.GreaterEqualStr,
+			Left:     argExpr,
+			Right:    newUnsafeSQLString(dateTruncRange.From.Format(c.getDatetimeFormat())),
+		})
+	}
+	if !dateTruncRange.To.IsZero() {
+		conditions = append(conditions, &sqlparser.ComparisonExpr{
+			Operator: sqlparser.LessThanStr,
+			Left:     argExpr,
+			Right:    newUnsafeSQLString(dateTruncRange.To.Format(c.getDatetimeFormat())),
+		})
+	}
+	var newExpr sqlparser.Expr
+	switch len(conditions) {
+	case 0:
+		newExpr = &sqlparser.IsExpr{Operator: sqlparser.IsNotNullStr, Expr: argExpr}
+	case 1:
+		newExpr = conditions[0]
+	default:
+		newExpr = &sqlparser.AndExpr{Left: conditions[0], Right: conditions[1]}
+	}
+	if dateTruncRange.Negate {
+		newExpr = &sqlparser.NotExpr{Expr: &sqlparser.ParenExpr{Expr: newExpr}}
+	}
+	*exprRef = &sqlparser.ParenExpr{Expr: newExpr}
+	return nil
+}
+
 func (c *QueryConverter) convertRangeCond(exprRef *sqlparser.Expr) error {
 	expr, ok := (*exprRef).(*sqlparser.RangeCond)
 	if !ok {
@@ -548,8 +836,23 @@ func (c *QueryConverter) convertValueExpr(
 		return nil
 	case *sqlparser.GroupConcatExpr:
 		return query.NewConverterError("%s: 'group_concat'", query.NotSupportedErrMessage)
-	case *sqlparser.FuncExpr:
-		return query.NewConverterError("%s: nested func", query.NotSupportedErrMessage)
+	case *sqlparser.FuncExpr, *sqlparser.BinaryExpr:
+		// This is relative time case, eg: now() - interval 1 hour.
+		value, err := query.ParseRelativeTime(expr, time.Now())
+		if err != nil {
+			return err
+		}
+		if saType != enumspb.INDEXED_VALUE_TYPE_DATETIME {
+			return query.NewConverterError(
+				"%s: relative time can only be compared to %s search attributes, %s has type %s",
+				query.InvalidExpressionErrMessage,
+				enumspb.INDEXED_VALUE_TYPE_DATETIME.String(),
+				saName,
+				saType.String(),
+			)
+		}
+		*exprRef = newUnsafeSQLString(value.UTC().Format(c.getDatetimeFormat()))
+		return nil
 	case *sqlparser.ColName:
 		return query.NewConverterError(
 			"%s: column name on the right side of comparison expression (did you forget to quote '%s'?)",
@@ -589,27 +892,9 @@ func (c *QueryConverter) parseSQLVal(
 	}
 
 	if saType == enumspb.INDEXED_VALUE_TYPE_DATETIME {
-		var tm time.Time
-		switch v := value.(type) {
-		case int64:
-			tm = time.Unix(0, v)
-		case string:
-			var err error
-			tm, err = time.Parse(time.RFC3339Nano, v)
-			if err != nil {
-				return nil, query.NewConverterError(
-					"%s: unable to parse datetime '%s'",
-					query.InvalidExpressionErrMessage,
-					v,
-				)
-			}
-		default:
-			return nil, query.NewConverterError(
-				"%s: unexpected value type %T for search attribute %s",
-				query.InvalidExpressionErrMessage,
-				v,
-				saName,
-			)
+		tm, err := parseDatetimeValue(value, saName)
+		if err != nil {
+			return nil, err
 		}
 		return tm.UTC().Format(c.getDatetimeFormat()), nil
 	}
@@ -664,6 +949,31 @@ func (c *QueryConverter) parseSQLVal(
 	return value, nil
 }
 
+// parseDatetimeValue parses datetime value which is either epoch nanoseconds or RFC3339 string.
+func parseDatetimeValue(value any, saName string) (time.Time, error) {
+	switch v := value.(type) {
+	case int64:
+		return time.Unix(0, v), nil
+	case string:
+		tm, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, query.NewConverterError(
+				"%s: unable to parse datetime '%s'",
+				query.InvalidExpressionErrMessage,
+				v,
+			)
+		}
+		return tm, nil
+	default:
+		return time.Time{}, query.NewConverterError(
+			"%s: unexpected value type %T for search attribute %s",
+			query.InvalidExpressionErrMessage,
+			v,
+			saName,
+		)
+	}
+}
+
 func (c *QueryConverter) convertIsExpr(exprRef *sqlparser.Expr) error {
 	expr, ok := (*exprRef).(*sqlparser.IsExpr)
 	if !ok {
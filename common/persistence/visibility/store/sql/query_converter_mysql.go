@@ -61,6 +61,12 @@ type (
 var (
 	convertTypeDatetime = &sqlparser.ConvertType{Type: "datetime"}
 	convertTypeJSON     = &sqlparser.ConvertType{Type: "json"}
+
+	// Full-text boolean mode operators are replaced by spaces, so they are not interpreted from
+	// the user input of prefix searches. Double quotes are already escaped at this point.
+	mysqlBooleanModeOperatorsReplacer = strings.NewReplacer(
+		"+", " ", "-", " ", "<", " ", ">", " ", "(", " ", ")", " ", "~", " ", "*", " ", "@", " ", `\"`, " ",
+	)
 )
 
 var _ sqlparser.Expr = (*castExpr)(nil)
@@ -113,6 +119,39 @@ func (c *mysqlQueryConverter) getCoalesceCloseTimeExpr() sqlparser.Expr {
 	)
 }
 
+func (c *mysqlQueryConverter) buildDateTruncExpr(unit query.DateTruncUnit, col sqlparser.Expr) sqlparser.Expr {
+	var format string
+	switch unit {
+	case query.DateTruncUnitSecond:
+		format = "%Y-%m-%d %H:%i:%s"
+	case query.DateTruncUnitMinute:
+		format = "%Y-%m-%d %H:%i:00"
+	case query.DateTruncUnitHour:
+		format = "%Y-%m-%d %H:00:00"
+	case query.DateTruncUnitDay:
+		format = "%Y-%m-%d 00:00:00"
+	case query.DateTruncUnitWeek:
+		// WEEKDAY returns 0 for Monday.
+		return newFuncExpr(
+			"date_format",
+			&sqlparser.BinaryExpr{
+				Operator: sqlparser.MinusStr,
+				Left:     col,
+				Right: &sqlparser.IntervalExpr{
+					Expr: newFuncExpr("weekday", col),
+					Unit: "day",
+				},
+			},
+			newUnsafeSQLString("%Y-%m-%d 00:00:00"),
+		)
+	case query.DateTruncUnitMonth:
+		format = "%Y-%m-01 00:00:00"
+	case query.DateTruncUnitYear:
+		format = "%Y-01-01 00:00:00"
+	}
+	return newFuncExpr("date_format", col, newUnsafeSQLString(format))
+}
+
 func (c *mysqlQueryConverter) convertKeywordListComparisonExpr(
 	expr *sqlparser.ComparisonExpr,
 ) (sqlparser.Expr, error) {
@@ -196,22 +235,68 @@ func (c *mysqlQueryConverter) convertTextComparisonExpr(
 			formatComparisonExprStringForError(*expr),
 		)
 	}
-	// build the following expression:
-	// `match ({expr.Left}) against ({expr.Right} in natural language mode)`
-	var newExpr sqlparser.Expr = &sqlparser.MatchExpr{
-		Columns: []sqlparser.SelectExpr{&sqlparser.AliasedExpr{Expr: expr.Left}},
-		Expr:    expr.Right,
-		Option:  sqlparser.NaturalLanguageModeStr,
+	var newExpr sqlparser.Expr
+	newExpr, err := c.buildTextMatchExpr(expr)
+	if err != nil {
+		return nil, err
 	}
-	if expr.Operator == sqlparser.NotEqualStr {
+	if expr.Operator == sqlparser.NotEqualStr || expr.Operator == sqlparser.NotStartsWithStr {
 		newExpr = &sqlparser.NotExpr{Expr: newExpr}
 	}
 	return newExpr, nil
 }
 
+func (c *mysqlQueryConverter) buildTextRelevanceExpr(expr *sqlparser.ComparisonExpr) sqlparser.Expr {
+	// MATCH returns the relevance score when used outside of the WHERE clause.
+	matchExpr, err := c.buildTextMatchExpr(expr)
+	if err != nil {
+		return nil
+	}
+	return matchExpr
+}
+
+// buildTextMatchExpr builds the following expression for equality:
+// `match ({expr.Left}) against ({expr.Right} in natural language mode)`
+// Prefix search needs the truncation operator of boolean mode, so it builds the following
+// expression instead, which matches any of the tokens:
+// `match ({expr.Left}) against ('token1* token2*' in boolean mode)`
+func (c *mysqlQueryConverter) buildTextMatchExpr(
+	expr *sqlparser.ComparisonExpr,
+) (*sqlparser.MatchExpr, error) {
+	if expr.Operator == sqlparser.EqualStr || expr.Operator == sqlparser.NotEqualStr {
+		return &sqlparser.MatchExpr{
+			Columns: []sqlparser.SelectExpr{&sqlparser.AliasedExpr{Expr: expr.Left}},
+			Expr:    expr.Right,
+			Option:  sqlparser.NaturalLanguageModeStr,
+		}, nil
+	}
+	valueExpr, ok := expr.Right.(*unsafeSQLString)
+	if !ok {
+		return nil, query.NewConverterError(
+			"%s: unexpected value type (expected string, got %s)",
+			query.InvalidExpressionErrMessage,
+			sqlparser.String(expr.Right),
+		)
+	}
+	tokens := tokenizeTextQueryString(mysqlBooleanModeOperatorsReplacer.Replace(valueExpr.Val))
+	if len(tokens) == 0 {
+		return nil, query.NewConverterError(
+			"%s: unexpected value for Text type search attribute (no tokens found in %s)",
+			query.InvalidExpressionErrMessage,
+			sqlparser.String(expr.Right),
+		)
+	}
+	return &sqlparser.MatchExpr{
+		Columns: []sqlparser.SelectExpr{&sqlparser.AliasedExpr{Expr: expr.Left}},
+		Expr:    newUnsafeSQLString(strings.Join(addSuffix("*", tokens), " ")),
+		Option:  sqlparser.BooleanModeStr,
+	}, nil
+}
+
 func (c *mysqlQueryConverter) buildSelectStmt(
 	namespaceID namespace.ID,
 	queryString string,
+	relevance string,
 	pageSize int,
 	token *pageToken,
 ) (string, []any) {
@@ -228,47 +313,33 @@ func (c *mysqlQueryConverter) buildSelectStmt(
 		whereClauses = append(whereClauses, queryString)
 	}
 
+	coalesceCloseTimeExpr := sqlparser.String(c.getCoalesceCloseTimeExpr())
 	if token != nil {
-		whereClauses = append(
-			whereClauses,
-			fmt.Sprintf(
-				"((%s = ? AND %s = ? AND %s > ?) OR (%s = ? AND %s < ?) OR %s < ?)",
-				sqlparser.String(c.getCoalesceCloseTimeExpr()),
-				searchattribute.GetSqlDbColName(searchattribute.StartTime),
-				searchattribute.GetSqlDbColName(searchattribute.RunID),
-				sqlparser.String(c.getCoalesceCloseTimeExpr()),
-				searchattribute.GetSqlDbColName(searchattribute.StartTime),
-				sqlparser.String(c.getCoalesceCloseTimeExpr()),
-			),
-		)
-		queryArgs = append(
-			queryArgs,
-			token.CloseTime,
-			token.StartTime,
-			token.RunID,
-			token.CloseTime,
-			token.StartTime,
-			token.CloseTime,
-		)
+		tokenClause, tokenArgs := buildPageTokenWhereClause(coalesceCloseTimeExpr, relevance, token)
+		whereClauses = append(whereClauses, tokenClause)
+		queryArgs = append(queryArgs, tokenArgs...)
 	}
 
 	queryArgs = append(queryArgs, pageSize)
 
+	selectFields := strings.Join(addPrefix("ev.", sqlplugin.DbFields), ", ")
+	if relevance != "" {
+		selectFields += fmt.Sprintf(", %s AS %s", relevance, relevanceColName)
+	}
+
 	return fmt.Sprintf(
 		`SELECT %s
 		FROM executions_visibility ev
 		LEFT JOIN custom_search_attributes
 		USING (%s, %s)
 		WHERE %s
-		ORDER BY %s DESC, %s DESC, %s
+		ORDER BY %s
 		LIMIT ?`,
-		strings.Join(addPrefix("ev.", sqlplugin.DbFields), ", "),
+		selectFields,
 		searchattribute.GetSqlDbColName(searchattribute.NamespaceID),
 		searchattribute.GetSqlDbColName(searchattribute.RunID),
 		strings.Join(whereClauses, " AND "),
-		sqlparser.String(c.getCoalesceCloseTimeExpr()),
-		searchattribute.GetSqlDbColName(searchattribute.StartTime),
-		searchattribute.GetSqlDbColName(searchattribute.RunID),
+		strings.Join(buildSelectOrderBy(coalesceCloseTimeExpr, relevance), ", "),
 	), queryArgs
 }
 
@@ -276,6 +347,7 @@ func (c *mysqlQueryConverter) buildCountStmt(
 	namespaceID namespace.ID,
 	queryString string,
 	groupBy []string,
+	aggregations []string,
 ) (string, []any) {
 	var whereClauses []string
 	var queryArgs []any
@@ -302,7 +374,7 @@ func (c *mysqlQueryConverter) buildCountStmt(
 		USING (%s, %s)
 		WHERE %s
 		%s`,
-		strings.Join(append(groupBy, "COUNT(*)"), ", "),
+		strings.Join(buildCountSelectExprs(groupBy, aggregations), ", "),
 		searchattribute.GetSqlDbColName(searchattribute.NamespaceID),
 		searchattribute.GetSqlDbColName(searchattribute.RunID),
 		strings.Join(whereClauses, " AND "),
@@ -57,6 +57,31 @@ func (s *mysqlQueryConverterSuite) TestGetCoalesceCloseTimeExpr() {
 	)
 }
 
+func (s *mysqlQueryConverterSuite) TestBuildDateTruncExpr() {
+	s.Equal(
+		"date_format(start_time, '%Y-%m-%d %H:00:00')",
+		sqlparser.String(s.queryConverter.buildDateTruncExpr(query.DateTruncUnitHour, newColName("start_time"))),
+	)
+	s.Equal(
+		"date_format(start_time - interval weekday(start_time) day, '%Y-%m-%d 00:00:00')",
+		sqlparser.String(s.queryConverter.buildDateTruncExpr(query.DateTruncUnitWeek, newColName("start_time"))),
+	)
+	s.Equal(
+		"date_format(start_time, '%Y-%m-01 00:00:00')",
+		sqlparser.String(s.queryConverter.buildDateTruncExpr(query.DateTruncUnitMonth, newColName("start_time"))),
+	)
+}
+
+func (s *mysqlQueryConverterSuite) TestBuildTextRelevanceExpr() {
+	s.queryConverter.queryString = "AliasForText01 = 'foo bar' AND AliasForText01 starts_with 'foo'"
+	qp, err := s.queryConverter.convertWhereString(s.queryConverter.queryString)
+	s.NoError(err)
+	s.Equal(
+		`round((match(Text01) against ('foo bar' in natural language mode) + match(Text01) against ('foo*' in boolean mode)) * 1000000)`,
+		sqlparser.String(qp.relevance),
+	)
+}
+
 func (s *mysqlQueryConverterSuite) TestConvertKeywordListComparisonExpr() {
 	var tests = []testCase{
 		{
@@ -139,6 +164,40 @@ func (s *mysqlQueryConverterSuite) TestConvertTextComparisonExpr() {
 			output: "not match(Text01) against ('foo bar' in natural language mode)",
 			err:    nil,
 		},
+		{
+			name:   "valid starts_with expression",
+			input:  "AliasForText01 starts_with 'foo bar'",
+			output: "match(Text01) against ('foo* bar*' in boolean mode)",
+			err:    nil,
+		},
+		{
+			name:   "valid not starts_with expression",
+			input:  "AliasForText01 not starts_with 'foo'",
+			output: "not match(Text01) against ('foo*' in boolean mode)",
+			err:    nil,
+		},
+		{
+			name:   "boolean mode operators are not interpreted in equal expression",
+			input:  `AliasForText01 = '+foo -bar'`,
+			output: "match(Text01) against ('+foo -bar' in natural language mode)",
+			err:    nil,
+		},
+		{
+			name:   "boolean mode operators are ignored in starts_with expression",
+			input:  `AliasForText01 starts_with '+foo -bar "baz" qux*'`,
+			output: "match(Text01) against ('foo* bar* baz* qux*' in boolean mode)",
+			err:    nil,
+		},
+		{
+			name:   "no tokens",
+			input:  "AliasForText01 starts_with '+ -'",
+			output: "",
+			err: query.NewConverterError(
+				"%s: unexpected value for Text type search attribute (no tokens found in %s)",
+				query.InvalidExpressionErrMessage,
+				"'+ -'",
+			),
+		},
 	}
 
 	for _, tc := range tests {
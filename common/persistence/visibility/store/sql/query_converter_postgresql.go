@@ -49,6 +49,8 @@ const (
 	jsonBuildArrayFuncName = "jsonb_build_array"
 	jsonContainsOp         = "@>"
 	ftsMatchOp             = "@@"
+	tsRankFuncName         = "ts_rank"
+	tsQueryPrefixSuffix    = ":*"
 )
 
 var (
@@ -91,6 +93,10 @@ func (c *pgQueryConverter) getCoalesceCloseTimeExpr() sqlparser.Expr {
 	)
 }
 
+func (c *pgQueryConverter) buildDateTruncExpr(unit query.DateTruncUnit, col sqlparser.Expr) sqlparser.Expr {
+	return newFuncExpr(query.DateTruncFuncName, newUnsafeSQLString(string(unit)), col)
+}
+
 func (c *pgQueryConverter) convertKeywordListComparisonExpr(
 	expr *sqlparser.ComparisonExpr,
 ) (sqlparser.Expr, error) {
@@ -174,6 +180,32 @@ func (c *pgQueryConverter) convertTextComparisonExpr(
 			formatComparisonExprStringForError(*expr),
 		)
 	}
+	tsQueryExpr, err := c.buildTSQueryExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	var newExpr sqlparser.Expr = &sqlparser.ComparisonExpr{
+		Operator: ftsMatchOp,
+		Left:     expr.Left,
+		Right:    tsQueryExpr,
+	}
+	if expr.Operator == sqlparser.NotEqualStr || expr.Operator == sqlparser.NotStartsWithStr {
+		newExpr = &sqlparser.NotExpr{Expr: newExpr}
+	}
+	return newExpr, nil
+}
+
+func (c *pgQueryConverter) buildTextRelevanceExpr(expr *sqlparser.ComparisonExpr) sqlparser.Expr {
+	tsQueryExpr, err := c.buildTSQueryExpr(expr)
+	if err != nil {
+		return nil
+	}
+	return newFuncExpr(tsRankFuncName, expr.Left, tsQueryExpr)
+}
+
+// buildTSQueryExpr builds the tsquery matching any of the tokens: 'token1 | token2'::tsquery.
+// Prefix search matches the tokens as prefixes: 'token1:* | token2:*'::tsquery.
+func (c *pgQueryConverter) buildTSQueryExpr(expr *sqlparser.ComparisonExpr) (sqlparser.Expr, error) {
 	valueExpr, ok := expr.Right.(*unsafeSQLString)
 	if !ok {
 		return nil, query.NewConverterError(
@@ -190,19 +222,13 @@ func (c *pgQueryConverter) convertTextComparisonExpr(
 			sqlparser.String(expr.Right),
 		)
 	}
-	valueExpr.Val = strings.Join(tokens, " | ")
-	var newExpr sqlparser.Expr = &sqlparser.ComparisonExpr{
-		Operator: ftsMatchOp,
-		Left:     expr.Left,
-		Right: &pgCastExpr{
-			Value: expr.Right,
-			Type:  convertTypeTSQuery,
-		},
-	}
-	if expr.Operator == sqlparser.NotEqualStr {
-		newExpr = &sqlparser.NotExpr{Expr: newExpr}
+	if expr.Operator == sqlparser.StartsWithStr || expr.Operator == sqlparser.NotStartsWithStr {
+		tokens = addSuffix(tsQueryPrefixSuffix, tokens)
 	}
-	return newExpr, nil
+	return &pgCastExpr{
+		Value: newUnsafeSQLString(strings.Join(tokens, " | ")),
+		Type:  convertTypeTSQuery,
+	}, nil
 }
 
 func (c *pgQueryConverter) newJsonContainsExpr(
@@ -219,6 +245,7 @@ func (c *pgQueryConverter) newJsonContainsExpr(
 func (c *pgQueryConverter) buildSelectStmt(
 	namespaceID namespace.ID,
 	queryString string,
+	relevance string,
 	pageSize int,
 	token *pageToken,
 ) (string, []any) {
@@ -235,43 +262,29 @@ func (c *pgQueryConverter) buildSelectStmt(
 		whereClauses = append(whereClauses, queryString)
 	}
 
+	coalesceCloseTimeExpr := sqlparser.String(c.getCoalesceCloseTimeExpr())
 	if token != nil {
-		whereClauses = append(
-			whereClauses,
-			fmt.Sprintf(
-				"((%s = ? AND %s = ? AND %s > ?) OR (%s = ? AND %s < ?) OR %s < ?)",
-				sqlparser.String(c.getCoalesceCloseTimeExpr()),
-				searchattribute.GetSqlDbColName(searchattribute.StartTime),
-				searchattribute.GetSqlDbColName(searchattribute.RunID),
-				sqlparser.String(c.getCoalesceCloseTimeExpr()),
-				searchattribute.GetSqlDbColName(searchattribute.StartTime),
-				sqlparser.String(c.getCoalesceCloseTimeExpr()),
-			),
-		)
-		queryArgs = append(
-			queryArgs,
-			token.CloseTime,
-			token.StartTime,
-			token.RunID,
-			token.CloseTime,
-			token.StartTime,
-			token.CloseTime,
-		)
+		tokenClause, tokenArgs := buildPageTokenWhereClause(coalesceCloseTimeExpr, relevance, token)
+		whereClauses = append(whereClauses, tokenClause)
+		queryArgs = append(queryArgs, tokenArgs...)
 	}
 
 	queryArgs = append(queryArgs, pageSize)
 
+	selectFields := strings.Join(sqlplugin.DbFields, ", ")
+	if relevance != "" {
+		selectFields += fmt.Sprintf(", %s AS %s", relevance, relevanceColName)
+	}
+
 	return fmt.Sprintf(
 		`SELECT %s
 		FROM executions_visibility
 		WHERE %s
-		ORDER BY %s DESC, %s DESC, %s
+		ORDER BY %s
 		LIMIT ?`,
-		strings.Join(sqlplugin.DbFields, ", "),
+		selectFields,
 		strings.Join(whereClauses, " AND "),
-		sqlparser.String(c.getCoalesceCloseTimeExpr()),
-		searchattribute.GetSqlDbColName(searchattribute.StartTime),
-		searchattribute.GetSqlDbColName(searchattribute.RunID),
+		strings.Join(buildSelectOrderBy(coalesceCloseTimeExpr, relevance), ", "),
 	), queryArgs
 }
 
@@ -279,6 +292,7 @@ func (c *pgQueryConverter) buildCountStmt(
 	namespaceID namespace.ID,
 	queryString string,
 	groupBy []string,
+	aggregations []string,
 ) (string, []any) {
 	var whereClauses []string
 	var queryArgs []any
@@ -300,7 +314,7 @@ func (c *pgQueryConverter) buildCountStmt(
 
 	return fmt.Sprintf(
 		"SELECT %s FROM executions_visibility WHERE %s %s",
-		strings.Join(append(groupBy, "COUNT(*)"), ", "),
+		strings.Join(buildCountSelectExprs(groupBy, aggregations), ", "),
 		strings.Join(whereClauses, " AND "),
 		groupByClause,
 	), queryArgs
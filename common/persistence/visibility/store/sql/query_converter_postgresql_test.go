@@ -57,6 +57,27 @@ func (s *postgresqlQueryConverterSuite) TestGetCoalesceCloseTimeExpr() {
 	)
 }
 
+func (s *postgresqlQueryConverterSuite) TestBuildDateTruncExpr() {
+	s.Equal(
+		"date_trunc('hour', start_time)",
+		sqlparser.String(s.queryConverter.buildDateTruncExpr(query.DateTruncUnitHour, newColName("start_time"))),
+	)
+	s.Equal(
+		"date_trunc('week', start_time)",
+		sqlparser.String(s.queryConverter.buildDateTruncExpr(query.DateTruncUnitWeek, newColName("start_time"))),
+	)
+}
+
+func (s *postgresqlQueryConverterSuite) TestBuildTextRelevanceExpr() {
+	s.queryConverter.queryString = "AliasForText01 = 'foo bar' AND AliasForText01 starts_with 'foo'"
+	qp, err := s.queryConverter.convertWhereString(s.queryConverter.queryString)
+	s.NoError(err)
+	s.Equal(
+		`round((ts_rank(Text01, 'foo | bar'::tsquery) + ts_rank(Text01, 'foo:*'::tsquery)) * 1000000)`,
+		sqlparser.String(qp.relevance),
+	)
+}
+
 func (s *postgresqlQueryConverterSuite) TestConvertKeywordListComparisonExpr() {
 	var tests = []testCase{
 		{
@@ -139,6 +160,18 @@ func (s *postgresqlQueryConverterSuite) TestConvertTextComparisonExpr() {
 			output: "not Text01 @@ 'foo | bar'::tsquery",
 			err:    nil,
 		},
+		{
+			name:   "valid starts_with expression",
+			input:  "AliasForText01 starts_with 'foo bar'",
+			output: "Text01 @@ 'foo:* | bar:*'::tsquery",
+			err:    nil,
+		},
+		{
+			name:   "valid not starts_with expression",
+			input:  "AliasForText01 not starts_with 'foo'",
+			output: "not Text01 @@ 'foo:*'::tsquery",
+			err:    nil,
+		},
 	}
 
 	for _, tc := range tests {
@@ -45,6 +45,7 @@ var _ pluginQueryConverter = (*sqliteQueryConverter)(nil)
 const (
 	keywordListTypeFtsTableName = "executions_visibility_fts_keyword_list"
 	textTypeFtsTableName        = "executions_visibility_fts_text"
+	bm25FuncName                = "bm25"
 )
 
 func newSqliteQueryConverter(
@@ -76,6 +77,34 @@ func (c *sqliteQueryConverter) getCoalesceCloseTimeExpr() sqlparser.Expr {
 	)
 }
 
+func (c *sqliteQueryConverter) buildDateTruncExpr(unit query.DateTruncUnit, col sqlparser.Expr) sqlparser.Expr {
+	var format string
+	switch unit {
+	case query.DateTruncUnitSecond:
+		format = "%Y-%m-%d %H:%M:%S"
+	case query.DateTruncUnitMinute:
+		format = "%Y-%m-%d %H:%M:00"
+	case query.DateTruncUnitHour:
+		format = "%Y-%m-%d %H:00:00"
+	case query.DateTruncUnitDay:
+		format = "%Y-%m-%d 00:00:00"
+	case query.DateTruncUnitWeek:
+		// Move to the next Sunday (or stay if it's Sunday already), then back to Monday.
+		return newFuncExpr(
+			"strftime",
+			newUnsafeSQLString("%Y-%m-%d 00:00:00"),
+			col,
+			newUnsafeSQLString("weekday 0"),
+			newUnsafeSQLString("-6 days"),
+		)
+	case query.DateTruncUnitMonth:
+		format = "%Y-%m-01 00:00:00"
+	case query.DateTruncUnitYear:
+		format = "%Y-01-01 00:00:00"
+	}
+	return newFuncExpr("strftime", newUnsafeSQLString(format), col)
+}
+
 func (c *sqliteQueryConverter) convertKeywordListComparisonExpr(
 	expr *sqlparser.ComparisonExpr,
 ) (sqlparser.Expr, error) {
@@ -173,9 +202,74 @@ func (c *sqliteQueryConverter) convertTextComparisonExpr(
 		)
 	}
 
+	var oper string
+	switch expr.Operator {
+	case sqlparser.EqualStr, sqlparser.StartsWithStr:
+		oper = sqlparser.InStr
+	case sqlparser.NotEqualStr, sqlparser.NotStartsWithStr:
+		oper = sqlparser.NotInStr
+	default:
+		// this should never happen since isSupportedTextOperator should already fail
+		return nil, query.NewConverterError(
+			"%s: operator '%s' not supported for Text type search attribute in `%s`",
+			query.InvalidExpressionErrMessage,
+			expr.Operator,
+			formatComparisonExprStringForError(*expr),
+		)
+	}
+
+	ftsQuery, err := c.buildTextFtsQueryString(expr)
+	if err != nil {
+		return nil, err
+	}
+	newExpr := sqlparser.ComparisonExpr{
+		Operator: oper,
+		Left:     newColName("rowid"),
+		Right: &sqlparser.Subquery{
+			Select: c.buildFtsSelectStmt(textTypeFtsTableName, ftsQuery),
+		},
+	}
+	return &newExpr, nil
+}
+
+// buildTextRelevanceExpr builds the following expression:
+//
+//	coalesce((SELECT -bm25(fts) FROM fts WHERE fts = '%s' AND rowid = executions_visibility.rowid), 0)
+//
+// bm25 returns lower values for better matches, so it's negated to order by relevance descending.
+func (c *sqliteQueryConverter) buildTextRelevanceExpr(expr *sqlparser.ComparisonExpr) sqlparser.Expr {
+	ftsQuery, err := c.buildTextFtsQueryString(expr)
+	if err != nil {
+		return nil
+	}
+	ftsSelectStmt := c.buildFtsSelectStmt(textTypeFtsTableName, ftsQuery).(*sqlparser.Select)
+	ftsSelectStmt.SelectExprs = sqlparser.SelectExprs{
+		&sqlparser.AliasedExpr{
+			Expr: &sqlparser.UnaryExpr{
+				Operator: sqlparser.UMinusStr,
+				Expr:     newFuncExpr(bm25FuncName, newColName(textTypeFtsTableName)),
+			},
+		},
+	}
+	ftsSelectStmt.Where.Expr = &sqlparser.AndExpr{
+		Left: ftsSelectStmt.Where.Expr,
+		Right: &sqlparser.ComparisonExpr{
+			Operator: sqlparser.EqualStr,
+			Left:     newColName("rowid"),
+			Right:    newColName("executions_visibility.rowid"),
+		},
+	}
+	return newFuncExpr(
+		coalesceFuncName,
+		&sqlparser.Subquery{Select: ftsSelectStmt},
+		sqlparser.NewIntVal([]byte("0")),
+	)
+}
+
+func (c *sqliteQueryConverter) buildTextFtsQueryString(expr *sqlparser.ComparisonExpr) (string, error) {
 	saColNameExpr, isSAColNameExpr := expr.Left.(*saColName)
 	if !isSAColNameExpr {
-		return nil, query.NewConverterError(
+		return "", query.NewConverterError(
 			"%s: must be a search attribute column name but was %T",
 			query.InvalidExpressionErrMessage,
 			expr.Left,
@@ -184,7 +278,7 @@ func (c *sqliteQueryConverter) convertTextComparisonExpr(
 
 	valueExpr, ok := expr.Right.(*unsafeSQLString)
 	if !ok {
-		return nil, query.NewConverterError(
+		return "", query.NewConverterError(
 			"%s: unexpected value type (expected string, got %s)",
 			query.InvalidExpressionErrMessage,
 			sqlparser.String(expr.Right),
@@ -192,43 +286,23 @@ func (c *sqliteQueryConverter) convertTextComparisonExpr(
 	}
 	tokens := tokenizeTextQueryString(valueExpr.Val)
 	if len(tokens) == 0 {
-		return nil, query.NewConverterError(
+		return "", query.NewConverterError(
 			"%s: unexpected value for Text type search attribute (no tokens found in %s)",
 			query.InvalidExpressionErrMessage,
 			sqlparser.String(expr.Right),
 		)
 	}
 
-	var oper string
-	switch expr.Operator {
-	case sqlparser.EqualStr:
-		oper = sqlparser.InStr
-	case sqlparser.NotEqualStr:
-		oper = sqlparser.NotInStr
-	default:
-		// this should never happen since isSupportedTextOperator should already fail
-		return nil, query.NewConverterError(
-			"%s: operator '%s' not supported for Text type search attribute in `%s`",
-			query.InvalidExpressionErrMessage,
-			expr.Operator,
-			formatComparisonExprStringForError(*expr),
-		)
-	}
-
-	ftsQuery := buildFtsQueryString(saColNameExpr.dbColName.Name, tokens...)
-	newExpr := sqlparser.ComparisonExpr{
-		Operator: oper,
-		Left:     newColName("rowid"),
-		Right: &sqlparser.Subquery{
-			Select: c.buildFtsSelectStmt(textTypeFtsTableName, ftsQuery),
-		},
+	if expr.Operator == sqlparser.StartsWithStr || expr.Operator == sqlparser.NotStartsWithStr {
+		return buildFtsPrefixQueryString(saColNameExpr.dbColName.Name, tokens...), nil
 	}
-	return &newExpr, nil
+	return buildFtsQueryString(saColNameExpr.dbColName.Name, tokens...), nil
 }
 
 func (c *sqliteQueryConverter) buildSelectStmt(
 	namespaceID namespace.ID,
 	queryString string,
+	relevance string,
 	pageSize int,
 	token *pageToken,
 ) (string, []any) {
@@ -245,43 +319,29 @@ func (c *sqliteQueryConverter) buildSelectStmt(
 		whereClauses = append(whereClauses, queryString)
 	}
 
+	coalesceCloseTimeExpr := sqlparser.String(c.getCoalesceCloseTimeExpr())
 	if token != nil {
-		whereClauses = append(
-			whereClauses,
-			fmt.Sprintf(
-				"((%s = ? AND %s = ? AND %s > ?) OR (%s = ? AND %s < ?) OR %s < ?)",
-				sqlparser.String(c.getCoalesceCloseTimeExpr()),
-				searchattribute.GetSqlDbColName(searchattribute.StartTime),
-				searchattribute.GetSqlDbColName(searchattribute.RunID),
-				sqlparser.String(c.getCoalesceCloseTimeExpr()),
-				searchattribute.GetSqlDbColName(searchattribute.StartTime),
-				sqlparser.String(c.getCoalesceCloseTimeExpr()),
-			),
-		)
-		queryArgs = append(
-			queryArgs,
-			token.CloseTime,
-			token.StartTime,
-			token.RunID,
-			token.CloseTime,
-			token.StartTime,
-			token.CloseTime,
-		)
+		tokenClause, tokenArgs := buildPageTokenWhereClause(coalesceCloseTimeExpr, relevance, token)
+		whereClauses = append(whereClauses, tokenClause)
+		queryArgs = append(queryArgs, tokenArgs...)
 	}
 
 	queryArgs = append(queryArgs, pageSize)
 
+	selectFields := strings.Join(sqlplugin.DbFields, ", ")
+	if relevance != "" {
+		selectFields += fmt.Sprintf(", %s AS %s", relevance, relevanceColName)
+	}
+
 	return fmt.Sprintf(
 		`SELECT %s
 		FROM executions_visibility
 		WHERE %s
-		ORDER BY %s DESC, %s DESC, %s
+		ORDER BY %s
 		LIMIT ?`,
-		strings.Join(sqlplugin.DbFields, ", "),
+		selectFields,
 		strings.Join(whereClauses, " AND "),
-		sqlparser.String(c.getCoalesceCloseTimeExpr()),
-		searchattribute.GetSqlDbColName(searchattribute.StartTime),
-		searchattribute.GetSqlDbColName(searchattribute.RunID),
+		strings.Join(buildSelectOrderBy(coalesceCloseTimeExpr, relevance), ", "),
 	), queryArgs
 }
 
@@ -320,6 +380,7 @@ func (c *sqliteQueryConverter) buildCountStmt(
 	namespaceID namespace.ID,
 	queryString string,
 	groupBy []string,
+	aggregations []string,
 ) (string, []any) {
 	var whereClauses []string
 	var queryArgs []any
@@ -341,7 +402,7 @@ func (c *sqliteQueryConverter) buildCountStmt(
 
 	return fmt.Sprintf(
 		"SELECT %s FROM executions_visibility WHERE %s %s",
-		strings.Join(append(groupBy, "COUNT(*)"), ", "),
+		strings.Join(buildCountSelectExprs(groupBy, aggregations), ", "),
 		strings.Join(whereClauses, " AND "),
 		groupByClause,
 	), queryArgs
@@ -351,3 +412,8 @@ func buildFtsQueryString(colname string, values ...string) string {
 	// FTS query format: 'colname : ("token1" OR "token2" OR ...)'
 	return fmt.Sprintf(`%s : ("%s")`, colname, strings.Join(values, `" OR "`))
 }
+
+func buildFtsPrefixQueryString(colname string, values ...string) string {
+	// FTS prefix query format: 'colname : ("token1"* OR "token2"* OR ...)'
+	return fmt.Sprintf(`%s : ("%s"*)`, colname, strings.Join(values, `"* OR "`))
+}
@@ -57,6 +57,31 @@ func (s *sqliteQueryConverterSuite) TestGetCoalesceCloseTimeExpr() {
 	)
 }
 
+func (s *sqliteQueryConverterSuite) TestBuildDateTruncExpr() {
+	s.Equal(
+		"strftime('%Y-%m-%d %H:00:00', start_time)",
+		sqlparser.String(s.queryConverter.buildDateTruncExpr(query.DateTruncUnitHour, newColName("start_time"))),
+	)
+	s.Equal(
+		"strftime('%Y-%m-%d 00:00:00', start_time, 'weekday 0', '-6 days')",
+		sqlparser.String(s.queryConverter.buildDateTruncExpr(query.DateTruncUnitWeek, newColName("start_time"))),
+	)
+	s.Equal(
+		"strftime('%Y-%m-01 00:00:00', start_time)",
+		sqlparser.String(s.queryConverter.buildDateTruncExpr(query.DateTruncUnitMonth, newColName("start_time"))),
+	)
+}
+
+func (s *sqliteQueryConverterSuite) TestBuildTextRelevanceExpr() {
+	s.queryConverter.queryString = "AliasForText01 = 'foo bar' AND AliasForText01 starts_with 'foo'"
+	qp, err := s.queryConverter.convertWhereString(s.queryConverter.queryString)
+	s.NoError(err)
+	s.Equal(
+		`round((coalesce((select -bm25(executions_visibility_fts_text) from executions_visibility_fts_text where executions_visibility_fts_text = 'Text01 : ("foo" OR "bar")' and rowid = executions_visibility.rowid), 0) + coalesce((select -bm25(executions_visibility_fts_text) from executions_visibility_fts_text where executions_visibility_fts_text = 'Text01 : ("foo"*)' and rowid = executions_visibility.rowid), 0)) * 1000000)`,
+		sqlparser.String(qp.relevance),
+	)
+}
+
 func (s *sqliteQueryConverterSuite) TestConvertKeywordListComparisonExpr() {
 	var tests = []testCase{
 		{
@@ -139,6 +164,18 @@ func (s *sqliteQueryConverterSuite) TestConvertTextComparisonExpr() {
 			output: `rowid not in (select rowid from executions_visibility_fts_text where executions_visibility_fts_text = 'Text01 : ("foo" OR "bar")')`,
 			err:    nil,
 		},
+		{
+			name:   "valid starts_with expression",
+			input:  "AliasForText01 starts_with 'foo bar'",
+			output: `rowid in (select rowid from executions_visibility_fts_text where executions_visibility_fts_text = 'Text01 : ("foo"* OR "bar"*)')`,
+			err:    nil,
+		},
+		{
+			name:   "valid not starts_with expression",
+			input:  "AliasForText01 not starts_with 'foo'",
+			output: `rowid not in (select rowid from executions_visibility_fts_text where executions_visibility_fts_text = 'Text01 : ("foo"*)')`,
+			err:    nil,
+		},
 	}
 
 	for _, tc := range tests {
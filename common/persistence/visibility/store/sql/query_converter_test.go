@@ -36,6 +36,7 @@ import (
 
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/sql/sqlplugin"
 	"go.temporal.io/server/common/persistence/visibility/store/query"
 	"go.temporal.io/server/common/searchattribute"
 )
@@ -79,6 +80,12 @@ func (s *queryConverterSuite) SetupTest() {
 // TestConvertWhereString tests convertSelectStmt since convertWhereString is
 // just a wrapper for convertSelectStmt to parse users query string.
 func (s *queryConverterSuite) TestConvertWhereString() {
+	startTimeSaColName := newSAColName(
+		searchattribute.GetSqlDbColName(searchattribute.StartTime),
+		searchattribute.StartTime,
+		searchattribute.StartTime,
+		enumspb.INDEXED_VALUE_TYPE_DATETIME,
+	)
 	var tests = []testCase{
 		{
 			name:   "empty string",
@@ -126,22 +133,171 @@ func (s *queryConverterSuite) TestConvertWhereString() {
 			err: nil,
 		},
 		{
-			name:   "group by two fields not supported",
-			input:  "GROUP BY ExecutionStatus, WorkflowType",
+			name:  "group by two fields",
+			input: "GROUP BY ExecutionStatus, WorkflowType",
+			output: &queryParams{
+				queryString: "TemporalNamespaceDivision is null",
+				groupBy:     []string{searchattribute.ExecutionStatus, searchattribute.WorkflowType},
+			},
+			err: nil,
+		},
+		{
+			name:  "group by custom keyword",
+			input: "GROUP BY AliasForKeyword01",
+			output: &queryParams{
+				queryString: "TemporalNamespaceDivision is null",
+				groupBy:     []string{"Keyword01"},
+			},
+			err: nil,
+		},
+		{
+			name:   "group by non keyword",
+			input:  "GROUP BY AliasForInt01",
 			output: nil,
 			err: query.NewConverterError(
-				"%s: 'group by' clause supports only a single field",
+				"%s: 'group by' clause is only supported for %s search attributes, %s has type %s",
 				query.NotSupportedErrMessage,
+				enumspb.INDEXED_VALUE_TYPE_KEYWORD.String(),
+				"AliasForInt01",
+				enumspb.INDEXED_VALUE_TYPE_INT.String(),
 			),
 		},
 		{
-			name:   "group by non ExecutionStatus",
-			input:  "GROUP BY WorkflowType",
+			name:  "group by with aggregations",
+			input: "SELECT MAX(CloseTime), avg(AliasForInt01) GROUP BY WorkflowType",
+			output: &queryParams{
+				queryString: "TemporalNamespaceDivision is null",
+				groupBy:     []string{searchattribute.WorkflowType},
+				aggregations: []*saAggregation{
+					newSAAggregation(query.AggregationFuncMax, closeTimeSaColName),
+					newSAAggregation(
+						query.AggregationFuncAvg,
+						newSAColName("Int01", "AliasForInt01", "Int01", enumspb.INDEXED_VALUE_TYPE_INT),
+					),
+				},
+			},
+			err: nil,
+		},
+		{
+			name:  "aggregation without group by fields",
+			input: "select MIN(StartTime) where AliasForInt01 = 1",
+			output: &queryParams{
+				queryString: "(Int01 = 1) and TemporalNamespaceDivision is null",
+				aggregations: []*saAggregation{
+					newSAAggregation(
+						query.AggregationFuncMin,
+						newSAColName(
+							searchattribute.GetSqlDbColName(searchattribute.StartTime),
+							searchattribute.StartTime,
+							searchattribute.StartTime,
+							enumspb.INDEXED_VALUE_TYPE_DATETIME,
+						),
+					),
+				},
+			},
+			err: nil,
+		},
+		{
+			name:   "avg over datetime not supported",
+			input:  "SELECT AVG(StartTime)",
 			output: nil,
 			err: query.NewConverterError(
-				"%s: 'group by' clause is only supported for %s search attribute",
+				"%s: function '%s' is not supported for %s, which has type %s",
 				query.NotSupportedErrMessage,
-				searchattribute.ExecutionStatus,
+				query.AggregationFuncAvg,
+				searchattribute.StartTime,
+				enumspb.INDEXED_VALUE_TYPE_DATETIME.String(),
+			),
+		},
+		{
+			name:   "aggregation over keyword not supported",
+			input:  "SELECT MAX(WorkflowType)",
+			output: nil,
+			err: query.NewConverterError(
+				"%s: function '%s' is not supported for %s, which has type %s",
+				query.NotSupportedErrMessage,
+				query.AggregationFuncMax,
+				searchattribute.WorkflowType,
+				enumspb.INDEXED_VALUE_TYPE_KEYWORD.String(),
+			),
+		},
+		{
+			name:   "unknown aggregate function",
+			input:  "SELECT SUM(AliasForInt01)",
+			output: nil,
+			err: query.NewConverterError(
+				"%s: function '%s' in select list, only %s, %s, and %s are supported",
+				query.NotSupportedErrMessage,
+				"SUM",
+				query.AggregationFuncMin,
+				query.AggregationFuncMax,
+				query.AggregationFuncAvg,
+			),
+		},
+		{
+			name:   "aggregation in group by",
+			input:  "GROUP BY WorkflowType, MAX(CloseTime)",
+			output: nil,
+			err: query.NewConverterError(
+				"%s: function '%s' in 'group by' clause, aggregate functions must be in the select list: SELECT %s ... GROUP BY ...",
+				query.NotSupportedErrMessage,
+				"MAX",
+				"MAX(CloseTime)",
+			),
+		},
+		{
+			name:   "select column",
+			input:  "SELECT WorkflowType GROUP BY WorkflowType",
+			output: nil,
+			err: query.NewConverterError(
+				"%s: only aggregate functions can be selected, got '%s'",
+				query.NotSupportedErrMessage,
+				"WorkflowType",
+			),
+		},
+		{
+			name:   "not expression",
+			input:  "NOT AliasForInt01 = 1 AND NOT (AliasForKeyword01 = 'foo' OR AliasForKeyword01 = 'bar')",
+			output: &queryParams{queryString: "(not Int01 = 1 and not (Keyword01 = 'foo' or Keyword01 = 'bar')) and TemporalNamespaceDivision is null"},
+			err:    nil,
+		},
+		{
+			name:  "group by date_trunc",
+			input: "GROUP BY date_trunc('day', StartTime), WorkflowType",
+			output: &queryParams{
+				queryString: "TemporalNamespaceDivision is null",
+				groupBy:     []string{searchattribute.StartTime, searchattribute.WorkflowType},
+				groupByDateTrunc: map[string]*saDateTrunc{
+					searchattribute.StartTime: newSADateTrunc(
+						query.DateTruncUnitDay,
+						startTimeSaColName,
+						s.queryConverter.buildDateTruncExpr(query.DateTruncUnitDay, startTimeSaColName.dbColName),
+					),
+				},
+			},
+			err: nil,
+		},
+		{
+			name:   "group by date_trunc of non datetime",
+			input:  "GROUP BY date_trunc('day', AliasForKeyword01)",
+			output: nil,
+			err: query.NewConverterError(
+				"%s: function '%s' is only supported for %s search attributes, %s has type %s",
+				query.NotSupportedErrMessage,
+				query.DateTruncFuncName,
+				enumspb.INDEXED_VALUE_TYPE_DATETIME.String(),
+				"AliasForKeyword01",
+				enumspb.INDEXED_VALUE_TYPE_KEYWORD.String(),
+			),
+		},
+		{
+			name:   "group by date_trunc of the same field twice",
+			input:  "GROUP BY date_trunc('day', StartTime), date_trunc('hour', StartTime)",
+			output: nil,
+			err: query.NewConverterError(
+				"%s: field %s is bucketed more than once in 'group by' clause",
+				query.InvalidExpressionErrMessage,
+				searchattribute.StartTime,
 			),
 		},
 		{
@@ -180,6 +336,78 @@ func (s *queryConverterSuite) TestConvertWhereString() {
 	}
 }
 
+func (s *queryConverterSuite) TestBuildCountStmt_Aggregations() {
+	qc := newQueryConverterInternal(
+		s.pqc,
+		testNamespaceName,
+		testNamespaceID,
+		searchattribute.TestNameTypeMap,
+		&searchattribute.TestMapper{},
+		"SELECT MAX(CloseTime), AVG(ExecutionDuration) GROUP BY WorkflowType, AliasForKeyword01",
+	)
+	filter, err := qc.BuildCountStmt()
+	s.NoError(err)
+	s.Equal([]string{searchattribute.WorkflowType, "Keyword01"}, filter.GroupBy)
+	s.Equal(
+		[]sqlplugin.VisibilityAggregation{
+			{Func: "max", FieldName: searchattribute.CloseTime},
+			{Func: "avg", FieldName: searchattribute.ExecutionDuration},
+		},
+		filter.Aggregations,
+	)
+	s.Contains(
+		filter.Query,
+		"SELECT workflow_type_name, Keyword01, MAX(close_time), AVG(execution_duration), COUNT(*)",
+	)
+	s.Contains(filter.Query, "GROUP BY workflow_type_name, Keyword01")
+}
+
+func (s *queryConverterSuite) TestBuildSelectStmt_TextRelevance() {
+	qc := newQueryConverterInternal(
+		s.pqc,
+		testNamespaceName,
+		testNamespaceID,
+		searchattribute.TestNameTypeMap,
+		&searchattribute.TestMapper{},
+		"",
+	)
+	filter, err := qc.BuildSelectStmt(10, nil)
+	s.NoError(err)
+	s.NotContains(filter.Query, relevanceColName)
+
+	qc.queryString = "AliasForText01 = 'foo bar' AND AliasForText01 starts_with 'baz'"
+	filter, err = qc.BuildSelectStmt(10, nil)
+	s.NoError(err)
+	s.Len(qc.textRelevanceExprs, 2)
+	relevance := sqlparser.String(buildRelevanceExpr(qc.textRelevanceExprs))
+	s.Contains(filter.Query, fmt.Sprintf("%s AS relevance", relevance))
+	s.Contains(filter.Query, "ORDER BY relevance DESC, ")
+	s.Equal([]any{testNamespaceID.String(), 10}, filter.QueryArgs)
+
+	relevanceScore := float64(1500000)
+	token, err := serializePageToken(&pageToken{
+		CloseTime: maxDatetimeValue,
+		StartTime: maxDatetimeValue,
+		RunID:     "run-id",
+		Relevance: &relevanceScore,
+	})
+	s.NoError(err)
+	qc.textRelevanceExprs = nil
+	filter, err = qc.BuildSelectStmt(10, token)
+	s.NoError(err)
+	s.Contains(filter.Query, fmt.Sprintf("(%s < ? OR (%s = ? AND ((", relevance, relevance))
+	s.Equal(relevanceScore, filter.QueryArgs[1])
+	s.Equal(relevanceScore, filter.QueryArgs[2])
+	s.Len(filter.QueryArgs, 10)
+
+	// Negated text search doesn't change the order.
+	qc.queryString = "AliasForText01 != 'foo bar'"
+	qc.textRelevanceExprs = nil
+	filter, err = qc.BuildSelectStmt(10, nil)
+	s.NoError(err)
+	s.NotContains(filter.Query, relevanceColName)
+}
+
 func (s *queryConverterSuite) TestConvertAndExpr() {
 	var tests = []testCase{
 		{
@@ -273,6 +501,8 @@ func (s *queryConverterSuite) TestConvertOrExpr() {
 }
 
 func (s *queryConverterSuite) TestConvertComparisonExpr() {
+	hour10 := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC).Format(s.queryConverter.getDatetimeFormat())
+	hour11 := time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC).Format(s.queryConverter.getDatetimeFormat())
 	var tests = []testCase{
 		{
 			name:   "invalid",
@@ -360,6 +590,111 @@ func (s *queryConverterSuite) TestConvertComparisonExpr() {
 				sqlparser.NotStartsWithStr,
 			),
 		},
+		{
+			name:   "lower equal expression",
+			input:  "lower(AliasForKeyword01) = 'Foo'",
+			output: "lower(Keyword01) = 'foo'",
+			err:    nil,
+		},
+		{
+			name:   "lower in expression",
+			input:  "LOWER(AliasForKeyword01) in ('Foo', 'BAR')",
+			output: "lower(Keyword01) in ('foo', 'bar')",
+			err:    nil,
+		},
+		{
+			name:   "lower starts_with expression",
+			input:  "lower(AliasForKeyword01) starts_with 'Foo_'",
+			output: `lower(Keyword01) like 'foo!_%' escape '!'`,
+			err:    nil,
+		},
+		{
+			name:   "lower of non keyword",
+			input:  "lower(AliasForText01) = 'foo'",
+			output: "",
+			err: query.NewConverterError(
+				"%s: function '%s' is only supported for %s search attributes, %s has type %s",
+				query.NotSupportedErrMessage,
+				query.LowerFuncName,
+				enumspb.INDEXED_VALUE_TYPE_KEYWORD.String(),
+				"AliasForText01",
+				enumspb.INDEXED_VALUE_TYPE_TEXT.String(),
+			),
+		},
+		{
+			name:   "lower with invalid operator",
+			input:  "lower(AliasForKeyword01) > 'foo'",
+			output: "",
+			err: query.NewConverterError(
+				"%s: operator '%s' with function '%s'",
+				query.NotSupportedErrMessage,
+				sqlparser.GreaterThanStr,
+				query.LowerFuncName,
+			),
+		},
+		{
+			name:   "unknown function",
+			input:  "upper(AliasForKeyword01) = 'FOO'",
+			output: "",
+			err: query.NewConverterError(
+				"%s: function '%s', only '%s' and '%s' are supported on the left side of comparison expression",
+				query.NotSupportedErrMessage,
+				"upper",
+				query.LowerFuncName,
+				query.DateTruncFuncName,
+			),
+		},
+		{
+			name:   "date_trunc equal expression",
+			input:  "date_trunc('hour', StartTime) = '2023-01-01T10:00:00Z'",
+			output: fmt.Sprintf("(start_time >= '%s' and start_time < '%s')", hour10, hour11),
+			err:    nil,
+		},
+		{
+			name:   "date_trunc not equal expression",
+			input:  "date_trunc('hour', StartTime) != '2023-01-01T10:00:00Z'",
+			output: fmt.Sprintf("(not (start_time >= '%s' and start_time < '%s'))", hour10, hour11),
+			err:    nil,
+		},
+		{
+			name:   "date_trunc less than or equal expression",
+			input:  "date_trunc('hour', StartTime) <= '2023-01-01T10:30:00Z'",
+			output: fmt.Sprintf("(start_time < '%s')", hour11),
+			err:    nil,
+		},
+		{
+			name:   "date_trunc equal expression with value not at start of unit",
+			input:  "date_trunc('hour', StartTime) = '2023-01-01T10:30:00Z'",
+			output: "1 = 0",
+			err:    nil,
+		},
+		{
+			name:   "date_trunc not equal expression with value not at start of unit",
+			input:  "date_trunc('hour', StartTime) != '2023-01-01T10:30:00Z'",
+			output: "(start_time is not null)",
+			err:    nil,
+		},
+		{
+			name:   "date_trunc with invalid unit",
+			input:  "date_trunc('fortnight', StartTime) = '2023-01-01T10:00:00Z'",
+			output: "",
+			err: query.NewConverterError(
+				"%s: unknown time unit '%s', must be one of: second, minute, hour, day, week, month, year",
+				query.InvalidExpressionErrMessage,
+				"fortnight",
+			),
+		},
+		{
+			name:   "date_trunc with in operator",
+			input:  "date_trunc('hour', StartTime) in ('2023-01-01T10:00:00Z')",
+			output: "",
+			err: query.NewConverterError(
+				"%s: operator '%s' with function '%s'",
+				query.NotSupportedErrMessage,
+				sqlparser.InStr,
+				query.DateTruncFuncName,
+			),
+		},
 		{
 			name:   "like expression",
 			input:  "AliasForKeyword01 like 'foo%'",
@@ -748,6 +1083,38 @@ func (s *queryConverterSuite) TestConvertValueExpr() {
 	}
 }
 
+func (s *queryConverterSuite) TestConvertValueExpr_RelativeTime() {
+	sql := "select * from table1 where StartTime > now() - interval 1 hour"
+	stmt, err := sqlparser.Parse(sql)
+	s.NoError(err)
+	expr := stmt.(*sqlparser.Select).Where.Expr.(*sqlparser.ComparisonExpr).Right
+
+	before := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+	err = s.queryConverter.convertValueExpr(&expr, searchattribute.StartTime, enumspb.INDEXED_VALUE_TYPE_DATETIME)
+	after := time.Now().UTC().Add(-time.Hour)
+	s.NoError(err)
+	value, ok := expr.(*unsafeSQLString)
+	s.True(ok)
+	tm, err := time.Parse(s.queryConverter.getDatetimeFormat(), value.Val)
+	s.NoError(err)
+	s.False(tm.Before(before), tm)
+	s.False(tm.After(after), tm)
+
+	expr = stmt.(*sqlparser.Select).Where.Expr.(*sqlparser.ComparisonExpr).Right
+	err = s.queryConverter.convertValueExpr(&expr, "AliasForKeyword01", enumspb.INDEXED_VALUE_TYPE_KEYWORD)
+	s.Error(err)
+	s.Equal(
+		query.NewConverterError(
+			"%s: relative time can only be compared to %s search attributes, %s has type %s",
+			query.InvalidExpressionErrMessage,
+			enumspb.INDEXED_VALUE_TYPE_DATETIME.String(),
+			"AliasForKeyword01",
+			enumspb.INDEXED_VALUE_TYPE_KEYWORD.String(),
+		),
+		err,
+	)
+}
+
 func (s *queryConverterSuite) TestParseSQLVal() {
 	dt, _ := time.Parse(time.RFC3339Nano, "2020-02-15T20:30:40.123456789Z")
 	var tests = []testCase{
@@ -902,6 +1269,8 @@ func TestSupportedTextOperators(t *testing.T) {
 		"MySQL, PostgreSQL and SQLite, and check their respective plugin converters."
 	s.True(isSupportedTextOperator(sqlparser.EqualStr), msg)
 	s.True(isSupportedTextOperator(sqlparser.NotEqualStr), msg)
+	s.True(isSupportedTextOperator(sqlparser.StartsWithStr), msg)
+	s.True(isSupportedTextOperator(sqlparser.NotStartsWithStr), msg)
 	s.False(isSupportedTextOperator(sqlparser.LessThanStr), msg)
 	s.False(isSupportedTextOperator(sqlparser.GreaterThanStr), msg)
 	s.False(isSupportedTextOperator(sqlparser.LessEqualStr), msg)
@@ -25,6 +25,7 @@
 package sql
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -55,15 +56,40 @@ type (
 		fieldName string
 		valueType enumspb.IndexedValueType
 	}
+
+	// saAggregation is an aggregate function over search attribute column.
+	saAggregation struct {
+		sqlparser.Expr
+		funcName query.AggregationFunc
+		col      *saColName
+	}
+
+	// saDateTrunc is a search attribute column truncated to the start of time unit.
+	// It is used to group by time buckets.
+	saDateTrunc struct {
+		sqlparser.Expr
+		unit query.DateTruncUnit
+		col  *saColName
+		// expr is DB specific expression which truncates the column.
+		expr sqlparser.Expr
+	}
 )
 
 const (
 	coalesceFuncName = "coalesce"
+	roundFuncName    = "round"
+	relevanceColName = "relevance"
+
+	// relevanceScale is the precision the relevance score is rounded to. The page token compares
+	// the score with equality, so it's rounded to an integer which every database compares exactly.
+	relevanceScale = "1000000"
 )
 
 var _ sqlparser.Expr = (*unsafeSQLString)(nil)
 var _ sqlparser.Expr = (*colName)(nil)
 var _ sqlparser.Expr = (*saColName)(nil)
+var _ sqlparser.Expr = (*saAggregation)(nil)
+var _ sqlparser.Expr = (*saDateTrunc)(nil)
 
 var (
 	maxDatetimeValue = getMaxDatetimeValue()
@@ -88,6 +114,16 @@ func (node *saColName) Format(buf *sqlparser.TrackedBuffer) {
 	buf.Myprintf("%v", node.dbColName)
 }
 
+func (node *saAggregation) Format(buf *sqlparser.TrackedBuffer) {
+	// Use db column directly instead of search attribute expression (eg: coalesce for CloseTime)
+	// since aggregate functions ignore null values.
+	buf.Myprintf("%s(%v)", strings.ToUpper(string(node.funcName)), node.col.dbColName)
+}
+
+func (node *saDateTrunc) Format(buf *sqlparser.TrackedBuffer) {
+	buf.Myprintf("%v", node.expr)
+}
+
 func newUnsafeSQLString(val string) *unsafeSQLString {
 	return &unsafeSQLString{Val: val}
 }
@@ -110,6 +146,21 @@ func newSAColName(
 	}
 }
 
+func newSAAggregation(funcName query.AggregationFunc, col *saColName) *saAggregation {
+	return &saAggregation{
+		funcName: funcName,
+		col:      col,
+	}
+}
+
+func newSADateTrunc(unit query.DateTruncUnit, col *saColName, expr sqlparser.Expr) *saDateTrunc {
+	return &saDateTrunc{
+		unit: unit,
+		col:  col,
+		expr: expr,
+	}
+}
+
 func newFuncExpr(name string, exprs ...sqlparser.Expr) *sqlparser.FuncExpr {
 	args := make([]sqlparser.SelectExpr, len(exprs))
 	for i := range exprs {
@@ -121,6 +172,15 @@ func newFuncExpr(name string, exprs ...sqlparser.Expr) *sqlparser.FuncExpr {
 	}
 }
 
+// buildCountSelectExprs returns select expressions of count statement: group by fields,
+// then aggregate functions, and COUNT(*) as the last column.
+func buildCountSelectExprs(groupBy []string, aggregations []string) []string {
+	exprs := make([]string, 0, len(groupBy)+len(aggregations)+1)
+	exprs = append(exprs, groupBy...)
+	exprs = append(exprs, aggregations...)
+	return append(exprs, "COUNT(*)")
+}
+
 func addPrefix(prefix string, fields []string) []string {
 	out := make([]string, len(fields))
 	for i, field := range fields {
@@ -129,6 +189,14 @@ func addPrefix(prefix string, fields []string) []string {
 	return out
 }
 
+func addSuffix(suffix string, fields []string) []string {
+	out := make([]string, len(fields))
+	for i, field := range fields {
+		out[i] = field + suffix
+	}
+	return out
+}
+
 func getMaxDatetimeValue() time.Time {
 	t, _ := time.Parse(time.RFC3339, "9999-12-31T23:59:59Z")
 	return t
@@ -144,17 +212,88 @@ func formatComparisonExprStringForError(expr sqlparser.ComparisonExpr) string {
 	return sqlparser.String(&expr)
 }
 
-// Simple tokenizer by spaces. It's a temporary solution as it doesn't cover tokenizer used by
-// PostgreSQL or SQLite.
+// Simple tokenizer by white spaces. Every plugin matches any of the tokens (OR semantics), so the
+// same query string returns the same workflows regardless of the database full-text features.
 func tokenizeTextQueryString(s string) []string {
-	tokens := strings.Split(s, " ")
-	nonEmptyTokens := make([]string, 0, len(tokens))
-	for _, token := range tokens {
-		if token != "" {
-			nonEmptyTokens = append(nonEmptyTokens, token)
+	return strings.Fields(s)
+}
+
+// buildRelevanceExpr returns the relevance score of the full-text search, the sum of the
+// relevance expressions rounded with relevanceScale, or nil if there are none.
+func buildRelevanceExpr(exprs []sqlparser.Expr) sqlparser.Expr {
+	sum := sumExprs(exprs)
+	if sum == nil {
+		return nil
+	}
+	return newFuncExpr(
+		roundFuncName,
+		&sqlparser.BinaryExpr{
+			Operator: sqlparser.MultStr,
+			Left:     &sqlparser.ParenExpr{Expr: sum},
+			Right:    sqlparser.NewIntVal([]byte(relevanceScale)),
+		},
+	)
+}
+
+// sumExprs returns the sum of the expressions, or nil if there are none.
+func sumExprs(exprs []sqlparser.Expr) sqlparser.Expr {
+	if len(exprs) == 0 {
+		return nil
+	}
+	sum := exprs[0]
+	for _, expr := range exprs[1:] {
+		sum = &sqlparser.BinaryExpr{
+			Operator: sqlparser.PlusStr,
+			Left:     sum,
+			Right:    expr,
 		}
 	}
-	return nonEmptyTokens
+	return sum
+}
+
+// buildSelectOrderBy returns the order by expressions of list queries. Results are ordered by
+// relevance first if the query does full-text search.
+func buildSelectOrderBy(coalesceCloseTimeExpr string, relevance string) []string {
+	orderBy := []string{
+		coalesceCloseTimeExpr + " DESC",
+		searchattribute.GetSqlDbColName(searchattribute.StartTime) + " DESC",
+		searchattribute.GetSqlDbColName(searchattribute.RunID),
+	}
+	if relevance != "" {
+		orderBy = append([]string{relevanceColName + " DESC"}, orderBy...)
+	}
+	return orderBy
+}
+
+// buildPageTokenWhereClause returns the condition to select the rows after the page token,
+// following the order of buildSelectOrderBy.
+func buildPageTokenWhereClause(
+	coalesceCloseTimeExpr string,
+	relevance string,
+	token *pageToken,
+) (string, []any) {
+	clause := fmt.Sprintf(
+		"((%s = ? AND %s = ? AND %s > ?) OR (%s = ? AND %s < ?) OR %s < ?)",
+		coalesceCloseTimeExpr,
+		searchattribute.GetSqlDbColName(searchattribute.StartTime),
+		searchattribute.GetSqlDbColName(searchattribute.RunID),
+		coalesceCloseTimeExpr,
+		searchattribute.GetSqlDbColName(searchattribute.StartTime),
+		coalesceCloseTimeExpr,
+	)
+	args := []any{
+		token.CloseTime,
+		token.StartTime,
+		token.RunID,
+		token.CloseTime,
+		token.StartTime,
+		token.CloseTime,
+	}
+	if relevance == "" || token.Relevance == nil {
+		return clause, args
+	}
+	clause = fmt.Sprintf("(%s < ? OR (%s = ? AND %s))", relevance, relevance, clause)
+	return clause, append([]any{*token.Relevance, *token.Relevance}, args...)
 }
 
 func getUnsafeStringTupleValues(valTuple sqlparser.ValTuple) ([]string, error) {
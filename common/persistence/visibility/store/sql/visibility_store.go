@@ -26,15 +26,17 @@ package sql
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
-	"go.temporal.io/api/workflowservice/v1"
 
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/log"
@@ -59,6 +61,15 @@ type (
 
 var _ store.VisibilityStore = (*VisibilityStore)(nil)
 
+var (
+	// aggregationDatetimeLayouts are layouts of datetime values returned as string by DB drivers.
+	aggregationDatetimeLayouts = []string{
+		"2006-01-02 15:04:05.999999999-07:00",
+		"2006-01-02 15:04:05.999999999",
+		time.RFC3339Nano,
+	}
+)
+
 var maxTime, _ = time.Parse(time.RFC3339, "9999-12-31T23:59:59Z")
 
 // NewSQLVisibilityStore creates an instance of VisibilityStore
@@ -388,6 +399,7 @@ func (s *VisibilityStore) ListWorkflowExecutions(
 			CloseTime: closeTime,
 			StartTime: lastRow.StartTime,
 			RunID:     lastRow.RunID,
+			Relevance: lastRow.Relevance,
 		})
 		if err != nil {
 			return nil, err
@@ -438,7 +450,7 @@ func (s *VisibilityStore) CountWorkflowExecutions(
 		return nil, err
 	}
 
-	if len(selectFilter.GroupBy) > 0 {
+	if len(selectFilter.GroupBy) > 0 || len(selectFilter.Aggregations) > 0 {
 		return s.countGroupByWorkflowExecutions(ctx, selectFilter, saTypeMap)
 	}
 
@@ -464,6 +476,14 @@ func (s *VisibilityStore) countGroupByWorkflowExecutions(
 			return nil, err
 		}
 	}
+	aggValueTypes := make([]enumspb.IndexedValueType, len(selectFilter.Aggregations))
+	for i, aggregation := range selectFilter.Aggregations {
+		fieldType, err := saTypeMap.GetType(aggregation.FieldName)
+		if err != nil {
+			return nil, err
+		}
+		aggValueTypes[i] = query.AggregationValueType(query.AggregationFunc(aggregation.Func), fieldType)
+	}
 
 	rows, err := s.sqlStore.Db.CountGroupByFromVisibility(ctx, *selectFilter)
 	if err != nil {
@@ -472,21 +492,47 @@ func (s *VisibilityStore) countGroupByWorkflowExecutions(
 	}
 	resp := &manager.CountWorkflowExecutionsResponse{
 		Count:  0,
-		Groups: make([]*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup, 0, len(rows)),
+		Groups: make([]*manager.AggregationGroup, 0, len(rows)),
 	}
 	for _, row := range rows {
-		groupValues := make([]*common.Payload, len(row.GroupValues))
+		groupValues := make([]*common.Payload, 0, len(row.GroupValues))
 		for i, val := range row.GroupValues {
-			groupValues[i], err = searchattribute.EncodeValue(val, groupByTypes[i])
+			if groupByTypes[i] == enumspb.INDEXED_VALUE_TYPE_DATETIME {
+				// Datetime fields are grouped by date_trunc buckets.
+				val, err = parseAggregationValue(val, groupByTypes[i])
+				if err != nil {
+					return nil, serviceerror.NewInternal(
+						fmt.Sprintf("Unable to parse %s value from DB: %v", selectFilter.GroupBy[i], err),
+					)
+				}
+			}
+			payload, err := searchattribute.EncodeValue(val, groupByTypes[i])
+			if err != nil {
+				return nil, err
+			}
+			groupValues = append(groupValues, payload)
+		}
+		var aggregationValues []*common.Payload
+		for i, val := range row.AggregationValues {
+			aggValue, err := parseAggregationValue(val, aggValueTypes[i])
+			if err != nil {
+				return nil, serviceerror.NewInternal(
+					fmt.Sprintf("Unable to parse %s(%s) value from DB: %v",
+						selectFilter.Aggregations[i].Func, selectFilter.Aggregations[i].FieldName, err),
+				)
+			}
+			payload, err := searchattribute.EncodeValue(aggValue, aggValueTypes[i])
 			if err != nil {
 				return nil, err
 			}
+			aggregationValues = append(aggregationValues, payload)
 		}
 		resp.Groups = append(
 			resp.Groups,
-			&workflowservice.CountWorkflowExecutionsResponse_AggregationGroup{
-				GroupValues: groupValues,
-				Count:       row.Count,
+			&manager.AggregationGroup{
+				GroupValues:       groupValues,
+				AggregationValues: aggregationValues,
+				Count:             row.Count,
 			},
 		)
 		resp.Count += row.Count
@@ -503,6 +549,10 @@ func (s *VisibilityStore) GetWorkflowExecution(
 		RunID:       request.RunID,
 	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, serviceerror.NewNotFound(
+				fmt.Sprintf("Workflow execution with run id %s not found.", request.RunID))
+		}
 		return nil, serviceerror.NewUnavailable(
 			fmt.Sprintf("GetWorkflowExecution operation failed. Select failed: %v", err))
 	}
@@ -753,3 +803,50 @@ func (s *VisibilityStore) buildQueryStringFromListRequest(
 
 	return strings.Join(queryTerms, " AND ")
 }
+
+// parseAggregationValue converts aggregate function or date_trunc bucket value returned by DB driver
+// to search attribute value.
+// Drivers return different types depending on DB and column type, eg: MySQL returns AVG as DECIMAL bytes,
+// and SQLite returns MIN and MAX of datetime as string.
+func parseAggregationValue(value any, t enumspb.IndexedValueType) (any, error) {
+	if bytesValue, isBytes := value.([]byte); isBytes {
+		value = string(bytesValue)
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	switch t {
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		switch v := value.(type) {
+		case time.Time:
+			return v.UTC(), nil
+		case string:
+			for _, layout := range aggregationDatetimeLayouts {
+				if timeValue, err := time.Parse(layout, v); err == nil {
+					return timeValue.UTC(), nil
+				}
+			}
+			return nil, fmt.Errorf("unable to parse datetime %q", v)
+		}
+	case enumspb.INDEXED_VALUE_TYPE_INT:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			return int64(math.Round(v)), nil
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int64:
+			return float64(v), nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	}
+	return nil, fmt.Errorf("unexpected value %v of type %T for %s", value, value, t.String())
+}
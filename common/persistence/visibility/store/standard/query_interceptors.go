@@ -72,8 +72,11 @@ func newValuesInterceptor(
 }
 
 func (ni *nameInterceptor) Name(name string, usage query.FieldNameUsage) (string, error) {
-	if usage == query.FieldNameSorter {
+	switch usage {
+	case query.FieldNameSorter:
 		return "", query.NewConverterError("order by not allowed for standard visibility")
+	case query.FieldNameLowerFuncArg, query.FieldNameDateTruncFuncArg:
+		return "", query.NewConverterError("functions not allowed for standard visibility")
 	}
 
 	for _, filter := range allowedFilters {
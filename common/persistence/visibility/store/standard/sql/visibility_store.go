@@ -26,6 +26,7 @@ package sql
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -344,6 +345,10 @@ func (s *visibilityStore) GetWorkflowExecution(
 		RunID:       request.RunID,
 	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, serviceerror.NewNotFound(
+				fmt.Sprintf("Workflow execution with run id %s not found.", request.RunID))
+		}
 		return nil, serviceerror.NewUnavailable(
 			fmt.Sprintf("GetWorkflowExecution operation failed. Select failed: %v", err))
 	}
@@ -105,6 +105,7 @@ type (
 	// InternalVisibilityRequestBase is a base request to visibility APIs.
 	InternalVisibilityRequestBase struct {
 		NamespaceID      string
+		Namespace        string
 		WorkflowID       string
 		RunID            string
 		WorkflowTypeName string
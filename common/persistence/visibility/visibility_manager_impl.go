@@ -306,6 +306,7 @@ func (p *visibilityManagerImpl) newInternalVisibilityRequestBase(
 
 	return &store.InternalVisibilityRequestBase{
 		NamespaceID:      request.NamespaceID.String(),
+		Namespace:        request.Namespace.String(),
 		WorkflowID:       request.Execution.GetWorkflowId(),
 		RunID:            request.Execution.GetRunId(),
 		WorkflowTypeName: request.WorkflowTypeName,
@@ -33,4 +33,5 @@ const (
 	AddSearchAttributesActivityTQ = "temporal-sys-add-search-attributes-activity-tq"
 	DeleteNamespaceActivityTQ     = "temporal-sys-delete-namespace-activity-tq"
 	DLQActivityTQ                 = "temporal-sys-dlq-activity-tq"
+	VisibilityBackfillActivityTQ  = "temporal-sys-visibility-backfill-activity-tq"
 )
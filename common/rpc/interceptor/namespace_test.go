@@ -75,6 +75,7 @@ var (
 		"AddTasks":                  {},
 		"ListQueues":                {},
 		"ListTasks":                 {},
+		"StreamVisibilityChanges":   {},
 	}
 )
 
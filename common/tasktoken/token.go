@@ -34,6 +34,8 @@ func NewWorkflowTaskToken(
 	namespaceID string,
 	workflowID string,
 	runID string,
+	workflowType string,
+	taskQueue string,
 	scheduledEventID int64,
 	startedEventId int64,
 	startedTime *timestamppb.Timestamp,
@@ -45,6 +47,8 @@ func NewWorkflowTaskToken(
 		NamespaceId:      namespaceID,
 		WorkflowId:       workflowID,
 		RunId:            runID,
+		WorkflowType:     workflowType,
+		TaskQueue:        taskQueue,
 		ScheduledEventId: scheduledEventID,
 		StartedEventId:   startedEventId,
 		StartedTime:      startedTime,
@@ -58,6 +62,8 @@ func NewActivityTaskToken(
 	namespaceID string,
 	workflowID string,
 	runID string,
+	workflowType string,
+	taskQueue string,
 	scheduledEventID int64,
 	activityId string,
 	activityType string,
@@ -69,6 +75,8 @@ func NewActivityTaskToken(
 		NamespaceId:      namespaceID,
 		WorkflowId:       workflowID,
 		RunId:            runID,
+		WorkflowType:     workflowType,
+		TaskQueue:        taskQueue,
 		ScheduledEventId: scheduledEventID,
 		ActivityType:     activityType,
 		Attempt:          attempt,
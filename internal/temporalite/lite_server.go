@@ -53,7 +53,10 @@ import (
 	"go.temporal.io/server/temporal"
 )
 
-const localBroadcastAddress = "127.0.0.1"
+const (
+	localBroadcastAddress = "127.0.0.1"
+	embeddedStoreName     = "embedded"
+)
 
 // LiteServerConfig encodes options for LiteServer instances.
 type LiteServerConfig struct {
@@ -77,6 +80,10 @@ type LiteServerConfig struct {
 	MetricsPort int
 	// Namespaces specified here will be automatically registered on Temporal start.
 	Namespaces []string
+	// EmbeddedVisibility uses the embedded in-process visibility store instead of SQLite for
+	// visibility records. Unless Ephemeral is set, the visibility index is persisted to a file next
+	// to the database file.
+	EmbeddedVisibility bool
 	// SQLitePragmas specified here will be applied as pragma statements to SQLite on Temporal start.
 	SQLitePragmas map[string]string
 	// Logger overrides the default logger.
@@ -141,6 +148,16 @@ func (cfg *LiteServerConfig) apply(serverConfig *config.Config, provider *PortPr
 			sqliteplugin.PluginName: {SQL: &sqliteConfig},
 		},
 	}
+	if cfg.EmbeddedVisibility {
+		embeddedConfig := config.EmbeddedVisibility{
+			IndexName: "temporalite_" + filepath.Base(sqliteConfig.DatabaseName),
+		}
+		if !cfg.Ephemeral {
+			embeddedConfig.SnapshotPath = cfg.DatabaseFilePath + ".visibility"
+		}
+		serverConfig.Persistence.VisibilityStore = embeddedStoreName
+		serverConfig.Persistence.DataStores[embeddedStoreName] = config.DataStore{Embedded: &embeddedConfig}
+	}
 	serverConfig.ClusterMetadata = &cluster.Config{
 		EnableGlobalNamespace:    false,
 		FailoverVersionIncrement: 10,
@@ -274,7 +291,7 @@ func NewLiteServer(liteConfig *LiteServerConfig, opts ...temporal.ServerOption)
 		return nil, fmt.Errorf("error creating namespaces: %w", err)
 	}
 
-	authorizer, err := authorization.GetAuthorizerFromConfig(&liteConfig.BaseConfig.Global.Authorization)
+	authorizer, err := authorization.NewAuthorizerFromConfig(&liteConfig.BaseConfig.Global.Authorization, liteConfig.Logger, nil)
 	if err != nil {
 		return nil, fmt.Errorf("unable to instantiate authorizer: %w", err)
 	}
@@ -308,6 +325,9 @@ func NewLiteServer(liteConfig *LiteServerConfig, opts ...temporal.ServerOption)
 
 	srv, err := temporal.NewServer(serverOpts...)
 	if err != nil {
+		if stopper, ok := authorizer.(interface{ Stop() }); ok {
+			stopper.Stop()
+		}
 		return nil, fmt.Errorf("unable to instantiate server: %w", err)
 	}
 
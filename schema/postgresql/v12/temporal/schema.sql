@@ -337,4 +337,19 @@ CREATE UNIQUE INDEX cm_idx_rolehost ON cluster_membership (role, host_id);
 CREATE INDEX cm_idx_rolelasthb ON cluster_membership (role, last_heartbeat);
 CREATE INDEX cm_idx_rpchost ON cluster_membership (rpc_address, role);
 CREATE INDEX cm_idx_lasthb ON cluster_membership (last_heartbeat);
-CREATE INDEX cm_idx_recordexpiry ON cluster_membership (record_expiry);
\ No newline at end of file
+CREATE INDEX cm_idx_recordexpiry ON cluster_membership (record_expiry);
+-- Stores information about Nexus incoming services
+CREATE TABLE nexus_incoming_services (
+    service_name    VARCHAR(255) NOT NULL,
+    data            BYTEA NOT NULL,  -- temporal.server.api.persistence.v1.NexusIncomingService
+    data_encoding   VARCHAR(16) NOT NULL, -- Encoding type used for serialization, in practice this should always be proto3
+    version         BIGINT NOT NULL,      -- Version of this row, used for optimistic concurrency
+    PRIMARY KEY (service_name)
+);
+
+-- Stores the version of Nexus incoming services table as a whole
+CREATE TABLE nexus_incoming_services_partition_status (
+    id      INT NOT NULL DEFAULT 0 CHECK (id = 0),  -- Restrict the table to a single row since it will only be used for incoming services
+    version BIGINT NOT NULL,                        -- Version of the nexus_incoming_services table
+    PRIMARY KEY (id)
+);
@@ -26,6 +26,7 @@ package frontend
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -70,6 +71,7 @@ import (
 	"go.temporal.io/server/client/admin"
 	"go.temporal.io/server/client/frontend"
 	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver/visibilityquery"
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/config"
 	"go.temporal.io/server/common/convert"
@@ -85,6 +87,7 @@ import (
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch"
 	esclient "go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
+	"go.temporal.io/server/common/persistence/visibility/store/embedded"
 	"go.temporal.io/server/common/sdk"
 	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/common/xdc"
@@ -105,6 +108,9 @@ type (
 
 		status int32
 
+		// visibilityWatchCount is the number of running WatchWorkflowExecutions streams.
+		visibilityWatchCount atomic.Int32
+
 		logger                     log.Logger
 		numberOfHistoryShards      int32
 		ESClient                   esclient.Client
@@ -126,8 +132,10 @@ type (
 		namespaceRegistry          namespace.Registry
 		saProvider                 searchattribute.Provider
 		saManager                  searchattribute.Manager
+		saMapperProvider           searchattribute.MapperProvider
 		clusterMetadata            cluster.Metadata
 		healthServer               *health.Server
+		dynamicConfig              *dynamicconfig.Collection
 
 		// DEPRECATED: only history service on server side is supposed to
 		// use the following components.
@@ -156,10 +164,12 @@ type (
 		NamespaceRegistry                   namespace.Registry
 		SaProvider                          searchattribute.Provider
 		SaManager                           searchattribute.Manager
+		SaMapperProvider                    searchattribute.MapperProvider
 		ClusterMetadata                     cluster.Metadata
 		HealthServer                        *health.Server
 		EventSerializer                     serialization.Serializer
 		TimeSource                          clock.TimeSource
+		DynamicConfig                       *dynamicconfig.Collection
 
 		// DEPRECATED: only history service on server side is supposed to
 		// use the following components.
@@ -212,8 +222,10 @@ func NewAdminHandler(
 		namespaceRegistry:           args.NamespaceRegistry,
 		saProvider:                  args.SaProvider,
 		saManager:                   args.SaManager,
+		saMapperProvider:            args.SaMapperProvider,
 		clusterMetadata:             args.ClusterMetadata,
 		healthServer:                args.HealthServer,
+		dynamicConfig:               args.DynamicConfig,
 		taskCategoryRegistry:        args.CategoryRegistry,
 	}
 }
@@ -290,7 +302,9 @@ func (adh *AdminHandler) AddSearchAttributes(
 	// register the search attributes in the cluster metadata if ES is up or if
 	// `skip-schema-update` is set. This is for backward compatibility using
 	// standard visibility.
-	if adh.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) || indexName == "" {
+	if adh.visibilityMgr.HasStoreName(embedded.PersistenceName) {
+		err = adh.addSearchAttributesEmbedded(ctx, request, indexName, currentSearchAttributes)
+	} else if adh.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) || indexName == "" {
 		err = adh.addSearchAttributesElasticsearch(ctx, request, indexName)
 	} else {
 		err = adh.addSearchAttributesSQL(ctx, request, currentSearchAttributes)
@@ -340,6 +354,23 @@ func (adh *AdminHandler) addSearchAttributesElasticsearch(
 	return nil
 }
 
+// addSearchAttributesEmbedded registers custom search attributes in the cluster metadata.
+// Embedded visibility store has no schema, so there is nothing else to update.
+func (adh *AdminHandler) addSearchAttributesEmbedded(
+	ctx context.Context,
+	request *adminservice.AddSearchAttributesRequest,
+	indexName string,
+	currentSearchAttributes searchattribute.NameTypeMap,
+) error {
+	newCustomSearchAttributes := util.CloneMapNonNil(currentSearchAttributes.Custom())
+	maps.Copy(newCustomSearchAttributes, request.GetSearchAttributes())
+	err := adh.saManager.SaveSearchAttributes(ctx, indexName, newCustomSearchAttributes)
+	if err != nil {
+		return serviceerror.NewUnavailable(fmt.Sprintf(errUnableToSaveSearchAttributesMessage, err))
+	}
+	return nil
+}
+
 func (adh *AdminHandler) addSearchAttributesSQL(
 	ctx context.Context,
 	request *adminservice.AddSearchAttributesRequest,
@@ -448,7 +479,9 @@ func (adh *AdminHandler) RemoveSearchAttributes(
 	// register the search attributes in the cluster metadata if ES is up or if
 	// `skip-schema-update` is set. This is for backward compatibility using
 	// standard visibility.
-	if adh.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) || indexName == "" {
+	if adh.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) ||
+		adh.visibilityMgr.HasStoreName(embedded.PersistenceName) ||
+		indexName == "" {
 		err = adh.removeSearchAttributesElasticsearch(ctx, request, indexName, currentSearchAttributes)
 	} else {
 		err = adh.removeSearchAttributesSQL(ctx, request, currentSearchAttributes)
@@ -559,7 +592,9 @@ func (adh *AdminHandler) GetSearchAttributes(
 	// register the search attributes in the cluster metadata if ES is up or if
 	// `skip-schema-update` is set. This is for backward compatibility using
 	// standard visibility.
-	if adh.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) || indexName == "" {
+	if adh.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) ||
+		adh.visibilityMgr.HasStoreName(embedded.PersistenceName) ||
+		indexName == "" {
 		return adh.getSearchAttributesElasticsearch(ctx, indexName, searchAttributes)
 	}
 	return adh.getSearchAttributesSQL(ctx, request, searchAttributes)
@@ -664,13 +699,19 @@ func (adh *AdminHandler) RebuildMutableState(
 		return nil, err
 	}
 
-	if _, err := adh.historyClient.RebuildMutableState(ctx, &historyservice.RebuildMutableStateRequest{
+	resp, err := adh.historyClient.RebuildMutableState(ctx, &historyservice.RebuildMutableStateRequest{
 		NamespaceId: namespaceID.String(),
 		Execution:   request.Execution,
-	}); err != nil {
+		DryRun:      request.GetDryRun(),
+	})
+	if err != nil {
 		return nil, err
 	}
-	return &adminservice.RebuildMutableStateResponse{}, nil
+	return &adminservice.RebuildMutableStateResponse{
+		CurrentMutableState: resp.GetCurrentMutableState(),
+		RebuiltMutableState: resp.GetRebuiltMutableState(),
+		Diffs:               resp.GetDiffs(),
+	}, nil
 }
 
 func (adh *AdminHandler) ImportWorkflowExecution(
@@ -779,7 +820,10 @@ func (adh *AdminHandler) GetShard(ctx context.Context, request *adminservice.Get
 	if err != nil {
 		return nil, err
 	}
-	return &adminservice.GetShardResponse{ShardInfo: resp.ShardInfo}, nil
+	return &adminservice.GetShardResponse{
+		ShardInfo:   resp.ShardInfo,
+		QueueAlerts: resp.QueueAlerts,
+	}, nil
 }
 
 // CloseShard returns information about the internal states of a history host
@@ -1716,6 +1760,70 @@ func (adh *AdminHandler) StreamWorkflowReplicationMessages(
 	return nil
 }
 
+// WatchWorkflowExecutions streams the visibility records written for the workflow executions
+// of a namespace matching a query. The stream has to be resumed with the resume token of the
+// last received response whenever it ends with an error.
+func (adh *AdminHandler) WatchWorkflowExecutions(
+	request *adminservice.WatchWorkflowExecutionsRequest,
+	server adminservice.AdminService_WatchWorkflowExecutionsServer,
+) (retError error) {
+	defer log.CapturePanic(adh.logger, &retError)
+
+	if request == nil {
+		return errRequestNotSet
+	}
+	if request.GetNamespace() == "" {
+		return errNamespaceNotSet
+	}
+	namespaceName := namespace.Name(request.GetNamespace())
+	namespaceID, err := adh.namespaceRegistry.GetNamespaceID(namespaceName)
+	if err != nil {
+		return err
+	}
+
+	saTypeMap, err := adh.saProvider.GetSearchAttributes(adh.visibilityMgr.GetIndexName(), false)
+	if err != nil {
+		return serviceerror.NewUnavailable(fmt.Sprintf(errUnableToGetSearchAttributesMessage, err))
+	}
+	saMapper, err := adh.saMapperProvider.GetMapper(namespaceName)
+	if err != nil {
+		return err
+	}
+	query, err := visibilityquery.ParseWithMapper(request.GetQuery(), saTypeMap, saMapper, namespaceName.String())
+	if err != nil {
+		return serviceerror.NewInvalidArgument(err.Error())
+	}
+	token, err := deserializeVisibilityWatchToken(request.GetResumeToken())
+	if err != nil {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Invalid resume token: %v.", err))
+	}
+	historyResolver, err := adh.membershipMonitor.GetResolver(primitives.HistoryService)
+	if err != nil {
+		return err
+	}
+
+	defer adh.visibilityWatchCount.Add(-1)
+	if adh.visibilityWatchCount.Add(1) > int32(adh.config.MaxConcurrentVisibilityWatches()) {
+		return serviceerror.NewResourceExhausted(
+			enumspb.RESOURCE_EXHAUSTED_CAUSE_CONCURRENT_LIMIT,
+			"Too many concurrent visibility watches on this host.",
+		)
+	}
+
+	watcher := &visibilityWatcher{
+		numberOfHistoryShards: adh.numberOfHistoryShards,
+		historyClient:         adh.historyClient,
+		historyResolver:       historyResolver,
+		saMapperProvider:      adh.saMapperProvider,
+		saTypeMap:             saTypeMap,
+		namespaceID:           namespaceID,
+		namespaceName:         namespaceName,
+		query:                 query,
+		token:                 token,
+	}
+	return watcher.run(server)
+}
+
 func (adh *AdminHandler) GetNamespace(ctx context.Context, request *adminservice.GetNamespaceRequest) (_ *adminservice.GetNamespaceResponse, err error) {
 	defer log.CapturePanic(adh.logger, &err)
 	if request == nil || (len(request.GetId()) == 0 && len(request.GetNamespace()) == 0) {
@@ -1756,14 +1864,73 @@ func (adh *AdminHandler) GetNamespace(ctx context.Context, request *adminservice
 			Clusters:          convertClusterReplicationConfigToProto(replicationConfig.Clusters),
 			State:             replicationConfig.GetState(),
 		},
-		ConfigVersion:     resp.Namespace.GetConfigVersion(),
-		FailoverVersion:   resp.Namespace.GetFailoverVersion(),
-		IsGlobalNamespace: resp.IsGlobalNamespace,
-		FailoverHistory:   convertFailoverHistoryToReplicationProto(resp.Namespace.GetReplicationConfig().GetFailoverHistory()),
+		ConfigVersion:       resp.Namespace.GetConfigVersion(),
+		FailoverVersion:     resp.Namespace.GetFailoverVersion(),
+		IsGlobalNamespace:   resp.IsGlobalNamespace,
+		FailoverHistory:     convertFailoverHistoryToReplicationProto(resp.Namespace.GetReplicationConfig().GetFailoverHistory()),
+		VisibilityRetention: nsConfig.VisibilityRetention,
 	}
 	return nsResponse, nil
 }
 
+// UpdateNamespaceVisibilityRetention sets the retention of the visibility records of closed workflows of a namespace.
+// The setting is local to the cluster and is not replicated to other clusters of a global namespace.
+// UpdateNamespaceVisibilityRetention updates the visibility retention of the namespace on this cluster only.
+// Unlike UpdateNamespace, it doesn't create a namespace replication task, and other clusters keep their own
+// visibility retention.
+func (adh *AdminHandler) UpdateNamespaceVisibilityRetention(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceVisibilityRetentionRequest,
+) (_ *adminservice.UpdateNamespaceVisibilityRetentionResponse, retError error) {
+	defer log.CapturePanic(adh.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if request.GetNamespace() == "" {
+		return nil, errNamespaceNotSet
+	}
+	if request.GetVisibilityRetention().AsDuration() < 0 {
+		return nil, errInvalidVisibilityRetention
+	}
+
+	// must get the metadata (notificationVersion) first
+	// this version can be regarded as the lock on the v2 namespace table
+	metadata, err := adh.persistenceMetadataManager.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := adh.persistenceMetadataManager.GetNamespace(ctx, &persistence.GetNamespaceRequest{Name: request.GetNamespace()})
+	if err != nil {
+		return nil, err
+	}
+
+	nsConfig := resp.Namespace.GetConfig()
+	if request.GetVisibilityRetention().AsDuration() == 0 {
+		nsConfig.VisibilityRetention = nil
+	} else {
+		nsConfig.VisibilityRetention = request.GetVisibilityRetention()
+	}
+	err = adh.persistenceMetadataManager.UpdateNamespace(ctx, &persistence.UpdateNamespaceRequest{
+		Namespace: &persistencespb.NamespaceDetail{
+			Info:              resp.Namespace.Info,
+			Config:            nsConfig,
+			ReplicationConfig: resp.Namespace.ReplicationConfig,
+			// Visibility retention is local to the cluster, config version is not bumped so that
+			// namespace replication tasks from other clusters are still applied.
+			ConfigVersion:               resp.Namespace.ConfigVersion,
+			FailoverVersion:             resp.Namespace.FailoverVersion,
+			FailoverNotificationVersion: resp.Namespace.FailoverNotificationVersion,
+		},
+		NotificationVersion: metadata.NotificationVersion,
+		IsGlobalNamespace:   resp.IsGlobalNamespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.UpdateNamespaceVisibilityRetentionResponse{}, nil
+}
+
 func (adh *AdminHandler) GetDLQTasks(
 	ctx context.Context,
 	request *adminservice.GetDLQTasksRequest,
@@ -1979,6 +2146,118 @@ func (adh *AdminHandler) ListQueues(
 	}, nil
 }
 
+// GetDynamicConfig returns the dynamic config values known to this frontend host and the value
+// of each key in effect for the requested constraints.
+func (adh *AdminHandler) GetDynamicConfig(
+	_ context.Context,
+	request *adminservice.GetDynamicConfigRequest,
+) (_ *adminservice.GetDynamicConfigResponse, retError error) {
+	defer log.CapturePanic(adh.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+
+	var keyInfos []dynamicconfig.KeyInfo
+	if len(request.GetKeys()) == 0 {
+		keyInfos = dynamicconfig.RegisteredKeys()
+	} else {
+		for _, key := range request.GetKeys() {
+			info, ok := dynamicconfig.GetKeyInfo(dynamicconfig.Key(key))
+			if !ok {
+				info = dynamicconfig.KeyInfo{Key: dynamicconfig.Key(key)}
+			}
+			keyInfos = append(keyInfos, info)
+		}
+	}
+
+	constraints := dynamicConfigConstraintsFromProto(request.GetConstraints())
+	keys := make([]*adminservice.GetDynamicConfigResponse_Key, 0, len(keyInfos))
+	for _, info := range keyInfos {
+		key := &adminservice.GetDynamicConfigResponse_Key{
+			Key:        info.Key.String(),
+			Registered: info.Type != "",
+			ValueType:  string(info.Type),
+			Precedence: string(info.Precedence),
+		}
+		for _, cv := range adh.dynamicConfig.GetValues(info.Key) {
+			key.Values = append(key.Values, adh.dynamicConfigValueToProto(info.Key, cv.Constraints, cv.Value))
+		}
+		if key.Registered {
+			if info.Default != nil {
+				key.DefaultValue = dynamicConfigValueToJSON(info.Default)
+			}
+			if resolved, ok := adh.dynamicConfig.Resolve(info.Key, constraints); ok {
+				key.ResolvedValue = adh.dynamicConfigValueToProto(info.Key, resolved.Constraints, resolved.Value)
+				key.ResolvedFromDefault = resolved.FromDefault
+				if resolved.FromDefault {
+					key.ResolvedValue.Source = ""
+				}
+			}
+		}
+		keys = append(keys, key)
+	}
+	return &adminservice.GetDynamicConfigResponse{Keys: keys}, nil
+}
+
+func (adh *AdminHandler) dynamicConfigValueToProto(
+	key dynamicconfig.Key,
+	constraints dynamicconfig.Constraints,
+	value any,
+) *adminservice.DynamicConfigValue {
+	source, _ := adh.dynamicConfig.GetValueSource(key, constraints)
+	return &adminservice.DynamicConfigValue{
+		Constraints: &adminservice.DynamicConfigConstraints{
+			Namespace:     constraints.Namespace,
+			NamespaceId:   constraints.NamespaceID,
+			TaskQueueName: constraints.TaskQueueName,
+			TaskQueueType: constraints.TaskQueueType,
+			ShardId:       constraints.ShardID,
+			TaskType:      constraints.TaskType,
+		},
+		Value:  dynamicConfigValueToJSON(value),
+		Source: source,
+	}
+}
+
+func dynamicConfigConstraintsFromProto(constraints *adminservice.DynamicConfigConstraints) dynamicconfig.Constraints {
+	return dynamicconfig.Constraints{
+		Namespace:     constraints.GetNamespace(),
+		NamespaceID:   constraints.GetNamespaceId(),
+		TaskQueueName: constraints.GetTaskQueueName(),
+		TaskQueueType: constraints.GetTaskQueueType(),
+		ShardID:       constraints.GetShardId(),
+		TaskType:      constraints.GetTaskType(),
+	}
+}
+
+// dynamicConfigValueToJSON encodes a dynamic config value as JSON. Durations are encoded as
+// strings in the format accepted by the dynamic config file.
+func dynamicConfigValueToJSON(value any) string {
+	switch v := value.(type) {
+	case time.Duration:
+		value = v.String()
+	case []dynamicconfig.ConstrainedValue:
+		cvs := make([]map[string]any, 0, len(v))
+		for _, cv := range v {
+			cvValue := cv.Value
+			if d, ok := cvValue.(time.Duration); ok {
+				cvValue = d.String()
+			}
+			cvs = append(cvs, map[string]any{
+				"constraints": cv.Constraints,
+				"value":       cvValue,
+			})
+		}
+		value = cvs
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%q", fmt.Sprint(value))
+	}
+	return string(data)
+}
+
 func (adh *AdminHandler) getDLQWorkflowID(
 	key *commonspb.HistoryDLQKey,
 ) string {
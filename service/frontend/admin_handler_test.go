@@ -31,6 +31,7 @@ import (
 	"math/rand"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
@@ -51,6 +52,7 @@ import (
 	"go.temporal.io/server/api/adminservice/v1"
 	"go.temporal.io/server/api/adminservicemock/v1"
 	commonspb "go.temporal.io/server/api/common/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
 	"go.temporal.io/server/api/historyservice/v1"
 	"go.temporal.io/server/api/historyservicemock/v1"
 	persistencespb "go.temporal.io/server/api/persistence/v1"
@@ -62,15 +64,19 @@ import (
 	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/membership"
 	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/serialization"
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch"
+	"go.temporal.io/server/common/persistence/visibility/store/embedded"
 	"go.temporal.io/server/common/primitives"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/resourcetest"
 	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/common/testing/mocksdk"
+	"go.temporal.io/server/common/testing/protomock"
+	"go.temporal.io/server/common/testing/protorequire"
 	"go.temporal.io/server/service/history/tasks"
 	"go.temporal.io/server/service/worker/dlq"
 )
@@ -143,6 +149,7 @@ func (s *adminHandlerSuite) SetupTest() {
 		NumHistoryShards:                 4,
 		AccessHistoryFraction:            dynamicconfig.GetFloatPropertyFn(0.0),
 		AdminDeleteAccessHistoryFraction: dynamicconfig.GetFloatPropertyFn(0.0),
+		MaxConcurrentVisibilityWatches:   dynamicconfig.GetIntPropertyFn(100),
 	}
 	args := NewAdminHandlerArgs{
 		persistenceConfig,
@@ -165,10 +172,12 @@ func (s *adminHandlerSuite) SetupTest() {
 		s.mockResource.GetNamespaceRegistry(),
 		s.mockResource.GetSearchAttributesProvider(),
 		s.mockResource.GetSearchAttributesManager(),
+		s.mockResource.GetSearchAttributesMapperProvider(),
 		s.mockMetadata,
 		health.NewServer(),
 		serialization.NewSerializer(),
 		clock.NewRealTimeSource(),
+		dynamicconfig.NewNoopCollection(),
 		s.mockResource.GetExecutionManager(),
 		tasks.NewDefaultTaskCategoryRegistry(),
 	}
@@ -276,6 +285,7 @@ func (s *adminHandlerSuite) Test_AddSearchAttributes() {
 
 	mockSdkClient := mocksdk.NewMockClient(s.controller)
 	s.mockResource.SDKClientFactory.EXPECT().GetSystemClient().Return(mockSdkClient).AnyTimes()
+	s.mockVisibilityMgr.EXPECT().HasStoreName(embedded.PersistenceName).Return(false).AnyTimes()
 	s.mockVisibilityMgr.EXPECT().HasStoreName(elasticsearch.PersistenceName).Return(true).AnyTimes()
 
 	// Start workflow failed.
@@ -1143,6 +1153,89 @@ func (s *adminHandlerSuite) TestGetNamespace_EmptyRequest() {
 	s.Equal(errRequestNotSet, err)
 }
 
+func (s *adminHandlerSuite) TestUpdateNamespaceVisibilityRetention() {
+	namespaceName := "some name"
+	nsResponse := &persistence.GetNamespaceResponse{
+		Namespace: &persistencespb.NamespaceDetail{
+			ConfigVersion:   3,
+			FailoverVersion: 1,
+			Info: &persistencespb.NamespaceInfo{
+				Id:   "some id",
+				Name: namespaceName,
+			},
+			Config: &persistencespb.NamespaceConfig{
+				Retention: timestamp.DurationFromDays(2),
+			},
+			ReplicationConfig: &persistencespb.NamespaceReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+			},
+		},
+		IsGlobalNamespace: true,
+	}
+	s.mockResource.MetadataMgr.EXPECT().GetMetadata(gomock.Any()).Return(&persistence.GetMetadataResponse{NotificationVersion: 7}, nil)
+	s.mockResource.MetadataMgr.EXPECT().GetNamespace(gomock.Any(), &persistence.GetNamespaceRequest{
+		Name: namespaceName,
+	}).Return(nsResponse, nil)
+	s.mockResource.MetadataMgr.EXPECT().UpdateNamespace(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *persistence.UpdateNamespaceRequest) error {
+			s.Equal(int64(7), request.NotificationVersion)
+			s.True(request.IsGlobalNamespace)
+			s.Equal(int64(3), request.Namespace.ConfigVersion)
+			s.Equal(2*24*time.Hour, request.Namespace.Config.Retention.AsDuration())
+			s.Equal(30*24*time.Hour, request.Namespace.Config.VisibilityRetention.AsDuration())
+			return nil
+		},
+	)
+
+	_, err := s.handler.UpdateNamespaceVisibilityRetention(context.Background(), &adminservice.UpdateNamespaceVisibilityRetentionRequest{
+		Namespace:           namespaceName,
+		VisibilityRetention: timestamp.DurationFromDays(30),
+	})
+	s.NoError(err)
+}
+
+func (s *adminHandlerSuite) TestUpdateNamespaceVisibilityRetention_InvalidRequest() {
+	_, err := s.handler.UpdateNamespaceVisibilityRetention(context.Background(), &adminservice.UpdateNamespaceVisibilityRetentionRequest{
+		VisibilityRetention: timestamp.DurationFromDays(30),
+	})
+	s.Equal(errNamespaceNotSet, err)
+
+	_, err = s.handler.UpdateNamespaceVisibilityRetention(context.Background(), &adminservice.UpdateNamespaceVisibilityRetentionRequest{
+		Namespace:           "some name",
+		VisibilityRetention: timestamp.DurationPtr(-time.Hour),
+	})
+	s.Equal(errInvalidVisibilityRetention, err)
+}
+
+func (s *adminHandlerSuite) TestRebuildMutableState_DryRun() {
+	execution := &commonpb.WorkflowExecution{WorkflowId: "workflowID", RunId: uuid.New()}
+	historyResponse := &historyservice.RebuildMutableStateResponse{
+		CurrentMutableState: &persistencespb.WorkflowMutableState{NextEventId: 10},
+		RebuiltMutableState: &persistencespb.WorkflowMutableState{NextEventId: 8},
+		Diffs: []*historyspb.MutableStateFieldDiff{
+			{Path: "next_event_id", Current: "10", Rebuilt: "8"},
+		},
+	}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil)
+	s.mockHistoryClient.EXPECT().RebuildMutableState(gomock.Any(), protomock.Eq(&historyservice.RebuildMutableStateRequest{
+		NamespaceId: s.namespaceID.String(),
+		Execution:   execution,
+		DryRun:      true,
+	})).Return(historyResponse, nil)
+
+	resp, err := s.handler.RebuildMutableState(context.Background(), &adminservice.RebuildMutableStateRequest{
+		Namespace: s.namespace.String(),
+		Execution: execution,
+		DryRun:    true,
+	})
+	s.NoError(err)
+	protorequire.ProtoEqual(s.T(), &adminservice.RebuildMutableStateResponse{
+		CurrentMutableState: historyResponse.CurrentMutableState,
+		RebuiltMutableState: historyResponse.RebuiltMutableState,
+		Diffs:               historyResponse.Diffs,
+	}, resp)
+}
+
 func (s *adminHandlerSuite) TestGetDLQTasks() {
 	for _, tc := range []struct {
 		name string
@@ -1634,3 +1727,160 @@ func (s *adminHandlerSuite) TestListQueues_Err() {
 	_, err := s.handler.ListQueues(context.Background(), &adminservice.ListQueuesRequest{})
 	s.ErrorIs(err, assert.AnError)
 }
+
+func (s *adminHandlerSuite) TestGetDynamicConfig() {
+	const (
+		key           = dynamicconfig.Key("testAdminHandlerGetDynamicConfigKey")
+		unknownKey    = dynamicconfig.Key("testAdminHandlerGetDynamicConfigUnknownKey")
+		testNamespace = "test-namespace"
+	)
+	dc := dynamicconfig.NewCollection(dynamicconfig.StaticClient{
+		key: []dynamicconfig.ConstrainedValue{
+			{Constraints: dynamicconfig.Constraints{Namespace: testNamespace}, Value: "5s"},
+		},
+	}, s.mockResource.GetLogger())
+	dc.GetDurationPropertyFilteredByNamespace(key, time.Second)
+	s.handler.dynamicConfig = dc
+
+	resp, err := s.handler.GetDynamicConfig(context.Background(), &adminservice.GetDynamicConfigRequest{
+		Keys:        []string{key.String(), unknownKey.String()},
+		Constraints: &adminservice.DynamicConfigConstraints{Namespace: testNamespace},
+	})
+	s.NoError(err)
+	s.Len(resp.Keys, 2)
+
+	namespaceConstraints := &adminservice.DynamicConfigConstraints{Namespace: testNamespace}
+	protorequire.ProtoEqual(s.T(), &adminservice.GetDynamicConfigResponse_Key{
+		Key:          key.String(),
+		Registered:   true,
+		ValueType:    "duration",
+		Precedence:   "namespace",
+		DefaultValue: `"1s"`,
+		Values: []*adminservice.DynamicConfigValue{
+			{Constraints: namespaceConstraints, Value: `"5s"`},
+		},
+		ResolvedValue: &adminservice.DynamicConfigValue{Constraints: namespaceConstraints, Value: `"5s"`},
+	}, resp.Keys[0])
+	protorequire.ProtoEqual(s.T(), &adminservice.GetDynamicConfigResponse_Key{Key: unknownKey.String()}, resp.Keys[1])
+
+	resp, err = s.handler.GetDynamicConfig(context.Background(), &adminservice.GetDynamicConfigRequest{
+		Keys: []string{key.String()},
+	})
+	s.NoError(err)
+	s.True(resp.Keys[0].ResolvedFromDefault)
+	s.Equal(`"1s"`, resp.Keys[0].ResolvedValue.Value)
+}
+
+func (s *adminHandlerSuite) Test_WatchWorkflowExecutions() {
+	newExecution := func(workflowID string, keyword string) *workflowpb.WorkflowExecutionInfo {
+		keywordPayload, err := payload.Encode(keyword)
+		s.NoError(err)
+		return &workflowpb.WorkflowExecutionInfo{
+			Execution: &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: uuid.New()},
+			Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			SearchAttributes: &commonpb.SearchAttributes{
+				IndexedFields: map[string]*commonpb.Payload{"CustomKeywordField": keywordPayload},
+			},
+		}
+	}
+	silverExecution := newExecution("silver-workflow", "silver")
+	goldExecution := newExecution("gold-workflow", "gold")
+
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil)
+	s.mockVisibilityMgr.EXPECT().GetIndexName().Return("")
+	s.mockResource.SearchAttributesProvider.EXPECT().GetSearchAttributes("", false).Return(searchattribute.TestNameTypeMap, nil)
+	s.mockResource.SearchAttributesMapperProvider.EXPECT().GetMapper(s.namespace).
+		Return(&searchattribute.TestMapper{Namespace: s.namespace.String()}, nil).AnyTimes()
+
+	s.handler.numberOfHistoryShards = 2
+	gomock.InOrder(
+		s.mockResource.HistoryServiceResolver.EXPECT().Lookup("1").Return(membership.NewHostInfoFromAddress("host1"), nil),
+		s.mockResource.HistoryServiceResolver.EXPECT().Lookup("2").Return(membership.NewHostInfoFromAddress("host1"), nil),
+		s.mockResource.HistoryServiceResolver.EXPECT().Lookup("1").Return(membership.NewHostInfoFromAddress("host1"), nil),
+		s.mockResource.HistoryServiceResolver.EXPECT().Lookup("2").Return(membership.NewHostInfoFromAddress("host2"), nil),
+	)
+
+	// Both shards are owned by the same host, so they are streamed together.
+	historyStream := historyservicemock.NewMockHistoryService_StreamVisibilityChangesClient(s.controller)
+	s.mockHistoryClient.EXPECT().StreamVisibilityChanges(gomock.Any(), protomock.Eq(&historyservice.StreamVisibilityChangesRequest{
+		NamespaceId: s.namespaceID.String(),
+		Shards: []*historyservice.StreamVisibilityChangesRequest_Shard{
+			{ShardId: 1, LastTaskId: 3},
+			{ShardId: 2, LastTaskId: 0},
+		},
+	})).Return(historyStream, nil)
+	gomock.InOrder(
+		historyStream.EXPECT().Recv().Return(&historyservice.StreamVisibilityChangesResponse{ShardId: 1, LastTaskId: 5}, nil),
+		historyStream.EXPECT().Recv().Return(&historyservice.StreamVisibilityChangesResponse{
+			ShardId: 2,
+			Changes: []*historyservice.StreamVisibilityChangesResponse_Change{
+				{TaskId: 6, Execution: silverExecution},
+				{TaskId: 7, Execution: goldExecution},
+			},
+			LastTaskId: 8,
+		}, nil),
+		historyStream.EXPECT().Recv().Return(nil, serviceerror.NewUnavailable("shard moved")),
+	)
+	// Once shard 2 moves, the streams are reopened per host from the last sent positions.
+	for _, shard := range []*historyservice.StreamVisibilityChangesRequest_Shard{
+		{ShardId: 1, LastTaskId: 5},
+		{ShardId: 2, LastTaskId: 8},
+	} {
+		reopenedStream := historyservicemock.NewMockHistoryService_StreamVisibilityChangesClient(s.controller)
+		s.mockHistoryClient.EXPECT().StreamVisibilityChanges(gomock.Any(), protomock.Eq(&historyservice.StreamVisibilityChangesRequest{
+			NamespaceId: s.namespaceID.String(),
+			Shards:      []*historyservice.StreamVisibilityChangesRequest_Shard{shard},
+		})).Return(reopenedStream, nil).MaxTimes(1)
+		reopenedStream.EXPECT().Recv().Return(nil, serviceerror.NewFailedPrecondition("resume token expired")).MaxTimes(1)
+	}
+
+	server := adminservicemock.NewMockAdminService_WatchWorkflowExecutionsServer(s.controller)
+	server.EXPECT().Context().Return(context.Background()).AnyTimes()
+	var responses []*adminservice.WatchWorkflowExecutionsResponse
+	server.EXPECT().Send(gomock.Any()).DoAndReturn(func(response *adminservice.WatchWorkflowExecutionsResponse) error {
+		responses = append(responses, response)
+		return nil
+	}).AnyTimes()
+
+	err := s.handler.WatchWorkflowExecutions(&adminservice.WatchWorkflowExecutionsRequest{
+		Namespace:   s.namespace.String(),
+		Query:       "AliasForCustomKeywordField = 'gold'",
+		ResumeToken: []byte(`{"lastTaskIds":{"1":3}}`),
+	}, server)
+	s.IsType(&serviceerror.FailedPrecondition{}, err)
+
+	s.Len(responses, 1)
+	s.Equal("gold-workflow", responses[0].GetExecution().GetExecution().GetWorkflowId())
+	s.Contains(responses[0].GetExecution().GetSearchAttributes().GetIndexedFields(), "AliasForCustomKeywordField")
+	s.JSONEq(`{"lastTaskIds":{"1":5}}`, string(responses[0].GetResumeToken()))
+}
+
+func (s *adminHandlerSuite) Test_WatchWorkflowExecutions_ConcurrentLimit() {
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil)
+	s.mockVisibilityMgr.EXPECT().GetIndexName().Return("")
+	s.mockResource.SearchAttributesProvider.EXPECT().GetSearchAttributes("", false).Return(searchattribute.TestNameTypeMap, nil)
+	s.mockResource.SearchAttributesMapperProvider.EXPECT().GetMapper(s.namespace).
+		Return(&searchattribute.TestMapper{Namespace: s.namespace.String()}, nil)
+	s.handler.config.MaxConcurrentVisibilityWatches = dynamicconfig.GetIntPropertyFn(0)
+
+	err := s.handler.WatchWorkflowExecutions(&adminservice.WatchWorkflowExecutionsRequest{
+		Namespace: s.namespace.String(),
+		Query:     "AliasForCustomKeywordField = 'gold'",
+	}, adminservicemock.NewMockAdminService_WatchWorkflowExecutionsServer(s.controller))
+	s.IsType(&serviceerror.ResourceExhausted{}, err)
+	s.Zero(s.handler.visibilityWatchCount.Load())
+}
+
+func (s *adminHandlerSuite) Test_WatchWorkflowExecutions_InvalidQuery() {
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil)
+	s.mockVisibilityMgr.EXPECT().GetIndexName().Return("")
+	s.mockResource.SearchAttributesProvider.EXPECT().GetSearchAttributes("", false).Return(searchattribute.TestNameTypeMap, nil)
+	s.mockResource.SearchAttributesMapperProvider.EXPECT().GetMapper(s.namespace).
+		Return(&searchattribute.TestMapper{Namespace: s.namespace.String()}, nil)
+
+	err := s.handler.WatchWorkflowExecutions(&adminservice.WatchWorkflowExecutionsRequest{
+		Namespace: s.namespace.String(),
+		Query:     "AliasForUnknownField = 'gold'",
+	}, adminservicemock.NewMockAdminService_WatchWorkflowExecutionsServer(s.controller))
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
@@ -84,15 +84,24 @@ var (
 	errUseVersioningWithoutNormalName                     = serviceerror.NewInvalidArgument("NormalName must be set on sticky queue if UseVersioning is true.")
 	errBuildIdTooLong                                     = serviceerror.NewInvalidArgument("Build ID exceeds configured limit.workerBuildIdSize, use a shorter build ID.")
 
-	errUpdateMetaNotSet       = serviceerror.NewInvalidArgument("Update meta is not set on request.")
-	errUpdateInputNotSet      = serviceerror.NewInvalidArgument("Update input is not set on request.")
-	errUpdateNameNotSet       = serviceerror.NewInvalidArgument("Update name is not set on request.")
-	errUpdateIDTooLong        = serviceerror.NewInvalidArgument("UpdateId length exceeds limit.")
-	errUpdateRefNotSet        = serviceerror.NewInvalidArgument("UpdateRef is not set on request.")
-	errUpdateWaitPolicyNotSet = serviceerror.NewInvalidArgument("WaitPolicy is not set on request.")
-	errSourceClusterNotSet    = serviceerror.NewInvalidArgument("SourceCluster is not set on request.")
-	errTargetClusterNotSet    = serviceerror.NewInvalidArgument("TargetCluster is not set on request.")
-	errInvalidDLQJobToken     = serviceerror.NewInvalidArgument("Invalid DLQ job token.")
+	errUpdateMetaNotSet           = serviceerror.NewInvalidArgument("Update meta is not set on request.")
+	errUpdateInputNotSet          = serviceerror.NewInvalidArgument("Update input is not set on request.")
+	errUpdateNameNotSet           = serviceerror.NewInvalidArgument("Update name is not set on request.")
+	errUpdateIDTooLong            = serviceerror.NewInvalidArgument("UpdateId length exceeds limit.")
+	errUpdateRefNotSet            = serviceerror.NewInvalidArgument("UpdateRef is not set on request.")
+	errUpdateWaitPolicyNotSet     = serviceerror.NewInvalidArgument("WaitPolicy is not set on request.")
+	errSourceClusterNotSet        = serviceerror.NewInvalidArgument("SourceCluster is not set on request.")
+	errTargetClusterNotSet        = serviceerror.NewInvalidArgument("TargetCluster is not set on request.")
+	errInvalidDLQJobToken         = serviceerror.NewInvalidArgument("Invalid DLQ job token.")
+	errInvalidVisibilityRetention = serviceerror.NewInvalidArgument("A negative VisibilityRetention is set on request.")
+
+	errNexusIncomingServiceNotSet          = serviceerror.NewInvalidArgument("Service is not set on request.")
+	errNexusIncomingServiceNameNotSet      = serviceerror.NewInvalidArgument("Service name is not set on request.")
+	errNexusIncomingServiceNameTooLong     = serviceerror.NewInvalidArgument("Service name length exceeds limit.")
+	errNexusIncomingServiceInvalidVersion  = serviceerror.NewInvalidArgument("Service version must not be negative.")
+	errNexusIncomingServiceNamespaceNotSet = serviceerror.NewInvalidArgument("Service namespace is not set on request.")
+	errNexusIncomingServiceTooLarge        = serviceerror.NewInvalidArgument("Service size exceeds limit.")
+	errNexusIncomingServicesPageToken      = serviceerror.NewInvalidArgument("Invalid Nexus incoming services page token.")
 
 	errPageSizeTooBigMessage = "PageSize is larger than allowed %d."
 
@@ -112,6 +121,7 @@ var (
 	errUnableToGetNamespaceInfoMessage                = "Unable to get namespace info with error: %v"
 	errUnableToCreateFrontendClientMessage            = "Unable to create frontend client with error: %v."
 	errTooManySearchAttributesMessage                 = "Unable to create search attributes: cannot have more than %d search attribute of type %s."
+	errNexusIncomingServiceNamespaceNotFoundMessage   = "Service namespace %q not found."
 
 	errListNotAllowed      = serviceerror.NewPermissionDenied("List is disabled on this namespace.", "")
 	errSchedulesNotAllowed = serviceerror.NewPermissionDenied("Schedules are disabled on this namespace.", "")
@@ -87,6 +87,7 @@ var Module = fx.Options(
 	fx.Provide(NamespaceRateLimitInterceptorProvider),
 	fx.Provide(SDKVersionInterceptorProvider),
 	fx.Provide(CallerInfoInterceptorProvider),
+	fx.Provide(AuthorizationAuditLoggerProvider),
 	fx.Provide(GrpcServerOptionsProvider),
 	fx.Provide(VisibilityManagerProvider),
 	fx.Provide(ThrottledLoggerRpsFnProvider),
@@ -145,6 +146,29 @@ type GrpcServerOptions struct {
 	UnaryInterceptors []grpc.UnaryServerInterceptor
 }
 
+// AuthorizationAuditLoggerProvider creates the authorization audit logger of the public frontend.
+// It returns nil if no audit sink is configured, or for the internal frontend, which only serves
+// calls from within the cluster.
+func AuthorizationAuditLoggerProvider(
+	cfg *config.Config,
+	serviceName primitives.ServiceName,
+	dc *dynamicconfig.Collection,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+	lc fx.Lifecycle,
+) (*authorization.AuditLogger, error) {
+	if serviceName != primitives.FrontendService {
+		return nil, nil
+	}
+	sink, err := authorization.NewAuditSinkFromConfig(&cfg.Global.Authorization.Audit)
+	if err != nil || sink == nil {
+		return nil, err
+	}
+	auditLogger := authorization.NewAuditLogger(sink, cfg.Global.Authorization.Audit.BufferSize, dc, metricsHandler, logger)
+	lc.Append(fx.StopHook(auditLogger.Close))
+	return auditLogger, nil
+}
+
 func GrpcServerOptionsProvider(
 	logger log.Logger,
 	cfg *config.Config,
@@ -166,6 +190,7 @@ func GrpcServerOptionsProvider(
 	authorizer authorization.Authorizer,
 	claimMapper authorization.ClaimMapper,
 	audienceGetter authorization.JWTAudienceMapper,
+	auditLogger *authorization.AuditLogger,
 	customInterceptors []grpc.UnaryServerInterceptor,
 	metricsHandler metrics.Handler,
 ) GrpcServerOptions {
@@ -208,6 +233,7 @@ func GrpcServerOptionsProvider(
 			audienceGetter,
 			cfg.Global.Authorization.AuthHeaderName,
 			cfg.Global.Authorization.AuthExtraHeaderName,
+			authorization.WithAuditLogger(auditLogger),
 		),
 		redirectionInterceptor.Intercept,
 		telemetryInterceptor.UnaryIntercept,
@@ -228,6 +254,16 @@ func GrpcServerOptionsProvider(
 
 	streamInterceptor := []grpc.StreamServerInterceptor{
 		telemetryInterceptor.StreamIntercept,
+		authorization.NewAuthorizationStreamInterceptor(
+			claimMapper,
+			authorizer,
+			metricsHandler,
+			logger,
+			audienceGetter,
+			cfg.Global.Authorization.AuthHeaderName,
+			cfg.Global.Authorization.AuthExtraHeaderName,
+			authorization.WithAuditLogger(auditLogger),
+		),
 	}
 
 	grpcServerOptions = append(
@@ -505,10 +541,12 @@ func AdminHandlerProvider(
 	namespaceRegistry namespace.Registry,
 	saProvider searchattribute.Provider,
 	saManager searchattribute.Manager,
+	saMapperProvider searchattribute.MapperProvider,
 	clusterMetadata cluster.Metadata,
 	healthServer *health.Server,
 	eventSerializer serialization.Serializer,
 	timeSource clock.TimeSource,
+	dc *dynamicconfig.Collection,
 	taskCategoryRegistry tasks.TaskCategoryRegistry,
 ) *AdminHandler {
 	args := NewAdminHandlerArgs{
@@ -532,10 +570,12 @@ func AdminHandlerProvider(
 		namespaceRegistry,
 		saProvider,
 		saManager,
+		saMapperProvider,
 		clusterMetadata,
 		healthServer,
 		eventSerializer,
 		timeSource,
+		dc,
 		persistenceExecutionManager,
 		taskCategoryRegistry,
 	}
@@ -555,6 +595,9 @@ func OperatorHandlerProvider(
 	clusterMetadataManager persistence.ClusterMetadataManager,
 	clusterMetadata cluster.Metadata,
 	clientFactory client.Factory,
+	namespaceRegistry namespace.Registry,
+	nexusIncomingServiceManager persistence.NexusIncomingServiceManager,
+	persistenceConfig *config.Persistence,
 ) *OperatorHandlerImpl {
 	args := NewOperatorHandlerImplArgs{
 		configuration,
@@ -569,10 +612,28 @@ func OperatorHandlerProvider(
 		clusterMetadataManager,
 		clusterMetadata,
 		clientFactory,
+		namespaceRegistry,
+		nexusIncomingServiceManager,
+		visibilityNamespaceIndices(persistenceConfig),
 	}
 	return NewOperatorHandlerImpl(args)
 }
 
+// visibilityNamespaceIndices returns namespace dedicated Elasticsearch indices keyed by visibility index name.
+func visibilityNamespaceIndices(persistenceConfig *config.Persistence) map[string][]string {
+	result := make(map[string][]string)
+	for _, ds := range []config.DataStore{
+		persistenceConfig.GetVisibilityStoreConfig(),
+		persistenceConfig.GetSecondaryVisibilityStoreConfig(),
+	} {
+		if ds.Elasticsearch == nil || len(ds.Elasticsearch.NamespaceIndices) == 0 {
+			continue
+		}
+		result[ds.Elasticsearch.GetVisibilityIndex()] = ds.Elasticsearch.NamespaceIndices.GetIndexNames()
+	}
+	return result
+}
+
 func HandlerProvider(
 	dcRedirectionPolicy config.DCRedirectionPolicy,
 	serviceConfig *Config,
@@ -26,18 +26,19 @@ package frontend
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync/atomic"
 
 	"golang.org/x/exp/maps"
-	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	namespacepb "go.temporal.io/api/namespace/v1"
+	nexuspb "go.temporal.io/api/nexus/v1"
 	"go.temporal.io/api/operatorservice/v1"
 	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/api/workflowservice/v1"
@@ -45,6 +46,7 @@ import (
 
 	"go.temporal.io/server/api/adminservice/v1"
 	persistencespb "go.temporal.io/server/api/persistence/v1"
+	tokenspb "go.temporal.io/server/api/token/v1"
 	svc "go.temporal.io/server/client"
 	"go.temporal.io/server/client/admin"
 	"go.temporal.io/server/client/frontend"
@@ -59,6 +61,7 @@ import (
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch"
 	esclient "go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
+	"go.temporal.io/server/common/persistence/visibility/store/embedded"
 	"go.temporal.io/server/common/primitives"
 	"go.temporal.io/server/common/resource"
 	"go.temporal.io/server/common/sdk"
@@ -90,6 +93,10 @@ type (
 		clusterMetadataManager persistence.ClusterMetadataManager
 		clusterMetadata        clustermetadata.Metadata
 		clientFactory          svc.Factory
+
+		namespaceRegistry           namespace.Registry
+		nexusIncomingServiceManager persistence.NexusIncomingServiceManager
+		visibilityNamespaceIndices  map[string][]string
 	}
 
 	NewOperatorHandlerImplArgs struct {
@@ -105,6 +112,11 @@ type (
 		clusterMetadataManager persistence.ClusterMetadataManager
 		clusterMetadata        clustermetadata.Metadata
 		clientFactory          svc.Factory
+
+		namespaceRegistry           namespace.Registry
+		nexusIncomingServiceManager persistence.NexusIncomingServiceManager
+		// Namespace dedicated Elasticsearch indices keyed by visibility index name.
+		visibilityNamespaceIndices map[string][]string
 	}
 )
 
@@ -133,6 +145,10 @@ func NewOperatorHandlerImpl(
 		clusterMetadataManager: args.clusterMetadataManager,
 		clusterMetadata:        args.clusterMetadata,
 		clientFactory:          args.clientFactory,
+
+		namespaceRegistry:           args.namespaceRegistry,
+		nexusIncomingServiceManager: args.nexusIncomingServiceManager,
+		visibilityNamespaceIndices:  args.visibilityNamespaceIndices,
 	}
 
 	return handler
@@ -234,6 +250,8 @@ func (h *OperatorHandlerImpl) addSearchAttributesInternal(
 		} else {
 			scope.Counter(metrics.AddSearchAttributesWorkflowSuccessCount.Name()).Record(1)
 		}
+	} else if storeName == embedded.PersistenceName {
+		err = h.addSearchAttributesEmbedded(ctx, request, indexName, currentSearchAttributes)
 	} else {
 		err = h.addSearchAttributesSQL(ctx, request, currentSearchAttributes)
 	}
@@ -271,6 +289,7 @@ func (h *OperatorHandlerImpl) addSearchAttributesElasticsearch(
 	wfParams := addsearchattributes.WorkflowParams{
 		CustomAttributesToAdd: customAttributesToAdd,
 		IndexName:             indexName,
+		NamespaceIndexNames:   h.visibilityNamespaceIndices[indexName],
 		SkipSchemaUpdate:      false,
 	}
 
@@ -302,6 +321,39 @@ func (h *OperatorHandlerImpl) addSearchAttributesElasticsearch(
 	return nil
 }
 
+// addSearchAttributesEmbedded registers custom search attributes in the cluster metadata.
+// Embedded visibility store has no schema, so there is nothing else to update.
+func (h *OperatorHandlerImpl) addSearchAttributesEmbedded(
+	ctx context.Context,
+	request *operatorservice.AddSearchAttributesRequest,
+	indexName string,
+	currentSearchAttributes searchattribute.NameTypeMap,
+) error {
+	newCustomSearchAttributes := util.CloneMapNonNil(currentSearchAttributes.Custom())
+	for saName, saType := range request.GetSearchAttributes() {
+		if currentSearchAttributes.IsDefined(saName) {
+			h.logger.Warn(
+				fmt.Sprintf(errSearchAttributeAlreadyExistsMessage, saName),
+				tag.NewStringTag(visibilityIndexNameTagName, indexName),
+				tag.NewStringTag(visibilitySearchAttributeTagName, saName),
+			)
+			continue
+		}
+		newCustomSearchAttributes[saName] = saType
+	}
+
+	// If no search attributes were added, then all custom search attributes already exist.
+	if len(newCustomSearchAttributes) == len(currentSearchAttributes.Custom()) {
+		return nil
+	}
+
+	err := h.saManager.SaveSearchAttributes(ctx, indexName, newCustomSearchAttributes)
+	if err != nil {
+		return serviceerror.NewUnavailable(fmt.Sprintf(errUnableToSaveSearchAttributesMessage, err))
+	}
+	return nil
+}
+
 func (h *OperatorHandlerImpl) addSearchAttributesSQL(
 	ctx context.Context,
 	request *operatorservice.AddSearchAttributesRequest,
@@ -416,7 +468,9 @@ func (h *OperatorHandlerImpl) RemoveSearchAttributes(
 	// register the search attributes in the cluster metadata if ES is up or if
 	// `skip-schema-update` is set. This is for backward compatibility using
 	// standard visibility.
-	if h.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) || indexName == "" {
+	if h.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) ||
+		h.visibilityMgr.HasStoreName(embedded.PersistenceName) ||
+		indexName == "" {
 		err = h.removeSearchAttributesElasticsearch(ctx, request, indexName, currentSearchAttributes)
 	} else {
 		err = h.removeSearchAttributesSQL(ctx, request, currentSearchAttributes)
@@ -526,7 +580,9 @@ func (h *OperatorHandlerImpl) ListSearchAttributes(
 	// register the search attributes in the cluster metadata if ES is up or if
 	// `skip-schema-update` is set. This is for backward compatibility using
 	// standard visibility.
-	if h.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) || indexName == "" {
+	if h.visibilityMgr.HasStoreName(elasticsearch.PersistenceName) ||
+		h.visibilityMgr.HasStoreName(embedded.PersistenceName) ||
+		indexName == "" {
 		return h.listSearchAttributesElasticsearch(ctx, indexName, searchAttributes)
 	}
 	return h.listSearchAttributesSQL(ctx, request, searchAttributes)
@@ -824,21 +880,252 @@ func (h *OperatorHandlerImpl) validateRemoteClusterMetadata(metadata *adminservi
 	return nil
 }
 
-func (h *OperatorHandlerImpl) CreateOrUpdateNexusIncomingService(context.Context, *operatorservice.CreateOrUpdateNexusIncomingServiceRequest) (*operatorservice.CreateOrUpdateNexusIncomingServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateOrUpdateNexusIncomingService not implemented")
+func (h *OperatorHandlerImpl) CreateOrUpdateNexusIncomingService(
+	ctx context.Context,
+	request *operatorservice.CreateOrUpdateNexusIncomingServiceRequest,
+) (_ *operatorservice.CreateOrUpdateNexusIncomingServiceResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	entry, err := h.validateAndConvertNexusIncomingService(request.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	tableVersion, err := h.getNexusIncomingServicesTableVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.nexusIncomingServiceManager.CreateOrUpdateNexusIncomingService(ctx, &persistence.CreateOrUpdateNexusIncomingServiceRequest{
+		LastKnownTableVersion: tableVersion,
+		Entry:                 entry,
+	})
+	if err != nil {
+		return nil, convertNexusIncomingServiceError(err)
+	}
+
+	service := common.CloneProto(request.GetService())
+	service.Version = resp.Version
+	return &operatorservice.CreateOrUpdateNexusIncomingServiceResponse{
+		Service: service,
+	}, nil
+}
+
+func (h *OperatorHandlerImpl) DeleteNexusIncomingService(
+	ctx context.Context,
+	request *operatorservice.DeleteNexusIncomingServiceRequest,
+) (_ *operatorservice.DeleteNexusIncomingServiceResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if err := h.validateNexusIncomingServiceName(request.GetName()); err != nil {
+		return nil, err
+	}
+
+	tableVersion, err := h.getNexusIncomingServicesTableVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.nexusIncomingServiceManager.DeleteNexusIncomingService(ctx, &persistence.DeleteNexusIncomingServiceRequest{
+		LastKnownTableVersion: tableVersion,
+		Name:                  request.GetName(),
+	}); err != nil {
+		return nil, convertNexusIncomingServiceError(err)
+	}
+	return &operatorservice.DeleteNexusIncomingServiceResponse{}, nil
+}
+
+func (h *OperatorHandlerImpl) GetNexusIncomingService(
+	ctx context.Context,
+	request *operatorservice.GetNexusIncomingServiceRequest,
+) (_ *operatorservice.GetNexusIncomingServiceResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	if err := h.validateNexusIncomingServiceName(request.GetName()); err != nil {
+		return nil, err
+	}
+
+	entry, err := h.nexusIncomingServiceManager.GetNexusIncomingService(ctx, &persistence.GetNexusIncomingServiceRequest{
+		Name: request.GetName(),
+	})
+	if err != nil {
+		return nil, convertNexusIncomingServiceError(err)
+	}
+	service, err := h.nexusIncomingServiceEntryToService(entry)
+	if err != nil {
+		return nil, err
+	}
+	return &operatorservice.GetNexusIncomingServiceResponse{
+		Service: service,
+	}, nil
+}
+
+func (h *OperatorHandlerImpl) ListNexusIncomingServices(
+	ctx context.Context,
+	request *operatorservice.ListNexusIncomingServicesRequest,
+) (_ *operatorservice.ListNexusIncomingServicesResponse, retError error) {
+	defer log.CapturePanic(h.logger, &retError)
+
+	if request == nil {
+		return nil, errRequestNotSet
+	}
+	pageSize := int(request.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = h.config.NexusIncomingServiceListDefaultPageSize()
+	}
+	if maxPageSize := h.config.NexusIncomingServiceListMaxPageSize(); pageSize > maxPageSize {
+		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf(errPageSizeTooBigMessage, maxPageSize))
+	}
+
+	pageToken := &tokenspb.NexusIncomingServicesPageToken{}
+	if len(request.GetNextPageToken()) > 0 {
+		if err := pageToken.Unmarshal(request.GetNextPageToken()); err != nil {
+			return nil, errNexusIncomingServicesPageToken
+		}
+	}
+
+	resp, err := h.nexusIncomingServiceManager.ListNexusIncomingServices(ctx, &persistence.ListNexusIncomingServicesRequest{
+		LastKnownTableVersion: pageToken.GetLastKnownTableVersion(),
+		NextPageToken:         pageToken.GetPersistenceToken(),
+		PageSize:              pageSize,
+	})
+	if err != nil {
+		return nil, convertNexusIncomingServiceError(err)
+	}
+
+	services := make([]*nexuspb.IncomingService, 0, len(resp.Entries))
+	for _, entry := range resp.Entries {
+		service, err := h.nexusIncomingServiceEntryToService(entry)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, service)
+	}
+
+	var nextPageToken []byte
+	if len(resp.NextPageToken) > 0 {
+		nextPageToken, err = (&tokenspb.NexusIncomingServicesPageToken{
+			LastKnownTableVersion: resp.TableVersion,
+			PersistenceToken:      resp.NextPageToken,
+		}).Marshal()
+		if err != nil {
+			return nil, serviceerror.NewInternal(fmt.Sprintf("failed to serialize page token: %v", err))
+		}
+	}
+	return &operatorservice.ListNexusIncomingServicesResponse{
+		Services:      services,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// getNexusIncomingServicesTableVersion reads the current version of the nexus incoming services table so that the
+// following mutation fails with a FailedPrecondition error instead of racing with a concurrent one.
+func (h *OperatorHandlerImpl) getNexusIncomingServicesTableVersion(ctx context.Context) (int64, error) {
+	resp, err := h.nexusIncomingServiceManager.ListNexusIncomingServices(ctx, &persistence.ListNexusIncomingServicesRequest{
+		PageSize: 1,
+	})
+	if err != nil {
+		return 0, convertNexusIncomingServiceError(err)
+	}
+	return resp.TableVersion, nil
 }
 
-// DeleteNexusIncomingService implements OperatorHandler.
-func (*OperatorHandlerImpl) DeleteNexusIncomingService(context.Context, *operatorservice.DeleteNexusIncomingServiceRequest) (*operatorservice.DeleteNexusIncomingServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteNexusIncomingService not implemented")
+func (h *OperatorHandlerImpl) validateNexusIncomingServiceName(name string) error {
+	if name == "" {
+		return errNexusIncomingServiceNameNotSet
+	}
+	if len(name) > h.config.NexusIncomingServiceNameMaxLength() {
+		return errNexusIncomingServiceNameTooLong
+	}
+	return nil
 }
 
-// GetNexusIncomingService implements OperatorHandler.
-func (*OperatorHandlerImpl) GetNexusIncomingService(context.Context, *operatorservice.GetNexusIncomingServiceRequest) (*operatorservice.GetNexusIncomingServiceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetNexusIncomingService not implemented")
+// validateAndConvertNexusIncomingService validates the given service and converts it to its persisted form, resolving
+// the target namespace name to an ID.
+func (h *OperatorHandlerImpl) validateAndConvertNexusIncomingService(
+	service *nexuspb.IncomingService,
+) (*persistencespb.NexusIncomingServiceEntry, error) {
+	if service == nil {
+		return nil, errNexusIncomingServiceNotSet
+	}
+	if err := h.validateNexusIncomingServiceName(service.GetName()); err != nil {
+		return nil, err
+	}
+	if service.GetVersion() < 0 {
+		return nil, errNexusIncomingServiceInvalidVersion
+	}
+	if service.GetNamespace() == "" {
+		return nil, errNexusIncomingServiceNamespaceNotSet
+	}
+	if service.GetTaskQueue() == "" {
+		return nil, errTaskQueueNotSet
+	}
+	if len(service.GetTaskQueue()) > h.config.MaxIDLengthLimit() {
+		return nil, errTaskQueueTooLong
+	}
+	if proto.Size(service) > h.config.NexusIncomingServiceMaxSize() {
+		return nil, errNexusIncomingServiceTooLarge
+	}
+
+	namespaceID, err := h.namespaceRegistry.GetNamespaceID(namespace.Name(service.GetNamespace()))
+	if err != nil {
+		var nsNotFound *serviceerror.NamespaceNotFound
+		if errors.As(err, &nsNotFound) {
+			return nil, serviceerror.NewInvalidArgument(fmt.Sprintf(errNexusIncomingServiceNamespaceNotFoundMessage, service.GetNamespace()))
+		}
+		return nil, err
+	}
+
+	return &persistencespb.NexusIncomingServiceEntry{
+		Version: service.GetVersion(),
+		Name:    service.GetName(),
+		Service: &persistencespb.NexusIncomingService{
+			NamespaceId: namespaceID.String(),
+			TaskQueue:   service.GetTaskQueue(),
+			Metadata:    service.GetMetadata(),
+		},
+	}, nil
 }
 
-// ListNexusIncomingServices implements OperatorHandler.
-func (*OperatorHandlerImpl) ListNexusIncomingServices(context.Context, *operatorservice.ListNexusIncomingServicesRequest) (*operatorservice.ListNexusIncomingServicesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListNexusIncomingServices not implemented")
+func (h *OperatorHandlerImpl) nexusIncomingServiceEntryToService(
+	entry *persistencespb.NexusIncomingServiceEntry,
+) (*nexuspb.IncomingService, error) {
+	namespaceName, err := h.namespaceRegistry.GetNamespaceName(namespace.ID(entry.GetService().GetNamespaceId()))
+	if err != nil {
+		var nsNotFound *serviceerror.NamespaceNotFound
+		if !errors.As(err, &nsNotFound) {
+			return nil, err
+		}
+		// The target namespace was deleted after the service was created. Still return the service so that it can be
+		// inspected and cleaned up.
+		h.logger.Warn("Nexus incoming service targets unknown namespace",
+			tag.WorkflowNamespaceID(entry.GetService().GetNamespaceId()),
+			tag.Name(entry.GetName()),
+		)
+	}
+
+	return &nexuspb.IncomingService{
+		Version:   entry.GetVersion(),
+		Name:      entry.GetName(),
+		Namespace: namespaceName.String(),
+		TaskQueue: entry.GetService().GetTaskQueue(),
+		Metadata:  entry.GetService().GetMetadata(),
+	}, nil
+}
+
+// convertNexusIncomingServiceError converts persistence version conflicts into FailedPrecondition errors that callers
+// can act upon.
+func convertNexusIncomingServiceError(err error) error {
+	var conditionFailedErr *persistence.ConditionFailedError
+	if errors.As(err, &conditionFailedErr) {
+		return serviceerror.NewFailedPrecondition(conditionFailedErr.Msg)
+	}
+	return err
 }
@@ -37,6 +37,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	enumspb "go.temporal.io/api/enums/v1"
 	namespacepb "go.temporal.io/api/namespace/v1"
+	nexuspb "go.temporal.io/api/nexus/v1"
 	"go.temporal.io/api/operatorservice/v1"
 	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/api/workflowservice/v1"
@@ -48,11 +49,13 @@ import (
 	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/sql/sqlplugin/mysql"
 	"go.temporal.io/server/common/persistence/visibility"
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch"
+	"go.temporal.io/server/common/persistence/visibility/store/embedded"
 	"go.temporal.io/server/common/primitives"
 	"go.temporal.io/server/common/resourcetest"
 	"go.temporal.io/server/common/searchattribute"
@@ -71,8 +74,9 @@ type (
 		suite.Suite
 		*require.Assertions
 
-		controller   *gomock.Controller
-		mockResource *resourcetest.Test
+		controller                      *gomock.Controller
+		mockResource                    *resourcetest.Test
+		mockNexusIncomingServiceManager *persistence.MockNexusIncomingServiceManager
 
 		handler *OperatorHandlerImpl
 	}
@@ -89,9 +93,17 @@ func (s *operatorHandlerSuite) SetupTest() {
 	s.controller = gomock.NewController(s.T())
 	s.mockResource = resourcetest.NewTest(s.controller, primitives.FrontendService)
 	s.mockResource.ClusterMetadata.EXPECT().GetCurrentClusterName().Return(uuid.New()).AnyTimes()
+	s.mockNexusIncomingServiceManager = persistence.NewMockNexusIncomingServiceManager(s.controller)
 
 	args := NewOperatorHandlerImplArgs{
-		&Config{NumHistoryShards: 4},
+		&Config{
+			NumHistoryShards:                        4,
+			MaxIDLengthLimit:                        dynamicconfig.GetIntPropertyFn(1000),
+			NexusIncomingServiceNameMaxLength:       dynamicconfig.GetIntPropertyFn(20),
+			NexusIncomingServiceMaxSize:             dynamicconfig.GetIntPropertyFn(1024),
+			NexusIncomingServiceListDefaultPageSize: dynamicconfig.GetIntPropertyFn(10),
+			NexusIncomingServiceListMaxPageSize:     dynamicconfig.GetIntPropertyFn(100),
+		},
 		s.mockResource.ESClient,
 		s.mockResource.Logger,
 		s.mockResource.GetSDKClientFactory(),
@@ -103,6 +115,9 @@ func (s *operatorHandlerSuite) SetupTest() {
 		s.mockResource.GetClusterMetadataManager(),
 		s.mockResource.GetClusterMetadata(),
 		s.mockResource.GetClientFactory(),
+		s.mockResource.GetNamespaceRegistry(),
+		s.mockNexusIncomingServiceManager,
+		nil,
 	}
 	s.handler = NewOperatorHandlerImpl(args)
 	s.handler.Start()
@@ -642,6 +657,47 @@ func (s *operatorHandlerSuite) Test_AddSearchAttributesElasticsearch() {
 	}
 }
 
+func (s *operatorHandlerSuite) Test_AddSearchAttributesElasticsearch_NamespaceIndices() {
+	ctx := context.Background()
+	s.handler.visibilityNamespaceIndices = map[string][]string{
+		testIndexName: {"test-index-ns1", "test-index-ns2"},
+	}
+	defer func() { s.handler.visibilityNamespaceIndices = nil }()
+
+	mockSdkClient := mocksdk.NewMockClient(s.controller)
+	s.mockResource.SDKClientFactory.EXPECT().GetSystemClient().Return(mockSdkClient)
+	mockWfRun := mocksdk.NewMockWorkflowRun(s.controller)
+	mockSdkClient.EXPECT().ExecuteWorkflow(
+		gomock.Any(),
+		sdkclient.StartWorkflowOptions{
+			TaskQueue: primitives.DefaultWorkerTaskQueue,
+			ID:        addsearchattributes.WorkflowName,
+		},
+		addsearchattributes.WorkflowName,
+		addsearchattributes.WorkflowParams{
+			CustomAttributesToAdd: map[string]enumspb.IndexedValueType{
+				"CustomAttr": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+			},
+			IndexName:           testIndexName,
+			NamespaceIndexNames: []string{"test-index-ns1", "test-index-ns2"},
+			SkipSchemaUpdate:    false,
+		},
+	).Return(mockWfRun, nil)
+	mockWfRun.EXPECT().Get(gomock.Any(), nil).Return(nil)
+
+	err := s.handler.addSearchAttributesElasticsearch(
+		ctx,
+		&operatorservice.AddSearchAttributesRequest{
+			SearchAttributes: map[string]enumspb.IndexedValueType{
+				"CustomAttr": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+			},
+		},
+		testIndexName,
+		searchattribute.TestNameTypeMap,
+	)
+	s.NoError(err)
+}
+
 func (s *operatorHandlerSuite) Test_AddSearchAttributesSQL() {
 	ctx := context.Background()
 	testCases := []struct {
@@ -872,6 +928,7 @@ func (s *operatorHandlerSuite) Test_ListSearchAttributes_SQL() {
 	ctx := context.Background()
 
 	s.mockResource.VisibilityManager.EXPECT().HasStoreName(elasticsearch.PersistenceName).Return(false)
+	s.mockResource.VisibilityManager.EXPECT().HasStoreName(embedded.PersistenceName).Return(false)
 	s.mockResource.VisibilityManager.EXPECT().GetIndexName().Return(testIndexName).AnyTimes()
 	s.mockResource.ClientFactory.EXPECT().
 		NewLocalFrontendClientWithTimeout(gomock.Any(), gomock.Any()).
@@ -1028,6 +1085,7 @@ func (s *operatorHandlerSuite) Test_RemoveSearchAttributes_SQL() {
 	ctx := context.Background()
 
 	s.mockResource.VisibilityManager.EXPECT().HasStoreName(elasticsearch.PersistenceName).Return(false).AnyTimes()
+	s.mockResource.VisibilityManager.EXPECT().HasStoreName(embedded.PersistenceName).Return(false).AnyTimes()
 	s.mockResource.VisibilityManager.EXPECT().GetIndexName().Return(testIndexName).AnyTimes()
 	s.mockResource.SearchAttributesManager.EXPECT().
 		GetSearchAttributes(testIndexName, true).
@@ -1590,3 +1648,195 @@ func (s *operatorHandlerSuite) Test_AddOrUpdateRemoteCluster_SaveClusterMetadata
 	s.Error(err)
 	s.IsType(&serviceerror.InvalidArgument{}, err)
 }
+
+func (s *operatorHandlerSuite) Test_CreateOrUpdateNexusIncomingService_Create() {
+	nsID := namespace.ID(uuid.New())
+	s.mockResource.NamespaceCache.EXPECT().GetNamespaceID(namespace.Name(testNamespace)).Return(nsID, nil)
+	s.mockNexusIncomingServiceManager.EXPECT().ListNexusIncomingServices(gomock.Any(), &persistence.ListNexusIncomingServicesRequest{
+		PageSize: 1,
+	}).Return(&persistence.ListNexusIncomingServicesResponse{TableVersion: 5}, nil)
+	s.mockNexusIncomingServiceManager.EXPECT().CreateOrUpdateNexusIncomingService(gomock.Any(), &persistence.CreateOrUpdateNexusIncomingServiceRequest{
+		LastKnownTableVersion: 5,
+		Entry: &persistencespb.NexusIncomingServiceEntry{
+			Version: 0,
+			Name:    "my-service",
+			Service: &persistencespb.NexusIncomingService{
+				NamespaceId: nsID.String(),
+				TaskQueue:   "my-task-queue",
+			},
+		},
+	}).Return(&persistence.CreateOrUpdateNexusIncomingServiceResponse{Version: 1}, nil)
+
+	resp, err := s.handler.CreateOrUpdateNexusIncomingService(context.Background(), &operatorservice.CreateOrUpdateNexusIncomingServiceRequest{
+		Service: &nexuspb.IncomingService{
+			Name:      "my-service",
+			Namespace: testNamespace,
+			TaskQueue: "my-task-queue",
+		},
+	})
+	s.NoError(err)
+	s.Equal(int64(1), resp.GetService().GetVersion())
+	s.Equal("my-service", resp.GetService().GetName())
+	s.Equal(testNamespace, resp.GetService().GetNamespace())
+}
+
+func (s *operatorHandlerSuite) Test_CreateOrUpdateNexusIncomingService_Validation() {
+	testCases := []struct {
+		name    string
+		service *nexuspb.IncomingService
+		err     error
+	}{
+		{
+			name: "service not set",
+			err:  errNexusIncomingServiceNotSet,
+		},
+		{
+			name:    "name not set",
+			service: &nexuspb.IncomingService{Namespace: testNamespace, TaskQueue: "tq"},
+			err:     errNexusIncomingServiceNameNotSet,
+		},
+		{
+			name:    "name too long",
+			service: &nexuspb.IncomingService{Name: "a-very-long-service-name", Namespace: testNamespace, TaskQueue: "tq"},
+			err:     errNexusIncomingServiceNameTooLong,
+		},
+		{
+			name:    "negative version",
+			service: &nexuspb.IncomingService{Version: -1, Name: "svc", Namespace: testNamespace, TaskQueue: "tq"},
+			err:     errNexusIncomingServiceInvalidVersion,
+		},
+		{
+			name:    "namespace not set",
+			service: &nexuspb.IncomingService{Name: "svc", TaskQueue: "tq"},
+			err:     errNexusIncomingServiceNamespaceNotSet,
+		},
+		{
+			name:    "task queue not set",
+			service: &nexuspb.IncomingService{Name: "svc", Namespace: testNamespace},
+			err:     errTaskQueueNotSet,
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := s.handler.CreateOrUpdateNexusIncomingService(context.Background(), &operatorservice.CreateOrUpdateNexusIncomingServiceRequest{
+				Service: tc.service,
+			})
+			s.Equal(tc.err, err)
+		})
+	}
+}
+
+func (s *operatorHandlerSuite) Test_CreateOrUpdateNexusIncomingService_NamespaceNotFound() {
+	s.mockResource.NamespaceCache.EXPECT().GetNamespaceID(namespace.Name(testNamespace)).Return(namespace.EmptyID, serviceerror.NewNamespaceNotFound(testNamespace))
+
+	_, err := s.handler.CreateOrUpdateNexusIncomingService(context.Background(), &operatorservice.CreateOrUpdateNexusIncomingServiceRequest{
+		Service: &nexuspb.IncomingService{
+			Name:      "my-service",
+			Namespace: testNamespace,
+			TaskQueue: "my-task-queue",
+		},
+	})
+	var invalidArgErr *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgErr)
+}
+
+func (s *operatorHandlerSuite) Test_CreateOrUpdateNexusIncomingService_VersionConflict() {
+	s.mockResource.NamespaceCache.EXPECT().GetNamespaceID(namespace.Name(testNamespace)).Return(namespace.ID(uuid.New()), nil)
+	s.mockNexusIncomingServiceManager.EXPECT().ListNexusIncomingServices(gomock.Any(), gomock.Any()).Return(
+		&persistence.ListNexusIncomingServicesResponse{TableVersion: 5}, nil,
+	)
+	s.mockNexusIncomingServiceManager.EXPECT().CreateOrUpdateNexusIncomingService(gomock.Any(), gomock.Any()).Return(
+		nil, persistence.ErrNexusIncomingServiceVersionConflict,
+	)
+
+	_, err := s.handler.CreateOrUpdateNexusIncomingService(context.Background(), &operatorservice.CreateOrUpdateNexusIncomingServiceRequest{
+		Service: &nexuspb.IncomingService{
+			Version:   3,
+			Name:      "my-service",
+			Namespace: testNamespace,
+			TaskQueue: "my-task-queue",
+		},
+	})
+	var failedPreconditionErr *serviceerror.FailedPrecondition
+	s.ErrorAs(err, &failedPreconditionErr)
+}
+
+func (s *operatorHandlerSuite) Test_GetNexusIncomingService() {
+	nsID := namespace.ID(uuid.New())
+	s.mockNexusIncomingServiceManager.EXPECT().GetNexusIncomingService(gomock.Any(), &persistence.GetNexusIncomingServiceRequest{
+		Name: "my-service",
+	}).Return(&persistencespb.NexusIncomingServiceEntry{
+		Version: 2,
+		Name:    "my-service",
+		Service: &persistencespb.NexusIncomingService{
+			NamespaceId: nsID.String(),
+			TaskQueue:   "my-task-queue",
+		},
+	}, nil)
+	s.mockResource.NamespaceCache.EXPECT().GetNamespaceName(nsID).Return(namespace.Name(testNamespace), nil)
+
+	resp, err := s.handler.GetNexusIncomingService(context.Background(), &operatorservice.GetNexusIncomingServiceRequest{
+		Name: "my-service",
+	})
+	s.NoError(err)
+	s.Equal(&nexuspb.IncomingService{
+		Version:   2,
+		Name:      "my-service",
+		Namespace: testNamespace,
+		TaskQueue: "my-task-queue",
+	}, resp.GetService())
+}
+
+func (s *operatorHandlerSuite) Test_DeleteNexusIncomingService_NotFound() {
+	s.mockNexusIncomingServiceManager.EXPECT().ListNexusIncomingServices(gomock.Any(), &persistence.ListNexusIncomingServicesRequest{
+		PageSize: 1,
+	}).Return(&persistence.ListNexusIncomingServicesResponse{TableVersion: 5}, nil)
+	s.mockNexusIncomingServiceManager.EXPECT().DeleteNexusIncomingService(gomock.Any(), &persistence.DeleteNexusIncomingServiceRequest{
+		LastKnownTableVersion: 5,
+		Name:                  "my-service",
+	}).Return(serviceerror.NewNotFound("not found"))
+
+	_, err := s.handler.DeleteNexusIncomingService(context.Background(), &operatorservice.DeleteNexusIncomingServiceRequest{
+		Name: "my-service",
+	})
+	var notFoundErr *serviceerror.NotFound
+	s.ErrorAs(err, &notFoundErr)
+}
+
+func (s *operatorHandlerSuite) Test_ListNexusIncomingServices_Pagination() {
+	nsID := namespace.ID(uuid.New())
+	s.mockResource.NamespaceCache.EXPECT().GetNamespaceName(nsID).Return(namespace.Name(testNamespace), nil).AnyTimes()
+
+	s.mockNexusIncomingServiceManager.EXPECT().ListNexusIncomingServices(gomock.Any(), &persistence.ListNexusIncomingServicesRequest{
+		PageSize: 10,
+	}).Return(&persistence.ListNexusIncomingServicesResponse{
+		TableVersion:  5,
+		NextPageToken: []byte("store-token"),
+		Entries: []*persistencespb.NexusIncomingServiceEntry{
+			{Version: 1, Name: "a", Service: &persistencespb.NexusIncomingService{NamespaceId: nsID.String(), TaskQueue: "tq"}},
+		},
+	}, nil)
+	resp, err := s.handler.ListNexusIncomingServices(context.Background(), &operatorservice.ListNexusIncomingServicesRequest{})
+	s.NoError(err)
+	s.Len(resp.GetServices(), 1)
+	s.NotEmpty(resp.GetNextPageToken())
+
+	s.mockNexusIncomingServiceManager.EXPECT().ListNexusIncomingServices(gomock.Any(), &persistence.ListNexusIncomingServicesRequest{
+		LastKnownTableVersion: 5,
+		NextPageToken:         []byte("store-token"),
+		PageSize:              10,
+	}).Return(nil, persistence.ErrNexusTableVersionConflict)
+	_, err = s.handler.ListNexusIncomingServices(context.Background(), &operatorservice.ListNexusIncomingServicesRequest{
+		NextPageToken: resp.GetNextPageToken(),
+	})
+	var failedPreconditionErr *serviceerror.FailedPrecondition
+	s.ErrorAs(err, &failedPreconditionErr)
+}
+
+func (s *operatorHandlerSuite) Test_ListNexusIncomingServices_PageSizeTooLarge() {
+	_, err := s.handler.ListNexusIncomingServices(context.Background(), &operatorservice.ListNexusIncomingServicesRequest{
+		PageSize: 101,
+	})
+	var invalidArgErr *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgErr)
+}
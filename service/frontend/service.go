@@ -93,6 +93,10 @@ type Config struct {
 	ReachabilityTaskQueueScanLimit                               dynamicconfig.IntPropertyFn
 	ReachabilityQueryBuildIdLimit                                dynamicconfig.IntPropertyFn
 	ReachabilityQuerySetDurationSinceDefault                     dynamicconfig.DurationPropertyFn
+	NexusIncomingServiceNameMaxLength                            dynamicconfig.IntPropertyFn
+	NexusIncomingServiceMaxSize                                  dynamicconfig.IntPropertyFn
+	NexusIncomingServiceListDefaultPageSize                      dynamicconfig.IntPropertyFn
+	NexusIncomingServiceListMaxPageSize                          dynamicconfig.IntPropertyFn
 	DisallowQuery                                                dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	ShutdownDrainDuration                                        dynamicconfig.DurationPropertyFn
 	ShutdownFailHealthCheckDuration                              dynamicconfig.DurationPropertyFn
@@ -189,6 +193,9 @@ type Config struct {
 	AdminDeleteAccessHistoryFraction dynamicconfig.FloatPropertyFn
 
 	AdminEnableListHistoryTasks dynamicconfig.BoolPropertyFn
+
+	// MaxConcurrentVisibilityWatches is the max number of concurrent visibility watches per host
+	MaxConcurrentVisibilityWatches dynamicconfig.IntPropertyFn
 }
 
 // NewConfig returns new service config with default values
@@ -240,6 +247,10 @@ func NewConfig(
 		ReachabilityTaskQueueScanLimit:           dc.GetIntProperty(dynamicconfig.ReachabilityTaskQueueScanLimit, 20),
 		ReachabilityQueryBuildIdLimit:            dc.GetIntProperty(dynamicconfig.ReachabilityQueryBuildIdLimit, 5),
 		ReachabilityQuerySetDurationSinceDefault: dc.GetDurationProperty(dynamicconfig.ReachabilityQuerySetDurationSinceDefault, 5*time.Minute),
+		NexusIncomingServiceNameMaxLength:        dc.GetIntProperty(dynamicconfig.NexusIncomingServiceNameMaxLength, 200),
+		NexusIncomingServiceMaxSize:              dc.GetIntProperty(dynamicconfig.NexusIncomingServiceMaxSize, 4*1024),
+		NexusIncomingServiceListDefaultPageSize:  dc.GetIntProperty(dynamicconfig.NexusIncomingServiceListDefaultPageSize, 100),
+		NexusIncomingServiceListMaxPageSize:      dc.GetIntProperty(dynamicconfig.NexusIncomingServiceListMaxPageSize, 1000),
 		MaxBadBinaries:                           dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxBadBinaries, namespace.MaxBadBinaries),
 		DisableListVisibilityByFilter:            dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.DisableListVisibilityByFilter, false),
 		BlobSizeLimitError:                       dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
@@ -288,6 +299,8 @@ func NewConfig(
 		AdminDeleteAccessHistoryFraction: dc.GetFloat64Property(dynamicconfig.FrontendAdminDeleteAccessHistoryFraction, 0.0),
 
 		AdminEnableListHistoryTasks: dc.GetBoolProperty(dynamicconfig.AdminEnableListHistoryTasks, true),
+
+		MaxConcurrentVisibilityWatches: dc.GetIntProperty(dynamicconfig.FrontendMaxConcurrentVisibilityWatches, 100),
 	}
 }
 
@@ -0,0 +1,291 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	archiverspb "go.temporal.io/server/api/archiver/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver/visibilityquery"
+	"go.temporal.io/server/common/backoff"
+	"go.temporal.io/server/common/convert"
+	"go.temporal.io/server/common/membership"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/searchattribute"
+	serviceerrors "go.temporal.io/server/common/serviceerror"
+)
+
+type (
+	// visibilityWatchToken is the resume token of WatchWorkflowExecutions.
+	visibilityWatchToken struct {
+		// LastTaskIDs are the positions of the history streams of the shards.
+		LastTaskIDs map[int32]int64 `json:"lastTaskIds"`
+	}
+
+	// visibilityWatcher streams the visibility changes of a namespace from the history streams
+	// of all history hosts to a WatchWorkflowExecutions stream.
+	visibilityWatcher struct {
+		numberOfHistoryShards int32
+		historyClient         historyservice.HistoryServiceClient
+		historyResolver       membership.ServiceResolver
+		saMapperProvider      searchattribute.MapperProvider
+		saTypeMap             searchattribute.NameTypeMap
+
+		namespaceID   namespace.ID
+		namespaceName namespace.Name
+		query         *visibilityquery.Query
+		token         *visibilityWatchToken
+	}
+
+	visibilityWatchShardResponse struct {
+		shardID  int32
+		response *historyservice.StreamVisibilityChangesResponse
+	}
+)
+
+var visibilityWatchRetryPolicy = backoff.NewExponentialRetryPolicy(time.Second).
+	WithMaximumInterval(time.Minute).
+	WithExpirationInterval(backoff.NoInterval)
+
+func isVisibilityWatchRetryable(err error) bool {
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	switch err.(type) {
+	case *serviceerror.Unavailable,
+		*serviceerror.ResourceExhausted,
+		*serviceerrors.ShardOwnershipLost:
+		return true
+	}
+	return false
+}
+
+func deserializeVisibilityWatchToken(data []byte) (*visibilityWatchToken, error) {
+	token := &visibilityWatchToken{}
+	if len(data) != 0 {
+		if err := json.Unmarshal(data, token); err != nil {
+			return nil, err
+		}
+	}
+	if token.LastTaskIDs == nil {
+		token.LastTaskIDs = make(map[int32]int64)
+	}
+	return token, nil
+}
+
+func (t *visibilityWatchToken) serialize() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+func (w *visibilityWatcher) run(
+	server adminservice.AdminService_WatchWorkflowExecutionsServer,
+) error {
+	retrier := backoff.NewRetrier(visibilityWatchRetryPolicy, backoff.SystemClock)
+	for {
+		err := w.watch(server, retrier)
+		if server.Context().Err() != nil {
+			return nil
+		}
+		if !isVisibilityWatchRetryable(err) {
+			return err
+		}
+		timer := time.NewTimer(retrier.NextBackOff())
+		select {
+		case <-timer.C:
+		case <-server.Context().Done():
+			timer.Stop()
+			return nil
+		}
+	}
+}
+
+// watch opens one history stream per history host for the shards owned by the host, and
+// returns when any of the streams ends, e.g. when a shard moves to another host. The streams
+// are then reopened from the positions of the last sent responses.
+func (w *visibilityWatcher) watch(
+	server adminservice.AdminService_WatchWorkflowExecutionsServer,
+	retrier backoff.Retrier,
+) error {
+	requests, err := w.requestsByHost()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(server.Context())
+	defer cancel()
+
+	responseC := make(chan visibilityWatchShardResponse)
+	errC := make(chan error, len(requests))
+	for _, request := range requests {
+		go func(request *historyservice.StreamVisibilityChangesRequest) {
+			errC <- w.receive(ctx, request, responseC)
+		}(request)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errC:
+			return err
+		case shardResponse := <-responseC:
+			retrier.Reset()
+			if err := w.send(server, shardResponse); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// requestsByHost groups the shards by the history host which owns them.
+func (w *visibilityWatcher) requestsByHost() (map[string]*historyservice.StreamVisibilityChangesRequest, error) {
+	requests := make(map[string]*historyservice.StreamVisibilityChangesRequest)
+	for shardID := int32(1); shardID <= w.numberOfHistoryShards; shardID++ {
+		host, err := w.historyResolver.Lookup(convert.Int32ToString(shardID))
+		if err != nil {
+			return nil, err
+		}
+		request, ok := requests[host.GetAddress()]
+		if !ok {
+			request = &historyservice.StreamVisibilityChangesRequest{NamespaceId: w.namespaceID.String()}
+			requests[host.GetAddress()] = request
+		}
+		request.Shards = append(request.Shards, &historyservice.StreamVisibilityChangesRequest_Shard{
+			ShardId:    shardID,
+			LastTaskId: w.token.LastTaskIDs[shardID],
+		})
+	}
+	return requests, nil
+}
+
+func (w *visibilityWatcher) receive(
+	ctx context.Context,
+	request *historyservice.StreamVisibilityChangesRequest,
+	responseC chan<- visibilityWatchShardResponse,
+) error {
+	stream, err := w.historyClient.StreamVisibilityChanges(ctx, request)
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		select {
+		case responseC <- visibilityWatchShardResponse{shardID: response.GetShardId(), response: response}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *visibilityWatcher) send(
+	server adminservice.AdminService_WatchWorkflowExecutionsServer,
+	shardResponse visibilityWatchShardResponse,
+) error {
+	// The position of the shard only moves past the changes of the response once all of them
+	// are sent, so the token sent with a change resumes from before the response, and changes
+	// may be sent again after resuming.
+	for _, change := range shardResponse.response.GetChanges() {
+		match, err := w.match(change.GetExecution())
+		if err != nil {
+			return err
+		}
+		if !match {
+			continue
+		}
+		execution, err := w.alias(change.GetExecution())
+		if err != nil {
+			return err
+		}
+		resumeToken, err := w.token.serialize()
+		if err != nil {
+			return err
+		}
+		if err := server.Send(&adminservice.WatchWorkflowExecutionsResponse{
+			Execution:   execution,
+			ResumeToken: resumeToken,
+		}); err != nil {
+			return err
+		}
+	}
+	if lastTaskID := shardResponse.response.GetLastTaskId(); lastTaskID != 0 {
+		w.token.LastTaskIDs[shardResponse.shardID] = lastTaskID
+	}
+	return nil
+}
+
+func (w *visibilityWatcher) match(
+	execution *workflowpb.WorkflowExecutionInfo,
+) (bool, error) {
+	searchAttributes, err := searchattribute.Stringify(execution.GetSearchAttributes(), &w.saTypeMap)
+	if err != nil {
+		return false, err
+	}
+	return w.query.Match(&archiverspb.VisibilityRecord{
+		NamespaceId:      w.namespaceID.String(),
+		Namespace:        w.namespaceName.String(),
+		WorkflowId:       execution.GetExecution().GetWorkflowId(),
+		RunId:            execution.GetExecution().GetRunId(),
+		WorkflowTypeName: execution.GetType().GetName(),
+		StartTime:        execution.GetStartTime(),
+		ExecutionTime:    execution.GetExecutionTime(),
+		CloseTime:        execution.GetCloseTime(),
+		Status:           execution.GetStatus(),
+		HistoryLength:    execution.GetHistoryLength(),
+		Memo:             execution.GetMemo(),
+		SearchAttributes: searchAttributes,
+	}), nil
+}
+
+func (w *visibilityWatcher) alias(
+	execution *workflowpb.WorkflowExecutionInfo,
+) (*workflowpb.WorkflowExecutionInfo, error) {
+	aliasedSearchAttributes, err := searchattribute.AliasFields(
+		w.saMapperProvider,
+		execution.GetSearchAttributes(),
+		w.namespaceName.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if aliasedSearchAttributes == execution.GetSearchAttributes() {
+		return execution, nil
+	}
+	execution = common.CloneProto(execution)
+	execution.SearchAttributes = aliasedSearchAttributes
+	return execution, nil
+}
@@ -961,6 +961,8 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeatById(ctx context.Context,
 		namespaceID.String(),
 		workflowID,
 		runID,
+		"",
+		"",
 		common.EmptyEventID,
 		activityID,
 		"",
@@ -1128,6 +1130,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedById(ctx context.Context,
 		namespaceID.String(),
 		workflowID,
 		runID,
+		"",
+		"",
 		common.EmptyEventID,
 		activityID,
 		"",
@@ -1312,6 +1316,8 @@ func (wh *WorkflowHandler) RespondActivityTaskFailedById(ctx context.Context, re
 		namespaceID.String(),
 		workflowID,
 		runID,
+		"",
+		"",
 		common.EmptyEventID,
 		activityID,
 		"",
@@ -1488,6 +1494,8 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledById(ctx context.Context,
 		namespaceID.String(),
 		workflowID,
 		runID,
+		"",
+		"",
 		common.EmptyEventID,
 		activityID,
 		"",
@@ -2182,7 +2190,14 @@ func (wh *WorkflowHandler) CountWorkflowExecutions(ctx context.Context, request
 
 	resp := &workflowservice.CountWorkflowExecutionsResponse{
 		Count:  persistenceResp.Count,
-		Groups: persistenceResp.Groups,
+		Groups: make([]*workflowservice.CountWorkflowExecutionsResponse_AggregationGroup, 0, len(persistenceResp.Groups)),
+	}
+	for _, group := range persistenceResp.Groups {
+		groupProto, err := group.ToProto()
+		if err != nil {
+			return nil, serviceerror.NewInternal(err.Error())
+		}
+		resp.Groups = append(resp.Groups, groupProto)
 	}
 	return resp, nil
 }
@@ -460,6 +460,8 @@ func (wh *WorkflowHandler) respondWorkflowTaskCompleted(
 			taskToken.GetNamespaceId(),
 			taskToken.GetWorkflowId(),
 			taskToken.GetRunId(),
+			histResp.StartedResponse.GetWorkflowType().GetName(),
+			histResp.StartedResponse.GetWorkflowExecutionTaskQueue().GetName(),
 			histResp.StartedResponse.GetScheduledEventId(),
 			histResp.StartedResponse.GetStartedEventId(),
 			histResp.StartedResponse.GetStartedTime(),
@@ -49,6 +49,7 @@ import (
 	"go.temporal.io/api/workflowservice/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -1634,6 +1635,47 @@ func (s *workflowHandlerSuite) TestCountWorkflowExecutions() {
 	s.Equal(int64(5), resp.Count)
 }
 
+func (s *workflowHandlerSuite) TestCountWorkflowExecutions_AggregationValues() {
+	wh := s.getWorkflowHandler(s.newConfig())
+
+	statusPayload := payload.EncodeString(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING.String())
+	maxPayload := payload.EncodeString("2023-11-14T05:20:00Z")
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(gomock.Any()).Return(s.testNamespaceID, nil).AnyTimes()
+	s.mockVisibilityMgr.EXPECT().CountWorkflowExecutions(gomock.Any(), gomock.Any()).Return(
+		&manager.CountWorkflowExecutionsResponse{
+			Count: 5,
+			Groups: []*manager.AggregationGroup{
+				{
+					GroupValues:       []*commonpb.Payload{statusPayload},
+					AggregationValues: []*commonpb.Payload{maxPayload},
+					Count:             5,
+				},
+			},
+		},
+		nil,
+	)
+
+	resp, err := wh.CountWorkflowExecutions(context.Background(), &workflowservice.CountWorkflowExecutionsRequest{
+		Namespace: s.testNamespace.String(),
+		Query:     "SELECT MAX(StartTime) GROUP BY ExecutionStatus",
+	})
+	s.NoError(err)
+	s.Equal(int64(5), resp.Count)
+	s.Len(resp.Groups, 1)
+	s.True(proto.Equal(statusPayload, resp.Groups[0].GroupValues[0]))
+	s.Len(resp.Groups[0].GroupValues, 1)
+
+	// Aggregation values survive the wire round trip as the aggregation_values field.
+	data, err := resp.Marshal()
+	s.NoError(err)
+	var decoded workflowservice.CountWorkflowExecutionsResponse
+	s.NoError(decoded.Unmarshal(data))
+	aggregationValues, err := manager.GetAggregationValues(decoded.Groups[0])
+	s.NoError(err)
+	s.Len(aggregationValues, 1)
+	s.True(proto.Equal(maxPayload, aggregationValues[0]))
+}
+
 func (s *workflowHandlerSuite) TestVerifyHistoryIsComplete() {
 	wh := s.getWorkflowHandler(s.newConfig())
 
@@ -108,6 +108,7 @@ func Invoke(
 	// record again if this happens.
 	if err := persistenceVisibilityMgr.DeleteWorkflowExecution(ctx, &manager.VisibilityDeleteWorkflowExecutionRequest{
 		NamespaceID: namespace.ID(request.GetNamespaceId()),
+		Namespace:   namespace.Name(req.GetNamespace()),
 		WorkflowID:  execution.GetWorkflowId(),
 		RunID:       execution.GetRunId(),
 		TaskID:      math.MaxInt64,
@@ -95,6 +95,7 @@ func Invoke(
 			if !isRunning ||
 				ai.StartedEventId == common.EmptyEventID ||
 				(token.GetScheduledEventId() != common.EmptyEventID && token.Attempt != ai.Attempt) ||
+				!api.TaskTokenMatchesWorkflow(token, mutableState, ai.TaskQueue) ||
 				(token.GetVersion() != common.EmptyVersion && token.Version != ai.Version) {
 				return nil, consts.ErrActivityTaskNotFound
 			}
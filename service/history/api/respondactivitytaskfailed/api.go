@@ -103,6 +103,7 @@ func Invoke(
 			if !activityRunning ||
 				ai.StartedEventId == common.EmptyEventID ||
 				(token.GetScheduledEventId() != common.EmptyEventID && token.Attempt != ai.Attempt) ||
+				!api.TaskTokenMatchesWorkflow(token, mutableState, ai.TaskQueue) ||
 				(token.GetVersion() != common.EmptyVersion && token.Version != ai.Version) {
 				return nil, consts.ErrActivityTaskNotFound
 			}
@@ -558,6 +558,8 @@ func (s *Starter) generateResponse(
 		s.namespace.ID().String(),
 		workflowID,
 		runID,
+		request.GetWorkflowType().GetName(),
+		request.GetTaskQueue().GetName(),
 		workflowTaskInfo.ScheduledEventID,
 		workflowTaskInfo.StartedEventID,
 		timestamppb.New(workflowTaskInfo.StartedTime),
@@ -29,8 +29,24 @@ import (
 	"go.temporal.io/server/api/historyservice/v1"
 	tokenspb "go.temporal.io/server/api/token/v1"
 	"go.temporal.io/server/service/history/consts"
+	"go.temporal.io/server/service/history/workflow"
 )
 
+// TaskTokenMatchesWorkflow checks the workflow type and task queue recorded in a task token against the
+// workflow the token is used on. Calls made with a task token are authorized by these fields, so a token
+// naming another workflow type or task queue than the persisted ones must be treated as not found.
+// Tokens that don't record them, such as the ones of the *ById APIs, always match.
+func TaskTokenMatchesWorkflow(
+	token *tokenspb.Task,
+	mutableState workflow.MutableState,
+	taskQueue string,
+) bool {
+	if token.GetWorkflowType() != "" && token.GetWorkflowType() != mutableState.GetExecutionInfo().WorkflowTypeName {
+		return false
+	}
+	return token.GetTaskQueue() == "" || token.GetTaskQueue() == taskQueue
+}
+
 // NOTE: DO NOT MODIFY UNLESS ALSO APPLIED TO ./service/frontend/token_deprecated.go
 func GeneratePaginationTokenV2Request(
 	request *historyservice.GetWorkflowExecutionRawHistoryV2Request,
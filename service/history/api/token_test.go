@@ -0,0 +1,53 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package api_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	tokenspb "go.temporal.io/server/api/token/v1"
+	"go.temporal.io/server/service/history/api"
+	"go.temporal.io/server/service/history/workflow"
+)
+
+func TestTaskTokenMatchesWorkflow(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	mutableState := workflow.NewMockMutableState(ctrl)
+	mutableState.EXPECT().GetExecutionInfo().Return(&persistencespb.WorkflowExecutionInfo{
+		WorkflowTypeName: "Refund",
+		TaskQueue:        "payments",
+	}).AnyTimes()
+
+	assert.True(t, api.TaskTokenMatchesWorkflow(&tokenspb.Task{}, mutableState, "payments"))
+	assert.True(t, api.TaskTokenMatchesWorkflow(&tokenspb.Task{WorkflowType: "Refund", TaskQueue: "payments"}, mutableState, "payments"))
+	assert.False(t, api.TaskTokenMatchesWorkflow(&tokenspb.Task{WorkflowType: "Payout"}, mutableState, "payments"))
+	assert.False(t, api.TaskTokenMatchesWorkflow(&tokenspb.Task{TaskQueue: "payouts"}, mutableState, "payments"))
+}
@@ -282,6 +282,9 @@ type Config struct {
 	VisibilityProcessorEnsureCloseBeforeDelete            dynamicconfig.BoolPropertyFn
 	VisibilityProcessorEnableCloseWorkflowCleanup         dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	VisibilityQueueMaxReaderCount                         dynamicconfig.IntPropertyFn
+	VisibilityWatchEnabled                                dynamicconfig.BoolPropertyFn
+	VisibilityWatchBufferSize                             dynamicconfig.IntPropertyFn
+	VisibilityRetentionScannerEnabled                     dynamicconfig.BoolPropertyFn
 
 	SearchAttributesNumberOfKeysLimit dynamicconfig.IntPropertyFnWithNamespaceFilter
 	SearchAttributesSizeOfValueLimit  dynamicconfig.IntPropertyFnWithNamespaceFilter
@@ -535,6 +538,9 @@ func NewConfig(
 		VisibilityProcessorEnsureCloseBeforeDelete:            dc.GetBoolProperty(dynamicconfig.VisibilityProcessorEnsureCloseBeforeDelete, false),
 		VisibilityProcessorEnableCloseWorkflowCleanup:         dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.VisibilityProcessorEnableCloseWorkflowCleanup, false),
 		VisibilityQueueMaxReaderCount:                         dc.GetIntProperty(dynamicconfig.VisibilityQueueMaxReaderCount, 2),
+		VisibilityWatchEnabled:                                dc.GetBoolProperty(dynamicconfig.VisibilityWatchEnabled, false),
+		VisibilityWatchBufferSize:                             dc.GetIntProperty(dynamicconfig.VisibilityWatchBufferSize, 1000),
+		VisibilityRetentionScannerEnabled:                     dc.GetBoolProperty(dynamicconfig.VisibilityRetentionScannerEnabled, false),
 
 		SearchAttributesNumberOfKeysLimit: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesNumberOfKeysLimit, 100),
 		SearchAttributesSizeOfValueLimit:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesSizeOfValueLimit, 2*1024),
@@ -88,6 +88,7 @@ var (
 		"UpdateWorkflowExecution":                1,
 		"PollWorkflowExecutionUpdate":            1,
 		"StreamWorkflowReplicationMessages":      1,
+		"StreamVisibilityChanges":                1,
 		"GetWorkflowExecutionHistory":            1,
 		"GetWorkflowExecutionHistoryReverse":     1,
 		"GetWorkflowExecutionRawHistory":         1,
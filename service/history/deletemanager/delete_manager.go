@@ -155,6 +155,13 @@ func (m *DeleteManagerImpl) DeleteWorkflowExecutionByRetention(
 	stage *tasks.DeleteWorkflowExecutionStage,
 ) error {
 
+	if m.config.VisibilityRetentionScannerEnabled() && ms.GetNamespaceEntry().VisibilityRetention() > 0 {
+		// Namespace has its own visibility retention, the visibility record outlives (or has already
+		// outlived) the history and is deleted by the visibility retention scanner in the worker service.
+		// Without the scanner, the record is deleted along with the history.
+		stage.MarkProcessed(tasks.DeleteWorkflowExecutionStageVisibility)
+	}
+
 	return m.deleteWorkflowExecutionInternal(ctx, nsID, we, weCtx, ms, false, stage, m.metricsHandler.WithTags(metrics.OperationTag(metrics.HistoryProcessDeleteHistoryEventScope)))
 }
 
@@ -38,9 +38,11 @@ import (
 	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
 	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tasks"
 	"go.temporal.io/server/service/history/tests"
@@ -222,3 +224,71 @@ func (s *deleteManagerWorkflowSuite) TestDeleteWorkflowExecution_OpenWorkflow()
 	)
 	s.NoError(err)
 }
+
+func (s *deleteManagerWorkflowSuite) TestDeleteWorkflowExecutionByRetention() {
+	s.testDeleteWorkflowExecutionByRetention(tests.LocalNamespaceEntry, false)
+}
+
+func (s *deleteManagerWorkflowSuite) TestDeleteWorkflowExecutionByRetention_VisibilityRetention() {
+	s.deleteManager.(*DeleteManagerImpl).config.VisibilityRetentionScannerEnabled = dynamicconfig.GetBoolPropertyFn(true)
+	s.testDeleteWorkflowExecutionByRetention(newVisibilityRetentionNamespaceEntry(), true)
+}
+
+func (s *deleteManagerWorkflowSuite) TestDeleteWorkflowExecutionByRetention_VisibilityRetentionScannerDisabled() {
+	s.testDeleteWorkflowExecutionByRetention(newVisibilityRetentionNamespaceEntry(), false)
+}
+
+func newVisibilityRetentionNamespaceEntry() *namespace.Namespace {
+	return namespace.NewLocalNamespaceForTest(
+		&persistencespb.NamespaceInfo{Id: tests.NamespaceID.String(), Name: tests.Namespace.String()},
+		&persistencespb.NamespaceConfig{
+			Retention:           timestamp.DurationFromDays(1),
+			VisibilityRetention: timestamp.DurationFromDays(30),
+		},
+		cluster.TestCurrentClusterName,
+	)
+}
+
+func (s *deleteManagerWorkflowSuite) testDeleteWorkflowExecutionByRetention(
+	namespaceEntry *namespace.Namespace,
+	visibilityKept bool,
+) {
+	we := commonpb.WorkflowExecution{
+		WorkflowId: tests.WorkflowID,
+		RunId:      tests.RunID,
+	}
+
+	mockWeCtx := workflow.NewMockContext(s.controller)
+	mockMutableState := workflow.NewMockMutableState(s.controller)
+	closeExecutionVisibilityTaskID := int64(39)
+	mockMutableState.EXPECT().GetNamespaceEntry().Return(namespaceEntry).AnyTimes()
+	mockMutableState.EXPECT().GetCurrentBranchToken().Return([]byte{22, 8, 78}, nil)
+	mockMutableState.EXPECT().GetExecutionInfo().MinTimes(1).Return(&persistencespb.WorkflowExecutionInfo{
+		CloseVisibilityTaskId: closeExecutionVisibilityTaskID,
+	})
+	stage := tasks.DeleteWorkflowExecutionStageNone
+
+	s.mockShardContext.EXPECT().DeleteWorkflowExecution(
+		gomock.Any(),
+		definition.WorkflowKey{
+			NamespaceID: tests.NamespaceID.String(),
+			WorkflowID:  tests.WorkflowID,
+			RunID:       tests.RunID,
+		},
+		[]byte{22, 8, 78},
+		closeExecutionVisibilityTaskID,
+		&stage,
+	).Return(nil)
+	mockWeCtx.EXPECT().Clear()
+
+	err := s.deleteManager.DeleteWorkflowExecutionByRetention(
+		context.Background(),
+		tests.NamespaceID,
+		&we,
+		mockWeCtx,
+		mockMutableState,
+		&stage,
+	)
+	s.NoError(err)
+	s.Equal(visibilityKept, stage.IsProcessed(tasks.DeleteWorkflowExecutionStageVisibility))
+}
@@ -56,6 +56,7 @@ import (
 	"go.temporal.io/server/service/history/consts"
 	"go.temporal.io/server/service/history/events"
 	"go.temporal.io/server/service/history/shard"
+	"go.temporal.io/server/service/history/visibilitywatch"
 	"go.temporal.io/server/service/history/workflow"
 	"go.temporal.io/server/service/history/workflow/cache"
 )
@@ -80,6 +81,7 @@ var Module = fx.Options(
 	service.PersistenceLazyLoadedServiceResolverModule,
 	fx.Provide(ServiceResolverProvider),
 	fx.Provide(EventNotifierProvider),
+	fx.Provide(VisibilityWatchNotifierProvider),
 	fx.Provide(HistoryEngineFactoryProvider),
 	fx.Provide(HandlerProvider),
 	fx.Provide(ServiceProvider),
@@ -139,6 +141,7 @@ func HandlerProvider(args NewHandlerArgs) *Handler {
 		hostInfoProvider:             args.HostInfoProvider,
 		controller:                   args.ShardController,
 		eventNotifier:                args.EventNotifier,
+		visibilityWatchNotifier:      args.VisibilityWatchNotifier,
 		tracer:                       args.TracerProvider.Tracer(consts.LibraryName),
 		taskQueueManager:             args.TaskQueueManager,
 		taskCategoryRegistry:         args.TaskCategoryRegistry,
@@ -292,6 +295,15 @@ func EventNotifierProvider(
 	)
 }
 
+func VisibilityWatchNotifierProvider(
+	config *configs.Config,
+) visibilitywatch.Notifier {
+	return visibilitywatch.NewNotifier(
+		config.VisibilityWatchEnabled,
+		config.VisibilityWatchBufferSize,
+	)
+}
+
 func ServiceLifetimeHooks(lc fx.Lifecycle, svc *Service) {
 	lc.Append(fx.StartStopHook(svc.Start, svc.Stop))
 }
@@ -30,6 +30,7 @@ import (
 	"math"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pborman/uuid"
 	"go.opentelemetry.io/otel/trace"
@@ -39,6 +40,7 @@ import (
 	"go.uber.org/fx"
 	"google.golang.org/grpc/metadata"
 
+	historyspb "go.temporal.io/server/api/history/v1"
 	"go.temporal.io/server/api/historyservice/v1"
 	namespacespb "go.temporal.io/server/api/namespace/v1"
 	replicationspb "go.temporal.io/server/api/replication/v1"
@@ -70,6 +72,7 @@ import (
 	"go.temporal.io/server/service/history/replication"
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/visibilitywatch"
 )
 
 type (
@@ -84,6 +87,7 @@ type (
 		startWG                      sync.WaitGroup
 		config                       *configs.Config
 		eventNotifier                events.Notifier
+		visibilityWatchNotifier      visibilitywatch.Notifier
 		logger                       log.Logger
 		throttledLogger              log.Logger
 		persistenceExecutionManager  persistence.ExecutionManager
@@ -128,6 +132,7 @@ type (
 		HostInfoProvider             membership.HostInfoProvider
 		ShardController              shard.Controller
 		EventNotifier                events.Notifier
+		VisibilityWatchNotifier      visibilitywatch.Notifier
 		TracerProvider               trace.TracerProvider
 		TaskQueueManager             persistence.HistoryTaskQueueManager
 		TaskCategoryRegistry         tasks.TaskCategoryRegistry
@@ -140,6 +145,8 @@ type (
 
 const (
 	serviceName = "temporal.api.workflowservice.v1.HistoryService"
+
+	visibilityWatchShardCheckInterval = 10 * time.Second
 )
 
 var (
@@ -156,7 +163,8 @@ var (
 
 	errDeserializeTaskTokenMessage = "Error to deserialize task token. Error: %v."
 
-	errShuttingDown = serviceerror.NewUnavailable("Shutting down")
+	errShuttingDown                 = serviceerror.NewUnavailable("Shutting down")
+	errVisibilityWatchShardReloaded = serviceerror.NewUnavailable("Shard was reloaded.")
 )
 
 // Start starts the handler
@@ -667,7 +675,19 @@ func (h *Handler) GetShard(ctx context.Context, request *historyservice.GetShard
 	if err != nil {
 		return nil, err
 	}
-	return &historyservice.GetShardResponse{ShardInfo: resp.ShardInfo}, nil
+
+	var queueAlerts []*historyspb.QueueAlert
+	// GetShard requests are routed to the shard owner, so the shard is expected to be loaded by this host.
+	// Queue alerts are best effort and skipped if the shard or its engine is not available.
+	if shardContext, err := h.controller.GetShardByID(request.ShardId); err == nil {
+		if engine, err := shardContext.GetEngine(ctx); err == nil {
+			queueAlerts = engine.GetQueueAlerts()
+		}
+	}
+	return &historyservice.GetShardResponse{
+		ShardInfo:   resp.ShardInfo,
+		QueueAlerts: queueAlerts,
+	}, nil
 }
 
 // RebuildMutableState attempts to rebuild mutable state according to persisted history events
@@ -695,10 +715,18 @@ func (h *Handler) RebuildMutableState(ctx context.Context, request *historyservi
 		return nil, h.convertError(err)
 	}
 
-	if err := engine.RebuildMutableState(ctx, namespaceID, &commonpb.WorkflowExecution{
+	execution := &commonpb.WorkflowExecution{
 		WorkflowId: workflowExecution.WorkflowId,
 		RunId:      workflowExecution.RunId,
-	}); err != nil {
+	}
+	if request.GetDryRun() {
+		resp, err := engine.RebuildMutableStateDryRun(ctx, namespaceID, execution)
+		if err != nil {
+			return nil, h.convertError(err)
+		}
+		return resp, nil
+	}
+	if err := engine.RebuildMutableState(ctx, namespaceID, execution); err != nil {
 		return nil, h.convertError(err)
 	}
 	return &historyservice.RebuildMutableStateResponse{}, nil
@@ -1896,8 +1924,13 @@ func (h *Handler) DeleteWorkflowVisibilityRecord(
 	// delete) again to delete again if this happens.
 	// For ES implementation, we used max int64 as the TaskID (version) to make sure deletion is
 	// the last operation applied for this workflow
-	err := h.persistenceVisibilityManager.DeleteWorkflowExecution(ctx, &manager.VisibilityDeleteWorkflowExecutionRequest{
+	namespaceName, err := h.namespaceRegistry.GetNamespaceName(namespaceID)
+	if err != nil {
+		return nil, h.convertError(err)
+	}
+	err = h.persistenceVisibilityManager.DeleteWorkflowExecution(ctx, &manager.VisibilityDeleteWorkflowExecutionRequest{
 		NamespaceID: namespaceID,
+		Namespace:   namespaceName,
 		WorkflowID:  request.Execution.GetWorkflowId(),
 		RunID:       request.Execution.GetRunId(),
 		TaskID:      math.MaxInt64,
@@ -2031,6 +2064,135 @@ func (h *Handler) StreamWorkflowReplicationMessages(
 	return nil
 }
 
+// StreamVisibilityChanges streams the visibility changes of a namespace written by the visibility
+// queues of the shards, until the stream is canceled or any of the shards is no longer owned by
+// this host.
+func (h *Handler) StreamVisibilityChanges(
+	request *historyservice.StreamVisibilityChangesRequest,
+	server historyservice.HistoryService_StreamVisibilityChangesServer,
+) (retError error) {
+	defer metrics.CapturePanic(h.logger, h.metricsHandler, &retError)
+	h.startWG.Wait()
+
+	if h.isStopped() {
+		return errShuttingDown
+	}
+
+	namespaceID := namespace.ID(request.GetNamespaceId())
+	if namespaceID == "" {
+		return h.convertError(errNamespaceNotSet)
+	}
+	if len(request.GetShards()) == 0 {
+		return h.convertError(errShardIDNotSet)
+	}
+
+	type watchedShard struct {
+		shardID      int32
+		shardContext shard.Context
+		watcher      *visibilitywatch.Watcher
+		lastTaskID   int64
+	}
+	// Watchers of all the shards signal the same channel.
+	notifyC := make(chan struct{}, 1)
+	shards := make([]*watchedShard, 0, len(request.GetShards()))
+	for _, shardRequest := range request.GetShards() {
+		shardID := shardRequest.GetShardId()
+		shardContext, err := h.controller.GetShardByID(shardID)
+		if err != nil {
+			return h.convertError(err)
+		}
+
+		// The stream position is the task ID up to which every change of the namespace was streamed.
+		// It is read from the ack level of the visibility queue before the changes are read, as
+		// changes are written before their tasks are acked.
+		ackLevel := visibilityQueueAckLevel(shardContext)
+		watcher, err := h.visibilityWatchNotifier.Watch(shardID, namespaceID, shardRequest.GetLastTaskId(), notifyC)
+		if err != nil {
+			return err
+		}
+		defer h.visibilityWatchNotifier.Unwatch(watcher)
+
+		lastTaskID := shardRequest.GetLastTaskId()
+		if lastTaskID == 0 {
+			lastTaskID = ackLevel - 1
+		}
+		shards = append(shards, &watchedShard{
+			shardID:      shardID,
+			shardContext: shardContext,
+			watcher:      watcher,
+			lastTaskID:   lastTaskID,
+		})
+	}
+
+	// The first responses let the caller resume from where the stream started.
+	for _, s := range shards {
+		if err := server.Send(&historyservice.StreamVisibilityChangesResponse{
+			LastTaskId: s.lastTaskID,
+			ShardId:    s.shardID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	shardCheckTicker := time.NewTicker(visibilityWatchShardCheckInterval)
+	defer shardCheckTicker.Stop()
+	for {
+		checkAllShards := false
+		select {
+		case <-server.Context().Done():
+			return nil
+		case <-shardCheckTicker.C:
+			// The ack level of the visibility queue moves without new changes of the namespace,
+			// which is reported as well.
+			for _, s := range shards {
+				currentShardContext, err := h.controller.GetShardByID(s.shardID)
+				if err != nil {
+					return h.convertError(err)
+				}
+				if currentShardContext != s.shardContext {
+					return errVisibilityWatchShardReloaded
+				}
+			}
+			checkAllShards = true
+		case <-notifyC:
+		}
+
+		for _, s := range shards {
+			if !checkAllShards {
+				select {
+				case <-s.watcher.C():
+				default:
+					continue
+				}
+			}
+			ackLevel := visibilityQueueAckLevel(s.shardContext)
+			changes, err := h.visibilityWatchNotifier.Changes(s.watcher)
+			if err != nil {
+				return err
+			}
+			position := max(s.lastTaskID, ackLevel-1)
+			if len(changes) == 0 && position == s.lastTaskID {
+				continue
+			}
+			s.lastTaskID = position
+			if err := server.Send(&historyservice.StreamVisibilityChangesResponse{
+				Changes:    changes,
+				LastTaskId: s.lastTaskID,
+				ShardId:    s.shardID,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func visibilityQueueAckLevel(
+	shardContext shard.Context,
+) int64 {
+	queueState, _ := shardContext.GetQueueState(tasks.CategoryVisibility)
+	return visibilitywatch.QueueAckLevel(queueState)
+}
+
 func (h *Handler) GetWorkflowExecutionHistory(
 	ctx context.Context,
 	request *historyservice.GetWorkflowExecutionHistoryRequest,
@@ -26,6 +26,7 @@ package history
 
 import (
 	"context"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 	historypb "go.temporal.io/api/history/v1"
 	"go.temporal.io/server/service/history/api/getworkflowexecutionrawhistory"
 	"go.temporal.io/server/service/history/api/listtasks"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	historyspb "go.temporal.io/server/api/history/v1"
 	workflowpb "go.temporal.io/server/api/workflow/v1"
@@ -100,6 +102,7 @@ import (
 	"go.temporal.io/server/service/history/replication"
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/workflow"
 	wcache "go.temporal.io/server/service/history/workflow/cache"
 )
 
@@ -859,6 +862,29 @@ func (e *historyEngineImpl) RebuildMutableState(
 	)
 }
 
+func (e *historyEngineImpl) RebuildMutableStateDryRun(
+	ctx context.Context,
+	namespaceUUID namespace.ID,
+	execution *commonpb.WorkflowExecution,
+) (*historyservice.RebuildMutableStateResponse, error) {
+	current, rebuilt, err := e.workflowRebuilder.rebuildDryRun(
+		ctx,
+		definition.NewWorkflowKey(
+			namespaceUUID.String(),
+			execution.GetWorkflowId(),
+			execution.GetRunId(),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &historyservice.RebuildMutableStateResponse{
+		CurrentMutableState: current,
+		RebuiltMutableState: rebuilt,
+		Diffs:               workflow.DiffMutableState(current, rebuilt),
+	}, nil
+}
+
 func (e *historyEngineImpl) RefreshWorkflowTasks(
 	ctx context.Context,
 	namespaceUUID namespace.ID,
@@ -936,3 +962,21 @@ func (e *historyEngineImpl) ListTasks(
 		request,
 	)
 }
+
+func (e *historyEngineImpl) GetQueueAlerts() []*historyspb.QueueAlert {
+	var queueAlerts []*historyspb.QueueAlert
+	for category, queue := range e.queueProcessors {
+		for _, alert := range queue.RecentAlerts() {
+			queueAlerts = append(queueAlerts, &historyspb.QueueAlert{
+				CategoryId:  int32(category.ID()),
+				AlertType:   alert.AlertType.String(),
+				Description: alert.Description(),
+				AlertTime:   timestamppb.New(alert.AlertTime),
+			})
+		}
+	}
+	sort.SliceStable(queueAlerts, func(i, j int) bool {
+		return queueAlerts[i].CategoryId < queueAlerts[j].CategoryId
+	})
+	return queueAlerts
+}
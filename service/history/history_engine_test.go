@@ -6085,6 +6085,35 @@ func (s *engineSuite) Test_SetRequestDefaultValueAndGetTargetVersionHistory_Defi
 	s.NoError(err)
 }
 
+func (s *engineSuite) TestGetQueueAlerts() {
+	alertTime := time.Now().UTC()
+	s.mockTxProcessor.EXPECT().RecentAlerts().Return(nil)
+	s.mockVisibilityProcessor.EXPECT().RecentAlerts().Return(nil)
+	s.mockArchivalProcessor.EXPECT().RecentAlerts().Return(nil)
+	s.mockMemoryScheduledQueue.EXPECT().RecentAlerts().Return(nil)
+	s.mockTimerProcessor.EXPECT().RecentAlerts().Return([]queues.RecentAlert{
+		{
+			Alert: queues.Alert{
+				AlertType: queues.AlertTypeSliceCount,
+				AlertAttributesSliceCount: &queues.AlertAttributesSlicesCount{
+					CurrentSliceCount:  600,
+					CriticalSliceCount: 500,
+				},
+			},
+			AlertTime: alertTime,
+		},
+	})
+
+	queueAlerts := s.mockHistoryEngine.GetQueueAlerts()
+	s.Len(queueAlerts, 1)
+	s.ProtoEqual(&historyspb.QueueAlert{
+		CategoryId:  int32(tasks.CategoryIDTimer),
+		AlertType:   queues.AlertTypeSliceCount.String(),
+		Description: "slice count 600 exceeds critical count 500",
+		AlertTime:   timestamppb.New(alertTime),
+	}, queueAlerts[0])
+}
+
 func (s *engineSuite) Test_SetRequestDefaultValueAndGetTargetVersionHistory_DefinedEndEvent() {
 	inputStartEventID := int64(1)
 	inputEndEventID := int64(100)
@@ -48,6 +48,7 @@ import (
 	"go.temporal.io/server/service/history/configs"
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/visibilitywatch"
 	"go.temporal.io/server/service/history/workflow"
 )
 
@@ -165,6 +166,7 @@ type unusedDependencies struct {
 	resource.MatchingRawClient
 	resource.HistoryRawClient
 	manager.VisibilityManager
+	visibilitywatch.Notifier
 	archival.Archiver
 	workflow.RelocatableAttributesFetcher
 	persistence.HistoryTaskQueueManager
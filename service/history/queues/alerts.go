@@ -25,6 +25,8 @@
 package queues
 
 import (
+	"fmt"
+
 	"go.temporal.io/server/service/history/tasks"
 )
 
@@ -61,3 +63,42 @@ const (
 	AlertTypeReaderStuck
 	AlertTypeSliceCount
 )
+
+func (t AlertType) String() string {
+	switch t {
+	case AlertTypeQueuePendingTaskCount:
+		return "QueuePendingTaskCount"
+	case AlertTypeReaderStuck:
+		return "ReaderStuck"
+	case AlertTypeSliceCount:
+		return "SliceCount"
+	default:
+		return "Unspecified"
+	}
+}
+
+// Description returns a human readable description of the alert attributes
+func (a *Alert) Description() string {
+	switch a.AlertType {
+	case AlertTypeQueuePendingTaskCount:
+		return fmt.Sprintf(
+			"pending task count %d exceeds critical count %d",
+			a.AlertAttributesQueuePendingTaskCount.CurrentPendingTaskCount,
+			a.AlertAttributesQueuePendingTaskCount.CiriticalPendingTaskCount,
+		)
+	case AlertTypeReaderStuck:
+		return fmt.Sprintf(
+			"reader %d stuck at %v",
+			a.AlertAttributesReaderStuck.ReaderID,
+			a.AlertAttributesReaderStuck.CurrentWatermark,
+		)
+	case AlertTypeSliceCount:
+		return fmt.Sprintf(
+			"slice count %d exceeds critical count %d",
+			a.AlertAttributesSliceCount.CurrentSliceCount,
+			a.AlertAttributesSliceCount.CriticalSliceCount,
+		)
+	default:
+		return ""
+	}
+}
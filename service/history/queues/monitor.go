@@ -25,6 +25,7 @@
 package queues
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -38,6 +39,7 @@ var _ Monitor = (*monitorImpl)(nil)
 const (
 	monitorWatermarkPrecision   = time.Second
 	defaultAlertSilenceDuration = 10 * time.Second
+	recentAlertWindow           = time.Minute
 
 	alertChSize = 10
 )
@@ -63,6 +65,9 @@ type (
 		ResolveAlert(AlertType)
 		SilenceAlert(AlertType)
 		AlertCh() <-chan *Alert
+		// RecentAlerts returns the last alert of each type raised within the recent alert window,
+		// including alerts which are already resolved or silenced.
+		RecentAlerts() []RecentAlert
 		Close()
 	}
 
@@ -87,6 +92,7 @@ type (
 
 		pendingAlerts  map[AlertType]struct{}
 		silencedAlerts map[AlertType]time.Time // silenced alertType => expiration
+		recentAlerts   map[AlertType]RecentAlert
 		alertCh        chan *Alert
 		shutdownCh     chan struct{}
 	}
@@ -104,6 +110,11 @@ type (
 	sliceStats struct {
 		pendingTaskCount int
 	}
+
+	RecentAlert struct {
+		Alert
+		AlertTime time.Time
+	}
 )
 
 func newMonitor(
@@ -119,6 +130,7 @@ func newMonitor(
 		options:        options,
 		pendingAlerts:  make(map[AlertType]struct{}),
 		silencedAlerts: make(map[AlertType]time.Time),
+		recentAlerts:   make(map[AlertType]RecentAlert),
 		alertCh:        make(chan *Alert, alertChSize),
 		shutdownCh:     make(chan struct{}),
 	}
@@ -301,6 +313,23 @@ func (m *monitorImpl) AlertCh() <-chan *Alert {
 	return m.alertCh
 }
 
+func (m *monitorImpl) RecentAlerts() []RecentAlert {
+	m.Lock()
+	defer m.Unlock()
+
+	now := m.timeSource.Now()
+	alerts := make([]RecentAlert, 0, len(m.recentAlerts))
+	for _, alert := range m.recentAlerts {
+		if now.Sub(alert.AlertTime) <= recentAlertWindow {
+			alerts = append(alerts, alert)
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].AlertType < alerts[j].AlertType
+	})
+	return alerts
+}
+
 func (m *monitorImpl) Close() {
 	m.Lock()
 	defer m.Unlock()
@@ -324,6 +353,11 @@ func (m *monitorImpl) sendAlertLocked(alert *Alert) {
 		return
 	}
 
+	m.recentAlerts[alert.AlertType] = RecentAlert{
+		Alert:     *alert,
+		AlertTime: m.timeSource.Now(),
+	}
+
 	if m.timeSource.Now().Before(m.silencedAlerts[alert.AlertType]) {
 		return
 	}
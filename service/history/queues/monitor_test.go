@@ -25,6 +25,7 @@
 package queues
 
 import (
+	"fmt"
 	"math/rand"
 	"testing"
 	"time"
@@ -232,6 +233,26 @@ func (s *monitorSuite) TestResolveAlert() {
 	}
 }
 
+func (s *monitorSuite) TestRecentAlerts() {
+	now := time.Now()
+	s.mockTimeSource.Update(now)
+	s.Empty(s.monitor.RecentAlerts())
+
+	sliceCount := s.monitor.options.SliceCountCriticalThreshold() * 2
+	s.monitor.SetSliceCount(DefaultReaderId, sliceCount) // trigger an alert
+
+	alert := <-s.alertCh
+	s.monitor.ResolveAlert(alert.AlertType)
+
+	// resolved alerts are still reported as recent
+	s.Equal([]RecentAlert{{Alert: *alert, AlertTime: now}}, s.monitor.RecentAlerts())
+	s.Equal("SliceCount", alert.AlertType.String())
+	s.Equal(fmt.Sprintf("slice count %d exceeds critical count %d", sliceCount, sliceCount/2), alert.Description())
+
+	s.mockTimeSource.Update(now.Add(recentAlertWindow * 2))
+	s.Empty(s.monitor.RecentAlerts())
+}
+
 func (s *monitorSuite) TestSilenceAlert() {
 	now := time.Now()
 	s.mockTimeSource.Update(now)
@@ -35,6 +35,7 @@ type (
 		Category() tasks.Category
 		NotifyNewTasks(tasks []tasks.Task)
 		FailoverNamespace(namespaceID string)
+		RecentAlerts() []RecentAlert
 		Start()
 		Stop()
 	}
@@ -287,6 +287,10 @@ func (p *queueBase) FailoverNamespace(
 	p.rescheduler.Reschedule(namespaceID)
 }
 
+func (p *queueBase) RecentAlerts() []RecentAlert {
+	return p.monitor.RecentAlerts()
+}
+
 func (p *queueBase) processNewRange() {
 	newMaxKey := p.shard.GetQueueExclusiveHighReadWatermark(p.category)
 
@@ -96,6 +96,20 @@ func (mr *MockQueueMockRecorder) NotifyNewTasks(tasks interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyNewTasks", reflect.TypeOf((*MockQueue)(nil).NotifyNewTasks), tasks)
 }
 
+// RecentAlerts mocks base method.
+func (m *MockQueue) RecentAlerts() []RecentAlert {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecentAlerts")
+	ret0, _ := ret[0].([]RecentAlert)
+	return ret0
+}
+
+// RecentAlerts indicates an expected call of RecentAlerts.
+func (mr *MockQueueMockRecorder) RecentAlerts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentAlerts", reflect.TypeOf((*MockQueue)(nil).RecentAlerts))
+}
+
 // Start mocks base method.
 func (m *MockQueue) Start() {
 	m.ctrl.T.Helper()
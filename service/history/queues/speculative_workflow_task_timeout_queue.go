@@ -114,3 +114,7 @@ func (q SpeculativeWorkflowTaskTimeoutQueue) NotifyNewTasks(ts []tasks.Task) {
 
 func (q SpeculativeWorkflowTaskTimeoutQueue) FailoverNamespace(_ string) {
 }
+
+func (q SpeculativeWorkflowTaskTimeoutQueue) RecentAlerts() []RecentAlert {
+	return nil
+}
@@ -217,6 +217,20 @@ func (mr *MockEngineMockRecorder) GetMutableState(ctx, request interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutableState", reflect.TypeOf((*MockEngine)(nil).GetMutableState), ctx, request)
 }
 
+// GetQueueAlerts mocks base method.
+func (m *MockEngine) GetQueueAlerts() []*history0.QueueAlert {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueueAlerts")
+	ret0, _ := ret[0].([]*history0.QueueAlert)
+	return ret0
+}
+
+// GetQueueAlerts indicates an expected call of GetQueueAlerts.
+func (mr *MockEngineMockRecorder) GetQueueAlerts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueueAlerts", reflect.TypeOf((*MockEngine)(nil).GetQueueAlerts))
+}
+
 // GetReplicationMessages mocks base method.
 func (m *MockEngine) GetReplicationMessages(ctx context.Context, pollingCluster string, ackMessageID int64, ackTimestamp time.Time, queryMessageID int64) (*repication.ReplicationMessages, error) {
 	m.ctrl.T.Helper()
@@ -509,6 +523,21 @@ func (mr *MockEngineMockRecorder) RebuildMutableState(ctx, namespaceUUID, execut
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildMutableState", reflect.TypeOf((*MockEngine)(nil).RebuildMutableState), ctx, namespaceUUID, execution)
 }
 
+// RebuildMutableStateDryRun mocks base method.
+func (m *MockEngine) RebuildMutableStateDryRun(ctx context.Context, namespaceUUID namespace.ID, execution *common.WorkflowExecution) (*historyservice.RebuildMutableStateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildMutableStateDryRun", ctx, namespaceUUID, execution)
+	ret0, _ := ret[0].(*historyservice.RebuildMutableStateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebuildMutableStateDryRun indicates an expected call of RebuildMutableStateDryRun.
+func (mr *MockEngineMockRecorder) RebuildMutableStateDryRun(ctx, namespaceUUID, execution interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildMutableStateDryRun", reflect.TypeOf((*MockEngine)(nil).RebuildMutableStateDryRun), ctx, namespaceUUID, execution)
+}
+
 // RecordActivityTaskHeartbeat mocks base method.
 func (m *MockEngine) RecordActivityTaskHeartbeat(ctx context.Context, request *historyservice.RecordActivityTaskHeartbeatRequest) (*historyservice.RecordActivityTaskHeartbeatResponse, error) {
 	m.ctrl.T.Helper()
@@ -34,6 +34,7 @@ import (
 	"go.temporal.io/server/service/history/queues"
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/visibilitywatch"
 	wcache "go.temporal.io/server/service/history/workflow/cache"
 )
 
@@ -47,13 +48,21 @@ type (
 
 		QueueFactoryBaseParams
 
-		VisibilityMgr manager.VisibilityManager
+		VisibilityMgr           manager.VisibilityManager
+		VisibilityWatchNotifier visibilitywatch.Notifier
 	}
 
 	visibilityQueueFactory struct {
 		visibilityQueueFactoryParams
 		QueueFactoryBase
 	}
+
+	// visibilityQueue stops the visibility watches of the shard when the queue stops.
+	visibilityQueue struct {
+		queues.Queue
+		shardID  int32
+		notifier visibilitywatch.Notifier
+	}
 )
 
 func NewVisibilityQueueFactory(
@@ -122,6 +131,7 @@ func (f *visibilityQueueFactory) CreateQueue(
 		f.VisibilityMgr,
 		logger,
 		f.MetricsHandler,
+		f.VisibilityWatchNotifier,
 		f.Config.VisibilityProcessorEnsureCloseBeforeDelete,
 		f.Config.VisibilityProcessorEnableCloseWorkflowCleanup,
 	)
@@ -144,7 +154,12 @@ func (f *visibilityQueueFactory) CreateQueue(
 		f.Config.TaskDLQUnexpectedErrorAttempts,
 		f.Config.TaskDLQInternalErrors,
 	)
-	return queues.NewImmediateQueue(
+	// The queue executes again every task from the persisted ack level, so the visibility changes
+	// of the shard are buffered from there.
+	queueState, _ := shard.GetQueueState(tasks.CategoryVisibility)
+	f.VisibilityWatchNotifier.StartShard(shard.GetShardID(), visibilitywatch.QueueAckLevel(queueState))
+
+	queue := queues.NewImmediateQueue(
 		shard,
 		tasks.CategoryVisibility,
 		shardScheduler,
@@ -173,4 +188,14 @@ func (f *visibilityQueueFactory) CreateQueue(
 		metricsHandler,
 		factory,
 	)
+	return &visibilityQueue{
+		Queue:    queue,
+		shardID:  shard.GetShardID(),
+		notifier: f.VisibilityWatchNotifier,
+	}
+}
+
+func (q *visibilityQueue) Stop() {
+	q.Queue.Stop()
+	q.notifier.StopShard(q.shardID)
 }
@@ -30,7 +30,10 @@ import (
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
 	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"go.temporal.io/server/api/historyservice/v1"
 	"go.temporal.io/server/common"
 	"go.temporal.io/server/common/definition"
 	"go.temporal.io/server/common/dynamicconfig"
@@ -43,6 +46,7 @@ import (
 	"go.temporal.io/server/service/history/queues"
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tasks"
+	"go.temporal.io/server/service/history/visibilitywatch"
 	"go.temporal.io/server/service/history/workflow"
 	wcache "go.temporal.io/server/service/history/workflow/cache"
 )
@@ -54,6 +58,8 @@ type (
 		logger         log.Logger
 		metricProvider metrics.Handler
 		visibilityMgr  manager.VisibilityManager
+		// visibilityWatchNotifier is notified of the records written to visibility.
+		visibilityWatchNotifier visibilitywatch.Notifier
 
 		ensureCloseBeforeDelete    dynamicconfig.BoolPropertyFn
 		enableCloseWorkflowCleanup dynamicconfig.BoolPropertyFnWithNamespaceFilter
@@ -68,6 +74,7 @@ func newVisibilityQueueTaskExecutor(
 	visibilityMgr manager.VisibilityManager,
 	logger log.Logger,
 	metricProvider metrics.Handler,
+	visibilityWatchNotifier visibilitywatch.Notifier,
 	ensureCloseBeforeDelete dynamicconfig.BoolPropertyFn,
 	enableCloseWorkflowCleanup dynamicconfig.BoolPropertyFnWithNamespaceFilter,
 ) queues.Executor {
@@ -78,6 +85,8 @@ func newVisibilityQueueTaskExecutor(
 		metricProvider: metricProvider,
 		visibilityMgr:  visibilityMgr,
 
+		visibilityWatchNotifier: visibilityWatchNotifier,
+
 		ensureCloseBeforeDelete:    ensureCloseBeforeDelete,
 		enableCloseWorkflowCleanup: enableCloseWorkflowCleanup,
 	}
@@ -180,12 +189,17 @@ func (t *visibilityQueueTaskExecutor) processStartExecution(
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
 
-	return t.visibilityMgr.RecordWorkflowExecutionStarted(
+	err = t.visibilityMgr.RecordWorkflowExecutionStarted(
 		ctx,
 		&manager.RecordWorkflowExecutionStartedRequest{
 			VisibilityRequestBase: requestBase,
 		},
 	)
+	if err != nil {
+		return err
+	}
+	t.notifyVisibilityChange(requestBase, newVisibilityExecutionInfo(requestBase))
+	return nil
 }
 
 func (t *visibilityQueueTaskExecutor) processUpsertExecution(
@@ -222,12 +236,17 @@ func (t *visibilityQueueTaskExecutor) processUpsertExecution(
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
 
-	return t.visibilityMgr.UpsertWorkflowExecution(
+	err = t.visibilityMgr.UpsertWorkflowExecution(
 		ctx,
 		&manager.UpsertWorkflowExecutionRequest{
 			VisibilityRequestBase: requestBase,
 		},
 	)
+	if err != nil {
+		return err
+	}
+	t.notifyVisibilityChange(requestBase, newVisibilityExecutionInfo(requestBase))
+	return nil
 }
 
 func (t *visibilityQueueTaskExecutor) processCloseExecution(
@@ -294,6 +313,12 @@ func (t *visibilityQueueTaskExecutor) processCloseExecution(
 	if err != nil {
 		return err
 	}
+	closedExecutionInfo := newVisibilityExecutionInfo(requestBase)
+	closedExecutionInfo.CloseTime = timestamppb.New(wfCloseTime)
+	closedExecutionInfo.HistoryLength = historyLength
+	closedExecutionInfo.HistorySizeBytes = historySizeBytes
+	closedExecutionInfo.StateTransitionCount = stateTransitionCount
+	t.notifyVisibilityChange(requestBase, closedExecutionInfo)
 
 	// Elasticsearch bulk processor doesn't respect context timeout
 	// because under heavy load bulk flush might take longer than taskTimeout.
@@ -313,8 +338,13 @@ func (t *visibilityQueueTaskExecutor) processDeleteExecution(
 	ctx, cancel := context.WithTimeout(ctx, taskTimeout)
 	defer cancel()
 
+	namespaceName, err := t.shardContext.GetNamespaceRegistry().GetNamespaceName(namespace.ID(task.NamespaceID))
+	if err != nil {
+		return err
+	}
 	request := &manager.VisibilityDeleteWorkflowExecutionRequest{
 		NamespaceID: namespace.ID(task.NamespaceID),
+		Namespace:   namespaceName,
 		WorkflowID:  task.WorkflowID,
 		RunID:       task.RunID,
 		TaskID:      task.TaskID,
@@ -377,6 +407,36 @@ func (t *visibilityQueueTaskExecutor) getVisibilityRequestBase(
 	}
 }
 
+func (t *visibilityQueueTaskExecutor) notifyVisibilityChange(
+	requestBase *manager.VisibilityRequestBase,
+	executionInfo *workflowpb.WorkflowExecutionInfo,
+) {
+	t.visibilityWatchNotifier.NotifyVisibilityChange(
+		t.shardContext.GetShardID(),
+		requestBase.NamespaceID,
+		&historyservice.StreamVisibilityChangesResponse_Change{
+			TaskId:    requestBase.TaskID,
+			Execution: executionInfo,
+		},
+	)
+}
+
+func newVisibilityExecutionInfo(
+	requestBase *manager.VisibilityRequestBase,
+) *workflowpb.WorkflowExecutionInfo {
+	return &workflowpb.WorkflowExecutionInfo{
+		Execution:        requestBase.Execution,
+		Type:             &commonpb.WorkflowType{Name: requestBase.WorkflowTypeName},
+		StartTime:        timestamppb.New(requestBase.StartTime),
+		Status:           requestBase.Status,
+		ParentExecution:  requestBase.ParentExecution,
+		ExecutionTime:    timestamppb.New(requestBase.ExecutionTime),
+		Memo:             requestBase.Memo,
+		SearchAttributes: requestBase.SearchAttributes,
+		TaskQueue:        requestBase.TaskQueue,
+	}
+}
+
 func (t *visibilityQueueTaskExecutor) isCloseExecutionVisibilityTaskPending(task *tasks.DeleteExecutionVisibilityTask) bool {
 	CloseExecutionVisibilityTaskID := task.CloseExecutionVisibilityTaskID
 	// taskID == 0 if workflow still running in passive cluster or closed before this field was added (v1.17).
@@ -37,6 +37,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
 	historypb "go.temporal.io/api/history/v1"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
 	"go.temporal.io/api/workflowservice/v1"
@@ -48,6 +49,7 @@ import (
 	"go.temporal.io/server/common/clock"
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/definition"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/metrics"
 	"go.temporal.io/server/common/namespace"
@@ -65,6 +67,7 @@ import (
 	"go.temporal.io/server/service/history/shard"
 	"go.temporal.io/server/service/history/tasks"
 	"go.temporal.io/server/service/history/tests"
+	"go.temporal.io/server/service/history/visibilitywatch"
 	"go.temporal.io/server/service/history/workflow"
 	wcache "go.temporal.io/server/service/history/workflow/cache"
 )
@@ -88,6 +91,7 @@ type (
 		now                         time.Time
 		timeSource                  *clock.EventTimeSource
 		visibilityQueueTaskExecutor queues.Executor
+		visibilityWatchNotifier     visibilitywatch.Notifier
 
 		enableCloseWorkflowCleanup bool
 	}
@@ -163,12 +167,17 @@ func (s *visibilityQueueTaskExecutorSuite) SetupTest() {
 	s.mockShard.SetEngineForTesting(h)
 
 	s.enableCloseWorkflowCleanup = false
+	s.visibilityWatchNotifier = visibilitywatch.NewNotifier(
+		dynamicconfig.GetBoolPropertyFn(true),
+		dynamicconfig.GetIntPropertyFn(100),
+	)
 	s.visibilityQueueTaskExecutor = newVisibilityQueueTaskExecutor(
 		s.mockShard,
 		s.workflowCache,
 		s.mockVisibilityMgr,
 		s.logger,
 		metrics.NoopMetricsHandler,
+		s.visibilityWatchNotifier,
 		config.VisibilityProcessorEnsureCloseBeforeDelete,
 		func(_ string) bool { return s.enableCloseWorkflowCleanup },
 	)
@@ -395,9 +404,20 @@ func (s *visibilityQueueTaskExecutorSuite) TestProcessRecordWorkflowStartedTask(
 		gomock.Any(),
 		s.createRecordWorkflowExecutionStartedRequest(s.namespace, event, visibilityTask, mutableState, backoff, taskQueueName),
 	).Return(nil)
+	watcher, err := s.visibilityWatchNotifier.Watch(s.mockShard.GetShardID(), s.namespaceID, 0, nil)
+	s.NoError(err)
+	defer s.visibilityWatchNotifier.Unwatch(watcher)
 
 	resp := s.visibilityQueueTaskExecutor.Execute(context.Background(), s.newTaskExecutable(visibilityTask))
 	s.Nil(resp.ExecutionErr)
+
+	changes, err := s.visibilityWatchNotifier.Changes(watcher)
+	s.NoError(err)
+	s.Len(changes, 1)
+	s.Equal(taskID, changes[0].GetTaskId())
+	s.Equal(execution.GetWorkflowId(), changes[0].GetExecution().GetExecution().GetWorkflowId())
+	s.Equal(workflowType, changes[0].GetExecution().GetType().GetName())
+	s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, changes[0].GetExecution().GetStatus())
 }
 
 func (s *visibilityQueueTaskExecutorSuite) TestProcessUpsertWorkflowSearchAttributes() {
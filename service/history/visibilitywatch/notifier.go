@@ -0,0 +1,315 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitywatch
+
+import (
+	"math"
+	"sync"
+
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/api/historyservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/namespace"
+)
+
+type (
+	// Notifier keeps the most recent visibility changes written by the visibility queue of each
+	// shard and notifies the watchers of the shard when new changes are written.
+	//
+	// Watchers resume from visibility task IDs rather than from positions in the buffer, so that
+	// a watch can be resumed on any host owning the shard: when the visibility queue of a shard
+	// starts, it executes again every task from its persisted ack level, and the changes of those
+	// tasks are written to the buffer again.
+	Notifier interface {
+		NotifyVisibilityChange(shardID int32, namespaceID namespace.ID, change *historyservice.StreamVisibilityChangesResponse_Change)
+		// StartShard drops the changes buffered for the shard when its visibility queue starts
+		// from the persisted ack level ackLevel. Watchers of the shard are closed.
+		StartShard(shardID int32, ackLevel int64)
+		// StopShard drops the changes buffered for the shard when its visibility queue stops.
+		// Watchers of the shard are closed and watches can't be resumed until the queue starts.
+		StopShard(shardID int32)
+		// Watch returns a watcher receiving the changes of the namespace with task ID greater than
+		// lastTaskID. If lastTaskID is 0, only changes written after the call are received.
+		// If set, notifyC is signaled along with the channel of the watcher, so that a single
+		// channel waits for the watchers of many shards.
+		Watch(shardID int32, namespaceID namespace.ID, lastTaskID int64, notifyC chan struct{}) (*Watcher, error)
+		// Changes returns the changes the watcher hasn't received yet.
+		Changes(watcher *Watcher) ([]*historyservice.StreamVisibilityChangesResponse_Change, error)
+		Unwatch(watcher *Watcher)
+	}
+
+	// Watcher receives the visibility changes of a namespace in a shard.
+	Watcher struct {
+		shardID     int32
+		namespaceID namespace.ID
+		// nextSeq is the sequence number of the next change the watcher receives.
+		nextSeq int64
+		// lastTaskID filters out the changes the watcher resumed past.
+		lastTaskID int64
+		// reset is set when the queue of the shard starts or stops while the watcher is open.
+		reset   bool
+		notifyC chan struct{}
+		// groupNotifyC is the channel shared with the watchers of other shards.
+		groupNotifyC chan struct{}
+	}
+
+	NotifierImpl struct {
+		enabled    dynamicconfig.BoolPropertyFn
+		bufferSize dynamicconfig.IntPropertyFn
+
+		sync.Mutex
+		shards map[int32]*shardChanges
+	}
+
+	shardChanges struct {
+		sync.Mutex
+		// changes are kept in the order they were written, which isn't necessarily
+		// the order of their task IDs.
+		changes []bufferedChange
+		// nextSeq is the sequence number of the next change written to the shard.
+		nextSeq int64
+		// minTaskID is the lowest task ID from which every change is in the buffer. It is the ack
+		// level of the visibility queue when it started, raised past evicted changes.
+		minTaskID int64
+		watchers  map[*Watcher]struct{}
+	}
+
+	bufferedChange struct {
+		seq         int64
+		namespaceID namespace.ID
+		change      *historyservice.StreamVisibilityChangesResponse_Change
+	}
+)
+
+var (
+	errWatchDisabled               = serviceerror.NewFailedPrecondition("Visibility watch is disabled.")
+	errResumeTokenExpired          = serviceerror.NewFailedPrecondition("Visibility watch resume token expired.")
+	errShardNotReady               = serviceerror.NewUnavailable("Visibility queue of the shard hasn't started.")
+	errShardReset                  = serviceerror.NewUnavailable("Visibility queue of the shard was restarted.")
+	_                     Notifier = (*NotifierImpl)(nil)
+)
+
+func NewNotifier(
+	enabled dynamicconfig.BoolPropertyFn,
+	bufferSize dynamicconfig.IntPropertyFn,
+) *NotifierImpl {
+	return &NotifierImpl{
+		enabled:    enabled,
+		bufferSize: bufferSize,
+		shards:     make(map[int32]*shardChanges),
+	}
+}
+
+// QueueAckLevel returns the task ID below which every task of the persisted visibility queue
+// state was executed.
+func QueueAckLevel(
+	queueState *persistencespb.QueueState,
+) int64 {
+	ackLevel := queueState.GetExclusiveReaderHighWatermark().GetTaskId()
+	for _, readerState := range queueState.GetReaderStates() {
+		for _, scope := range readerState.GetScopes() {
+			ackLevel = min(ackLevel, scope.GetRange().GetInclusiveMin().GetTaskId())
+		}
+	}
+	return ackLevel
+}
+
+// C returns the channel signaled when the watcher has new changes.
+func (w *Watcher) C() <-chan struct{} {
+	return w.notifyC
+}
+
+func (n *NotifierImpl) NotifyVisibilityChange(
+	shardID int32,
+	namespaceID namespace.ID,
+	change *historyservice.StreamVisibilityChangesResponse_Change,
+) {
+	if !n.enabled() {
+		return
+	}
+
+	shard := n.getShard(shardID)
+	shard.Lock()
+	defer shard.Unlock()
+
+	shard.changes = append(shard.changes, bufferedChange{
+		seq:         shard.nextSeq,
+		namespaceID: namespaceID,
+		change:      change,
+	})
+	shard.nextSeq++
+	if bufferSize := n.bufferSize(); len(shard.changes) > bufferSize {
+		evicted := shard.changes[:len(shard.changes)-bufferSize]
+		for _, c := range evicted {
+			shard.minTaskID = max(shard.minTaskID, c.change.GetTaskId()+1)
+		}
+		shard.changes = shard.changes[len(evicted):]
+	}
+
+	// Watchers of other namespaces are notified as well, so that they move past
+	// the change before it is evicted from the buffer.
+	for watcher := range shard.watchers {
+		watcher.notify()
+	}
+}
+
+func (n *NotifierImpl) StartShard(
+	shardID int32,
+	ackLevel int64,
+) {
+	n.resetShard(shardID, ackLevel)
+}
+
+func (n *NotifierImpl) StopShard(
+	shardID int32,
+) {
+	n.resetShard(shardID, math.MaxInt64)
+}
+
+func (n *NotifierImpl) Watch(
+	shardID int32,
+	namespaceID namespace.ID,
+	lastTaskID int64,
+	notifyC chan struct{},
+) (*Watcher, error) {
+	if !n.enabled() {
+		return nil, errWatchDisabled
+	}
+
+	shard := n.getShard(shardID)
+	shard.Lock()
+	defer shard.Unlock()
+
+	watcher := &Watcher{
+		shardID:      shardID,
+		namespaceID:  namespaceID,
+		nextSeq:      shard.nextSeq,
+		lastTaskID:   lastTaskID,
+		notifyC:      make(chan struct{}, 1),
+		groupNotifyC: notifyC,
+	}
+	if lastTaskID != 0 {
+		if shard.minTaskID == math.MaxInt64 {
+			return nil, errShardNotReady
+		}
+		if lastTaskID+1 < shard.minTaskID {
+			return nil, errResumeTokenExpired
+		}
+		if len(shard.changes) > 0 {
+			watcher.nextSeq = shard.changes[0].seq
+			watcher.notify()
+		}
+	}
+	shard.watchers[watcher] = struct{}{}
+	return watcher, nil
+}
+
+func (n *NotifierImpl) Changes(
+	watcher *Watcher,
+) ([]*historyservice.StreamVisibilityChangesResponse_Change, error) {
+	shard := n.getShard(watcher.shardID)
+	shard.Lock()
+	defer shard.Unlock()
+
+	if watcher.reset {
+		return nil, errShardReset
+	}
+	if watcher.nextSeq >= shard.nextSeq {
+		return nil, nil
+	}
+	if len(shard.changes) == 0 || shard.changes[0].seq > watcher.nextSeq {
+		// Changes the watcher hasn't received were evicted from the buffer.
+		return nil, errResumeTokenExpired
+	}
+
+	var changes []*historyservice.StreamVisibilityChangesResponse_Change
+	for _, c := range shard.changes[watcher.nextSeq-shard.changes[0].seq:] {
+		if c.namespaceID == watcher.namespaceID && c.change.GetTaskId() > watcher.lastTaskID {
+			changes = append(changes, c.change)
+		}
+	}
+	watcher.nextSeq = shard.nextSeq
+	return changes, nil
+}
+
+func (n *NotifierImpl) Unwatch(
+	watcher *Watcher,
+) {
+	shard := n.getShard(watcher.shardID)
+	shard.Lock()
+	defer shard.Unlock()
+
+	delete(shard.watchers, watcher)
+}
+
+func (n *NotifierImpl) getShard(
+	shardID int32,
+) *shardChanges {
+	n.Lock()
+	defer n.Unlock()
+
+	shard, ok := n.shards[shardID]
+	if !ok {
+		shard = &shardChanges{
+			// Watches can't be resumed until the visibility queue of the shard starts.
+			minTaskID: math.MaxInt64,
+			watchers:  make(map[*Watcher]struct{}),
+		}
+		n.shards[shardID] = shard
+	}
+	return shard
+}
+
+func (n *NotifierImpl) resetShard(
+	shardID int32,
+	minTaskID int64,
+) {
+	shard := n.getShard(shardID)
+	shard.Lock()
+	defer shard.Unlock()
+
+	shard.changes = nil
+	shard.minTaskID = minTaskID
+	for watcher := range shard.watchers {
+		watcher.reset = true
+		watcher.notify()
+		delete(shard.watchers, watcher)
+	}
+}
+
+func (w *Watcher) notify() {
+	select {
+	case w.notifyC <- struct{}{}:
+	default:
+	}
+	if w.groupNotifyC != nil {
+		select {
+		case w.groupNotifyC <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitywatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/api/historyservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/namespace"
+)
+
+const (
+	testShardID     = int32(1)
+	testNamespaceID = namespace.ID("test-namespace-id")
+	otherNamespace  = namespace.ID("other-namespace-id")
+)
+
+func newTestNotifier(bufferSize int) *NotifierImpl {
+	return NewNotifier(dynamicconfig.GetBoolPropertyFn(true), dynamicconfig.GetIntPropertyFn(bufferSize))
+}
+
+func newChange(taskID int64) *historyservice.StreamVisibilityChangesResponse_Change {
+	return &historyservice.StreamVisibilityChangesResponse_Change{TaskId: taskID}
+}
+
+func taskIDs(changes []*historyservice.StreamVisibilityChangesResponse_Change) []int64 {
+	var ids []int64
+	for _, c := range changes {
+		ids = append(ids, c.GetTaskId())
+	}
+	return ids
+}
+
+func TestNotifier_NewChanges(t *testing.T) {
+	n := newTestNotifier(10)
+	n.StartShard(testShardID, 1)
+	n.NotifyVisibilityChange(testShardID, testNamespaceID, newChange(1))
+
+	watcher, err := n.Watch(testShardID, testNamespaceID, 0, nil)
+	require.NoError(t, err)
+	defer n.Unwatch(watcher)
+	require.Empty(t, watcher.C())
+
+	// Changes may be written out of task ID order.
+	n.NotifyVisibilityChange(testShardID, testNamespaceID, newChange(5))
+	n.NotifyVisibilityChange(testShardID, otherNamespace, newChange(6))
+	n.NotifyVisibilityChange(testShardID, testNamespaceID, newChange(3))
+	n.NotifyVisibilityChange(testShardID+1, testNamespaceID, newChange(7))
+
+	<-watcher.C()
+	changes, err := n.Changes(watcher)
+	require.NoError(t, err)
+	require.Equal(t, []int64{5, 3}, taskIDs(changes))
+
+	changes, err = n.Changes(watcher)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestNotifier_Resume(t *testing.T) {
+	n := newTestNotifier(3)
+	n.StartShard(testShardID, 1)
+	for _, taskID := range []int64{1, 4, 2, 3} {
+		n.NotifyVisibilityChange(testShardID, testNamespaceID, newChange(taskID))
+	}
+
+	// Task 1 was evicted from the buffer, changes from task 2 are resumed from.
+	watcher, err := n.Watch(testShardID, testNamespaceID, 1, nil)
+	require.NoError(t, err)
+	defer n.Unwatch(watcher)
+	<-watcher.C()
+	changes, err := n.Changes(watcher)
+	require.NoError(t, err)
+	require.Equal(t, []int64{4, 2, 3}, taskIDs(changes))
+
+	otherWatcher, err := n.Watch(testShardID, testNamespaceID, 2, nil)
+	require.NoError(t, err)
+	defer n.Unwatch(otherWatcher)
+	changes, err = n.Changes(otherWatcher)
+	require.NoError(t, err)
+	require.Equal(t, []int64{4, 3}, taskIDs(changes))
+
+	// Task 4 is evicted, so the changes after task 3 aren't all in the buffer anymore.
+	n.NotifyVisibilityChange(testShardID, testNamespaceID, newChange(5))
+	_, err = n.Watch(testShardID, testNamespaceID, 3, nil)
+	var failedPrecondition *serviceerror.FailedPrecondition
+	require.ErrorAs(t, err, &failedPrecondition)
+}
+
+func TestNotifier_ShardRestarted(t *testing.T) {
+	n := newTestNotifier(10)
+
+	// Watches can't be resumed before the visibility queue starts.
+	_, err := n.Watch(testShardID, testNamespaceID, 1, nil)
+	var unavailable *serviceerror.Unavailable
+	require.ErrorAs(t, err, &unavailable)
+
+	n.StartShard(testShardID, 5)
+	_, err = n.Watch(testShardID, testNamespaceID, 3, nil)
+	var failedPrecondition *serviceerror.FailedPrecondition
+	require.ErrorAs(t, err, &failedPrecondition)
+
+	watcher, err := n.Watch(testShardID, testNamespaceID, 4, nil)
+	require.NoError(t, err)
+	defer n.Unwatch(watcher)
+	n.NotifyVisibilityChange(testShardID, testNamespaceID, newChange(5))
+	<-watcher.C()
+	changes, err := n.Changes(watcher)
+	require.NoError(t, err)
+	require.Equal(t, []int64{5}, taskIDs(changes))
+
+	// Changes are written again when the queue starts from the ack level on another host.
+	n.StopShard(testShardID)
+	<-watcher.C()
+	_, err = n.Changes(watcher)
+	require.ErrorAs(t, err, &unavailable)
+	_, err = n.Watch(testShardID, testNamespaceID, 5, nil)
+	require.ErrorAs(t, err, &unavailable)
+
+	n.StartShard(testShardID, 5)
+	watcher, err = n.Watch(testShardID, testNamespaceID, 4, nil)
+	require.NoError(t, err)
+	defer n.Unwatch(watcher)
+	n.NotifyVisibilityChange(testShardID, testNamespaceID, newChange(5))
+	<-watcher.C()
+	changes, err = n.Changes(watcher)
+	require.NoError(t, err)
+	require.Equal(t, []int64{5}, taskIDs(changes))
+}
+
+func TestNotifier_Evicted(t *testing.T) {
+	n := newTestNotifier(2)
+	n.StartShard(testShardID, 1)
+	watcher, err := n.Watch(testShardID, testNamespaceID, 0, nil)
+	require.NoError(t, err)
+	defer n.Unwatch(watcher)
+
+	for taskID := int64(1); taskID <= 3; taskID++ {
+		n.NotifyVisibilityChange(testShardID, testNamespaceID, newChange(taskID))
+	}
+	_, err = n.Changes(watcher)
+	var failedPrecondition *serviceerror.FailedPrecondition
+	require.ErrorAs(t, err, &failedPrecondition)
+}
+
+func TestNotifier_SharedNotifyChannel(t *testing.T) {
+	n := newTestNotifier(10)
+	n.StartShard(testShardID, 1)
+	n.StartShard(testShardID+1, 1)
+	notifyC := make(chan struct{}, 1)
+	watcher, err := n.Watch(testShardID, testNamespaceID, 0, notifyC)
+	require.NoError(t, err)
+	defer n.Unwatch(watcher)
+	otherWatcher, err := n.Watch(testShardID+1, testNamespaceID, 0, notifyC)
+	require.NoError(t, err)
+	defer n.Unwatch(otherWatcher)
+
+	n.NotifyVisibilityChange(testShardID+1, testNamespaceID, newChange(1))
+	<-notifyC
+	select {
+	case <-watcher.C():
+		require.Fail(t, "watcher of another shard was notified")
+	default:
+	}
+	<-otherWatcher.C()
+	changes, err := n.Changes(otherWatcher)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, taskIDs(changes))
+}
+
+func TestNotifier_Disabled(t *testing.T) {
+	n := NewNotifier(dynamicconfig.GetBoolPropertyFn(false), dynamicconfig.GetIntPropertyFn(10))
+	n.StartShard(testShardID, 1)
+	n.NotifyVisibilityChange(testShardID, testNamespaceID, newChange(1))
+
+	_, err := n.Watch(testShardID, testNamespaceID, 0, nil)
+	var failedPrecondition *serviceerror.FailedPrecondition
+	require.ErrorAs(t, err, &failedPrecondition)
+}
+
+func TestQueueAckLevel(t *testing.T) {
+	require.Equal(t, int64(0), QueueAckLevel(nil))
+	require.Equal(t, int64(10), QueueAckLevel(&persistencespb.QueueState{
+		ExclusiveReaderHighWatermark: &persistencespb.TaskKey{TaskId: 10},
+	}))
+	require.Equal(t, int64(4), QueueAckLevel(&persistencespb.QueueState{
+		ReaderStates: map[int64]*persistencespb.QueueReaderState{
+			0: {Scopes: []*persistencespb.QueueSliceScope{
+				{Range: &persistencespb.QueueSliceRange{InclusiveMin: &persistencespb.TaskKey{TaskId: 6}}},
+			}},
+			1: {Scopes: []*persistencespb.QueueSliceScope{
+				{Range: &persistencespb.QueueSliceRange{InclusiveMin: &persistencespb.TaskKey{TaskId: 4}}},
+			}},
+		},
+		ExclusiveReaderHighWatermark: &persistencespb.TaskKey{TaskId: 10},
+	}))
+}
@@ -0,0 +1,235 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	historyspb "go.temporal.io/server/api/history/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+)
+
+// DiffMutableState returns the field level differences between the current and rebuilt mutable state.
+// Map entries and repeated messages are compared element by element, well known types and scalar lists
+// are compared as a whole.
+func DiffMutableState(
+	current *persistencespb.WorkflowMutableState,
+	rebuilt *persistencespb.WorkflowMutableState,
+) []*historyspb.MutableStateFieldDiff {
+	var diffs []*historyspb.MutableStateFieldDiff
+	diffMessage("", current.ProtoReflect(), rebuilt.ProtoReflect(), &diffs)
+	return diffs
+}
+
+func diffMessage(
+	path string,
+	current protoreflect.Message,
+	rebuilt protoreflect.Message,
+	diffs *[]*historyspb.MutableStateFieldDiff,
+) {
+	fields := current.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldPath := string(field.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+
+		switch {
+		case field.IsMap():
+			diffMap(fieldPath, field, current.Get(field).Map(), rebuilt.Get(field).Map(), diffs)
+		case field.IsList() && field.Message() != nil:
+			diffMessageList(fieldPath, field, current.Get(field).List(), rebuilt.Get(field).List(), diffs)
+		case field.Message() != nil && !isDiffLeafMessage(field.Message()):
+			currentHas, rebuiltHas := current.Has(field), rebuilt.Has(field)
+			switch {
+			case !currentHas && !rebuiltHas:
+			case currentHas && rebuiltHas:
+				diffMessage(fieldPath, current.Get(field).Message(), rebuilt.Get(field).Message(), diffs)
+			default:
+				appendFieldDiff(fieldPath, field, current, rebuilt, diffs)
+			}
+		default:
+			if !current.Get(field).Equal(rebuilt.Get(field)) {
+				appendFieldDiff(fieldPath, field, current, rebuilt, diffs)
+			}
+		}
+	}
+}
+
+func diffMap(
+	path string,
+	field protoreflect.FieldDescriptor,
+	current protoreflect.Map,
+	rebuilt protoreflect.Map,
+	diffs *[]*historyspb.MutableStateFieldDiff,
+) {
+	keys := make(map[string]protoreflect.MapKey)
+	current.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys[key.String()] = key
+		return true
+	})
+	rebuilt.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys[key.String()] = key
+		return true
+	})
+	sortedKeys := make([]protoreflect.MapKey, 0, len(keys))
+	for _, key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Slice(sortedKeys, func(i, j int) bool {
+		if field.MapKey().Kind() == protoreflect.StringKind {
+			return sortedKeys[i].String() < sortedKeys[j].String()
+		}
+		return sortedKeys[i].Int() < sortedKeys[j].Int()
+	})
+
+	valueField := field.MapValue()
+	for _, key := range sortedKeys {
+		entryPath := fmt.Sprintf("%s[%s]", path, key.String())
+		currentHas, rebuiltHas := current.Has(key), rebuilt.Has(key)
+		if currentHas && rebuiltHas && valueField.Message() != nil && !isDiffLeafMessage(valueField.Message()) {
+			diffMessage(entryPath, current.Get(key).Message(), rebuilt.Get(key).Message(), diffs)
+			continue
+		}
+		if currentHas && rebuiltHas && current.Get(key).Equal(rebuilt.Get(key)) {
+			continue
+		}
+		var currentValue, rebuiltValue string
+		if currentHas {
+			currentValue = encodeDiffValue(valueField, current.Get(key))
+		}
+		if rebuiltHas {
+			rebuiltValue = encodeDiffValue(valueField, rebuilt.Get(key))
+		}
+		*diffs = append(*diffs, &historyspb.MutableStateFieldDiff{
+			Path:    entryPath,
+			Current: currentValue,
+			Rebuilt: rebuiltValue,
+		})
+	}
+}
+
+func diffMessageList(
+	path string,
+	field protoreflect.FieldDescriptor,
+	current protoreflect.List,
+	rebuilt protoreflect.List,
+	diffs *[]*historyspb.MutableStateFieldDiff,
+) {
+	for i := 0; i < current.Len() || i < rebuilt.Len(); i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i < current.Len() && i < rebuilt.Len():
+			if isDiffLeafMessage(field.Message()) {
+				if !current.Get(i).Equal(rebuilt.Get(i)) {
+					*diffs = append(*diffs, &historyspb.MutableStateFieldDiff{
+						Path:    elementPath,
+						Current: encodeDiffValue(field, current.Get(i)),
+						Rebuilt: encodeDiffValue(field, rebuilt.Get(i)),
+					})
+				}
+				continue
+			}
+			diffMessage(elementPath, current.Get(i).Message(), rebuilt.Get(i).Message(), diffs)
+		case i < current.Len():
+			*diffs = append(*diffs, &historyspb.MutableStateFieldDiff{
+				Path:    elementPath,
+				Current: encodeDiffValue(field, current.Get(i)),
+			})
+		default:
+			*diffs = append(*diffs, &historyspb.MutableStateFieldDiff{
+				Path:    elementPath,
+				Rebuilt: encodeDiffValue(field, rebuilt.Get(i)),
+			})
+		}
+	}
+}
+
+func appendFieldDiff(
+	path string,
+	field protoreflect.FieldDescriptor,
+	current protoreflect.Message,
+	rebuilt protoreflect.Message,
+	diffs *[]*historyspb.MutableStateFieldDiff,
+) {
+	// proto3 scalars are never reported as unset, zero values are encoded as is
+	isScalar := field.Message() == nil && !field.IsList()
+	diff := &historyspb.MutableStateFieldDiff{Path: path}
+	if isScalar || current.Has(field) {
+		diff.Current = encodeDiffFieldValue(field, current.Get(field))
+	}
+	if isScalar || rebuilt.Has(field) {
+		diff.Rebuilt = encodeDiffFieldValue(field, rebuilt.Get(field))
+	}
+	*diffs = append(*diffs, diff)
+}
+
+func encodeDiffFieldValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if !field.IsList() {
+		return encodeDiffValue(field, value)
+	}
+	list := value.List()
+	elements := make([]json.RawMessage, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		elements = append(elements, json.RawMessage(encodeDiffValue(field, list.Get(i))))
+	}
+	data, err := json.Marshal(elements)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// encodeDiffValue encodes a single (non list) value of the given field, or of the map value if field is a map value.
+func encodeDiffValue(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	var data []byte
+	var err error
+	switch {
+	case field.Message() != nil:
+		data, err = protojson.Marshal(value.Message().Interface())
+	case field.Enum() != nil:
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			data, err = json.Marshal(string(enumValue.Name()))
+		} else {
+			data, err = json.Marshal(value.Enum())
+		}
+	default:
+		data, err = json.Marshal(value.Interface())
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+func isDiffLeafMessage(message protoreflect.MessageDescriptor) bool {
+	return message.ParentFile().Package() == "google.protobuf"
+}
@@ -0,0 +1,113 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/persistence/versionhistory"
+)
+
+func TestDiffMutableState(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	current := &persistencespb.WorkflowMutableState{
+		ExecutionInfo: &persistencespb.WorkflowExecutionInfo{
+			WorkflowId:           "wf-1",
+			StateTransitionCount: 10,
+			LastUpdateTime:       timestamppb.New(now),
+			VersionHistories: versionhistory.NewVersionHistories(versionhistory.NewVersionHistory([]byte("branch"), []*historyspb.VersionHistoryItem{
+				versionhistory.NewVersionHistoryItem(8, common.EmptyVersion),
+			})),
+		},
+		ExecutionState: &persistencespb.WorkflowExecutionState{
+			RunId:  "run-1",
+			State:  enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+			Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		},
+		NextEventId: 9,
+		ActivityInfos: map[int64]*persistencespb.ActivityInfo{
+			5: {ActivityId: "activity-1", ScheduledEventId: 5, StartedEventId: 7},
+		},
+		TimerInfos: map[string]*persistencespb.TimerInfo{
+			"timer-1": {TimerId: "timer-1", StartedEventId: 6},
+		},
+		SignalRequestedIds: []string{"signal-1"},
+		Checksum: &persistencespb.Checksum{
+			Version: 1,
+			Value:   []byte("checksum-1"),
+		},
+	}
+	rebuilt := common.CloneProto(current)
+	require.Empty(t, DiffMutableState(current, rebuilt))
+
+	rebuilt.ExecutionInfo.LastUpdateTime = timestamppb.New(now.Add(time.Minute))
+	rebuilt.ExecutionInfo.VersionHistories.Histories[0].Items[0].EventId = 7
+	rebuilt.ExecutionState.State = enumsspb.WORKFLOW_EXECUTION_STATE_COMPLETED
+	rebuilt.NextEventId = 8
+	rebuilt.ActivityInfos[5].StartedEventId = common.EmptyEventID
+	delete(rebuilt.TimerInfos, "timer-1")
+	rebuilt.ChildExecutionInfos = map[int64]*persistencespb.ChildExecutionInfo{
+		4: {InitiatedEventId: 4},
+	}
+	rebuilt.SignalRequestedIds = nil
+	rebuilt.Checksum.Value = []byte("checksum-2")
+
+	diffs := DiffMutableState(current, rebuilt)
+	paths := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		paths = append(paths, diff.GetPath())
+	}
+	require.Equal(t, []string{
+		"activity_infos[5].started_event_id",
+		"timer_infos[timer-1]",
+		"child_execution_infos[4]",
+		"signal_requested_ids",
+		"execution_info.last_update_time",
+		"execution_info.version_histories.histories[0].items[0].event_id",
+		"execution_state.state",
+		"next_event_id",
+		"checksum.value",
+	}, paths)
+
+	require.Equal(t, "7", diffs[0].GetCurrent())
+	require.Equal(t, "0", diffs[0].GetRebuilt())
+	require.NotEmpty(t, diffs[1].GetCurrent())
+	require.Empty(t, diffs[1].GetRebuilt())
+	require.Empty(t, diffs[2].GetCurrent())
+	require.NotEmpty(t, diffs[2].GetRebuilt())
+	require.Equal(t, `["signal-1"]`, diffs[3].GetCurrent())
+	require.Empty(t, diffs[3].GetRebuilt())
+	require.Equal(t, `"WORKFLOW_EXECUTION_STATE_RUNNING"`, diffs[6].GetCurrent())
+	require.Equal(t, `"WORKFLOW_EXECUTION_STATE_COMPLETED"`, diffs[6].GetRebuilt())
+}
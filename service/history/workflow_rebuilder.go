@@ -33,6 +33,7 @@ import (
 	commonpb "go.temporal.io/api/common/v1"
 	"go.temporal.io/api/serviceerror"
 
+	persistencespb "go.temporal.io/server/api/persistence/v1"
 	"go.temporal.io/server/common/definition"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/namespace"
@@ -51,6 +52,7 @@ type (
 		stateTransitionCount int64
 		dbRecordVersion      int64
 		requestID            string
+		mutableState         *persistencespb.WorkflowMutableState
 	}
 	workflowRebuilder interface {
 		// rebuild rebuilds a workflow, in case of any kind of corruption
@@ -58,6 +60,12 @@ type (
 			ctx context.Context,
 			workflowKey definition.WorkflowKey,
 		) error
+		// rebuildDryRun rebuilds a workflow without persisting the result,
+		// it returns both the current and the rebuilt mutable state
+		rebuildDryRun(
+			ctx context.Context,
+			workflowKey definition.WorkflowKey,
+		) (*persistencespb.WorkflowMutableState, *persistencespb.WorkflowMutableState, error)
 	}
 
 	workflowRebuilderImpl struct {
@@ -125,6 +133,59 @@ func (r *workflowRebuilderImpl) rebuild(
 	return r.overwriteToDB(ctx, rebuildMutableState)
 }
 
+func (r *workflowRebuilderImpl) rebuildDryRun(
+	ctx context.Context,
+	workflowKey definition.WorkflowKey,
+) (_ *persistencespb.WorkflowMutableState, _ *persistencespb.WorkflowMutableState, retError error) {
+
+	wfCache := r.workflowConsistencyChecker.GetWorkflowCache()
+	rebuildSpec, err := r.getRebuildSpecFromMutableState(ctx, &workflowKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the workflow is locked so that it doesn't change while being rebuilt, but the cached
+	// mutable state is kept since nothing is persisted
+	_, releaseFn, err := wfCache.GetOrCreateWorkflowExecution(
+		ctx,
+		r.shard,
+		namespace.ID(workflowKey.NamespaceID),
+		&commonpb.WorkflowExecution{
+			WorkflowId: workflowKey.WorkflowID,
+			RunId:      workflowKey.RunID,
+		},
+		workflow.LockPriorityHigh,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { releaseFn(retError) }()
+
+	rebuildMutableState, err := r.replayResetWorkflow(
+		ctx,
+		workflowKey,
+		rebuildSpec.branchToken,
+		rebuildSpec.stateTransitionCount,
+		rebuildSpec.dbRecordVersion,
+		rebuildSpec.requestID,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// close the transaction the same way overwriteToDB does, so that the checksum and
+	// state transition count match what would be persisted
+	_, resetWorkflowEventsSeq, err := rebuildMutableState.CloseTransactionAsSnapshot(
+		workflow.TransactionPolicyPassive,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resetWorkflowEventsSeq) != 0 {
+		return nil, nil, serviceerror.NewInternal("workflowRebuilder encountered new events when rebuilding mutable state")
+	}
+	return rebuildSpec.mutableState, rebuildMutableState.CloneToProto(), nil
+}
+
 func (r *workflowRebuilderImpl) getRebuildSpecFromMutableState(
 	ctx context.Context,
 	workflowKey *definition.WorkflowKey,
@@ -167,6 +228,7 @@ func (r *workflowRebuilderImpl) getRebuildSpecFromMutableState(
 		stateTransitionCount: mutableState.ExecutionInfo.StateTransitionCount,
 		dbRecordVersion:      resp.DBRecordVersion,
 		requestID:            mutableState.ExecutionState.CreateRequestId,
+		mutableState:         mutableState,
 	}, nil
 }
 
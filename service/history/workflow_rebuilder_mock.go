@@ -33,6 +33,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	persistence "go.temporal.io/server/api/persistence/v1"
 	definition "go.temporal.io/server/common/definition"
 )
 
@@ -72,3 +73,19 @@ func (mr *MockworkflowRebuilderMockRecorder) rebuild(ctx, workflowKey interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "rebuild", reflect.TypeOf((*MockworkflowRebuilder)(nil).rebuild), ctx, workflowKey)
 }
+
+// rebuildDryRun mocks base method.
+func (m *MockworkflowRebuilder) rebuildDryRun(ctx context.Context, workflowKey definition.WorkflowKey) (*persistence.WorkflowMutableState, *persistence.WorkflowMutableState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "rebuildDryRun", ctx, workflowKey)
+	ret0, _ := ret[0].(*persistence.WorkflowMutableState)
+	ret1, _ := ret[1].(*persistence.WorkflowMutableState)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// rebuildDryRun indicates an expected call of rebuildDryRun.
+func (mr *MockworkflowRebuilderMockRecorder) rebuildDryRun(ctx, workflowKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "rebuildDryRun", reflect.TypeOf((*MockworkflowRebuilder)(nil).rebuildDryRun), ctx, workflowKey)
+}
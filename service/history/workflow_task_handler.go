@@ -514,6 +514,8 @@ func (handler *workflowTaskHandlerImpl) handlePostCommandEagerExecuteActivity(
 		namespaceID.String(),
 		executionInfo.WorkflowId,
 		runID,
+		executionInfo.WorkflowTypeName,
+		ai.TaskQueue,
 		ai.GetScheduledEventId(),
 		attr.ActivityId,
 		attr.ActivityType.GetName(),
@@ -352,6 +352,7 @@ func (handler *workflowTaskHandlerCallbacksImpl) handleWorkflowTaskFailed(
 				(token.StartedEventId != common.EmptyEventID && token.StartedEventId != workflowTask.StartedEventID) ||
 				(token.StartedTime != nil && !workflowTask.StartedTime.IsZero() && !token.StartedTime.AsTime().Equal(workflowTask.StartedTime)) ||
 				workflowTask.Attempt != token.Attempt ||
+				!api.TaskTokenMatchesWorkflow(token, mutableState, mutableState.GetExecutionInfo().TaskQueue) ||
 				(workflowTask.Version != common.EmptyVersion && token.Version != workflowTask.Version) {
 				// we have not alter mutable state yet, so release with it with nil to avoid clear MS.
 				workflowLease.GetReleaseFn()(nil)
@@ -431,6 +432,7 @@ func (handler *workflowTaskHandlerCallbacksImpl) handleWorkflowTaskCompleted(
 		(token.StartedEventId != common.EmptyEventID && token.StartedEventId != currentWorkflowTask.StartedEventID) ||
 		(token.StartedTime != nil && !currentWorkflowTask.StartedTime.IsZero() && !token.StartedTime.AsTime().Equal(currentWorkflowTask.StartedTime)) ||
 		currentWorkflowTask.Attempt != token.Attempt ||
+		!api.TaskTokenMatchesWorkflow(token, ms, ms.GetExecutionInfo().TaskQueue) ||
 		(token.Version != common.EmptyVersion && token.Version != currentWorkflowTask.Version) {
 		// we have not alter mutable state yet, so release with it with nil to avoid clear MS.
 		workflowLease.GetReleaseFn()(nil)
@@ -1172,6 +1174,8 @@ func (handler *workflowTaskHandlerCallbacksImpl) withNewWorkflowTask(
 		taskToken.GetNamespaceId(),
 		taskToken.GetWorkflowId(),
 		taskToken.GetRunId(),
+		response.GetWorkflowType().GetName(),
+		response.GetWorkflowExecutionTaskQueue().GetName(),
 		response.GetScheduledEventId(),
 		response.GetStartedEventId(),
 		response.GetStartedTime(),
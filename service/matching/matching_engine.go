@@ -1434,6 +1434,8 @@ func (e *matchingEngineImpl) createPollWorkflowTaskQueueResponse(
 			task.event.Data.GetNamespaceId(),
 			task.event.Data.GetWorkflowId(),
 			task.event.Data.GetRunId(),
+			recordStartResp.GetWorkflowType().GetName(),
+			recordStartResp.GetWorkflowExecutionTaskQueue().GetName(),
 			recordStartResp.GetScheduledEventId(),
 			recordStartResp.GetStartedEventId(),
 			recordStartResp.GetStartedTime(),
@@ -1486,6 +1488,8 @@ func (e *matchingEngineImpl) createPollActivityTaskQueueResponse(
 		task.event.Data.GetNamespaceId(),
 		task.event.Data.GetWorkflowId(),
 		task.event.Data.GetRunId(),
+		historyResponse.GetWorkflowType().GetName(),
+		attributes.GetTaskQueue().GetName(),
 		task.event.Data.GetScheduledEventId(),
 		attributes.GetActivityId(),
 		attributes.GetActivityType().GetName(),
@@ -846,6 +846,7 @@ func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 			ScheduledEventId: scheduledEventID,
 			ActivityId:       activityID,
 			ActivityType:     activityTypeName,
+			TaskQueue:        taskQueue.Name,
 		}
 
 		serializedToken, _ := s.matchingEngine.tokenSerializer.Serialize(taskToken)
@@ -1011,6 +1012,7 @@ func (s *matchingEngineSuite) TestSyncMatchActivities() {
 			ScheduledEventId: scheduledEventID,
 			ActivityId:       activityID,
 			ActivityType:     activityTypeName,
+			TaskQueue:        taskQueue.Name,
 		}
 
 		serializedToken, _ := s.matchingEngine.tokenSerializer.Serialize(taskToken)
@@ -1215,6 +1217,7 @@ func (s *matchingEngineSuite) concurrentPublishConsumeActivities(
 					ScheduledEventId: scheduledEventID,
 					ActivityId:       activityID,
 					ActivityType:     activityTypeName,
+					TaskQueue:        taskQueue.Name,
 				}
 				resultToken, err := s.matchingEngine.tokenSerializer.Deserialize(result.TaskToken)
 				s.NoError(err)
@@ -1344,6 +1347,7 @@ func (s *matchingEngineSuite) TestConcurrentPublishConsumeWorkflowTasks() {
 					RunId:            runID,
 					ScheduledEventId: scheduledEventID,
 					StartedEventId:   startedEventID,
+					WorkflowType:     workflowTypeName,
 				}
 				resultToken, err := s.matchingEngine.tokenSerializer.Deserialize(result.TaskToken)
 				if err != nil {
@@ -1535,6 +1539,7 @@ func (s *matchingEngineSuite) TestMultipleEnginesActivitiesRangeStealing() {
 					RunId:        runID,
 					ActivityId:   activityID,
 					ActivityType: activityTypeName,
+					TaskQueue:    taskQueue.Name,
 				}
 				resultToken, err := engine.tokenSerializer.Deserialize(result.TaskToken)
 				if err != nil {
@@ -1681,6 +1686,7 @@ func (s *matchingEngineSuite) TestMultipleEnginesWorkflowTasksRangeStealing() {
 					WorkflowId:     workflowID,
 					RunId:          runID,
 					StartedEventId: startedEventID,
+					WorkflowType:   workflowTypeName,
 				}
 				resultToken, err := engine.tokenSerializer.Deserialize(result.TaskToken)
 				if err != nil {
@@ -56,6 +56,9 @@ type (
 	WorkflowParams struct {
 		// Elasticsearch index name. Can be empty string if Elasticsearch is not configured.
 		IndexName string
+		// Namespace dedicated Elasticsearch indices which get the same mapping as IndexName.
+		// Search attributes are saved to cluster metadata for IndexName only.
+		NamespaceIndexNames []string
 		// Search attributes that need to be added to the index.
 		CustomAttributesToAdd map[string]enumspb.IndexedValueType
 		// If true skip Elasticsearch schema update and only update cluster metadata.
@@ -119,9 +122,11 @@ func AddSearchAttributesWorkflow(ctx workflow.Context, params WorkflowParams) er
 		}
 
 		ctx2 := workflow.WithActivityOptions(ctx, waitForYellowStatusActivityOptions)
-		err = workflow.ExecuteActivity(ctx2, a.WaitForYellowStatusActivity, params.IndexName).Get(ctx, nil)
-		if err != nil {
-			return fmt.Errorf("%w: WaitForYellowStatusActivity: %v", ErrUnableToExecuteActivity, err)
+		for _, indexName := range params.indexNames() {
+			err = workflow.ExecuteActivity(ctx2, a.WaitForYellowStatusActivity, indexName).Get(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("%w: WaitForYellowStatusActivity: %v", ErrUnableToExecuteActivity, err)
+			}
 		}
 	}
 
@@ -135,25 +140,45 @@ func AddSearchAttributesWorkflow(ctx workflow.Context, params WorkflowParams) er
 	return nil
 }
 
+// indexNames returns the visibility index followed by the namespace dedicated indices.
+func (p WorkflowParams) indexNames() []string {
+	indexNames := []string{p.IndexName}
+	for _, indexName := range p.NamespaceIndexNames {
+		if indexName != p.IndexName {
+			indexNames = append(indexNames, indexName)
+		}
+	}
+	return indexNames
+}
+
 func (a *activities) AddESMappingFieldActivity(ctx context.Context, params WorkflowParams) error {
 	if a.esClient == nil {
 		a.logger.Info("Elasticsearch client is not configured. Skipping mapping update.")
 		return nil
 	}
 
-	a.logger.Info("Creating Elasticsearch mapping.", tag.ESIndex(params.IndexName), tag.ESMapping(params.CustomAttributesToAdd))
-	_, err := a.esClient.PutMapping(ctx, params.IndexName, params.CustomAttributesToAdd)
+	for _, indexName := range params.indexNames() {
+		if err := a.addESMappingField(ctx, indexName, params.CustomAttributesToAdd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *activities) addESMappingField(ctx context.Context, indexName string, customAttributesToAdd map[string]enumspb.IndexedValueType) error {
+	a.logger.Info("Creating Elasticsearch mapping.", tag.ESIndex(indexName), tag.ESMapping(customAttributesToAdd))
+	_, err := a.esClient.PutMapping(ctx, indexName, customAttributesToAdd)
 	if err != nil {
 		a.metricsHandler.Counter(metrics.AddSearchAttributesFailuresCount.Name()).Record(1)
 
 		if a.isRetryableError(err) {
-			a.logger.Error("Unable to update Elasticsearch mapping (retryable error).", tag.ESIndex(params.IndexName), tag.Error(err))
+			a.logger.Error("Unable to update Elasticsearch mapping (retryable error).", tag.ESIndex(indexName), tag.Error(err))
 			return fmt.Errorf("%w: %v", ErrUnableToUpdateESMapping, err)
 		}
-		a.logger.Error("Unable to update Elasticsearch mapping (non-retryable error).", tag.ESIndex(params.IndexName), tag.Error(err))
+		a.logger.Error("Unable to update Elasticsearch mapping (non-retryable error).", tag.ESIndex(indexName), tag.Error(err))
 		return temporal.NewNonRetryableApplicationError(fmt.Sprintf("%v: %v", ErrUnableToUpdateESMapping, err), "", nil)
 	}
-	a.logger.Info("Elasticsearch mapping created.", tag.ESIndex(params.IndexName), tag.ESMapping(params.CustomAttributesToAdd))
+	a.logger.Info("Elasticsearch mapping created.", tag.ESIndex(indexName), tag.ESMapping(customAttributesToAdd))
 
 	return nil
 }
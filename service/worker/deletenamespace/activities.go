@@ -133,7 +133,7 @@ func (a *localActivities) GenerateDeletedNamespaceNameActivity(ctx context.Conte
 	const initialSuffixLength = 5
 
 	for suffixLength := initialSuffixLength; suffixLength < len(nsID.String()); suffixLength++ { // Just in case. 5 chars from ID should be good enough.
-		suffix := fmt.Sprintf("-deleted-%s", nsID.String()[:suffixLength])
+		suffix := namespace.DeletedNameSuffix(nsID, suffixLength)
 		if strings.HasSuffix(nsName.String(), suffix) {
 			a.logger.Info("Namespace is already renamed for deletion")
 			return nsName, nil
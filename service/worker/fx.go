@@ -41,6 +41,7 @@ import (
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/visibility"
 	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/persistence/visibility/store/elasticsearch"
 	esclient "go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
 	"go.temporal.io/server/common/primitives"
 	"go.temporal.io/server/common/resolver"
@@ -54,6 +55,7 @@ import (
 	"go.temporal.io/server/service/worker/dlq"
 	"go.temporal.io/server/service/worker/migration"
 	"go.temporal.io/server/service/worker/scheduler"
+	"go.temporal.io/server/service/worker/visibilitybackfill"
 )
 
 var Module = fx.Options(
@@ -64,6 +66,7 @@ var Module = fx.Options(
 	scheduler.Module,
 	batcher.Module,
 	dlq.Module,
+	visibilitybackfill.Module,
 	fx.Provide(
 		func(c resource.HistoryClient) dlq.HistoryClient {
 			return c
@@ -87,6 +90,7 @@ var Module = fx.Options(
 		},
 	),
 	fx.Provide(VisibilityManagerProvider),
+	fx.Provide(VisibilityBackfillManagerFactoryProvider),
 	fx.Provide(dynamicconfig.NewCollection),
 	fx.Provide(ThrottledLoggerRpsFnProvider),
 	fx.Provide(ConfigProvider),
@@ -178,6 +182,50 @@ func VisibilityManagerProvider(
 	)
 }
 
+// VisibilityBackfillManagerFactoryProvider provides the factory of the visibility manager used by
+// visibility backfill. Unlike the worker visibility manager, it can write to visibility stores.
+// Visibility backfill closes the manager it creates when the worker stops.
+func VisibilityBackfillManagerFactoryProvider(
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+	persistenceConfig *config.Persistence,
+	customVisibilityStoreFactory visibility.VisibilityStoreFactory,
+	serviceConfig *Config,
+	esClient esclient.Client,
+	persistenceServiceResolver resolver.ServiceResolver,
+	searchAttributesMapperProvider searchattribute.MapperProvider,
+	saProvider searchattribute.Provider,
+) visibilitybackfill.VisibilityManagerFactory {
+	return func() (manager.VisibilityManager, error) {
+		return visibility.NewManager(
+			*persistenceConfig,
+			persistenceServiceResolver,
+			customVisibilityStoreFactory,
+			esClient,
+			&elasticsearch.ProcessorConfig{
+				IndexerConcurrency:       serviceConfig.IndexerConcurrency,
+				ESProcessorNumOfWorkers:  serviceConfig.ESProcessorNumOfWorkers,
+				ESProcessorBulkActions:   serviceConfig.ESProcessorBulkActions,
+				ESProcessorBulkSize:      serviceConfig.ESProcessorBulkSize,
+				ESProcessorFlushInterval: serviceConfig.ESProcessorFlushInterval,
+				ESProcessorAckTimeout:    serviceConfig.ESProcessorAckTimeout,
+			},
+			saProvider,
+			searchAttributesMapperProvider,
+			serviceConfig.VisibilityPersistenceMaxReadQPS,
+			serviceConfig.VisibilityPersistenceMaxWriteQPS,
+			serviceConfig.OperatorRPSRatio,
+			// Backfill reads and writes the primary and secondary stores directly.
+			dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false),
+			dynamicconfig.GetStringPropertyFn(visibility.SecondaryVisibilityWritingModeOff),
+			serviceConfig.VisibilityDisableOrderByClause,
+			serviceConfig.VisibilityEnableManualPagination,
+			metricsHandler,
+			logger,
+		)
+	}
+}
+
 func ServiceLifetimeHooks(lc fx.Lifecycle, svc *Service) {
 	lc.Append(fx.StartStopHook(svc.Start, svc.Stop))
 }
@@ -46,3 +46,13 @@ type (
 		Validate(ctx context.Context, mutableState *MutableState) ([]MutableStateValidationResult, error)
 	}
 )
+
+// FailureType returns the type tag of the failure
+func (r MutableStateValidationResult) FailureType() string {
+	return r.failureType
+}
+
+// FailureDetails returns the human readable details of the failure
+func (r MutableStateValidationResult) FailureDetails() string {
+	return r.failureDetails
+}
@@ -0,0 +1,282 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/backoff"
+	"go.temporal.io/server/common/collection"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/quotas"
+	"go.temporal.io/server/service/worker/scanner/executor"
+)
+
+type (
+	// ExecutionProcessor processes an execution listed by a ShardScavenger. The rate limiter is the one of the
+	// shard, processors making calls per execution wait on it. Errors are logged and the shard is scanned again
+	// once all of its executions are processed.
+	ExecutionProcessor func(
+		ctx context.Context,
+		shardID int32,
+		rateLimiter quotas.RateLimiter,
+		mutableState *persistencespb.WorkflowMutableState,
+	) error
+
+	// ShardScavenger holds the state shared by the scavengers iterating over the concrete executions of a range of
+	// history shards. It runs a task per shard on a fixed size pool, each task lists the executions of its shard
+	// rate limited per host and per shard, and passes them to the ExecutionProcessor.
+	ShardScavenger struct {
+		status          int32
+		name            string
+		firstShardID    int32
+		lastShardID     int32
+		activityContext context.Context
+
+		executionManager persistence.ExecutionManager
+		processor        ExecutionProcessor
+		executor         executor.Executor
+		rateLimiter      quotas.RateLimiter
+		perShardQPS      dynamicconfig.IntPropertyFn
+		metricsHandler   metrics.Handler
+		logger           log.Logger
+
+		stopC  chan struct{}
+		stopWG sync.WaitGroup
+	}
+
+	// shardTask is a runnable task that adheres to the executor.Task interface
+	// for the scavenger, each of this task processes all executions of a single shard
+	shardTask struct {
+		shardID   int32
+		scavenger *ShardScavenger
+
+		ctx             context.Context
+		rateLimiter     quotas.RateLimiter
+		paginationToken []byte
+	}
+)
+
+// NewShardScavenger returns an instance of a scavenger daemon over the executions of shards firstShardID to
+// lastShardID, name is used in its logs and operation is the metrics operation of its pool and metrics.
+// Calling the Start() method will result in one complete iteration over the executions of the shards.
+//
+// The scavenger will only stop under two conditions
+//   - either all executions are processed (or)
+//   - Stop() method is called to stop the scavenger
+func NewShardScavenger(
+	activityContext context.Context,
+	name string,
+	firstShardID int32,
+	lastShardID int32,
+	perHostQPS dynamicconfig.IntPropertyFn,
+	perShardQPS dynamicconfig.IntPropertyFn,
+	taskWorkerCount dynamicconfig.IntPropertyFn,
+	executionManager persistence.ExecutionManager,
+	processor ExecutionProcessor,
+	operation string,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+) *ShardScavenger {
+	return &ShardScavenger{
+		activityContext:  activityContext,
+		name:             name,
+		firstShardID:     firstShardID,
+		lastShardID:      lastShardID,
+		executionManager: executionManager,
+		processor:        processor,
+		executor: executor.NewFixedSizePoolExecutor(
+			taskWorkerCount(),
+			executorMaxDeferredTasks,
+			metricsHandler,
+			operation,
+		),
+		rateLimiter: quotas.NewDefaultOutgoingRateLimiter(
+			func() float64 { return float64(perHostQPS()) },
+		),
+		perShardQPS:    perShardQPS,
+		metricsHandler: metricsHandler.WithTags(metrics.OperationTag(operation)),
+		logger:         logger,
+
+		stopC: make(chan struct{}),
+	}
+}
+
+// Start starts the scavenger
+func (s *ShardScavenger) Start() {
+	if !atomic.CompareAndSwapInt32(
+		&s.status,
+		common.DaemonStatusInitialized,
+		common.DaemonStatusStarted,
+	) {
+		return
+	}
+	s.logger.Info(s.name + " scavenger starting")
+	s.stopWG.Add(1)
+	s.executor.Start()
+	go s.run()
+	s.metricsHandler.Counter(metrics.StartedCount.Name()).Record(1)
+	s.logger.Info(s.name + " scavenger started")
+}
+
+// Stop stops the scavenger
+func (s *ShardScavenger) Stop() {
+	if !atomic.CompareAndSwapInt32(
+		&s.status,
+		common.DaemonStatusStarted,
+		common.DaemonStatusStopped,
+	) {
+		return
+	}
+	s.metricsHandler.Counter(metrics.StoppedCount.Name()).Record(1)
+	s.logger.Info(s.name + " scavenger stopping")
+	close(s.stopC)
+	s.executor.Stop()
+	s.stopWG.Wait()
+	s.logger.Info(s.name + " scavenger stopped")
+}
+
+// Alive returns true if the scavenger is still running
+func (s *ShardScavenger) Alive() bool {
+	return atomic.LoadInt32(&s.status) == common.DaemonStatusStarted
+}
+
+// NewTask returns the task processing the executions of a single shard, rate limited per host and per shard.
+func (s *ShardScavenger) NewTask(shardID int32) executor.Task {
+	return &shardTask{
+		shardID:   shardID,
+		scavenger: s,
+
+		ctx: s.activityContext,
+		rateLimiter: quotas.NewMultiRateLimiter([]quotas.RateLimiter{
+			quotas.NewDefaultOutgoingRateLimiter(
+				func() float64 { return float64(s.perShardQPS()) },
+			),
+			s.rateLimiter,
+		}),
+	}
+}
+
+// run does a single run over all executions of the shard range and processes them
+func (s *ShardScavenger) run() {
+	defer func() {
+		go s.Stop()
+		s.stopWG.Done()
+	}()
+
+	for shardID := s.firstShardID; shardID <= s.lastShardID; shardID++ {
+		if !s.executor.Submit(s.NewTask(shardID)) {
+			s.logger.Error("unable to submit task to executor", tag.ShardID(shardID))
+		}
+	}
+
+	s.awaitExecutor()
+}
+
+func (s *ShardScavenger) awaitExecutor() {
+	// gauge value persists, so we want to reset it to 0
+	defer s.metricsHandler.Gauge(metrics.ExecutionsOutstandingCount.Name()).Record(float64(0))
+
+	outstanding := s.executor.TaskCount()
+	for outstanding > 0 {
+		timer := time.NewTimer(executorPollInterval)
+		select {
+		case <-timer.C:
+			outstanding = s.executor.TaskCount()
+			s.metricsHandler.Gauge(metrics.ExecutionsOutstandingCount.Name()).Record(float64(outstanding))
+		case <-s.stopC:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// Run runs the task
+func (t *shardTask) Run() executor.TaskStatus {
+	numShards := t.scavenger.lastShardID - t.scavenger.firstShardID + 1
+	time.Sleep(backoff.Jitter(
+		taskStartupDelayRatio*time.Duration(numShards),
+		taskStartupDelayRandomizationRatio,
+	))
+
+	metricsHandler := t.scavenger.metricsHandler
+	logger := t.scavenger.logger
+	iter := collection.NewPagingIteratorWithToken(t.getPaginationFn(), t.paginationToken)
+	var retryTask bool
+	for iter.HasNext() {
+		record, err := iter.Next()
+		if err != nil {
+			metricsHandler.Counter(metrics.ScavengerValidationSkipsCount.Name()).Record(1)
+			// continue validation process and retry after all workflow records has been iterated.
+			logger.Error("unable to paginate concrete execution", tag.ShardID(t.shardID), tag.Error(err))
+			retryTask = true
+			continue
+		}
+
+		if err := t.scavenger.processor(t.ctx, t.shardID, t.rateLimiter, record); err != nil {
+			// continue validation process and retry after all workflow records has been iterated.
+			executionInfo := record.GetExecutionInfo()
+			metricsHandler.Counter(metrics.ScavengerValidationSkipsCount.Name()).Record(1)
+			logger.Error("unable to process workflow execution",
+				tag.ShardID(t.shardID),
+				tag.Error(err),
+				tag.WorkflowNamespaceID(executionInfo.GetNamespaceId()),
+				tag.WorkflowID(executionInfo.GetWorkflowId()),
+				tag.WorkflowRunID(record.GetExecutionState().GetRunId()))
+			retryTask = true
+		}
+	}
+	if retryTask {
+		return executor.TaskStatusDefer
+	}
+	return executor.TaskStatusDone
+}
+
+func (t *shardTask) getPaginationFn() collection.PaginationFn[*persistencespb.WorkflowMutableState] {
+	return func(paginationToken []byte) ([]*persistencespb.WorkflowMutableState, []byte, error) {
+		_ = t.rateLimiter.Wait(t.ctx)
+		req := &persistence.ListConcreteExecutionsRequest{
+			ShardID:   t.shardID,
+			PageSize:  executionsPageSize,
+			PageToken: paginationToken,
+		}
+		resp, err := t.scavenger.executionManager.ListConcreteExecutions(t.ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		paginateItems := resp.States
+		t.paginationToken = resp.PageToken
+		return paginateItems, resp.PageToken, nil
+	}
+}
@@ -0,0 +1,119 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/quotas"
+	"go.temporal.io/server/service/worker/scanner/executor"
+)
+
+func TestShardScavenger_Task(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	executionManager := persistence.NewMockExecutionManager(ctrl)
+
+	var processed []string
+	scavenger := newTestShardScavenger(executionManager, func(
+		_ context.Context,
+		shardID int32,
+		_ quotas.RateLimiter,
+		mutableState *persistencespb.WorkflowMutableState,
+	) error {
+		require.Equal(t, int32(3), shardID)
+		workflowID := mutableState.GetExecutionInfo().GetWorkflowId()
+		processed = append(processed, workflowID)
+		if workflowID == "failed" && len(processed) < 4 {
+			return errors.New("process failed")
+		}
+		return nil
+	})
+
+	gomock.InOrder(
+		executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), &persistence.ListConcreteExecutionsRequest{
+			ShardID:  3,
+			PageSize: executionsPageSize,
+		}).Return(&persistence.ListConcreteExecutionsResponse{
+			States:    []*persistencespb.WorkflowMutableState{testShardMutableState("first"), testShardMutableState("failed")},
+			PageToken: []byte("token"),
+		}, nil),
+		executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), &persistence.ListConcreteExecutionsRequest{
+			ShardID:   3,
+			PageSize:  executionsPageSize,
+			PageToken: []byte("token"),
+		}).Return(nil, errors.New("list failed")),
+		// the deferred task resumes from the page which failed
+		executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), &persistence.ListConcreteExecutionsRequest{
+			ShardID:   3,
+			PageSize:  executionsPageSize,
+			PageToken: []byte("token"),
+		}).Return(&persistence.ListConcreteExecutionsResponse{
+			States: []*persistencespb.WorkflowMutableState{testShardMutableState("last"), testShardMutableState("failed")},
+		}, nil),
+	)
+
+	task := scavenger.NewTask(3)
+	require.Equal(t, executor.TaskStatusDefer, task.Run())
+	require.Equal(t, []string{"first", "failed"}, processed)
+	require.Equal(t, executor.TaskStatusDone, task.Run())
+	require.Equal(t, []string{"first", "failed", "last", "failed"}, processed)
+}
+
+func newTestShardScavenger(
+	executionManager persistence.ExecutionManager,
+	processor ExecutionProcessor,
+) *ShardScavenger {
+	return NewShardScavenger(
+		context.Background(),
+		"Test",
+		3,
+		3,
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFn(1),
+		executionManager,
+		processor,
+		metrics.ExecutionsScavengerScope,
+		metrics.NoopMetricsHandler,
+		log.NewTestLogger(),
+	)
+}
+
+func testShardMutableState(workflowID string) *persistencespb.WorkflowMutableState {
+	return &persistencespb.WorkflowMutableState{
+		ExecutionInfo:  &persistencespb.WorkflowExecutionInfo{WorkflowId: workflowID},
+		ExecutionState: &persistencespb.WorkflowExecutionState{RunId: "run-" + workflowID},
+	}
+}
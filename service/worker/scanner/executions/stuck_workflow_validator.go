@@ -0,0 +1,267 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/service/history/workflow"
+)
+
+const (
+	stuckWorkflowTaskFailureType    = "stuck_workflow_workflow_task"
+	stuckActivityTimeoutFailureType = "stuck_workflow_activity_timeout"
+	stuckBufferedEventsFailureType  = "stuck_workflow_buffered_events"
+)
+
+type (
+	// workflowTaskValidator reports running workflows with a workflow task scheduled long ago and never started.
+	workflowTaskValidator struct {
+		maxScheduledAge dynamicconfig.DurationPropertyFn
+	}
+
+	// activityTimeoutValidator reports running workflows with an activity past one of its timeouts. The timer task
+	// of the timeout times out the activity, so such an activity is missing its timer task or the timer task is not
+	// processed.
+	activityTimeoutValidator struct {
+		timeoutBuffer dynamicconfig.DurationPropertyFn
+	}
+
+	// bufferedEventsValidator reports running workflows with a large buffered events backlog, or with buffered
+	// events and no started workflow task which would flush them.
+	bufferedEventsValidator struct {
+		maxBufferedEvents dynamicconfig.IntPropertyFn
+	}
+)
+
+var _ Validator = (*workflowTaskValidator)(nil)
+var _ Validator = (*activityTimeoutValidator)(nil)
+var _ Validator = (*bufferedEventsValidator)(nil)
+
+// NewWorkflowTaskValidator returns new instance.
+func NewWorkflowTaskValidator(
+	maxScheduledAge dynamicconfig.DurationPropertyFn,
+) *workflowTaskValidator {
+	return &workflowTaskValidator{
+		maxScheduledAge: maxScheduledAge,
+	}
+}
+
+// NewActivityTimeoutValidator returns new instance.
+func NewActivityTimeoutValidator(
+	timeoutBuffer dynamicconfig.DurationPropertyFn,
+) *activityTimeoutValidator {
+	return &activityTimeoutValidator{
+		timeoutBuffer: timeoutBuffer,
+	}
+}
+
+// NewBufferedEventsValidator returns new instance.
+func NewBufferedEventsValidator(
+	maxBufferedEvents dynamicconfig.IntPropertyFn,
+) *bufferedEventsValidator {
+	return &bufferedEventsValidator{
+		maxBufferedEvents: maxBufferedEvents,
+	}
+}
+
+// Validate checks that the pending workflow task of a running workflow is started in time.
+func (v *workflowTaskValidator) Validate(
+	_ context.Context,
+	mutableState *MutableState,
+) ([]MutableStateValidationResult, error) {
+	executionInfo := mutableState.GetExecutionInfo()
+	if !isRunning(mutableState) ||
+		executionInfo.GetWorkflowTaskScheduledEventId() == common.EmptyEventID ||
+		executionInfo.GetWorkflowTaskStartedEventId() != common.EmptyEventID ||
+		// speculative workflow tasks are not persisted
+		executionInfo.GetWorkflowTaskType() == enumsspb.WORKFLOW_TASK_TYPE_SPECULATIVE {
+		return nil, nil
+	}
+
+	scheduledTime := timestamp.TimeValue(executionInfo.GetWorkflowTaskScheduledTime())
+	if scheduledTime.IsZero() {
+		return nil, nil
+	}
+	age := time.Since(scheduledTime)
+	if age <= v.maxScheduledAge() {
+		return nil, nil
+	}
+	return []MutableStateValidationResult{{
+		failureType: stuckWorkflowTaskFailureType,
+		failureDetails: fmt.Sprintf(
+			"WorkflowTask scheduled event ID: %d, attempt: %d, scheduled %s ago and not started",
+			executionInfo.GetWorkflowTaskScheduledEventId(),
+			executionInfo.GetWorkflowTaskAttempt(),
+			age.Truncate(time.Second),
+		),
+	}}, nil
+}
+
+// Validate checks that no activity of a running workflow is past one of its timeouts.
+func (v *activityTimeoutValidator) Validate(
+	_ context.Context,
+	mutableState *MutableState,
+) ([]MutableStateValidationResult, error) {
+	if !isRunning(mutableState) {
+		return nil, nil
+	}
+
+	var results []MutableStateValidationResult
+	deadline := time.Now().UTC().Add(-v.timeoutBuffer())
+	for _, activityInfo := range mutableState.GetActivityInfos() {
+		for _, timeout := range activityTimeouts(activityInfo) {
+			if timeout.timeoutTime.IsZero() || !timeout.timeoutTime.Before(deadline) {
+				continue
+			}
+			results = append(results, MutableStateValidationResult{
+				failureType: stuckActivityTimeoutFailureType,
+				failureDetails: fmt.Sprintf(
+					"Activity scheduled event ID: %d, attempt: %d, %s timeout passed at %s, timer task created: %t",
+					activityInfo.GetScheduledEventId(),
+					activityInfo.GetAttempt(),
+					timeout.timeoutType,
+					timeout.timeoutTime.Format(time.RFC3339),
+					activityInfo.GetTimerTaskStatus()&timeout.timerTaskStatus != 0,
+				),
+			})
+			// one timeout is enough to report the activity
+			break
+		}
+	}
+	return results, nil
+}
+
+type activityTimeout struct {
+	timeoutType     enumspb.TimeoutType
+	timeoutTime     time.Time
+	timerTaskStatus int32
+}
+
+// activityTimeouts follows the timer sequence of the history service to compute the timeouts of an activity.
+func activityTimeouts(
+	activityInfo *persistencespb.ActivityInfo,
+) []activityTimeout {
+	// activity is not scheduled yet, probably due to retry & backoff
+	if activityInfo.GetScheduledEventId() == common.EmptyEventID {
+		return nil
+	}
+
+	var timeouts []activityTimeout
+	scheduledTime := timestamp.TimeValue(activityInfo.GetScheduledTime())
+	if duration := timestamp.DurationValue(activityInfo.GetScheduleToCloseTimeout()); duration > 0 {
+		timeouts = append(timeouts, activityTimeout{
+			timeoutType:     enumspb.TIMEOUT_TYPE_SCHEDULE_TO_CLOSE,
+			timeoutTime:     scheduledTime.Add(duration),
+			timerTaskStatus: workflow.TimerTaskStatusCreatedScheduleToClose,
+		})
+	}
+
+	if activityInfo.GetStartedEventId() == common.EmptyEventID {
+		if duration := timestamp.DurationValue(activityInfo.GetScheduleToStartTimeout()); duration > 0 {
+			timeouts = append(timeouts, activityTimeout{
+				timeoutType:     enumspb.TIMEOUT_TYPE_SCHEDULE_TO_START,
+				timeoutTime:     scheduledTime.Add(duration),
+				timerTaskStatus: workflow.TimerTaskStatusCreatedScheduleToStart,
+			})
+		}
+		return timeouts
+	}
+
+	startedTime := timestamp.TimeValue(activityInfo.GetStartedTime())
+	if duration := timestamp.DurationValue(activityInfo.GetStartToCloseTimeout()); duration > 0 {
+		timeouts = append(timeouts, activityTimeout{
+			timeoutType:     enumspb.TIMEOUT_TYPE_START_TO_CLOSE,
+			timeoutTime:     startedTime.Add(duration),
+			timerTaskStatus: workflow.TimerTaskStatusCreatedStartToClose,
+		})
+	}
+	if duration := timestamp.DurationValue(activityInfo.GetHeartbeatTimeout()); duration > 0 {
+		// use the latest time as last heartbeat time
+		lastHeartbeat := startedTime
+		if lastHeartbeatUpdateTime := timestamp.TimeValue(activityInfo.GetLastHeartbeatUpdateTime()); lastHeartbeatUpdateTime.After(lastHeartbeat) {
+			lastHeartbeat = lastHeartbeatUpdateTime
+		}
+		timeouts = append(timeouts, activityTimeout{
+			timeoutType:     enumspb.TIMEOUT_TYPE_HEARTBEAT,
+			timeoutTime:     lastHeartbeat.Add(duration),
+			timerTaskStatus: workflow.TimerTaskStatusCreatedHeartbeat,
+		})
+	}
+	return timeouts
+}
+
+// Validate checks that buffered events of a running workflow are flushed.
+func (v *bufferedEventsValidator) Validate(
+	_ context.Context,
+	mutableState *MutableState,
+) ([]MutableStateValidationResult, error) {
+	bufferedEvents := len(mutableState.GetBufferedEvents())
+	if !isRunning(mutableState) || bufferedEvents == 0 {
+		return nil, nil
+	}
+
+	// Events are only buffered while a workflow task is started, completing the workflow task flushes them.
+	if mutableState.GetExecutionInfo().GetWorkflowTaskStartedEventId() == common.EmptyEventID {
+		return []MutableStateValidationResult{{
+			failureType: stuckBufferedEventsFailureType,
+			failureDetails: fmt.Sprintf(
+				"%d buffered events without a started workflow task",
+				bufferedEvents,
+			),
+		}}, nil
+	}
+	if bufferedEvents > v.maxBufferedEvents() {
+		return []MutableStateValidationResult{{
+			failureType: stuckBufferedEventsFailureType,
+			failureDetails: fmt.Sprintf(
+				"%d buffered events exceed the limit of %d",
+				bufferedEvents,
+				v.maxBufferedEvents(),
+			),
+		}}, nil
+	}
+	return nil, nil
+}
+
+func isRunning(
+	mutableState *MutableState,
+) bool {
+	switch mutableState.GetExecutionState().GetState() {
+	case enumsspb.WORKFLOW_EXECUTION_STATE_CREATED, enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,133 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/dynamicconfig"
+)
+
+func TestActivityTimeoutValidator(t *testing.T) {
+	now := time.Now()
+	validator := NewActivityTimeoutValidator(dynamicconfig.GetDurationPropertyFn(time.Hour))
+
+	for _, tc := range []struct {
+		name         string
+		state        enumsspb.WorkflowExecutionState
+		activityInfo *persistencespb.ActivityInfo
+		expectStuck  bool
+	}{
+		{
+			name:  "schedule to start timeout passed",
+			state: enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+			activityInfo: &persistencespb.ActivityInfo{
+				ScheduledEventId:       5,
+				ScheduledTime:          timestamppb.New(now.Add(-3 * time.Hour)),
+				StartedEventId:         common.EmptyEventID,
+				ScheduleToStartTimeout: durationpb.New(time.Hour),
+			},
+			expectStuck: true,
+		},
+		{
+			name:  "schedule to start timeout within buffer",
+			state: enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+			activityInfo: &persistencespb.ActivityInfo{
+				ScheduledEventId:       5,
+				ScheduledTime:          timestamppb.New(now.Add(-90 * time.Minute)),
+				StartedEventId:         common.EmptyEventID,
+				ScheduleToStartTimeout: durationpb.New(time.Hour),
+			},
+		},
+		{
+			name:  "heartbeat timeout passed",
+			state: enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+			activityInfo: &persistencespb.ActivityInfo{
+				ScheduledEventId:        5,
+				ScheduledTime:           timestamppb.New(now.Add(-5 * time.Hour)),
+				StartedEventId:          6,
+				StartedTime:             timestamppb.New(now.Add(-5 * time.Hour)),
+				HeartbeatTimeout:        durationpb.New(time.Minute),
+				LastHeartbeatUpdateTime: timestamppb.New(now.Add(-2 * time.Hour)),
+			},
+			expectStuck: true,
+		},
+		{
+			name:  "recent heartbeat",
+			state: enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+			activityInfo: &persistencespb.ActivityInfo{
+				ScheduledEventId:        5,
+				ScheduledTime:           timestamppb.New(now.Add(-5 * time.Hour)),
+				StartedEventId:          6,
+				StartedTime:             timestamppb.New(now.Add(-5 * time.Hour)),
+				HeartbeatTimeout:        durationpb.New(time.Minute),
+				LastHeartbeatUpdateTime: timestamppb.New(now),
+			},
+		},
+		{
+			name:  "not scheduled yet",
+			state: enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+			activityInfo: &persistencespb.ActivityInfo{
+				ScheduledEventId:       common.EmptyEventID,
+				ScheduledTime:          timestamppb.New(now.Add(-3 * time.Hour)),
+				ScheduleToCloseTimeout: durationpb.New(time.Minute),
+			},
+		},
+		{
+			name:  "workflow completed",
+			state: enumsspb.WORKFLOW_EXECUTION_STATE_COMPLETED,
+			activityInfo: &persistencespb.ActivityInfo{
+				ScheduledEventId:       5,
+				ScheduledTime:          timestamppb.New(now.Add(-3 * time.Hour)),
+				ScheduleToCloseTimeout: durationpb.New(time.Minute),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := validator.Validate(context.Background(), &MutableState{
+				WorkflowMutableState: &persistencespb.WorkflowMutableState{
+					ExecutionState: &persistencespb.WorkflowExecutionState{State: tc.state},
+					ActivityInfos:  map[int64]*persistencespb.ActivityInfo{5: tc.activityInfo},
+				},
+			})
+			require.NoError(t, err)
+			if !tc.expectStuck {
+				require.Empty(t, results)
+				return
+			}
+			require.Len(t, results, 1)
+			require.Equal(t, stuckActivityTimeoutFailureType, results[0].FailureType())
+		})
+	}
+}
@@ -48,6 +48,7 @@ import (
 	"go.temporal.io/server/common/persistence/visibility/manager"
 	"go.temporal.io/server/common/sdk"
 	"go.temporal.io/server/service/worker/scanner/build_ids"
+	"go.temporal.io/server/service/worker/scanner/visibility_retention"
 
 	"go.temporal.io/server/common/backoff"
 	"go.temporal.io/server/common/dynamicconfig"
@@ -74,6 +75,12 @@ type (
 		HistoryScannerEnabled dynamicconfig.BoolPropertyFn
 		// ExecutionsScannerEnabled indicates if executions scanner should be started as part of scanner
 		ExecutionsScannerEnabled dynamicconfig.BoolPropertyFn
+		// VisibilityScannerEnabled indicates if visibility scanner should be started as part of scanner
+		VisibilityScannerEnabled dynamicconfig.BoolPropertyFn
+		// VisibilityRetentionScannerEnabled indicates if visibility retention scanner should be started as part of scanner
+		VisibilityRetentionScannerEnabled dynamicconfig.BoolPropertyFn
+		// StuckWorkflowScannerEnabled indicates if stuck workflow scanner should be started as part of scanner
+		StuckWorkflowScannerEnabled dynamicconfig.BoolPropertyFn
 		// HistoryScannerDataMinAge indicates the cleanup threshold of history branch data
 		// Only clean up history branches that older than this threshold
 		HistoryScannerDataMinAge dynamicconfig.DurationPropertyFn
@@ -89,12 +96,44 @@ type (
 		ExecutionScannerWorkerCount dynamicconfig.IntPropertyFn
 		// ExecutionScannerHistoryEventIdValidator indicates if the execution scavenger to validate history event id.
 		ExecutionScannerHistoryEventIdValidator dynamicconfig.BoolPropertyFn
+		// VisibilityScannerPerHostQPS the max rate of calls to scan visibility data per host
+		VisibilityScannerPerHostQPS dynamicconfig.IntPropertyFn
+		// VisibilityScannerPerShardQPS the max rate of calls to scan visibility data per shard
+		VisibilityScannerPerShardQPS dynamicconfig.IntPropertyFn
+		// VisibilityScannerWorkerCount is the visibility scavenger task worker number
+		VisibilityScannerWorkerCount dynamicconfig.IntPropertyFn
+		// VisibilityScannerSampleRate is the fraction of executions checked by the visibility scavenger
+		VisibilityScannerSampleRate dynamicconfig.FloatPropertyFn
+		// VisibilityScannerMinAge is the minimum time since the last update of an execution before it is checked
+		VisibilityScannerMinAge dynamicconfig.DurationPropertyFn
+		// VisibilityScannerRepairEnabled indicates if the visibility scavenger regenerates visibility tasks on mismatch
+		VisibilityScannerRepairEnabled dynamicconfig.BoolPropertyFn
+		// StuckWorkflowScannerPerHostQPS the max rate of calls to scan execution data per host for stuck workflows
+		StuckWorkflowScannerPerHostQPS dynamicconfig.IntPropertyFn
+		// StuckWorkflowScannerPerShardQPS the max rate of calls to scan execution data per shard for stuck workflows
+		StuckWorkflowScannerPerShardQPS dynamicconfig.IntPropertyFn
+		// StuckWorkflowScannerWorkerCount is the stuck workflow scavenger task worker number
+		StuckWorkflowScannerWorkerCount dynamicconfig.IntPropertyFn
+		// StuckWorkflowScannerWorkflowTaskMaxAge is the age of a scheduled and not started workflow task after which
+		// its workflow is reported as stuck
+		StuckWorkflowScannerWorkflowTaskMaxAge dynamicconfig.DurationPropertyFn
+		// StuckWorkflowScannerActivityTimeoutBuffer is the time past an activity timeout after which its workflow is
+		// reported as stuck
+		StuckWorkflowScannerActivityTimeoutBuffer dynamicconfig.DurationPropertyFn
+		// StuckWorkflowScannerMaxBufferedEvents is the number of buffered events above which a workflow is reported
+		// as stuck
+		StuckWorkflowScannerMaxBufferedEvents dynamicconfig.IntPropertyFn
+		// StuckWorkflowScannerRemediationEnabled indicates if the stuck workflow scavenger refreshes the tasks of
+		// stuck workflows
+		StuckWorkflowScannerRemediationEnabled dynamicconfig.BoolPropertyFn
 
 		// RemovableBuildIdDurationSinceDefault is the minimum duration since a build id was last default in its
 		// containing set for it to be considered for removal.
 		RemovableBuildIdDurationSinceDefault dynamicconfig.DurationPropertyFn
 		// BuildIdScavengerVisibilityRPS is the rate limit for visibility calls from the build id scavenger
 		BuildIdScavengerVisibilityRPS dynamicconfig.FloatPropertyFn
+		// VisibilityRetentionScannerRPS is the rate limit for visibility calls from the visibility retention scanner
+		VisibilityRetentionScannerRPS dynamicconfig.FloatPropertyFn
 	}
 
 	// scannerContext is the context object that gets
@@ -186,6 +225,23 @@ func (s *Scanner) Start() error {
 		workerTaskQueueNames = append(workerTaskQueueNames, executionsScannerTaskQueueName)
 	}
 
+	if s.context.cfg.VisibilityScannerEnabled() {
+		s.wg.Add(1)
+		go s.startWorkflowWithRetry(ctx, visibilityScannerWFStartOptions, visibilityScannerWFTypeName)
+		workerTaskQueueNames = append(workerTaskQueueNames, visibilityScannerTaskQueueName)
+	}
+
+	if s.context.cfg.StuckWorkflowScannerEnabled() {
+		s.wg.Add(1)
+		go s.startWorkflowWithRetry(
+			ctx,
+			stuckWorkflowScannerWFStartOptions,
+			stuckWorkflowScannerWFTypeName,
+			s.context.cfg.Persistence.NumHistoryShards,
+		)
+		workerTaskQueueNames = append(workerTaskQueueNames, stuckWorkflowScannerTaskQueueName)
+	}
+
 	if s.context.cfg.Persistence.DefaultStoreType() == config.StoreTypeSQL && s.context.cfg.TaskQueueScannerEnabled() {
 		s.wg.Add(1)
 		go s.startWorkflowWithRetry(ctx, tlScannerWFStartOptions, tqScannerWFTypeName)
@@ -224,6 +280,28 @@ func (s *Scanner) Start() error {
 		}
 	}
 
+	if s.context.cfg.VisibilityRetentionScannerEnabled() {
+		s.wg.Add(1)
+		go s.startWorkflowWithRetry(ctx, visibility_retention.ScavengerWFStartOptions, visibility_retention.ScavengerWorkflowName)
+
+		visibilityRetentionActivities := visibility_retention.NewActivities(
+			s.context.logger,
+			s.context.metadataManager,
+			s.context.visibilityManager,
+			s.context.metricsHandler,
+			s.context.cfg.VisibilityRetentionScannerRPS,
+		)
+
+		work := s.context.sdkClientFactory.NewWorker(s.context.sdkClientFactory.GetSystemClient(), visibility_retention.ScavengerTaskQueueName, workerOpts)
+		work.RegisterWorkflowWithOptions(visibility_retention.ScavengerWorkflow, workflow.RegisterOptions{Name: visibility_retention.ScavengerWorkflowName})
+		work.RegisterActivityWithOptions(visibilityRetentionActivities.ScavengeVisibilityRecords, activity.RegisterOptions{Name: visibility_retention.ScavengerActivityName})
+
+		// TODO: Nothing is gracefully stopping these workers or listening for fatal errors.
+		if err := work.Start(); err != nil {
+			return err
+		}
+	}
+
 	// TODO: There's no reason to register all activities and workflows on every task queue.
 	for _, tl := range workerTaskQueueNames {
 		work := s.context.sdkClientFactory.NewWorker(s.context.sdkClientFactory.GetSystemClient(), tl, workerOpts)
@@ -231,9 +309,13 @@ func (s *Scanner) Start() error {
 		work.RegisterWorkflowWithOptions(TaskQueueScannerWorkflow, workflow.RegisterOptions{Name: tqScannerWFTypeName})
 		work.RegisterWorkflowWithOptions(HistoryScannerWorkflow, workflow.RegisterOptions{Name: historyScannerWFTypeName})
 		work.RegisterWorkflowWithOptions(ExecutionsScannerWorkflow, workflow.RegisterOptions{Name: executionsScannerWFTypeName})
+		work.RegisterWorkflowWithOptions(VisibilityScannerWorkflow, workflow.RegisterOptions{Name: visibilityScannerWFTypeName})
+		work.RegisterWorkflowWithOptions(StuckWorkflowScannerWorkflow, workflow.RegisterOptions{Name: stuckWorkflowScannerWFTypeName})
 		work.RegisterActivityWithOptions(TaskQueueScavengerActivity, activity.RegisterOptions{Name: taskQueueScavengerActivityName})
 		work.RegisterActivityWithOptions(HistoryScavengerActivity, activity.RegisterOptions{Name: historyScavengerActivityName})
 		work.RegisterActivityWithOptions(ExecutionsScavengerActivity, activity.RegisterOptions{Name: executionsScavengerActivityName})
+		work.RegisterActivityWithOptions(VisibilityScavengerActivity, activity.RegisterOptions{Name: visibilityScavengerActivityName})
+		work.RegisterActivityWithOptions(StuckWorkflowScavengerActivity, activity.RegisterOptions{Name: stuckWorkflowScavengerActivityName})
 
 		// TODO: Nothing is gracefully stopping these workers or listening for fatal errors.
 		if err := work.Start(); err != nil {
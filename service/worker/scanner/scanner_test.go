@@ -44,6 +44,7 @@ import (
 	"go.temporal.io/server/common/sdk"
 	"go.temporal.io/server/common/testing/mocksdk"
 	"go.temporal.io/server/service/worker/scanner/build_ids"
+	"go.temporal.io/server/service/worker/scanner/visibility_retention"
 )
 
 type scannerTestSuite struct {
@@ -64,6 +65,10 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 		TaskQueueName: executionsScannerTaskQueueName,
 	}
 	_ = executionScanner
+	visibilityScanner := expectedScanner{
+		WFTypeName:    visibilityScannerWFTypeName,
+		TaskQueueName: visibilityScannerTaskQueueName,
+	}
 	taskQueueScanner := expectedScanner{
 		WFTypeName:    tqScannerWFTypeName,
 		TaskQueueName: tqScannerTaskQueueName,
@@ -76,15 +81,26 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 		WFTypeName:    build_ids.BuildIdScavangerWorkflowName,
 		TaskQueueName: build_ids.BuildIdScavengerTaskQueueName,
 	}
+	stuckWorkflowScanner := expectedScanner{
+		WFTypeName:    stuckWorkflowScannerWFTypeName,
+		TaskQueueName: stuckWorkflowScannerTaskQueueName,
+	}
+	visibilityRetentionScavenger := expectedScanner{
+		WFTypeName:    visibility_retention.ScavengerWorkflowName,
+		TaskQueueName: visibility_retention.ScavengerTaskQueueName,
+	}
 
 	type testCase struct {
-		Name                     string
-		ExecutionsScannerEnabled bool
-		TaskQueueScannerEnabled  bool
-		HistoryScannerEnabled    bool
-		BuildIdScavengerEnabled  bool
-		DefaultStore             string
-		ExpectedScanners         []expectedScanner
+		Name                              string
+		ExecutionsScannerEnabled          bool
+		VisibilityScannerEnabled          bool
+		VisibilityRetentionScannerEnabled bool
+		StuckWorkflowScannerEnabled       bool
+		TaskQueueScannerEnabled           bool
+		HistoryScannerEnabled             bool
+		BuildIdScavengerEnabled           bool
+		DefaultStore                      string
+		ExpectedScanners                  []expectedScanner
 	}
 
 	for _, c := range []testCase{
@@ -160,6 +176,18 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 			DefaultStore:             config.StoreTypeSQL,
 			ExpectedScanners:         []expectedScanner{executionScanner},
 		},
+		{
+			Name:                     "VisibilityScanner",
+			VisibilityScannerEnabled: true,
+			DefaultStore:             config.StoreTypeSQL,
+			ExpectedScanners:         []expectedScanner{visibilityScanner},
+		},
+		{
+			Name:                        "StuckWorkflowScanner",
+			StuckWorkflowScannerEnabled: true,
+			DefaultStore:                config.StoreTypeNoSQL,
+			ExpectedScanners:            []expectedScanner{stuckWorkflowScanner},
+		},
 		{
 			Name:                     "BuildIdScavengerNoSQL",
 			ExecutionsScannerEnabled: false,
@@ -178,6 +206,12 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 			DefaultStore:             config.StoreTypeSQL,
 			ExpectedScanners:         []expectedScanner{buildIdScavenger},
 		},
+		{
+			Name:                              "VisibilityRetentionScanner",
+			VisibilityRetentionScannerEnabled: true,
+			DefaultStore:                      config.StoreTypeNoSQL,
+			ExpectedScanners:                  []expectedScanner{visibilityRetentionScavenger},
+		},
 		{
 			Name:                     "AllScannersSQL",
 			ExecutionsScannerEnabled: true,
@@ -204,6 +238,9 @@ func (s *scannerTestSuite) TestScannerEnabled() {
 					HistoryScannerEnabled:                  dynamicconfig.GetBoolPropertyFn(c.HistoryScannerEnabled),
 					BuildIdScavengerEnabled:                dynamicconfig.GetBoolPropertyFn(c.BuildIdScavengerEnabled),
 					ExecutionsScannerEnabled:               dynamicconfig.GetBoolPropertyFn(c.ExecutionsScannerEnabled),
+					VisibilityScannerEnabled:               dynamicconfig.GetBoolPropertyFn(c.VisibilityScannerEnabled),
+					VisibilityRetentionScannerEnabled:      dynamicconfig.GetBoolPropertyFn(c.VisibilityRetentionScannerEnabled),
+					StuckWorkflowScannerEnabled:            dynamicconfig.GetBoolPropertyFn(c.StuckWorkflowScannerEnabled),
 					TaskQueueScannerEnabled:                dynamicconfig.GetBoolPropertyFn(c.TaskQueueScannerEnabled),
 					Persistence: &config.Persistence{
 						DefaultStore: c.DefaultStore,
@@ -281,6 +318,9 @@ func (s *scannerTestSuite) TestScannerShutdown() {
 			MaxConcurrentWorkflowTaskPollers:       dynamicconfig.GetIntPropertyFn(1),
 			HistoryScannerEnabled:                  dynamicconfig.GetBoolPropertyFn(true),
 			ExecutionsScannerEnabled:               dynamicconfig.GetBoolPropertyFn(false),
+			VisibilityScannerEnabled:               dynamicconfig.GetBoolPropertyFn(false),
+			VisibilityRetentionScannerEnabled:      dynamicconfig.GetBoolPropertyFn(false),
+			StuckWorkflowScannerEnabled:            dynamicconfig.GetBoolPropertyFn(false),
 			TaskQueueScannerEnabled:                dynamicconfig.GetBoolPropertyFn(false),
 			BuildIdScavengerEnabled:                dynamicconfig.GetBoolPropertyFn(false),
 			Persistence: &config.Persistence{
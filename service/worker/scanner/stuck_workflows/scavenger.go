@@ -0,0 +1,308 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package stuck_workflows
+
+import (
+	"context"
+	"sync"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/quotas"
+	"go.temporal.io/server/service/worker/scanner/executions"
+)
+
+const (
+	// ReportQueryType is the query type returning the report of the shards scanned so far by the stuck workflow
+	// scanner workflow.
+	ReportQueryType = "report"
+
+	// maxReportFindings caps the number of findings kept in the report, all findings are logged.
+	maxReportFindings = 1000
+)
+
+type (
+	// Scavenger is the type that holds the state for stuck workflow scavenger daemon
+	Scavenger struct {
+		*executions.ShardScavenger
+
+		historyClient      historyservice.HistoryServiceClient
+		validators         []executions.Validator
+		remediationEnabled dynamicconfig.BoolPropertyFn
+		metricsHandler     metrics.Handler
+		logger             log.Logger
+
+		reportLock sync.Mutex
+		report     Report
+	}
+
+	// ShardRange is an inclusive range of history shard IDs
+	ShardRange struct {
+		FirstShardID int32
+		LastShardID  int32
+	}
+
+	// Report is the findings report of a stuck workflow scavenger run
+	Report struct {
+		ExecutionsChecked   int64
+		StuckExecutions     int64
+		Remediated          int64
+		RemediationFailures int64
+		// Findings holds the first maxReportFindings findings
+		Findings []Finding
+	}
+
+	// Finding is a single failed invariant check of a stuck workflow execution.
+	Finding struct {
+		ShardID     int32
+		NamespaceID string
+		WorkflowID  string
+		RunID       string
+		FailureType string
+		Details     string
+		Remediated  bool
+	}
+)
+
+// NewScavenger returns an instance of stuck workflow scavenger daemon
+// The Scavenger can be started by calling the Start() method on the
+// returned object. Calling the Start() method will result in one
+// complete iteration over the workflow executions of the shard range. Running
+// executions are checked by the validators, and the ones failing a check are
+// reported through metrics, logs and the Report. If remediation is enabled,
+// tasks of the stuck executions are refreshed.
+//
+// The scavenger will only stop under two conditions
+//   - either all executions are processed (or)
+//   - Stop() method is called to stop the scavenger
+func NewScavenger(
+	activityContext context.Context,
+	shards ShardRange,
+	perHostQPS dynamicconfig.IntPropertyFn,
+	perShardQPS dynamicconfig.IntPropertyFn,
+	taskWorkerCount dynamicconfig.IntPropertyFn,
+	remediationEnabled dynamicconfig.BoolPropertyFn,
+	validators []executions.Validator,
+	executionManager persistence.ExecutionManager,
+	historyClient historyservice.HistoryServiceClient,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+) *Scavenger {
+	s := &Scavenger{
+		historyClient:      historyClient,
+		validators:         validators,
+		remediationEnabled: remediationEnabled,
+		metricsHandler:     metricsHandler.WithTags(metrics.OperationTag(metrics.StuckWorkflowScavengerScope)),
+		logger:             logger,
+	}
+	s.ShardScavenger = executions.NewShardScavenger(
+		activityContext,
+		"Stuck workflow",
+		shards.FirstShardID,
+		shards.LastShardID,
+		perHostQPS,
+		perShardQPS,
+		taskWorkerCount,
+		executionManager,
+		s.processExecution,
+		metrics.StuckWorkflowScavengerScope,
+		metricsHandler,
+		logger,
+	)
+	return s
+}
+
+// Report returns a copy of the findings report collected so far
+func (s *Scavenger) Report() Report {
+	s.reportLock.Lock()
+	defer s.reportLock.Unlock()
+
+	report := s.report
+	report.Findings = append([]Finding(nil), s.report.Findings...)
+	return report
+}
+
+// Merge adds the counts and findings of another report to the report
+func (r *Report) Merge(other Report) {
+	r.ExecutionsChecked += other.ExecutionsChecked
+	r.StuckExecutions += other.StuckExecutions
+	r.Remediated += other.Remediated
+	r.RemediationFailures += other.RemediationFailures
+	for _, finding := range other.Findings {
+		if len(r.Findings) >= maxReportFindings {
+			return
+		}
+		r.Findings = append(r.Findings, finding)
+	}
+}
+
+func (s *Scavenger) recordChecked() {
+	s.reportLock.Lock()
+	defer s.reportLock.Unlock()
+
+	s.report.ExecutionsChecked++
+}
+
+func (s *Scavenger) recordStuck(findings []Finding, remediationAttempted bool, remediationErr error) {
+	s.reportLock.Lock()
+	defer s.reportLock.Unlock()
+
+	report := Report{StuckExecutions: 1, Findings: findings}
+	if remediationAttempted {
+		if remediationErr != nil {
+			report.RemediationFailures++
+		} else {
+			report.Remediated++
+		}
+	}
+	s.report.Merge(report)
+}
+
+// processExecution validates a running execution, and remediates it if it is stuck and remediation is enabled.
+func (s *Scavenger) processExecution(
+	ctx context.Context,
+	shardID int32,
+	rateLimiter quotas.RateLimiter,
+	mutableState *persistencespb.WorkflowMutableState,
+) error {
+	if !s.shouldValidate(mutableState) {
+		return nil
+	}
+	return s.validate(ctx, shardID, rateLimiter, mutableState)
+}
+
+// shouldValidate filters out executions that are not running, closed executions can't be stuck.
+func (s *Scavenger) shouldValidate(
+	mutableState *persistencespb.WorkflowMutableState,
+) bool {
+	switch mutableState.GetExecutionState().GetState() {
+	case enumsspb.WORKFLOW_EXECUTION_STATE_CREATED, enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *Scavenger) validate(
+	ctx context.Context,
+	shardID int32,
+	rateLimiter quotas.RateLimiter,
+	mutableState *persistencespb.WorkflowMutableState,
+) error {
+	executionInfo := mutableState.GetExecutionInfo()
+	var findings []Finding
+	for _, validator := range s.validators {
+		results, err := validator.Validate(ctx, &executions.MutableState{WorkflowMutableState: mutableState})
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			findings = append(findings, Finding{
+				ShardID:     shardID,
+				NamespaceID: executionInfo.GetNamespaceId(),
+				WorkflowID:  executionInfo.GetWorkflowId(),
+				RunID:       mutableState.GetExecutionState().GetRunId(),
+				FailureType: result.FailureType(),
+				Details:     result.FailureDetails(),
+			})
+		}
+	}
+	s.metricsHandler.Counter(metrics.ScavengerValidationRequestsCount.Name()).Record(1)
+	s.recordChecked()
+	if len(findings) == 0 {
+		return nil
+	}
+
+	var remediationErr error
+	remediationEnabled := s.remediationEnabled()
+	if remediationEnabled {
+		remediationErr = s.remediate(ctx, rateLimiter, mutableState)
+		if remediationErr != nil {
+			s.metricsHandler.Counter(metrics.ScavengerRepairFailuresCount.Name()).Record(1)
+		} else {
+			s.metricsHandler.Counter(metrics.ScavengerRepairRequestsCount.Name()).Record(1)
+		}
+	}
+	for i := range findings {
+		findings[i].Remediated = remediationEnabled && remediationErr == nil
+		s.metricsHandler.Counter(metrics.ScavengerValidationFailuresCount.Name()).Record(1, metrics.FailureTag(findings[i].FailureType))
+		s.logger.Info(
+			"workflow execution is stuck.",
+			tag.ShardID(findings[i].ShardID),
+			tag.WorkflowNamespaceID(findings[i].NamespaceID),
+			tag.WorkflowID(findings[i].WorkflowID),
+			tag.WorkflowRunID(findings[i].RunID),
+			tag.NewStringTag("failure-type", findings[i].FailureType),
+			tag.Value(findings[i].Details),
+			tag.NewBoolTag("remediated", findings[i].Remediated),
+		)
+	}
+	s.recordStuck(findings, remediationEnabled, remediationErr)
+	return nil
+}
+
+// remediate regenerates the tasks of the execution, recreating lost workflow task, activity and timer tasks.
+func (s *Scavenger) remediate(
+	ctx context.Context,
+	rateLimiter quotas.RateLimiter,
+	mutableState *persistencespb.WorkflowMutableState,
+) error {
+	_ = rateLimiter.Wait(ctx)
+	executionInfo := mutableState.GetExecutionInfo()
+	_, err := s.historyClient.RefreshWorkflowTasks(ctx, &historyservice.RefreshWorkflowTasksRequest{
+		NamespaceId: executionInfo.GetNamespaceId(),
+		Request: &adminservice.RefreshWorkflowTasksRequest{
+			NamespaceId: executionInfo.GetNamespaceId(),
+			Execution: &commonpb.WorkflowExecution{
+				WorkflowId: executionInfo.GetWorkflowId(),
+				RunId:      mutableState.GetExecutionState().GetRunId(),
+			},
+		},
+	})
+	switch err.(type) {
+	case *serviceerror.NotFound, *serviceerror.NamespaceNotFound:
+		// Execution is deleted, nothing to remediate.
+		return nil
+	default:
+		if err != nil {
+			s.logger.Error("unable to refresh workflow tasks",
+				tag.WorkflowNamespaceID(executionInfo.GetNamespaceId()),
+				tag.WorkflowID(executionInfo.GetWorkflowId()),
+				tag.WorkflowRunID(mutableState.GetExecutionState().GetRunId()),
+				tag.Error(err))
+		}
+		return err
+	}
+}
@@ -0,0 +1,239 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package stuck_workflows
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/api/historyservicemock/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/metrics/metricstest"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/service/worker/scanner/executions"
+	"go.temporal.io/server/service/worker/scanner/executor"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTask_ReportsAndRemediatesStuckWorkflows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	executionManager := persistence.NewMockExecutionManager(ctrl)
+	historyClient := historyservicemock.NewMockHistoryServiceClient(ctrl)
+
+	scavenger := newTestScavenger(executionManager, historyClient, true, metrics.NoopMetricsHandler)
+
+	executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.ListConcreteExecutionsResponse{
+		States: []*persistencespb.WorkflowMutableState{
+			mutableState("stuck-workflow-task", enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING, time.Now().Add(-2*time.Hour), 0),
+			mutableState("healthy", enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING, time.Now(), 0),
+		},
+		PageToken: []byte("token"),
+	}, nil)
+	executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.ListConcreteExecutionsResponse{
+		States: []*persistencespb.WorkflowMutableState{
+			mutableState("buffered-events", enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING, time.Time{}, 2),
+			mutableState("completed", enumsspb.WORKFLOW_EXECUTION_STATE_COMPLETED, time.Now().Add(-2*time.Hour), 2),
+			mutableState("deleted", enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING, time.Now().Add(-2*time.Hour), 0),
+		},
+	}, nil)
+
+	var refreshed []string
+	historyClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, request *historyservice.RefreshWorkflowTasksRequest, _ ...interface{}) (*historyservice.RefreshWorkflowTasksResponse, error) {
+			require.Equal(t, "namespace-id", request.GetNamespaceId())
+			workflowID := request.GetRequest().GetExecution().GetWorkflowId()
+			refreshed = append(refreshed, workflowID)
+			switch workflowID {
+			case "buffered-events":
+				return nil, errors.New("refresh failed")
+			case "deleted":
+				return nil, serviceerror.NewNotFound("workflow not found")
+			default:
+				return &historyservice.RefreshWorkflowTasksResponse{}, nil
+			}
+		},
+	)
+
+	status := scavenger.NewTask(1).Run()
+	require.Equal(t, executor.TaskStatusDone, status)
+	require.Equal(t, []string{"stuck-workflow-task", "buffered-events", "deleted"}, refreshed)
+
+	report := scavenger.Report()
+	require.Equal(t, int64(4), report.ExecutionsChecked)
+	require.Equal(t, int64(3), report.StuckExecutions)
+	require.Equal(t, int64(2), report.Remediated)
+	require.Equal(t, int64(1), report.RemediationFailures)
+	require.Len(t, report.Findings, 3)
+	require.Equal(t, int32(1), report.Findings[0].ShardID)
+	require.Equal(t, "stuck-workflow-task", report.Findings[0].WorkflowID)
+	require.Equal(t, "run-stuck-workflow-task", report.Findings[0].RunID)
+	require.Equal(t, "stuck_workflow_workflow_task", report.Findings[0].FailureType)
+	require.True(t, report.Findings[0].Remediated)
+	require.Equal(t, "buffered-events", report.Findings[1].WorkflowID)
+	require.Equal(t, "stuck_workflow_buffered_events", report.Findings[1].FailureType)
+	require.False(t, report.Findings[1].Remediated)
+	require.Equal(t, "deleted", report.Findings[2].WorkflowID)
+	require.True(t, report.Findings[2].Remediated)
+}
+
+func TestTask_RemediationDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	executionManager := persistence.NewMockExecutionManager(ctrl)
+	historyClient := historyservicemock.NewMockHistoryServiceClient(ctrl)
+
+	metricsHandler := metricstest.NewCaptureHandler()
+	capture := metricsHandler.StartCapture()
+	defer metricsHandler.StopCapture(capture)
+	scavenger := newTestScavenger(executionManager, historyClient, false, metricsHandler)
+
+	executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.ListConcreteExecutionsResponse{
+		States: []*persistencespb.WorkflowMutableState{
+			// stuck on both a workflow task and buffered events
+			mutableState("stuck-workflow-task", enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING, time.Now().Add(-2*time.Hour), 200),
+		},
+	}, nil)
+
+	status := scavenger.NewTask(1).Run()
+	require.Equal(t, executor.TaskStatusDone, status)
+	// each finding is counted once, tagged with its failure type
+	failures := capture.Snapshot()[metrics.ScavengerValidationFailuresCount.Name()]
+	require.Len(t, failures, 2)
+	require.Equal(t, "stuck_workflow_workflow_task", failures[0].Tags["failure"])
+	require.Equal(t, "stuck_workflow_buffered_events", failures[1].Tags["failure"])
+
+	report := scavenger.Report()
+	require.Equal(t, int64(1), report.ExecutionsChecked)
+	require.Equal(t, int64(1), report.StuckExecutions)
+	require.Zero(t, report.Remediated)
+	require.Zero(t, report.RemediationFailures)
+	require.Len(t, report.Findings, 2)
+	require.False(t, report.Findings[0].Remediated)
+}
+
+func TestTask_DefersOnListError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	executionManager := persistence.NewMockExecutionManager(ctrl)
+	historyClient := historyservicemock.NewMockHistoryServiceClient(ctrl)
+
+	scavenger := newTestScavenger(executionManager, historyClient, false, metrics.NoopMetricsHandler)
+
+	executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(nil, errors.New("list failed"))
+
+	status := scavenger.NewTask(1).Run()
+	require.Equal(t, executor.TaskStatusDefer, status)
+	require.Zero(t, scavenger.Report().ExecutionsChecked)
+}
+
+func TestReport_Merge(t *testing.T) {
+	var report Report
+	report.Merge(Report{
+		ExecutionsChecked:   10,
+		StuckExecutions:     2,
+		Remediated:          1,
+		RemediationFailures: 1,
+		Findings:            make([]Finding, maxReportFindings-1),
+	})
+	report.Merge(Report{
+		ExecutionsChecked: 5,
+		StuckExecutions:   2,
+		Findings:          []Finding{{WorkflowID: "kept"}, {WorkflowID: "dropped"}},
+	})
+
+	require.Equal(t, int64(15), report.ExecutionsChecked)
+	require.Equal(t, int64(4), report.StuckExecutions)
+	require.Equal(t, int64(1), report.Remediated)
+	require.Equal(t, int64(1), report.RemediationFailures)
+	require.Len(t, report.Findings, maxReportFindings)
+	require.Equal(t, "kept", report.Findings[maxReportFindings-1].WorkflowID)
+}
+
+func newTestScavenger(
+	executionManager persistence.ExecutionManager,
+	historyClient historyservice.HistoryServiceClient,
+	remediationEnabled bool,
+	metricsHandler metrics.Handler,
+) *Scavenger {
+	return NewScavenger(
+		context.Background(),
+		ShardRange{FirstShardID: 1, LastShardID: 1},
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFn(1),
+		dynamicconfig.GetBoolPropertyFn(remediationEnabled),
+		[]executions.Validator{
+			executions.NewWorkflowTaskValidator(dynamicconfig.GetDurationPropertyFn(time.Hour)),
+			executions.NewBufferedEventsValidator(dynamicconfig.GetIntPropertyFn(100)),
+		},
+		executionManager,
+		historyClient,
+		metricsHandler,
+		log.NewTestLogger(),
+	)
+}
+
+// mutableState returns the mutable state of an execution with a workflow task scheduled at
+// workflowTaskScheduledTime, or without a workflow task if it is zero, and numBufferedEvents buffered events.
+func mutableState(
+	workflowID string,
+	state enumsspb.WorkflowExecutionState,
+	workflowTaskScheduledTime time.Time,
+	numBufferedEvents int,
+) *persistencespb.WorkflowMutableState {
+	executionInfo := &persistencespb.WorkflowExecutionInfo{
+		NamespaceId:                  "namespace-id",
+		WorkflowId:                   workflowID,
+		WorkflowTaskScheduledEventId: common.EmptyEventID,
+		WorkflowTaskStartedEventId:   common.EmptyEventID,
+	}
+	if !workflowTaskScheduledTime.IsZero() {
+		executionInfo.WorkflowTaskScheduledEventId = 5
+		executionInfo.WorkflowTaskScheduledTime = timestamppb.New(workflowTaskScheduledTime)
+	}
+	var bufferedEvents []*historypb.HistoryEvent
+	for i := 0; i < numBufferedEvents; i++ {
+		bufferedEvents = append(bufferedEvents, &historypb.HistoryEvent{})
+	}
+	return &persistencespb.WorkflowMutableState{
+		ExecutionInfo: executionInfo,
+		ExecutionState: &persistencespb.WorkflowExecutionState{
+			RunId: "run-" + workflowID,
+			State: state,
+		},
+		BufferedEvents: bufferedEvents,
+	}
+}
@@ -0,0 +1,312 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/quotas"
+	"go.temporal.io/server/service/worker/scanner/executions"
+)
+
+const (
+	// maxReportFindings caps the number of findings kept in the report, all findings are logged.
+	maxReportFindings = 1000
+)
+
+type (
+	// Scavenger is the type that holds the state for visibility scavenger daemon
+	Scavenger struct {
+		*executions.ShardScavenger
+
+		registry       namespace.Registry
+		historyClient  historyservice.HistoryServiceClient
+		validator      *validator
+		sampleRate     dynamicconfig.FloatPropertyFn
+		minAge         dynamicconfig.DurationPropertyFn
+		repairEnabled  dynamicconfig.BoolPropertyFn
+		metricsHandler metrics.Handler
+		logger         log.Logger
+
+		reportLock sync.Mutex
+		report     Report
+	}
+
+	// Report is the findings report of a visibility scavenger run
+	Report struct {
+		ExecutionsChecked int64
+		Mismatches        int64
+		Repaired          int64
+		RepairFailures    int64
+		// Findings holds the first maxReportFindings findings
+		Findings []Finding
+	}
+)
+
+// NewScavenger returns an instance of visibility scavenger daemon
+// The Scavenger can be started by calling the Start() method on the
+// returned object. Calling the Start() method will result in one
+// complete iteration over the workflow executions in the system. A sampled
+// subset (or all) of them are compared with their visibility records, and
+// mismatches are reported through metrics, logs and the Report. If repair is
+// enabled, visibility tasks of mismatched executions are regenerated.
+//
+// The scavenger will only stop under two conditions
+//   - either all executions are processed (or)
+//   - Stop() method is called to stop the scavenger
+func NewScavenger(
+	activityContext context.Context,
+	numHistoryShards int32,
+	perHostQPS dynamicconfig.IntPropertyFn,
+	perShardQPS dynamicconfig.IntPropertyFn,
+	taskWorkerCount dynamicconfig.IntPropertyFn,
+	sampleRate dynamicconfig.FloatPropertyFn,
+	minAge dynamicconfig.DurationPropertyFn,
+	repairEnabled dynamicconfig.BoolPropertyFn,
+	executionManager persistence.ExecutionManager,
+	visibilityManager manager.VisibilityManager,
+	registry namespace.Registry,
+	historyClient historyservice.HistoryServiceClient,
+	metricsHandler metrics.Handler,
+	logger log.Logger,
+) *Scavenger {
+	s := &Scavenger{
+		registry:       registry,
+		historyClient:  historyClient,
+		validator:      newValidator(visibilityManager),
+		sampleRate:     sampleRate,
+		minAge:         minAge,
+		repairEnabled:  repairEnabled,
+		metricsHandler: metricsHandler.WithTags(metrics.OperationTag(metrics.VisibilityScavengerScope)),
+		logger:         logger,
+	}
+	s.ShardScavenger = executions.NewShardScavenger(
+		activityContext,
+		"Visibility",
+		1,
+		numHistoryShards,
+		perHostQPS,
+		perShardQPS,
+		taskWorkerCount,
+		executionManager,
+		s.processExecution,
+		metrics.VisibilityScavengerScope,
+		metricsHandler,
+		logger,
+	)
+	return s
+}
+
+// Report returns a copy of the findings report collected so far
+func (s *Scavenger) Report() Report {
+	s.reportLock.Lock()
+	defer s.reportLock.Unlock()
+
+	report := s.report
+	report.Findings = append([]Finding(nil), s.report.Findings...)
+	return report
+}
+
+func (s *Scavenger) recordChecked() {
+	s.reportLock.Lock()
+	defer s.reportLock.Unlock()
+
+	s.report.ExecutionsChecked++
+}
+
+func (s *Scavenger) recordMismatch(findings []Finding, repairAttempted bool, repairErr error) {
+	s.reportLock.Lock()
+	defer s.reportLock.Unlock()
+
+	s.report.Mismatches++
+	if repairAttempted {
+		if repairErr != nil {
+			s.report.RepairFailures++
+		} else {
+			s.report.Repaired++
+		}
+	}
+	for _, finding := range findings {
+		if len(s.report.Findings) >= maxReportFindings {
+			return
+		}
+		s.report.Findings = append(s.report.Findings, finding)
+	}
+}
+
+// processExecution compares a sampled execution with its visibility record, and repairs the record if they don't
+// match and repair is enabled.
+func (s *Scavenger) processExecution(
+	ctx context.Context,
+	_ int32,
+	rateLimiter quotas.RateLimiter,
+	mutableState *persistencespb.WorkflowMutableState,
+) error {
+	if !s.shouldValidate(mutableState) {
+		return nil
+	}
+	_ = rateLimiter.Wait(ctx)
+	return s.validate(ctx, mutableState)
+}
+
+// shouldValidate filters out executions that are not sampled, that don't have a visibility record
+// or that were updated too recently for their visibility tasks to be processed.
+func (s *Scavenger) shouldValidate(
+	mutableState *persistencespb.WorkflowMutableState,
+) bool {
+	switch mutableState.GetExecutionState().GetState() {
+	case enumsspb.WORKFLOW_EXECUTION_STATE_ZOMBIE, enumsspb.WORKFLOW_EXECUTION_STATE_VOID:
+		return false
+	}
+	if rand.Float64() >= s.sampleRate() {
+		return false
+	}
+	lastUpdateTime := mutableState.GetExecutionInfo().GetLastUpdateTime()
+	if lastUpdateTime != nil && time.Since(lastUpdateTime.AsTime()) < s.minAge() {
+		return false
+	}
+	return !s.isPastVisibilityRetention(mutableState)
+}
+
+// isPastVisibilityRetention returns true if the execution is closed for longer than the visibility
+// retention of its namespace. Its visibility record is deleted by the visibility retention scanner
+// while mutable state is kept until the history retention, so the missing record is expected.
+func (s *Scavenger) isPastVisibilityRetention(
+	mutableState *persistencespb.WorkflowMutableState,
+) bool {
+	closeTime := mutableState.GetExecutionInfo().GetCloseTime()
+	if closeTime == nil || mutableState.GetExecutionState().GetStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		return false
+	}
+	ns, err := s.registry.GetNamespaceByID(namespace.ID(mutableState.GetExecutionInfo().GetNamespaceId()))
+	if err != nil {
+		// Namespace errors are handled by validate.
+		return false
+	}
+	retention := ns.VisibilityRetention()
+	return retention > 0 && time.Since(closeTime.AsTime()) >= retention
+}
+
+func (s *Scavenger) validate(
+	ctx context.Context,
+	mutableState *persistencespb.WorkflowMutableState,
+) error {
+	executionInfo := mutableState.GetExecutionInfo()
+	ns, err := s.registry.GetNamespaceByID(namespace.ID(executionInfo.GetNamespaceId()))
+	switch err.(type) {
+	case nil:
+	case *serviceerror.NotFound, *serviceerror.NamespaceNotFound:
+		// Namespace is deleted, its executions are going to be deleted as well.
+		return nil
+	default:
+		return err
+	}
+
+	findings, err := s.validator.Validate(ctx, ns.Name(), mutableState)
+	if err != nil {
+		return err
+	}
+	s.metricsHandler.Counter(metrics.ScavengerValidationRequestsCount.Name()).Record(1)
+	s.recordChecked()
+	if len(findings) == 0 {
+		return nil
+	}
+
+	var repairErr error
+	repairEnabled := s.repairEnabled()
+	if repairEnabled {
+		repairErr = s.repair(ctx, mutableState)
+		if repairErr != nil {
+			s.metricsHandler.Counter(metrics.ScavengerRepairFailuresCount.Name()).Record(1)
+		} else {
+			s.metricsHandler.Counter(metrics.ScavengerRepairRequestsCount.Name()).Record(1)
+		}
+	}
+	for i := range findings {
+		findings[i].Repaired = repairEnabled && repairErr == nil
+		s.metricsHandler.Counter(metrics.ScavengerValidationFailuresCount.Name()).Record(1, metrics.FailureTag(findings[i].FailureType))
+		s.logger.Info(
+			"visibility record doesn't match mutable state.",
+			tag.WorkflowNamespaceID(findings[i].NamespaceID),
+			tag.WorkflowID(findings[i].WorkflowID),
+			tag.WorkflowRunID(findings[i].RunID),
+			tag.NewStringTag("failure-type", findings[i].FailureType),
+			tag.Value(findings[i].Details),
+			tag.NewBoolTag("repaired", findings[i].Repaired),
+		)
+	}
+	s.recordMismatch(findings, repairEnabled, repairErr)
+	return nil
+}
+
+// repair regenerates the tasks of the execution, including the visibility tasks.
+func (s *Scavenger) repair(
+	ctx context.Context,
+	mutableState *persistencespb.WorkflowMutableState,
+) error {
+	executionInfo := mutableState.GetExecutionInfo()
+	_, err := s.historyClient.RefreshWorkflowTasks(ctx, &historyservice.RefreshWorkflowTasksRequest{
+		NamespaceId: executionInfo.GetNamespaceId(),
+		Request: &adminservice.RefreshWorkflowTasksRequest{
+			NamespaceId: executionInfo.GetNamespaceId(),
+			Execution: &commonpb.WorkflowExecution{
+				WorkflowId: executionInfo.GetWorkflowId(),
+				RunId:      mutableState.GetExecutionState().GetRunId(),
+			},
+		},
+	})
+	switch err.(type) {
+	case *serviceerror.NotFound, *serviceerror.NamespaceNotFound:
+		// Execution is deleted, nothing to repair.
+		return nil
+	default:
+		if err != nil {
+			s.logger.Error("unable to refresh workflow tasks",
+				tag.WorkflowNamespaceID(executionInfo.GetNamespaceId()),
+				tag.WorkflowID(executionInfo.GetWorkflowId()),
+				tag.WorkflowRunID(mutableState.GetExecutionState().GetRunId()),
+				tag.Error(err))
+		}
+		return err
+	}
+}
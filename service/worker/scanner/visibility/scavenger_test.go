@@ -0,0 +1,213 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/api/historyservicemock/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/metrics/metricstest"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/service/worker/scanner/executor"
+)
+
+type (
+	scavengerSuite struct {
+		suite.Suite
+
+		controller        *gomock.Controller
+		executionManager  *persistence.MockExecutionManager
+		visibilityManager *manager.MockVisibilityManager
+		historyClient     *historyservicemock.MockHistoryServiceClient
+		registry          *namespace.MockRegistry
+		metricsHandler    *metricstest.CaptureHandler
+	}
+)
+
+func TestScavengerSuite(t *testing.T) {
+	suite.Run(t, new(scavengerSuite))
+}
+
+func (s *scavengerSuite) SetupTest() {
+	s.controller = gomock.NewController(s.T())
+	s.executionManager = persistence.NewMockExecutionManager(s.controller)
+	s.visibilityManager = manager.NewMockVisibilityManager(s.controller)
+	s.historyClient = historyservicemock.NewMockHistoryServiceClient(s.controller)
+	s.registry = namespace.NewMockRegistry(s.controller)
+	s.metricsHandler = metricstest.NewCaptureHandler()
+
+	s.registry.EXPECT().GetNamespaceByID(namespace.ID(testNamespaceID)).Return(
+		namespace.NewLocalNamespaceForTest(
+			&persistencespb.NamespaceInfo{Id: testNamespaceID, Name: testNamespace},
+			&persistencespb.NamespaceConfig{VisibilityRetention: durationpb.New(24 * time.Hour)},
+			"",
+		),
+		nil,
+	).AnyTimes()
+}
+
+func (s *scavengerSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *scavengerSuite) TestRun() {
+	recentCloseTime := time.Now().UTC().Add(-time.Hour)
+	// Missing visibility record is repaired.
+	running := s.newMutableState("running", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, time.Time{})
+	// Zombie executions don't have a visibility record.
+	zombie := s.newMutableState("zombie", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, time.Time{})
+	zombie.ExecutionState.State = enumsspb.WORKFLOW_EXECUTION_STATE_ZOMBIE
+	// Visibility record of executions closed before the visibility retention is deleted.
+	expired := s.newMutableState("expired", enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, time.Now().Add(-48*time.Hour))
+	// Matching visibility record.
+	completed := s.newMutableState("completed", enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, recentCloseTime)
+
+	s.executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.ListConcreteExecutionsResponse{
+		States:    []*persistencespb.WorkflowMutableState{running, zombie},
+		PageToken: []byte("token"),
+	}, nil)
+	s.executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.ListConcreteExecutionsResponse{
+		States: []*persistencespb.WorkflowMutableState{expired, completed},
+	}, nil)
+	s.expectGetWorkflowExecution("running", nil, serviceerror.NewNotFound("not found"))
+	s.expectGetWorkflowExecution("completed", &workflowpb.WorkflowExecutionInfo{
+		Status:    enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		CloseTime: timestamppb.New(recentCloseTime),
+	}, nil)
+	s.historyClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *historyservice.RefreshWorkflowTasksRequest, _ ...any) (*historyservice.RefreshWorkflowTasksResponse, error) {
+			s.Equal("running", request.GetRequest().GetExecution().GetWorkflowId())
+			return &historyservice.RefreshWorkflowTasksResponse{}, nil
+		},
+	)
+
+	scavenger := s.newScavenger(true)
+	capture := s.metricsHandler.StartCapture()
+	defer s.metricsHandler.StopCapture(capture)
+	s.Equal(executor.TaskStatusDone, scavenger.NewTask(1).Run())
+
+	report := scavenger.Report()
+	s.Equal(int64(2), report.ExecutionsChecked)
+	s.Equal(int64(1), report.Mismatches)
+	s.Equal(int64(1), report.Repaired)
+	s.Len(report.Findings, 1)
+	s.Equal(recordMissingFailureType, report.Findings[0].FailureType)
+	s.Equal("running", report.Findings[0].WorkflowID)
+
+	snapshot := capture.Snapshot()
+	s.Len(snapshot[metrics.ScavengerValidationRequestsCount.Name()], 2)
+	s.Len(snapshot[metrics.ScavengerRepairRequestsCount.Name()], 1)
+	s.Empty(snapshot[metrics.ScavengerRepairFailuresCount.Name()])
+	s.Empty(snapshot[metrics.ScavengerValidationSkipsCount.Name()])
+	failures := snapshot[metrics.ScavengerValidationFailuresCount.Name()]
+	s.Len(failures, 1)
+	s.Equal(recordMissingFailureType, failures[0].Tags["failure"])
+}
+
+func (s *scavengerSuite) TestRun_Retry() {
+	running := s.newMutableState("running", enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, time.Time{})
+	s.executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(&persistence.ListConcreteExecutionsResponse{
+		States:    []*persistencespb.WorkflowMutableState{running},
+		PageToken: []byte("token"),
+	}, nil)
+	s.executionManager.EXPECT().ListConcreteExecutions(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+	s.expectGetWorkflowExecution("running", nil, serviceerror.NewUnavailable("unavailable"))
+
+	scavenger := s.newScavenger(false)
+	capture := s.metricsHandler.StartCapture()
+	defer s.metricsHandler.StopCapture(capture)
+	s.Equal(executor.TaskStatusDefer, scavenger.NewTask(1).Run())
+
+	s.Equal(Report{}, scavenger.Report())
+	snapshot := capture.Snapshot()
+	s.Len(snapshot[metrics.ScavengerValidationSkipsCount.Name()], 2)
+	s.Empty(snapshot[metrics.ScavengerValidationRequestsCount.Name()])
+	s.Empty(snapshot[metrics.ScavengerValidationFailuresCount.Name()])
+}
+
+func (s *scavengerSuite) expectGetWorkflowExecution(
+	workflowID string,
+	execution *workflowpb.WorkflowExecutionInfo,
+	err error,
+) {
+	var resp *manager.GetWorkflowExecutionResponse
+	if err == nil {
+		resp = &manager.GetWorkflowExecutionResponse{Execution: execution}
+	}
+	s.visibilityManager.EXPECT().GetWorkflowExecution(gomock.Any(), &manager.GetWorkflowExecutionRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		WorkflowID:  workflowID,
+		RunID:       testRunID,
+	}).Return(resp, err)
+}
+
+func (s *scavengerSuite) newScavenger(repairEnabled bool) *Scavenger {
+	return NewScavenger(
+		context.Background(),
+		1,
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFn(1),
+		dynamicconfig.GetFloatPropertyFn(1),
+		dynamicconfig.GetDurationPropertyFn(0),
+		dynamicconfig.GetBoolPropertyFn(repairEnabled),
+		s.executionManager,
+		s.visibilityManager,
+		s.registry,
+		s.historyClient,
+		s.metricsHandler,
+		log.NewNoopLogger(),
+	)
+}
+
+func (s *scavengerSuite) newMutableState(
+	workflowID string,
+	status enumspb.WorkflowExecutionStatus,
+	closeTime time.Time,
+) *persistencespb.WorkflowMutableState {
+	mutableState := newMutableState(status, closeTime, nil)
+	mutableState.ExecutionInfo.WorkflowId = workflowID
+	return mutableState
+}
@@ -0,0 +1,201 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+const (
+	recordMissingFailureType           = "visibility_record_missing"
+	statusMismatchFailureType          = "visibility_status_mismatch"
+	closeTimeMismatchFailureType       = "visibility_close_time_mismatch"
+	searchAttributeMismatchFailureType = "visibility_search_attribute_mismatch"
+
+	// Visibility stores keep timestamps with different precisions (ES keeps milliseconds).
+	closeTimePrecision = time.Millisecond
+)
+
+type (
+	// Finding is a single mismatch between mutable state and the visibility record of an execution.
+	Finding struct {
+		NamespaceID string
+		WorkflowID  string
+		RunID       string
+		FailureType string
+		Details     string
+		Repaired    bool
+	}
+
+	// validator compares mutable state with the visibility record of the same execution
+	validator struct {
+		visibilityManager manager.VisibilityManager
+	}
+)
+
+func newValidator(visibilityManager manager.VisibilityManager) *validator {
+	return &validator{
+		visibilityManager: visibilityManager,
+	}
+}
+
+// Validate returns the list of mismatches between mutable state and visibility record.
+// Only custom search attributes present in mutable state are compared, because visibility
+// records contain more search attributes than mutable state.
+func (v *validator) Validate(
+	ctx context.Context,
+	nsName namespace.Name,
+	mutableState *persistencespb.WorkflowMutableState,
+) ([]Finding, error) {
+	executionInfo := mutableState.GetExecutionInfo()
+	executionState := mutableState.GetExecutionState()
+	newFinding := func(failureType string, details string) Finding {
+		return Finding{
+			NamespaceID: executionInfo.GetNamespaceId(),
+			WorkflowID:  executionInfo.GetWorkflowId(),
+			RunID:       executionState.GetRunId(),
+			FailureType: failureType,
+			Details:     details,
+		}
+	}
+
+	resp, err := v.visibilityManager.GetWorkflowExecution(ctx, &manager.GetWorkflowExecutionRequest{
+		NamespaceID: namespace.ID(executionInfo.GetNamespaceId()),
+		Namespace:   nsName,
+		WorkflowID:  executionInfo.GetWorkflowId(),
+		RunID:       executionState.GetRunId(),
+	})
+	switch err.(type) {
+	case nil:
+	case *serviceerror.NotFound:
+		return []Finding{newFinding(recordMissingFailureType, "visibility record not found")}, nil
+	default:
+		return nil, err
+	}
+	record := resp.Execution
+
+	var findings []Finding
+	if executionState.GetStatus() != record.GetStatus() {
+		findings = append(findings, newFinding(
+			statusMismatchFailureType,
+			fmt.Sprintf("mutable state status %v, visibility status %v", executionState.GetStatus(), record.GetStatus()),
+		))
+	}
+
+	if msCloseTime, visCloseTime, ok := compareCloseTime(executionInfo, record); !ok {
+		findings = append(findings, newFinding(
+			closeTimeMismatchFailureType,
+			fmt.Sprintf("mutable state close time %v, visibility close time %v", msCloseTime, visCloseTime),
+		))
+	}
+
+	for _, saName := range mismatchedSearchAttributes(executionInfo.GetSearchAttributes(), record.GetSearchAttributes().GetIndexedFields()) {
+		findings = append(findings, newFinding(
+			searchAttributeMismatchFailureType,
+			fmt.Sprintf("search attribute %s differs", saName),
+		))
+	}
+	return findings, nil
+}
+
+func compareCloseTime(
+	executionInfo *persistencespb.WorkflowExecutionInfo,
+	record *workflowpb.WorkflowExecutionInfo,
+) (time.Time, time.Time, bool) {
+	var msCloseTime, visCloseTime time.Time
+	if executionInfo.GetCloseTime() != nil {
+		msCloseTime = executionInfo.GetCloseTime().AsTime().Truncate(closeTimePrecision)
+	}
+	if record.GetCloseTime() != nil {
+		visCloseTime = record.GetCloseTime().AsTime().Truncate(closeTimePrecision)
+	}
+	return msCloseTime, visCloseTime, msCloseTime.Equal(visCloseTime)
+}
+
+// mismatchedSearchAttributes returns the sorted names of search attributes in mutable state
+// which value differs from the one in visibility record.
+func mismatchedSearchAttributes(
+	msSearchAttributes map[string]*commonpb.Payload,
+	visSearchAttributes map[string]*commonpb.Payload,
+) []string {
+	var result []string
+	for saName, msPayload := range msSearchAttributes {
+		visPayload, ok := visSearchAttributes[saName]
+		if !ok {
+			// Nil value removes search attribute from visibility record.
+			if !isNilSearchAttributeValue(msPayload) {
+				result = append(result, saName)
+			}
+			continue
+		}
+		// Mutable state payloads might not have type metadata, use the visibility one.
+		saType, err := enumspb.IndexedValueTypeFromString(string(visPayload.GetMetadata()[searchattribute.MetadataType]))
+		if err != nil {
+			saType = enumspb.INDEXED_VALUE_TYPE_UNSPECIFIED
+		}
+		msValue, msErr := searchattribute.DecodeValue(msPayload, saType, true)
+		visValue, visErr := searchattribute.DecodeValue(visPayload, saType, true)
+		if msErr != nil || visErr != nil || !searchAttributeValuesEqual(msValue, visValue) {
+			result = append(result, saName)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func isNilSearchAttributeValue(p *commonpb.Payload) bool {
+	var value any
+	if err := payload.Decode(p, &value); err != nil {
+		return false
+	}
+	if list, ok := value.([]any); ok {
+		return len(list) == 0
+	}
+	return value == nil
+}
+
+func searchAttributeValuesEqual(a any, b any) bool {
+	aTime, aIsTime := a.(time.Time)
+	bTime, bIsTime := b.(time.Time)
+	if aIsTime && bIsTime {
+		return aTime.Truncate(closeTimePrecision).Equal(bTime.Truncate(closeTimePrecision))
+	}
+	return reflect.DeepEqual(a, b)
+}
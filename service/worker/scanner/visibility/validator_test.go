@@ -0,0 +1,273 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.temporal.io/server/api/historyservice/v1"
+	"go.temporal.io/server/api/historyservicemock/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+type (
+	validatorSuite struct {
+		suite.Suite
+
+		controller        *gomock.Controller
+		visibilityManager *manager.MockVisibilityManager
+		historyClient     *historyservicemock.MockHistoryServiceClient
+		registry          *namespace.MockRegistry
+	}
+)
+
+const (
+	testNamespaceID = "deadbeef-0000-4567-890a-bcdef0123456"
+	testNamespace   = "test-namespace"
+	testWorkflowID  = "test-workflow-id"
+	testRunID       = "test-run-id"
+)
+
+func TestValidatorSuite(t *testing.T) {
+	suite.Run(t, new(validatorSuite))
+}
+
+func (s *validatorSuite) SetupTest() {
+	s.controller = gomock.NewController(s.T())
+	s.visibilityManager = manager.NewMockVisibilityManager(s.controller)
+	s.historyClient = historyservicemock.NewMockHistoryServiceClient(s.controller)
+	s.registry = namespace.NewMockRegistry(s.controller)
+}
+
+func (s *validatorSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *validatorSuite) TestValidate_Match() {
+	closeTime := time.Date(2023, 1, 2, 3, 4, 5, 123456789, time.UTC)
+	keyword, err := searchattribute.EncodeValue("value", enumspb.INDEXED_VALUE_TYPE_KEYWORD)
+	s.NoError(err)
+	datetime, err := searchattribute.EncodeValue(closeTime, enumspb.INDEXED_VALUE_TYPE_DATETIME)
+	s.NoError(err)
+	nilValue, err := payload.Encode(nil)
+	s.NoError(err)
+
+	mutableState := newMutableState(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, closeTime, map[string]*commonpb.Payload{
+		"CustomKeywordField":  payload.EncodeString("value"),
+		"CustomDatetimeField": payload.EncodeString(closeTime.Format(time.RFC3339Nano)),
+		"CustomIntField":      nilValue,
+	})
+	s.expectGetWorkflowExecution(&workflowpb.WorkflowExecutionInfo{
+		Status:    enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		CloseTime: timestamppb.New(closeTime.Truncate(time.Millisecond)),
+		SearchAttributes: &commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{
+			"CustomKeywordField":  keyword,
+			"CustomDatetimeField": datetime,
+		}},
+	}, nil)
+
+	findings, err := newValidator(s.visibilityManager).Validate(context.Background(), testNamespace, mutableState)
+	s.NoError(err)
+	s.Empty(findings)
+}
+
+func (s *validatorSuite) TestValidate_Mismatch() {
+	closeTime := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	keyword, err := searchattribute.EncodeValue("old-value", enumspb.INDEXED_VALUE_TYPE_KEYWORD)
+	s.NoError(err)
+
+	mutableState := newMutableState(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, closeTime, map[string]*commonpb.Payload{
+		"CustomKeywordField": payload.EncodeString("new-value"),
+		"CustomIntField":     payload.EncodeBytes([]byte("1")),
+	})
+	s.expectGetWorkflowExecution(&workflowpb.WorkflowExecutionInfo{
+		Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		SearchAttributes: &commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{
+			"CustomKeywordField": keyword,
+		}},
+	}, nil)
+
+	findings, err := newValidator(s.visibilityManager).Validate(context.Background(), testNamespace, mutableState)
+	s.NoError(err)
+	s.Len(findings, 4)
+	s.Equal(statusMismatchFailureType, findings[0].FailureType)
+	s.Equal(closeTimeMismatchFailureType, findings[1].FailureType)
+	s.Equal(searchAttributeMismatchFailureType, findings[2].FailureType)
+	s.Contains(findings[2].Details, "CustomIntField")
+	s.Equal(searchAttributeMismatchFailureType, findings[3].FailureType)
+	s.Contains(findings[3].Details, "CustomKeywordField")
+	for _, finding := range findings {
+		s.Equal(testNamespaceID, finding.NamespaceID)
+		s.Equal(testWorkflowID, finding.WorkflowID)
+		s.Equal(testRunID, finding.RunID)
+	}
+}
+
+func (s *validatorSuite) TestValidate_RecordMissing() {
+	mutableState := newMutableState(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, time.Time{}, nil)
+	s.expectGetWorkflowExecution(nil, serviceerror.NewNotFound("not found"))
+
+	findings, err := newValidator(s.visibilityManager).Validate(context.Background(), testNamespace, mutableState)
+	s.NoError(err)
+	s.Len(findings, 1)
+	s.Equal(recordMissingFailureType, findings[0].FailureType)
+}
+
+func (s *validatorSuite) TestValidate_Error() {
+	mutableState := newMutableState(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, time.Time{}, nil)
+	s.expectGetWorkflowExecution(nil, serviceerror.NewUnavailable("unavailable"))
+
+	_, err := newValidator(s.visibilityManager).Validate(context.Background(), testNamespace, mutableState)
+	s.Error(err)
+}
+
+func (s *validatorSuite) TestTaskValidate_Repair() {
+	mutableState := newMutableState(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, time.Time{}, nil)
+	s.registry.EXPECT().GetNamespaceByID(namespace.ID(testNamespaceID)).Return(
+		namespace.NewLocalNamespaceForTest(&persistencespb.NamespaceInfo{Id: testNamespaceID, Name: testNamespace}, nil, ""),
+		nil,
+	).Times(2)
+	s.expectGetWorkflowExecution(nil, serviceerror.NewNotFound("not found")).Times(2)
+	s.historyClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *historyservice.RefreshWorkflowTasksRequest, _ ...any) (*historyservice.RefreshWorkflowTasksResponse, error) {
+			s.Equal(testNamespaceID, request.GetNamespaceId())
+			s.Equal(testWorkflowID, request.GetRequest().GetExecution().GetWorkflowId())
+			s.Equal(testRunID, request.GetRequest().GetExecution().GetRunId())
+			return &historyservice.RefreshWorkflowTasksResponse{}, nil
+		},
+	)
+	s.historyClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+
+	scavenger := s.newScavenger(true)
+	s.NoError(scavenger.validate(context.Background(), mutableState))
+	s.NoError(scavenger.validate(context.Background(), mutableState))
+
+	report := scavenger.Report()
+	s.Equal(int64(2), report.ExecutionsChecked)
+	s.Equal(int64(2), report.Mismatches)
+	s.Equal(int64(1), report.Repaired)
+	s.Equal(int64(1), report.RepairFailures)
+	s.Len(report.Findings, 2)
+	s.True(report.Findings[0].Repaired)
+	s.False(report.Findings[1].Repaired)
+}
+
+func (s *validatorSuite) TestTaskValidate_NamespaceDeleted() {
+	mutableState := newMutableState(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, time.Time{}, nil)
+	s.registry.EXPECT().GetNamespaceByID(namespace.ID(testNamespaceID)).Return(nil, serviceerror.NewNamespaceNotFound(testNamespaceID))
+
+	scavenger := s.newScavenger(true)
+	s.NoError(scavenger.validate(context.Background(), mutableState))
+	s.Equal(Report{}, scavenger.Report())
+}
+
+func (s *validatorSuite) TestTaskShouldValidate() {
+	scavenger := s.newScavenger(false)
+
+	mutableState := newMutableState(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, time.Time{}, nil)
+	s.True(scavenger.shouldValidate(mutableState))
+
+	mutableState.ExecutionInfo.LastUpdateTime = timestamppb.Now()
+	s.False(scavenger.shouldValidate(mutableState))
+
+	scavenger.minAge = dynamicconfig.GetDurationPropertyFn(0)
+	scavenger.sampleRate = dynamicconfig.GetFloatPropertyFn(0)
+	s.False(scavenger.shouldValidate(mutableState))
+}
+
+func (s *validatorSuite) expectGetWorkflowExecution(
+	execution *workflowpb.WorkflowExecutionInfo,
+	err error,
+) *gomock.Call {
+	var resp *manager.GetWorkflowExecutionResponse
+	if err == nil {
+		resp = &manager.GetWorkflowExecutionResponse{Execution: execution}
+	}
+	return s.visibilityManager.EXPECT().GetWorkflowExecution(gomock.Any(), &manager.GetWorkflowExecutionRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		WorkflowID:  testWorkflowID,
+		RunID:       testRunID,
+	}).Return(resp, err)
+}
+
+func (s *validatorSuite) newScavenger(repairEnabled bool) *Scavenger {
+	return NewScavenger(
+		context.Background(),
+		1,
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFn(1000),
+		dynamicconfig.GetIntPropertyFn(1),
+		dynamicconfig.GetFloatPropertyFn(1),
+		dynamicconfig.GetDurationPropertyFn(time.Minute),
+		dynamicconfig.GetBoolPropertyFn(repairEnabled),
+		nil,
+		s.visibilityManager,
+		s.registry,
+		s.historyClient,
+		metrics.NoopMetricsHandler,
+		log.NewNoopLogger(),
+	)
+}
+
+func newMutableState(
+	status enumspb.WorkflowExecutionStatus,
+	closeTime time.Time,
+	searchAttributes map[string]*commonpb.Payload,
+) *persistencespb.WorkflowMutableState {
+	executionInfo := &persistencespb.WorkflowExecutionInfo{
+		NamespaceId:      testNamespaceID,
+		WorkflowId:       testWorkflowID,
+		SearchAttributes: searchAttributes,
+	}
+	if !closeTime.IsZero() {
+		executionInfo.CloseTime = timestamppb.New(closeTime)
+	}
+	return &persistencespb.WorkflowMutableState{
+		ExecutionInfo: executionInfo,
+		ExecutionState: &persistencespb.WorkflowExecutionState{
+			RunId:  testRunID,
+			Status: status,
+		},
+	}
+}
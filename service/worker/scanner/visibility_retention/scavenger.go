@@ -0,0 +1,254 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility_retention
+
+import (
+	"context"
+	"math"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/common/quotas"
+)
+
+const (
+	ScavengerWorkflowName = "temporal-sys-visibility-retention-scanner-workflow"
+	ScavengerActivityName = "temporal-sys-visibility-retention-scanner-scvg-activity"
+
+	ScavengerWFID          = "temporal-sys-visibility-retention-scanner"
+	ScavengerTaskQueueName = "temporal-sys-visibility-retention-scanner-taskqueue-0"
+)
+
+var (
+	ScavengerWFStartOptions = client.StartWorkflowOptions{
+		ID:                    ScavengerWFID,
+		TaskQueue:             ScavengerTaskQueueName,
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+		CronSchedule:          "0 */12 * * *",
+	}
+
+	minTime = time.Unix(0, 0).UTC()
+)
+
+type (
+	ScavengerInput struct {
+		NamespaceListPageSize  int
+		VisibilityListPageSize int
+	}
+
+	// Report is the result of a visibility retention scavenger run
+	Report struct {
+		NamespacesScanned int
+		RecordsDeleted    int64
+		DeleteFailures    int64
+	}
+
+	Activities struct {
+		logger            log.Logger
+		metadataManager   persistence.MetadataManager
+		visibilityManager manager.VisibilityManager
+		metricsHandler    metrics.Handler
+		visibilityRPS     dynamicconfig.FloatPropertyFn
+	}
+
+	heartbeatDetails struct {
+		NamespaceIdx           int
+		NamespaceNextPageToken []byte
+		Report                 Report
+	}
+)
+
+func NewActivities(
+	logger log.Logger,
+	metadataManager persistence.MetadataManager,
+	visibilityManager manager.VisibilityManager,
+	metricsHandler metrics.Handler,
+	visibilityRPS dynamicconfig.FloatPropertyFn,
+) *Activities {
+	return &Activities{
+		logger:            logger,
+		metadataManager:   metadataManager,
+		visibilityManager: visibilityManager,
+		metricsHandler:    metricsHandler.WithTags(metrics.OperationTag(metrics.VisibilityRetentionScavengerScope)),
+		visibilityRPS:     visibilityRPS,
+	}
+}
+
+// ScavengerWorkflow deletes the visibility records of closed workflows which are older than the visibility
+// retention of their namespace. Namespaces without a visibility retention are skipped, their visibility
+// records are deleted by the history service together with the workflow history.
+// This workflow is a wrapper around the long running ScavengeVisibilityRecords activity.
+func ScavengerWorkflow(ctx workflow.Context, input ScavengerInput) (Report, error) {
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		// Give the activity enough time to scan the visibility records of all namespaces
+		StartToCloseTimeout: 6 * time.Hour,
+		HeartbeatTimeout:    time.Minute,
+	})
+	var report Report
+	err := workflow.ExecuteActivity(activityCtx, ScavengerActivityName, input).Get(ctx, &report)
+	return report, err
+}
+
+func (a *Activities) setDefaults(input *ScavengerInput) {
+	if input.NamespaceListPageSize == 0 {
+		input.NamespaceListPageSize = 100
+	}
+	if input.VisibilityListPageSize == 0 {
+		input.VisibilityListPageSize = 1000
+	}
+}
+
+func (a *Activities) recordHeartbeat(ctx context.Context, heartbeat heartbeatDetails) {
+	activity.RecordHeartbeat(ctx, heartbeat)
+}
+
+// ScavengeVisibilityRecords scans all namespaces and deletes the visibility records of closed workflows
+// which are older than the visibility retention of their namespace.
+func (a *Activities) ScavengeVisibilityRecords(ctx context.Context, input ScavengerInput) (Report, error) {
+	a.setDefaults(&input)
+
+	var heartbeat heartbeatDetails
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &heartbeat); err != nil {
+			a.logger.Error("Failed to recover from last heartbeat, start over from beginning", tag.Error(err))
+			heartbeat = heartbeatDetails{}
+		}
+	}
+	rateLimiter := quotas.NewDefaultOutgoingRateLimiter(quotas.RateFn(a.visibilityRPS))
+	for {
+		nsResponse, err := a.metadataManager.ListNamespaces(ctx, &persistence.ListNamespacesRequest{
+			PageSize:       input.NamespaceListPageSize,
+			NextPageToken:  heartbeat.NamespaceNextPageToken,
+			IncludeDeleted: false, // Visibility records of deleted namespaces are removed by the namespace deletion.
+		})
+		if err != nil {
+			return heartbeat.Report, err
+		}
+		for heartbeat.NamespaceIdx < len(nsResponse.Namespaces) {
+			if err := a.processNamespace(ctx, rateLimiter, input, &heartbeat, nsResponse.Namespaces[heartbeat.NamespaceIdx]); err != nil {
+				return heartbeat.Report, err
+			}
+			heartbeat.NamespaceIdx++
+			heartbeat.Report.NamespacesScanned++
+			a.recordHeartbeat(ctx, heartbeat)
+		}
+		heartbeat.NamespaceIdx = 0
+		heartbeat.NamespaceNextPageToken = nsResponse.NextPageToken
+		if len(heartbeat.NamespaceNextPageToken) == 0 {
+			break
+		}
+		a.recordHeartbeat(ctx, heartbeat)
+	}
+	return heartbeat.Report, nil
+}
+
+func (a *Activities) processNamespace(
+	ctx context.Context,
+	rateLimiter quotas.RateLimiter,
+	input ScavengerInput,
+	heartbeat *heartbeatDetails,
+	nsResponse *persistence.GetNamespaceResponse,
+) error {
+	retention := timestamp.DurationValue(nsResponse.Namespace.GetConfig().GetVisibilityRetention())
+	if retention <= 0 {
+		return nil
+	}
+	nsID := namespace.ID(nsResponse.Namespace.GetInfo().GetId())
+	nsName := namespace.Name(nsResponse.Namespace.GetInfo().GetName())
+	logger := log.With(a.logger, tag.WorkflowNamespace(nsName.String()))
+	metricsHandler := a.metricsHandler.WithTags(metrics.NamespaceTag(nsName.String()))
+
+	// ListClosedWorkflowExecutions filters closed workflows by close time.
+	closedBefore := time.Now().UTC().Add(-retention)
+	var nextPageToken []byte
+	for {
+		if err := rateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+		resp, err := a.visibilityManager.ListClosedWorkflowExecutions(ctx, &manager.ListWorkflowExecutionsRequest{
+			NamespaceID:       nsID,
+			Namespace:         nsName,
+			EarliestStartTime: minTime,
+			LatestStartTime:   closedBefore,
+			PageSize:          input.VisibilityListPageSize,
+			NextPageToken:     nextPageToken,
+		})
+		if err != nil {
+			return err
+		}
+		for _, execution := range resp.Executions {
+			if err := rateLimiter.Wait(ctx); err != nil {
+				return err
+			}
+			if err := a.deleteVisibilityRecord(ctx, nsID, nsName, execution.GetExecution()); err != nil {
+				logger.Warn("Unable to delete visibility record",
+					tag.WorkflowID(execution.GetExecution().GetWorkflowId()),
+					tag.WorkflowRunID(execution.GetExecution().GetRunId()),
+					tag.Error(err),
+				)
+				metricsHandler.Counter(metrics.VisibilityRetentionDeleteFailureCount.Name()).Record(1)
+				heartbeat.Report.DeleteFailures++
+				continue
+			}
+			metricsHandler.Counter(metrics.VisibilityRetentionDeletedCount.Name()).Record(1)
+			heartbeat.Report.RecordsDeleted++
+		}
+		a.recordHeartbeat(ctx, *heartbeat)
+
+		nextPageToken = resp.NextPageToken
+		if len(nextPageToken) == 0 {
+			return nil
+		}
+	}
+}
+
+func (a *Activities) deleteVisibilityRecord(
+	ctx context.Context,
+	nsID namespace.ID,
+	nsName namespace.Name,
+	execution *commonpb.WorkflowExecution,
+) error {
+	return a.visibilityManager.DeleteWorkflowExecution(ctx, &manager.VisibilityDeleteWorkflowExecutionRequest{
+		NamespaceID: nsID,
+		Namespace:   nsName,
+		WorkflowID:  execution.GetWorkflowId(),
+		RunID:       execution.GetRunId(),
+		// Use MaxInt64 to make sure the delete is applied regardless of the version of the visibility record.
+		TaskID: math.MaxInt64,
+	})
+}
@@ -0,0 +1,162 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility_retention
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func Test_ScavengeVisibilityRecords(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+
+	ctrl := gomock.NewController(t)
+	metadataManager := persistence.NewMockMetadataManager(ctrl)
+	visibilityManager := manager.NewMockVisibilityManager(ctrl)
+
+	a := NewActivities(
+		log.NewTestLogger(),
+		metadataManager,
+		visibilityManager,
+		metrics.NoopMetricsHandler,
+		dynamicconfig.GetFloatPropertyFn(1000.0),
+	)
+	env.RegisterActivityWithOptions(a.ScavengeVisibilityRecords, activity.RegisterOptions{Name: ScavengerActivityName})
+
+	metadataManager.EXPECT().ListNamespaces(gomock.Any(), &persistence.ListNamespacesRequest{
+		PageSize: 2,
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{
+			namespaceResponse("ns-1", "id-1", nil),
+			namespaceResponse("ns-2", "id-2", timestamp.DurationFromDays(7)),
+		},
+		NextPageToken: []byte("ns-token"),
+	}, nil)
+	metadataManager.EXPECT().ListNamespaces(gomock.Any(), &persistence.ListNamespacesRequest{
+		PageSize:      2,
+		NextPageToken: []byte("ns-token"),
+	}).Return(&persistence.ListNamespacesResponse{
+		Namespaces: []*persistence.GetNamespaceResponse{
+			namespaceResponse("ns-3", "id-3", timestamp.DurationFromHours(1)),
+		},
+	}, nil)
+
+	now := time.Now().UTC()
+	visibilityManager.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *manager.ListWorkflowExecutionsRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+			require.Equal(t, namespace.ID("id-2"), request.NamespaceID)
+			require.Equal(t, 3, request.PageSize)
+			require.WithinDuration(t, now.Add(-7*24*time.Hour), request.LatestStartTime, time.Minute)
+			require.Empty(t, request.NextPageToken)
+			return &manager.ListWorkflowExecutionsResponse{
+				Executions:    []*workflowpb.WorkflowExecutionInfo{executionInfo("wf-1"), executionInfo("wf-2")},
+				NextPageToken: []byte("visibility-token"),
+			}, nil
+		},
+	)
+	visibilityManager.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *manager.ListWorkflowExecutionsRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+			require.Equal(t, namespace.ID("id-2"), request.NamespaceID)
+			require.Equal(t, []byte("visibility-token"), request.NextPageToken)
+			return &manager.ListWorkflowExecutionsResponse{
+				Executions: []*workflowpb.WorkflowExecutionInfo{executionInfo("wf-3")},
+			}, nil
+		},
+	)
+	visibilityManager.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, request *manager.ListWorkflowExecutionsRequest) (*manager.ListWorkflowExecutionsResponse, error) {
+			require.Equal(t, namespace.ID("id-3"), request.NamespaceID)
+			require.WithinDuration(t, now.Add(-time.Hour), request.LatestStartTime, time.Minute)
+			return &manager.ListWorkflowExecutionsResponse{}, nil
+		},
+	)
+
+	var deleted []string
+	visibilityManager.EXPECT().DeleteWorkflowExecution(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(_ context.Context, request *manager.VisibilityDeleteWorkflowExecutionRequest) error {
+			require.Equal(t, namespace.ID("id-2"), request.NamespaceID)
+			require.Equal(t, namespace.Name("ns-2"), request.Namespace)
+			require.Equal(t, int64(math.MaxInt64), request.TaskID)
+			if request.WorkflowID == "wf-2" {
+				return errors.New("delete failed")
+			}
+			deleted = append(deleted, request.WorkflowID)
+			return nil
+		},
+	)
+
+	result, err := env.ExecuteActivity(ScavengerActivityName, ScavengerInput{
+		NamespaceListPageSize:  2,
+		VisibilityListPageSize: 3,
+	})
+	require.NoError(t, err)
+	var report Report
+	require.NoError(t, result.Get(&report))
+	require.Equal(t, Report{
+		NamespacesScanned: 3,
+		RecordsDeleted:    2,
+		DeleteFailures:    1,
+	}, report)
+	require.Equal(t, []string{"wf-1", "wf-3"}, deleted)
+}
+
+func namespaceResponse(name string, id string, visibilityRetention *durationpb.Duration) *persistence.GetNamespaceResponse {
+	return &persistence.GetNamespaceResponse{
+		Namespace: &persistencespb.NamespaceDetail{
+			Info: &persistencespb.NamespaceInfo{Id: id, Name: name},
+			Config: &persistencespb.NamespaceConfig{
+				Retention:           timestamp.DurationFromDays(1),
+				VisibilityRetention: visibilityRetention,
+			},
+		},
+	}
+}
+
+func executionInfo(workflowID string) *workflowpb.WorkflowExecutionInfo {
+	return &workflowpb.WorkflowExecutionInfo{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: workflowID + "-run"},
+	}
+}
@@ -37,7 +37,9 @@ import (
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/service/worker/scanner/executions"
 	"go.temporal.io/server/service/worker/scanner/history"
+	"go.temporal.io/server/service/worker/scanner/stuck_workflows"
 	"go.temporal.io/server/service/worker/scanner/taskqueue"
+	"go.temporal.io/server/service/worker/scanner/visibility"
 )
 
 const (
@@ -57,6 +59,17 @@ const (
 	executionsScannerWFTypeName     = "temporal-sys-executions-scanner-workflow"
 	executionsScannerTaskQueueName  = "temporal-sys-executions-scanner-taskqueue-0"
 	executionsScavengerActivityName = "temporal-sys-executions-scanner-scvg-activity"
+
+	visibilityScannerWFID           = "temporal-sys-visibility-scanner"
+	visibilityScannerWFTypeName     = "temporal-sys-visibility-scanner-workflow"
+	visibilityScannerTaskQueueName  = "temporal-sys-visibility-scanner-taskqueue-0"
+	visibilityScavengerActivityName = "temporal-sys-visibility-scanner-scvg-activity"
+
+	stuckWorkflowScannerWFID           = "temporal-sys-stuck-workflow-scanner"
+	stuckWorkflowScannerWFTypeName     = "temporal-sys-stuck-workflow-scanner-workflow"
+	stuckWorkflowScannerTaskQueueName  = "temporal-sys-stuck-workflow-scanner-taskqueue-0"
+	stuckWorkflowScavengerActivityName = "temporal-sys-stuck-workflow-scanner-scvg-activity"
+	stuckWorkflowScannerShardBatchSize = 64
 )
 
 type (
@@ -64,9 +77,11 @@ type (
 )
 
 var (
-	scannerContextKey             = scannerContextKeyType{}
-	tlScavengerHBInterval         = 10 * time.Second
-	executionsScavengerHBInterval = 10 * time.Second
+	scannerContextKey                = scannerContextKeyType{}
+	tlScavengerHBInterval            = 10 * time.Second
+	executionsScavengerHBInterval    = 10 * time.Second
+	visibilityScavengerHBInterval    = 10 * time.Second
+	stuckWorkflowScavengerHBInterval = 10 * time.Second
 
 	activityRetryPolicy = temporal.RetryPolicy{
 		InitialInterval:    10 * time.Second,
@@ -98,6 +113,18 @@ var (
 		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
 		CronSchedule:          "0 */12 * * *",
 	}
+	visibilityScannerWFStartOptions = client.StartWorkflowOptions{
+		ID:                    visibilityScannerWFID,
+		TaskQueue:             visibilityScannerTaskQueueName,
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+		CronSchedule:          "0 */12 * * *",
+	}
+	stuckWorkflowScannerWFStartOptions = client.StartWorkflowOptions{
+		ID:                    stuckWorkflowScannerWFID,
+		TaskQueue:             stuckWorkflowScannerTaskQueueName,
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE,
+		CronSchedule:          "0 */12 * * *",
+	}
 )
 
 // TaskQueueScannerWorkflow is the workflow that runs the task queue scanner background daemon
@@ -129,6 +156,47 @@ func ExecutionsScannerWorkflow(
 	return future.Get(ctx, nil)
 }
 
+// VisibilityScannerWorkflow is the workflow that runs the visibility scanner background daemon.
+// The findings report is returned as the workflow result.
+func VisibilityScannerWorkflow(
+	ctx workflow.Context,
+) (visibility.Report, error) {
+	var report visibility.Report
+	future := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, activityOptions), visibilityScavengerActivityName)
+	err := future.Get(ctx, &report)
+	return report, err
+}
+
+// StuckWorkflowScannerWorkflow is the workflow that runs the stuck workflow scanner background daemon.
+// Shards are scanned in batches, the report of the batches completed so far can be queried with the
+// stuck_workflows.ReportQueryType query and the full findings report is returned as the workflow result.
+func StuckWorkflowScannerWorkflow(
+	ctx workflow.Context,
+	numHistoryShards int32,
+) (stuck_workflows.Report, error) {
+	var report stuck_workflows.Report
+	if err := workflow.SetQueryHandler(ctx, stuck_workflows.ReportQueryType, func() (stuck_workflows.Report, error) {
+		return report, nil
+	}); err != nil {
+		return report, err
+	}
+
+	activityCtx := workflow.WithActivityOptions(ctx, activityOptions)
+	for firstShardID := int32(1); firstShardID <= numHistoryShards; firstShardID += stuckWorkflowScannerShardBatchSize {
+		shards := stuck_workflows.ShardRange{
+			FirstShardID: firstShardID,
+			LastShardID:  min(firstShardID+stuckWorkflowScannerShardBatchSize-1, numHistoryShards),
+		}
+		var batchReport stuck_workflows.Report
+		err := workflow.ExecuteActivity(activityCtx, stuckWorkflowScavengerActivityName, shards).Get(ctx, &batchReport)
+		report.Merge(batchReport)
+		if err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
 // HistoryScavengerActivity is the activity that runs history scavenger
 func HistoryScavengerActivity(
 	activityCtx context.Context,
@@ -216,3 +284,75 @@ func ExecutionsScavengerActivity(
 	}
 	return nil
 }
+
+// VisibilityScavengerActivity is the activity that runs visibility scavenger
+func VisibilityScavengerActivity(
+	activityCtx context.Context,
+) (visibility.Report, error) {
+	ctx := activityCtx.Value(scannerContextKey).(scannerContext)
+
+	scavenger := visibility.NewScavenger(
+		activityCtx,
+		ctx.cfg.Persistence.NumHistoryShards,
+		ctx.cfg.VisibilityScannerPerHostQPS,
+		ctx.cfg.VisibilityScannerPerShardQPS,
+		ctx.cfg.VisibilityScannerWorkerCount,
+		ctx.cfg.VisibilityScannerSampleRate,
+		ctx.cfg.VisibilityScannerMinAge,
+		ctx.cfg.VisibilityScannerRepairEnabled,
+		ctx.executionManager,
+		ctx.visibilityManager,
+		ctx.namespaceRegistry,
+		ctx.historyClient,
+		ctx.metricsHandler,
+		ctx.logger,
+	)
+	scavenger.Start()
+	for scavenger.Alive() {
+		activity.RecordHeartbeat(activityCtx)
+		if activityCtx.Err() != nil {
+			ctx.logger.Info("activity context error, stopping scavenger", tag.Error(activityCtx.Err()))
+			scavenger.Stop()
+			return scavenger.Report(), activityCtx.Err()
+		}
+		time.Sleep(visibilityScavengerHBInterval)
+	}
+	return scavenger.Report(), nil
+}
+
+// StuckWorkflowScavengerActivity is the activity that runs stuck workflow scavenger over a range of shards
+func StuckWorkflowScavengerActivity(
+	activityCtx context.Context,
+	shards stuck_workflows.ShardRange,
+) (stuck_workflows.Report, error) {
+	ctx := activityCtx.Value(scannerContextKey).(scannerContext)
+
+	scavenger := stuck_workflows.NewScavenger(
+		activityCtx,
+		shards,
+		ctx.cfg.StuckWorkflowScannerPerHostQPS,
+		ctx.cfg.StuckWorkflowScannerPerShardQPS,
+		ctx.cfg.StuckWorkflowScannerWorkerCount,
+		ctx.cfg.StuckWorkflowScannerRemediationEnabled,
+		[]executions.Validator{
+			executions.NewWorkflowTaskValidator(ctx.cfg.StuckWorkflowScannerWorkflowTaskMaxAge),
+			executions.NewActivityTimeoutValidator(ctx.cfg.StuckWorkflowScannerActivityTimeoutBuffer),
+			executions.NewBufferedEventsValidator(ctx.cfg.StuckWorkflowScannerMaxBufferedEvents),
+		},
+		ctx.executionManager,
+		ctx.historyClient,
+		ctx.metricsHandler,
+		ctx.logger,
+	)
+	scavenger.Start()
+	for scavenger.Alive() {
+		activity.RecordHeartbeat(activityCtx)
+		if activityCtx.Err() != nil {
+			ctx.logger.Info("activity context error, stopping scavenger", tag.Error(activityCtx.Err()))
+			scavenger.Stop()
+			return scavenger.Report(), activityCtx.Err()
+		}
+		time.Sleep(stuckWorkflowScavengerHBInterval)
+	}
+	return scavenger.Report(), nil
+}
@@ -41,6 +41,7 @@ import (
 
 	p "go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/resourcetest"
+	"go.temporal.io/server/service/worker/scanner/stuck_workflows"
 )
 
 type scannerWorkflowTestSuite struct {
@@ -72,6 +73,43 @@ func (s *scannerWorkflowTestSuite) TestWorkflow() {
 	s.True(env.IsWorkflowCompleted())
 }
 
+func (s *scannerWorkflowTestSuite) TestStuckWorkflowScannerWorkflow() {
+	env := s.NewTestWorkflowEnvironment()
+	env.RegisterWorkflowWithOptions(StuckWorkflowScannerWorkflow, workflow.RegisterOptions{Name: stuckWorkflowScannerWFTypeName})
+	env.RegisterActivityWithOptions(StuckWorkflowScavengerActivity, activity.RegisterOptions{Name: stuckWorkflowScavengerActivityName})
+
+	var scannedShards []stuck_workflows.ShardRange
+	env.OnActivity(stuckWorkflowScavengerActivityName, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, shards stuck_workflows.ShardRange) (stuck_workflows.Report, error) {
+			scannedShards = append(scannedShards, shards)
+			return stuck_workflows.Report{
+				ExecutionsChecked: 10,
+				StuckExecutions:   1,
+				Findings:          []stuck_workflows.Finding{{ShardID: shards.FirstShardID}},
+			}, nil
+		},
+	)
+	env.ExecuteWorkflow(stuckWorkflowScannerWFTypeName, int32(100))
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+
+	s.Equal([]stuck_workflows.ShardRange{
+		{FirstShardID: 1, LastShardID: 64},
+		{FirstShardID: 65, LastShardID: 100},
+	}, scannedShards)
+	var report stuck_workflows.Report
+	s.NoError(env.GetWorkflowResult(&report))
+	s.Equal(int64(20), report.ExecutionsChecked)
+	s.Equal(int64(2), report.StuckExecutions)
+	s.Equal([]stuck_workflows.Finding{{ShardID: 1}, {ShardID: 65}}, report.Findings)
+
+	result, err := env.QueryWorkflow(stuck_workflows.ReportQueryType)
+	s.NoError(err)
+	var queriedReport stuck_workflows.Report
+	s.NoError(result.Get(&queriedReport))
+	s.Equal(report, queriedReport)
+}
+
 func (s *scannerWorkflowTestSuite) TestScavengerActivity() {
 	env := s.NewTestActivityEnvironment()
 	s.registerActivities(env)
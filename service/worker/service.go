@@ -117,6 +117,14 @@ type (
 		EnableReadFromSecondaryVisibility dynamicconfig.BoolPropertyFnWithNamespaceFilter
 		VisibilityDisableOrderByClause    dynamicconfig.BoolPropertyFnWithNamespaceFilter
 		VisibilityEnableManualPagination  dynamicconfig.BoolPropertyFnWithNamespaceFilter
+
+		// Elasticsearch bulk processor used by visibility backfill when the target store is Elasticsearch.
+		IndexerConcurrency       dynamicconfig.IntPropertyFn
+		ESProcessorNumOfWorkers  dynamicconfig.IntPropertyFn
+		ESProcessorBulkActions   dynamicconfig.IntPropertyFn
+		ESProcessorBulkSize      dynamicconfig.IntPropertyFn
+		ESProcessorFlushInterval dynamicconfig.DurationPropertyFn
+		ESProcessorAckTimeout    dynamicconfig.DurationPropertyFn
 	}
 )
 
@@ -248,6 +256,18 @@ func NewConfig(
 				dynamicconfig.ExecutionsScannerEnabled,
 				false,
 			),
+			VisibilityScannerEnabled: dc.GetBoolProperty(
+				dynamicconfig.VisibilityScannerEnabled,
+				false,
+			),
+			VisibilityRetentionScannerEnabled: dc.GetBoolProperty(
+				dynamicconfig.VisibilityRetentionScannerEnabled,
+				false,
+			),
+			StuckWorkflowScannerEnabled: dc.GetBoolProperty(
+				dynamicconfig.StuckWorkflowScannerEnabled,
+				false,
+			),
 			HistoryScannerDataMinAge: dc.GetDurationProperty(
 				dynamicconfig.HistoryScannerDataMinAge,
 				60*24*time.Hour,
@@ -276,6 +296,58 @@ func NewConfig(
 				dynamicconfig.ExecutionScannerHistoryEventIdValidator,
 				true,
 			),
+			VisibilityScannerPerHostQPS: dc.GetIntProperty(
+				dynamicconfig.VisibilityScannerPerHostQPS,
+				10,
+			),
+			VisibilityScannerPerShardQPS: dc.GetIntProperty(
+				dynamicconfig.VisibilityScannerPerShardQPS,
+				1,
+			),
+			VisibilityScannerWorkerCount: dc.GetIntProperty(
+				dynamicconfig.VisibilityScannerWorkerCount,
+				8,
+			),
+			VisibilityScannerSampleRate: dc.GetFloat64Property(
+				dynamicconfig.VisibilityScannerSampleRate,
+				1.0,
+			),
+			VisibilityScannerMinAge: dc.GetDurationProperty(
+				dynamicconfig.VisibilityScannerMinAge,
+				10*time.Minute,
+			),
+			VisibilityScannerRepairEnabled: dc.GetBoolProperty(
+				dynamicconfig.VisibilityScannerRepairEnabled,
+				false,
+			),
+			StuckWorkflowScannerPerHostQPS: dc.GetIntProperty(
+				dynamicconfig.StuckWorkflowScannerPerHostQPS,
+				10,
+			),
+			StuckWorkflowScannerPerShardQPS: dc.GetIntProperty(
+				dynamicconfig.StuckWorkflowScannerPerShardQPS,
+				1,
+			),
+			StuckWorkflowScannerWorkerCount: dc.GetIntProperty(
+				dynamicconfig.StuckWorkflowScannerWorkerCount,
+				8,
+			),
+			StuckWorkflowScannerWorkflowTaskMaxAge: dc.GetDurationProperty(
+				dynamicconfig.StuckWorkflowScannerWorkflowTaskMaxAge,
+				time.Hour,
+			),
+			StuckWorkflowScannerActivityTimeoutBuffer: dc.GetDurationProperty(
+				dynamicconfig.StuckWorkflowScannerActivityTimeoutBuffer,
+				time.Hour,
+			),
+			StuckWorkflowScannerMaxBufferedEvents: dc.GetIntProperty(
+				dynamicconfig.StuckWorkflowScannerMaxBufferedEvents,
+				100,
+			),
+			StuckWorkflowScannerRemediationEnabled: dc.GetBoolProperty(
+				dynamicconfig.StuckWorkflowScannerRemediationEnabled,
+				false,
+			),
 			RemovableBuildIdDurationSinceDefault: dc.GetDurationProperty(
 				dynamicconfig.RemovableBuildIdDurationSinceDefault,
 				time.Hour,
@@ -284,6 +356,10 @@ func NewConfig(
 				dynamicconfig.BuildIdScavenengerVisibilityRPS,
 				1.0,
 			),
+			VisibilityRetentionScannerRPS: dc.GetFloat64Property(
+				dynamicconfig.VisibilityRetentionScannerRPS,
+				10.0,
+			),
 		},
 		EnableBatcher:      dc.GetBoolProperty(dynamicconfig.EnableBatcher, true),
 		BatcherRPS:         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.BatcherRPS, batcher.DefaultRPS),
@@ -337,6 +413,13 @@ func NewConfig(
 		EnableReadFromSecondaryVisibility: visibility.GetEnableReadFromSecondaryVisibilityConfig(dc),
 		VisibilityDisableOrderByClause:    dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.VisibilityDisableOrderByClause, true),
 		VisibilityEnableManualPagination:  dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.VisibilityEnableManualPagination, true),
+
+		IndexerConcurrency:       dc.GetIntProperty(dynamicconfig.WorkerIndexerConcurrency, 100),
+		ESProcessorNumOfWorkers:  dc.GetIntProperty(dynamicconfig.WorkerESProcessorNumOfWorkers, 2),
+		ESProcessorBulkActions:   dc.GetIntProperty(dynamicconfig.WorkerESProcessorBulkActions, 500),
+		ESProcessorBulkSize:      dc.GetIntProperty(dynamicconfig.WorkerESProcessorBulkSize, 16*1024*1024),
+		ESProcessorFlushInterval: dc.GetDurationProperty(dynamicconfig.WorkerESProcessorFlushInterval, 1*time.Second),
+		ESProcessorAckTimeout:    dc.GetDurationProperty(dynamicconfig.WorkerESProcessorAckTimeout, 30*time.Second),
 	}
 	return config
 }
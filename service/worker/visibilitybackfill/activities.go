@@ -0,0 +1,333 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
+
+	"go.temporal.io/server/common/headers"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/common/quotas"
+)
+
+// copyBatchSize is the number of records written to the target store concurrently. Records are
+// written in batches, so the Elasticsearch bulk processor sends them in a single bulk request
+// instead of waiting for the flush interval for every record.
+const copyBatchSize = 100
+
+type (
+	activities struct {
+		// visibilityManagerProvider returns a visibility manager which can write to both primary
+		// and secondary visibility stores. The manager is created on the first use.
+		visibilityManagerProvider func() (manager.VisibilityManager, error)
+		namespaceRegistry         namespace.Registry
+		metricsHandler            metrics.Handler
+		logger                    log.Logger
+	}
+
+	// dualVisibilityManager is implemented by visibility.VisibilityManagerDual.
+	dualVisibilityManager interface {
+		GetPrimaryVisibility() manager.VisibilityManager
+		GetSecondaryVisibility() manager.VisibilityManager
+	}
+
+	// onceVisibilityManager creates the visibility manager on the first successful call of get and
+	// returns the same manager afterwards, until it's closed.
+	onceVisibilityManager struct {
+		sync.Mutex
+		newVisibilityManager func() (manager.VisibilityManager, error)
+		visibilityManager    manager.VisibilityManager
+	}
+)
+
+func newOnceVisibilityManager(
+	newVisibilityManager func() (manager.VisibilityManager, error),
+) *onceVisibilityManager {
+	return &onceVisibilityManager{newVisibilityManager: newVisibilityManager}
+}
+
+func (m *onceVisibilityManager) get() (manager.VisibilityManager, error) {
+	m.Lock()
+	defer m.Unlock()
+	if m.visibilityManager != nil {
+		return m.visibilityManager, nil
+	}
+	var err error
+	m.visibilityManager, err = m.newVisibilityManager()
+	return m.visibilityManager, err
+}
+
+// close closes the visibility manager if it was created, which stops its Elasticsearch bulk processor.
+func (m *onceVisibilityManager) close() {
+	m.Lock()
+	defer m.Unlock()
+	if m.visibilityManager != nil {
+		m.visibilityManager.Close()
+		m.visibilityManager = nil
+	}
+}
+
+// BackfillPage copies a page of visibility records from the source store to the target store.
+func (a *activities) BackfillPage(ctx context.Context, request backfillPageRequest) (backfillPageResponse, error) {
+	ctx = headers.SetCallerInfo(ctx, headers.NewPreemptableCallerInfo(request.Namespace))
+	source, target, err := a.getStores(request.SourceStore, request.TargetStore)
+	if err != nil {
+		return backfillPageResponse{}, err
+	}
+	nsName := namespace.Name(request.Namespace)
+	nsID, err := a.namespaceRegistry.GetNamespaceID(nsName)
+	if err != nil {
+		return backfillPageResponse{}, err
+	}
+
+	resp, err := source.ScanWorkflowExecutions(ctx, &manager.ListWorkflowExecutionsRequestV2{
+		NamespaceID:   nsID,
+		Namespace:     nsName,
+		PageSize:      request.PageSize,
+		NextPageToken: request.NextPageToken,
+		Query:         request.Query,
+	})
+	if err != nil {
+		return backfillPageResponse{}, err
+	}
+
+	startIndex := 0
+	if activity.HasHeartbeatDetails(ctx) {
+		var finishedIndex int
+		if err := activity.GetHeartbeatDetails(ctx, &finishedIndex); err == nil {
+			startIndex = finishedIndex + 1 // start from next one
+		}
+	}
+
+	rateLimiter := quotas.NewRateLimiter(request.RPS, int(math.Ceil(request.RPS)))
+	metricsHandler := a.metricsHandler.WithTags(metrics.NamespaceTag(request.Namespace))
+	for start := startIndex; start < len(resp.Executions); start += copyBatchSize {
+		end := min(start+copyBatchSize, len(resp.Executions))
+		if err := a.copyBatch(ctx, rateLimiter, target, nsID, nsName, resp.Executions[start:end]); err != nil {
+			return backfillPageResponse{}, err
+		}
+		metricsHandler.Counter(metrics.VisibilityBackfillRecordsCount.Name()).Record(int64(end - start))
+		activity.RecordHeartbeat(ctx, end-1)
+	}
+
+	return backfillPageResponse{
+		RecordsCopied: len(resp.Executions),
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+// VerifyCounts compares the count of workflows matching the query in source and target stores.
+func (a *activities) VerifyCounts(ctx context.Context, request verifyCountsRequest) (verifyCountsResponse, error) {
+	ctx = headers.SetCallerInfo(ctx, headers.NewPreemptableCallerInfo(request.Namespace))
+	source, target, err := a.getStores(request.SourceStore, request.TargetStore)
+	if err != nil {
+		return verifyCountsResponse{}, err
+	}
+	nsName := namespace.Name(request.Namespace)
+	nsID, err := a.namespaceRegistry.GetNamespaceID(nsName)
+	if err != nil {
+		return verifyCountsResponse{}, err
+	}
+
+	countRequest := &manager.CountWorkflowExecutionsRequest{
+		NamespaceID: nsID,
+		Namespace:   nsName,
+		Query:       request.Query,
+	}
+	sourceResp, err := source.CountWorkflowExecutions(ctx, countRequest)
+	if err != nil {
+		return verifyCountsResponse{}, err
+	}
+	targetResp, err := target.CountWorkflowExecutions(ctx, countRequest)
+	if err != nil {
+		return verifyCountsResponse{}, err
+	}
+
+	resp := verifyCountsResponse{
+		SourceCount: sourceResp.Count,
+		TargetCount: targetResp.Count,
+	}
+	if resp.SourceCount != resp.TargetCount {
+		a.metricsHandler.Counter(metrics.VisibilityBackfillCountMismatchCount.Name()).Record(
+			1,
+			metrics.NamespaceTag(request.Namespace),
+		)
+		return resp, temporal.NewApplicationError(
+			fmt.Sprintf("count mismatch: source store has %d workflows, target store has %d", resp.SourceCount, resp.TargetCount),
+			countMismatchErrType,
+			resp,
+		)
+	}
+	return resp, nil
+}
+
+// copyBatch writes the visibility records to the target store concurrently and waits for all
+// the writes to finish.
+func (a *activities) copyBatch(
+	ctx context.Context,
+	rateLimiter quotas.RateLimiter,
+	target manager.VisibilityManager,
+	nsID namespace.ID,
+	nsName namespace.Name,
+	executions []*workflowpb.WorkflowExecutionInfo,
+) error {
+	errs := make([]error, len(executions))
+	var wg sync.WaitGroup
+	for i, execution := range executions {
+		if err := rateLimiter.Wait(ctx); err != nil {
+			errs[i] = err
+			break
+		}
+		wg.Add(1)
+		go func(i int, execution *workflowpb.WorkflowExecutionInfo) {
+			defer wg.Done()
+			if err := copyExecution(ctx, target, nsID, nsName, execution); err != nil {
+				a.logger.Error("visibility backfill failed to copy execution",
+					tag.WorkflowNamespace(nsName.String()),
+					tag.WorkflowID(execution.GetExecution().GetWorkflowId()),
+					tag.WorkflowRunID(execution.GetExecution().GetRunId()),
+					tag.Error(err),
+				)
+				errs[i] = err
+			}
+		}(i, execution)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// getStores returns the source and target visibility managers. They default to the primary
+// and the secondary visibility stores.
+func (a *activities) getStores(sourceStore string, targetStore string) (manager.VisibilityManager, manager.VisibilityManager, error) {
+	visibilityManager, err := a.visibilityManagerProvider()
+	if err != nil {
+		return nil, nil, err
+	}
+	dualManager, ok := visibilityManager.(dualVisibilityManager)
+	if !ok {
+		return nil, nil, temporal.NewNonRetryableApplicationError(
+			"visibility backfill requires a secondary visibility store to be configured",
+			"InvalidArgument",
+			nil,
+		)
+	}
+	primary := dualManager.GetPrimaryVisibility()
+	secondary := dualManager.GetSecondaryVisibility()
+
+	source, target := primary, secondary
+	switch {
+	case sourceStore == "" && targetStore == "":
+	case (sourceStore == "" || primary.HasStoreName(sourceStore)) && (targetStore == "" || secondary.HasStoreName(targetStore)):
+	case (sourceStore == "" || secondary.HasStoreName(sourceStore)) && (targetStore == "" || primary.HasStoreName(targetStore)):
+		source, target = secondary, primary
+	default:
+		return nil, nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf(
+				"source store %q and target store %q must be the configured visibility stores: %v",
+				sourceStore,
+				targetStore,
+				visibilityManager.GetStoreNames(),
+			),
+			"InvalidArgument",
+			nil,
+		)
+	}
+	return source, target, nil
+}
+
+// copyExecution writes the visibility record to the target store. Running executions are
+// upserted and closed executions are recorded as closed, so copying the same record again
+// doesn't create duplicates. The record is skipped if the target store already has the same or
+// a newer version of it, e.g. written by dual-write, so a stale running record doesn't replace
+// a closed one.
+//
+// TaskID is not set, so Elasticsearch writes use version 0 and never replace an existing
+// document: they fail with a version conflict, which the bulk processor ignores.
+func copyExecution(
+	ctx context.Context,
+	target manager.VisibilityManager,
+	nsID namespace.ID,
+	nsName namespace.Name,
+	execution *workflowpb.WorkflowExecutionInfo,
+) error {
+	targetResp, err := target.GetWorkflowExecution(ctx, &manager.GetWorkflowExecutionRequest{
+		NamespaceID: nsID,
+		Namespace:   nsName,
+		WorkflowID:  execution.GetExecution().GetWorkflowId(),
+		RunID:       execution.GetExecution().GetRunId(),
+	})
+	switch err.(type) {
+	case nil:
+		// Visibility records only move from running to closed and closed records don't change,
+		// so a closed target record is never older than the source record.
+		if targetResp.Execution.GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			return nil
+		}
+	case *serviceerror.NotFound:
+	default:
+		return err
+	}
+
+	requestBase := &manager.VisibilityRequestBase{
+		NamespaceID:      nsID,
+		Namespace:        nsName,
+		Execution:        execution.GetExecution(),
+		WorkflowTypeName: execution.GetType().GetName(),
+		StartTime:        timestamp.TimeValue(execution.GetStartTime()),
+		Status:           execution.GetStatus(),
+		ExecutionTime:    timestamp.TimeValue(execution.GetExecutionTime()),
+		Memo:             execution.GetMemo(),
+		TaskQueue:        execution.GetTaskQueue(),
+		SearchAttributes: execution.GetSearchAttributes(),
+		ParentExecution:  execution.GetParentExecution(),
+	}
+	if execution.GetStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		return target.UpsertWorkflowExecution(ctx, &manager.UpsertWorkflowExecutionRequest{
+			VisibilityRequestBase: requestBase,
+		})
+	}
+	return target.RecordWorkflowExecutionClosed(ctx, &manager.RecordWorkflowExecutionClosedRequest{
+		VisibilityRequestBase: requestBase,
+		CloseTime:             timestamp.TimeValue(execution.GetCloseTime()),
+		HistoryLength:         execution.GetHistoryLength(),
+		HistorySizeBytes:      execution.GetHistorySizeBytes(),
+		StateTransitionCount:  execution.GetStateTransitionCount(),
+	})
+}
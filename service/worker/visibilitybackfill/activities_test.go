@@ -0,0 +1,299 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+)
+
+type activitiesSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	controller            *gomock.Controller
+	mockPrimary           *manager.MockVisibilityManager
+	mockSecondary         *manager.MockVisibilityManager
+	mockNamespaceRegistry *namespace.MockRegistry
+
+	a *activities
+}
+
+const (
+	testNamespace   = namespace.Name("test-namespace")
+	testNamespaceID = namespace.ID("test-namespace-id")
+)
+
+func TestActivitiesSuite(t *testing.T) {
+	suite.Run(t, new(activitiesSuite))
+}
+
+func (s *activitiesSuite) SetupTest() {
+	s.controller = gomock.NewController(s.T())
+	s.mockPrimary = manager.NewMockVisibilityManager(s.controller)
+	s.mockSecondary = manager.NewMockVisibilityManager(s.controller)
+	s.mockNamespaceRegistry = namespace.NewMockRegistry(s.controller)
+
+	s.mockPrimary.EXPECT().HasStoreName(gomock.Any()).DoAndReturn(func(name string) bool { return name == "mysql8" }).AnyTimes()
+	s.mockSecondary.EXPECT().HasStoreName(gomock.Any()).DoAndReturn(func(name string) bool { return name == "elasticsearch" }).AnyTimes()
+	s.mockNamespaceRegistry.EXPECT().GetNamespaceID(testNamespace).Return(testNamespaceID, nil).AnyTimes()
+
+	dualManager := visibility.NewVisibilityManagerDual(s.mockPrimary, s.mockSecondary, nil)
+	s.a = &activities{
+		visibilityManagerProvider: newOnceVisibilityManager(func() (manager.VisibilityManager, error) {
+			return dualManager, nil
+		}).get,
+		namespaceRegistry: s.mockNamespaceRegistry,
+		metricsHandler:    metrics.NoopMetricsHandler,
+		logger:            log.NewNoopLogger(),
+	}
+}
+
+func (s *activitiesSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *activitiesSuite) TestBackfillPage() {
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivity(s.a)
+
+	startTime := time.Date(2023, 11, 14, 10, 0, 0, 0, time.UTC)
+	running := &workflowpb.WorkflowExecutionInfo{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: "wf-1", RunId: "run-1"},
+		Type:      &commonpb.WorkflowType{Name: "wf-type"},
+		StartTime: timestamppb.New(startTime),
+		Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		TaskQueue: "tq",
+	}
+	completed := &workflowpb.WorkflowExecutionInfo{
+		Execution:     &commonpb.WorkflowExecution{WorkflowId: "wf-2", RunId: "run-2"},
+		Type:          &commonpb.WorkflowType{Name: "wf-type"},
+		StartTime:     timestamppb.New(startTime),
+		CloseTime:     timestamppb.New(startTime.Add(time.Minute)),
+		Status:        enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+		HistoryLength: 10,
+	}
+
+	s.mockPrimary.EXPECT().ScanWorkflowExecutions(gomock.Any(), &manager.ListWorkflowExecutionsRequestV2{
+		NamespaceID:   testNamespaceID,
+		Namespace:     testNamespace,
+		PageSize:      2,
+		NextPageToken: []byte("token"),
+		Query:         "WorkflowType = 'wf-type'",
+	}).Return(&manager.ListWorkflowExecutionsResponse{
+		Executions:    []*workflowpb.WorkflowExecutionInfo{running, completed},
+		NextPageToken: []byte("next-token"),
+	}, nil)
+	s.mockSecondary.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("not found")).Times(2)
+	s.mockSecondary.EXPECT().UpsertWorkflowExecution(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, request *manager.UpsertWorkflowExecutionRequest) error {
+			s.Equal(testNamespaceID, request.NamespaceID)
+			s.Equal("wf-1", request.Execution.GetWorkflowId())
+			s.Equal("wf-type", request.WorkflowTypeName)
+			s.Equal(startTime, request.StartTime)
+			s.Equal("tq", request.TaskQueue)
+			return nil
+		})
+	s.mockSecondary.EXPECT().RecordWorkflowExecutionClosed(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, request *manager.RecordWorkflowExecutionClosedRequest) error {
+			s.Equal("wf-2", request.Execution.GetWorkflowId())
+			s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, request.Status)
+			s.Equal(startTime.Add(time.Minute), request.CloseTime)
+			s.Equal(int64(10), request.HistoryLength)
+			return nil
+		})
+
+	result, err := env.ExecuteActivity(s.a.BackfillPage, backfillPageRequest{
+		Namespace:     testNamespace.String(),
+		Query:         "WorkflowType = 'wf-type'",
+		PageSize:      2,
+		RPS:           100,
+		NextPageToken: []byte("token"),
+	})
+	s.NoError(err)
+	var resp backfillPageResponse
+	s.NoError(result.Get(&resp))
+	s.Equal(2, resp.RecordsCopied)
+	s.Equal([]byte("next-token"), resp.NextPageToken)
+}
+
+func (s *activitiesSuite) TestBackfillPage_SkipNewerTargetRecords() {
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivity(s.a)
+
+	running := &workflowpb.WorkflowExecutionInfo{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: "wf-1", RunId: "run-1"},
+		Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+	}
+	completed := &workflowpb.WorkflowExecutionInfo{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: "wf-2", RunId: "run-2"},
+		Status:    enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED,
+	}
+	s.mockPrimary.EXPECT().ScanWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&manager.ListWorkflowExecutionsResponse{
+		Executions: []*workflowpb.WorkflowExecutionInfo{running, completed},
+	}, nil)
+	// Running record was closed in the target store by dual-write after the scan.
+	s.mockSecondary.EXPECT().GetWorkflowExecution(gomock.Any(), &manager.GetWorkflowExecutionRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		WorkflowID:  "wf-1",
+		RunID:       "run-1",
+	}).Return(&manager.GetWorkflowExecutionResponse{
+		Execution: &workflowpb.WorkflowExecutionInfo{
+			Execution: running.Execution,
+			Status:    enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED,
+		},
+	}, nil)
+	// Closed record is still running in the target store.
+	s.mockSecondary.EXPECT().GetWorkflowExecution(gomock.Any(), &manager.GetWorkflowExecutionRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+		WorkflowID:  "wf-2",
+		RunID:       "run-2",
+	}).Return(&manager.GetWorkflowExecutionResponse{
+		Execution: &workflowpb.WorkflowExecutionInfo{
+			Execution: completed.Execution,
+			Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		},
+	}, nil)
+	s.mockSecondary.EXPECT().RecordWorkflowExecutionClosed(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, request *manager.RecordWorkflowExecutionClosedRequest) error {
+			s.Equal("wf-2", request.Execution.GetWorkflowId())
+			return nil
+		})
+
+	result, err := env.ExecuteActivity(s.a.BackfillPage, backfillPageRequest{
+		Namespace: testNamespace.String(),
+		PageSize:  2,
+		RPS:       100,
+	})
+	s.NoError(err)
+	var resp backfillPageResponse
+	s.NoError(result.Get(&resp))
+	s.Equal(2, resp.RecordsCopied)
+}
+
+func (s *activitiesSuite) TestOnceVisibilityManager() {
+	created := 0
+	m := newOnceVisibilityManager(func() (manager.VisibilityManager, error) {
+		created++
+		return s.mockPrimary, nil
+	})
+	// Closing a manager which was never created is a no-op.
+	m.close()
+
+	visibilityManager, err := m.get()
+	s.NoError(err)
+	s.Equal(s.mockPrimary, visibilityManager)
+	_, err = m.get()
+	s.NoError(err)
+	s.Equal(1, created)
+
+	s.mockPrimary.EXPECT().Close()
+	m.close()
+	_, err = m.get()
+	s.NoError(err)
+	s.Equal(2, created)
+}
+
+func (s *activitiesSuite) TestBackfillPage_SecondaryToPrimary() {
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivity(s.a)
+
+	s.mockSecondary.EXPECT().ScanWorkflowExecutions(gomock.Any(), gomock.Any()).Return(&manager.ListWorkflowExecutionsResponse{}, nil)
+
+	result, err := env.ExecuteActivity(s.a.BackfillPage, backfillPageRequest{
+		Namespace:   testNamespace.String(),
+		SourceStore: "elasticsearch",
+		TargetStore: "mysql8",
+		PageSize:    10,
+		RPS:         100,
+	})
+	s.NoError(err)
+	var resp backfillPageResponse
+	s.NoError(result.Get(&resp))
+	s.Equal(0, resp.RecordsCopied)
+	s.Empty(resp.NextPageToken)
+}
+
+func (s *activitiesSuite) TestBackfillPage_UnknownStore() {
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivity(s.a)
+	s.mockPrimary.EXPECT().GetStoreNames().Return([]string{"mysql8"})
+	s.mockSecondary.EXPECT().GetStoreNames().Return([]string{"elasticsearch"})
+
+	_, err := env.ExecuteActivity(s.a.BackfillPage, backfillPageRequest{
+		Namespace:   testNamespace.String(),
+		SourceStore: "postgres12",
+		PageSize:    10,
+		RPS:         100,
+	})
+	var appErr *temporal.ApplicationError
+	s.ErrorAs(err, &appErr)
+	s.True(appErr.NonRetryable())
+	s.Contains(appErr.Error(), `source store "postgres12"`)
+}
+
+func (s *activitiesSuite) TestVerifyCounts() {
+	env := s.NewTestActivityEnvironment()
+	env.RegisterActivity(s.a)
+
+	countRequest := &manager.CountWorkflowExecutionsRequest{
+		NamespaceID: testNamespaceID,
+		Namespace:   testNamespace,
+	}
+	s.mockPrimary.EXPECT().CountWorkflowExecutions(gomock.Any(), countRequest).Return(&manager.CountWorkflowExecutionsResponse{Count: 10}, nil).Times(2)
+	s.mockSecondary.EXPECT().CountWorkflowExecutions(gomock.Any(), countRequest).Return(&manager.CountWorkflowExecutionsResponse{Count: 10}, nil)
+	s.mockSecondary.EXPECT().CountWorkflowExecutions(gomock.Any(), countRequest).Return(&manager.CountWorkflowExecutionsResponse{Count: 9}, nil)
+
+	result, err := env.ExecuteActivity(s.a.VerifyCounts, verifyCountsRequest{Namespace: testNamespace.String()})
+	s.NoError(err)
+	var resp verifyCountsResponse
+	s.NoError(result.Get(&resp))
+	s.Equal(verifyCountsResponse{SourceCount: 10, TargetCount: 10}, resp)
+
+	_, err = env.ExecuteActivity(s.a.VerifyCounts, verifyCountsRequest{Namespace: testNamespace.String()})
+	var appErr *temporal.ApplicationError
+	s.ErrorAs(err, &appErr)
+	s.Equal(countMismatchErrType, appErr.Type())
+	s.NoError(appErr.Details(&resp))
+	s.Equal(verifyCountsResponse{SourceCount: 10, TargetCount: 9}, resp)
+}
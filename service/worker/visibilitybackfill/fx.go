@@ -0,0 +1,105 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"context"
+
+	sdkworker "go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/fx"
+
+	"go.temporal.io/server/common/headers"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/visibility/manager"
+	"go.temporal.io/server/common/primitives"
+	workercommon "go.temporal.io/server/service/worker/common"
+)
+
+type (
+	// VisibilityManagerFactory creates a visibility manager which can write to the primary and
+	// secondary visibility stores.
+	VisibilityManagerFactory func() (manager.VisibilityManager, error)
+
+	initParams struct {
+		fx.In
+		Lifecycle                fx.Lifecycle
+		VisibilityManagerFactory VisibilityManagerFactory
+		NamespaceRegistry        namespace.Registry
+		MetricsHandler           metrics.Handler
+		Logger                   log.Logger
+	}
+
+	visibilityBackfillComponent struct {
+		initParams
+		// The visibility manager is created on the first backfill only, so workers don't start
+		// the Elasticsearch bulk processor if visibility backfill is never used.
+		visibilityManager *onceVisibilityManager
+	}
+)
+
+var Module = workercommon.AnnotateWorkerComponentProvider(newComponent)
+
+func newComponent(params initParams) workercommon.WorkerComponent {
+	wc := &visibilityBackfillComponent{
+		initParams:        params,
+		visibilityManager: newOnceVisibilityManager(params.VisibilityManagerFactory),
+	}
+	params.Lifecycle.Append(fx.StopHook(wc.visibilityManager.close))
+	return wc
+}
+
+func (wc *visibilityBackfillComponent) RegisterWorkflow(registry sdkworker.Registry) {
+	registry.RegisterWorkflowWithOptions(BackfillWorkflow, workflow.RegisterOptions{Name: WorkflowName})
+}
+
+func (wc *visibilityBackfillComponent) DedicatedWorkflowWorkerOptions() *workercommon.DedicatedWorkerOptions {
+	// use default worker
+	return nil
+}
+
+func (wc *visibilityBackfillComponent) RegisterActivities(registry sdkworker.Registry) {
+	registry.RegisterActivity(wc.activities())
+}
+
+func (wc *visibilityBackfillComponent) DedicatedActivityWorkerOptions() *workercommon.DedicatedWorkerOptions {
+	return &workercommon.DedicatedWorkerOptions{
+		TaskQueue: primitives.VisibilityBackfillActivityTQ,
+		Options: sdkworker.Options{
+			BackgroundActivityContext: headers.SetCallerType(context.Background(), headers.CallerTypePreemptable),
+		},
+	}
+}
+
+func (wc *visibilityBackfillComponent) activities() *activities {
+	return &activities{
+		visibilityManagerProvider: wc.visibilityManager.get,
+		namespaceRegistry:         wc.NamespaceRegistry,
+		metricsHandler:            wc.MetricsHandler.WithTags(metrics.OperationTag(metrics.VisibilityBackfillWorkflowScope)),
+		logger:                    wc.Logger,
+	}
+}
@@ -0,0 +1,284 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+
+	"go.temporal.io/server/common/primitives"
+)
+
+const (
+	// WorkflowName is the name of the system workflow copying visibility records between stores.
+	WorkflowName = "temporal-sys-visibility-backfill-workflow"
+	// StatusQueryType is the query type returning the BackfillStatus of the workflow.
+	StatusQueryType = "visibility-backfill-status"
+
+	countMismatchErrType = "CountMismatch"
+
+	defaultPageSize              = 1000
+	defaultRPS                   = 100.0
+	defaultPageCountPerExecution = 100
+	maxPageCountPerExecution     = 1000
+)
+
+type (
+	// WorkflowParams is the parameters for visibility backfill workflow.
+	WorkflowParams struct {
+		// Namespaces to backfill. They are processed one after the other.
+		Namespaces []string
+		// Store names of the source and target visibility stores (eg: elasticsearch, mysql8).
+		// Default to the primary store as source and the secondary store as target.
+		SourceStore string
+		TargetStore string
+		// Query selects the workflows to backfill in every namespace. Empty query backfills all
+		// workflows of the namespace, except the ones in a namespace division.
+		Query string
+		// PageSize of the scan of the source store.
+		PageSize int
+		// RPS limits the number of records written to the target store per second.
+		RPS float64
+		// PageCountPerExecution is the number of pages processed before continue-as-new, max is 1000.
+		PageCountPerExecution int
+		// SkipVerification skips the comparison of the source and target counts for every namespace.
+		SkipVerification bool
+
+		// Checkpoint carried over continue-as-new.
+		NamespaceIndex      int
+		NextPageToken       []byte
+		Status              []NamespaceStatus
+		ContinuedAsNewCount int
+	}
+
+	// NamespaceStatus is the backfill progress of a single namespace.
+	NamespaceStatus struct {
+		Namespace     string
+		RecordsCopied int64
+		// Done is true when all the records were copied and counts were verified.
+		Done bool
+		// Counts of the workflows matching the query in source and target stores after the copy.
+		SourceCount int64
+		TargetCount int64
+		// VerificationError is set if the counts don't match or they couldn't be verified.
+		VerificationError string
+	}
+
+	// BackfillStatus is the result of the status query.
+	BackfillStatus struct {
+		Namespaces          []NamespaceStatus
+		CurrentNamespace    string
+		ContinuedAsNewCount int
+	}
+
+	backfillPageRequest struct {
+		Namespace     string
+		SourceStore   string
+		TargetStore   string
+		Query         string
+		PageSize      int
+		RPS           float64
+		NextPageToken []byte
+	}
+
+	backfillPageResponse struct {
+		RecordsCopied int
+		NextPageToken []byte
+	}
+
+	verifyCountsRequest struct {
+		Namespace   string
+		SourceStore string
+		TargetStore string
+		Query       string
+	}
+
+	verifyCountsResponse struct {
+		SourceCount int64
+		TargetCount int64
+	}
+)
+
+var (
+	backfillPageActivityOptions = workflow.ActivityOptions{
+		StartToCloseTimeout: time.Hour,
+		HeartbeatTimeout:    30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval: time.Second,
+			MaximumInterval: 10 * time.Minute,
+		},
+	}
+
+	// Stores might not be in sync right after the copy (eg: Elasticsearch refresh interval),
+	// so mismatching counts are retried for a while before reporting the mismatch.
+	verifyCountsActivityOptions = workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumAttempts:    5,
+		},
+	}
+)
+
+// BackfillWorkflow copies the visibility records of the namespaces from the source visibility
+// store to the target visibility store, and verifies the counts match once they are copied.
+// Writes to the target are idempotent, so the workflow can be restarted from scratch safely.
+func BackfillWorkflow(ctx workflow.Context, params WorkflowParams) error {
+	ctx = workflow.WithTaskQueue(ctx, primitives.VisibilityBackfillActivityTQ)
+
+	if err := validateAndSetWorkflowParams(&params); err != nil {
+		return err
+	}
+
+	if err := workflow.SetQueryHandler(ctx, StatusQueryType, func() (BackfillStatus, error) {
+		status := BackfillStatus{
+			Namespaces:          params.Status,
+			ContinuedAsNewCount: params.ContinuedAsNewCount,
+		}
+		if params.NamespaceIndex < len(params.Namespaces) {
+			status.CurrentNamespace = params.Namespaces[params.NamespaceIndex]
+		}
+		return status, nil
+	}); err != nil {
+		return err
+	}
+
+	var a *activities
+	pageCount := 0
+	for ; params.NamespaceIndex < len(params.Namespaces); params.NamespaceIndex++ {
+		status := &params.Status[params.NamespaceIndex]
+
+		for {
+			if pageCount >= params.PageCountPerExecution {
+				params.ContinuedAsNewCount++
+				// Continue-as-new to prevent history size from exceeding the server-defined limit.
+				return workflow.NewContinueAsNewError(ctx, BackfillWorkflow, params)
+			}
+
+			var resp backfillPageResponse
+			err := workflow.ExecuteActivity(
+				workflow.WithActivityOptions(ctx, backfillPageActivityOptions),
+				a.BackfillPage,
+				backfillPageRequest{
+					Namespace:     status.Namespace,
+					SourceStore:   params.SourceStore,
+					TargetStore:   params.TargetStore,
+					Query:         params.Query,
+					PageSize:      params.PageSize,
+					RPS:           params.RPS,
+					NextPageToken: params.NextPageToken,
+				},
+			).Get(ctx, &resp)
+			if err != nil {
+				return err
+			}
+			pageCount++
+			status.RecordsCopied += int64(resp.RecordsCopied)
+			params.NextPageToken = resp.NextPageToken
+			if len(params.NextPageToken) == 0 {
+				break
+			}
+		}
+
+		if !params.SkipVerification {
+			verifyCounts(ctx, params, status)
+		}
+		status.Done = true
+	}
+
+	var mismatches []string
+	for _, status := range params.Status {
+		if status.VerificationError != "" {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s", status.Namespace, status.VerificationError))
+		}
+	}
+	if len(mismatches) > 0 {
+		return temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("visibility backfill verification failed for %d namespace(s): %s", len(mismatches), strings.Join(mismatches, "; ")),
+			countMismatchErrType,
+			nil,
+			params.Status,
+		)
+	}
+	return nil
+}
+
+func verifyCounts(ctx workflow.Context, params WorkflowParams, status *NamespaceStatus) {
+	var a *activities
+	var resp verifyCountsResponse
+	err := workflow.ExecuteActivity(
+		workflow.WithActivityOptions(ctx, verifyCountsActivityOptions),
+		a.VerifyCounts,
+		verifyCountsRequest{
+			Namespace:   status.Namespace,
+			SourceStore: params.SourceStore,
+			TargetStore: params.TargetStore,
+			Query:       params.Query,
+		},
+	).Get(ctx, &resp)
+	if err != nil {
+		var appErr *temporal.ApplicationError
+		if errors.As(err, &appErr) && appErr.Type() == countMismatchErrType && appErr.HasDetails() {
+			_ = appErr.Details(&resp)
+		}
+		status.VerificationError = err.Error()
+	}
+	status.SourceCount = resp.SourceCount
+	status.TargetCount = resp.TargetCount
+}
+
+func validateAndSetWorkflowParams(params *WorkflowParams) error {
+	if len(params.Namespaces) == 0 {
+		return temporal.NewNonRetryableApplicationError("InvalidArgument: Namespaces is required", "InvalidArgument", nil)
+	}
+	if params.SourceStore != "" && params.SourceStore == params.TargetStore {
+		return temporal.NewNonRetryableApplicationError("InvalidArgument: SourceStore and TargetStore must be different", "InvalidArgument", nil)
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = defaultPageSize
+	}
+	if params.RPS <= 0 {
+		params.RPS = defaultRPS
+	}
+	if params.PageCountPerExecution <= 0 {
+		params.PageCountPerExecution = defaultPageCountPerExecution
+	}
+	if params.PageCountPerExecution > maxPageCountPerExecution {
+		params.PageCountPerExecution = maxPageCountPerExecution
+	}
+	if len(params.Status) != len(params.Namespaces) {
+		params.Status = make([]NamespaceStatus, len(params.Namespaces))
+		for i, ns := range params.Namespaces {
+			params.Status[i].Namespace = ns
+		}
+	}
+	return nil
+}
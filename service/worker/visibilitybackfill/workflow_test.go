@@ -0,0 +1,143 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func Test_BackfillWorkflow(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	var a *activities
+
+	env.OnActivity(a.BackfillPage, mock.Anything, backfillPageRequest{
+		Namespace: "ns-1", PageSize: 10, RPS: defaultRPS,
+	}).Return(backfillPageResponse{RecordsCopied: 10, NextPageToken: []byte("token")}, nil).Once()
+	env.OnActivity(a.BackfillPage, mock.Anything, backfillPageRequest{
+		Namespace: "ns-1", PageSize: 10, RPS: defaultRPS, NextPageToken: []byte("token"),
+	}).Return(backfillPageResponse{RecordsCopied: 5}, nil).Once()
+	env.OnActivity(a.BackfillPage, mock.Anything, backfillPageRequest{
+		Namespace: "ns-2", PageSize: 10, RPS: defaultRPS,
+	}).Return(backfillPageResponse{RecordsCopied: 3}, nil).Once()
+	env.OnActivity(a.VerifyCounts, mock.Anything, verifyCountsRequest{Namespace: "ns-1"}).
+		Return(verifyCountsResponse{SourceCount: 15, TargetCount: 15}, nil).Once()
+	env.OnActivity(a.VerifyCounts, mock.Anything, verifyCountsRequest{Namespace: "ns-2"}).
+		Return(verifyCountsResponse{SourceCount: 3, TargetCount: 3}, nil).Once()
+
+	env.ExecuteWorkflow(BackfillWorkflow, WorkflowParams{
+		Namespaces: []string{"ns-1", "ns-2"},
+		PageSize:   10,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+
+	encodedStatus, err := env.QueryWorkflow(StatusQueryType)
+	require.NoError(t, err)
+	var status BackfillStatus
+	require.NoError(t, encodedStatus.Get(&status))
+	require.Equal(t, []NamespaceStatus{
+		{Namespace: "ns-1", RecordsCopied: 15, Done: true, SourceCount: 15, TargetCount: 15},
+		{Namespace: "ns-2", RecordsCopied: 3, Done: true, SourceCount: 3, TargetCount: 3},
+	}, status.Namespaces)
+}
+
+func Test_BackfillWorkflow_ContinueAsNew(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	var a *activities
+
+	env.OnActivity(a.BackfillPage, mock.Anything, mock.Anything).
+		Return(backfillPageResponse{RecordsCopied: 10, NextPageToken: []byte("token")}, nil).Times(2)
+
+	env.ExecuteWorkflow(BackfillWorkflow, WorkflowParams{
+		Namespaces:            []string{"ns-1"},
+		PageCountPerExecution: 2,
+		SkipVerification:      true,
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	err := env.GetWorkflowError()
+	var continueAsNewErr *workflow.ContinueAsNewError
+	require.True(t, errors.As(err, &continueAsNewErr))
+	env.AssertExpectations(t)
+
+	encodedStatus, err := env.QueryWorkflow(StatusQueryType)
+	require.NoError(t, err)
+	var status BackfillStatus
+	require.NoError(t, encodedStatus.Get(&status))
+	require.Equal(t, "ns-1", status.CurrentNamespace)
+	require.Equal(t, 1, status.ContinuedAsNewCount)
+	require.Equal(t, int64(20), status.Namespaces[0].RecordsCopied)
+}
+
+func Test_BackfillWorkflow_CountMismatch(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+	var a *activities
+
+	env.OnActivity(a.BackfillPage, mock.Anything, mock.Anything).Return(backfillPageResponse{RecordsCopied: 10}, nil).Once()
+	env.OnActivity(a.VerifyCounts, mock.Anything, mock.Anything).Return(
+		verifyCountsResponse{},
+		temporal.NewNonRetryableApplicationError("count mismatch", countMismatchErrType, nil, verifyCountsResponse{SourceCount: 10, TargetCount: 9}),
+	).Once()
+
+	env.ExecuteWorkflow(BackfillWorkflow, WorkflowParams{
+		Namespaces: []string{"ns-1"},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, env.GetWorkflowError(), &appErr)
+	require.Equal(t, countMismatchErrType, appErr.Type())
+	var status []NamespaceStatus
+	require.NoError(t, appErr.Details(&status))
+	require.Len(t, status, 1)
+	require.Equal(t, int64(10), status[0].SourceCount)
+	require.Equal(t, int64(9), status[0].TargetCount)
+	require.True(t, status[0].Done)
+	require.NotEmpty(t, status[0].VerificationError)
+}
+
+func Test_BackfillWorkflow_InvalidParams(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	env.ExecuteWorkflow(BackfillWorkflow, WorkflowParams{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	var appErr *temporal.ApplicationError
+	require.ErrorAs(t, env.GetWorkflowError(), &appErr)
+	require.True(t, appErr.NonRetryable())
+}
@@ -0,0 +1,127 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"time"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/log/tag"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/service/frontend"
+	historyconfigs "go.temporal.io/server/service/history/configs"
+	"go.temporal.io/server/service/matching"
+	"go.temporal.io/server/service/worker"
+)
+
+// dynamicConfigValidationInterval is how often dynamic config values are validated. Clients
+// reload their values in the background, so they are validated periodically rather than once.
+const dynamicConfigValidationInterval = time.Minute
+
+var dynamicConfigValidationErrorKinds = []dynamicconfig.ValidationErrorKind{
+	dynamicconfig.ValidationErrorUnknownKey,
+	dynamicconfig.ValidationErrorWrongType,
+	dynamicconfig.ValidationErrorUnusedConstraints,
+}
+
+// dynamicConfigValidator logs dynamic config validation errors and emits their count by kind.
+// Each error is only logged once while it remains.
+type dynamicConfigValidator struct {
+	client         dynamicconfig.Client
+	logger         log.Logger
+	metricsHandler metrics.Handler
+	reported       map[string]struct{}
+}
+
+func newDynamicConfigValidator(
+	client dynamicconfig.Client,
+	logger log.Logger,
+	metricsHandler metrics.Handler,
+) *dynamicConfigValidator {
+	return &dynamicConfigValidator{
+		client:         client,
+		logger:         logger,
+		metricsHandler: metricsHandler,
+		reported:       make(map[string]struct{}),
+	}
+}
+
+func (v *dynamicConfigValidator) run(doneCh <-chan interface{}) {
+	if _, ok := v.client.(dynamicconfig.ListableClient); !ok {
+		return
+	}
+
+	ticker := time.NewTicker(dynamicConfigValidationInterval)
+	defer ticker.Stop()
+	for {
+		v.validate()
+		select {
+		case <-doneCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (v *dynamicConfigValidator) validate() {
+	errs := dynamicconfig.Validate(v.client)
+
+	counts := make(map[dynamicconfig.ValidationErrorKind]int, len(dynamicConfigValidationErrorKinds))
+	reported := make(map[string]struct{}, len(errs))
+	for _, err := range errs {
+		counts[err.Kind]++
+		msg := err.Error()
+		reported[msg] = struct{}{}
+		if _, ok := v.reported[msg]; !ok {
+			v.logger.Warn("Invalid dynamic config value", tag.Key(err.Key.String()), tag.Error(err))
+		}
+	}
+	v.reported = reported
+
+	for _, kind := range dynamicConfigValidationErrorKinds {
+		metrics.DynamicConfigValidationErrors.With(v.metricsHandler).Record(
+			float64(counts[kind]),
+			metrics.StringTag("error_kind", string(kind)),
+		)
+	}
+}
+
+// ValidateDynamicConfig validates the values of client without starting the server. The dynamic
+// config keys used by the configs of all services are registered first, so that their types and
+// constraints can be checked.
+func ValidateDynamicConfig(
+	client dynamicconfig.Client,
+	persistenceConfig *config.Persistence,
+	logger log.Logger,
+) []dynamicconfig.ValidationError {
+	dc := dynamicconfig.NewCollection(client, logger)
+	frontend.NewConfig(dc, persistenceConfig.NumHistoryShards)
+	historyconfigs.NewConfig(dc, persistenceConfig.NumHistoryShards)
+	matching.NewConfig(dc)
+	worker.NewConfig(dc, persistenceConfig)
+	return dynamicconfig.Validate(client)
+}
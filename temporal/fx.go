@@ -218,11 +218,20 @@ func ServerOptionsProvider(opts []ServerOption) (serverOptionsProvider, error) {
 	dcClient := so.dynamicConfigClient
 	if dcClient == nil {
 		dcConfig := so.config.DynamicConfigClient
+		dcDirectoryConfig := so.config.DynamicConfigDirectoryClient
+		if dcConfig != nil && dcDirectoryConfig != nil {
+			return serverOptionsProvider{}, errors.New("only one of dynamicConfigClient and dynamicConfigDirectoryClient can be configured")
+		}
 		if dcConfig != nil {
 			dcClient, err = dynamicconfig.NewFileBasedClient(dcConfig, logger, stopChan)
 			if err != nil {
 				return serverOptionsProvider{}, fmt.Errorf("unable to create dynamic config client: %w", err)
 			}
+		} else if dcDirectoryConfig != nil {
+			dcClient, err = dynamicconfig.NewDirectoryClient(dcDirectoryConfig, logger, stopChan)
+			if err != nil {
+				return serverOptionsProvider{}, fmt.Errorf("unable to create dynamic config client: %w", err)
+			}
 		} else {
 			// noop client
 			logger.Info("Dynamic config client is not configured. Using default values.")
@@ -33,6 +33,7 @@ import (
 
 	"go.temporal.io/server/common/cluster"
 	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
 	"go.temporal.io/server/common/headers"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
@@ -56,6 +57,7 @@ type (
 		clusterMetadata            *cluster.Config
 		persistenceFactoryProvider persistenceClient.FactoryProviderFn
 		metricsHandler             metrics.Handler
+		dynamicConfigClient        dynamicconfig.Client
 	}
 )
 
@@ -70,6 +72,7 @@ func NewServerFxImpl(
 	clusterMetadata *cluster.Config,
 	persistenceFactoryProvider persistenceClient.FactoryProviderFn,
 	metricsHandler metrics.Handler,
+	dynamicConfigClient dynamicconfig.Client,
 ) *ServerImpl {
 	s := &ServerImpl{
 		so:                         opts,
@@ -80,6 +83,7 @@ func NewServerFxImpl(
 		clusterMetadata:            clusterMetadata,
 		persistenceFactoryProvider: persistenceFactoryProvider,
 		metricsHandler:             metricsHandler,
+		dynamicConfigClient:        dynamicConfigClient,
 	}
 	for _, svcMeta := range servicesGroup.Services {
 		if svcMeta != nil {
@@ -106,7 +110,14 @@ func (s *ServerImpl) Start(ctx context.Context) error {
 		return fmt.Errorf("unable to initialize system namespace: %w", err)
 	}
 
-	return s.startServices()
+	if err := s.startServices(); err != nil {
+		return err
+	}
+
+	// Services register the dynamic config keys they use while they are created, so values can
+	// be validated once they are all up.
+	go newDynamicConfigValidator(s.dynamicConfigClient, s.logger, s.metricsHandler).run(s.stoppedCh)
+	return nil
 }
 
 func (s *ServerImpl) Stop(ctx context.Context) error {
@@ -123,6 +134,9 @@ func (s *ServerImpl) Stop(ctx context.Context) error {
 
 	wg.Wait()
 
+	if stopper, ok := s.so.authorizer.(interface{ Stop() }); ok {
+		stopper.Stop()
+	}
 	if s.so.metricHandler != nil {
 		s.so.metricHandler.Stop(s.logger)
 	}
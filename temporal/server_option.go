@@ -99,7 +99,9 @@ func WithNamespaceLogger(namespaceLogger log.Logger) ServerOption {
 	})
 }
 
-// WithAuthorizer sets a low level authorizer to allow/deny all API calls
+// WithAuthorizer sets a low level authorizer to allow/deny all API calls.
+// If the authorizer has a Stop method, such as the policy authorizer created by
+// authorization.NewAuthorizerFromConfig, it is called when the server stops.
 func WithAuthorizer(authorizer authorization.Authorizer) ServerOption {
 	return applyFunc(func(s *serverOptions) {
 		s.authorizer = authorizer
@@ -133,5 +133,15 @@ func (so *serverOptions) validateConfig() error {
 			return fmt.Errorf("%q service is missing in config", name)
 		}
 	}
+
+	// Embedded visibility store keeps the index in the process, so the records written by history
+	// service are only visible to the other services running in the same process.
+	if so.config.Persistence.IsEmbeddedVisibilityStore() {
+		for _, name := range DefaultServices {
+			if _, ok := so.serviceNames[primitives.ServiceName(name)]; !ok {
+				return fmt.Errorf("embedded visibility store requires all services in one process: %q service is not started", name)
+			}
+		}
+	}
 	return nil
 }
@@ -0,0 +1,53 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/primitives"
+	"go.temporal.io/server/tests/testutils"
+)
+
+func TestValidateConfig_EmbeddedVisibility(t *testing.T) {
+	configDir := path.Join(testutils.GetRepoRootDirectory(), "config")
+	cfg, err := config.LoadConfig("development-sqlite", configDir, "")
+	require.NoError(t, err)
+	cfg.Persistence.DataStores["embedded-visibility"] = config.DataStore{
+		Embedded: &config.EmbeddedVisibility{IndexName: "temporal_visibility"},
+	}
+	cfg.Persistence.VisibilityStore = "embedded-visibility"
+
+	so := newServerOptions([]ServerOption{ForServices(DefaultServices), WithConfig(cfg)})
+	require.NoError(t, so.validateConfig())
+
+	so = newServerOptions([]ServerOption{ForServices([]string{string(primitives.HistoryService)}), WithConfig(cfg)})
+	err = so.validateConfig()
+	require.ErrorContains(t, err, "embedded visibility store requires all services in one process")
+}
@@ -77,3 +77,12 @@ func WithBaseServerOptions(options ...temporal.ServerOption) TestServerOption {
 		server.serverOptions = append(server.serverOptions, options...)
 	})
 }
+
+// WithEmbeddedVisibility configures the test server to use the embedded in-process visibility store
+// instead of SQLite, so visibility queries behave as with Elasticsearch (e.g. custom search attributes
+// are created on demand instead of being pre-allocated).
+func WithEmbeddedVisibility() TestServerOption {
+	return applyFunc(func(server *TestServer) {
+		server.embeddedVisibility = true
+	})
+}
@@ -57,6 +57,7 @@ type TestServer struct {
 	defaultClientOptions client.Options
 	defaultWorkerOptions worker.Options
 	serverOptions        []temporal.ServerOption
+	embeddedVisibility   bool
 }
 
 func (ts *TestServer) fatal(err error) {
@@ -165,9 +166,10 @@ func NewServer(opts ...TestServerOption) *TestServer {
 	}
 
 	s, err := temporalite.NewLiteServer(&temporalite.LiteServerConfig{
-		Namespaces: []string{ts.defaultTestNamespace},
-		Ephemeral:  true,
-		Logger:     log.NewNoopLogger(),
+		Namespaces:         []string{ts.defaultTestNamespace},
+		Ephemeral:          true,
+		EmbeddedVisibility: ts.embeddedVisibility,
+		Logger:             log.NewNoopLogger(),
 		DynamicConfig: dynamicconfig.StaticClient{
 			dynamicconfig.ForceSearchAttributesCacheRefreshOnRead: []dynamicconfig.ConstrainedValue{{Value: true}},
 		},
@@ -282,9 +282,16 @@ func TestClientWithCustomInterceptor(t *testing.T) {
 }
 
 func TestSearchAttributeRegistration(t *testing.T) {
+	testSearchAttributeRegistration(t, temporaltest.NewServer(temporaltest.WithT(t)))
+}
+
+func TestSearchAttributeRegistrationEmbeddedVisibility(t *testing.T) {
+	testSearchAttributeRegistration(t, temporaltest.NewServer(temporaltest.WithT(t), temporaltest.WithEmbeddedVisibility()))
+}
+
+func testSearchAttributeRegistration(t *testing.T, ts *temporaltest.TestServer) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	ts := temporaltest.NewServer(temporaltest.WithT(t))
 	c := ts.GetDefaultClient()
 
 	testSearchAttr := "MySearchAttr"
@@ -57,6 +57,7 @@ import (
 	"go.temporal.io/server/common/log/tag"
 	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/payloads"
+	"go.temporal.io/server/common/persistence/visibility/manager"
 	esclient "go.temporal.io/server/common/persistence/visibility/store/elasticsearch/client"
 	"go.temporal.io/server/common/primitives"
 	"go.temporal.io/server/common/searchattribute"
@@ -1141,17 +1142,32 @@ func (s *AdvancedVisibilitySuite) TestCountGroupByWorkflow() {
 		resp.Groups[1],
 	)
 
-	query = `GROUP BY WorkflowType`
+	query = fmt.Sprintf(`SELECT MAX(StartTime) WHERE WorkflowType = %q GROUP BY ExecutionStatus, WorkflowType`, wt)
+	countRequest.Query = query
+	resp, err = s.engine.CountWorkflowExecutions(NewContext(), countRequest)
+	s.NoError(err)
+	s.Equal(int64(numWorkflows), resp.GetCount())
+	s.Equal(2, len(resp.Groups))
+	for _, group := range resp.Groups {
+		// ExecutionStatus and WorkflowType values.
+		s.Equal(2, len(group.GroupValues))
+		aggregationValues, err := manager.GetAggregationValues(group)
+		s.NoError(err)
+		// MAX(StartTime) value.
+		s.Equal(1, len(aggregationValues))
+	}
+
+	query = `GROUP BY StartTime`
 	countRequest.Query = query
 	_, err = s.engine.CountWorkflowExecutions(NewContext(), countRequest)
 	s.Error(err)
-	s.Contains(err.Error(), "'group by' clause is only supported for ExecutionStatus search attribute")
+	s.Contains(err.Error(), "'group by' clause is only supported for Keyword search attributes")
 
-	query = `GROUP BY ExecutionStatus, WorkflowType`
+	query = `SELECT AVG(StartTime)`
 	countRequest.Query = query
 	_, err = s.engine.CountWorkflowExecutions(NewContext(), countRequest)
 	s.Error(err)
-	s.Contains(err.Error(), "'group by' clause supports only a single field")
+	s.Contains(err.Error(), "function 'avg' is not supported for StartTime")
 }
 
 func (s *AdvancedVisibilitySuite) createStartWorkflowExecutionRequest(id, wt, tl string) *workflowservice.StartWorkflowExecutionRequest {
@@ -35,6 +35,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/olekukonko/tablewriter"
 	"github.com/temporalio/tctl-kit/pkg/color"
 	commonpb "go.temporal.io/api/common/v1"
 	enumspb "go.temporal.io/api/enums/v1"
@@ -679,17 +680,41 @@ func AdminRebuildMutableState(c *cli.Context, clientFactory ClientFactory) error
 	ctx, cancel := newContext(c)
 	defer cancel()
 
-	_, err = adminClient.RebuildMutableState(ctx, &adminservice.RebuildMutableStateRequest{
+	dryRun := c.Bool(FlagDryRun)
+	resp, err := adminClient.RebuildMutableState(ctx, &adminservice.RebuildMutableStateRequest{
 		Namespace: namespace,
 		Execution: &commonpb.WorkflowExecution{
 			WorkflowId: wid,
 			RunId:      rid,
 		},
+		DryRun: dryRun,
 	})
 	if err != nil {
 		return fmt.Errorf("rebuild mutable state failed: %s", err)
-	} else {
+	}
+	if !dryRun {
 		fmt.Println("rebuild mutable state succeeded.")
+		return nil
+	}
+
+	if c.Bool(FlagPrintFullyDetail) {
+		fmt.Println(color.Green(c, "Current mutable state:"))
+		prettyPrintJSONObject(resp.GetCurrentMutableState())
+		fmt.Println(color.Green(c, "Rebuilt mutable state:"))
+		prettyPrintJSONObject(resp.GetRebuiltMutableState())
+	}
+	fmt.Println(color.Green(c, "Differences:"))
+	if len(resp.GetDiffs()) == 0 {
+		fmt.Println("none, rebuilt mutable state is identical to the current one.")
+		return nil
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Path", "Current", "Rebuilt"})
+	for _, diff := range resp.GetDiffs() {
+		table.Append([]string{diff.GetPath(), diff.GetCurrent(), diff.GetRebuilt()})
 	}
+	table.Render()
 	return nil
 }
@@ -0,0 +1,78 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tdbg
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+)
+
+// AdminGetDynamicConfig displays the dynamic config values known to the frontend and the value
+// in effect for the given constraints
+func AdminGetDynamicConfig(c *cli.Context, clientFactory ClientFactory) error {
+	tqType, err := stringToEnumWithPrefix(c.String(FlagTaskQueueType), "TASK_QUEUE_TYPE_", enumspb.TaskQueueType_value)
+	if err != nil {
+		return fmt.Errorf("invalid task queue type: %v", err)
+	}
+	taskType, err := stringToEnumWithPrefix(c.String(FlagTaskType), "TASK_TYPE_", enumsspb.TaskType_value)
+	if err != nil {
+		return fmt.Errorf("invalid task type: %v", err)
+	}
+
+	adminClient := clientFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.GetDynamicConfig(ctx, &adminservice.GetDynamicConfigRequest{
+		Keys: c.StringSlice(FlagKey),
+		Constraints: &adminservice.DynamicConfigConstraints{
+			Namespace:     c.String(FlagNamespace),
+			NamespaceId:   c.String(FlagNamespaceID),
+			TaskQueueName: c.String(FlagTaskQueue),
+			TaskQueueType: enumspb.TaskQueueType(tqType),
+			ShardId:       int32(c.Int(FlagShardID)),
+			TaskType:      enumsspb.TaskType(taskType),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get dynamic config: %v", err)
+	}
+
+	prettyPrintJSONObject(response)
+	return nil
+}
+
+// stringToEnumWithPrefix is like StringToEnum but also accepts enum names without their prefix,
+// e.g. "activity" for TASK_QUEUE_TYPE_ACTIVITY.
+func stringToEnumWithPrefix(search string, prefix string, candidates map[string]int32) (int32, error) {
+	if value, err := StringToEnum(search, candidates); err == nil {
+		return value, nil
+	}
+	return StringToEnum(prefix+search, candidates)
+}
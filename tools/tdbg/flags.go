@@ -86,4 +86,14 @@ var (
 	FlagBase64File                 = "base64-file"
 	FlagTaskCategoryID             = "task-category-id"
 	FlagEncoding                   = "encoding"
+	FlagKey                        = "key"
+	FlagVisibilityRetention        = "visibility-retention"
+	FlagQuery                      = "query"
+	FlagQueryAlias                 = []string{"q"}
+	FlagArchiveDir                 = "archive-dir"
+	FlagProgressFile               = "progress-file"
+	FlagDryRun                     = "dry-run"
+	FlagMinShardID                 = "min-shard-id"
+	FlagMaxShardID                 = "max-shard-id"
+	FlagRefreshInterval            = "refresh-interval"
 )
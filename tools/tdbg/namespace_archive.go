@@ -0,0 +1,451 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tdbg
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/protobuf/proto"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence/versionhistory"
+)
+
+const (
+	namespaceArchiveVersion          = 1
+	namespaceArchiveManifestFile     = "manifest.json"
+	namespaceArchiveExecutionsDir    = "executions"
+	namespaceArchiveProgressFile     = "import.progress"
+	namespaceArchiveListPageSize     = 100
+	namespaceArchiveHistoryPageSize  = 100
+	namespaceImportMaxBatchesPerCall = 16
+	namespaceImportMaxBytesPerCall   = 256 * 1024 // 256K
+)
+
+type (
+	// namespaceArchiveManifest describes the content of a namespace archive written by
+	// `tdbg namespace export` and read by `tdbg namespace import`.
+	namespaceArchiveManifest struct {
+		Version     int                         `json:"version"`
+		Namespace   string                      `json:"namespace"`
+		NamespaceID string                      `json:"namespaceId"`
+		Query       string                      `json:"query"`
+		ExportTime  time.Time                   `json:"exportTime"`
+		Executions  []namespaceArchiveExecution `json:"executions"`
+	}
+
+	// namespaceArchiveExecution describes one exported workflow execution. The history file holds
+	// an adminservice.GetWorkflowExecutionRawHistoryV2Response with all raw history batches of the
+	// current branch, the mutable state file holds the persistence.WorkflowMutableState of the source.
+	namespaceArchiveExecution struct {
+		WorkflowID           string `json:"workflowId"`
+		RunID                string `json:"runId"`
+		HistoryFile          string `json:"historyFile"`
+		HistoryChecksum      string `json:"historyChecksum"`
+		MutableStateFile     string `json:"mutableStateFile"`
+		MutableStateChecksum string `json:"mutableStateChecksum"`
+		LastEventID          int64  `json:"lastEventId"`
+		LastEventVersion     int64  `json:"lastEventVersion"`
+	}
+
+	namespaceImportProgress struct {
+		WorkflowID string `json:"workflowId"`
+		RunID      string `json:"runId"`
+	}
+)
+
+// AdminExportNamespace exports the history and mutable state of the executions matching a visibility query
+// into an archive directory
+func AdminExportNamespace(c *cli.Context, clientFactory ClientFactory) error {
+	nsName, err := getRequiredOption(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	archiveDir, err := getRequiredOption(c, FlagArchiveDir)
+	if err != nil {
+		return err
+	}
+	query := c.String(FlagQuery)
+
+	if _, err := os.Stat(filepath.Join(archiveDir, namespaceArchiveManifestFile)); err == nil {
+		return fmt.Errorf("archive directory %s already contains a manifest", archiveDir)
+	}
+	if err := os.MkdirAll(filepath.Join(archiveDir, namespaceArchiveExecutionsDir), 0755); err != nil {
+		return fmt.Errorf("unable to create archive directory: %s", err)
+	}
+
+	nsID, err := getNamespaceID(c, clientFactory, namespace.Name(nsName))
+	if err != nil {
+		return fmt.Errorf("unable to describe namespace: %s", err)
+	}
+
+	manifest := namespaceArchiveManifest{
+		Version:     namespaceArchiveVersion,
+		Namespace:   nsName,
+		NamespaceID: nsID.String(),
+		Query:       query,
+		ExportTime:  time.Now().UTC(),
+	}
+	var token []byte
+	for doContinue := true; doContinue; doContinue = len(token) != 0 {
+		ctx, cancel := newContext(c)
+		resp, err := clientFactory.WorkflowClient(c).ListWorkflowExecutions(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     nsName,
+			PageSize:      namespaceArchiveListPageSize,
+			NextPageToken: token,
+			Query:         query,
+		})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("unable to list workflow executions: %s", err)
+		}
+		for _, executionInfo := range resp.GetExecutions() {
+			execution, err := exportExecution(c, clientFactory, archiveDir, nsName, nsID, len(manifest.Executions), executionInfo.GetExecution())
+			if err != nil {
+				return err
+			}
+			manifest.Executions = append(manifest.Executions, execution)
+		}
+		token = resp.GetNextPageToken()
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize manifest: %s", err)
+	}
+	if err := writeFileAtomic(filepath.Join(archiveDir, namespaceArchiveManifestFile), data); err != nil {
+		return fmt.Errorf("unable to write manifest: %s", err)
+	}
+	fmt.Printf("Exported %d workflow executions of namespace %s to %s.\n", len(manifest.Executions), nsName, archiveDir)
+	return nil
+}
+
+func exportExecution(
+	c *cli.Context,
+	clientFactory ClientFactory,
+	archiveDir string,
+	nsName string,
+	nsID namespace.ID,
+	idx int,
+	execution *commonpb.WorkflowExecution,
+) (namespaceArchiveExecution, error) {
+	adminClient := clientFactory.AdminClient(c)
+
+	rawHistory := &adminservice.GetWorkflowExecutionRawHistoryV2Response{}
+	var token []byte
+	for doContinue := true; doContinue; doContinue = len(token) != 0 {
+		ctx, cancel := newContext(c)
+		resp, err := adminClient.GetWorkflowExecutionRawHistoryV2(ctx, &adminservice.GetWorkflowExecutionRawHistoryV2Request{
+			NamespaceId:     nsID.String(),
+			Execution:       execution,
+			MaximumPageSize: namespaceArchiveHistoryPageSize,
+			NextPageToken:   token,
+		})
+		cancel()
+		if err != nil {
+			return namespaceArchiveExecution{}, fmt.Errorf("unable to read history of workflow %s/%s: %s", execution.GetWorkflowId(), execution.GetRunId(), err)
+		}
+		rawHistory.HistoryBatches = append(rawHistory.HistoryBatches, resp.GetHistoryBatches()...)
+		if rawHistory.VersionHistory == nil {
+			rawHistory.VersionHistory = resp.GetVersionHistory()
+		}
+		token = resp.GetNextPageToken()
+	}
+	lastItem, err := versionhistory.GetLastVersionHistoryItem(rawHistory.GetVersionHistory())
+	if err != nil {
+		return namespaceArchiveExecution{}, fmt.Errorf("invalid version history of workflow %s/%s: %s", execution.GetWorkflowId(), execution.GetRunId(), err)
+	}
+
+	ctx, cancel := newContext(c)
+	msResp, err := adminClient.DescribeMutableState(ctx, &adminservice.DescribeMutableStateRequest{
+		Namespace: nsName,
+		Execution: execution,
+	})
+	cancel()
+	if err != nil {
+		return namespaceArchiveExecution{}, fmt.Errorf("unable to describe mutable state of workflow %s/%s: %s", execution.GetWorkflowId(), execution.GetRunId(), err)
+	}
+
+	exported := namespaceArchiveExecution{
+		WorkflowID:       execution.GetWorkflowId(),
+		RunID:            execution.GetRunId(),
+		HistoryFile:      filepath.Join(namespaceArchiveExecutionsDir, fmt.Sprintf("%08d.history", idx)),
+		MutableStateFile: filepath.Join(namespaceArchiveExecutionsDir, fmt.Sprintf("%08d.mutablestate", idx)),
+		LastEventID:      lastItem.GetEventId(),
+		LastEventVersion: lastItem.GetVersion(),
+	}
+	if exported.HistoryChecksum, err = writeArchiveBlob(archiveDir, exported.HistoryFile, rawHistory); err != nil {
+		return namespaceArchiveExecution{}, err
+	}
+	if exported.MutableStateChecksum, err = writeArchiveBlob(archiveDir, exported.MutableStateFile, msResp.GetDatabaseMutableState()); err != nil {
+		return namespaceArchiveExecution{}, err
+	}
+	return exported, nil
+}
+
+// AdminImportNamespace imports the executions of an archive written by AdminExportNamespace. Imported
+// executions are recorded in a progress file, so that a failed import can be resumed by running it again.
+func AdminImportNamespace(c *cli.Context, clientFactory ClientFactory) error {
+	nsName, err := getRequiredOption(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	archiveDir, err := getRequiredOption(c, FlagArchiveDir)
+	if err != nil {
+		return err
+	}
+	progressFile := c.String(FlagProgressFile)
+	if progressFile == "" {
+		progressFile = filepath.Join(archiveDir, namespaceArchiveProgressFile)
+	}
+
+	data, err := os.ReadFile(filepath.Join(archiveDir, namespaceArchiveManifestFile))
+	if err != nil {
+		return fmt.Errorf("unable to read manifest: %s", err)
+	}
+	var manifest namespaceArchiveManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("unable to deserialize manifest: %s", err)
+	}
+	if manifest.Version != namespaceArchiveVersion {
+		return fmt.Errorf("unsupported archive version: %d", manifest.Version)
+	}
+
+	imported, err := readImportProgress(progressFile)
+	if err != nil {
+		return fmt.Errorf("unable to read import progress: %s", err)
+	}
+	progress, err := os.OpenFile(progressFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open import progress: %s", err)
+	}
+	defer func() { _ = progress.Close() }()
+
+	importedCount, skippedCount := 0, 0
+	for _, execution := range manifest.Executions {
+		key := namespaceImportProgress{WorkflowID: execution.WorkflowID, RunID: execution.RunID}
+		if _, ok := imported[key]; ok {
+			skippedCount++
+			continue
+		}
+		if err := importExecution(c, clientFactory, archiveDir, nsName, execution); err != nil {
+			return fmt.Errorf("%s, %d workflow executions imported, run the command again to resume", err, importedCount)
+		}
+		if err := appendImportProgress(progress, key); err != nil {
+			return fmt.Errorf("unable to record import progress: %s", err)
+		}
+		importedCount++
+	}
+	fmt.Printf("Imported %d workflow executions into namespace %s, %d were already imported.\n", importedCount, nsName, skippedCount)
+	return nil
+}
+
+func importExecution(
+	c *cli.Context,
+	clientFactory ClientFactory,
+	archiveDir string,
+	nsName string,
+	execution namespaceArchiveExecution,
+) error {
+	rawHistory := &adminservice.GetWorkflowExecutionRawHistoryV2Response{}
+	if err := readArchiveBlob(archiveDir, execution.HistoryFile, execution.HistoryChecksum, rawHistory); err != nil {
+		return err
+	}
+	if err := readArchiveBlob(archiveDir, execution.MutableStateFile, execution.MutableStateChecksum, &persistencespb.WorkflowMutableState{}); err != nil {
+		return err
+	}
+
+	adminClient := clientFactory.AdminClient(c)
+	workflowExecution := &commonpb.WorkflowExecution{
+		WorkflowId: execution.WorkflowID,
+		RunId:      execution.RunID,
+	}
+
+	// the execution may have been imported by a previous run which failed before recording the progress
+	ctx, cancel := newContext(c)
+	msResp, err := adminClient.DescribeMutableState(ctx, &adminservice.DescribeMutableStateRequest{
+		Namespace: nsName,
+		Execution: workflowExecution,
+	})
+	cancel()
+	switch err.(type) {
+	case nil:
+		return verifyImportedExecution(execution, msResp.GetDatabaseMutableState())
+	case *serviceerror.NotFound:
+	default:
+		return fmt.Errorf("unable to describe mutable state of workflow %s/%s: %s", execution.WorkflowID, execution.RunID, err)
+	}
+
+	var token []byte
+	sendBatches := func(batches []*commonpb.DataBlob) error {
+		ctx, cancel := newContext(c)
+		defer cancel()
+		resp, err := adminClient.ImportWorkflowExecution(ctx, &adminservice.ImportWorkflowExecutionRequest{
+			Namespace:      nsName,
+			Execution:      workflowExecution,
+			HistoryBatches: batches,
+			VersionHistory: rawHistory.GetVersionHistory(),
+			Token:          token,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to import workflow %s/%s: %s", execution.WorkflowID, execution.RunID, err)
+		}
+		token = resp.GetToken()
+		return nil
+	}
+
+	var batches []*commonpb.DataBlob
+	batchesSize := 0
+	for _, batch := range rawHistory.GetHistoryBatches() {
+		batches = append(batches, batch)
+		batchesSize += len(batch.GetData())
+		if len(batches) >= namespaceImportMaxBatchesPerCall || batchesSize >= namespaceImportMaxBytesPerCall {
+			if err := sendBatches(batches); err != nil {
+				return err
+			}
+			batches = nil
+			batchesSize = 0
+		}
+	}
+	if len(batches) != 0 {
+		if err := sendBatches(batches); err != nil {
+			return err
+		}
+	}
+	// call with empty history to commit
+	if err := sendBatches([]*commonpb.DataBlob{}); err != nil {
+		return err
+	}
+	if len(token) != 0 {
+		return fmt.Errorf("unable to import workflow %s/%s, not committed", execution.WorkflowID, execution.RunID)
+	}
+	return nil
+}
+
+func verifyImportedExecution(execution namespaceArchiveExecution, mutableState *persistencespb.WorkflowMutableState) error {
+	versionHistory, err := versionhistory.GetCurrentVersionHistory(mutableState.GetExecutionInfo().GetVersionHistories())
+	if err != nil {
+		return fmt.Errorf("invalid version history of existing workflow %s/%s: %s", execution.WorkflowID, execution.RunID, err)
+	}
+	lastItem, err := versionhistory.GetLastVersionHistoryItem(versionHistory)
+	if err != nil {
+		return fmt.Errorf("invalid version history of existing workflow %s/%s: %s", execution.WorkflowID, execution.RunID, err)
+	}
+	if lastItem.GetEventId() != execution.LastEventID || lastItem.GetVersion() != execution.LastEventVersion {
+		return fmt.Errorf(
+			"workflow %s/%s already exists with a different history, last event %d@%d, archive last event %d@%d",
+			execution.WorkflowID,
+			execution.RunID,
+			lastItem.GetEventId(),
+			lastItem.GetVersion(),
+			execution.LastEventID,
+			execution.LastEventVersion,
+		)
+	}
+	return nil
+}
+
+func writeArchiveBlob(archiveDir string, file string, message proto.Message) (string, error) {
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return "", fmt.Errorf("unable to serialize %s: %s", file, err)
+	}
+	if err := writeFileAtomic(filepath.Join(archiveDir, file), data); err != nil {
+		return "", fmt.Errorf("unable to write %s: %s", file, err)
+	}
+	checksum := sha256.Sum256(data)
+	return hex.EncodeToString(checksum[:]), nil
+}
+
+func readArchiveBlob(archiveDir string, file string, expectedChecksum string, message proto.Message) error {
+	data, err := os.ReadFile(filepath.Join(archiveDir, file))
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %s", file, err)
+	}
+	checksum := sha256.Sum256(data)
+	if hex.EncodeToString(checksum[:]) != expectedChecksum {
+		return fmt.Errorf("checksum mismatch for %s, archive is corrupted", file)
+	}
+	if err := proto.Unmarshal(data, message); err != nil {
+		return fmt.Errorf("unable to deserialize %s: %s", file, err)
+	}
+	return nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func readImportProgress(progressFile string) (map[namespaceImportProgress]struct{}, error) {
+	imported := make(map[namespaceImportProgress]struct{})
+	file, err := os.Open(progressFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return imported, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry namespaceImportProgress
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// the last line may be truncated if the previous import was interrupted while writing it
+			continue
+		}
+		imported[entry] = struct{}{}
+	}
+	return imported, scanner.Err()
+}
+
+func appendImportProgress(progress *os.File, entry namespaceImportProgress) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := progress.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return progress.Sync()
+}
@@ -0,0 +1,256 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tdbg_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/serviceerror"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/persistence/versionhistory"
+	"go.temporal.io/server/common/testing/protorequire"
+	"go.temporal.io/server/tools/tdbg"
+	"go.temporal.io/server/tools/tdbg/tdbgtest"
+)
+
+type (
+	archiveTestClient struct {
+		adminservice.AdminServiceClient
+
+		// source cluster
+		histories map[string][]*commonpb.DataBlob
+		// target cluster
+		importErr error
+		imported  map[string][]*commonpb.DataBlob
+		pending   map[string][]*commonpb.DataBlob
+	}
+
+	archiveTestWorkflowClient struct {
+		workflowservice.WorkflowServiceClient
+		histories map[string][]*commonpb.DataBlob
+	}
+)
+
+func TestNamespaceExportImport(t *testing.T) {
+	archiveDir := t.TempDir()
+	source := &archiveTestClient{
+		histories: map[string][]*commonpb.DataBlob{
+			"wf-1": {{Data: []byte("batch-1")}, {Data: []byte("batch-2")}, {Data: []byte("batch-3")}},
+			"wf-2": {{Data: []byte("batch-4")}},
+		},
+	}
+	require.NoError(t, runArchiveCommand(source, "export", "--"+tdbg.FlagArchiveDir, archiveDir, "--"+tdbg.FlagQuery, "WorkflowType='test'"))
+
+	target := &archiveTestClient{
+		importErr: errors.New("import failed"),
+		imported:  map[string][]*commonpb.DataBlob{},
+		pending:   map[string][]*commonpb.DataBlob{},
+	}
+	err := runArchiveCommand(target, "import", "--"+tdbg.FlagArchiveDir, archiveDir)
+	require.ErrorContains(t, err, "unable to import workflow wf-2/wf-2-run")
+	protorequire.ProtoSliceEqual(t, source.histories["wf-1"], target.imported["wf-1"])
+	require.NotContains(t, target.imported, "wf-2")
+
+	// resume, wf-1 is skipped
+	target.importErr = nil
+	delete(target.imported, "wf-1")
+	require.NoError(t, runArchiveCommand(target, "import", "--"+tdbg.FlagArchiveDir, archiveDir))
+	require.NotContains(t, target.imported, "wf-1")
+	protorequire.ProtoSliceEqual(t, source.histories["wf-2"], target.imported["wf-2"])
+}
+
+func TestNamespaceImport_AlreadyImported(t *testing.T) {
+	archiveDir := t.TempDir()
+	source := &archiveTestClient{
+		histories: map[string][]*commonpb.DataBlob{
+			"wf-1": {{Data: []byte("batch-1")}},
+		},
+	}
+	require.NoError(t, runArchiveCommand(source, "export", "--"+tdbg.FlagArchiveDir, archiveDir))
+
+	// the execution exists in the target, but the progress was not recorded
+	target := &archiveTestClient{
+		imported: map[string][]*commonpb.DataBlob{"wf-1": source.histories["wf-1"]},
+		pending:  map[string][]*commonpb.DataBlob{},
+	}
+	require.NoError(t, runArchiveCommand(target, "import", "--"+tdbg.FlagArchiveDir, archiveDir))
+
+	target.imported["wf-1"] = append(target.imported["wf-1"], &commonpb.DataBlob{Data: []byte("batch-2")})
+	err := runArchiveCommand(target, "import",
+		"--"+tdbg.FlagArchiveDir, archiveDir,
+		"--"+tdbg.FlagProgressFile, filepath.Join(t.TempDir(), "progress"),
+	)
+	require.ErrorContains(t, err, "already exists with a different history")
+}
+
+func TestNamespaceImport_CorruptedArchive(t *testing.T) {
+	archiveDir := t.TempDir()
+	source := &archiveTestClient{
+		histories: map[string][]*commonpb.DataBlob{
+			"wf-1": {{Data: []byte("batch-1")}},
+		},
+	}
+	require.NoError(t, runArchiveCommand(source, "export", "--"+tdbg.FlagArchiveDir, archiveDir))
+
+	files, err := filepath.Glob(filepath.Join(archiveDir, "executions", "*.history"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.NoError(t, os.WriteFile(files[0], []byte("corrupted"), 0644))
+
+	target := &archiveTestClient{
+		imported: map[string][]*commonpb.DataBlob{},
+		pending:  map[string][]*commonpb.DataBlob{},
+	}
+	err = runArchiveCommand(target, "import", "--"+tdbg.FlagArchiveDir, archiveDir)
+	require.ErrorContains(t, err, "checksum mismatch")
+	require.Empty(t, target.imported)
+}
+
+func runArchiveCommand(client *archiveTestClient, command string, args ...string) error {
+	app := tdbgtest.NewCliApp(func(params *tdbg.Params) {
+		params.ClientFactory = client
+	})
+	return app.Run(append([]string{"tdbg", "--" + tdbg.FlagNamespace, "test-namespace", "namespace", command}, args...))
+}
+
+func versionHistoryOf(batches []*commonpb.DataBlob) *historyspb.VersionHistory {
+	return versionhistory.NewVersionHistory(nil, []*historyspb.VersionHistoryItem{
+		versionhistory.NewVersionHistoryItem(int64(len(batches)), 1),
+	})
+}
+
+func (c *archiveTestClient) AdminClient(*cli.Context) adminservice.AdminServiceClient {
+	return c
+}
+
+func (c *archiveTestClient) WorkflowClient(*cli.Context) workflowservice.WorkflowServiceClient {
+	return &archiveTestWorkflowClient{histories: c.histories}
+}
+
+func (c *archiveTestWorkflowClient) DescribeNamespace(
+	_ context.Context,
+	request *workflowservice.DescribeNamespaceRequest,
+	_ ...grpc.CallOption,
+) (*workflowservice.DescribeNamespaceResponse, error) {
+	return &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo: &namespacepb.NamespaceInfo{Id: "test-namespace-id", Name: request.GetNamespace()},
+	}, nil
+}
+
+func (c *archiveTestWorkflowClient) ListWorkflowExecutions(
+	_ context.Context,
+	request *workflowservice.ListWorkflowExecutionsRequest,
+	_ ...grpc.CallOption,
+) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	// one execution per page
+	workflowID := "wf-1"
+	var nextPageToken []byte
+	if len(request.GetNextPageToken()) == 0 {
+		if _, ok := c.histories["wf-2"]; ok {
+			nextPageToken = []byte("wf-2")
+		}
+	} else {
+		workflowID = string(request.GetNextPageToken())
+	}
+	return &workflowservice.ListWorkflowExecutionsResponse{
+		Executions: []*workflowpb.WorkflowExecutionInfo{{
+			Execution: &commonpb.WorkflowExecution{WorkflowId: workflowID, RunId: workflowID + "-run"},
+		}},
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (c *archiveTestClient) GetWorkflowExecutionRawHistoryV2(
+	_ context.Context,
+	request *adminservice.GetWorkflowExecutionRawHistoryV2Request,
+	_ ...grpc.CallOption,
+) (*adminservice.GetWorkflowExecutionRawHistoryV2Response, error) {
+	// one batch per page
+	batches := c.histories[request.GetExecution().GetWorkflowId()]
+	idx := len(request.GetNextPageToken())
+	var nextPageToken []byte
+	if idx+1 < len(batches) {
+		nextPageToken = make([]byte, idx+1)
+	}
+	return &adminservice.GetWorkflowExecutionRawHistoryV2Response{
+		HistoryBatches: batches[idx : idx+1],
+		VersionHistory: versionHistoryOf(batches),
+		NextPageToken:  nextPageToken,
+	}, nil
+}
+
+func (c *archiveTestClient) DescribeMutableState(
+	_ context.Context,
+	request *adminservice.DescribeMutableStateRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.DescribeMutableStateResponse, error) {
+	batches, ok := c.histories[request.GetExecution().GetWorkflowId()]
+	if !ok {
+		batches, ok = c.imported[request.GetExecution().GetWorkflowId()]
+	}
+	if !ok {
+		return nil, serviceerror.NewNotFound("workflow not found")
+	}
+	return &adminservice.DescribeMutableStateResponse{
+		DatabaseMutableState: &persistencespb.WorkflowMutableState{
+			ExecutionInfo: &persistencespb.WorkflowExecutionInfo{
+				WorkflowId:       request.GetExecution().GetWorkflowId(),
+				VersionHistories: versionhistory.NewVersionHistories(versionHistoryOf(batches)),
+			},
+		},
+	}, nil
+}
+
+func (c *archiveTestClient) ImportWorkflowExecution(
+	_ context.Context,
+	request *adminservice.ImportWorkflowExecutionRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.ImportWorkflowExecutionResponse, error) {
+	workflowID := request.GetExecution().GetWorkflowId()
+	if c.importErr != nil && workflowID == "wf-2" {
+		return nil, c.importErr
+	}
+	if len(request.GetHistoryBatches()) != 0 {
+		c.pending[workflowID] = append(c.pending[workflowID], request.GetHistoryBatches()...)
+		return &adminservice.ImportWorkflowExecutionResponse{Token: []byte("token")}, nil
+	}
+	c.imported[workflowID] = c.pending[workflowID]
+	delete(c.pending, workflowID)
+	return &adminservice.ImportWorkflowExecutionResponse{}, nil
+}
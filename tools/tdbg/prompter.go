@@ -55,12 +55,14 @@ type (
 	BoolFlagLookup interface {
 		Bool(name string) bool
 	}
-	PrompterFactory func(c BoolFlagLookup) *Prompter
+	// PrompterFactory creates a Prompter. Options passed to the factory are applied after the ones the factory was
+	// created with.
+	PrompterFactory func(c BoolFlagLookup, opts ...PrompterOption) *Prompter
 )
 
 func NewPrompterFactory(opts ...PrompterOption) PrompterFactory {
-	return func(c BoolFlagLookup) *Prompter {
-		return NewPrompter(c, opts...)
+	return func(c BoolFlagLookup, extraOpts ...PrompterOption) *Prompter {
+		return NewPrompter(c, append(opts[:len(opts):len(opts)], extraOpts...)...)
 	}
 }
 
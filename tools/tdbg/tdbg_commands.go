@@ -29,6 +29,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	commonpb "go.temporal.io/api/common/v1"
@@ -88,11 +89,104 @@ func getCommands(
 				},
 			},
 		},
+		{
+			Name:  "dynamic-config",
+			Usage: "Show dynamic config values and the value in effect for the given constraints",
+			Flags: newAdminDynamicConfigFlags(),
+			Action: func(c *cli.Context) error {
+				return AdminGetDynamicConfig(c, clientFactory)
+			},
+		},
+		{
+			Name:        "namespace",
+			Aliases:     []string{"n"},
+			Usage:       "Run admin operation on namespace",
+			Subcommands: newAdminNamespaceCommands(clientFactory),
+		},
 		{
 			Name:        "decode",
 			Usage:       "Decode payload",
 			Subcommands: newDecodeCommands(taskBlobEncoder, writer),
 		},
+		{
+			Name:  "top",
+			Usage: "Show live queue states of history shards and DLQ sizes, with task drill-down and shard, task and DLQ actions",
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:  FlagMinShardID,
+					Value: 1,
+					Usage: "Lowest shard ID to show",
+				},
+				&cli.IntFlag{
+					Name:  FlagMaxShardID,
+					Usage: "Highest shard ID to show, defaults to the number of history shards of the cluster",
+				},
+				&cli.DurationFlag{
+					Name:  FlagRefreshInterval,
+					Value: 10 * time.Second,
+					Usage: "How often shard queue states and DLQ sizes are reloaded",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminTop(c, clientFactory, taskCategoryRegistry, prompterFactory)
+			},
+		},
+	}
+}
+
+func newAdminNamespaceCommands(clientFactory ClientFactory) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "update-visibility-retention",
+			Usage: "Set how long visibility records of closed workflows are kept on the current cluster, independently of the history retention",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagVisibilityRetention,
+					Usage:    "Visibility retention, e.g. 365d or 720h, unit-less numbers are days. 0 deletes visibility records together with the history",
+					Required: true,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminUpdateNamespaceVisibilityRetention(c, clientFactory)
+			},
+		},
+		{
+			Name:  "export",
+			Usage: "Export the history and mutable state of the workflow executions matching a visibility query into an archive directory",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagQuery,
+					Aliases: FlagQueryAlias,
+					Usage:   "Visibility query selecting the workflow executions to export, all executions if empty",
+				},
+				&cli.StringFlag{
+					Name:     FlagArchiveDir,
+					Usage:    "Directory the archive is written to",
+					Required: true,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminExportNamespace(c, clientFactory)
+			},
+		},
+		{
+			Name:  "import",
+			Usage: "Import the workflow executions of an archive directory, run again to resume a failed import",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     FlagArchiveDir,
+					Usage:    "Directory the archive is read from",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  FlagProgressFile,
+					Usage: "File recording the imported workflow executions, defaults to import.progress in the archive directory",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminImportNamespace(c, clientFactory)
+			},
+		},
 	}
 }
 
@@ -214,11 +308,43 @@ func newAdminWorkflowCommands(clientFactory ClientFactory, prompterFactory Promp
 					Aliases: FlagRunIDAlias,
 					Usage:   "Run ID",
 				},
+				&cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "Show the differences between the rebuilt and the current mutable state without persisting it",
+				},
+				&cli.BoolFlag{
+					Name:  FlagPrintFullyDetail,
+					Usage: "Also print the full current and rebuilt mutable state in dry run mode",
+				},
 			},
 			Action: func(c *cli.Context) error {
 				return AdminRebuildMutableState(c, clientFactory)
 			},
 		},
+		{
+			Name:    "replay-check",
+			Aliases: []string{"rc"},
+			Usage:   "Replay workflow history into a new mutable state and report differences from the persisted mutable state",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagWorkflowID,
+					Aliases: FlagWorkflowIDAlias,
+					Usage:   "Workflow ID",
+				},
+				&cli.StringFlag{
+					Name:    FlagRunID,
+					Aliases: FlagRunIDAlias,
+					Usage:   "Run ID",
+				},
+				&cli.StringFlag{
+					Name:  FlagInputFilename,
+					Usage: "Input file with history generated by the show command. History is fetched from the cluster if not set",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				return AdminReplayCheckWorkflow(c, clientFactory)
+			},
+		},
 		{
 			Name:    "delete",
 			Aliases: []string{"del"},
@@ -464,6 +590,40 @@ func newAdminHistoryHostCommands(clientFactory ClientFactory) []*cli.Command {
 	}
 }
 
+func newAdminDynamicConfigFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  FlagKey,
+			Usage: "Dynamic config key to show, can be repeated. All keys used by the frontend are shown if not set",
+		},
+		&cli.StringFlag{
+			Name:    FlagNamespace,
+			Aliases: FlagNamespaceAlias,
+			Usage:   "Namespace constraint",
+		},
+		&cli.StringFlag{
+			Name:  FlagNamespaceID,
+			Usage: "Namespace ID constraint",
+		},
+		&cli.StringFlag{
+			Name:  FlagTaskQueue,
+			Usage: "Task Queue name constraint",
+		},
+		&cli.StringFlag{
+			Name:  FlagTaskQueueType,
+			Usage: "Task Queue type constraint: activity, workflow",
+		},
+		&cli.IntFlag{
+			Name:  FlagShardID,
+			Usage: "Shard ID constraint",
+		},
+		&cli.StringFlag{
+			Name:  FlagTaskType,
+			Usage: "History task type constraint, e.g. transfer_activity_task",
+		},
+	}
+}
+
 func newAdminTaskQueueCommands(clientFactory ClientFactory) []*cli.Command {
 	return []*cli.Command{
 		{
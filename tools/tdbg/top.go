@@ -0,0 +1,1028 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tdbg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	commonspb "go.temporal.io/server/api/common/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/service/history/tasks"
+)
+
+const (
+	// topPendingTaskLimit caps the number of pending tasks loaded for the drilled down queue.
+	topPendingTaskLimit = 100
+	// topShardConcurrency caps the number of shards loaded concurrently during a refresh.
+	topShardConcurrency = 16
+	topDLQPageSize      = 100
+	topTaskKeyFormat    = "01-02T15:04:05"
+	topAlertTimeFormat  = "15:04:05"
+)
+
+const (
+	topKeyNone topKey = iota
+	topKeyUp
+	topKeyDown
+	topKeyEnter
+	topKeyBack
+	topKeyTab
+	topKeyRefresh
+	topKeyQuit
+	topKeyCloseShard
+	topKeyRemoveTask
+	topKeyMergeDLQ
+)
+
+const (
+	topFocusShards topFocus = iota
+	topFocusDLQs
+)
+
+type (
+	topKey   int
+	topFocus int
+
+	// topQueueRow is a row of the shard table, the queue of one task category in a shard.
+	topQueueRow struct {
+		shardID    int32
+		owner      string
+		rangeID    int64
+		category   tasks.Category
+		queueState *persistencespb.QueueState
+		alerts     []*historyspb.QueueAlert
+		err        error
+	}
+
+	topDLQRow struct {
+		info *adminservice.ListQueuesResponse_QueueInfo
+		// key is nil if the queue name is not a history DLQ name
+		key *commonspb.HistoryDLQKey
+	}
+
+	// topSnapshot is the result of a refresh, loaded in the background and applied by the UI loop.
+	topSnapshot struct {
+		rows   []topQueueRow
+		dlqs   []topDLQRow
+		dlqErr error
+		// tasks are only loaded for the queue drilled down into when the refresh started.
+		detail    *topQueueRow
+		tasks     []*adminservice.Task
+		moreTasks bool
+		tasksErr  error
+		time      time.Time
+	}
+
+	// topUI holds the state of the top terminal UI. Rendering and key handling are kept free of terminal I/O so
+	// that they can be driven by tests.
+	topUI struct {
+		client     adminservice.AdminServiceClient
+		registry   tasks.TaskCategoryRegistry
+		newContext func() (context.Context, context.CancelFunc)
+		confirm    func(msg string) bool
+		minShardID int32
+		maxShardID int32
+
+		// refreshed receives the snapshot of the refresh in flight, if any.
+		refreshed    chan *topSnapshot
+		refreshing   bool
+		refreshAgain bool
+
+		rows        []topQueueRow
+		dlqs        []topDLQRow
+		dlqErr      error
+		refreshTime time.Time
+		focus       topFocus
+		rowCursor   int
+		dlqCursor   int
+		status      string
+
+		// detail is the queue drilled down into, nil when showing the shard table.
+		detail     *topQueueRow
+		tasks      []*adminservice.Task
+		moreTasks  bool
+		tasksErr   error
+		taskCursor int
+	}
+
+	// topPromptReader feeds terminal input to a Prompter while the terminal is in raw mode, which neither echoes
+	// input nor translates carriage returns to new lines.
+	topPromptReader struct {
+		input   <-chan []byte
+		echo    io.Writer
+		pending []byte
+	}
+)
+
+// AdminTop shows live queue states of history shards and DLQ sizes in a terminal UI
+func AdminTop(
+	c *cli.Context,
+	clientFactory ClientFactory,
+	taskCategoryRegistry tasks.TaskCategoryRegistry,
+	prompterFactory PrompterFactory,
+) error {
+	inFd := int(os.Stdin.Fd())
+	outFd := int(os.Stdout.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return errors.New("top requires an interactive terminal")
+	}
+
+	ui := &topUI{
+		client:   clientFactory.AdminClient(c),
+		registry: taskCategoryRegistry,
+		newContext: func() (context.Context, context.CancelFunc) {
+			return newContext(c)
+		},
+	}
+	if err := ui.setShardRange(c); err != nil {
+		return err
+	}
+
+	oldState, err := term.MakeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("unable to switch terminal to raw mode: %w", err)
+	}
+	// use the alternate screen and hide the cursor while running
+	_, _ = io.WriteString(os.Stdout, "\x1b[?1049h\x1b[?25l")
+	defer func() {
+		_, _ = io.WriteString(os.Stdout, "\x1b[?25h\x1b[?1049l")
+		_ = term.Restore(inFd, oldState)
+	}()
+
+	input := make(chan []byte)
+	go readTopInput(os.Stdin, input)
+	confirm := newTopConfirm(c, prompterFactory, &topPromptReader{input: input, echo: os.Stdout}, os.Stdout)
+	ui.confirm = func(msg string) bool {
+		_, _ = io.WriteString(os.Stdout, "\r\n\x1b[?25h")
+		defer func() { _, _ = io.WriteString(os.Stdout, "\x1b[?25l") }()
+		return confirm(msg)
+	}
+
+	size := func() (int, int) {
+		width, height, err := term.GetSize(outFd)
+		if err != nil {
+			return 80, 24
+		}
+		return width, height
+	}
+	return ui.run(input, os.Stdout, size, c.Duration(FlagRefreshInterval))
+}
+
+// newTopConfirm returns a confirmation function backed by a Prompter which, unlike the other commands, does not
+// exit the process when the user declines.
+func newTopConfirm(
+	c BoolFlagLookup,
+	prompterFactory PrompterFactory,
+	reader io.Reader,
+	writer io.Writer,
+) func(msg string) bool {
+	return func(msg string) bool {
+		confirmed := true
+		prompter := prompterFactory(c, func(params *PrompterParams) {
+			params.Reader = reader
+			params.Writer = writer
+			params.Exiter = func(int) {
+				confirmed = false
+			}
+		})
+		prompter.Prompt(msg)
+		return confirmed
+	}
+}
+
+func readTopInput(reader io.Reader, input chan<- []byte) {
+	defer close(input)
+	buf := make([]byte, 64)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			input <- append([]byte(nil), buf[:n]...)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (r *topPromptReader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		data, ok := <-r.input
+		if !ok {
+			return 0, io.EOF
+		}
+		r.pending = data
+	}
+	n := 0
+	for n < len(p) && len(r.pending) > 0 {
+		b := r.pending[0]
+		r.pending = r.pending[1:]
+		if b == '\r' {
+			b = '\n'
+		}
+		p[n] = b
+		n++
+		if b == '\n' {
+			_, _ = io.WriteString(r.echo, "\r\n")
+			break
+		}
+		_, _ = r.echo.Write([]byte{b})
+	}
+	return n, nil
+}
+
+func (ui *topUI) setShardRange(c *cli.Context) error {
+	ui.minShardID = int32(c.Int(FlagMinShardID))
+	if c.IsSet(FlagMaxShardID) {
+		ui.maxShardID = int32(c.Int(FlagMaxShardID))
+	} else {
+		ctx, cancel := ui.newContext()
+		defer cancel()
+		resp, err := ui.client.DescribeCluster(ctx, &adminservice.DescribeClusterRequest{})
+		if err != nil {
+			return fmt.Errorf("unable to describe cluster: %w", err)
+		}
+		ui.maxShardID = resp.GetHistoryShardCount()
+	}
+	if ui.minShardID < 1 || ui.maxShardID < ui.minShardID {
+		return fmt.Errorf("invalid shard range [%d, %d]", ui.minShardID, ui.maxShardID)
+	}
+	return nil
+}
+
+func (ui *topUI) run(
+	input <-chan []byte,
+	out io.Writer,
+	size func() (int, int),
+	refreshInterval time.Duration,
+) error {
+	ui.refresh()
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		width, height := size()
+		ui.draw(out, width, height)
+		select {
+		case <-ticker.C:
+			ui.refresh()
+		case snapshot := <-ui.refreshed:
+			ui.applySnapshot(snapshot)
+		case data, ok := <-input:
+			if !ok {
+				return nil
+			}
+			for _, key := range parseTopKeys(data) {
+				if key == topKeyQuit {
+					return nil
+				}
+				ui.handleKey(key)
+			}
+		}
+	}
+}
+
+func (ui *topUI) draw(out io.Writer, width int, height int) {
+	var buf bytes.Buffer
+	buf.WriteString("\x1b[H\x1b[2J")
+	buf.WriteString(strings.Join(ui.render(width, height), "\r\n"))
+	_, _ = out.Write(buf.Bytes())
+}
+
+func parseTopKeys(data []byte) []topKey {
+	var keys []topKey
+	for i := 0; i < len(data); i++ {
+		key := topKeyNone
+		switch data[i] {
+		case 0x1b:
+			// arrow keys are sent as ESC [ A or ESC O A
+			if i+2 < len(data) && (data[i+1] == '[' || data[i+1] == 'O') {
+				switch data[i+2] {
+				case 'A':
+					key = topKeyUp
+				case 'B':
+					key = topKeyDown
+				}
+				i += 2
+			} else {
+				key = topKeyBack
+			}
+		case '\r', '\n':
+			key = topKeyEnter
+		case '\t':
+			key = topKeyTab
+		case 0x7f, 0x08:
+			key = topKeyBack
+		case 'k':
+			key = topKeyUp
+		case 'j':
+			key = topKeyDown
+		case ' ':
+			key = topKeyRefresh
+		case 'q', 0x03:
+			key = topKeyQuit
+		case 'c':
+			key = topKeyCloseShard
+		case 'r':
+			key = topKeyRemoveTask
+		case 'm':
+			key = topKeyMergeDLQ
+		}
+		if key != topKeyNone {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (ui *topUI) handleKey(key topKey) {
+	switch key {
+	case topKeyUp:
+		ui.moveCursor(-1)
+	case topKeyDown:
+		ui.moveCursor(1)
+	case topKeyTab:
+		if ui.detail == nil {
+			if ui.focus == topFocusShards {
+				ui.focus = topFocusDLQs
+			} else {
+				ui.focus = topFocusShards
+			}
+		}
+	case topKeyEnter:
+		if ui.detail == nil && ui.focus == topFocusShards && ui.rowCursor < len(ui.rows) {
+			row := ui.rows[ui.rowCursor]
+			if row.queueState != nil {
+				ui.detail = &row
+				ui.taskCursor = 0
+				ui.loadTasks()
+			}
+		}
+	case topKeyBack:
+		ui.detail = nil
+		ui.tasks = nil
+	case topKeyRefresh:
+		ui.refresh()
+	case topKeyCloseShard:
+		ui.closeShard()
+	case topKeyRemoveTask:
+		ui.removeTask()
+	case topKeyMergeDLQ:
+		ui.mergeDLQ()
+	}
+}
+
+func (ui *topUI) moveCursor(delta int) {
+	move := func(cursor int, n int) int {
+		return max(0, min(n-1, cursor+delta))
+	}
+	switch {
+	case ui.detail != nil:
+		ui.taskCursor = move(ui.taskCursor, len(ui.tasks))
+	case ui.focus == topFocusDLQs:
+		ui.dlqCursor = move(ui.dlqCursor, len(ui.dlqs))
+	default:
+		ui.rowCursor = move(ui.rowCursor, len(ui.rows))
+	}
+}
+
+func (ui *topUI) selectedShardID() (int32, bool) {
+	if ui.detail != nil {
+		return ui.detail.shardID, true
+	}
+	if ui.focus == topFocusShards && ui.rowCursor < len(ui.rows) {
+		return ui.rows[ui.rowCursor].shardID, true
+	}
+	return 0, false
+}
+
+func (ui *topUI) closeShard() {
+	shardID, ok := ui.selectedShardID()
+	if !ok {
+		return
+	}
+	if !ui.confirm(fmt.Sprintf("Close shard %d? Its owner will reload it from persistence.", shardID)) {
+		ui.status = "close shard cancelled"
+		return
+	}
+	ctx, cancel := ui.newContext()
+	defer cancel()
+	if _, err := ui.client.CloseShard(ctx, &adminservice.CloseShardRequest{ShardId: shardID}); err != nil {
+		ui.status = fmt.Sprintf("unable to close shard %d: %v", shardID, err)
+		return
+	}
+	ui.status = fmt.Sprintf("closed shard %d", shardID)
+	ui.refresh()
+}
+
+func (ui *topUI) removeTask() {
+	if ui.detail == nil || ui.taskCursor >= len(ui.tasks) {
+		return
+	}
+	task := ui.tasks[ui.taskCursor]
+	if !ui.confirm(fmt.Sprintf(
+		"Remove %s task %d of workflow %s from shard %d?",
+		task.GetTaskType(),
+		task.GetTaskId(),
+		task.GetWorkflowId(),
+		ui.detail.shardID,
+	)) {
+		ui.status = "remove task cancelled"
+		return
+	}
+	ctx, cancel := ui.newContext()
+	defer cancel()
+	if _, err := ui.client.RemoveTask(ctx, &adminservice.RemoveTaskRequest{
+		ShardId:        ui.detail.shardID,
+		Category:       int32(ui.detail.category.ID()),
+		TaskId:         task.GetTaskId(),
+		VisibilityTime: task.GetFireTime(),
+	}); err != nil {
+		ui.status = fmt.Sprintf("unable to remove task %d: %v", task.GetTaskId(), err)
+		return
+	}
+	ui.status = fmt.Sprintf("removed task %d", task.GetTaskId())
+	ui.refresh()
+}
+
+func (ui *topUI) mergeDLQ() {
+	if ui.detail != nil || ui.focus != topFocusDLQs || ui.dlqCursor >= len(ui.dlqs) {
+		return
+	}
+	dlq := ui.dlqs[ui.dlqCursor]
+	if dlq.key == nil {
+		ui.status = fmt.Sprintf("unable to merge %s: not a history DLQ", dlq.info.GetQueueName())
+		return
+	}
+	if !ui.confirm(fmt.Sprintf(
+		"Merge all %d messages of DLQ %s back into the history queues?",
+		dlq.info.GetMessageCount(),
+		dlq.info.GetQueueName(),
+	)) {
+		ui.status = "merge DLQ cancelled"
+		return
+	}
+	ctx, cancel := ui.newContext()
+	defer cancel()
+	if _, err := ui.client.MergeDLQTasks(ctx, &adminservice.MergeDLQTasksRequest{
+		DlqKey: dlq.key,
+		InclusiveMaxTaskMetadata: &commonspb.HistoryDLQTaskMetadata{
+			MessageId: persistence.MaxQueueMessageID,
+		},
+	}); err != nil {
+		ui.status = fmt.Sprintf("unable to merge DLQ %s: %v", dlq.info.GetQueueName(), err)
+		return
+	}
+	ui.status = fmt.Sprintf("started merging DLQ %s", dlq.info.GetQueueName())
+	ui.refresh()
+}
+
+// refresh starts reloading the queue states of all shards in range, the DLQs and the tasks of the drilled down
+// queue in the background. A refresh requested while another one is in flight starts once that one is applied, so
+// that changes made in the meantime are shown.
+func (ui *topUI) refresh() {
+	if ui.refreshing {
+		ui.refreshAgain = true
+		return
+	}
+	if ui.refreshed == nil {
+		ui.refreshed = make(chan *topSnapshot, 1)
+	}
+	ui.refreshing = true
+	var detail *topQueueRow
+	if ui.detail != nil {
+		row := *ui.detail
+		detail = &row
+	}
+	go func() {
+		ui.refreshed <- ui.loadSnapshot(detail)
+	}()
+}
+
+// loadSnapshot loads shards with bounded concurrency. It must not touch the UI state, which is owned by the UI loop.
+func (ui *topUI) loadSnapshot(detail *topQueueRow) *topSnapshot {
+	shardRows := make([][]topQueueRow, ui.maxShardID-ui.minShardID+1)
+	semaphore := make(chan struct{}, topShardConcurrency)
+	var wg sync.WaitGroup
+	for shardID := ui.minShardID; shardID <= ui.maxShardID; shardID++ {
+		shardID := shardID
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			shardRows[shardID-ui.minShardID] = ui.loadShard(shardID)
+		}()
+	}
+	wg.Wait()
+
+	snapshot := &topSnapshot{}
+	for _, rows := range shardRows {
+		snapshot.rows = append(snapshot.rows, rows...)
+	}
+	snapshot.dlqs, snapshot.dlqErr = ui.loadDLQs()
+	if detail != nil {
+		for _, row := range snapshot.rows {
+			if row.shardID == detail.shardID && row.category == detail.category && row.queueState != nil {
+				row := row
+				detail = &row
+				break
+			}
+		}
+		snapshot.detail = detail
+		ctx, cancel := ui.newContext()
+		defer cancel()
+		snapshot.tasks, snapshot.moreTasks, snapshot.tasksErr = ui.listPendingTasks(ctx, detail)
+	}
+	snapshot.time = time.Now()
+	return snapshot
+}
+
+func (ui *topUI) applySnapshot(snapshot *topSnapshot) {
+	ui.refreshing = false
+	ui.rows = snapshot.rows
+	ui.rowCursor = max(0, min(len(ui.rows)-1, ui.rowCursor))
+	ui.dlqs, ui.dlqErr = snapshot.dlqs, snapshot.dlqErr
+	ui.dlqCursor = max(0, min(len(ui.dlqs)-1, ui.dlqCursor))
+	// the tasks are stale if the user went back or drilled down into another queue during the refresh
+	if ui.detail != nil && snapshot.detail != nil &&
+		ui.detail.shardID == snapshot.detail.shardID && ui.detail.category == snapshot.detail.category {
+		ui.detail = snapshot.detail
+		ui.tasks, ui.moreTasks, ui.tasksErr = snapshot.tasks, snapshot.moreTasks, snapshot.tasksErr
+		ui.taskCursor = max(0, min(len(ui.tasks)-1, ui.taskCursor))
+	}
+	ui.refreshTime = snapshot.time
+	if ui.refreshAgain {
+		ui.refreshAgain = false
+		ui.refresh()
+	}
+}
+
+func (ui *topUI) loadShard(shardID int32) []topQueueRow {
+	ctx, cancel := ui.newContext()
+	defer cancel()
+	resp, err := ui.client.GetShard(ctx, &adminservice.GetShardRequest{ShardId: shardID})
+	if err != nil {
+		return []topQueueRow{{shardID: shardID, err: err}}
+	}
+	shardInfo := resp.GetShardInfo()
+	categoryIDs := make([]int32, 0, len(shardInfo.GetQueueStates()))
+	for categoryID := range shardInfo.GetQueueStates() {
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	sort.Slice(categoryIDs, func(i, j int) bool {
+		return categoryIDs[i] < categoryIDs[j]
+	})
+	if len(categoryIDs) == 0 {
+		return []topQueueRow{{shardID: shardID, owner: shardInfo.GetOwner(), rangeID: shardInfo.GetRangeId()}}
+	}
+
+	rows := make([]topQueueRow, 0, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		row := topQueueRow{
+			shardID:    shardID,
+			owner:      shardInfo.GetOwner(),
+			rangeID:    shardInfo.GetRangeId(),
+			category:   ui.getCategory(categoryID),
+			queueState: shardInfo.GetQueueStates()[categoryID],
+		}
+		for _, alert := range resp.GetQueueAlerts() {
+			if alert.GetCategoryId() == categoryID {
+				row.alerts = append(row.alerts, alert)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (ui *topUI) getCategory(categoryID int32) tasks.Category {
+	if category, ok := ui.registry.GetCategoryByID(int(categoryID)); ok {
+		return category
+	}
+	return tasks.NewCategory(int(categoryID), tasks.CategoryType(0), fmt.Sprintf("category-%d", categoryID))
+}
+
+// listPendingTasks lists the first page of tasks between the lowest reader level and the reader high watermark.
+func (ui *topUI) listPendingTasks(
+	ctx context.Context,
+	row *topQueueRow,
+) ([]*adminservice.Task, bool, error) {
+	highWatermark := row.queueState.GetExclusiveReaderHighWatermark()
+	minLevel, ok := topMinReaderLevel(row.queueState)
+	if !ok || topCompareTaskKeys(minLevel, highWatermark) >= 0 {
+		return nil, false, nil
+	}
+	resp, err := ui.client.ListHistoryTasks(ctx, &adminservice.ListHistoryTasksRequest{
+		ShardId:  row.shardID,
+		Category: int32(row.category.ID()),
+		TaskRange: &historyspb.TaskRange{
+			InclusiveMinTaskKey: &historyspb.TaskKey{TaskId: minLevel.GetTaskId(), FireTime: minLevel.GetFireTime()},
+			ExclusiveMaxTaskKey: &historyspb.TaskKey{TaskId: highWatermark.GetTaskId(), FireTime: highWatermark.GetFireTime()},
+		},
+		BatchSize: topPendingTaskLimit,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to list pending tasks: %w", err)
+	}
+	return resp.GetTasks(), len(resp.GetNextPageToken()) != 0, nil
+}
+
+func (ui *topUI) loadTasks() {
+	ctx, cancel := ui.newContext()
+	defer cancel()
+	ui.tasks, ui.moreTasks, ui.tasksErr = ui.listPendingTasks(ctx, ui.detail)
+	ui.taskCursor = max(0, min(len(ui.tasks)-1, ui.taskCursor))
+}
+
+func (ui *topUI) loadDLQs() ([]topDLQRow, error) {
+	var dlqs []topDLQRow
+	var pageToken []byte
+	for {
+		ctx, cancel := ui.newContext()
+		resp, err := ui.client.ListQueues(ctx, &adminservice.ListQueuesRequest{
+			QueueType:     int32(persistence.QueueTypeHistoryDLQ),
+			PageSize:      topDLQPageSize,
+			NextPageToken: pageToken,
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("call to ListQueues failed: %w", err)
+		}
+		for _, info := range resp.GetQueues() {
+			dlqs = append(dlqs, topDLQRow{info: info, key: parseHistoryDLQName(info.GetQueueName())})
+		}
+		pageToken = resp.GetNextPageToken()
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+	// Sort the list of queues in decreasing order of MessageCount.
+	sort.SliceStable(dlqs, func(i, j int) bool {
+		return dlqs[i].info.GetMessageCount() > dlqs[j].info.GetMessageCount()
+	})
+	return dlqs, nil
+}
+
+// parseHistoryDLQName reverses persistence.GetHistoryTaskQueueName. Cluster names may contain underscores, so every
+// split is tried until one produces the same queue name.
+func parseHistoryDLQName(queueName string) *commonspb.HistoryDLQKey {
+	categoryIDString, clusters, ok := strings.Cut(queueName, "_")
+	if !ok {
+		return nil
+	}
+	categoryID, err := strconv.Atoi(categoryIDString)
+	if err != nil {
+		return nil
+	}
+	for i := 0; i < len(clusters); i++ {
+		if clusters[i] != '_' {
+			continue
+		}
+		for j := i + 1; j < len(clusters); j++ {
+			if clusters[j] != '_' {
+				continue
+			}
+			sourceCluster, targetCluster := clusters[:i], clusters[i+1:j]
+			if persistence.GetHistoryTaskQueueName(categoryID, sourceCluster, targetCluster) == queueName {
+				return &commonspb.HistoryDLQKey{
+					TaskCategory:  int32(categoryID),
+					SourceCluster: sourceCluster,
+					TargetCluster: targetCluster,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// topMinReaderLevel returns the lowest inclusive min of all slices, false if the queue has no slices.
+func topMinReaderLevel(queueState *persistencespb.QueueState) (*persistencespb.TaskKey, bool) {
+	var minLevel *persistencespb.TaskKey
+	for _, readerState := range queueState.GetReaderStates() {
+		for _, scope := range readerState.GetScopes() {
+			level := scope.GetRange().GetInclusiveMin()
+			if minLevel == nil || topCompareTaskKeys(level, minLevel) < 0 {
+				minLevel = level
+			}
+		}
+	}
+	return minLevel, minLevel != nil
+}
+
+func topCompareTaskKeys(left *persistencespb.TaskKey, right *persistencespb.TaskKey) int {
+	return tasks.NewKey(left.GetFireTime().AsTime(), left.GetTaskId()).CompareTo(
+		tasks.NewKey(right.GetFireTime().AsTime(), right.GetTaskId()),
+	)
+}
+
+func formatTopTaskKey(category tasks.Category, key *persistencespb.TaskKey) string {
+	if key == nil {
+		return "-"
+	}
+	if category.Type() == tasks.CategoryTypeScheduled {
+		return key.GetFireTime().AsTime().UTC().Format(topTaskKeyFormat)
+	}
+	return strconv.FormatInt(key.GetTaskId(), 10)
+}
+
+func formatTopPredicate(predicate *persistencespb.Predicate) string {
+	formatPredicates := func(predicates []*persistencespb.Predicate, op string) string {
+		formatted := make([]string, 0, len(predicates))
+		for _, p := range predicates {
+			formatted = append(formatted, formatTopPredicate(p))
+		}
+		return "(" + strings.Join(formatted, " "+op+" ") + ")"
+	}
+	switch predicate.GetPredicateType() {
+	case enumsspb.PREDICATE_TYPE_UNIVERSAL:
+		return "all"
+	case enumsspb.PREDICATE_TYPE_EMPTY:
+		return "none"
+	case enumsspb.PREDICATE_TYPE_AND:
+		return formatPredicates(predicate.GetAndPredicateAttributes().GetPredicates(), "AND")
+	case enumsspb.PREDICATE_TYPE_OR:
+		return formatPredicates(predicate.GetOrPredicateAttributes().GetPredicates(), "OR")
+	case enumsspb.PREDICATE_TYPE_NOT:
+		return "NOT " + formatTopPredicate(predicate.GetNotPredicateAttributes().GetPredicate())
+	case enumsspb.PREDICATE_TYPE_NAMESPACE_ID:
+		return fmt.Sprintf("namespace_id in %v", predicate.GetNamespaceIdPredicateAttributes().GetNamespaceIds())
+	case enumsspb.PREDICATE_TYPE_TASK_TYPE:
+		return fmt.Sprintf("task_type in %v", predicate.GetTaskTypePredicateAttributes().GetTaskTypes())
+	case enumsspb.PREDICATE_TYPE_DESTINATION:
+		return fmt.Sprintf("destination in %v", predicate.GetDestinationPredicateAttributes().GetDestinations())
+	default:
+		return predicate.GetPredicateType().String()
+	}
+}
+
+func (row *topQueueRow) readerLevels() string {
+	readerIDs := make([]int64, 0, len(row.queueState.GetReaderStates()))
+	for readerID := range row.queueState.GetReaderStates() {
+		readerIDs = append(readerIDs, readerID)
+	}
+	sort.Slice(readerIDs, func(i, j int) bool {
+		return readerIDs[i] < readerIDs[j]
+	})
+	levels := make([]string, 0, len(readerIDs))
+	for _, readerID := range readerIDs {
+		level, ok := topMinReaderLevel(&persistencespb.QueueState{
+			ReaderStates: map[int64]*persistencespb.QueueReaderState{
+				readerID: row.queueState.GetReaderStates()[readerID],
+			},
+		})
+		if !ok {
+			level = row.queueState.GetExclusiveReaderHighWatermark()
+		}
+		levels = append(levels, fmt.Sprintf("%d:%s", readerID, formatTopTaskKey(row.category, level)))
+	}
+	return strings.Join(levels, " ")
+}
+
+func (row *topQueueRow) sliceCount() int {
+	count := 0
+	for _, readerState := range row.queueState.GetReaderStates() {
+		count += len(readerState.GetScopes())
+	}
+	return count
+}
+
+func (row *topQueueRow) alertTypes() string {
+	var alertTypes []string
+	for _, alert := range row.alerts {
+		alertTypes = append(alertTypes, alert.GetAlertType())
+	}
+	return strings.Join(alertTypes, ",")
+}
+
+// render returns the lines of the screen, cut to the given width and height.
+func (ui *topUI) render(width int, height int) []string {
+	var lines []string
+	if ui.detail != nil {
+		lines = ui.renderDetail(height)
+	} else {
+		lines = ui.renderOverview(height)
+	}
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for i, line := range lines {
+		if runes := []rune(line); len(runes) > width {
+			lines[i] = string(runes[:width])
+		}
+	}
+	return lines
+}
+
+func (ui *topUI) refreshStatus() string {
+	switch {
+	case ui.refreshTime.IsZero():
+		return "loading"
+	case ui.refreshing:
+		return "refreshed at " + ui.refreshTime.Format(topAlertTimeFormat) + ", refreshing"
+	default:
+		return "refreshed at " + ui.refreshTime.Format(topAlertTimeFormat)
+	}
+}
+
+func (ui *topUI) renderOverview(height int) []string {
+	lines := []string{
+		fmt.Sprintf(
+			"shards %d-%d, %s",
+			ui.minShardID,
+			ui.maxShardID,
+			ui.refreshStatus(),
+		),
+		"",
+	}
+
+	shardTable := [][]string{{"SHARD", "OWNER", "RANGE", "QUEUE", "READER LEVELS", "SLICES", "ALERTS"}}
+	for _, row := range ui.rows {
+		shardID := strconv.Itoa(int(row.shardID))
+		switch {
+		case row.queueState == nil && row.err != nil:
+			shardTable = append(shardTable, []string{shardID, "", "", "", "error: " + row.err.Error()})
+		case row.queueState == nil:
+			shardTable = append(shardTable, []string{shardID, row.owner, strconv.FormatInt(row.rangeID, 10)})
+		default:
+			shardTable = append(shardTable, []string{
+				shardID,
+				row.owner,
+				strconv.FormatInt(row.rangeID, 10),
+				row.category.Name(),
+				row.readerLevels(),
+				strconv.Itoa(row.sliceCount()),
+				row.alertTypes(),
+			})
+		}
+	}
+
+	dlqTable := [][]string{{"DLQ", "CATEGORY", "SOURCE", "TARGET", "MESSAGES"}}
+	for _, dlq := range ui.dlqs {
+		category, sourceCluster, targetCluster := "", "", ""
+		if dlq.key != nil {
+			category = ui.getCategory(dlq.key.GetTaskCategory()).Name()
+			sourceCluster = dlq.key.GetSourceCluster()
+			targetCluster = dlq.key.GetTargetCluster()
+		}
+		dlqTable = append(dlqTable, []string{
+			dlq.info.GetQueueName(),
+			category,
+			sourceCluster,
+			targetCluster,
+			strconv.FormatInt(dlq.info.GetMessageCount(), 10),
+		})
+	}
+	if ui.dlqErr != nil {
+		dlqTable = append(dlqTable, []string{"error: " + ui.dlqErr.Error()})
+	}
+
+	// title, blank lines between the tables, status and help lines
+	available := max(2, height-len(lines)-6)
+	dlqRows := min(len(dlqTable)-1, max(1, available/4))
+	shardRows := max(1, available-dlqRows)
+	lines = append(lines, renderTopTable(shardTable, ui.rowCursor, ui.focus == topFocusShards, shardRows)...)
+	lines = append(lines, "")
+	lines = append(lines, renderTopTable(dlqTable, ui.dlqCursor, ui.focus == topFocusDLQs, dlqRows)...)
+	lines = append(lines, "", ui.status)
+	if ui.focus == topFocusShards {
+		lines = append(lines, "up/down select, enter tasks, c close shard, tab DLQs, space refresh, q quit")
+	} else {
+		lines = append(lines, "up/down select, m merge DLQ, tab shards, space refresh, q quit")
+	}
+	return lines
+}
+
+func (ui *topUI) renderDetail(height int) []string {
+	row := ui.detail
+	lines := []string{
+		fmt.Sprintf(
+			"shard %d, %s queue, owner %s, range %d, %s",
+			row.shardID,
+			row.category.Name(),
+			row.owner,
+			row.rangeID,
+			ui.refreshStatus(),
+		),
+		"high watermark " + formatTopTaskKey(row.category, row.queueState.GetExclusiveReaderHighWatermark()),
+	}
+
+	readerIDs := make([]int64, 0, len(row.queueState.GetReaderStates()))
+	for readerID := range row.queueState.GetReaderStates() {
+		readerIDs = append(readerIDs, readerID)
+	}
+	sort.Slice(readerIDs, func(i, j int) bool {
+		return readerIDs[i] < readerIDs[j]
+	})
+	for _, readerID := range readerIDs {
+		lines = append(lines, fmt.Sprintf("reader %d", readerID))
+		for _, scope := range row.queueState.GetReaderStates()[readerID].GetScopes() {
+			lines = append(lines, fmt.Sprintf(
+				"  [%s, %s) %s",
+				formatTopTaskKey(row.category, scope.GetRange().GetInclusiveMin()),
+				formatTopTaskKey(row.category, scope.GetRange().GetExclusiveMax()),
+				formatTopPredicate(scope.GetPredicate()),
+			))
+		}
+	}
+	for _, alert := range row.alerts {
+		lines = append(lines, fmt.Sprintf(
+			"alert %s %s: %s",
+			alert.GetAlertTime().AsTime().Local().Format(topAlertTimeFormat),
+			alert.GetAlertType(),
+			alert.GetDescription(),
+		))
+	}
+	lines = append(lines, "")
+
+	taskTable := [][]string{{"TASK ID", "TYPE", "FIRE TIME", "VERSION", "NAMESPACE ID", "WORKFLOW ID", "RUN ID"}}
+	for _, task := range ui.tasks {
+		fireTime := "-"
+		if row.category.Type() == tasks.CategoryTypeScheduled {
+			fireTime = task.GetFireTime().AsTime().UTC().Format(topTaskKeyFormat)
+		}
+		taskTable = append(taskTable, []string{
+			strconv.FormatInt(task.GetTaskId(), 10),
+			task.GetTaskType().String(),
+			fireTime,
+			strconv.FormatInt(task.GetVersion(), 10),
+			task.GetNamespaceId(),
+			task.GetWorkflowId(),
+			task.GetRunId(),
+		})
+	}
+	switch {
+	case ui.tasksErr != nil:
+		lines = append(lines, "pending tasks: "+ui.tasksErr.Error())
+	case ui.moreTasks:
+		lines = append(lines, fmt.Sprintf("pending tasks, first %d", len(ui.tasks)))
+	default:
+		lines = append(lines, fmt.Sprintf("pending tasks, %d", len(ui.tasks)))
+	}
+	lines = append(lines, renderTopTable(taskTable, ui.taskCursor, true, max(1, height-len(lines)-4))...)
+	lines = append(lines, "", ui.status, "up/down select, r remove task, c close shard, esc back, space refresh, q quit")
+	return lines
+}
+
+// renderTopTable aligns the table columns and shows the window of at most maxRows rows which contains the cursor.
+func renderTopTable(table [][]string, cursor int, focused bool, maxRows int) []string {
+	var buf bytes.Buffer
+	writer := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for i, row := range table {
+		prefix := "  "
+		if focused && i == cursor+1 {
+			prefix = "> "
+		}
+		_, _ = fmt.Fprintln(writer, prefix+strings.Join(row, "\t"))
+	}
+	_ = writer.Flush()
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+	header, rows := lines[0], lines[1:]
+	start := 0
+	if cursor >= maxRows {
+		start = cursor - maxRows + 1
+	}
+	end := min(len(rows), start+maxRows)
+	return append([]string{header}, rows[start:end]...)
+}
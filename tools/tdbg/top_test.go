@@ -0,0 +1,334 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tdbg
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	commonspb "go.temporal.io/server/api/common/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/testing/protorequire"
+	"go.temporal.io/server/service/history/tasks"
+)
+
+type (
+	topTestAdminClient struct {
+		adminservice.AdminServiceClient
+
+		shards            map[int32]*adminservice.GetShardResponse
+		tasks             []*adminservice.Task
+		queues            []*adminservice.ListQueuesResponse_QueueInfo
+		listTasksRequests []*adminservice.ListHistoryTasksRequest
+		closedShards      []int32
+		removeRequests    []*adminservice.RemoveTaskRequest
+		mergeRequests     []*adminservice.MergeDLQTasksRequest
+	}
+
+	topTestFlagLookup struct {
+		yes bool
+	}
+)
+
+func (f topTestFlagLookup) Bool(name string) bool {
+	return name == FlagYes && f.yes
+}
+
+func (c *topTestAdminClient) GetShard(
+	_ context.Context,
+	request *adminservice.GetShardRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.GetShardResponse, error) {
+	return c.shards[request.GetShardId()], nil
+}
+
+func (c *topTestAdminClient) ListHistoryTasks(
+	_ context.Context,
+	request *adminservice.ListHistoryTasksRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.ListHistoryTasksResponse, error) {
+	c.listTasksRequests = append(c.listTasksRequests, request)
+	return &adminservice.ListHistoryTasksResponse{Tasks: c.tasks}, nil
+}
+
+func (c *topTestAdminClient) ListQueues(
+	_ context.Context,
+	_ *adminservice.ListQueuesRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.ListQueuesResponse, error) {
+	return &adminservice.ListQueuesResponse{Queues: c.queues}, nil
+}
+
+func (c *topTestAdminClient) CloseShard(
+	_ context.Context,
+	request *adminservice.CloseShardRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.CloseShardResponse, error) {
+	c.closedShards = append(c.closedShards, request.GetShardId())
+	return &adminservice.CloseShardResponse{}, nil
+}
+
+func (c *topTestAdminClient) RemoveTask(
+	_ context.Context,
+	request *adminservice.RemoveTaskRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.RemoveTaskResponse, error) {
+	c.removeRequests = append(c.removeRequests, request)
+	return &adminservice.RemoveTaskResponse{}, nil
+}
+
+func (c *topTestAdminClient) MergeDLQTasks(
+	_ context.Context,
+	request *adminservice.MergeDLQTasksRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.MergeDLQTasksResponse, error) {
+	c.mergeRequests = append(c.mergeRequests, request)
+	return &adminservice.MergeDLQTasksResponse{}, nil
+}
+
+func TestTop_Overview(t *testing.T) {
+	client := newTopTestAdminClient()
+	ui := newTestTopUI(client, topTestFlagLookup{})
+	refreshTopUI(ui)
+
+	screen := strings.Join(ui.render(200, 40), "\n")
+	assert.Contains(t, screen, "host-1")
+	assert.Contains(t, screen, "transfer")
+	assert.Contains(t, screen, "0:100 1:150")
+	assert.Contains(t, screen, "ReaderStuck")
+	assert.Contains(t, screen, "timer")
+	assert.Contains(t, screen, "cluster_a")
+	assert.Contains(t, screen, "42")
+
+	// tasks are only listed for the queue drilled down into
+	assert.Empty(t, client.listTasksRequests)
+}
+
+func TestTop_RemoveTask(t *testing.T) {
+	client := newTopTestAdminClient()
+	ui := newTestTopUI(client, topTestFlagLookup{}, "n\r", "y\r")
+	refreshTopUI(ui)
+
+	ui.handleKey(topKeyEnter)
+	require.NotNil(t, ui.detail)
+	// pending tasks are listed from the lowest reader level up to the high watermark
+	require.Len(t, client.listTasksRequests, 1)
+	taskRange := client.listTasksRequests[0].GetTaskRange()
+	assert.Equal(t, int64(100), taskRange.GetInclusiveMinTaskKey().GetTaskId())
+	assert.Equal(t, int64(200), taskRange.GetExclusiveMaxTaskKey().GetTaskId())
+
+	// a refresh reloads the tasks of the drilled down queue
+	refreshTopUI(ui)
+	require.Len(t, client.listTasksRequests, 2)
+	screen := strings.Join(ui.render(200, 40), "\n")
+	assert.Contains(t, screen, "namespace_id in [namespace-1]")
+	assert.Contains(t, screen, "reader 0 stuck")
+	assert.Contains(t, screen, "workflow-2")
+
+	ui.handleKey(topKeyDown)
+	ui.handleKey(topKeyRemoveTask)
+	assert.Empty(t, client.removeRequests)
+	assert.Equal(t, "remove task cancelled", ui.status)
+
+	ui.handleKey(topKeyRemoveTask)
+	require.Len(t, client.removeRequests, 1)
+	assert.Equal(t, int32(1), client.removeRequests[0].GetShardId())
+	assert.Equal(t, int32(tasks.CategoryIDTransfer), client.removeRequests[0].GetCategory())
+	assert.Equal(t, int64(120), client.removeRequests[0].GetTaskId())
+
+	ui.handleKey(topKeyBack)
+	assert.Nil(t, ui.detail)
+}
+
+func TestTop_CloseShardAndMergeDLQ(t *testing.T) {
+	client := newTopTestAdminClient()
+	ui := newTestTopUI(client, topTestFlagLookup{yes: true})
+	refreshTopUI(ui)
+
+	ui.handleKey(topKeyCloseShard)
+	assert.Equal(t, []int32{1}, client.closedShards)
+
+	// merging is only available when the DLQ table is focused
+	ui.handleKey(topKeyMergeDLQ)
+	assert.Empty(t, client.mergeRequests)
+	ui.handleKey(topKeyTab)
+	ui.handleKey(topKeyMergeDLQ)
+	require.Len(t, client.mergeRequests, 1)
+	protorequire.ProtoEqual(t, &commonspb.HistoryDLQKey{
+		TaskCategory:  int32(tasks.CategoryIDTimer),
+		SourceCluster: "cluster_a",
+		TargetCluster: "cluster_b",
+	}, client.mergeRequests[0].GetDlqKey())
+	assert.Equal(t, int64(persistence.MaxQueueMessageID), client.mergeRequests[0].GetInclusiveMaxTaskMetadata().GetMessageId())
+}
+
+func TestParseHistoryDLQName(t *testing.T) {
+	key := parseHistoryDLQName(persistence.GetHistoryTaskQueueName(tasks.CategoryIDTimer, "a_b", "c_d_e"))
+	require.NotNil(t, key)
+	assert.Equal(t, int32(tasks.CategoryIDTimer), key.GetTaskCategory())
+	assert.Equal(t, "a_b", key.GetSourceCluster())
+	assert.Equal(t, "c_d_e", key.GetTargetCluster())
+
+	assert.Nil(t, parseHistoryDLQName("not-a-dlq"))
+	assert.Nil(t, parseHistoryDLQName("1_a_b_wronghash"))
+}
+
+func TestParseTopKeys(t *testing.T) {
+	assert.Equal(t,
+		[]topKey{topKeyUp, topKeyDown, topKeyEnter, topKeyBack, topKeyTab, topKeyRemoveTask, topKeyQuit},
+		parseTopKeys([]byte("\x1b[A\x1b[B\r\x1b\tr\x03")),
+	)
+}
+
+// refreshTopUI refreshes the UI and waits for the snapshot to be applied.
+func refreshTopUI(ui *topUI) {
+	ui.refresh()
+	ui.applySnapshot(<-ui.refreshed)
+}
+
+func newTestTopUI(client *topTestAdminClient, flagLookup topTestFlagLookup, input ...string) *topUI {
+	// the terminal sends carriage returns in raw mode
+	inputCh := make(chan []byte, len(input))
+	for _, line := range input {
+		inputCh <- []byte(line)
+	}
+	close(inputCh)
+	return &topUI{
+		client:   client,
+		registry: tasks.NewDefaultTaskCategoryRegistry(),
+		newContext: func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), time.Second)
+		},
+		confirm: newTopConfirm(
+			flagLookup,
+			NewPrompterFactory(),
+			&topPromptReader{input: inputCh, echo: &bytes.Buffer{}},
+			&bytes.Buffer{},
+		),
+		minShardID: 1,
+		maxShardID: 1,
+	}
+}
+
+func newTopTestAdminClient() *topTestAdminClient {
+	taskKey := func(taskID int64) *persistencespb.TaskKey {
+		return &persistencespb.TaskKey{FireTime: timestamppb.New(tasks.DefaultFireTime), TaskId: taskID}
+	}
+	timerKey := func(fireTime time.Time) *persistencespb.TaskKey {
+		return &persistencespb.TaskKey{FireTime: timestamppb.New(fireTime)}
+	}
+	now := time.Now().UTC()
+	return &topTestAdminClient{
+		shards: map[int32]*adminservice.GetShardResponse{
+			1: {
+				ShardInfo: &persistencespb.ShardInfo{
+					ShardId: 1,
+					RangeId: 5,
+					Owner:   "host-1",
+					QueueStates: map[int32]*persistencespb.QueueState{
+						int32(tasks.CategoryIDTransfer): {
+							ReaderStates: map[int64]*persistencespb.QueueReaderState{
+								0: {Scopes: []*persistencespb.QueueSliceScope{{
+									Range: &persistencespb.QueueSliceRange{InclusiveMin: taskKey(100), ExclusiveMax: taskKey(200)},
+									Predicate: &persistencespb.Predicate{
+										PredicateType: enumsspb.PREDICATE_TYPE_NAMESPACE_ID,
+										Attributes: &persistencespb.Predicate_NamespaceIdPredicateAttributes{
+											NamespaceIdPredicateAttributes: &persistencespb.NamespaceIdPredicateAttributes{
+												NamespaceIds: []string{"namespace-1"},
+											},
+										},
+									},
+								}}},
+								1: {Scopes: []*persistencespb.QueueSliceScope{{
+									Range: &persistencespb.QueueSliceRange{InclusiveMin: taskKey(150), ExclusiveMax: taskKey(200)},
+									Predicate: &persistencespb.Predicate{
+										PredicateType: enumsspb.PREDICATE_TYPE_UNIVERSAL,
+										Attributes: &persistencespb.Predicate_UniversalPredicateAttributes{
+											UniversalPredicateAttributes: &persistencespb.UniversalPredicateAttributes{},
+										},
+									},
+								}}},
+							},
+							ExclusiveReaderHighWatermark: taskKey(200),
+						},
+						int32(tasks.CategoryIDTimer): {
+							ReaderStates: map[int64]*persistencespb.QueueReaderState{
+								0: {Scopes: []*persistencespb.QueueSliceScope{{
+									Range: &persistencespb.QueueSliceRange{
+										InclusiveMin: timerKey(now.Add(-time.Minute)),
+										ExclusiveMax: timerKey(now),
+									},
+								}}},
+							},
+							ExclusiveReaderHighWatermark: timerKey(now),
+						},
+					},
+				},
+				QueueAlerts: []*historyspb.QueueAlert{{
+					CategoryId:  int32(tasks.CategoryIDTransfer),
+					AlertType:   "ReaderStuck",
+					Description: "reader 0 stuck at 100",
+					AlertTime:   timestamppb.New(now),
+				}},
+			},
+		},
+		tasks: []*adminservice.Task{
+			{
+				NamespaceId: "namespace-1",
+				WorkflowId:  "workflow-1",
+				RunId:       "run-1",
+				TaskId:      110,
+				TaskType:    enumsspb.TASK_TYPE_TRANSFER_ACTIVITY_TASK,
+				FireTime:    timestamppb.New(tasks.DefaultFireTime),
+			},
+			{
+				NamespaceId: "namespace-1",
+				WorkflowId:  "workflow-2",
+				RunId:       "run-2",
+				TaskId:      120,
+				TaskType:    enumsspb.TASK_TYPE_TRANSFER_WORKFLOW_TASK,
+				FireTime:    timestamppb.New(tasks.DefaultFireTime),
+			},
+		},
+		queues: []*adminservice.ListQueuesResponse_QueueInfo{
+			{
+				QueueName:    persistence.GetHistoryTaskQueueName(tasks.CategoryIDTimer, "cluster_a", "cluster_b"),
+				MessageCount: 42,
+			},
+		},
+	}
+}
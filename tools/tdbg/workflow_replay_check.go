@@ -0,0 +1,680 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tdbg
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/archiver"
+	"go.temporal.io/server/common/clock"
+	"go.temporal.io/server/common/cluster"
+	"go.temporal.io/server/common/codec"
+	"go.temporal.io/server/common/config"
+	"go.temporal.io/server/common/dynamicconfig"
+	"go.temporal.io/server/common/log"
+	"go.temporal.io/server/common/metrics"
+	"go.temporal.io/server/common/namespace"
+	"go.temporal.io/server/common/persistence"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/versionhistory"
+	"go.temporal.io/server/service/history/configs"
+	"go.temporal.io/server/service/history/events"
+	"go.temporal.io/server/service/history/shard"
+	"go.temporal.io/server/service/history/workflow"
+)
+
+const (
+	replayCheckCategoryEventSequence  = "event-sequence"
+	replayCheckCategoryReplay         = "replay"
+	replayCheckCategoryExecution      = "execution"
+	replayCheckCategoryActivity       = "activity"
+	replayCheckCategoryTimer          = "timer"
+	replayCheckCategoryChildExecution = "child-execution"
+	replayCheckCategoryRequestCancel  = "request-cancel"
+	replayCheckCategorySignal         = "signal"
+	replayCheckCategoryVersionHistory = "version-history"
+)
+
+type (
+	// replayCheckReport is the structured output of the replay-check command. Expected values are derived from
+	// the history (event sequencing) or from the replayed mutable state, actual values come from the history
+	// or from the persisted mutable state returned by DescribeMutableState.
+	replayCheckReport struct {
+		Namespace  string            `json:"namespace"`
+		WorkflowID string            `json:"workflowId"`
+		RunID      string            `json:"runId"`
+		EventCount int               `json:"eventCount"`
+		Consistent bool              `json:"consistent"`
+		Diffs      []replayCheckDiff `json:"diffs"`
+	}
+
+	replayCheckDiff struct {
+		Category string      `json:"category"`
+		Key      string      `json:"key,omitempty"`
+		Field    string      `json:"field"`
+		Expected interface{} `json:"expected"`
+		Actual   interface{} `json:"actual"`
+	}
+
+	// replayShardContext is an offline shard.Context which only provides what is needed to replay history
+	// events into an in-memory mutable state. Any other call panics and is reported as a replay failure.
+	replayShardContext struct {
+		shard.Context
+
+		config            *configs.Config
+		executionManager  persistence.ExecutionManager
+		clusterMetadata   cluster.Metadata
+		namespaceRegistry namespace.Registry
+		archivalMetadata  archiver.ArchivalMetadata
+		eventsCache       events.Cache
+		logger            log.Logger
+		timeSource        clock.TimeSource
+	}
+
+	// replayExecutionManager only provides the history branch util, nothing is read from or written to
+	// persistence during replay.
+	replayExecutionManager struct {
+		persistence.ExecutionManager
+
+		historyBranchUtil persistence.HistoryBranchUtil
+	}
+
+	// replayNamespaceRegistry resolves namespaces through the admin service on demand.
+	replayNamespaceRegistry struct {
+		namespace.Registry
+
+		ctx         context.Context
+		adminClient adminservice.AdminServiceClient
+		byID        map[namespace.ID]*namespace.Namespace
+		byName      map[namespace.Name]*namespace.Namespace
+	}
+)
+
+// AdminReplayCheckWorkflow replays workflow history into a fresh mutable state and compares the result
+// with the persisted mutable state
+func AdminReplayCheckWorkflow(c *cli.Context, clientFactory ClientFactory) error {
+	nsName, err := getRequiredOption(c, FlagNamespace)
+	if err != nil {
+		return err
+	}
+	wid, err := getRequiredOption(c, FlagWorkflowID)
+	if err != nil {
+		return err
+	}
+	rid := c.String(FlagRunID)
+	inputFileName := c.String(FlagInputFilename)
+
+	adminClient := clientFactory.AdminClient(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	registry := newReplayNamespaceRegistry(ctx, adminClient)
+	nsEntry, err := registry.GetNamespace(namespace.Name(nsName))
+	if err != nil {
+		return fmt.Errorf("unable to get namespace: %s", err)
+	}
+
+	msResp, err := adminClient.DescribeMutableState(ctx, &adminservice.DescribeMutableStateRequest{
+		Namespace: nsName,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get Workflow Mutable State: %s", err)
+	}
+	persisted := msResp.GetDatabaseMutableState()
+	rid = persisted.GetExecutionState().GetRunId()
+
+	var historyBatches []*historypb.History
+	if inputFileName != "" {
+		data, err := os.ReadFile(inputFileName)
+		if err != nil {
+			return fmt.Errorf("unable to read History data file: %s", err)
+		}
+		encoder := codec.NewJSONPBEncoder()
+		historyBatches, err = encoder.DecodeHistories(data)
+		if err != nil {
+			return fmt.Errorf("unable to deserialize History data: %s", err)
+		}
+	} else {
+		historyBatches, err = fetchReplayHistory(ctx, adminClient, nsEntry.ID(), wid, rid)
+		if err != nil {
+			return err
+		}
+	}
+
+	clusterMetadata, err := newReplayClusterMetadata(ctx, adminClient)
+	if err != nil {
+		return err
+	}
+
+	report := &replayCheckReport{
+		Namespace:  nsName,
+		WorkflowID: wid,
+		RunID:      rid,
+	}
+	for _, batch := range historyBatches {
+		report.EventCount += len(batch.Events)
+	}
+
+	report.Diffs = checkReplayEventSequence(historyBatches)
+	if len(report.Diffs) == 0 {
+		replayed, err := replayHistory(ctx, newReplayShardContext(clusterMetadata, registry), nsEntry, wid, rid, historyBatches)
+		if err != nil {
+			report.Diffs = append(report.Diffs, replayCheckDiff{
+				Category: replayCheckCategoryReplay,
+				Field:    "error",
+				Actual:   err.Error(),
+			})
+		} else {
+			report.Diffs = append(report.Diffs, diffReplayedMutableState(replayed, persisted)...)
+		}
+	}
+	report.Consistent = len(report.Diffs) == 0
+
+	prettyPrintJSONObject(report)
+	if !report.Consistent {
+		return fmt.Errorf("replay check found %d inconsistencies", len(report.Diffs))
+	}
+	return nil
+}
+
+func fetchReplayHistory(
+	ctx context.Context,
+	adminClient adminservice.AdminServiceClient,
+	nsID namespace.ID,
+	wid string,
+	rid string,
+) ([]*historypb.History, error) {
+	serializer := serialization.NewSerializer()
+
+	var historyBatches []*historypb.History
+	var token []byte
+	for doContinue := true; doContinue; doContinue = len(token) != 0 {
+		resp, err := adminClient.GetWorkflowExecutionRawHistoryV2(ctx, &adminservice.GetWorkflowExecutionRawHistoryV2Request{
+			NamespaceId: nsID.String(),
+			Execution: &commonpb.WorkflowExecution{
+				WorkflowId: wid,
+				RunId:      rid,
+			},
+			MaximumPageSize: 100,
+			NextPageToken:   token,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to recv History Branch: %s", err)
+		}
+		for _, blob := range resp.HistoryBatches {
+			historyEvents, err := serializer.DeserializeEvents(blob)
+			if err != nil {
+				return nil, fmt.Errorf("unable to deserialize Events: %s", err)
+			}
+			historyBatches = append(historyBatches, &historypb.History{Events: historyEvents})
+		}
+		token = resp.NextPageToken
+	}
+	return historyBatches, nil
+}
+
+// checkReplayEventSequence verifies that event IDs start at the first event ID and are contiguous
+// within and across batches, and that event versions never decrease.
+func checkReplayEventSequence(historyBatches []*historypb.History) []replayCheckDiff {
+	var diffs []replayCheckDiff
+	expectedEventID := common.FirstEventID
+	lastVersion := common.EmptyVersion
+	for batchIdx, batch := range historyBatches {
+		if len(batch.Events) == 0 {
+			diffs = append(diffs, replayCheckDiff{
+				Category: replayCheckCategoryEventSequence,
+				Key:      fmt.Sprintf("batch %d", batchIdx),
+				Field:    "eventCount",
+				Expected: "> 0",
+				Actual:   0,
+			})
+			continue
+		}
+		for _, event := range batch.Events {
+			if event.GetEventId() != expectedEventID {
+				diffs = append(diffs, replayCheckDiff{
+					Category: replayCheckCategoryEventSequence,
+					Key:      fmt.Sprintf("batch %d", batchIdx),
+					Field:    "eventId",
+					Expected: expectedEventID,
+					Actual:   event.GetEventId(),
+				})
+			}
+			if event.GetVersion() < lastVersion {
+				diffs = append(diffs, replayCheckDiff{
+					Category: replayCheckCategoryEventSequence,
+					Key:      fmt.Sprintf("event %d", event.GetEventId()),
+					Field:    "version",
+					Expected: fmt.Sprintf(">= %d", lastVersion),
+					Actual:   event.GetVersion(),
+				})
+			}
+			expectedEventID = event.GetEventId() + 1
+			lastVersion = event.GetVersion()
+		}
+	}
+	return diffs
+}
+
+func replayHistory(
+	ctx context.Context,
+	shardContext shard.Context,
+	nsEntry *namespace.Namespace,
+	wid string,
+	rid string,
+	historyBatches []*historypb.History,
+) (retMutableState *persistencespb.WorkflowMutableState, retError error) {
+	defer func() {
+		if p := recover(); p != nil {
+			retMutableState = nil
+			retError = fmt.Errorf("panic during replay: %v", p)
+		}
+	}()
+
+	startTime := time.Now().UTC()
+	if len(historyBatches) > 0 && len(historyBatches[0].Events) > 0 {
+		startTime = historyBatches[0].Events[0].GetEventTime().AsTime()
+	}
+
+	mutableState := workflow.NewMutableState(
+		shardContext,
+		shardContext.GetEventsCache(),
+		shardContext.GetLogger(),
+		nsEntry,
+		wid,
+		rid,
+		startTime,
+	)
+	rebuilder := workflow.NewMutableStateRebuilder(shardContext, shardContext.GetLogger(), mutableState)
+	execution := &commonpb.WorkflowExecution{
+		WorkflowId: wid,
+		RunId:      rid,
+	}
+	for _, batch := range historyBatches {
+		if _, err := rebuilder.ApplyEvents(
+			ctx,
+			nsEntry.ID(),
+			uuid.New(),
+			execution,
+			[][]*historypb.HistoryEvent{batch.Events},
+			nil, // new run is replayed separately
+		); err != nil {
+			return nil, fmt.Errorf("unable to apply events [%d, %d]: %s",
+				batch.Events[0].GetEventId(),
+				batch.Events[len(batch.Events)-1].GetEventId(),
+				err,
+			)
+		}
+	}
+	return mutableState.CloneToProto(), nil
+}
+
+func diffReplayedMutableState(replayed, persisted *persistencespb.WorkflowMutableState) []replayCheckDiff {
+	var diffs []replayCheckDiff
+	addDiff := func(category string, key string, field string, expected interface{}, actual interface{}) {
+		if expected != actual {
+			diffs = append(diffs, replayCheckDiff{
+				Category: category,
+				Key:      key,
+				Field:    field,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+
+	addDiff(replayCheckCategoryExecution, "", "nextEventId", replayed.GetNextEventId(), persisted.GetNextEventId())
+	addDiff(replayCheckCategoryExecution, "", "state",
+		replayed.GetExecutionState().GetState().String(), persisted.GetExecutionState().GetState().String())
+	addDiff(replayCheckCategoryExecution, "", "status",
+		replayed.GetExecutionState().GetStatus().String(), persisted.GetExecutionState().GetStatus().String())
+
+	for _, key := range mergeReplayKeys(replayed.GetActivityInfos(), persisted.GetActivityInfos()) {
+		keyStr := fmt.Sprintf("%d", key)
+		expected, expectedOk := replayed.GetActivityInfos()[key]
+		actual, actualOk := persisted.GetActivityInfos()[key]
+		if !expectedOk || !actualOk {
+			addDiff(replayCheckCategoryActivity, keyStr, "present", expectedOk, actualOk)
+			continue
+		}
+		addDiff(replayCheckCategoryActivity, keyStr, "activityId", expected.GetActivityId(), actual.GetActivityId())
+		// started events of pending activities are only written to history once the activity completes
+		if actual.GetStartedEventId() != common.TransientEventID {
+			addDiff(replayCheckCategoryActivity, keyStr, "startedEventId", expected.GetStartedEventId(), actual.GetStartedEventId())
+		}
+	}
+
+	for _, key := range mergeReplayKeys(replayed.GetTimerInfos(), persisted.GetTimerInfos()) {
+		expected, expectedOk := replayed.GetTimerInfos()[key]
+		actual, actualOk := persisted.GetTimerInfos()[key]
+		if !expectedOk || !actualOk {
+			addDiff(replayCheckCategoryTimer, key, "present", expectedOk, actualOk)
+			continue
+		}
+		addDiff(replayCheckCategoryTimer, key, "startedEventId", expected.GetStartedEventId(), actual.GetStartedEventId())
+		addDiff(replayCheckCategoryTimer, key, "expiryTime",
+			expected.GetExpiryTime().AsTime().String(), actual.GetExpiryTime().AsTime().String())
+	}
+
+	for _, key := range mergeReplayKeys(replayed.GetChildExecutionInfos(), persisted.GetChildExecutionInfos()) {
+		keyStr := fmt.Sprintf("%d", key)
+		expected, expectedOk := replayed.GetChildExecutionInfos()[key]
+		actual, actualOk := persisted.GetChildExecutionInfos()[key]
+		if !expectedOk || !actualOk {
+			addDiff(replayCheckCategoryChildExecution, keyStr, "present", expectedOk, actualOk)
+			continue
+		}
+		addDiff(replayCheckCategoryChildExecution, keyStr, "startedEventId", expected.GetStartedEventId(), actual.GetStartedEventId())
+		addDiff(replayCheckCategoryChildExecution, keyStr, "startedWorkflowId", expected.GetStartedWorkflowId(), actual.GetStartedWorkflowId())
+		addDiff(replayCheckCategoryChildExecution, keyStr, "startedRunId", expected.GetStartedRunId(), actual.GetStartedRunId())
+	}
+
+	for _, key := range mergeReplayKeys(replayed.GetRequestCancelInfos(), persisted.GetRequestCancelInfos()) {
+		_, expectedOk := replayed.GetRequestCancelInfos()[key]
+		_, actualOk := persisted.GetRequestCancelInfos()[key]
+		addDiff(replayCheckCategoryRequestCancel, fmt.Sprintf("%d", key), "present", expectedOk, actualOk)
+	}
+
+	for _, key := range mergeReplayKeys(replayed.GetSignalInfos(), persisted.GetSignalInfos()) {
+		_, expectedOk := replayed.GetSignalInfos()[key]
+		_, actualOk := persisted.GetSignalInfos()[key]
+		addDiff(replayCheckCategorySignal, fmt.Sprintf("%d", key), "present", expectedOk, actualOk)
+	}
+
+	diffs = append(diffs, diffReplayVersionHistory(
+		replayed.GetExecutionInfo().GetVersionHistories(),
+		persisted.GetExecutionInfo().GetVersionHistories(),
+	)...)
+	return diffs
+}
+
+func diffReplayVersionHistory(replayed, persisted *historyspb.VersionHistories) []replayCheckDiff {
+	replayedItems, err := currentVersionHistoryItems(replayed)
+	if err != nil {
+		return []replayCheckDiff{{
+			Category: replayCheckCategoryVersionHistory,
+			Field:    "items",
+			Expected: err.Error(),
+		}}
+	}
+	persistedItems, err := currentVersionHistoryItems(persisted)
+	if err != nil {
+		return []replayCheckDiff{{
+			Category: replayCheckCategoryVersionHistory,
+			Field:    "items",
+			Actual:   err.Error(),
+		}}
+	}
+
+	var diffs []replayCheckDiff
+	for idx := 0; idx < len(replayedItems) || idx < len(persistedItems); idx++ {
+		var expected, actual string
+		if idx < len(replayedItems) {
+			expected = replayedItems[idx]
+		}
+		if idx < len(persistedItems) {
+			actual = persistedItems[idx]
+		}
+		if expected != actual {
+			diffs = append(diffs, replayCheckDiff{
+				Category: replayCheckCategoryVersionHistory,
+				Key:      fmt.Sprintf("%d", idx),
+				Field:    "item",
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+	return diffs
+}
+
+func currentVersionHistoryItems(versionHistories *historyspb.VersionHistories) ([]string, error) {
+	currentVersionHistory, err := versionhistory.GetCurrentVersionHistory(versionHistories)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]string, 0, len(currentVersionHistory.GetItems()))
+	for _, item := range currentVersionHistory.GetItems() {
+		items = append(items, fmt.Sprintf("eventId: %d, version: %d", item.GetEventId(), item.GetVersion()))
+	}
+	return items, nil
+}
+
+func mergeReplayKeys[K int64 | string, V any](left map[K]V, right map[K]V) []K {
+	keys := make([]K, 0, len(left)+len(right))
+	for key := range left {
+		keys = append(keys, key)
+	}
+	for key := range right {
+		if _, ok := left[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// newReplayClusterMetadata builds cluster metadata from the target cluster, so that event versions can be
+// mapped to cluster names the same way the history service does.
+func newReplayClusterMetadata(
+	ctx context.Context,
+	adminClient adminservice.AdminServiceClient,
+) (retMetadata cluster.Metadata, retError error) {
+	describeResp, err := adminClient.DescribeCluster(ctx, &adminservice.DescribeClusterRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe cluster: %s", err)
+	}
+
+	clusterInfo := make(map[string]cluster.ClusterInformation)
+	var token []byte
+	for doContinue := true; doContinue; doContinue = len(token) != 0 {
+		listResp, err := adminClient.ListClusters(ctx, &adminservice.ListClustersRequest{
+			NextPageToken: token,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list clusters: %s", err)
+		}
+		for _, clusterMetadata := range listResp.GetClusters() {
+			clusterInfo[clusterMetadata.GetClusterName()] = cluster.ClusterInformation{
+				Enabled:                clusterMetadata.GetIsConnectionEnabled(),
+				InitialFailoverVersion: clusterMetadata.GetInitialFailoverVersion(),
+				RPCAddress:             clusterMetadata.GetClusterAddress(),
+			}
+		}
+		token = listResp.GetNextPageToken()
+	}
+	currentCluster := clusterInfo[describeResp.GetClusterName()]
+	currentCluster.Enabled = true
+	currentCluster.InitialFailoverVersion = describeResp.GetInitialFailoverVersion()
+	if currentCluster.RPCAddress == "" {
+		// addresses are never dialed during replay
+		currentCluster.RPCAddress = describeResp.GetClusterName()
+	}
+	clusterInfo[describeResp.GetClusterName()] = currentCluster
+
+	defer func() {
+		if p := recover(); p != nil {
+			retMetadata = nil
+			retError = fmt.Errorf("invalid cluster metadata: %v", p)
+		}
+	}()
+	return cluster.NewMetadataForTest(&cluster.Config{
+		EnableGlobalNamespace:    describeResp.GetIsGlobalNamespaceEnabled(),
+		FailoverVersionIncrement: describeResp.GetFailoverVersionIncrement(),
+		MasterClusterName:        describeResp.GetClusterName(),
+		CurrentClusterName:       describeResp.GetClusterName(),
+		ClusterInformation:       clusterInfo,
+	}), nil
+}
+
+func newReplayShardContext(clusterMetadata cluster.Metadata, registry namespace.Registry) *replayShardContext {
+	dc := dynamicconfig.NewNoopCollection()
+	cfg := configs.NewConfig(dc, 1)
+	logger := log.NewNoopLogger()
+	executionManager := &replayExecutionManager{
+		historyBranchUtil: &persistence.HistoryBranchUtilImpl{},
+	}
+	return &replayShardContext{
+		config:            cfg,
+		executionManager:  executionManager,
+		clusterMetadata:   clusterMetadata,
+		namespaceRegistry: registry,
+		archivalMetadata: archiver.NewArchivalMetadata(
+			dc,
+			"disabled",
+			false,
+			"disabled",
+			false,
+			&config.ArchivalNamespaceDefaults{},
+		),
+		eventsCache: events.NewShardLevelEventsCache(executionManager, cfg, metrics.NoopMetricsHandler, logger, false),
+		logger:      logger,
+		timeSource:  clock.NewRealTimeSource(),
+	}
+}
+
+func (s *replayShardContext) GetShardID() int32 {
+	return 1
+}
+
+func (s *replayShardContext) GetConfig() *configs.Config {
+	return s.config
+}
+
+func (s *replayShardContext) GetExecutionManager() persistence.ExecutionManager {
+	return s.executionManager
+}
+
+func (s *replayShardContext) GetClusterMetadata() cluster.Metadata {
+	return s.clusterMetadata
+}
+
+func (s *replayShardContext) GetNamespaceRegistry() namespace.Registry {
+	return s.namespaceRegistry
+}
+
+func (s *replayShardContext) GetArchivalMetadata() archiver.ArchivalMetadata {
+	return s.archivalMetadata
+}
+
+func (s *replayShardContext) GetEventsCache() events.Cache {
+	return s.eventsCache
+}
+
+func (s *replayShardContext) GetLogger() log.Logger {
+	return s.logger
+}
+
+func (s *replayShardContext) GetThrottledLogger() log.Logger {
+	return s.logger
+}
+
+func (s *replayShardContext) GetMetricsHandler() metrics.Handler {
+	return metrics.NoopMetricsHandler
+}
+
+func (s *replayShardContext) GetTimeSource() clock.TimeSource {
+	return s.timeSource
+}
+
+func (m *replayExecutionManager) GetHistoryBranchUtil() persistence.HistoryBranchUtil {
+	return m.historyBranchUtil
+}
+
+func newReplayNamespaceRegistry(ctx context.Context, adminClient adminservice.AdminServiceClient) *replayNamespaceRegistry {
+	return &replayNamespaceRegistry{
+		ctx:         ctx,
+		adminClient: adminClient,
+		byID:        make(map[namespace.ID]*namespace.Namespace),
+		byName:      make(map[namespace.Name]*namespace.Namespace),
+	}
+}
+
+func (r *replayNamespaceRegistry) GetNamespace(name namespace.Name) (*namespace.Namespace, error) {
+	if ns, ok := r.byName[name]; ok {
+		return ns, nil
+	}
+	return r.load(&adminservice.GetNamespaceRequest{
+		Attributes: &adminservice.GetNamespaceRequest_Namespace{Namespace: name.String()},
+	})
+}
+
+func (r *replayNamespaceRegistry) GetNamespaceByID(id namespace.ID) (*namespace.Namespace, error) {
+	if ns, ok := r.byID[id]; ok {
+		return ns, nil
+	}
+	return r.load(&adminservice.GetNamespaceRequest{
+		Attributes: &adminservice.GetNamespaceRequest_Id{Id: id.String()},
+	})
+}
+
+func (r *replayNamespaceRegistry) GetNamespaceName(id namespace.ID) (namespace.Name, error) {
+	ns, err := r.GetNamespaceByID(id)
+	if err != nil {
+		return namespace.EmptyName, err
+	}
+	return ns.Name(), nil
+}
+
+func (r *replayNamespaceRegistry) GetNamespaceID(name namespace.Name) (namespace.ID, error) {
+	ns, err := r.GetNamespace(name)
+	if err != nil {
+		return namespace.EmptyID, err
+	}
+	return ns.ID(), nil
+}
+
+func (r *replayNamespaceRegistry) load(request *adminservice.GetNamespaceRequest) (*namespace.Namespace, error) {
+	resp, err := r.adminClient.GetNamespace(r.ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetInfo().GetId() == "" {
+		return nil, serviceerror.NewNamespaceNotFound(request.GetNamespace() + request.GetId())
+	}
+	ns := namespace.FromAdminClientApiResponse(resp)
+	r.byID[ns.ID()] = ns
+	r.byName[ns.Name()] = ns
+	return ns, nil
+}
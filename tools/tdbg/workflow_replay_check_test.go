@@ -0,0 +1,298 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tdbg_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	replicationpb "go.temporal.io/api/replication/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	historyspb "go.temporal.io/server/api/history/v1"
+	persistencespb "go.temporal.io/server/api/persistence/v1"
+	"go.temporal.io/server/common"
+	"go.temporal.io/server/common/codec"
+	"go.temporal.io/server/common/persistence/serialization"
+	"go.temporal.io/server/common/persistence/versionhistory"
+	"go.temporal.io/server/tools/tdbg"
+	"go.temporal.io/server/tools/tdbg/tdbgtest"
+)
+
+type replayCheckTestClient struct {
+	adminservice.AdminServiceClient
+
+	history   []*historypb.History
+	persisted *persistencespb.WorkflowMutableState
+}
+
+var replayCheckStartTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestReplayCheck_Consistent(t *testing.T) {
+	client := newReplayCheckTestClient()
+	require.NoError(t, runReplayCheckCommand(client))
+}
+
+func TestReplayCheck_MutableStateMismatch(t *testing.T) {
+	client := newReplayCheckTestClient()
+	client.persisted.NextEventId = 8
+	delete(client.persisted.TimerInfos, "timer-1")
+	client.persisted.ActivityInfos[5].StartedEventId = 7
+
+	err := runReplayCheckCommand(client)
+	require.ErrorContains(t, err, "replay check found 3 inconsistencies")
+}
+
+func TestReplayCheck_PendingActivityStarted(t *testing.T) {
+	client := newReplayCheckTestClient()
+	// the started event of a pending activity is not in history yet
+	client.persisted.ActivityInfos[5].StartedEventId = common.TransientEventID
+	require.NoError(t, runReplayCheckCommand(client))
+}
+
+func TestReplayCheck_InputFileEventGap(t *testing.T) {
+	client := newReplayCheckTestClient()
+	history := replayCheckHistory()
+	history[2].Events = history[2].Events[1:]
+
+	encoder := codec.NewJSONPBEncoder()
+	data, err := encoder.EncodeHistories(history)
+	require.NoError(t, err)
+	inputFile := filepath.Join(t.TempDir(), "history.json")
+	require.NoError(t, os.WriteFile(inputFile, data, 0644))
+
+	err = runReplayCheckCommand(client, "--"+tdbg.FlagInputFilename, inputFile)
+	require.ErrorContains(t, err, "replay check found 1 inconsistencies")
+}
+
+func runReplayCheckCommand(client *replayCheckTestClient, args ...string) error {
+	app := tdbgtest.NewCliApp(func(params *tdbg.Params) {
+		params.ClientFactory = client
+	})
+	return app.Run(append([]string{
+		"tdbg", "--" + tdbg.FlagNamespace, "test-namespace",
+		"workflow", "replay-check",
+		"--" + tdbg.FlagWorkflowID, "wf-1",
+	}, args...))
+}
+
+func newReplayCheckTestClient() *replayCheckTestClient {
+	return &replayCheckTestClient{
+		history: replayCheckHistory(),
+		persisted: &persistencespb.WorkflowMutableState{
+			ExecutionInfo: &persistencespb.WorkflowExecutionInfo{
+				WorkflowId: "wf-1",
+				VersionHistories: versionhistory.NewVersionHistories(versionhistory.NewVersionHistory(nil, []*historyspb.VersionHistoryItem{
+					versionhistory.NewVersionHistoryItem(6, common.EmptyVersion),
+				})),
+			},
+			ExecutionState: &persistencespb.WorkflowExecutionState{
+				RunId:  "wf-1-run",
+				State:  enumsspb.WORKFLOW_EXECUTION_STATE_RUNNING,
+				Status: enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			},
+			NextEventId: 7,
+			ActivityInfos: map[int64]*persistencespb.ActivityInfo{
+				5: {ActivityId: "activity-1", ScheduledEventId: 5, StartedEventId: common.EmptyEventID},
+			},
+			TimerInfos: map[string]*persistencespb.TimerInfo{
+				"timer-1": {
+					TimerId:        "timer-1",
+					StartedEventId: 6,
+					ExpiryTime:     timestamppb.New(replayCheckStartTime.Add(time.Minute)),
+				},
+			},
+		},
+	}
+}
+
+func replayCheckHistory() []*historypb.History {
+	taskQueue := &taskqueuepb.TaskQueue{Name: "test-task-queue"}
+	event := func(eventID int64, eventType enumspb.EventType) *historypb.HistoryEvent {
+		return &historypb.HistoryEvent{
+			EventId:   eventID,
+			EventTime: timestamppb.New(replayCheckStartTime),
+			EventType: eventType,
+			Version:   common.EmptyVersion,
+		}
+	}
+
+	started := event(1, enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED)
+	started.Attributes = &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+		WorkflowExecutionStartedEventAttributes: &historypb.WorkflowExecutionStartedEventAttributes{
+			WorkflowType:             &commonpb.WorkflowType{Name: "test-workflow"},
+			TaskQueue:                taskQueue,
+			WorkflowRunTimeout:       durationpb.New(time.Hour),
+			WorkflowExecutionTimeout: durationpb.New(time.Hour),
+			WorkflowTaskTimeout:      durationpb.New(10 * time.Second),
+			OriginalExecutionRunId:   "wf-1-run",
+			FirstExecutionRunId:      "wf-1-run",
+			Attempt:                  1,
+		},
+	}
+	wtScheduled := event(2, enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED)
+	wtScheduled.Attributes = &historypb.HistoryEvent_WorkflowTaskScheduledEventAttributes{
+		WorkflowTaskScheduledEventAttributes: &historypb.WorkflowTaskScheduledEventAttributes{
+			TaskQueue:           taskQueue,
+			StartToCloseTimeout: durationpb.New(10 * time.Second),
+			Attempt:             1,
+		},
+	}
+	wtStarted := event(3, enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED)
+	wtStarted.Attributes = &historypb.HistoryEvent_WorkflowTaskStartedEventAttributes{
+		WorkflowTaskStartedEventAttributes: &historypb.WorkflowTaskStartedEventAttributes{
+			ScheduledEventId: 2,
+			RequestId:        "request-id",
+		},
+	}
+	wtCompleted := event(4, enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED)
+	wtCompleted.Attributes = &historypb.HistoryEvent_WorkflowTaskCompletedEventAttributes{
+		WorkflowTaskCompletedEventAttributes: &historypb.WorkflowTaskCompletedEventAttributes{
+			ScheduledEventId: 2,
+			StartedEventId:   3,
+		},
+	}
+	activityScheduled := event(5, enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED)
+	activityScheduled.Attributes = &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+		ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+			ActivityId:                   "activity-1",
+			ActivityType:                 &commonpb.ActivityType{Name: "test-activity"},
+			TaskQueue:                    taskQueue,
+			ScheduleToCloseTimeout:       durationpb.New(time.Minute),
+			ScheduleToStartTimeout:       durationpb.New(time.Minute),
+			StartToCloseTimeout:          durationpb.New(time.Minute),
+			WorkflowTaskCompletedEventId: 4,
+		},
+	}
+	timerStarted := event(6, enumspb.EVENT_TYPE_TIMER_STARTED)
+	timerStarted.Attributes = &historypb.HistoryEvent_TimerStartedEventAttributes{
+		TimerStartedEventAttributes: &historypb.TimerStartedEventAttributes{
+			TimerId:                      "timer-1",
+			StartToFireTimeout:           durationpb.New(time.Minute),
+			WorkflowTaskCompletedEventId: 4,
+		},
+	}
+
+	return []*historypb.History{
+		{Events: []*historypb.HistoryEvent{started, wtScheduled}},
+		{Events: []*historypb.HistoryEvent{wtStarted}},
+		{Events: []*historypb.HistoryEvent{wtCompleted, activityScheduled, timerStarted}},
+	}
+}
+
+func (c *replayCheckTestClient) AdminClient(*cli.Context) adminservice.AdminServiceClient {
+	return c
+}
+
+func (c *replayCheckTestClient) WorkflowClient(*cli.Context) workflowservice.WorkflowServiceClient {
+	return nil
+}
+
+func (c *replayCheckTestClient) GetNamespace(
+	_ context.Context,
+	_ *adminservice.GetNamespaceRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.GetNamespaceResponse, error) {
+	return &adminservice.GetNamespaceResponse{
+		Info: &namespacepb.NamespaceInfo{
+			Id:    "test-namespace-id",
+			Name:  "test-namespace",
+			State: enumspb.NAMESPACE_STATE_REGISTERED,
+		},
+		Config: &namespacepb.NamespaceConfig{
+			WorkflowExecutionRetentionTtl: durationpb.New(24 * time.Hour),
+		},
+		ReplicationConfig: &replicationpb.NamespaceReplicationConfig{
+			ActiveClusterName: "active",
+			Clusters:          []*replicationpb.ClusterReplicationConfig{{ClusterName: "active"}},
+		},
+	}, nil
+}
+
+func (c *replayCheckTestClient) DescribeCluster(
+	_ context.Context,
+	_ *adminservice.DescribeClusterRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.DescribeClusterResponse, error) {
+	return &adminservice.DescribeClusterResponse{
+		ClusterName:              "active",
+		FailoverVersionIncrement: 10,
+		InitialFailoverVersion:   1,
+	}, nil
+}
+
+func (c *replayCheckTestClient) ListClusters(
+	_ context.Context,
+	_ *adminservice.ListClustersRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.ListClustersResponse, error) {
+	return &adminservice.ListClustersResponse{
+		Clusters: []*persistencespb.ClusterMetadata{{ClusterName: "active", ClusterAddress: "127.0.0.1:7233", InitialFailoverVersion: 1}},
+	}, nil
+}
+
+func (c *replayCheckTestClient) DescribeMutableState(
+	_ context.Context,
+	_ *adminservice.DescribeMutableStateRequest,
+	_ ...grpc.CallOption,
+) (*adminservice.DescribeMutableStateResponse, error) {
+	return &adminservice.DescribeMutableStateResponse{
+		DatabaseMutableState: c.persisted,
+	}, nil
+}
+
+func (c *replayCheckTestClient) GetWorkflowExecutionRawHistoryV2(
+	_ context.Context,
+	_ *adminservice.GetWorkflowExecutionRawHistoryV2Request,
+	_ ...grpc.CallOption,
+) (*adminservice.GetWorkflowExecutionRawHistoryV2Response, error) {
+	serializer := serialization.NewSerializer()
+	var blobs []*commonpb.DataBlob
+	for _, batch := range c.history {
+		blob, err := serializer.SerializeEvents(batch.Events, enumspb.ENCODING_TYPE_PROTO3)
+		if err != nil {
+			return nil, err
+		}
+		blobs = append(blobs, blob)
+	}
+	return &adminservice.GetWorkflowExecutionRawHistoryV2Response{
+		HistoryBatches: blobs,
+	}, nil
+}